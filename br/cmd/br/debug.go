@@ -76,6 +76,11 @@ func newCheckSumCommand() *cobra.Command {
 			if err != nil {
 				return errors.Trace(err)
 			}
+			// the backup data files aren't encrypted by the master key.
+			_, dataStorage, err := task.GetStorage(ctx, &cfg)
+			if err != nil {
+				return errors.Trace(err)
+			}
 
 			reader := metautil.NewMetaReader(backupMeta, s, &cfg.CipherInfo)
 			dbs, err := utils.LoadBackupTables(ctx, reader)
@@ -115,7 +120,7 @@ func newCheckSumCommand() *cobra.Command {
 					)
 
 					var data []byte
-					data, err = s.ReadFile(ctx, file.Name)
+					data, err = dataStorage.ReadFile(ctx, file.Name)
 					if err != nil {
 						return errors.Trace(err)
 					}
@@ -326,6 +331,9 @@ func encodeBackupMetaCommand() *cobra.Command {
 			if err != nil {
 				return errors.Trace(err)
 			}
+			if s, err = task.GetMetaStorage(ctx, s, &cfg); err != nil {
+				return errors.Trace(err)
+			}
 
 			metaData, err := s.ReadFile(ctx, metautil.MetaJSONFile)
 			if err != nil {
@@ -457,6 +457,54 @@ func WriteBackupDDLJobs(metaWriter *metautil.MetaWriter, store kv.Storage, lastB
 	return nil
 }
 
+// WriteBackupRoutines sends the stored routines of the databases matched by
+// tableFilter to metaWriter. They are sent as create routine ddl jobs, which are
+// executed by restore after the databases are created.
+func WriteBackupRoutines(metaWriter *metautil.MetaWriter, store kv.Storage, tableFilter filter.Filter, backupTS uint64) error {
+	snapshot := store.GetSnapshot(kv.NewVersion(backupTS))
+	m := meta.NewSnapshotMeta(snapshot)
+	schemaVersion, err := m.GetSchemaVersion()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	dbs, err := m.ListDatabases()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	count := 0
+	for _, dbInfo := range dbs {
+		if !tableFilter.MatchSchema(dbInfo.Name.O) || util.IsMemDB(dbInfo.Name.L) || utils.IsSysDB(dbInfo.Name.L) {
+			continue
+		}
+		routines, err := m.ListRoutines(dbInfo.ID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, routine := range routines {
+			job := &model.Job{
+				SchemaID:   dbInfo.ID,
+				SchemaName: dbInfo.Name.O,
+				Type:       model.ActionCreateRoutine,
+				State:      model.JobStateSynced,
+				BinlogInfo: &model.HistoryInfo{SchemaVersion: schemaVersion},
+				Args:       []interface{}{routine},
+			}
+			jobBytes, err := job.Encode(true)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			err = metaWriter.Send(jobBytes, metautil.AppendDDL)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			count++
+		}
+	}
+	log.Debug("get stored routines", zap.Int("routines", count))
+	return nil
+}
+
 // BackupRanges make a backup of the given key ranges.
 func (bc *Client) BackupRanges(
 	ctx context.Context,
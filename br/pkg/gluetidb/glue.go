@@ -190,6 +190,11 @@ func (gs *tidbSession) CreateTable(ctx context.Context, dbName model.CIStr, tabl
 	return d.CreateTableWithInfo(gs.se, dbName, table, ddl.OnExistIgnore)
 }
 
+// CreateRoutine implements glue.Session.
+func (gs *tidbSession) CreateRoutine(ctx context.Context, dbName model.CIStr, routine *model.RoutineInfo) error {
+	return executor.CreateRoutineWithInfo(ctx, gs.se, dbName, routine)
+}
+
 // Close implements glue.Session.
 func (gs *tidbSession) Close() {
 	gs.se.Close()
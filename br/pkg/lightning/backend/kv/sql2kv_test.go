@@ -22,6 +22,7 @@ import (
 
 	"github.com/pingcap/tidb/br/pkg/lightning/common"
 	"github.com/pingcap/tidb/br/pkg/lightning/log"
+	"github.com/pingcap/tidb/br/pkg/lightning/mydump"
 	"github.com/pingcap/tidb/br/pkg/lightning/verification"
 	"github.com/pingcap/tidb/ddl"
 	"github.com/pingcap/tidb/kv"
@@ -308,6 +309,38 @@ func TestEncodeTimestamp(t *testing.T) {
 	}})
 }
 
+func TestEncodeMissingColumn(t *testing.T) {
+	c1 := &model.ColumnInfo{
+		ID:                 1,
+		Name:               model.NewCIStr("c1"),
+		State:              model.StatePublic,
+		Offset:             0,
+		FieldType:          *types.NewFieldType(mysql.TypeLong),
+		DefaultValue:       "42",
+		OriginDefaultValue: "42",
+	}
+	cols := []*model.ColumnInfo{c1}
+	tblInfo := &model.TableInfo{ID: 1, Columns: cols, PKIsHandle: false, State: model.StatePublic}
+	tbl, err := tables.TableFromMeta(NewPanickingAllocators(0), tblInfo)
+	require.NoError(t, err)
+
+	logger := log.Logger{Logger: zap.NewNop()}
+	encoder, err := NewTableKVEncoder(tbl, &SessionOptions{
+		SQLMode:   mysql.ModeStrictAllTables,
+		Timestamp: 1234567893,
+	})
+	require.NoError(t, err)
+
+	// the column which is missing in the row is filled with its default value.
+	var missing types.Datum
+	mydump.SetMissingDatum(&missing)
+	pairs, err := encoder.Encode(logger, []types.Datum{missing}, 1, []int{0, -1}, "1.jsonl", 1234)
+	require.NoError(t, err)
+	expected, err := encoder.Encode(logger, []types.Datum{types.NewIntDatum(42)}, 1, []int{0, -1}, "1.jsonl", 1234)
+	require.NoError(t, err)
+	require.Equal(t, expected, pairs)
+}
+
 func TestEncodeDoubleAutoIncrement(t *testing.T) {
 	tblInfo := mockTableInfo(t, "create table t (id double not null auto_increment, unique key `u_id` (`id`));")
 	tbl, err := tables.TableFromMeta(NewPanickingAllocators(0), tblInfo)
@@ -28,6 +28,7 @@ import (
 	"github.com/pingcap/tidb/br/pkg/lightning/config"
 	"github.com/pingcap/tidb/br/pkg/lightning/errormanager"
 	"github.com/pingcap/tidb/br/pkg/lightning/log"
+	"github.com/pingcap/tidb/br/pkg/lightning/mydump"
 	"github.com/pingcap/tidb/br/pkg/lightning/verification"
 	"github.com/pingcap/tidb/br/pkg/utils"
 	"github.com/pingcap/tidb/parser/charset"
@@ -608,6 +609,16 @@ func TestEncodeRowForRecord(t *testing.T) {
 	}, []int{0, -1, -1, -1, -1, -1, -1, -1, 1, 2, -1, -1, -1, -1})
 	require.Equal(t, row, "(5,'test test',x'000000abcdef')")
 
+	// the column which is missing in the row is filled with its default value.
+	var missing types.Datum
+	mydump.SetMissingDatum(&missing)
+	row = tidb.EncodeRowForRecord(s.tbl, mysql.ModeStrictTransTables, []types.Datum{
+		types.NewIntDatum(5),
+		missing,
+		types.NewBinaryLiteralDatum(types.NewBinaryLiteralFromUint(0xabcdef, 6)),
+	}, []int{0, -1, -1, -1, -1, -1, -1, -1, 1, 2, -1, -1, -1, -1})
+	require.Equal(t, row, "(5,DEFAULT,x'000000abcdef')")
+
 	// the following row will result in column count mismatch error, there for encode
 	// result will fallback to a "," separated string list.
 	row = tidb.EncodeRowForRecord(s.tbl, mysql.ModeStrictTransTables, []types.Datum{
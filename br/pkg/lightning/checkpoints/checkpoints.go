@@ -248,6 +248,15 @@ type ChunkCheckpoint struct {
 	Timestamp         int64
 }
 
+// TotalSize returns the size of the source data of the chunk. The end offset of
+// the chunk of a compressed file is in the decompressed data, so the file size is used instead.
+func (ccp *ChunkCheckpoint) TotalSize() int64 {
+	if ccp.FileMeta.Compression != mydump.CompressionNone {
+		return ccp.FileMeta.FileSize
+	}
+	return ccp.Chunk.EndOffset - ccp.Key.Offset
+}
+
 func (ccp *ChunkCheckpoint) DeepCopy() *ChunkCheckpoint {
 	colPerm := make([]int, 0, len(ccp.ColumnPermutation))
 	colPerm = append(colPerm, ccp.ColumnPermutation...)
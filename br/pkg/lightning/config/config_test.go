@@ -591,6 +591,7 @@ func TestLoadConfig(t *testing.T) {
 		"-tidb-password", "12345",
 		"-pd-urls", "172.16.30.11:2379,172.16.30.12:2379",
 		"-d", path,
+		"-master-key", "/path/to/master.key",
 		"-backend", config.BackendLocal,
 		"-sorted-kv-dir", ".",
 		"-checksum=false",
@@ -604,6 +605,7 @@ func TestLoadConfig(t *testing.T) {
 	require.Equal(t, "12345", cfg.TiDB.Psw)
 	require.Equal(t, "172.16.30.11:2379,172.16.30.12:2379", cfg.TiDB.PdAddr)
 	require.Equal(t, path, cfg.Mydumper.SourceDir)
+	require.Equal(t, "/path/to/master.key", cfg.Mydumper.MasterKey)
 	require.Equal(t, config.BackendLocal, cfg.TikvImporter.Backend)
 	require.Equal(t, ".", cfg.TikvImporter.SortedKVDir)
 	require.Equal(t, config.OpLevelOff, cfg.PostRestore.Checksum)
@@ -614,6 +616,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, config.OpLevelOff, taskCfg.PostRestore.Checksum)
 	require.Equal(t, config.OpLevelOptional, taskCfg.PostRestore.Analyze)
+	require.Equal(t, "/path/to/master.key", taskCfg.Mydumper.MasterKey)
 
 	taskCfg.Checkpoint.DSN = ""
 	taskCfg.Checkpoint.Driver = config.CheckpointDriverMySQL
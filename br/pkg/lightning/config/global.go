@@ -52,6 +52,7 @@ type GlobalTiDB struct {
 
 type GlobalMydumper struct {
 	SourceDir string `toml:"data-source-dir" json:"data-source-dir"`
+	MasterKey string `toml:"master-key" json:"master-key"`
 	// Deprecated
 	NoSchema      bool             `toml:"no-schema" json:"no-schema"`
 	Filter        []string         `toml:"filter" json:"filter"`
@@ -153,6 +154,7 @@ func LoadGlobalConfig(args []string, extraFlags func(*flag.FlagSet)) (*GlobalCon
 	tidbStatusPort := fs.Int("tidb-status", 0, "TiDB server status port (default 10080)")
 	pdAddr := fs.String("pd-urls", "", "PD endpoint address")
 	dataSrcPath := fs.String("d", "", "Directory of the dump to import")
+	masterKey := fs.String("master-key", "", "URI of the master key to decrypt the encrypted dump")
 	importerAddr := fs.String("importer", "", "address (host:port) to connect to tikv-importer")
 	backend := flagext.ChoiceVar(fs, "backend", "", `delivery backend: local, importer, tidb`, "", "local", "importer", "tidb")
 	sortedKVDir := fs.String("sorted-kv-dir", "", "path for KV pairs when local backend enabled")
@@ -225,6 +227,9 @@ func LoadGlobalConfig(args []string, extraFlags func(*flag.FlagSet)) (*GlobalCon
 	if *dataSrcPath != "" {
 		cfg.Mydumper.SourceDir = *dataSrcPath
 	}
+	if *masterKey != "" {
+		cfg.Mydumper.MasterKey = *masterKey
+	}
 	if *importerAddr != "" {
 		cfg.TikvImporter.Addr = *importerAddr
 	}
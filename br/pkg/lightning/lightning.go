@@ -295,6 +295,10 @@ func (l *Lightning) run(taskCtx context.Context, taskCfg *config.Config, g glue.
 	if err != nil {
 		return common.NormalizeError(err)
 	}
+	s, err = mydump.WithSourceEncryption(ctx, taskCfg, s)
+	if err != nil {
+		return errors.Trace(err)
+	}
 
 	// return expectedErr means at least meet one file
 	expectedErr := errors.New("Stop Iter")
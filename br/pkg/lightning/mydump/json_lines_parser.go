@@ -0,0 +1,339 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/lightning/worker"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	tjson "github.com/pingcap/tidb/types/json"
+)
+
+// JSONLinesParser is a parser of the newline-delimited JSON files, in which
+// each line is a JSON object representing a row.
+//
+// The values are mapped to the columns by the keys of the objects, the keys
+// are case-insensitive. If the columns are not set, the keys of the first row
+// are used as the columns. The keys which don't match any column are ignored,
+// and the columns which are missing in a row are filled with their default
+// values, see SetMissingDatum.
+//
+// The strings and the numbers are converted to the columns like the fields
+// of a CSV file, the booleans are converted to 1 and 0, and the nested
+// objects and arrays are converted to JSON values. The values of the binary
+// columns are base64 encoded strings, like the ones dumped by dumpling.
+type JSONLinesParser struct {
+	blockParser
+
+	// columnIndexes maps the lower-case column names to their indexes.
+	columnIndexes map[string]int
+	// binaryColumns marks the columns whose values are base64 encoded.
+	binaryColumns []bool
+}
+
+// NewJSONLinesParser creates a new parser which reads rows from a
+// newline-delimited JSON file.
+func NewJSONLinesParser(
+	reader ReadSeekCloser,
+	blockBufSize int64,
+	ioWorkers *worker.Pool,
+) *JSONLinesParser {
+	return &JSONLinesParser{
+		blockParser: makeBlockParser(reader, blockBufSize, ioWorkers),
+	}
+}
+
+// SetColumns sets the columns that the values are mapped to.
+func (parser *JSONLinesParser) SetColumns(columns []string) {
+	parser.columns = columns
+	parser.columnIndexes = make(map[string]int, len(columns))
+	for i, col := range columns {
+		parser.columnIndexes[strings.ToLower(col)] = i
+	}
+	parser.binaryColumns = nil
+}
+
+// SetTableInfo sets the columns to the columns of the table whose values can
+// be read from the file, and decodes the values of the binary columns.
+func (parser *JSONLinesParser) SetTableInfo(tableInfo *model.TableInfo) {
+	columns := make([]string, 0, len(tableInfo.Columns))
+	binaryColumns := make([]bool, 0, len(tableInfo.Columns))
+	for _, col := range tableInfo.Columns {
+		if col.IsGenerated() {
+			continue
+		}
+		columns = append(columns, col.Name.L)
+		binaryColumns = append(binaryColumns, types.IsBinaryStr(&col.FieldType) || col.Tp == mysql.TypeBit)
+	}
+	parser.SetColumns(columns)
+	parser.binaryColumns = binaryColumns
+}
+
+// readUntilNewLine reads the buffer until a new line is found. The returned
+// line doesn't contain the new line, and it is only valid until the next read.
+// io.EOF is returned if there is no new line until the end of the file, along
+// with the rest of the file.
+func (parser *JSONLinesParser) readUntilNewLine() ([]byte, error) {
+	if i := bytes.IndexByte(parser.buf, '\n'); i >= 0 {
+		line := parser.buf[:i]
+		parser.buf = parser.buf[i+1:]
+		parser.pos += int64(i + 1)
+		return line, nil
+	}
+
+	// not found in parser.buf, need allocate and loop.
+	var line []byte
+	for {
+		line = append(line, parser.buf...)
+		parser.pos += int64(len(parser.buf))
+		parser.buf = nil
+		if err := parser.readBlock(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(parser.buf) == 0 {
+			return line, io.EOF
+		}
+		if i := bytes.IndexByte(parser.buf, '\n'); i >= 0 {
+			line = append(line, parser.buf[:i]...)
+			parser.buf = parser.buf[i+1:]
+			parser.pos += int64(i + 1)
+			return line, nil
+		}
+	}
+}
+
+// ReadRow reads a row from the datafile.
+func (parser *JSONLinesParser) ReadRow() error {
+	for {
+		offset := parser.pos
+		line, err := parser.readUntilNewLine()
+		if err != nil && (errors.Cause(err) != io.EOF || len(bytes.TrimSpace(line)) == 0) {
+			return err
+		}
+		line = bytes.TrimSpace(line)
+		// skip the empty lines
+		if len(line) == 0 {
+			continue
+		}
+
+		row := &parser.lastRow
+		row.RowID++
+		row.Length = len(line)
+		row.Row = parser.acquireDatumSlice()
+		if err = parser.parseObject(line, row); err != nil {
+			return errors.Annotatef(err, "syntax error at offset %d", offset)
+		}
+		return nil
+	}
+}
+
+type jsonLinesField struct {
+	key   string
+	value json.RawMessage
+}
+
+func (parser *JSONLinesParser) parseObject(line []byte, row *Row) error {
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.UseNumber()
+	tok, err := decoder.Token()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return errors.Errorf("expecting a JSON object, but got %v", tok)
+	}
+
+	var fields []jsonLinesField
+	for decoder.More() {
+		tok, err = decoder.Token()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		var field jsonLinesField
+		field.key = strings.ToLower(tok.(string))
+		if err = decoder.Decode(&field.value); err != nil {
+			return errors.Trace(err)
+		}
+		fields = append(fields, field)
+	}
+	if _, err = decoder.Token(); err != nil {
+		return errors.Trace(err)
+	}
+	if decoder.More() {
+		return errors.New("unexpected content after the JSON object")
+	}
+
+	// use the keys of the first row as the columns if they are not set.
+	if parser.columnIndexes == nil {
+		columns := make([]string, 0, len(fields))
+		seen := make(map[string]struct{}, len(fields))
+		for _, field := range fields {
+			if _, ok := seen[field.key]; !ok {
+				seen[field.key] = struct{}{}
+				columns = append(columns, field.key)
+			}
+		}
+		parser.SetColumns(columns)
+	}
+
+	if cap(row.Row) >= len(parser.columns) {
+		row.Row = row.Row[:len(parser.columns)]
+	} else {
+		row.Row = make([]types.Datum, len(parser.columns))
+	}
+	for i := range row.Row {
+		SetMissingDatum(&row.Row[i])
+	}
+	for _, field := range fields {
+		i, ok := parser.columnIndexes[field.key]
+		if !ok {
+			continue
+		}
+		isBinary := i < len(parser.binaryColumns) && parser.binaryColumns[i]
+		if err = setJSONLinesDatum(&row.Row[i], field.value, isBinary); err != nil {
+			return errors.Annotatef(err, "invalid value of key '%s'", field.key)
+		}
+	}
+	return nil
+}
+
+func setJSONLinesDatum(d *types.Datum, value json.RawMessage, isBinary bool) error {
+	switch value[0] {
+	case 'n':
+		d.SetNull()
+	case 't':
+		d.SetInt64(1)
+	case 'f':
+		d.SetInt64(0)
+	case '"':
+		s, err := unquoteJSONString(value)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if isBinary {
+			b, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			d.SetBytes(b)
+			return nil
+		}
+		d.SetString(s, "utf8mb4_bin")
+	case '{', '[':
+		bj, err := tjson.ParseBinaryFromString(string(value))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		d.SetMysqlJSON(bj)
+	default:
+		// keep the text of the numbers to avoid losing precision.
+		d.SetString(string(value), "utf8mb4_bin")
+	}
+	return nil
+}
+
+// unquoteJSONString unquotes a JSON string. Unlike json.Unmarshal, the bytes
+// which are not valid UTF-8 are kept as they are instead of being replaced by
+// U+FFFD, so that the strings in other character sets can be restored.
+func unquoteJSONString(value []byte) (string, error) {
+	value = value[1 : len(value)-1]
+	if bytes.IndexByte(value, '\\') < 0 {
+		return string(value), nil
+	}
+
+	buf := make([]byte, 0, len(value))
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' {
+			buf = append(buf, value[i])
+			continue
+		}
+		i++
+		if i >= len(value) {
+			return "", errors.New("invalid escape sequence")
+		}
+		switch value[i] {
+		case '"', '\\', '/':
+			buf = append(buf, value[i])
+		case 'b':
+			buf = append(buf, '\b')
+		case 'f':
+			buf = append(buf, '\f')
+		case 'n':
+			buf = append(buf, '\n')
+		case 'r':
+			buf = append(buf, '\r')
+		case 't':
+			buf = append(buf, '\t')
+		case 'u':
+			r, ok := parseJSONHex4(value[i+1:])
+			if !ok {
+				return "", errors.New("invalid unicode escape sequence")
+			}
+			i += 4
+			// a surrogate pair is escaped as two sequences, like "\ud83d\ude00".
+			if utf16.IsSurrogate(r) {
+				r2 := rune(-1)
+				if i+2 < len(value) && value[i+1] == '\\' && value[i+2] == 'u' {
+					if low, ok := parseJSONHex4(value[i+3:]); ok {
+						r2 = low
+					}
+				}
+				if dec := utf16.DecodeRune(r, r2); dec != utf8.RuneError {
+					r = dec
+					i += 6
+				} else {
+					r = utf8.RuneError
+				}
+			}
+			var runeBuf [utf8.UTFMax]byte
+			buf = append(buf, runeBuf[:utf8.EncodeRune(runeBuf[:], r)]...)
+		default:
+			return "", errors.Errorf("invalid escape character '%c'", value[i])
+		}
+	}
+	return string(buf), nil
+}
+
+func parseJSONHex4(s []byte) (rune, bool) {
+	if len(s) < 4 {
+		return 0, false
+	}
+	r, err := strconv.ParseUint(string(s[:4]), 16, 16)
+	if err != nil {
+		return 0, false
+	}
+	return rune(r), true
+}
+
+// ReadUntilTerminator seeks the file until a new line is found, and returns
+// the file offset beyond the new line.
+// This function is used in dividing a JSON lines file.
+func (parser *JSONLinesParser) ReadUntilTerminator() (int64, error) {
+	if _, err := parser.readUntilNewLine(); err != nil {
+		return 0, err
+	}
+	return parser.pos, nil
+}
@@ -0,0 +1,230 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/lightning/config"
+	"github.com/pingcap/tidb/br/pkg/lightning/mydump"
+	"github.com/pingcap/tidb/br/pkg/lightning/worker"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
+	"github.com/stretchr/testify/require"
+)
+
+var missingDatum = func() types.Datum {
+	var d types.Datum
+	mydump.SetMissingDatum(&d)
+	return d
+}()
+
+func newJSONDatum(t *testing.T, s string) types.Datum {
+	bj, err := json.ParseBinaryFromString(s)
+	require.NoError(t, err)
+	return types.NewJSONDatum(bj)
+}
+
+func TestJSONLinesParser(t *testing.T) {
+	input := `{"id": 1, "Name": "alice", "tags": ["a", "b"], "extra": {"x": 1.50}, "ok": true}` + "\n" +
+		"\r\n" +
+		`{"name": "bob\n\"quoted\"", "id": 18446744073709551616, "ok": false, "unknown": "ignored"}` + "\r\n" +
+		"   \n" +
+		`{"id": null, "amount": 123.4500}`
+	for _, blockBufSize := range []int64{1, 4, int64(config.ReadBlockSize)} {
+		parser := mydump.NewJSONLinesParser(mydump.NewStringReader(input), blockBufSize, ioWorkers)
+
+		require.NoError(t, parser.ReadRow())
+		require.Equal(t, []string{"id", "name", "tags", "extra", "ok"}, parser.Columns())
+		require.Equal(t, mydump.Row{
+			RowID: 1,
+			Row: []types.Datum{
+				types.NewStringDatum("1"),
+				types.NewStringDatum("alice"),
+				newJSONDatum(t, `["a", "b"]`),
+				newJSONDatum(t, `{"x": 1.50}`),
+				types.NewIntDatum(1),
+			},
+			Length: 80,
+		}, parser.LastRow())
+		assertPosEqual(t, parser, 81, 1)
+
+		// the keys are mapped to the columns of the first row.
+		require.NoError(t, parser.ReadRow())
+		require.Equal(t, []types.Datum{
+			types.NewStringDatum("18446744073709551616"),
+			types.NewStringDatum("bob\n\"quoted\""),
+			missingDatum,
+			missingDatum,
+			types.NewIntDatum(0),
+		}, parser.LastRow().Row)
+		assertPosEqual(t, parser, 175, 2)
+
+		require.NoError(t, parser.ReadRow())
+		require.Equal(t, []types.Datum{nullDatum, missingDatum, missingDatum, missingDatum, missingDatum}, parser.LastRow().Row)
+		assertPosEqual(t, parser, int64(len(input)), 3)
+
+		require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF)
+		require.NoError(t, parser.Close())
+	}
+}
+
+func TestJSONLinesParserSetColumns(t *testing.T) {
+	input := `{"b": "1", "c": "2"}` + "\n" + `{"A": 3, "d": "4"}` + "\n"
+	parser := mydump.NewJSONLinesParser(mydump.NewStringReader(input), int64(config.ReadBlockSize), ioWorkers)
+	parser.SetColumns([]string{"a", "b", "c"})
+
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{missingDatum, types.NewStringDatum("1"), types.NewStringDatum("2")}, parser.LastRow().Row)
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{types.NewStringDatum("3"), missingDatum, missingDatum}, parser.LastRow().Row)
+	require.Equal(t, []string{"a", "b", "c"}, parser.Columns())
+
+	// starts from the second row.
+	parser = mydump.NewJSONLinesParser(mydump.NewStringReader(input), int64(config.ReadBlockSize), ioWorkers)
+	parser.SetColumns([]string{"a", "b", "c"})
+	require.NoError(t, parser.SetPos(21, 1))
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, mydump.Row{
+		RowID:  2,
+		Row:    []types.Datum{types.NewStringDatum("3"), missingDatum, missingDatum},
+		Length: 18,
+	}, parser.LastRow())
+	require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF)
+}
+
+func TestJSONLinesParserEscapedString(t *testing.T) {
+	input := `{"a": "\"\\\/\b\f\n\r\t", "b": "\u0000` + "\xff" + `\u4e2d\ud83d\ude00", "c": "\ud83d", "d": "\u00e9"}`
+	parser := mydump.NewJSONLinesParser(mydump.NewStringReader(input), int64(config.ReadBlockSize), ioWorkers)
+	parser.SetColumns([]string{"a", "b", "c"})
+
+	// the invalid UTF-8 bytes are kept as they are.
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{
+		types.NewStringDatum("\"\\/\b\f\n\r\t"),
+		types.NewStringDatum("\x00\xff中😀"),
+		types.NewStringDatum("\ufffd"),
+	}, parser.LastRow().Row)
+	require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF)
+}
+
+func TestJSONLinesParserSetTableInfo(t *testing.T) {
+	newColumn := func(name string, ft *types.FieldType) *model.ColumnInfo {
+		return &model.ColumnInfo{Name: model.NewCIStr(name), FieldType: *ft}
+	}
+	tableInfo := &model.TableInfo{Columns: []*model.ColumnInfo{
+		newColumn("a", types.NewFieldType(mysql.TypeLong)),
+		newColumn("b", types.NewFieldTypeWithCollation(mysql.TypeVarchar, charset.CollationBin, 10)),
+		newColumn("c", types.NewFieldTypeWithCollation(mysql.TypeBlob, charset.CollationBin, 65535)),
+		newColumn("d", types.NewFieldType(mysql.TypeBit)),
+		newColumn("e", types.NewFieldTypeWithCollation(mysql.TypeVarchar, charset.CollationUTF8MB4, 10)),
+		newColumn("f", types.NewFieldType(mysql.TypeLong)),
+	}}
+	tableInfo.Columns[5].GeneratedExprString = "a + 1"
+
+	input := `{"b": "AP8=", "c": "", "d": "AQI=", "e": "AP8=", "f": 2}` + "\n" + `{"b": "not base64"}`
+	parser := mydump.NewJSONLinesParser(mydump.NewStringReader(input), int64(config.ReadBlockSize), ioWorkers)
+	parser.SetTableInfo(tableInfo)
+	require.Equal(t, []string{"a", "b", "c", "d", "e"}, parser.Columns())
+
+	// the values of the binary columns are decoded from base64.
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{
+		missingDatum,
+		types.NewBytesDatum([]byte{0x00, 0xff}),
+		types.NewBytesDatum([]byte{}),
+		types.NewBytesDatum([]byte{0x01, 0x02}),
+		types.NewStringDatum("AP8="),
+	}, parser.LastRow().Row)
+
+	err := parser.ReadRow()
+	require.Error(t, err)
+	require.Regexp(t, "invalid value of key 'b'", err.Error())
+}
+
+func TestJSONLinesParserSyntaxError(t *testing.T) {
+	for _, input := range []string{
+		`[1, 2]`,
+		`"not an object"`,
+		`{"a": 1`,
+		`{"a": 1} {"b": 2}`,
+		`{"a": tru}`,
+		"{\"a\": \"new\nline\"}",
+	} {
+		parser := mydump.NewJSONLinesParser(mydump.NewStringReader(`{"a": 0}`+"\n"+input), int64(config.ReadBlockSize), ioWorkers)
+		require.NoError(t, parser.ReadRow())
+		err := parser.ReadRow()
+		require.Error(t, err, input)
+		require.Regexp(t, "syntax error at offset 9", err.Error())
+	}
+}
+
+func TestSplitLargeJSONLinesFile(t *testing.T) {
+	meta := &mydump.MDTableMeta{
+		DB:   "jsonl",
+		Name: "large_jsonl_file",
+	}
+	cfg := &config.Config{
+		Mydumper: config.MydumperRuntime{
+			ReadBlockSize: config.ReadBlockSize,
+			MaxRegionSize: 1,
+			Filter:        []string{"*.*"},
+		},
+		App: config.Lightning{
+			RegionConcurrency: 1,
+			TableConcurrency:  1,
+		},
+	}
+
+	dir := t.TempDir()
+	fileName := "test.jsonl"
+	content := []byte(`{"a": "1\n"}` + "\n" + `{"a": 2}` + "\r\n" + `{"a": 3}`)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, fileName), content, 0o644))
+	meta.DataFiles = []mydump.FileInfo{{
+		FileMeta: mydump.SourceFileMeta{Path: fileName, Type: mydump.SourceTypeJSONLines, FileSize: int64(len(content))},
+	}}
+	ioWorker := worker.NewPool(context.Background(), 4, "io")
+	store, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+
+	regions, err := mydump.MakeTableRegions(context.Background(), meta, 1, cfg, ioWorker, store)
+	require.NoError(t, err)
+	offsets := [][]int64{{0, 13}, {13, 23}, {23, 31}}
+	require.Len(t, regions, len(offsets))
+	for i := range offsets {
+		require.Equal(t, offsets[i][0], regions[i].Chunk.Offset)
+		require.Equal(t, offsets[i][1], regions[i].Chunk.EndOffset)
+		require.Nil(t, regions[i].Chunk.Columns)
+
+		// each region contains exactly one row.
+		r, err := store.Open(context.Background(), fileName)
+		require.NoError(t, err)
+		parser := mydump.NewJSONLinesParser(r, int64(config.ReadBlockSize), ioWorker)
+		require.NoError(t, parser.SetPos(offsets[i][0], 0))
+		require.NoError(t, parser.ReadRow())
+		pos, _ := parser.Pos()
+		require.Equal(t, offsets[i][1], pos)
+		require.NoError(t, parser.Close())
+	}
+}
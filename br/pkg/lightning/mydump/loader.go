@@ -119,10 +119,27 @@ func NewMyDumpLoader(ctx context.Context, cfg *config.Config) (*MDLoader, error)
 	if err != nil {
 		return nil, common.NormalizeError(err)
 	}
+	s, err = WithSourceEncryption(ctx, cfg, s)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	return NewMyDumpLoaderWithStore(ctx, cfg, s)
 }
 
+// WithSourceEncryption wraps the storage to decrypt the source files by the
+// `mydumper.master-key`. The compressed files are decompressed after being decrypted.
+func WithSourceEncryption(ctx context.Context, cfg *config.Config, store storage.ExternalStorage) (storage.ExternalStorage, error) {
+	if cfg.Mydumper.MasterKey == "" {
+		return store, nil
+	}
+	masterKey, err := storage.NewMasterKey(ctx, cfg.Mydumper.MasterKey)
+	if err != nil {
+		return nil, common.ErrInvalidConfig.Wrap(err).GenWithStack("invalid `mydumper.master-key`")
+	}
+	return storage.WithEncryption(store, masterKey), nil
+}
+
 func NewMyDumpLoaderWithStore(ctx context.Context, cfg *config.Config, store storage.ExternalStorage) (*MDLoader, error) {
 	var r *regexprrouter.RouteTable
 	var err error
@@ -329,7 +346,7 @@ func (s *mdLoaderSetup) listFiles(ctx context.Context, store storage.ExternalSto
 			s.tableSchemas = append(s.tableSchemas, info)
 		case SourceTypeViewSchema:
 			s.viewSchemas = append(s.viewSchemas, info)
-		case SourceTypeSQL, SourceTypeCSV, SourceTypeParquet:
+		case SourceTypeSQL, SourceTypeCSV, SourceTypeParquet, SourceTypeJSONLines:
 			s.tableDatas = append(s.tableDatas, info)
 		}
 
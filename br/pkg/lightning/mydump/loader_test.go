@@ -16,6 +16,8 @@ package mydump_test
 
 import (
 	"context"
+	"encoding/hex"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
@@ -314,6 +316,7 @@ func TestTablesWithDots(t *testing.T) {
 
 	// insert some tables with file name structures which we're going to ignore.
 	s.touch(t, "db.v-schema-trigger.sql")
+	s.touch(t, "db.v-schema-triggers.sql")
 	s.touch(t, "db.v-schema-post.sql")
 	s.touch(t, "db.sql")
 	s.touch(t, "db-schema.sql")
@@ -810,3 +813,140 @@ func TestInputWithSpecialChars(t *testing.T) {
 		},
 	}, mdl.GetDatabases())
 }
+
+func TestCompressedFiles(t *testing.T) {
+	s := newTestMydumpLoaderSuite(t)
+	ctx := context.Background()
+	store, err := storage.NewLocalStorage(s.sourceDir)
+	require.NoError(t, err)
+
+	err = storage.WithCompression(store, storage.Gzip).WriteFile(ctx, "db-schema-create.sql.gz", []byte("CREATE DATABASE db;\n"))
+	require.NoError(t, err)
+	err = storage.WithCompression(store, storage.Zstd).WriteFile(ctx, "db.tbl-schema.sql.zst", []byte("CREATE TABLE tbl (a INT);\n"))
+	require.NoError(t, err)
+	s.touch(t, "db.tbl.000000000.sql.gz")
+	s.touch(t, "db.tbl.000000001.sql.snappy")
+	s.touch(t, "db.tbl.000000002.csv.lz4")
+	s.touch(t, "db.tbl.000000003.sql.zst")
+	s.touch(t, "db.tbl-schema-triggers.sql.gz")
+	s.touch(t, "db.tbl.000000004.sql.bak")
+
+	mdl, err := md.NewMyDumpLoader(ctx, s.cfg)
+	require.NoError(t, err)
+	dbMetas := mdl.GetDatabases()
+	require.Len(t, dbMetas, 1)
+	require.Equal(t, md.CompressionGZ, dbMetas[0].SchemaFile.FileMeta.Compression)
+	require.Len(t, dbMetas[0].Tables, 1)
+	tblMeta := dbMetas[0].Tables[0]
+	require.Equal(t, md.CompressionZStd, tblMeta.SchemaFile.FileMeta.Compression)
+	expected := []struct {
+		path        string
+		tp          md.SourceType
+		compression md.Compression
+	}{
+		{"db.tbl.000000000.sql.gz", md.SourceTypeSQL, md.CompressionGZ},
+		{"db.tbl.000000001.sql.snappy", md.SourceTypeSQL, md.CompressionSnappy},
+		{"db.tbl.000000002.csv.lz4", md.SourceTypeCSV, md.CompressionLZ4},
+		{"db.tbl.000000003.sql.zst", md.SourceTypeSQL, md.CompressionZStd},
+	}
+	require.Len(t, tblMeta.DataFiles, len(expected))
+	for i, e := range expected {
+		require.Equal(t, e.path, tblMeta.DataFiles[i].FileMeta.Path)
+		require.Equal(t, e.tp, tblMeta.DataFiles[i].FileMeta.Type)
+		require.Equal(t, e.compression, tblMeta.DataFiles[i].FileMeta.Compression)
+	}
+
+	// the statements in the compressed schema files are decompressed.
+	data, err := md.ExportStatement(ctx, mdl.GetStore(), dbMetas[0].SchemaFile, "auto")
+	require.NoError(t, err)
+	require.Equal(t, "CREATE DATABASE db;", string(data))
+	data, err = md.ExportStatement(ctx, mdl.GetStore(), tblMeta.SchemaFile, "auto")
+	require.NoError(t, err)
+	require.Equal(t, "CREATE TABLE tbl (a INT);", string(data))
+
+	// compressed parquet files are rejected.
+	s.touch(t, "db.tbl.000000005.parquet.gz")
+	_, err = md.NewMyDumpLoader(ctx, s.cfg)
+	require.Regexp(t, "compressed parquet files are not supported", err.Error())
+}
+
+func TestJSONLinesFiles(t *testing.T) {
+	s := newTestMydumpLoaderSuite(t)
+
+	s.touch(t, "db-schema-create.sql")
+	s.touch(t, "db.tbl-schema.sql")
+	s.touch(t, "db.tbl.000000000.jsonl")
+	s.touch(t, "db.tbl.000000001.NDJSON")
+	s.touch(t, "db.tbl.000000002.jsonl.gz")
+
+	mdl, err := md.NewMyDumpLoader(context.Background(), s.cfg)
+	require.NoError(t, err)
+	dbMetas := mdl.GetDatabases()
+	require.Len(t, dbMetas, 1)
+	require.Len(t, dbMetas[0].Tables, 1)
+	dataFiles := dbMetas[0].Tables[0].DataFiles
+	require.Len(t, dataFiles, 3)
+	for i, compression := range []md.Compression{md.CompressionNone, md.CompressionNone, md.CompressionGZ} {
+		require.Equal(t, md.SourceTypeJSONLines, dataFiles[i].FileMeta.Type)
+		require.Equal(t, compression, dataFiles[i].FileMeta.Compression)
+	}
+	require.Equal(t, "jsonl", dataFiles[0].FileMeta.Type.String())
+}
+
+func TestEncryptedFiles(t *testing.T) {
+	s := newTestMydumpLoaderSuite(t)
+	ctx := context.Background()
+	keyFile := filepath.Join(t.TempDir(), "master.key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(hex.EncodeToString(make([]byte, 32))), 0o600))
+	masterKey, err := storage.NewMasterKey(ctx, keyFile)
+	require.NoError(t, err)
+	store, err := storage.NewLocalStorage(s.sourceDir)
+	require.NoError(t, err)
+	encryptedStore := storage.WithEncryption(store, masterKey)
+
+	// the files are written like the output of dumpling with `--master-key`.
+	err = encryptedStore.WriteFile(ctx, "db-schema-create.sql", []byte("CREATE DATABASE db;\n"))
+	require.NoError(t, err)
+	err = encryptedStore.WriteFile(ctx, "db.tbl-schema.sql", []byte("CREATE TABLE tbl (a INT);\n"))
+	require.NoError(t, err)
+	content := []byte("INSERT INTO tbl VALUES (1),(2),(3);\n")
+	err = storage.WithCompression(encryptedStore, storage.Gzip).WriteFile(ctx, "db.tbl.000000000.sql.gz", content)
+	require.NoError(t, err)
+
+	// the source files can't be read without the master key.
+	mdl, err := md.NewMyDumpLoader(ctx, s.cfg)
+	require.NoError(t, err)
+	data, err := md.ExportStatement(ctx, mdl.GetStore(), mdl.GetDatabases()[0].SchemaFile, "binary")
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "CREATE DATABASE")
+
+	s.cfg.Mydumper.MasterKey = keyFile
+	mdl, err = md.NewMyDumpLoader(ctx, s.cfg)
+	require.NoError(t, err)
+	dbMetas := mdl.GetDatabases()
+	require.Len(t, dbMetas, 1)
+	data, err = md.ExportStatement(ctx, mdl.GetStore(), dbMetas[0].SchemaFile, "auto")
+	require.NoError(t, err)
+	require.Equal(t, "CREATE DATABASE db;", string(data))
+	require.Len(t, dbMetas[0].Tables, 1)
+	tblMeta := dbMetas[0].Tables[0]
+	data, err = md.ExportStatement(ctx, mdl.GetStore(), tblMeta.SchemaFile, "auto")
+	require.NoError(t, err)
+	require.Equal(t, "CREATE TABLE tbl (a INT);", string(data))
+
+	require.Len(t, tblMeta.DataFiles, 1)
+	regions, err := md.MakeTableRegions(ctx, tblMeta, 1, config.NewConfig(), nil, mdl.GetStore())
+	require.NoError(t, err)
+	require.Len(t, regions, 1)
+	require.Equal(t, int64(len(content)), regions[0].Chunk.EndOffset)
+	reader, err := md.OpenReader(ctx, regions[0].FileMeta, mdl.GetStore())
+	require.NoError(t, err)
+	data, err = io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, content, data)
+	require.NoError(t, reader.Close())
+
+	s.cfg.Mydumper.MasterKey = filepath.Join(s.sourceDir, "not-exists.key")
+	_, err = md.NewMyDumpLoader(ctx, s.cfg)
+	require.Regexp(t, "invalid `mydumper.master-key`", err.Error())
+}
@@ -97,6 +97,18 @@ type Row struct {
 	Length int
 }
 
+// SetMissingDatum marks the datum as the value of a column which is missing in
+// the row, the encoders fill such a column with its default value.
+func SetMissingDatum(d *types.Datum) {
+	d.SetMinNotNull()
+}
+
+// IsMissingDatum returns whether the datum is the value of a column which is
+// missing in the row, see SetMissingDatum.
+func IsMissingDatum(d *types.Datum) bool {
+	return d.Kind() == types.KindMinNotNull
+}
+
 // MarshalLogArray implements the zapcore.ArrayMarshaler interface
 func (row Row) MarshalLogArray(encoder zapcore.ArrayEncoder) error {
 	for _, r := range row.Row {
@@ -67,8 +67,25 @@ func decodeCharacterSet(data []byte, characterSet string) ([]byte, error) {
 	return data, nil
 }
 
+// OpenReader opens a reader of the source file, the content of the compressed
+// files is decompressed by the reader.
+func OpenReader(ctx context.Context, fileMeta SourceFileMeta, store storage.ExternalStorage) (storage.ReadSeekCloser, error) {
+	switch {
+	case fileMeta.Type == SourceTypeParquet:
+		return OpenParquetReader(ctx, store, fileMeta.Path, fileMeta.FileSize)
+	case fileMeta.Compression != CompressionNone:
+		compressType, err := ToStorageCompressType(fileMeta.Compression)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return storage.WithCompression(store, compressType).Open(ctx, fileMeta.Path)
+	default:
+		return store.Open(ctx, fileMeta.Path)
+	}
+}
+
 func ExportStatement(ctx context.Context, store storage.ExternalStorage, sqlFile FileInfo, characterSet string) ([]byte, error) {
-	fd, err := store.Open(ctx, sqlFile.FileMeta.Path)
+	fd, err := OpenReader(ctx, sqlFile.FileMeta, store)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
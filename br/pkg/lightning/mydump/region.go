@@ -15,6 +15,7 @@
 package mydump
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"math"
@@ -34,6 +35,18 @@ const (
 	tableRegionSizeWarningThreshold int64 = 1024 * 1024 * 1024
 	// the increment ratio of large CSV file size threshold by `region-split-size`
 	largeCSVLowerThresholdRation = 10
+	// the decompressed size of a large compressed file is estimated, so its chunk
+	// ends at this offset and is read until EOF.
+	compressedFileEndOffset = 1024 * 1024 * tableRegionSizeWarningThreshold
+	// the compressed files not larger than this size are read through to get their
+	// decompressed sizes, while the prefixes of this size of the larger ones are
+	// sampled to estimate their compression ratios.
+	compressedFileSampleSize = 4 * 1024 * 1024
+	// the estimated ratio of the decompressed size to the compressed size of a file,
+	// which is used if no data can be decompressed from the sampled prefix.
+	compressedFileSizeRatio = 10
+	// the size of the shortest row `{}\n` in a JSON lines file.
+	jsonLinesMinRowSize = 3
 )
 
 type TableRegion struct {
@@ -247,6 +260,59 @@ func MakeTableRegions(
 	return filesRegions, nil
 }
 
+// getDecompressedFileSize returns the decompressed size of the compressed file, and whether the size is exact.
+// Reading through all the large files costs too much, so only the small file is read through, and the size of
+// the large one is estimated by the compression ratio of its prefix.
+func getDecompressedFileSize(ctx context.Context, store storage.ExternalStorage, fileMeta SourceFileMeta) (int64, bool, error) {
+	if fileMeta.FileSize <= compressedFileSampleSize {
+		reader, err := OpenReader(ctx, fileMeta, store)
+		if err != nil {
+			return 0, false, errors.Trace(err)
+		}
+		defer reader.Close()
+		size, err := io.Copy(io.Discard, reader)
+		if err != nil {
+			return 0, false, errors.Annotatef(err, "failed to decompress file '%s'", fileMeta.Path)
+		}
+		return size, true, nil
+	}
+	ratio, err := sampleCompressionRatio(ctx, store, fileMeta)
+	if err != nil {
+		return 0, false, err
+	}
+	return int64(float64(fileMeta.FileSize) * ratio), false, nil
+}
+
+// sampleCompressionRatio decompresses the prefix of the compressed file to get its compression ratio.
+func sampleCompressionRatio(ctx context.Context, store storage.ExternalStorage, fileMeta SourceFileMeta) (float64, error) {
+	compressType, err := ToStorageCompressType(fileMeta.Compression)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	fileReader, err := store.Open(ctx, fileMeta.Path)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	defer fileReader.Close()
+	sample := make([]byte, compressedFileSampleSize)
+	n, err := io.ReadFull(fileReader, sample)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return 0, errors.Annotatef(err, "failed to read file '%s'", fileMeta.Path)
+	}
+	reader, err := storage.NewDecompressReader(bytes.NewReader(sample[:n]), compressType)
+	if err != nil {
+		return 0, errors.Annotatef(err, "failed to decompress file '%s'", fileMeta.Path)
+	}
+	defer reader.Close()
+	// The prefix is cut in the middle of the compressed data, so the error of the incomplete data is ignored,
+	// and the ratio only counts the data decompressed before it.
+	decompressedSize, _ := io.Copy(io.Discard, reader)
+	if decompressedSize == 0 {
+		return compressedFileSizeRatio, nil
+	}
+	return math.Max(float64(decompressedSize)/float64(n), 1), nil
+}
+
 func makeSourceFileRegion(
 	ctx context.Context,
 	meta *MDTableMeta,
@@ -267,15 +333,49 @@ func makeSourceFileRegion(
 	dataFileSize := fi.FileMeta.FileSize
 	divisor := int64(columns)
 	isCsvFile := fi.FileMeta.Type == SourceTypeCSV
-	if !isCsvFile {
+	isJSONLinesFile := fi.FileMeta.Type == SourceTypeJSONLines
+	switch {
+	case isJSONLinesFile:
+		// the columns may be missing in the rows of a JSON lines file, so the
+		// rows can be as short as an empty object.
+		divisor = jsonLinesMinRowSize
+	case !isCsvFile:
 		divisor += 2
 	}
+	// A compressed file can't be split since the offsets in it are unknown. Its
+	// decompressed size is measured or estimated to allocate the row IDs, and
+	// the chunk with the estimated size is read until EOF.
+	if fi.FileMeta.Compression != CompressionNone {
+		decompressedSize, exact, err := getDecompressedFileSize(ctx, store, fi.FileMeta)
+		if err != nil {
+			return nil, nil, err
+		}
+		endOffset := decompressedSize
+		if !exact {
+			endOffset = compressedFileEndOffset
+		}
+		tableRegion := &TableRegion{
+			DB:       meta.DB,
+			Table:    meta.Name,
+			FileMeta: fi.FileMeta,
+			Chunk: Chunk{
+				Offset:       0,
+				EndOffset:    endOffset,
+				PrevRowIDMax: 0,
+				RowIDMax:     decompressedSize / divisor,
+			},
+		}
+		return []*TableRegion{tableRegion}, []float64{float64(decompressedSize)}, nil
+	}
 	// If a csv file is overlarge, we need to split it into multiple regions.
 	// Note: We can only split a csv file whose format is strict.
 	// We increase the check threshold by 1/10 of the `max-region-size` because the source file size dumped by tools
 	// like dumpling might be slight exceed the threshold when it is equal `max-region-size`, so we can
 	// avoid split a lot of small chunks.
-	if isCsvFile && cfg.Mydumper.StrictFormat && dataFileSize > int64(cfg.Mydumper.MaxRegionSize+cfg.Mydumper.MaxRegionSize/largeCSVLowerThresholdRation) {
+	// A JSON lines file can always be split since the new lines are escaped in
+	// the JSON strings.
+	if (isJSONLinesFile || isCsvFile && cfg.Mydumper.StrictFormat) &&
+		dataFileSize > int64(cfg.Mydumper.MaxRegionSize+cfg.Mydumper.MaxRegionSize/largeCSVLowerThresholdRation) {
 		_, regions, subFileSizes, err := SplitLargeFile(ctx, meta, cfg, fi, divisor, 0, ioWorkers, store)
 		return regions, subFileSizes, err
 	}
@@ -333,8 +433,8 @@ func makeParquetFileRegion(
 	return rowIDMax, region, nil
 }
 
-// SplitLargeFile splits a large csv file into multiple regions, the size of
-// each regions is specified by `config.MaxRegionSize`.
+// SplitLargeFile splits a large csv or JSON lines file into multiple regions,
+// the size of each regions is specified by `config.MaxRegionSize`.
 // Note: We split the file coarsely, thus the format of csv file is needed to be
 // strict.
 // e.g.
@@ -354,7 +454,7 @@ func SplitLargeFile(
 	dataFileSizes = make([]float64, 0, dataFile.FileMeta.FileSize/maxRegionSize+1)
 	startOffset, endOffset := int64(0), maxRegionSize
 	var columns []string
-	if cfg.Mydumper.CSV.Header {
+	if cfg.Mydumper.CSV.Header && dataFile.FileMeta.Type == SourceTypeCSV {
 		r, err := store.Open(ctx, dataFile.FileMeta.Path)
 		if err != nil {
 			return 0, nil, nil, err
@@ -386,14 +486,22 @@ func SplitLargeFile(
 			if err != nil {
 				return 0, nil, nil, err
 			}
-			// Create a utf8mb4 convertor to encode and decode data with the charset of CSV files.
-			charsetConvertor, err := NewCharsetConvertor(cfg.Mydumper.DataCharacterSet, cfg.Mydumper.DataInvalidCharReplace)
-			if err != nil {
-				return 0, nil, nil, err
+			var parser interface {
+				Parser
+				ReadUntilTerminator() (int64, error)
 			}
-			parser, err := NewCSVParser(&cfg.Mydumper.CSV, r, int64(cfg.Mydumper.ReadBlockSize), ioWorker, false, charsetConvertor)
-			if err != nil {
-				return 0, nil, nil, err
+			if dataFile.FileMeta.Type == SourceTypeJSONLines {
+				parser = NewJSONLinesParser(r, int64(cfg.Mydumper.ReadBlockSize), ioWorker)
+			} else {
+				// Create a utf8mb4 convertor to encode and decode data with the charset of CSV files.
+				charsetConvertor, err := NewCharsetConvertor(cfg.Mydumper.DataCharacterSet, cfg.Mydumper.DataInvalidCharReplace)
+				if err != nil {
+					return 0, nil, nil, err
+				}
+				parser, err = NewCSVParser(&cfg.Mydumper.CSV, r, int64(cfg.Mydumper.ReadBlockSize), ioWorker, false, charsetConvertor)
+				if err != nil {
+					return 0, nil, nil, err
+				}
 			}
 			if err = parser.SetPos(endOffset, prevRowIDMax); err != nil {
 				return 0, nil, nil, err
@@ -16,10 +16,15 @@ package mydump_test
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"math/rand"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/br/pkg/lightning/config"
 	. "github.com/pingcap/tidb/br/pkg/lightning/mydump"
 	"github.com/pingcap/tidb/br/pkg/lightning/worker"
@@ -382,3 +387,163 @@ func TestSplitLargeFileOnlyOneChunk(t *testing.T) {
 		require.Equal(t, columns, regions[i].Chunk.Columns)
 	}
 }
+
+func TestMakeTableRegionsCompressedFile(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+
+	var content []byte
+	for i := 0; i < 1000; i++ {
+		content = append(content, []byte("123,456\r\n")...)
+	}
+	fileName := "test.csv.zst"
+	err = storage.WithCompression(store, storage.Zstd).WriteFile(context.Background(), fileName, content)
+	require.NoError(t, err)
+	dataFileInfo, err := os.Stat(filepath.Join(dir, fileName))
+	require.NoError(t, err)
+	fileSize := dataFileInfo.Size()
+	require.Less(t, fileSize, int64(len(content)))
+
+	meta := &MDTableMeta{
+		DB:   "csv",
+		Name: "compressed",
+		DataFiles: []FileInfo{{FileMeta: SourceFileMeta{
+			Path:        fileName,
+			Type:        SourceTypeCSV,
+			Compression: CompressionZStd,
+			FileSize:    fileSize,
+		}}},
+	}
+	cfg := config.NewConfig()
+	cfg.Mydumper.CSV.Separator = ","
+	cfg.Mydumper.CSV.Header = false
+	cfg.Mydumper.StrictFormat = true
+	cfg.Mydumper.MaxRegionSize = 15
+	ioWorkers := worker.NewPool(context.Background(), 4, "io")
+
+	// a compressed file can't be split, and its chunk ends at the decompressed size.
+	// The content is compressed more than 10 times, the row IDs must still be enough.
+	require.Greater(t, int64(len(content)), fileSize*10)
+	regions, err := MakeTableRegions(context.Background(), meta, 2, cfg, ioWorkers, store)
+	require.NoError(t, err)
+	require.Len(t, regions, 1)
+	require.Equal(t, int64(0), regions[0].Chunk.Offset)
+	require.Equal(t, int64(len(content)), regions[0].Chunk.EndOffset)
+	require.Equal(t, int64(len(content)/2), regions[0].Chunk.RowIDMax)
+
+	reader, err := OpenReader(context.Background(), regions[0].FileMeta, store)
+	require.NoError(t, err)
+	charsetConvertor, err := NewCharsetConvertor(cfg.Mydumper.DataCharacterSet, cfg.Mydumper.DataInvalidCharReplace)
+	require.NoError(t, err)
+	parser, err := NewCSVParser(&cfg.Mydumper.CSV, reader, int64(cfg.Mydumper.ReadBlockSize), ioWorkers, false, charsetConvertor)
+	require.NoError(t, err)
+	defer parser.Close()
+	require.NoError(t, parser.SetPos(regions[0].Chunk.Offset, regions[0].Chunk.PrevRowIDMax))
+	rows := 0
+	for {
+		err = parser.ReadRow()
+		if errors.Cause(err) == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		rows++
+	}
+	require.Equal(t, 1000, rows)
+	require.LessOrEqual(t, int64(rows), regions[0].Chunk.RowIDMax)
+	pos, _ := parser.Pos()
+	require.Equal(t, int64(len(content)), pos)
+
+	// the rows of a JSON lines file can be as short as an empty object.
+	content = []byte(strings.Repeat("{}\n", 1000))
+	fileName = "test.jsonl.gz"
+	err = storage.WithCompression(store, storage.Gzip).WriteFile(context.Background(), fileName, content)
+	require.NoError(t, err)
+	dataFileInfo, err = os.Stat(filepath.Join(dir, fileName))
+	require.NoError(t, err)
+	meta.DataFiles = []FileInfo{{FileMeta: SourceFileMeta{
+		Path:        fileName,
+		Type:        SourceTypeJSONLines,
+		Compression: CompressionGZ,
+		FileSize:    dataFileInfo.Size(),
+	}}}
+	regions, err = MakeTableRegions(context.Background(), meta, 2, cfg, ioWorkers, store)
+	require.NoError(t, err)
+	require.Len(t, regions, 1)
+	require.Equal(t, int64(len(content)), regions[0].Chunk.EndOffset)
+	require.Equal(t, int64(1000), regions[0].Chunk.RowIDMax)
+}
+
+func TestMakeTableRegionsLargeCompressedFile(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+
+	rnd := rand.New(rand.NewSource(1))
+	var content []byte
+	rowCount := 0
+	for len(content) < 12*1024*1024 {
+		content = append(content, []byte(fmt.Sprintf("%d,%d\r\n", rnd.Int63(), rnd.Int63()))...)
+		rowCount++
+	}
+	cfg := config.NewConfig()
+	cfg.Mydumper.CSV.Separator = ","
+	cfg.Mydumper.CSV.Header = false
+	ioWorkers := worker.NewPool(context.Background(), 4, "io")
+
+	cases := []struct {
+		fileName     string
+		compressType storage.CompressType
+		compression  Compression
+	}{
+		{"test.csv.gz", storage.Gzip, CompressionGZ},
+		{"test.csv.zst", storage.Zstd, CompressionZStd},
+		{"test.csv.snappy", storage.Snappy, CompressionSnappy},
+	}
+	for _, c := range cases {
+		err = storage.WithCompression(store, c.compressType).WriteFile(context.Background(), c.fileName, content)
+		require.NoError(t, err)
+		dataFileInfo, err := os.Stat(filepath.Join(dir, c.fileName))
+		require.NoError(t, err)
+		// the file is larger than the sampled prefix, so its decompressed size is estimated.
+		require.Greater(t, dataFileInfo.Size(), int64(4*1024*1024))
+		meta := &MDTableMeta{
+			DB:   "csv",
+			Name: "compressed",
+			DataFiles: []FileInfo{{FileMeta: SourceFileMeta{
+				Path:        c.fileName,
+				Type:        SourceTypeCSV,
+				Compression: c.compression,
+				FileSize:    dataFileInfo.Size(),
+			}}},
+		}
+		regions, err := MakeTableRegions(context.Background(), meta, 2, cfg, ioWorkers, store)
+		require.NoError(t, err)
+		require.Len(t, regions, 1)
+		// the chunk is read until EOF, and the estimated size is close to the decompressed size.
+		require.Equal(t, int64(0), regions[0].Chunk.Offset)
+		require.Greater(t, regions[0].Chunk.EndOffset, int64(len(content)))
+		estimatedSize := regions[0].Chunk.RowIDMax * 2
+		require.InEpsilon(t, len(content), estimatedSize, 0.1, c.fileName)
+		require.Greater(t, regions[0].Chunk.RowIDMax, int64(rowCount))
+
+		reader, err := OpenReader(context.Background(), regions[0].FileMeta, store)
+		require.NoError(t, err)
+		charsetConvertor, err := NewCharsetConvertor(cfg.Mydumper.DataCharacterSet, cfg.Mydumper.DataInvalidCharReplace)
+		require.NoError(t, err)
+		parser, err := NewCSVParser(&cfg.Mydumper.CSV, reader, int64(cfg.Mydumper.ReadBlockSize), ioWorkers, false, charsetConvertor)
+		require.NoError(t, err)
+		require.NoError(t, parser.SetPos(regions[0].Chunk.Offset, regions[0].Chunk.PrevRowIDMax))
+		rows := 0
+		for {
+			err = parser.ReadRow()
+			if errors.Cause(err) == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			rows++
+		}
+		require.Equal(t, rowCount, rows)
+		require.NoError(t, parser.Close())
+	}
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/pingcap/tidb/br/pkg/lightning/config"
 	"github.com/pingcap/tidb/br/pkg/lightning/log"
+	"github.com/pingcap/tidb/br/pkg/storage"
 )
 
 type SourceType int
@@ -25,16 +26,19 @@ const (
 	SourceTypeCSV
 	SourceTypeParquet
 	SourceTypeViewSchema
+	SourceTypeJSONLines
 )
 
 const (
-	SchemaSchema = "schema-schema"
-	TableSchema  = "table-schema"
-	ViewSchema   = "view-schema"
-	TypeSQL      = "sql"
-	TypeCSV      = "csv"
-	TypeParquet  = "parquet"
-	TypeIgnore   = "ignore"
+	SchemaSchema  = "schema-schema"
+	TableSchema   = "table-schema"
+	ViewSchema    = "view-schema"
+	TypeSQL       = "sql"
+	TypeCSV       = "csv"
+	TypeParquet   = "parquet"
+	TypeJSONLines = "jsonl"
+	TypeNDJSON    = "ndjson" // alias of TypeJSONLines
+	TypeIgnore    = "ignore"
 )
 
 type Compression int
@@ -45,6 +49,7 @@ const (
 	CompressionLZ4
 	CompressionZStd
 	CompressionXZ
+	CompressionSnappy
 )
 
 func parseSourceType(t string) (SourceType, error) {
@@ -59,6 +64,8 @@ func parseSourceType(t string) (SourceType, error) {
 		return SourceTypeCSV, nil
 	case TypeParquet:
 		return SourceTypeParquet, nil
+	case TypeJSONLines, TypeNDJSON:
+		return SourceTypeJSONLines, nil
 	case TypeIgnore:
 		return SourceTypeIgnore, nil
 	case ViewSchema:
@@ -80,6 +87,8 @@ func (s SourceType) String() string {
 		return TypeSQL
 	case SourceTypeParquet:
 		return TypeParquet
+	case SourceTypeJSONLines:
+		return TypeJSONLines
 	case SourceTypeViewSchema:
 		return ViewSchema
 	default:
@@ -93,10 +102,12 @@ func parseCompressionType(t string) (Compression, error) {
 		return CompressionGZ, nil
 	case "lz4":
 		return CompressionLZ4, nil
-	case "zstd":
+	case "zstd", "zst":
 		return CompressionZStd, nil
 	case "xz":
 		return CompressionXZ, nil
+	case "snappy":
+		return CompressionSnappy, nil
 	case "":
 		return CompressionNone, nil
 	default:
@@ -104,19 +115,41 @@ func parseCompressionType(t string) (Compression, error) {
 	}
 }
 
+// ToStorageCompressType converts Compression to the compression type of the
+// storage layer, which decompresses the source files.
+func ToStorageCompressType(compression Compression) (storage.CompressType, error) {
+	switch compression {
+	case CompressionNone:
+		return storage.NoCompression, nil
+	case CompressionGZ:
+		return storage.Gzip, nil
+	case CompressionLZ4:
+		return storage.LZ4, nil
+	case CompressionZStd:
+		return storage.Zstd, nil
+	case CompressionSnappy:
+		return storage.Snappy, nil
+	default:
+		return storage.NoCompression, errors.Errorf("compression %d is not supported yet", compression)
+	}
+}
+
 var expandVariablePattern = regexp.MustCompile(`\$(?:\$|[\pL\p{Nd}_]+|\{[\pL\p{Nd}_]+\})`)
 
+// compressionSuffixPattern matches the optional suffix of the files compressed by dumpling.
+const compressionSuffixPattern = `(?:\.(gz|lz4|zst|zstd|snappy))?`
+
 var defaultFileRouteRules = []*config.FileRouteRule{
-	// ignore *-schema-trigger.sql, *-schema-post.sql files
-	{Pattern: `(?i).*(-schema-trigger|-schema-post)\.sql$`, Type: "ignore"},
-	// db schema create file pattern, matches files like '{schema}-schema-create.sql'
-	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)-schema-create\.sql$`, Schema: "$1", Table: "", Type: SchemaSchema, Unescape: true},
-	// table schema create file pattern, matches files like '{schema}.{table}-schema.sql'
-	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)-schema\.sql$`, Schema: "$1", Table: "$2", Type: TableSchema, Unescape: true},
-	// view schema create file pattern, matches files like '{schema}.{table}-schema-view.sql'
-	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)-schema-view\.sql$`, Schema: "$1", Table: "$2", Type: ViewSchema, Unescape: true},
-	// source file pattern, matches files like '{schema}.{table}.0001.{sql|csv}'
-	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)(?:\.([0-9]+))?\.(sql|csv|parquet)$`, Schema: "$1", Table: "$2", Type: "$4", Key: "$3", Unescape: true},
+	// ignore *-schema-trigger.sql, *-schema-triggers.sql, *-schema-post.sql files
+	{Pattern: `(?i).*(-schema-triggers?|-schema-post)\.sql` + compressionSuffixPattern + `$`, Type: "ignore"},
+	// db schema create file pattern, matches files like '{schema}-schema-create.sql[.{compress}]'
+	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)-schema-create\.sql` + compressionSuffixPattern + `$`, Schema: "$1", Table: "", Type: SchemaSchema, Compression: "$2", Unescape: true},
+	// table schema create file pattern, matches files like '{schema}.{table}-schema.sql[.{compress}]'
+	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)-schema\.sql` + compressionSuffixPattern + `$`, Schema: "$1", Table: "$2", Type: TableSchema, Compression: "$3", Unescape: true},
+	// view schema create file pattern, matches files like '{schema}.{table}-schema-view.sql[.{compress}]'
+	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)-schema-view\.sql` + compressionSuffixPattern + `$`, Schema: "$1", Table: "$2", Type: ViewSchema, Compression: "$3", Unescape: true},
+	// source file pattern, matches files like '{schema}.{table}.0001.{sql|csv|parquet|jsonl|ndjson}[.{compress}]'
+	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)(?:\.([0-9]+))?\.(sql|csv|parquet|jsonl|ndjson)` + compressionSuffixPattern + `$`, Schema: "$1", Table: "$2", Type: "$4", Key: "$3", Compression: "$5", Unescape: true},
 }
 
 // // RouteRule is a rule to route file path to target schema/table
@@ -175,6 +208,10 @@ func (r *RegexRouter) Route(path string) (*RouteResult, error) {
 			return nil, err
 		}
 	}
+	// parquet files are read with random access, which can't be done on the compressed files.
+	if result.Type == SourceTypeParquet && result.Compression != CompressionNone {
+		return nil, errors.New("compressed parquet files are not supported")
+	}
 	return result, nil
 }
 
@@ -265,13 +302,12 @@ func (p regexRouterParser) Parse(r *config.FileRouteRule) (*RegexRouter, error)
 
 	if len(r.Compression) > 0 {
 		err = p.parseFieldExtractor(rule, "compression", r.Compression, func(result *RouteResult, value string) error {
-			// TODO: should support restore compressed source files
 			compression, err := parseCompressionType(value)
 			if err != nil {
 				return err
 			}
-			if compression != CompressionNone {
-				return errors.New("Currently we don't support restore compressed source file yet")
+			if _, err = ToStorageCompressType(compression); err != nil {
+				return errors.Errorf("compression type '%s' is not supported yet", value)
 			}
 			result.Compression = compression
 			return nil
@@ -111,7 +111,7 @@ func TestSingleRouteRule(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, r)
 	invalidMatchPaths := []string{
-		"my_schema.my_table.sql.gz",
+		"my_schema.my_table.sql.xz",
 		"my_schema.my_table.sql.rar",
 		"my_schema.my_table.txt",
 	}
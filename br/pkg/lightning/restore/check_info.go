@@ -597,12 +597,7 @@ func hasDefault(col *model.ColumnInfo) bool {
 }
 
 func (rc *Controller) readFirstRow(ctx context.Context, dataFileMeta mydump.SourceFileMeta) (cols []string, row []types.Datum, err error) {
-	var reader storage.ReadSeekCloser
-	if dataFileMeta.Type == mydump.SourceTypeParquet {
-		reader, err = mydump.OpenParquetReader(ctx, rc.store, dataFileMeta.Path, dataFileMeta.FileSize)
-	} else {
-		reader, err = rc.store.Open(ctx, dataFileMeta.Path)
-	}
+	reader, err := mydump.OpenReader(ctx, dataFileMeta, rc.store)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
@@ -628,6 +623,8 @@ func (rc *Controller) readFirstRow(ctx context.Context, dataFileMeta mydump.Sour
 		if err != nil {
 			return nil, nil, errors.Trace(err)
 		}
+	case mydump.SourceTypeJSONLines:
+		parser = mydump.NewJSONLinesParser(reader, blockBufSize, rc.ioWorkers)
 	default:
 		panic(fmt.Sprintf("unknown file type '%s'", dataFileMeta.Type))
 	}
@@ -680,7 +677,8 @@ func (rc *Controller) SchemaIsValid(ctx context.Context, tableInfo *mydump.MDTab
 	// get columns name from data file.
 	dataFileMeta := dataFile.FileMeta
 
-	if tp := dataFileMeta.Type; tp != mydump.SourceTypeCSV && tp != mydump.SourceTypeSQL && tp != mydump.SourceTypeParquet {
+	if tp := dataFileMeta.Type; tp != mydump.SourceTypeCSV && tp != mydump.SourceTypeSQL && tp != mydump.SourceTypeParquet &&
+		tp != mydump.SourceTypeJSONLines {
 		msgs = append(msgs, fmt.Sprintf("file '%s' with unknown source type '%s'", dataFileMeta.Path, dataFileMeta.Type.String()))
 		return msgs, nil
 	}
@@ -943,13 +941,7 @@ func (rc *Controller) sampleDataFromTable(
 		return nil
 	}
 	sampleFile := tableMeta.DataFiles[0].FileMeta
-	var reader storage.ReadSeekCloser
-	var err error
-	if sampleFile.Type == mydump.SourceTypeParquet {
-		reader, err = mydump.OpenParquetReader(ctx, rc.store, sampleFile.Path, sampleFile.FileSize)
-	} else {
-		reader, err = rc.store.Open(ctx, sampleFile.Path)
-	}
+	reader, err := mydump.OpenReader(ctx, sampleFile, rc.store)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -989,6 +981,10 @@ func (rc *Controller) sampleDataFromTable(
 		if err != nil {
 			return errors.Trace(err)
 		}
+	case mydump.SourceTypeJSONLines:
+		jsonLinesParser := mydump.NewJSONLinesParser(reader, blockBufSize, rc.ioWorkers)
+		jsonLinesParser.SetTableInfo(tableInfo)
+		parser = jsonLinesParser
 	default:
 		panic(fmt.Sprintf("file '%s' with unknown source type '%s'", sampleFile.Path, sampleFile.Type.String()))
 	}
@@ -908,14 +908,16 @@ func (rc *Controller) estimateChunkCountIntoMetrics(ctx context.Context) error {
 					estimatedChunkCount += cnt
 					continue
 				}
-				if fileMeta.FileMeta.Type == mydump.SourceTypeCSV {
+				switch fileMeta.FileMeta.Type {
+				case mydump.SourceTypeCSV, mydump.SourceTypeJSONLines:
 					cfg := rc.cfg.Mydumper
-					if fileMeta.FileMeta.FileSize > int64(cfg.MaxRegionSize) && cfg.StrictFormat && !cfg.CSV.Header {
+					canSplit := fileMeta.FileMeta.Type == mydump.SourceTypeJSONLines || cfg.StrictFormat && !cfg.CSV.Header
+					if fileMeta.FileMeta.FileSize > int64(cfg.MaxRegionSize) && canSplit {
 						estimatedChunkCount += math.Round(float64(fileMeta.FileMeta.FileSize) / float64(cfg.MaxRegionSize))
 					} else {
 						estimatedChunkCount++
 					}
-				} else {
+				default:
 					estimatedChunkCount++
 				}
 			}
@@ -1998,13 +2000,7 @@ func newChunkRestore(
 ) (*chunkRestore, error) {
 	blockBufSize := int64(cfg.Mydumper.ReadBlockSize)
 
-	var reader storage.ReadSeekCloser
-	var err error
-	if chunk.FileMeta.Type == mydump.SourceTypeParquet {
-		reader, err = mydump.OpenParquetReader(ctx, store, chunk.FileMeta.Path, chunk.FileMeta.FileSize)
-	} else {
-		reader, err = store.Open(ctx, chunk.FileMeta.Path)
-	}
+	reader, err := mydump.OpenReader(ctx, chunk.FileMeta, store)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -2029,6 +2025,12 @@ func newChunkRestore(
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+	case mydump.SourceTypeJSONLines:
+		jsonLinesParser := mydump.NewJSONLinesParser(reader, blockBufSize, ioWorkers)
+		// the values of JSON lines files are mapped to the columns by the keys,
+		// so the columns are always all the columns of the table.
+		jsonLinesParser.SetTableInfo(tableInfo.Core)
+		parser = jsonLinesParser
 	default:
 		panic(fmt.Sprintf("file '%s' with unknown source type '%s'", chunk.Key.Path, chunk.FileMeta.Type.String()))
 	}
@@ -2036,7 +2038,7 @@ func newChunkRestore(
 	if err = parser.SetPos(chunk.Chunk.Offset, chunk.Chunk.PrevRowIDMax); err != nil {
 		return nil, errors.Trace(err)
 	}
-	if len(chunk.ColumnPermutation) > 0 {
+	if len(chunk.ColumnPermutation) > 0 && chunk.FileMeta.Type != mydump.SourceTypeJSONLines {
 		parser.SetColumns(getColumnNames(tableInfo.Core, chunk.ColumnPermutation))
 	}
 
@@ -93,6 +93,9 @@ func DBFromConfig(ctx context.Context, dsn config.DBStore) (*sql.DB, error) {
 		"autocommit": "1",
 		// alway set transaction mode to optimistic
 		"tidb_txn_mode": "optimistic",
+		// the tables are restored concurrently, the rows referring to the tables
+		// not restored yet can't pass the foreign key checks
+		"foreign_key_checks": "0",
 	}
 
 	if dsn.Vars != nil {
@@ -43,6 +43,11 @@ func NewDB(g glue.Glue, store kv.Storage) (*DB, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	// Skip the foreign key checks, the referenced tables may not be restored yet
+	err = se.Execute(context.Background(), "set @@foreign_key_checks=0")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	return &DB{
 		se: se,
 	}, nil
@@ -69,9 +74,22 @@ func (db *DB) ExecDDL(ctx context.Context, ddlJob *model.Job) error {
 				zap.Error(err))
 		}
 		return errors.Trace(err)
+	case model.ActionCreateRoutine:
+		routineInfo := &model.RoutineInfo{}
+		if err = ddlJob.DecodeArgs(routineInfo); err != nil {
+			return errors.Trace(err)
+		}
+		err = db.se.CreateRoutine(ctx, model.NewCIStr(ddlJob.SchemaName), routineInfo)
+		if err != nil {
+			log.Error("create routine failed",
+				zap.String("db", ddlJob.SchemaName),
+				zap.Stringer("routine", routineInfo.Name),
+				zap.Error(err))
+		}
+		return errors.Trace(err)
 	}
 
-	if tableInfo != nil {
+	if tableInfo != nil || ddlJob.Type == model.ActionDropRoutine {
 		switchDBSQL := fmt.Sprintf("use %s;", utils.EncloseName(ddlJob.SchemaName))
 		err = db.se.Execute(ctx, switchDBSQL)
 		if err != nil {
@@ -298,6 +316,9 @@ func FilterDDLJobs(allDDLJobs []*model.Job, tables []*metautil.Table) (ddlJobs [
 					// For the jobs executed after rename, like the step 3 in the example above.
 					dbNames[job.BinlogInfo.DBInfo.Name.String()] = true
 				}
+			} else if isRoutineJob(job) && dbIDs[job.SchemaID] {
+				// The jobs of stored routines don't carry the database info.
+				ddlJobs = append(ddlJobs, job)
 			}
 		}
 	}
@@ -324,6 +345,10 @@ func FilterDDLJobs(allDDLJobs []*model.Job, tables []*metautil.Table) (ddlJobs [
 	return ddlJobs
 }
 
+func isRoutineJob(job *model.Job) bool {
+	return job.Type == model.ActionCreateRoutine || job.Type == model.ActionDropRoutine
+}
+
 func getDatabases(tables []*metautil.Table) (dbs []*model.DBInfo) {
 	dbIDs := make(map[int64]bool)
 	for _, table := range tables {
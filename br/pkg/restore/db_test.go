@@ -12,6 +12,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	backuppb "github.com/pingcap/kvproto/pkg/brpb"
 	"github.com/pingcap/kvproto/pkg/encryptionpb"
+	filter "github.com/pingcap/tidb-tools/pkg/table-filter"
 	"github.com/pingcap/tidb/br/pkg/backup"
 	"github.com/pingcap/tidb/br/pkg/gluetidb"
 	"github.com/pingcap/tidb/br/pkg/metautil"
@@ -293,3 +294,80 @@ func TestFilterDDLJobsV2(t *testing.T) {
 	}
 	require.Equal(t, 7, len(ddlJobs))
 }
+
+func TestRestoreRoutines(t *testing.T) {
+	s, clean := createRestoreSchemaSuite(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, s.mock.Storage)
+	tk.MustExec("CREATE DATABASE test_db;")
+	tk.MustExec("USE test_db;")
+	tk.MustExec("CREATE TABLE test_table (c1 INT);")
+	tk.MustExec("SET @@sql_mode = 'ANSI_QUOTES';")
+	tk.MustExec(`CREATE PROCEDURE ins(IN a INT) INSERT INTO "test_table" VALUES (a);`)
+	tk.MustExec("SET @@sql_mode = DEFAULT;")
+	tk.MustExec("CREATE FUNCTION one() RETURNS INT RETURN 1;")
+	lastTS, err := s.mock.GetOracle().GetTimestamp(context.Background(), &oracle.Option{TxnScope: oracle.GlobalTxnScope})
+	require.NoError(t, err)
+	tk.MustExec("DROP FUNCTION one;")
+	ts, err := s.mock.GetOracle().GetTimestamp(context.Background(), &oracle.Option{TxnScope: oracle.GlobalTxnScope})
+	require.NoError(t, err)
+
+	cipher := backuppb.CipherInfo{
+		CipherType: encryptionpb.EncryptionMethod_PLAINTEXT,
+	}
+	ctx := context.Background()
+	readDDLJobs := func(write func(*metautil.MetaWriter) error) []*model.Job {
+		metaWriter := metautil.NewMetaWriter(s.storage, metautil.MetaFileSize, false, &cipher)
+		metaWriter.StartWriteMetasAsync(ctx, metautil.AppendDDL)
+		require.NoError(t, write(metaWriter))
+		require.NoError(t, metaWriter.FinishWriteMetas(ctx, metautil.AppendDDL))
+		require.NoError(t, metaWriter.FlushBackupMeta(ctx))
+
+		metaBytes, err := s.storage.ReadFile(ctx, metautil.MetaFile)
+		require.NoError(t, err)
+		mockMeta := &backuppb.BackupMeta{}
+		require.NoError(t, proto.Unmarshal(metaBytes, mockMeta))
+		metaReader := metautil.NewMetaReader(mockMeta, s.storage, &cipher)
+		allDDLJobsBytes, err := metaReader.ReadDDLs(ctx)
+		require.NoError(t, err)
+		var allDDLJobs []*model.Job
+		require.NoError(t, json.Unmarshal(allDDLJobsBytes, &allDDLJobs))
+		return allDDLJobs
+	}
+
+	infoSchema, err := s.mock.Domain.GetSnapshotInfoSchema(ts)
+	require.NoError(t, err)
+	dbInfo, ok := infoSchema.SchemaByName(model.NewCIStr("test_db"))
+	require.True(t, ok)
+	tableInfo, err := infoSchema.TableByName(model.NewCIStr("test_db"), model.NewCIStr("test_table"))
+	require.NoError(t, err)
+	tables := []*metautil.Table{{
+		DB:   dbInfo,
+		Info: tableInfo.Meta(),
+	}}
+
+	// A full backup contains the stored routines of the databases.
+	fullJobs := restore.FilterDDLJobs(readDDLJobs(func(w *metautil.MetaWriter) error {
+		return backup.WriteBackupRoutines(w, s.mock.Storage, filter.All(), ts)
+	}), tables)
+	require.Len(t, fullJobs, 1)
+	// An incremental backup contains the ddl jobs of the stored routines.
+	incrJobs := restore.FilterDDLJobs(readDDLJobs(func(w *metautil.MetaWriter) error {
+		return backup.WriteBackupDDLJobs(w, s.mock.Storage, lastTS, ts)
+	}), tables)
+	require.Len(t, incrJobs, 1)
+	require.Equal(t, model.ActionDropRoutine, incrJobs[0].Type)
+
+	tk.MustExec("DROP PROCEDURE ins;")
+	tk.MustExec("CREATE FUNCTION one() RETURNS INT RETURN 1;")
+	db, err := restore.NewDB(gluetidb.New(), s.mock.Storage)
+	require.NoError(t, err)
+	defer db.Close()
+	for _, job := range append(fullJobs, incrJobs...) {
+		require.NoError(t, db.ExecDDL(ctx, job))
+	}
+	tk.MustQuery("SELECT routine_name, sql_mode FROM information_schema.routines WHERE routine_schema = 'test_db'").
+		Check(testkit.Rows("ins ANSI_QUOTES"))
+	tk.MustExec("CALL ins(1);")
+	tk.MustQuery("SELECT * FROM test_table;").Check(testkit.Rows("1"))
+}
@@ -376,7 +376,10 @@ func (s *AzureBlobStorage) Create(ctx context.Context, name string) (ExternalFil
 		accessTier: s.accessTier,
 	}
 
-	uploaderWriter := newBufferedWriter(uploader, azblob.BlockBlobMaxUploadBlobBytes, NoCompression)
+	uploaderWriter, err := newBufferedWriter(uploader, azblob.BlockBlobMaxUploadBlobBytes, NoCompression)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	return uploaderWriter, nil
 }
 
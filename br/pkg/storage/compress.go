@@ -38,7 +38,10 @@ func (w *withCompression) Create(ctx context.Context, name string) (ExternalFile
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	compressedWriter := newBufferedWriter(writer, hardcodedS3ChunkSize, w.compressType)
+	compressedWriter, err := newBufferedWriter(writer, hardcodedS3ChunkSize, w.compressType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	return compressedWriter, nil
 }
 
@@ -56,8 +59,11 @@ func (w *withCompression) Open(ctx context.Context, path string) (ExternalFileRe
 
 func (w *withCompression) WriteFile(ctx context.Context, name string, data []byte) error {
 	bf := bytes.NewBuffer(make([]byte, 0, len(data)))
-	compressBf := newCompressWriter(w.compressType, bf)
-	_, err := compressBf.Write(data)
+	compressBf, err := newCompressWriter(w.compressType, bf)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = compressBf.Write(data)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -78,11 +84,21 @@ func (w *withCompression) ReadFile(ctx context.Context, name string) ([]byte, er
 	if err != nil {
 		return nil, err
 	}
+	defer compressBf.Close()
 	return io.ReadAll(compressBf)
 }
 
+// NewDecompressReader returns a reader which decompresses the data read from r.
+func NewDecompressReader(r io.Reader, compressType CompressType) (io.ReadCloser, error) {
+	if compressType == NoCompression {
+		return io.NopCloser(r), nil
+	}
+	return newCompressReader(compressType, r)
+}
+
 type compressReader struct {
 	io.ReadCloser
+	pos int64
 }
 
 // nolint:interfacer
@@ -99,8 +115,27 @@ func newInterceptReader(fileReader ExternalFileReader, compressType CompressType
 	}, nil
 }
 
-func (r *compressReader) Seek(_ int64, _ int) (int64, error) {
-	return int64(0), errors.Annotatef(berrors.ErrStorageInvalidConfig, "compressReader doesn't support Seek now")
+func (r *compressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.pos += int64(n)
+	return n, err
+}
+
+// Seek implements io.Seeker. The offset is in the uncompressed data, and only
+// seeking forward is supported since the skipped data has to be decompressed.
+func (r *compressReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.pos
+	default:
+		return r.pos, errors.Annotatef(berrors.ErrStorageInvalidConfig, "compressReader doesn't support Seek with whence %d", whence)
+	}
+	if offset < r.pos {
+		return r.pos, errors.Annotatef(berrors.ErrStorageInvalidConfig, "compressReader can't Seek backward from %d to %d", r.pos, offset)
+	}
+	_, err := io.CopyN(io.Discard, r, offset-r.pos)
+	return r.pos, errors.Trace(err)
 }
 
 type flushStorageWriter struct {
@@ -4,6 +4,7 @@ package storage
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -41,3 +42,44 @@ func TestWithCompressReadWriteFile(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, content, string(newContent))
 }
+
+func TestCompressReaderSeek(t *testing.T) {
+	dir := t.TempDir()
+	backend, err := ParseBackend("local://"+filepath.ToSlash(dir), nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+	storage, err := Create(ctx, backend, true)
+	require.NoError(t, err)
+	content := strings.Repeat("0123456789", 1000)
+	for _, compressType := range []CompressType{Gzip, Snappy, Zstd, LZ4} {
+		s := WithCompression(storage, compressType)
+		fileName := fmt.Sprintf("seek-%d.txt", compressType)
+		require.NoError(t, s.WriteFile(ctx, fileName, []byte(content)))
+
+		r, err := s.Open(ctx, fileName)
+		require.NoError(t, err)
+		pos, err := r.Seek(0, io.SeekStart)
+		require.NoError(t, err)
+		require.Equal(t, int64(0), pos)
+		pos, err = r.Seek(5003, io.SeekStart)
+		require.NoError(t, err)
+		require.Equal(t, int64(5003), pos)
+		buf := make([]byte, 4)
+		_, err = io.ReadFull(r, buf)
+		require.NoError(t, err)
+		require.Equal(t, "3456", string(buf))
+		pos, err = r.Seek(10, io.SeekCurrent)
+		require.NoError(t, err)
+		require.Equal(t, int64(5017), pos)
+		rest, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, content[5017:], string(rest))
+
+		// the decompressed data can't be read backward.
+		_, err = r.Seek(0, io.SeekStart)
+		require.Error(t, err)
+		_, err = r.Seek(0, io.SeekEnd)
+		require.Error(t, err)
+		require.NoError(t, r.Close())
+	}
+}
@@ -0,0 +1,522 @@
+// Copyright 2022 PingCAP, Inc. Licensed under Apache-2.0.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/errors"
+
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
+)
+
+// The layout of an encrypted file is:
+//
+//	magic (4 bytes) | version (1 byte) | nonce prefix (7 bytes) |
+//	length of the encrypted data key (2 bytes) | encrypted data key |
+//	segment 0 | segment 1 | ... | last segment
+//
+// Every file is encrypted by a random AES-256 data key, which is encrypted by
+// the MasterKey and stored in the header. The data is split into segments of
+// encryptSegmentSize bytes, which are sealed by AES-GCM separately, so the file
+// can be encrypted and decrypted as a stream, and be read from any offset. The
+// nonce of a segment consists of the nonce prefix, the index of the segment and
+// whether it is the last segment, so the segments can't be reordered or dropped
+// without being detected.
+const (
+	encryptVersion       byte = 1
+	encryptSegmentSize        = 64 * 1024
+	encryptTagSize            = 16
+	encryptDataKeySize        = 32
+	encryptNoncePrefix        = 7
+	encryptFixedHeader        = 4 + 1 + encryptNoncePrefix + 2
+	encryptedSegmentSize      = encryptSegmentSize + encryptTagSize
+)
+
+var encryptMagic = []byte("TENC")
+
+// MasterKey encrypts and decrypts the data keys of the encrypted files. It is
+// implemented by a local key, and can be implemented by a key management
+// service, see RegisterMasterKeyBackend.
+type MasterKey interface {
+	// Encrypt encrypts the data key.
+	Encrypt(ctx context.Context, plaintext []byte) ([]byte, error)
+	// Decrypt decrypts the data key encrypted by Encrypt.
+	Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error)
+}
+
+// MasterKeyBackend creates the MasterKey located by the URI.
+type MasterKeyBackend func(ctx context.Context, uri *url.URL) (MasterKey, error)
+
+var masterKeyBackends = struct {
+	sync.RWMutex
+	m map[string]MasterKeyBackend
+}{m: make(map[string]MasterKeyBackend)}
+
+// RegisterMasterKeyBackend registers the backend of the master keys whose URI
+// has the scheme, e.g. a key management service.
+func RegisterMasterKeyBackend(scheme string, backend MasterKeyBackend) {
+	masterKeyBackends.Lock()
+	defer masterKeyBackends.Unlock()
+	masterKeyBackends.m[strings.ToLower(scheme)] = backend
+}
+
+// NewMasterKey creates the MasterKey located by the URI. A path, or an URI with
+// the "file" or "local" scheme, is a local file containing the key as a
+// hexadecimal string, other schemes are looked up in the registered backends.
+func NewMasterKey(ctx context.Context, rawURI string) (MasterKey, error) {
+	u, err := url.Parse(rawURI)
+	if err != nil {
+		return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "invalid master key URI '%s': %s", rawURI, err)
+	}
+	switch scheme := strings.ToLower(u.Scheme); scheme {
+	case "":
+		return NewLocalMasterKeyFromFile(rawURI)
+	case "file", "local":
+		return NewLocalMasterKeyFromFile(u.Path)
+	default:
+		masterKeyBackends.RLock()
+		backend, ok := masterKeyBackends.m[scheme]
+		masterKeyBackends.RUnlock()
+		if !ok {
+			return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "master key backend '%s' is not supported", scheme)
+		}
+		return backend(ctx, u)
+	}
+}
+
+type localMasterKey struct {
+	aead cipher.AEAD
+}
+
+// NewLocalMasterKey creates a MasterKey that encrypts the data keys by
+// AES-GCM with the key, whose length must be 16, 24 or 32 bytes.
+func NewLocalMasterKey(key []byte) (MasterKey, error) {
+	aead, err := newAESGCM(key)
+	if err != nil {
+		return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "invalid master key: %s", err)
+	}
+	return &localMasterKey{aead: aead}, nil
+}
+
+// NewLocalMasterKeyFromFile creates a local MasterKey from the file containing
+// the key as a hexadecimal string.
+func NewLocalMasterKeyFromFile(path string) (MasterKey, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to read master key file")
+	}
+	key, err := hex.DecodeString(string(bytes.TrimSpace(content)))
+	if err != nil {
+		return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "invalid master key file '%s': %s", path, err)
+	}
+	return NewLocalMasterKey(key)
+}
+
+func (k *localMasterKey) Encrypt(_ context.Context, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, k.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return k.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func (k *localMasterKey) Decrypt(_ context.Context, ciphertext []byte) ([]byte, error) {
+	nonceSize := k.aead.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, errors.Annotate(berrors.ErrStorageInvalidConfig, "the encrypted data key is too short")
+	}
+	plaintext, err := k.aead.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], nil)
+	if err != nil {
+		return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "failed to decrypt the data key: %s", err)
+	}
+	return plaintext, nil
+}
+
+func newAESGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// fileCrypter seals and opens the segments of an encrypted file.
+type fileCrypter struct {
+	aead        cipher.AEAD
+	noncePrefix []byte
+	headerLen   int64
+}
+
+// newFileCrypter generates a data key for a new file, and returns the header
+// of the file.
+func newFileCrypter(ctx context.Context, masterKey MasterKey) (*fileCrypter, []byte, error) {
+	dataKey := make([]byte, encryptDataKeySize)
+	noncePrefix := make([]byte, encryptNoncePrefix)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if _, err := rand.Read(noncePrefix); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	encryptedKey, err := masterKey.Encrypt(ctx, dataKey)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if len(encryptedKey) > 0xffff {
+		return nil, nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "the encrypted data key is too long: %d bytes", len(encryptedKey))
+	}
+	aead, err := newAESGCM(dataKey)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	header := make([]byte, 0, encryptFixedHeader+len(encryptedKey))
+	header = append(header, encryptMagic...)
+	header = append(header, encryptVersion)
+	header = append(header, noncePrefix...)
+	header = header[:encryptFixedHeader]
+	binary.BigEndian.PutUint16(header[encryptFixedHeader-2:], uint16(len(encryptedKey)))
+	header = append(header, encryptedKey...)
+	return &fileCrypter{aead: aead, noncePrefix: noncePrefix, headerLen: int64(len(header))}, header, nil
+}
+
+// readFileCrypter reads the header of an encrypted file, and decrypts its data key.
+func readFileCrypter(ctx context.Context, masterKey MasterKey, r io.Reader) (*fileCrypter, error) {
+	fixed := make([]byte, encryptFixedHeader)
+	if _, err := io.ReadFull(r, fixed); err != nil {
+		return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "failed to read the header of the encrypted file: %s", err)
+	}
+	if !bytes.Equal(fixed[:4], encryptMagic) {
+		return nil, errors.Annotate(berrors.ErrStorageInvalidConfig, "the file is not encrypted")
+	}
+	if fixed[4] != encryptVersion {
+		return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "unsupported encrypted file version %d", fixed[4])
+	}
+	noncePrefix := fixed[5 : 5+encryptNoncePrefix]
+	encryptedKey := make([]byte, binary.BigEndian.Uint16(fixed[5+encryptNoncePrefix:]))
+	if _, err := io.ReadFull(r, encryptedKey); err != nil {
+		return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "failed to read the header of the encrypted file: %s", err)
+	}
+	dataKey, err := masterKey.Decrypt(ctx, encryptedKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	aead, err := newAESGCM(dataKey)
+	if err != nil {
+		return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "invalid data key: %s", err)
+	}
+	return &fileCrypter{
+		aead:        aead,
+		noncePrefix: noncePrefix,
+		headerLen:   int64(encryptFixedHeader + len(encryptedKey)),
+	}, nil
+}
+
+func (c *fileCrypter) nonce(idx int64, last bool) ([]byte, error) {
+	if idx > 0xffffffff {
+		return nil, errors.Annotate(berrors.ErrStorageInvalidConfig, "the encrypted file is too large")
+	}
+	nonce := make([]byte, encryptNoncePrefix+5)
+	copy(nonce, c.noncePrefix)
+	binary.BigEndian.PutUint32(nonce[encryptNoncePrefix:], uint32(idx))
+	if last {
+		nonce[encryptNoncePrefix+4] = 1
+	}
+	return nonce, nil
+}
+
+func (c *fileCrypter) seal(dst, plaintext []byte, idx int64, last bool) ([]byte, error) {
+	nonce, err := c.nonce(idx, last)
+	if err != nil {
+		return nil, err
+	}
+	return c.aead.Seal(dst, nonce, plaintext, nil), nil
+}
+
+func (c *fileCrypter) open(dst, ciphertext []byte, idx int64, last bool) ([]byte, error) {
+	nonce, err := c.nonce(idx, last)
+	if err != nil {
+		return nil, err
+	}
+	return c.aead.Open(dst, nonce, ciphertext, nil)
+}
+
+// plaintextSize returns the size of the data in an encrypted file of the size.
+func (c *fileCrypter) plaintextSize(size int64) int64 {
+	size -= c.headerLen
+	full, rem := size/encryptedSegmentSize, size%encryptedSegmentSize
+	if rem == 0 {
+		return full * encryptSegmentSize
+	}
+	return full*encryptSegmentSize + rem - encryptTagSize
+}
+
+type withEncryption struct {
+	ExternalStorage
+	masterKey MasterKey
+}
+
+// WithEncryption returns an ExternalStorage which encrypts the files before
+// writing them to the inner storage, and decrypts them after reading. The sizes
+// reported by WalkDir are the sizes of the encrypted files.
+func WithEncryption(inner ExternalStorage, masterKey MasterKey) ExternalStorage {
+	return &withEncryption{ExternalStorage: inner, masterKey: masterKey}
+}
+
+func (w *withEncryption) WriteFile(ctx context.Context, name string, data []byte) error {
+	crypter, header, err := newFileCrypter(ctx, w.masterKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	segments := int64(len(data))/encryptSegmentSize + 1
+	encrypted := make([]byte, 0, int64(len(header))+int64(len(data))+segments*encryptTagSize)
+	encrypted = append(encrypted, header...)
+	for idx := int64(0); ; idx++ {
+		last := len(data) <= encryptSegmentSize
+		segment := data
+		if !last {
+			segment, data = data[:encryptSegmentSize], data[encryptSegmentSize:]
+		}
+		encrypted, err = crypter.seal(encrypted, segment, idx, last)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if last {
+			break
+		}
+	}
+	return w.ExternalStorage.WriteFile(ctx, name, encrypted)
+}
+
+func (w *withEncryption) ReadFile(ctx context.Context, name string) ([]byte, error) {
+	data, err := w.ExternalStorage.ReadFile(ctx, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	r := bytes.NewReader(data)
+	crypter, err := readFileCrypter(ctx, w.masterKey, r)
+	if err != nil {
+		return nil, errors.Annotatef(err, "failed to decrypt file '%s'", name)
+	}
+	reader := &decryptReader{reader: newNopCloser(r), crypter: crypter, name: name, segIdx: -1}
+	return io.ReadAll(reader)
+}
+
+func (w *withEncryption) Open(ctx context.Context, path string) (ExternalFileReader, error) {
+	fileReader, err := w.ExternalStorage.Open(ctx, path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	crypter, err := readFileCrypter(ctx, w.masterKey, fileReader)
+	if err != nil {
+		_ = fileReader.Close()
+		return nil, errors.Annotatef(err, "failed to decrypt file '%s'", path)
+	}
+	return &decryptReader{reader: fileReader, crypter: crypter, name: path, segIdx: -1}, nil
+}
+
+func (w *withEncryption) Create(ctx context.Context, name string) (ExternalFileWriter, error) {
+	crypter, header, err := newFileCrypter(ctx, w.masterKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	writer, err := w.ExternalStorage.Create(ctx, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &encryptWriter{
+		writer:  writer,
+		crypter: crypter,
+		header:  header,
+		buf:     make([]byte, 0, encryptSegmentSize),
+	}, nil
+}
+
+// encryptWriter seals the data written to it segment by segment. A full segment
+// is only sealed when more data is written, since the last segment is sealed
+// differently when the writer is closed.
+type encryptWriter struct {
+	writer  ExternalFileWriter
+	crypter *fileCrypter
+	header  []byte
+	buf     []byte
+	segIdx  int64
+	sealed  []byte
+}
+
+func (w *encryptWriter) Write(ctx context.Context, p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		if len(w.buf) == encryptSegmentSize {
+			if err := w.flushSegment(ctx, false); err != nil {
+				return written, errors.Trace(err)
+			}
+		}
+		n := encryptSegmentSize - len(w.buf)
+		if n > len(p) {
+			n = len(p)
+		}
+		w.buf = append(w.buf, p[:n]...)
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
+
+func (w *encryptWriter) flushSegment(ctx context.Context, last bool) error {
+	var err error
+	w.sealed = append(w.sealed[:0], w.header...)
+	w.header = nil
+	w.sealed, err = w.crypter.seal(w.sealed, w.buf, w.segIdx, last)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.buf = w.buf[:0]
+	w.segIdx++
+	_, err = w.writer.Write(ctx, w.sealed)
+	return errors.Trace(err)
+}
+
+func (w *encryptWriter) Close(ctx context.Context) error {
+	if err := w.flushSegment(ctx, true); err != nil {
+		return errors.Trace(err)
+	}
+	return w.writer.Close(ctx)
+}
+
+// decryptReader reads and opens the segments of an encrypted file. It supports
+// seeking by locating the segment containing the offset.
+type decryptReader struct {
+	reader  ExternalFileReader
+	crypter *fileCrypter
+	name    string
+
+	// pos is the offset in the decrypted data.
+	pos int64
+	// segIdx is the index of the segment in plain, -1 if no segment is read.
+	segIdx  int64
+	plain   []byte
+	lastSeg bool
+	// nextIdx is the index of the segment the inner reader is located at.
+	nextIdx int64
+	buf     []byte
+}
+
+func (r *decryptReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	idx, off := r.pos/encryptSegmentSize, r.pos%encryptSegmentSize
+	if idx != r.segIdx {
+		if r.segIdx >= 0 && r.lastSeg && idx > r.segIdx {
+			return 0, io.EOF
+		}
+		if err := r.readSegment(idx); err != nil {
+			return 0, err
+		}
+		if idx > r.segIdx {
+			return 0, io.EOF
+		}
+	}
+	if off >= int64(len(r.plain)) {
+		if r.lastSeg {
+			return 0, io.EOF
+		}
+		return 0, errors.Annotatef(berrors.ErrStorageInvalidConfig, "the encrypted file '%s' is truncated", r.name)
+	}
+	n := copy(p, r.plain[off:])
+	r.pos += int64(n)
+	return n, nil
+}
+
+func (r *decryptReader) readSegment(idx int64) error {
+	if idx != r.nextIdx {
+		offset := r.crypter.headerLen + idx*encryptedSegmentSize
+		if _, err := r.reader.Seek(offset, io.SeekStart); err != nil {
+			return errors.Trace(err)
+		}
+		r.nextIdx = idx
+	}
+	if r.buf == nil {
+		r.buf = make([]byte, encryptedSegmentSize)
+	}
+	n, err := io.ReadFull(r.reader, r.buf)
+	switch errors.Cause(err) {
+	case nil, io.ErrUnexpectedEOF:
+	case io.EOF:
+		// the offset is beyond the last segment, which must be sealed as the
+		// last one, otherwise the file is truncated.
+		size, err := r.reader.Seek(0, io.SeekEnd)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		r.nextIdx = -1
+		if size <= r.crypter.headerLen {
+			return errors.Annotatef(berrors.ErrStorageInvalidConfig, "the encrypted file '%s' is truncated", r.name)
+		}
+		lastIdx := (size - r.crypter.headerLen - 1) / encryptedSegmentSize
+		if lastIdx >= idx {
+			return errors.Annotatef(berrors.ErrStorageInvalidConfig, "the encrypted file '%s' is truncated", r.name)
+		}
+		if err := r.readSegment(lastIdx); err != nil {
+			return err
+		}
+		if !r.lastSeg {
+			return errors.Annotatef(berrors.ErrStorageInvalidConfig, "the encrypted file '%s' is truncated", r.name)
+		}
+		return nil
+	default:
+		return errors.Trace(err)
+	}
+	r.nextIdx = idx + 1
+	// A full segment may be the last one, which is only known by opening it.
+	last := n < encryptedSegmentSize
+	plain, err := r.crypter.open(r.plain[:0], r.buf[:n], idx, last)
+	if err != nil && !last {
+		last = true
+		plain, err = r.crypter.open(r.plain[:0], r.buf[:n], idx, last)
+	}
+	if err != nil {
+		return errors.Annotatef(berrors.ErrStorageInvalidConfig, "failed to decrypt segment %d of file '%s': %s", idx, r.name, err)
+	}
+	r.segIdx, r.plain, r.lastSeg = idx, plain, last
+	return nil
+}
+
+func (r *decryptReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.pos
+	case io.SeekEnd:
+		size, err := r.reader.Seek(0, io.SeekEnd)
+		if err != nil {
+			return r.pos, errors.Trace(err)
+		}
+		// the inner reader isn't located at any segment now.
+		r.nextIdx = -1
+		offset += r.crypter.plaintextSize(size)
+	default:
+		return r.pos, errors.Annotatef(berrors.ErrStorageInvalidConfig, "invalid whence %d", whence)
+	}
+	if offset < 0 {
+		return r.pos, errors.Annotatef(berrors.ErrStorageInvalidConfig, "invalid offset %d", offset)
+	}
+	r.pos = offset
+	return r.pos, nil
+}
+
+func (r *decryptReader) Close() error {
+	return r.reader.Close()
+}
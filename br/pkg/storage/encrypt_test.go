@@ -0,0 +1,248 @@
+// Copyright 2022 PingCAP, Inc. Licensed under Apache-2.0.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newEncryptTestStorage(t *testing.T) (string, ExternalStorage, MasterKey) {
+	dir := t.TempDir()
+	backend, err := ParseBackend("local://"+filepath.ToSlash(dir), nil)
+	require.NoError(t, err)
+	storage, err := Create(context.Background(), backend, true)
+	require.NoError(t, err)
+	key := make([]byte, 32)
+	_, err = rand.Read(key)
+	require.NoError(t, err)
+	masterKey, err := NewLocalMasterKey(key)
+	require.NoError(t, err)
+	return dir, storage, masterKey
+}
+
+func TestWithEncryptionReadWriteFile(t *testing.T) {
+	ctx := context.Background()
+	dir, storage, masterKey := newEncryptTestStorage(t)
+	s := WithEncryption(storage, masterKey)
+
+	for _, size := range []int{0, 1, encryptSegmentSize - 1, encryptSegmentSize, encryptSegmentSize + 1, 3*encryptSegmentSize + 100} {
+		content := make([]byte, size)
+		_, err := rand.Read(content)
+		require.NoError(t, err)
+
+		// WriteFile and Create produce files that can be read by both ReadFile and Open.
+		name1 := fmt.Sprintf("write-%d.txt", size)
+		require.NoError(t, s.WriteFile(ctx, name1, content))
+		name2 := fmt.Sprintf("create-%d.txt", size)
+		w, err := s.Create(ctx, name2)
+		require.NoError(t, err)
+		for data := content; len(data) > 0; {
+			n := 1000
+			if n > len(data) {
+				n = len(data)
+			}
+			_, err = w.Write(ctx, data[:n])
+			require.NoError(t, err)
+			data = data[n:]
+		}
+		require.NoError(t, w.Close(ctx))
+
+		for _, name := range []string{name1, name2} {
+			raw, err := os.ReadFile(filepath.Join(dir, name))
+			require.NoError(t, err)
+			if size > 100 {
+				require.False(t, bytes.Contains(raw, content))
+			}
+
+			data, err := s.ReadFile(ctx, name)
+			require.NoError(t, err)
+			require.Equal(t, content, data)
+
+			r, err := s.Open(ctx, name)
+			require.NoError(t, err)
+			data, err = io.ReadAll(r)
+			require.NoError(t, err)
+			require.Equal(t, content, data)
+			require.NoError(t, r.Close())
+		}
+	}
+}
+
+func TestEncryptReaderSeek(t *testing.T) {
+	ctx := context.Background()
+	_, storage, masterKey := newEncryptTestStorage(t)
+	s := WithEncryption(storage, masterKey)
+	content := make([]byte, 3*encryptSegmentSize+100)
+	_, err := rand.Read(content)
+	require.NoError(t, err)
+	require.NoError(t, s.WriteFile(ctx, "seek.txt", content))
+
+	r, err := s.Open(ctx, "seek.txt")
+	require.NoError(t, err)
+	defer r.Close()
+	buf := make([]byte, 200)
+
+	cases := []struct {
+		offset int64
+		whence int
+		pos    int64
+	}{
+		{offset: 10, whence: io.SeekStart, pos: 10},
+		{offset: encryptSegmentSize - 100, whence: io.SeekStart, pos: encryptSegmentSize - 100},
+		{offset: 2*encryptSegmentSize - 200, whence: io.SeekCurrent, pos: 3*encryptSegmentSize - 100},
+		{offset: -300, whence: io.SeekEnd, pos: int64(len(content)) - 300},
+		{offset: 0, whence: io.SeekStart, pos: 0},
+	}
+	for _, c := range cases {
+		pos, err := r.Seek(c.offset, c.whence)
+		require.NoError(t, err)
+		require.Equal(t, c.pos, pos)
+		n, err := io.ReadFull(r, buf)
+		require.NoError(t, err)
+		require.Equal(t, content[pos:pos+int64(n)], buf[:n])
+	}
+
+	_, err = r.Seek(0, io.SeekEnd)
+	require.NoError(t, err)
+	_, err = r.Read(buf)
+	require.Equal(t, io.EOF, err)
+	_, err = r.Seek(int64(len(content))+encryptSegmentSize, io.SeekStart)
+	require.NoError(t, err)
+	_, err = r.Read(buf)
+	require.Equal(t, io.EOF, err)
+	_, err = r.Seek(-1, io.SeekStart)
+	require.Error(t, err)
+}
+
+func TestEncryptDetectTampering(t *testing.T) {
+	ctx := context.Background()
+	dir, storage, masterKey := newEncryptTestStorage(t)
+	s := WithEncryption(storage, masterKey)
+	content := make([]byte, 2*encryptSegmentSize)
+	_, err := rand.Read(content)
+	require.NoError(t, err)
+	require.NoError(t, s.WriteFile(ctx, "origin.txt", content))
+	raw, err := os.ReadFile(filepath.Join(dir, "origin.txt"))
+	require.NoError(t, err)
+	headerLen := len(raw) - 2*encryptedSegmentSize
+
+	flipped := append([]byte{}, raw...)
+	flipped[len(flipped)-1] ^= 1
+	truncated := raw[:len(raw)-10]
+	// drop the last segment.
+	truncatedAtSegment := raw[:headerLen+encryptedSegmentSize]
+	swapped := append([]byte{}, raw[:headerLen]...)
+	swapped = append(swapped, raw[headerLen+encryptedSegmentSize:]...)
+	swapped = append(swapped, raw[headerLen:headerLen+encryptedSegmentSize]...)
+
+	for i, data := range [][]byte{flipped, truncated, truncatedAtSegment, swapped} {
+		name := fmt.Sprintf("tampered-%d.txt", i)
+		require.NoError(t, storage.WriteFile(ctx, name, data))
+		_, err = s.ReadFile(ctx, name)
+		require.Error(t, err, i)
+
+		r, err := s.Open(ctx, name)
+		require.NoError(t, err)
+		_, err = io.ReadAll(r)
+		require.Error(t, err, i)
+		require.NoError(t, r.Close())
+	}
+
+	// the file can't be decrypted by another key.
+	_, _, otherKey := newEncryptTestStorage(t)
+	_, err = WithEncryption(storage, otherKey).ReadFile(ctx, "origin.txt")
+	require.Error(t, err)
+	// the file isn't encrypted.
+	require.NoError(t, storage.WriteFile(ctx, "plain.txt", content))
+	_, err = s.ReadFile(ctx, "plain.txt")
+	require.Regexp(t, "the file is not encrypted", err.Error())
+}
+
+func TestEncryptWithCompression(t *testing.T) {
+	ctx := context.Background()
+	_, storage, masterKey := newEncryptTestStorage(t)
+	s := WithCompression(WithEncryption(storage, masterKey), Gzip)
+	content := bytes.Repeat([]byte("0123456789"), 20000)
+
+	require.NoError(t, s.WriteFile(ctx, "compressed.txt.gz", content))
+	data, err := s.ReadFile(ctx, "compressed.txt.gz")
+	require.NoError(t, err)
+	require.Equal(t, content, data)
+
+	w, err := s.Create(ctx, "created.txt.gz")
+	require.NoError(t, err)
+	_, err = w.Write(ctx, content)
+	require.NoError(t, err)
+	require.NoError(t, w.Close(ctx))
+	r, err := s.Open(ctx, "created.txt.gz")
+	require.NoError(t, err)
+	data, err = io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, content, data)
+	require.NoError(t, r.Close())
+}
+
+type testMasterKey struct {
+	xor byte
+}
+
+func (k testMasterKey) Encrypt(_ context.Context, plaintext []byte) ([]byte, error) {
+	ciphertext := make([]byte, len(plaintext))
+	for i, b := range plaintext {
+		ciphertext[i] = b ^ k.xor
+	}
+	return ciphertext, nil
+}
+
+func (k testMasterKey) Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	return k.Encrypt(ctx, ciphertext)
+}
+
+func TestNewMasterKey(t *testing.T) {
+	ctx := context.Background()
+	_, storage, _ := newEncryptTestStorage(t)
+	content := []byte("hello,world!")
+
+	keyFile := filepath.Join(t.TempDir(), "master.key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(hex.EncodeToString(make([]byte, 16))+"\n"), 0o600))
+	for _, uri := range []string{keyFile, "file://" + keyFile, "local://" + keyFile} {
+		masterKey, err := NewMasterKey(ctx, uri)
+		require.NoError(t, err)
+		s := WithEncryption(storage, masterKey)
+		require.NoError(t, s.WriteFile(ctx, "local.txt", content))
+		data, err := s.ReadFile(ctx, "local.txt")
+		require.NoError(t, err)
+		require.Equal(t, content, data)
+	}
+
+	require.NoError(t, os.WriteFile(keyFile, []byte("not-a-hex-key"), 0o600))
+	_, err := NewMasterKey(ctx, keyFile)
+	require.Error(t, err)
+	_, err = NewLocalMasterKey(make([]byte, 10))
+	require.Error(t, err)
+
+	_, err = NewMasterKey(ctx, "test-kms://key-1")
+	require.Regexp(t, "master key backend 'test-kms' is not supported", err.Error())
+	RegisterMasterKeyBackend("test-kms", func(_ context.Context, uri *url.URL) (MasterKey, error) {
+		require.Equal(t, "key-1", uri.Host)
+		return testMasterKey{xor: 0x5a}, nil
+	})
+	masterKey, err := NewMasterKey(ctx, "test-kms://key-1")
+	require.NoError(t, err)
+	s := WithEncryption(storage, masterKey)
+	require.NoError(t, s.WriteFile(ctx, "kms.txt", content))
+	data, err := s.ReadFile(ctx, "kms.txt")
+	require.NoError(t, err)
+	require.Equal(t, content, data)
+}
@@ -58,7 +58,7 @@ func ParseBackend(rawURL string, options *BackendOptions) (*backuppb.StorageBack
 		local := &backuppb.Local{Path: u.Path}
 		return &backuppb.StorageBackend{Backend: &backuppb.StorageBackend_Local{Local: local}}, nil
 
-	case "hdfs":
+	case "hdfs", "webhdfs", "swebhdfs":
 		hdfs := &backuppb.HDFS{Remote: rawURL}
 		return &backuppb.StorageBackend{Backend: &backuppb.StorageBackend_Hdfs{Hdfs: hdfs}}, nil
 
@@ -189,6 +189,12 @@ func FormatBackendURL(backend *backuppb.StorageBackend) (u url.URL) {
 		u.Scheme = "azure"
 		u.Host = b.AzureBlobStorage.Bucket
 		u.Path = b.AzureBlobStorage.Prefix
+	case *backuppb.StorageBackend_Hdfs:
+		if remote, err := url.Parse(b.Hdfs.Remote); err == nil {
+			u.Scheme = remote.Scheme
+			u.Host = remote.Host
+			u.Path = remote.Path
+		}
 	}
 	return
 }
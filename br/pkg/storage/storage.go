@@ -154,6 +154,9 @@ func New(ctx context.Context, backend *backuppb.StorageBackend, opts *ExternalSt
 		if backend.Hdfs == nil {
 			return nil, errors.Annotate(berrors.ErrStorageInvalidConfig, "hdfs config not found")
 		}
+		if isWebHDFS(backend.Hdfs.Remote) {
+			return newWebHDFSStorage(backend.Hdfs.Remote, opts)
+		}
 		return NewHDFSStorage(backend.Hdfs.Remote), nil
 	case *backuppb.StorageBackend_S3:
 		if backend.S3 == nil {
@@ -0,0 +1,478 @@
+// Copyright 2022 PingCAP, Inc. Licensed under Apache-2.0.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
+)
+
+const (
+	webHDFSPathPrefix = "/webhdfs/v1"
+	// webHDFSUserEnv is the environment variable of the user when it isn't
+	// specified in the URI, which is the same as the Hadoop client.
+	webHDFSUserEnv = "HADOOP_USER_NAME"
+)
+
+// WebHDFSStorage represents the HDFS storage accessed by the WebHDFS REST API,
+// which is served by the NameNode or an HttpFS gateway. The URI looks like
+// `webhdfs://user@namenode:9870/path`, or `swebhdfs://...` to use HTTPS.
+type WebHDFSStorage struct {
+	remote   string
+	endpoint url.URL
+	base     string
+	user     string
+	client   *http.Client
+}
+
+type webHDFSFileStatus struct {
+	PathSuffix string `json:"pathSuffix"`
+	Type       string `json:"type"`
+	Length     int64  `json:"length"`
+}
+
+type webHDFSRemoteException struct {
+	RemoteException struct {
+		Exception string `json:"exception"`
+		Message   string `json:"message"`
+	} `json:"RemoteException"`
+}
+
+// isWebHDFS returns whether the HDFS storage is accessed by WebHDFS rather than
+// the hdfs command.
+func isWebHDFS(remote string) bool {
+	return strings.HasPrefix(remote, "webhdfs://") || strings.HasPrefix(remote, "swebhdfs://")
+}
+
+func newWebHDFSStorage(remote string, opts *ExternalStorageOptions) (*WebHDFSStorage, error) {
+	u, err := ParseRawURL(remote)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	endpoint := url.URL{Host: u.Host}
+	switch u.Scheme {
+	case "webhdfs":
+		endpoint.Scheme = "http"
+	case "swebhdfs":
+		endpoint.Scheme = "https"
+	default:
+		return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "storage %s is not WebHDFS", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "please specify the namenode for webhdfs in %s", remote)
+	}
+	user := u.User.Username()
+	if user == "" {
+		user = os.Getenv(webHDFSUserEnv)
+	}
+
+	client := http.DefaultClient
+	if opts != nil && opts.HTTPClient != nil {
+		client = opts.HTTPClient
+	}
+	// the redirections are followed by the storage, since the data of CREATE
+	// and APPEND must be sent to the redirected DataNode.
+	noRedirectClient := *client
+	noRedirectClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &WebHDFSStorage{
+		remote:   remote,
+		endpoint: endpoint,
+		base:     path.Clean("/" + u.Path),
+		user:     user,
+		client:   &noRedirectClient,
+	}, nil
+}
+
+func (s *WebHDFSStorage) opURL(name, op string, params url.Values) string {
+	query := url.Values{}
+	for k, v := range params {
+		query[k] = v
+	}
+	query.Set("op", op)
+	if s.user != "" {
+		query.Set("user.name", s.user)
+	}
+	u := s.endpoint
+	u.Path = webHDFSPathPrefix + path.Join(s.base, name)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+func (s *WebHDFSStorage) send(ctx context.Context, method, rawURL string, data []byte) (*http.Response, error) {
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if data != nil {
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+	resp, err := s.client.Do(req)
+	return resp, errors.Trace(err)
+}
+
+// request sends the operation to the NameNode and follows the redirection. The
+// data, if it isn't nil, is only sent to the redirected location.
+func (s *WebHDFSStorage) request(
+	ctx context.Context, method, name, op string, params url.Values, data []byte,
+) (*http.Response, error) {
+	resp, err := s.send(ctx, method, s.opURL(name, op, params), nil)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusFound, http.StatusSeeOther, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		closeWebHDFSResponse(resp)
+		location, err := resp.Request.URL.Parse(resp.Header.Get("Location"))
+		if err != nil {
+			return nil, errors.Annotatef(berrors.ErrStorageUnknown, "webhdfs %s '%s' redirects to invalid location: %s", op, name, err)
+		}
+		return s.send(ctx, method, location.String(), data)
+	}
+	if data != nil && resp.StatusCode < http.StatusMultipleChoices {
+		closeWebHDFSResponse(resp)
+		return nil, errors.Annotatef(berrors.ErrStorageUnknown, "webhdfs %s '%s' isn't redirected to the datanode", op, name)
+	}
+	return resp, nil
+}
+
+// checkWebHDFSResponse returns the error in the response, and closes the response
+// if there is an error.
+func checkWebHDFSResponse(resp *http.Response, op, name string) error {
+	if resp.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+	defer closeWebHDFSResponse(resp)
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	var exception webHDFSRemoteException
+	if err := json.Unmarshal(body, &exception); err == nil && exception.RemoteException.Exception != "" {
+		return errors.Annotatef(berrors.ErrStorageUnknown, "webhdfs %s '%s' failed with status %d: %s: %s",
+			op, name, resp.StatusCode, exception.RemoteException.Exception, exception.RemoteException.Message)
+	}
+	return errors.Annotatef(berrors.ErrStorageUnknown, "webhdfs %s '%s' failed with status %d: %s",
+		op, name, resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+func closeWebHDFSResponse(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if err := resp.Body.Close(); err != nil {
+		log.Warn("failed to close webhdfs response", zap.Error(err))
+	}
+}
+
+// do sends the operation, and decodes the JSON response into result if it
+// isn't nil.
+func (s *WebHDFSStorage) do(
+	ctx context.Context, method, name, op string, params url.Values, data []byte, result interface{},
+) error {
+	resp, err := s.request(ctx, method, name, op, params, data)
+	if err != nil {
+		return err
+	}
+	if err := checkWebHDFSResponse(resp, op, name); err != nil {
+		return err
+	}
+	defer closeWebHDFSResponse(resp)
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return errors.Annotatef(berrors.ErrStorageUnknown, "failed to decode the response of webhdfs %s '%s': %s", op, name, err)
+	}
+	return nil
+}
+
+func (s *WebHDFSStorage) getFileStatus(ctx context.Context, name string) (*webHDFSFileStatus, error) {
+	var result struct {
+		FileStatus webHDFSFileStatus `json:"FileStatus"`
+	}
+	if err := s.do(ctx, http.MethodGet, name, "GETFILESTATUS", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result.FileStatus, nil
+}
+
+func (s *WebHDFSStorage) create(ctx context.Context, name string, data []byte) error {
+	params := url.Values{"overwrite": []string{"true"}}
+	return s.do(ctx, http.MethodPut, name, "CREATE", params, data, nil)
+}
+
+func (s *WebHDFSStorage) append(ctx context.Context, name string, data []byte) error {
+	return s.do(ctx, http.MethodPost, name, "APPEND", nil, data, nil)
+}
+
+// open opens the file from the offset to the end.
+func (s *WebHDFSStorage) open(ctx context.Context, name string, offset int64) (io.ReadCloser, error) {
+	params := url.Values{"offset": []string{strconv.FormatInt(offset, 10)}}
+	resp, err := s.request(ctx, http.MethodGet, name, "OPEN", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkWebHDFSResponse(resp, "OPEN", name); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// WriteFile writes a complete file to storage, similar to os.WriteFile.
+func (s *WebHDFSStorage) WriteFile(ctx context.Context, name string, data []byte) error {
+	if data == nil {
+		data = []byte{}
+	}
+	return s.create(ctx, name, data)
+}
+
+// ReadFile reads a complete file from storage, similar to os.ReadFile.
+func (s *WebHDFSStorage) ReadFile(ctx context.Context, name string) ([]byte, error) {
+	body, err := s.open(ctx, name, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	return data, errors.Trace(err)
+}
+
+// FileExists return true if file exists.
+func (s *WebHDFSStorage) FileExists(ctx context.Context, name string) (bool, error) {
+	resp, err := s.request(ctx, http.MethodGet, name, "GETFILESTATUS", nil, nil)
+	if err != nil {
+		return false, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		closeWebHDFSResponse(resp)
+		return false, nil
+	}
+	if err := checkWebHDFSResponse(resp, "GETFILESTATUS", name); err != nil {
+		return false, err
+	}
+	closeWebHDFSResponse(resp)
+	return true, nil
+}
+
+// DeleteFile delete the file in storage.
+func (s *WebHDFSStorage) DeleteFile(ctx context.Context, name string) error {
+	return s.do(ctx, http.MethodDelete, name, "DELETE", nil, nil, nil)
+}
+
+// Open a Reader by file path. path is relative path to storage base path.
+func (s *WebHDFSStorage) Open(ctx context.Context, name string) (ExternalFileReader, error) {
+	status, err := s.getFileStatus(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if status.Type != "FILE" {
+		return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "webhdfs path '%s' is not a file", name)
+	}
+	return &webHDFSReader{ctx: ctx, storage: s, name: name, size: status.Length}, nil
+}
+
+// WalkDir traverse all the files in a dir.
+//
+// fn is the function called for each regular file visited by WalkDir.
+// The argument `path` is the file path that can be used in `Open`
+// function; the argument `size` is the size in byte of the file determined
+// by path.
+func (s *WebHDFSStorage) WalkDir(ctx context.Context, opt *WalkOption, fn func(path string, size int64) error) error {
+	if opt == nil {
+		opt = &WalkOption{}
+	}
+	return s.walkDir(ctx, strings.Trim(opt.SubDir, "/"), fn)
+}
+
+func (s *WebHDFSStorage) walkDir(ctx context.Context, dir string, fn func(path string, size int64) error) error {
+	resp, err := s.request(ctx, http.MethodGet, dir, "LISTSTATUS", nil, nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		// if path not exists, we should return nil to continue.
+		closeWebHDFSResponse(resp)
+		return nil
+	}
+	if err := checkWebHDFSResponse(resp, "LISTSTATUS", dir); err != nil {
+		return err
+	}
+	var result struct {
+		FileStatuses struct {
+			FileStatus []webHDFSFileStatus `json:"FileStatus"`
+		} `json:"FileStatuses"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	closeWebHDFSResponse(resp)
+	if err != nil {
+		return errors.Annotatef(berrors.ErrStorageUnknown, "failed to decode the response of webhdfs LISTSTATUS '%s': %s", dir, err)
+	}
+
+	for _, status := range result.FileStatuses.FileStatus {
+		// the status of a file is listed with an empty path suffix.
+		name := path.Join(dir, status.PathSuffix)
+		switch status.Type {
+		case "DIRECTORY":
+			if status.PathSuffix == "" {
+				continue
+			}
+			if err := s.walkDir(ctx, name, fn); err != nil {
+				return err
+			}
+		case "FILE":
+			if err := fn(name, status.Length); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// URI returns the base path as a URI.
+func (s *WebHDFSStorage) URI() string {
+	return s.remote
+}
+
+// Create opens a file writer by path. path is relative path to storage base path.
+func (s *WebHDFSStorage) Create(ctx context.Context, name string) (ExternalFileWriter, error) {
+	writer, err := newBufferedWriter(&webHDFSWriter{storage: s, name: name}, hardcodedS3ChunkSize, NoCompression)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return writer, nil
+}
+
+// Rename a file name from oldFileName to newFileName.
+func (s *WebHDFSStorage) Rename(ctx context.Context, oldFileName, newFileName string) error {
+	// HDFS neither creates the parent directory of the destination, nor
+	// overwrites the existing destination like os.Rename.
+	var result struct {
+		Boolean bool `json:"boolean"`
+	}
+	if err := s.do(ctx, http.MethodPut, path.Dir(newFileName), "MKDIRS", nil, nil, &result); err != nil {
+		return err
+	}
+	if err := s.DeleteFile(ctx, newFileName); err != nil {
+		return err
+	}
+	params := url.Values{"destination": []string{path.Join(s.base, newFileName)}}
+	if err := s.do(ctx, http.MethodPut, oldFileName, "RENAME", params, nil, &result); err != nil {
+		return err
+	}
+	if !result.Boolean {
+		return errors.Annotatef(berrors.ErrStorageUnknown, "webhdfs failed to rename '%s' to '%s'", oldFileName, newFileName)
+	}
+	return nil
+}
+
+// webHDFSWriter creates the file by the first chunk, and appends the
+// following chunks to it.
+type webHDFSWriter struct {
+	storage *WebHDFSStorage
+	name    string
+	created bool
+}
+
+func (w *webHDFSWriter) Write(ctx context.Context, p []byte) (int, error) {
+	var err error
+	if w.created {
+		err = w.storage.append(ctx, w.name, p)
+	} else {
+		err = w.storage.create(ctx, w.name, p)
+		w.created = err == nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w *webHDFSWriter) Close(ctx context.Context) error {
+	if w.created {
+		return nil
+	}
+	return w.storage.create(ctx, w.name, []byte{})
+}
+
+// webHDFSReader reads the file from the position by OPEN, and reopens it
+// after seeking.
+type webHDFSReader struct {
+	ctx     context.Context
+	storage *WebHDFSStorage
+	name    string
+	size    int64
+	pos     int64
+	body    io.ReadCloser
+}
+
+func (r *webHDFSReader) Read(p []byte) (int, error) {
+	if r.pos >= r.size {
+		return 0, io.EOF
+	}
+	if r.body == nil {
+		body, err := r.storage.open(r.ctx, r.name, r.pos)
+		if err != nil {
+			return 0, err
+		}
+		r.body = body
+	}
+	n, err := r.body.Read(p)
+	r.pos += int64(n)
+	if err == io.EOF && r.pos < r.size {
+		err = errors.Annotatef(berrors.ErrStorageUnknown, "webhdfs file '%s' is truncated at %d, expected size %d", r.name, r.pos, r.size)
+	}
+	return n, err
+}
+
+func (r *webHDFSReader) Seek(offset int64, whence int) (int64, error) {
+	var realOffset int64
+	switch whence {
+	case io.SeekStart:
+		realOffset = offset
+	case io.SeekCurrent:
+		realOffset = r.pos + offset
+	case io.SeekEnd:
+		realOffset = r.size + offset
+	default:
+		return 0, errors.Annotatef(berrors.ErrStorageUnknown, "Seek: invalid whence '%d'", whence)
+	}
+	if realOffset < 0 {
+		return 0, errors.Annotatef(berrors.ErrStorageUnknown, "Seek: invalid offset %d", realOffset)
+	}
+	if realOffset == r.pos {
+		return r.pos, nil
+	}
+	if err := r.closeBody(); err != nil {
+		return 0, err
+	}
+	r.pos = realOffset
+	return r.pos, nil
+}
+
+func (r *webHDFSReader) closeBody() error {
+	if r.body == nil {
+		return nil
+	}
+	err := r.body.Close()
+	r.body = nil
+	return errors.Trace(err)
+}
+
+func (r *webHDFSReader) Close() error {
+	return r.closeBody()
+}
@@ -0,0 +1,296 @@
+// Copyright 2022 PingCAP, Inc. Licensed under Apache-2.0.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const fakeDataNodePrefix = "/datanode"
+
+// fakeWebHDFS is an in-memory WebHDFS server, which redirects the data of
+// OPEN, CREATE and APPEND to the fake DataNode like the real NameNode.
+type fakeWebHDFS struct {
+	t     *testing.T
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+func (f *fakeWebHDFS) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	require.NoError(f.t, json.NewEncoder(w).Encode(v))
+}
+
+func (f *fakeWebHDFS) notFound(w http.ResponseWriter, p string) {
+	f.writeJSON(w, http.StatusNotFound, map[string]interface{}{
+		"RemoteException": map[string]string{
+			"exception": "FileNotFoundException",
+			"message":   "File does not exist: " + p,
+		},
+	})
+}
+
+func (f *fakeWebHDFS) isDir(p string) bool {
+	for name := range f.files {
+		if strings.HasPrefix(name, strings.TrimSuffix(p, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fakeWebHDFS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	q := r.URL.Query()
+	require.Equal(f.t, "tidb", q.Get("user.name"))
+	op := q.Get("op")
+
+	if strings.HasPrefix(r.URL.Path, fakeDataNodePrefix) {
+		p := strings.TrimPrefix(r.URL.Path, fakeDataNodePrefix)
+		data, err := io.ReadAll(r.Body)
+		require.NoError(f.t, err)
+		switch op {
+		case "CREATE":
+			f.files[p] = data
+			w.WriteHeader(http.StatusCreated)
+		case "APPEND":
+			f.files[p] = append(f.files[p], data...)
+			w.WriteHeader(http.StatusOK)
+		case "OPEN":
+			offset, err := strconv.Atoi(q.Get("offset"))
+			require.NoError(f.t, err)
+			_, _ = w.Write(f.files[p][offset:])
+		}
+		return
+	}
+
+	require.True(f.t, strings.HasPrefix(r.URL.Path, webHDFSPathPrefix))
+	p := strings.TrimPrefix(r.URL.Path, webHDFSPathPrefix)
+	_, isFile := f.files[p]
+	switch op {
+	case "OPEN", "CREATE", "APPEND":
+		if op != "CREATE" && !isFile {
+			f.notFound(w, p)
+			return
+		}
+		require.Equal(f.t, int64(0), r.ContentLength, "the data must be sent to the datanode")
+		http.Redirect(w, r, fakeDataNodePrefix+p+"?"+r.URL.RawQuery, http.StatusTemporaryRedirect)
+	case "GETFILESTATUS":
+		switch {
+		case isFile:
+			f.writeJSON(w, http.StatusOK, map[string]interface{}{
+				"FileStatus": webHDFSFileStatus{Type: "FILE", Length: int64(len(f.files[p]))},
+			})
+		case f.isDir(p):
+			f.writeJSON(w, http.StatusOK, map[string]interface{}{
+				"FileStatus": webHDFSFileStatus{Type: "DIRECTORY"},
+			})
+		default:
+			f.notFound(w, p)
+		}
+	case "LISTSTATUS":
+		if !f.isDir(p) {
+			f.notFound(w, p)
+			return
+		}
+		children := make(map[string]webHDFSFileStatus)
+		prefix := strings.TrimSuffix(p, "/") + "/"
+		for name, data := range f.files {
+			if !strings.HasPrefix(name, prefix) {
+				continue
+			}
+			child := strings.TrimPrefix(name, prefix)
+			if i := strings.Index(child, "/"); i >= 0 {
+				children[child[:i]] = webHDFSFileStatus{PathSuffix: child[:i], Type: "DIRECTORY"}
+			} else {
+				children[child] = webHDFSFileStatus{PathSuffix: child, Type: "FILE", Length: int64(len(data))}
+			}
+		}
+		statuses := make([]webHDFSFileStatus, 0, len(children))
+		for _, status := range children {
+			statuses = append(statuses, status)
+		}
+		sort.Slice(statuses, func(i, j int) bool { return statuses[i].PathSuffix < statuses[j].PathSuffix })
+		f.writeJSON(w, http.StatusOK, map[string]interface{}{
+			"FileStatuses": map[string]interface{}{"FileStatus": statuses},
+		})
+	case "DELETE":
+		delete(f.files, p)
+		f.writeJSON(w, http.StatusOK, map[string]bool{"boolean": isFile})
+	case "MKDIRS":
+		f.writeJSON(w, http.StatusOK, map[string]bool{"boolean": true})
+	case "RENAME":
+		dest := q.Get("destination")
+		_, exists := f.files[dest]
+		if isFile && !exists {
+			f.files[dest] = f.files[p]
+			delete(f.files, p)
+		}
+		f.writeJSON(w, http.StatusOK, map[string]bool{"boolean": isFile && !exists})
+	default:
+		f.writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"RemoteException": map[string]string{
+				"exception": "IllegalArgumentException",
+				"message":   "Invalid value for webhdfs parameter \"op\": " + op,
+			},
+		})
+	}
+}
+
+func newWebHDFSTestStorage(t *testing.T) (*fakeWebHDFS, ExternalStorage) {
+	fake := &fakeWebHDFS{t: t, files: make(map[string][]byte)}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	backend, err := ParseBackend(strings.Replace(server.URL, "http://", "webhdfs://tidb@", 1)+"/backup", nil)
+	require.NoError(t, err)
+	s, err := New(context.Background(), backend, &ExternalStorageOptions{})
+	require.NoError(t, err)
+	require.IsType(t, &WebHDFSStorage{}, s)
+	return fake, s
+}
+
+func TestWebHDFSReadWriteFile(t *testing.T) {
+	ctx := context.Background()
+	fake, s := newWebHDFSTestStorage(t)
+
+	exists, err := s.FileExists(ctx, "a.txt")
+	require.NoError(t, err)
+	require.False(t, exists)
+	_, err = s.ReadFile(ctx, "a.txt")
+	require.Regexp(t, "webhdfs OPEN 'a.txt' failed with status 404: FileNotFoundException", err.Error())
+
+	require.NoError(t, s.WriteFile(ctx, "a.txt", []byte("hello,world!")))
+	require.Equal(t, []byte("hello,world!"), fake.files["/backup/a.txt"])
+	exists, err = s.FileExists(ctx, "a.txt")
+	require.NoError(t, err)
+	require.True(t, exists)
+	data, err := s.ReadFile(ctx, "a.txt")
+	require.NoError(t, err)
+	require.Equal(t, "hello,world!", string(data))
+
+	require.NoError(t, s.Rename(ctx, "a.txt", "dir/b.txt"))
+	require.NoError(t, s.WriteFile(ctx, "a.txt", []byte("another")))
+	// the destination is overwritten.
+	require.NoError(t, s.Rename(ctx, "a.txt", "dir/b.txt"))
+	data, err = s.ReadFile(ctx, "dir/b.txt")
+	require.NoError(t, err)
+	require.Equal(t, "another", string(data))
+	exists, err = s.FileExists(ctx, "a.txt")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	require.NoError(t, s.DeleteFile(ctx, "dir/b.txt"))
+	require.Empty(t, fake.files)
+}
+
+func TestWebHDFSCreateAndOpen(t *testing.T) {
+	ctx := context.Background()
+	fake, s := newWebHDFSTestStorage(t)
+
+	content := bytes.Repeat([]byte("0123456789"), hardcodedS3ChunkSize/4)
+	w, err := s.Create(ctx, "big.txt")
+	require.NoError(t, err)
+	for data := content; len(data) > 0; {
+		n := 100000
+		if n > len(data) {
+			n = len(data)
+		}
+		_, err = w.Write(ctx, data[:n])
+		require.NoError(t, err)
+		data = data[n:]
+	}
+	require.NoError(t, w.Close(ctx))
+	require.Equal(t, content, fake.files["/backup/big.txt"])
+
+	w, err = s.Create(ctx, "empty.txt")
+	require.NoError(t, err)
+	require.NoError(t, w.Close(ctx))
+	require.Equal(t, []byte{}, fake.files["/backup/empty.txt"])
+
+	r, err := s.Open(ctx, "big.txt")
+	require.NoError(t, err)
+	defer r.Close()
+	buf := make([]byte, 100)
+	for _, c := range []struct {
+		offset int64
+		whence int
+		pos    int64
+	}{
+		{offset: 10, whence: io.SeekStart, pos: 10},
+		{offset: 1000, whence: io.SeekCurrent, pos: 1110},
+		{offset: -100, whence: io.SeekEnd, pos: int64(len(content)) - 100},
+		{offset: 5, whence: io.SeekStart, pos: 5},
+	} {
+		pos, err := r.Seek(c.offset, c.whence)
+		require.NoError(t, err)
+		require.Equal(t, c.pos, pos)
+		_, err = io.ReadFull(r, buf)
+		require.NoError(t, err)
+		require.Equal(t, content[pos:pos+100], buf)
+	}
+	_, err = r.Seek(0, io.SeekEnd)
+	require.NoError(t, err)
+	_, err = r.Read(buf)
+	require.Equal(t, io.EOF, err)
+
+	_, err = s.Open(ctx, "not-exist.txt")
+	require.Error(t, err)
+}
+
+func TestWebHDFSWalkDir(t *testing.T) {
+	ctx := context.Background()
+	_, s := newWebHDFSTestStorage(t)
+	files := []string{"a.txt", "dir/b.txt", "dir/sub/c.txt", "dir2/d.txt"}
+	for i, name := range files {
+		require.NoError(t, s.WriteFile(ctx, name, bytes.Repeat([]byte("x"), i)))
+	}
+
+	walk := func(opt *WalkOption) []string {
+		var result []string
+		err := s.WalkDir(ctx, opt, func(path string, size int64) error {
+			result = append(result, fmt.Sprintf("%s:%d", path, size))
+			return nil
+		})
+		require.NoError(t, err)
+		return result
+	}
+	require.Equal(t, []string{"a.txt:0", "dir/b.txt:1", "dir/sub/c.txt:2", "dir2/d.txt:3"}, walk(&WalkOption{}))
+	require.Equal(t, []string{"dir/b.txt:1", "dir/sub/c.txt:2"}, walk(&WalkOption{SubDir: "dir"}))
+	require.Empty(t, walk(&WalkOption{SubDir: "not-exist"}))
+}
+
+func TestWebHDFSURI(t *testing.T) {
+	s, err := ParseBackend("swebhdfs://tidb@namenode:9871/backup", nil)
+	require.NoError(t, err)
+	require.Equal(t, "swebhdfs://tidb@namenode:9871/backup", s.GetHdfs().GetRemote())
+	u := FormatBackendURL(s)
+	require.Equal(t, "swebhdfs://namenode:9871/backup", u.String())
+
+	storage, err := newWebHDFSStorage(s.GetHdfs().GetRemote(), nil)
+	require.NoError(t, err)
+	require.Equal(t, "https://namenode:9871/webhdfs/v1/backup/a.txt?op=OPEN&user.name=tidb", storage.opURL("a.txt", "OPEN", nil))
+
+	err = os.Setenv(webHDFSUserEnv, "hadoop")
+	require.NoError(t, err)
+	defer os.Unsetenv(webHDFSUserEnv)
+	storage, err = newWebHDFSStorage("webhdfs://namenode:9870", nil)
+	require.NoError(t, err)
+	require.Equal(t, "http://namenode:9870/webhdfs/v1/a.txt?op=OPEN&user.name=hadoop", storage.opURL("a.txt", "OPEN", nil))
+}
@@ -6,6 +6,9 @@ import (
 	"context"
 	"io"
 
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
 	"github.com/pingcap/errors"
 )
 
@@ -17,6 +20,12 @@ const (
 	NoCompression CompressType = iota
 	// Gzip will compress given bytes in gzip format.
 	Gzip
+	// Snappy will compress given bytes in snappy framing format.
+	Snappy
+	// Zstd will compress given bytes in zstd format.
+	Zstd
+	// LZ4 will compress given bytes in lz4 frame format.
+	LZ4
 )
 
 type flusher interface {
@@ -39,19 +48,29 @@ type interceptBuffer interface {
 	Compressed() bool
 }
 
-func newInterceptBuffer(chunkSize int, compressType CompressType) interceptBuffer {
+func newInterceptBuffer(chunkSize int, compressType CompressType) (interceptBuffer, error) {
 	if compressType == NoCompression {
-		return newNoCompressionBuffer(chunkSize)
+		return newNoCompressionBuffer(chunkSize), nil
 	}
 	return newSimpleCompressBuffer(chunkSize, compressType)
 }
 
-func newCompressWriter(compressType CompressType, w io.Writer) simpleCompressWriter {
+func newCompressWriter(compressType CompressType, w io.Writer) (simpleCompressWriter, error) {
 	switch compressType {
 	case Gzip:
-		return gzip.NewWriter(w)
+		return gzip.NewWriter(w), nil
+	case Snappy:
+		return snappy.NewBufferedWriter(w), nil
+	case Zstd:
+		zstdWriter, err := zstd.NewWriter(w)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return zstdWriter, nil
+	case LZ4:
+		return lz4.NewWriter(w), nil
 	default:
-		return nil
+		return nil, errors.Errorf("unsupported compress type %d", compressType)
 	}
 }
 
@@ -59,6 +78,16 @@ func newCompressReader(compressType CompressType, r io.Reader) (io.ReadCloser, e
 	switch compressType {
 	case Gzip:
 		return gzip.NewReader(r)
+	case Snappy:
+		return io.NopCloser(snappy.NewReader(r)), nil
+	case Zstd:
+		zstdReader, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return zstdReader.IOReadCloser(), nil
+	case LZ4:
+		return io.NopCloser(lz4.NewReader(r)), nil
 	default:
 		return nil, nil
 	}
@@ -124,13 +153,17 @@ func (b *simpleCompressBuffer) Compressed() bool {
 	return true
 }
 
-func newSimpleCompressBuffer(chunkSize int, compressType CompressType) *simpleCompressBuffer {
+func newSimpleCompressBuffer(chunkSize int, compressType CompressType) (*simpleCompressBuffer, error) {
 	bf := bytes.NewBuffer(make([]byte, 0, chunkSize))
+	compressWriter, err := newCompressWriter(compressType, bf)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	return &simpleCompressBuffer{
 		Buffer:         bf,
 		cap:            chunkSize,
-		compressWriter: newCompressWriter(compressType, bf),
-	}
+		compressWriter: compressWriter,
+	}, nil
 }
 
 type bufferedWriter struct {
@@ -190,16 +223,24 @@ func (u *bufferedWriter) Close(ctx context.Context) error {
 }
 
 // NewUploaderWriter wraps the Writer interface over an uploader.
-func NewUploaderWriter(writer ExternalFileWriter, chunkSize int, compressType CompressType) ExternalFileWriter {
-	return newBufferedWriter(writer, chunkSize, compressType)
+func NewUploaderWriter(writer ExternalFileWriter, chunkSize int, compressType CompressType) (ExternalFileWriter, error) {
+	uploaderWriter, err := newBufferedWriter(writer, chunkSize, compressType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return uploaderWriter, nil
 }
 
 // newBufferedWriter is used to build a buffered writer.
-func newBufferedWriter(writer ExternalFileWriter, chunkSize int, compressType CompressType) *bufferedWriter {
+func newBufferedWriter(writer ExternalFileWriter, chunkSize int, compressType CompressType) (*bufferedWriter, error) {
+	buf, err := newInterceptBuffer(chunkSize, compressType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	return &bufferedWriter{
 		writer: writer,
-		buf:    newInterceptBuffer(chunkSize, compressType),
-	}
+		buf:    buf,
+	}, nil
 }
 
 // BytesWriter is a Writer implementation on top of bytes.Buffer that is useful for testing.
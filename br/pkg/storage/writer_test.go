@@ -102,8 +102,9 @@ func TestCompressReaderWriter(t *testing.T) {
 		ctx := context.Background()
 		storage, err := Create(ctx, backend, true)
 		require.NoError(t, err)
-		storage = WithCompression(storage, Gzip)
-		fileName := strings.ReplaceAll(test.name, " ", "-") + ".txt.gz"
+		storage = WithCompression(storage, test.compressType)
+		suffix := map[CompressType]string{Gzip: ".gz", Snappy: ".snappy", Zstd: ".zst", LZ4: ".lz4"}[test.compressType]
+		fileName := strings.ReplaceAll(test.name, " ", "-") + ".txt" + suffix
 		writer, err := storage.Create(ctx, fileName)
 		require.NoError(t, err)
 		for _, str := range test.content {
@@ -135,7 +136,7 @@ func TestCompressReaderWriter(t *testing.T) {
 
 		require.Nil(t, file.Close())
 	}
-	compressTypeArr := []CompressType{Gzip}
+	compressTypeArr := []CompressType{Gzip, Snappy, Zstd, LZ4}
 	tests := []testcase{
 		{
 			name: "long text medium chunks",
@@ -167,3 +168,19 @@ func TestCompressReaderWriter(t *testing.T) {
 		}
 	}
 }
+
+func TestNewCompressWriterError(t *testing.T) {
+	_, err := newCompressWriter(CompressType(255), &bytes.Buffer{})
+	require.Error(t, err)
+
+	dir := t.TempDir()
+	backend, err := ParseBackend("local://"+filepath.ToSlash(dir), nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+	storage, err := Create(ctx, backend, true)
+	require.NoError(t, err)
+	storage = WithCompression(storage, CompressType(255))
+	_, err = storage.Create(ctx, "unsupported.txt")
+	require.Error(t, err)
+	require.Error(t, storage.WriteFile(ctx, "unsupported.txt", []byte("hello")))
+}
@@ -328,8 +328,12 @@ func RunBackup(c context.Context, g glue.Glue, cmdName string, cfg *BackupConfig
 		return errors.Trace(err)
 	}
 
+	metaStorage, err := GetMetaStorage(ctx, client.GetStorage(), &cfg.Config)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	// Metafile size should be less than 64MB.
-	metawriter := metautil.NewMetaWriter(client.GetStorage(),
+	metawriter := metautil.NewMetaWriter(metaStorage,
 		metautil.MetaFileSize, cfg.UseBackupMetaV2, &cfg.CipherInfo)
 	// Hack way to update backupmeta.
 	metawriter.Update(func(m *backuppb.BackupMeta) {
@@ -373,6 +377,15 @@ func RunBackup(c context.Context, g glue.Glue, cmdName string, cfg *BackupConfig
 		if err = metawriter.FinishWriteMetas(ctx, metautil.AppendDDL); err != nil {
 			return errors.Trace(err)
 		}
+	} else {
+		metawriter.StartWriteMetasAsync(ctx, metautil.AppendDDL)
+		err = backup.WriteBackupRoutines(metawriter, mgr.GetStorage(), cfg.TableFilter, backupTS)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err = metawriter.FinishWriteMetas(ctx, metautil.AppendDDL); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	summary.CollectInt("backup total ranges", len(ranges))
@@ -467,7 +480,7 @@ func RunBackup(c context.Context, g glue.Glue, cmdName string, cfg *BackupConfig
 
 	if !skipChecksum {
 		// Check if checksum from files matches checksum from coprocessor.
-		err = checksum.FastChecksum(ctx, metawriter.Backupmeta(), client.GetStorage(), &cfg.CipherInfo)
+		err = checksum.FastChecksum(ctx, metawriter.Backupmeta(), metaStorage, &cfg.CipherInfo)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -80,6 +80,8 @@ const (
 	flagCipherType    = "crypter.method"
 	flagCipherKey     = "crypter.key"
 	flagCipherKeyFile = "crypter.key-file"
+	// flagMasterKey is the URI of the master key to encrypt the meta files on the client side.
+	flagMasterKey = "master-key"
 
 	unlimited           = 0
 	crypterAES128KeyLen = 16
@@ -168,6 +170,8 @@ type Config struct {
 	GRPCKeepaliveTimeout time.Duration `json:"grpc-keepalive-timeout" toml:"grpc-keepalive-timeout"`
 
 	CipherInfo backuppb.CipherInfo `json:"-" toml:"-"`
+	// MasterKey is the URI of the master key to encrypt and decrypt the meta files on the client side.
+	MasterKey string `json:"master-key" toml:"master-key"`
 }
 
 // DefineCommonFlags defines the flags common to all BRIE commands.
@@ -222,6 +226,9 @@ func DefineCommonFlags(flags *pflag.FlagSet) {
 		"aes-crypter key, used to encrypt/decrypt the data "+
 			"by the hexadecimal string, eg: \"0123456789abcdef0123456789abcdef\"")
 	flags.String(flagCipherKeyFile, "", "FilePath, its content is used as the cipher-key")
+	flags.String(flagMasterKey, "", "the URI of the master key used to encrypt/decrypt the meta files "+
+		"written by BR on the client side, eg: \"/path/to/master.key\", the backup data files written "+
+		"by TiKV are encrypted by the crypter")
 
 	storage.DefineFlags(flags)
 }
@@ -492,6 +499,9 @@ func (cfg *Config) ParseFromFlags(flags *pflag.FlagSet) error {
 	if err = cfg.parseCipherInfo(flags); err != nil {
 		return errors.Trace(err)
 	}
+	if cfg.MasterKey, err = flags.GetString(flagMasterKey); err != nil {
+		return errors.Trace(err)
+	}
 
 	return cfg.normalizePDURLs()
 }
@@ -547,6 +557,23 @@ func GetStorage(
 	return u, s, nil
 }
 
+// GetMetaStorage wraps the storage to encrypt and decrypt the meta files written
+// by BR with the master key, the backup data files written by TiKV aren't affected.
+func GetMetaStorage(
+	ctx context.Context,
+	s storage.ExternalStorage,
+	cfg *Config,
+) (storage.ExternalStorage, error) {
+	if len(cfg.MasterKey) == 0 {
+		return s, nil
+	}
+	masterKey, err := storage.NewMasterKey(ctx, cfg.MasterKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return storage.WithEncryption(s, masterKey), nil
+}
+
 func storageOpts(cfg *Config) *storage.ExternalStorageOptions {
 	return &storage.ExternalStorageOptions{
 		NoCredentials:   cfg.NoCreds,
@@ -554,7 +581,8 @@ func storageOpts(cfg *Config) *storage.ExternalStorageOptions {
 	}
 }
 
-// ReadBackupMeta reads the backupmeta file from the storage.
+// ReadBackupMeta reads the backupmeta file from the storage. The returned storage
+// reads the meta files, see GetMetaStorage.
 func ReadBackupMeta(
 	ctx context.Context,
 	fileName string,
@@ -564,6 +592,9 @@ func ReadBackupMeta(
 	if err != nil {
 		return nil, nil, nil, errors.Trace(err)
 	}
+	if s, err = GetMetaStorage(ctx, s, cfg); err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
 	metaData, err := s.ReadFile(ctx, fileName)
 	if err != nil {
 		if gcsObjectNotFound(err) {
@@ -576,6 +607,9 @@ func ReadBackupMeta(
 			if err != nil {
 				return nil, nil, nil, errors.Trace(err)
 			}
+			if s, err = GetMetaStorage(ctx, s, cfg); err != nil {
+				return nil, nil, nil, errors.Trace(err)
+			}
 			log.Info("retry load metadata in gcs", zap.String("newPrefix", newPrefix), zap.String("newFileName", newFileName))
 			metaData, err = s.ReadFile(ctx, newFileName)
 			if err != nil {
@@ -3,12 +3,17 @@
 package task
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/gogo/protobuf/proto"
 	backup "github.com/pingcap/kvproto/pkg/brpb"
 	"github.com/pingcap/kvproto/pkg/encryptionpb"
+	"github.com/pingcap/tidb/br/pkg/metautil"
 	"github.com/pingcap/tidb/config"
 	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/require"
@@ -157,3 +162,40 @@ func TestCheckCipherKey(t *testing.T) {
 		}
 	}
 }
+
+func TestMasterKeyEncryptMetaFiles(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "master.key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(hex.EncodeToString(make([]byte, 32))), 0o600))
+	backupDir := filepath.Join(dir, "backup")
+	require.NoError(t, os.Mkdir(backupDir, 0o755))
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	DefineCommonFlags(flags)
+	require.NoError(t, flags.Parse([]string{"--storage", "local://" + filepath.ToSlash(backupDir), "--master-key", keyFile}))
+	var cfg Config
+	require.NoError(t, cfg.ParseFromFlags(flags))
+	require.Equal(t, keyFile, cfg.MasterKey)
+
+	// the backupmeta is encrypted by the master key.
+	_, s, err := GetStorage(ctx, &cfg)
+	require.NoError(t, err)
+	metaStorage, err := GetMetaStorage(ctx, s, &cfg)
+	require.NoError(t, err)
+	metaData, err := proto.Marshal(&backup.BackupMeta{ClusterId: 42})
+	require.NoError(t, err)
+	require.NoError(t, metaStorage.WriteFile(ctx, metautil.MetaFile, metaData))
+	raw, err := s.ReadFile(ctx, metautil.MetaFile)
+	require.NoError(t, err)
+	require.NotEqual(t, metaData, raw)
+
+	_, _, backupMeta, err := ReadBackupMeta(ctx, metautil.MetaFile, &cfg)
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), backupMeta.ClusterId)
+
+	// the backupmeta can't be read without the master key.
+	cfg.MasterKey = ""
+	_, _, _, err = ReadBackupMeta(ctx, metautil.MetaFile, &cfg)
+	require.Error(t, err)
+}
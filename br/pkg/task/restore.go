@@ -364,9 +364,11 @@ func RunRestore(c context.Context, g glue.Glue, cmdName string, cfg *RestoreConf
 	defer restoreDBConfig()
 
 	// execute DDL first
-	err = client.ExecDDLs(ctx, ddlJobs)
-	if err != nil {
-		return errors.Trace(err)
+	if client.IsIncremental() {
+		err = client.ExecDDLs(ctx, ddlJobs)
+		if err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	// nothing to restore, maybe only ddl changes in incremental restore
@@ -384,6 +386,15 @@ func RunRestore(c context.Context, g glue.Glue, cmdName string, cfg *RestoreConf
 		}
 	}
 
+	// The ddl jobs of a full backup are the stored routines of the databases,
+	// they are created after the databases.
+	if !client.IsIncremental() && !client.IsSkipCreateSQL() {
+		err = client.ExecDDLs(ctx, ddlJobs)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	// We make bigger errCh so we won't block on multi-part failed.
 	errCh := make(chan error, 32)
 	// Maybe allow user modify the DDL concurrency isn't necessary,
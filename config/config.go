@@ -188,6 +188,8 @@ type Config struct {
 	// EnableTCP4Only enables net.Listen("tcp4",...)
 	// Note that: it can make lvs with toa work and thus tidb can get real client ip.
 	EnableTCP4Only bool `toml:"enable-tcp4-only" json:"enable-tcp4-only"`
+	// EnableProtocolCompression enables the compressed protocol with zlib and zstd for the clients that request it.
+	EnableProtocolCompression bool `toml:"enable-protocol-compression" json:"enable-protocol-compression"`
 	// The client will forward the requests through the follower
 	// if one of the following conditions happens:
 	// 1. there is a network partition problem between TiDB and PD leader.
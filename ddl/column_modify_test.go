@@ -410,7 +410,7 @@ func TestRenameColumn(t *testing.T) {
 
 	// Test renaming the column with foreign key.
 	tk.MustExec("drop table test_rename_column")
-	tk.MustExec("create table test_rename_column_base (base int)")
+	tk.MustExec("create table test_rename_column_base (base int, key(base))")
 	tk.MustExec("create table test_rename_column (col int, foreign key (col) references test_rename_column_base(base))")
 
 	tk.MustGetErrCode("alter table test_rename_column rename column col to col1", errno.ErrFKIncompatibleColumns)
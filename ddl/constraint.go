@@ -0,0 +1,601 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/dbterror"
+	"github.com/pingcap/tidb/util/sqlexec"
+)
+
+func allocateConstraintID(tblInfo *model.TableInfo) int64 {
+	tblInfo.MaxConstraintID++
+	return tblInfo.MaxConstraintID
+}
+
+// setNameForConstraintInfo sets the name of the unnamed check constraints in the form of `<table>_chk_<n>`.
+func setNameForConstraintInfo(tableLowerName string, constraints []*ast.Constraint, existNames map[string]struct{}) {
+	cnt := 1
+	for _, constr := range constraints {
+		if constr.Tp != ast.ConstraintCheck || constr.Name != "" {
+			continue
+		}
+		for {
+			name := fmt.Sprintf("%s_chk_%d", tableLowerName, cnt)
+			cnt++
+			if _, ok := existNames[name]; !ok {
+				constr.Name = name
+				existNames[name] = struct{}{}
+				break
+			}
+		}
+	}
+}
+
+// checkConstraintNamesNotExists checks whether the names of the check constraints are unique.
+func checkConstraintNamesNotExists(constraints []*ast.Constraint, existNames map[string]struct{}) error {
+	for _, constr := range constraints {
+		if constr.Tp != ast.ConstraintCheck || constr.Name == "" {
+			continue
+		}
+		name := strings.ToLower(constr.Name)
+		if _, ok := existNames[name]; ok {
+			return dbterror.ErrCheckConstraintDupName.GenWithStackByArgs(constr.Name)
+		}
+		existNames[name] = struct{}{}
+	}
+	return nil
+}
+
+// buildConstraintInfo validates the check constraint expression and builds the constraint info from it.
+// The column-level check constraint can only refer to the column it's defined on.
+func buildConstraintInfo(ctx sessionctx.Context, tblInfo *model.TableInfo, constr *ast.Constraint, state model.SchemaState) (*model.ConstraintInfo, error) {
+	if err := checkIllegalFn4Generated(constr.Name, typeCheckConstraint, constr.Expr); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	dependedCols := make([]model.CIStr, 0, 1)
+	dependedColsMap := make(map[string]struct{})
+	for _, colName := range findColumnNamesInExpr(constr.Expr) {
+		if _, ok := dependedColsMap[colName.Name.L]; ok {
+			continue
+		}
+		dependedColsMap[colName.Name.L] = struct{}{}
+		if constr.InColumn && colName.Name.L != strings.ToLower(constr.InColumnName) {
+			return nil, dbterror.ErrColumnCheckConstraintReferencesOtherColumn.GenWithStackByArgs(constr.Name)
+		}
+		col := model.FindColumnInfo(tblInfo.Columns, colName.Name.L)
+		if col == nil {
+			return nil, dbterror.ErrTableCheckConstraintReferUnknown.GenWithStackByArgs(constr.Name, colName.Name.O)
+		}
+		if mysql.HasAutoIncrementFlag(col.Flag) {
+			return nil, dbterror.ErrCheckConstraintRefersAutoIncrementColumn.GenWithStackByArgs(constr.Name)
+		}
+		dependedCols = append(dependedCols, col.Name)
+	}
+	// Make sure the expression can be built on the table.
+	if _, err := expression.RewriteSimpleExprWithTableInfo(ctx, tblInfo, constr.Expr); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var sb strings.Builder
+	restoreFlags := format.RestoreStringSingleQuotes | format.RestoreKeyWordLowercase | format.RestoreNameBackQuotes |
+		format.RestoreSpacesAroundBinaryOperation
+	restoreCtx := format.NewRestoreCtx(restoreFlags, &sb)
+	if err := constr.Expr.Restore(restoreCtx); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return &model.ConstraintInfo{
+		Name:           model.NewCIStr(constr.Name),
+		Table:          tblInfo.Name,
+		ConstraintCols: dependedCols,
+		Enforced:       constr.Enforced,
+		InColumn:       constr.InColumn,
+		ExprString:     sb.String(),
+		State:          state,
+	}, nil
+}
+
+// existConstraintNames returns the names of the existing check constraints of the table,
+// and the ones added by the previous sub-jobs of the statement.
+func existConstraintNames(ctx sessionctx.Context, tblInfo *model.TableInfo) map[string]struct{} {
+	existNames := make(map[string]struct{}, len(tblInfo.Constraints))
+	for _, c := range tblInfo.Constraints {
+		existNames[c.Name.L] = struct{}{}
+	}
+	info := ctx.GetSessionVars().StmtCtx.MultiSchemaInfo
+	if info == nil {
+		return existNames
+	}
+	for _, sub := range info.SubJobs {
+		switch sub.Type {
+		case model.ActionAddColumn:
+			if len(sub.Args) < 4 {
+				continue
+			}
+			for _, c := range sub.Args[3].([]*model.ConstraintInfo) {
+				existNames[c.Name.L] = struct{}{}
+			}
+		case model.ActionAddCheckConstraint:
+			existNames[sub.Args[0].(*model.ConstraintInfo).Name.L] = struct{}{}
+		}
+	}
+	return existNames
+}
+
+// buildConstraintInfosForNewColumn builds the column-level check constraints of the column being added.
+// The unnamed ones are named after the existing constraints and the ones added by the previous sub-jobs of the statement.
+func buildConstraintInfosForNewColumn(ctx sessionctx.Context, tblInfo *model.TableInfo, col *table.Column, constraints []*ast.Constraint) ([]*model.ConstraintInfo, error) {
+	checks := make([]*ast.Constraint, 0, len(constraints))
+	for _, constr := range constraints {
+		if constr.Tp == ast.ConstraintCheck {
+			checks = append(checks, constr)
+		}
+	}
+	if len(checks) == 0 {
+		return nil, nil
+	}
+
+	existNames := existConstraintNames(ctx, tblInfo)
+	if err := checkConstraintNamesNotExists(checks, existNames); err != nil {
+		return nil, errors.Trace(err)
+	}
+	setNameForConstraintInfo(tblInfo.Name.L, checks, existNames)
+
+	// Build the constraints on the table with the new column, which can only be referred by them.
+	newTblInfo := tblInfo.Clone()
+	colInfo := col.ToInfo().Clone()
+	colInfo.State = model.StatePublic
+	colInfo.Offset = len(newTblInfo.Columns)
+	newTblInfo.Columns = append(newTblInfo.Columns, colInfo)
+	constraintInfos := make([]*model.ConstraintInfo, 0, len(checks))
+	for _, constr := range checks {
+		constraintInfo, err := buildConstraintInfo(ctx, newTblInfo, constr, model.StateNone)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		constraintInfos = append(constraintInfos, constraintInfo)
+	}
+	return constraintInfos, nil
+}
+
+// hasDependentByCheckConstraint checks whether there is a check constraint depending on the column.
+func hasDependentByCheckConstraint(tblInfo *model.TableInfo, colName model.CIStr) (bool, string) {
+	for _, constr := range tblInfo.Constraints {
+		for _, col := range constr.ConstraintCols {
+			if col.L == colName.L {
+				return true, constr.Name.O
+			}
+		}
+	}
+	return false, ""
+}
+
+// checkDropColumnWithCheckConstraint checks whether the column can be dropped with the check constraints depending on it.
+// Like MySQL, the constraint only referring to the column is dropped with it, while the one also referring to other
+// columns prevents the column from being dropped.
+func checkDropColumnWithCheckConstraint(tblInfo *model.TableInfo, colName model.CIStr) error {
+	for _, constr := range tblInfo.Constraints {
+		if !constraintRefersColumn(constr, colName) {
+			continue
+		}
+		for _, col := range constr.ConstraintCols {
+			if col.L != colName.L {
+				return dbterror.ErrDependentByCheckConstraint.GenWithStackByArgs(constr.Name.O, colName.O)
+			}
+		}
+	}
+	return nil
+}
+
+// removeDependentCheckConstraints removes the check constraints which only refer to the dropped column.
+func removeDependentCheckConstraints(tblInfo *model.TableInfo, colName model.CIStr) {
+	constraints := tblInfo.Constraints[:0]
+	for _, constr := range tblInfo.Constraints {
+		if !constraintRefersColumn(constr, colName) {
+			constraints = append(constraints, constr)
+		}
+	}
+	tblInfo.Constraints = constraints
+}
+
+func constraintRefersColumn(constr *model.ConstraintInfo, colName model.CIStr) bool {
+	for _, col := range constr.ConstraintCols {
+		if col.L == colName.L {
+			return true
+		}
+	}
+	return false
+}
+
+func removeConstraintInfo(tblInfo *model.TableInfo, constrName model.CIStr) {
+	constraints := tblInfo.Constraints[:0]
+	for _, constr := range tblInfo.Constraints {
+		if constr.Name.L != constrName.L {
+			constraints = append(constraints, constr)
+		}
+	}
+	tblInfo.Constraints = constraints
+}
+
+func (w *worker) onAddCheckConstraint(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	schemaID := job.SchemaID
+	dbInfo, err := checkSchemaExistAndCancelNotExistJob(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, schemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	var constraintInfoInJob model.ConstraintInfo
+	err = job.DecodeArgs(&constraintInfoInJob)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	constraintInfo := tblInfo.FindConstraintInfoByName(constraintInfoInJob.Name.L)
+	if constraintInfo != nil && constraintInfo.State == model.StatePublic {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrCheckConstraintDupName.GenWithStackByArgs(constraintInfo.Name.O)
+	}
+	if constraintInfo == nil {
+		constraintInfo = &constraintInfoInJob
+		constraintInfo.ID = allocateConstraintID(tblInfo)
+		tblInfo.Constraints = append(tblInfo.Constraints, constraintInfo)
+	}
+
+	originalState := constraintInfo.State
+	switch constraintInfo.State {
+	case model.StateNone:
+		// none -> write only
+		// Make sure all the servers check the constraint on writing before validating the existing rows.
+		job.SchemaState = model.StateWriteOnly
+		constraintInfo.State = model.StateWriteOnly
+		ver, err = updateVersionAndTableInfoWithCheck(t, job, tblInfo, originalState != constraintInfo.State)
+	case model.StateWriteOnly:
+		// write only -> public
+		// The existing rows have been validated in the revertible phase of the multi-schema change.
+		inNonRevertible := job.MultiSchemaInfo != nil && !job.MultiSchemaInfo.Revertible
+		if constraintInfo.Enforced && !inNonRevertible {
+			err = w.verifyRemainRecordsForCheckConstraint(dbInfo.Name, tblInfo, constraintInfo)
+			if err != nil {
+				if table.ErrCheckConstraintViolated.Equal(err) {
+					return rollbackAddCheckConstraint(t, job, tblInfo, constraintInfo.Name, err)
+				}
+				return ver, errors.Trace(err)
+			}
+		}
+		if job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible {
+			// The constraint becomes public together with the other sub-jobs in the non-revertible phase.
+			job.MarkNonRevertible()
+			return ver, nil
+		}
+		constraintInfo.State = model.StatePublic
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != constraintInfo.State)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		// Finish this job.
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	default:
+		err = dbterror.ErrInvalidDDLState.GenWithStackByArgs("constraint", constraintInfo.State)
+	}
+	return ver, errors.Trace(err)
+}
+
+// rollbackAddCheckConstraint removes the check constraint being added and finishes the job as rolled back.
+func rollbackAddCheckConstraint(t *meta.Meta, job *model.Job, tblInfo *model.TableInfo, constrName model.CIStr, occurredErr error) (ver int64, err error) {
+	removeConstraintInfo(tblInfo, constrName)
+	job.SchemaState = model.StateNone
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateRollbackDone, model.StateNone, ver, tblInfo)
+	return ver, errors.Trace(occurredErr)
+}
+
+func rollingbackAddCheckConstraint(t *meta.Meta, job *model.Job) (ver int64, err error) {
+	if job.SchemaState == model.StateNone {
+		return cancelOnlyNotHandledJob(job)
+	}
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	var constraintInfo model.ConstraintInfo
+	if err = job.DecodeArgs(&constraintInfo); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	return rollbackAddCheckConstraint(t, job, tblInfo, constraintInfo.Name, dbterror.ErrCancelledDDLJob)
+}
+
+func onDropCheckConstraint(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	tblInfo, constraintInfo, err := checkDropCheckConstraint(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	if job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible {
+		// The constraint is dropped together with the other sub-jobs in the non-revertible phase.
+		job.MarkNonRevertible()
+		return ver, nil
+	}
+
+	switch constraintInfo.State {
+	case model.StatePublic:
+		// Removing a constraint only relaxes the restriction on writing, so we can do it in one step.
+		// public -> none
+		removeConstraintInfo(tblInfo, constraintInfo.Name)
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		// Finish this job.
+		job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
+		return ver, nil
+	default:
+		return ver, dbterror.ErrInvalidDDLState.GenWithStackByArgs("constraint", constraintInfo.State)
+	}
+}
+
+func checkDropCheckConstraint(t *meta.Meta, job *model.Job) (*model.TableInfo, *model.ConstraintInfo, error) {
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	var constrName model.CIStr
+	err = job.DecodeArgs(&constrName)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return nil, nil, errors.Trace(err)
+	}
+
+	constraintInfo := tblInfo.FindConstraintInfoByName(constrName.L)
+	if constraintInfo == nil {
+		job.State = model.JobStateCancelled
+		return nil, nil, dbterror.ErrCheckConstraintNotFound.GenWithStackByArgs(constrName.O)
+	}
+	return tblInfo, constraintInfo, nil
+}
+
+func (w *worker) onAlterCheckConstraint(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	dbInfo, err := checkSchemaExistAndCancelNotExistJob(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	var (
+		constrName model.CIStr
+		enforced   bool
+	)
+	err = job.DecodeArgs(&constrName, &enforced)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	constraintInfo := tblInfo.FindConstraintInfoByName(constrName.L)
+	if constraintInfo == nil {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrCheckConstraintNotFound.GenWithStackByArgs(constrName.O)
+	}
+
+	if !enforced || constraintInfo.State == model.StatePublic && constraintInfo.Enforced {
+		// Relaxing the constraint or altering nothing can be done in one step.
+		if job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible {
+			job.MarkNonRevertible()
+			return ver, nil
+		}
+		constraintInfo.Enforced = enforced
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+		return ver, nil
+	}
+
+	switch constraintInfo.State {
+	case model.StatePublic:
+		// Enforce the constraint on writing before validating the existing rows.
+		// public(not enforced) -> write only(enforced)
+		job.SchemaState = model.StateWriteOnly
+		constraintInfo.Enforced = true
+		constraintInfo.State = model.StateWriteOnly
+		ver, err = updateVersionAndTableInfoWithCheck(t, job, tblInfo, true)
+	case model.StateWriteOnly:
+		// write only(enforced) -> public(enforced)
+		// The existing rows have been validated in the revertible phase of the multi-schema change.
+		if job.MultiSchemaInfo == nil || job.MultiSchemaInfo.Revertible {
+			err = w.verifyRemainRecordsForCheckConstraint(dbInfo.Name, tblInfo, constraintInfo)
+			if err != nil {
+				if !table.ErrCheckConstraintViolated.Equal(err) {
+					return ver, errors.Trace(err)
+				}
+				return rollbackAlterCheckConstraint(t, job, tblInfo, constraintInfo, err)
+			}
+		}
+		if job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible {
+			// The constraint becomes public together with the other sub-jobs in the non-revertible phase.
+			job.MarkNonRevertible()
+			return ver, nil
+		}
+		constraintInfo.State = model.StatePublic
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	default:
+		err = dbterror.ErrInvalidDDLState.GenWithStackByArgs("constraint", constraintInfo.State)
+	}
+	return ver, errors.Trace(err)
+}
+
+// rollbackAlterCheckConstraint makes the constraint being enforced not enforced again and finishes the job as rolled back.
+func rollbackAlterCheckConstraint(t *meta.Meta, job *model.Job, tblInfo *model.TableInfo, constraintInfo *model.ConstraintInfo, occurredErr error) (ver int64, err error) {
+	constraintInfo.Enforced = false
+	constraintInfo.State = model.StatePublic
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateRollbackDone, model.StatePublic, ver, tblInfo)
+	return ver, errors.Trace(occurredErr)
+}
+
+func rollingbackAlterCheckConstraint(t *meta.Meta, job *model.Job) (ver int64, err error) {
+	if job.SchemaState == model.StateNone {
+		return cancelOnlyNotHandledJob(job)
+	}
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	var constrName model.CIStr
+	if err = job.DecodeArgs(&constrName); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	constraintInfo := tblInfo.FindConstraintInfoByName(constrName.L)
+	if constraintInfo == nil {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrCheckConstraintNotFound.GenWithStackByArgs(constrName.O)
+	}
+	return rollbackAlterCheckConstraint(t, job, tblInfo, constraintInfo, dbterror.ErrCancelledDDLJob)
+}
+
+// verifyRemainRecordsForCheckConstraint checks whether the existing rows of the table satisfy the check constraint.
+func (w *worker) verifyRemainRecordsForCheckConstraint(schemaName model.CIStr, tblInfo *model.TableInfo, constr *model.ConstraintInfo) error {
+	var ctx sessionctx.Context
+	ctx, err := w.sessPool.get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer w.sessPool.put(ctx)
+
+	// The constraint expression may contain the identifier, which couldn't be escaped in our ParseWithParams(...)
+	// So we write it to the origin sql string here.
+	sql := "select 1 from %n.%n where not (" + constr.ExprString + ") limit 1"
+	rows, _, err := ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(w.ddlJobCtx, nil, sql, schemaName.L, tblInfo.Name.L)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(rows) != 0 {
+		return table.ErrCheckConstraintViolated.GenWithStackByArgs(constr.Name.O)
+	}
+	return nil
+}
+
+// checkConstraintsOfNewColumn checks whether the check constraints of the column being added can be added to the table.
+func (w *worker) checkConstraintsOfNewColumn(t *meta.Meta, job *model.Job, tblInfo *model.TableInfo, colInfo *model.ColumnInfo, constraints []*model.ConstraintInfo) error {
+	for _, constr := range constraints {
+		// The constraint with the same name may be added after the job is submitted.
+		if tblInfo.FindConstraintInfoByName(constr.Name.L) != nil {
+			return dbterror.ErrCheckConstraintDupName.GenWithStackByArgs(constr.Name.O)
+		}
+	}
+	dbInfo, err := t.GetDatabase(job.SchemaID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return w.verifyRemainRecordsForNewColumn(dbInfo.Name, tblInfo, colInfo, constraints)
+}
+
+// verifyRemainRecordsForNewColumn checks whether the existing rows of the table satisfy the check constraints of the column being added.
+// The column isn't public yet, so its value in the existing rows is the origin default value or the generated expression.
+func (w *worker) verifyRemainRecordsForNewColumn(schemaName model.CIStr, tblInfo *model.TableInfo, colInfo *model.ColumnInfo, constraints []*model.ConstraintInfo) error {
+	var ctx sessionctx.Context
+	ctx, err := w.sessPool.get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer w.sessPool.put(ctx)
+
+	for _, constr := range constraints {
+		if !constr.Enforced {
+			continue
+		}
+		var (
+			sql  string
+			args []interface{}
+		)
+		if colInfo.IsGenerated() {
+			// The generated expression and the constraint expression may contain the identifiers,
+			// which couldn't be escaped in our ParseWithParams(...), so we write them to the origin sql string here.
+			sql = "select 1 from (select " + colInfo.GeneratedExprString + " as %n from %n.%n) as t where not (" + constr.ExprString + ") limit 1"
+			args = []interface{}{colInfo.Name.L, schemaName.L, tblInfo.Name.L}
+		} else {
+			err := checkOriginDefaultValue(ctx, tblInfo, colInfo, constr)
+			if !table.ErrCheckConstraintViolated.Equal(err) {
+				if err != nil {
+					return errors.Trace(err)
+				}
+				continue
+			}
+			// The origin default value violates the constraint, so the constraint can only be added to an empty table.
+			sql = "select 1 from %n.%n limit 1"
+			args = []interface{}{schemaName.L, tblInfo.Name.L}
+		}
+		rows, _, err := ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(w.ddlJobCtx, nil, sql, args...)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(rows) != 0 {
+			return table.ErrCheckConstraintViolated.GenWithStackByArgs(constr.Name.O)
+		}
+	}
+	return nil
+}
+
+// checkOriginDefaultValue checks whether the origin default value of the column satisfies the check constraint.
+func checkOriginDefaultValue(ctx sessionctx.Context, tblInfo *model.TableInfo, colInfo *model.ColumnInfo, constr *model.ConstraintInfo) error {
+	defVal, err := table.GetColOriginDefaultValue(ctx, colInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The column-level constraint only refers to its own column, so it's evaluated on the column alone.
+	col := colInfo.Clone()
+	col.State = model.StatePublic
+	col.Offset = 0
+	colTblInfo := &model.TableInfo{Name: tblInfo.Name, Columns: []*model.ColumnInfo{col}}
+	expr, err := expression.ParseSimpleExprWithTableInfo(ctx, constr.ExprString, colTblInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return table.CheckRowConstraint(ctx, []*table.Constraint{{ConstraintInfo: constr, ConstraintExpr: expr}}, []types.Datum{defVal})
+}
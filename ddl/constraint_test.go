@@ -0,0 +1,158 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/testkit"
+)
+
+func TestCreateTableWithCheckConstraints(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int check (a > 0), b int, constraint c_ab check (a < b) not enforced, check (b < 100))")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` int(11) DEFAULT NULL,\n" +
+		"  CONSTRAINT `t_chk_1` CHECK ((`a` > 0)),\n" +
+		"  CONSTRAINT `c_ab` CHECK ((`a` < `b`)) /*!80016 NOT ENFORCED */,\n" +
+		"  CONSTRAINT `t_chk_2` CHECK ((`b` < 100))\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustQuery("select * from information_schema.check_constraints where constraint_schema = 'test' order by constraint_name").Check(testkit.Rows(
+		"def test c_ab (`a` < `b`)",
+		"def test t_chk_1 (`a` > 0)",
+		"def test t_chk_2 (`b` < 100)",
+	))
+
+	tk.MustExec("drop table t")
+	tk.MustGetErrCode("create table t (a int, constraint c check (a > 0), constraint C check (a < 10))", errno.ErrCheckConstraintDupName)
+	tk.MustGetErrCode("create table t (a int check (b > 0), b int)", errno.ErrColumnCheckConstraintReferencesOtherColumn)
+	tk.MustGetErrCode("create table t (a int, check (c > 0))", errno.ErrTableCheckConstraintReferUnknown)
+	tk.MustGetErrCode("create table t (a int auto_increment primary key, check (a > 0))", errno.ErrCheckConstraintRefersAutoIncrementColumn)
+	tk.MustGetErrCode("create table t (a int, check (a > rand()))", errno.ErrCheckConstraintFunctionIsNotAllowed)
+	tk.MustGetErrCode("create table t (a int, check (a > (select 1)))", errno.ErrCheckConstraintFunctionIsNotAllowed)
+	tk.MustGetErrCode("create table t (a int, check (a > @v))", errno.ErrCheckConstraintVariables)
+}
+
+func TestCheckConstraintOnWriting(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (id int primary key, a int, constraint c check (a > 0), constraint d check (a < 10) not enforced)")
+
+	// NULL doesn't violate the constraint.
+	tk.MustExec("insert into t values (1, 1), (2, null), (3, 20)")
+	tk.MustGetErrMsg("insert into t values (4, 0)", "[table:3819]Check constraint 'c' is violated.")
+	tk.MustGetErrCode("replace into t values (1, -1)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("update t set a = a - 1 where id = 1", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("insert into t values (1, 1) on duplicate key update a = 0", errno.ErrCheckConstraintViolated)
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 1", "2 <nil>", "3 20"))
+
+	// The violated rows are skipped with IGNORE.
+	tk.MustExec("insert ignore into t values (4, 0), (5, 5)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 3819 Check constraint 'c' is violated."))
+	tk.MustExec("update ignore t set a = a - 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 3819 Check constraint 'c' is violated."))
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 1", "2 <nil>", "3 19", "5 4"))
+
+	// The constraint is not checked after it's dropped.
+	tk.MustExec("alter table t drop check c")
+	tk.MustExec("insert into t values (4, 0)")
+	tk.MustQuery("select * from t where id = 4").Check(testkit.Rows("4 0"))
+}
+
+func TestCheckConstraintDependentColumn(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int, b int, c int, constraint c_ab check (a < b))")
+	tk.MustGetErrMsg("alter table t drop column a", "[ddl:3959]Check constraint 'c_ab' uses column 'a', hence column cannot be dropped or renamed.")
+	tk.MustGetErrCode("alter table t rename column b to d", errno.ErrDependentByCheckConstraint)
+	tk.MustGetErrCode("alter table t change column b d int", errno.ErrDependentByCheckConstraint)
+	tk.MustExec("alter table t drop column c")
+	tk.MustExec("alter table t modify column b bigint")
+	tk.MustExec("alter table t drop check c_ab")
+	tk.MustExec("alter table t drop column a")
+
+	// The constraints only referring to the dropped column are dropped with it.
+	tk.MustExec("drop table t")
+	tk.MustExec("create table t (a int check (a > 0), b int, c int, constraint c_b check (b < 10), constraint c_bc check (b < c))")
+	tk.MustExec("alter table t drop column a")
+	tk.MustGetErrMsg("alter table t drop column b", "[ddl:3959]Check constraint 'c_bc' uses column 'b', hence column cannot be dropped or renamed.")
+	tk.MustQuery("select constraint_name from information_schema.check_constraints where constraint_schema = 'test' order by constraint_name").Check(testkit.Rows("c_b", "c_bc"))
+	tk.MustExec("alter table t drop check c_bc")
+	tk.MustExec("alter table t drop column b")
+	tk.MustQuery("select constraint_name from information_schema.check_constraints where constraint_schema = 'test'").Check(testkit.Rows())
+	tk.MustExec("insert into t values (0)")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `c` int(11) DEFAULT NULL\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+}
+
+func TestAddColumnWithCheckConstraint(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (id int primary key, a int check (a > 0))")
+	tk.MustExec("insert into t values (1, 1)")
+
+	// The column-level constraints are numbered in the statement order after the existing ones.
+	tk.MustExec("alter table t add column b int default 1 check (b > 0) check (b < 10)")
+	tk.MustQuery("show warnings").Check(testkit.Rows())
+	tk.MustQuery("select * from information_schema.check_constraints where constraint_schema = 'test' order by constraint_name").Check(testkit.Rows(
+		"def test t_chk_1 (`a` > 0)",
+		"def test t_chk_2 (`b` > 0)",
+		"def test t_chk_3 (`b` < 10)",
+	))
+	tk.MustGetErrCode("insert into t values (2, 1, 10)", errno.ErrCheckConstraintViolated)
+	tk.MustExec("insert into t values (2, 1, 9)")
+
+	// The existing rows are validated with the default value or the generated expression of the new column.
+	tk.MustGetErrMsg("alter table t add column c int default 0 check (c > 0)", "[table:3819]Check constraint 't_chk_4' is violated.")
+	tk.MustGetErrCode("alter table t add column c int as (b - 5) check (c > 0)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("alter table t add column c int check (c > 0) check (b > 0)", errno.ErrColumnCheckConstraintReferencesOtherColumn)
+	tk.MustGetErrCode("alter table t add column c int constraint t_chk_2 check (c > 0)", errno.ErrCheckConstraintDupName)
+	tk.MustQuery("select count(*) from information_schema.columns where table_schema = 'test' and table_name = 't' and column_name = 'c'").Check(testkit.Rows("0"))
+	tk.MustExec("alter table t add column c int as (b + 5) check (c > 5)")
+	tk.MustExec("alter table t add column d int check (d > 0)")
+	tk.MustGetErrCode("insert into t (id, a, b, d) values (3, 1, 1, 0)", errno.ErrCheckConstraintViolated)
+
+	// The constraints of the columns added together are named in the statement order.
+	tk.MustExec("drop table t")
+	tk.MustExec("create table t (id int primary key)")
+	tk.MustExec("insert into t values (1)")
+	tk.MustExec("alter table t add column (a int check (a > 0), b int), add column c int default 1 check (c < 10)")
+	tk.MustQuery("select * from information_schema.check_constraints where constraint_schema = 'test' order by constraint_name").Check(testkit.Rows(
+		"def test t_chk_1 (`a` > 0)",
+		"def test t_chk_2 (`c` < 10)",
+	))
+	tk.MustGetErrCode("alter table t add column (d int default 0 check (d > 0), e int)", errno.ErrCheckConstraintViolated)
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 <nil> <nil> 1"))
+}
@@ -67,3 +67,35 @@ func TestTemporaryTableForeignKey(t *testing.T) {
 	tk.MustGetErrCode(failSQL, mysql.ErrCannotAddForeign)
 	tk.MustExec("drop table if exists t1,t2,t3, t4,t1_tmp,t2_tmp;")
 }
+
+func TestForeignKeyIndex(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@foreign_key_checks = 1")
+	tk.MustExec("create table parent (id int primary key, a int, b int, key idx_ab (a, b))")
+
+	// The index is created for the foreign key if there is no index on the foreign key columns.
+	tk.MustExec("create table child (id int, pid int, pa int, key idx_pa (pa, id), " +
+		"constraint fk_1 foreign key (pid) references parent (id), constraint fk_2 foreign key (pa) references parent (a))")
+	tk.MustQuery("select key_name, column_name from information_schema.tidb_indexes where table_name = 'child' order by key_name, seq_in_index").Check(
+		testkit.Rows("fk_1 pid", "idx_pa pa", "idx_pa id"))
+	tk.MustExec("alter table child add column pb int")
+	tk.MustGetErrMsg("alter table child add constraint fk_3 foreign key (pb) references parent (b)",
+		"[ddl:1822]Failed to add the foreign key constaint. Missing index for constraint 'fk_3' in the referenced table 'parent'")
+	tk.MustGetErrCode("create table child2 (id int, pb int, constraint fk_3 foreign key (pb) references parent (b))", errno.ErrFkNoIndexParent)
+	// The referenced table isn't checked if foreign_key_checks is off.
+	tk.MustExec("set @@foreign_key_checks = 0")
+	tk.MustExec("alter table child add constraint fk_3 foreign key (pb) references parent (b)")
+	tk.MustQuery("select key_name, column_name from information_schema.tidb_indexes where table_name = 'child' order by key_name, seq_in_index").Check(
+		testkit.Rows("fk_1 pid", "fk_3 pb", "idx_pa pa", "idx_pa id"))
+
+	// The indexes needed by the foreign keys can't be dropped.
+	tk.MustGetErrMsg("alter table child drop index fk_1", "[ddl:1553]Cannot drop index 'fk_1': needed in a foreign key constraint")
+	tk.MustGetErrCode("alter table parent drop index idx_ab", errno.ErrDropIndexFk)
+	tk.MustExec("alter table child add index idx_pid (pid)")
+	tk.MustExec("alter table child drop index fk_1")
+	tk.MustExec("alter table child drop foreign key fk_2")
+	tk.MustExec("alter table parent drop index idx_ab")
+}
@@ -1936,8 +1936,10 @@ func (s *testDBSuite4) TestColumnCheck(c *C) {
 	tk.MustExec("drop table if exists column_check")
 	tk.MustExec("create table column_check (pk int primary key, a int check (a > 1))")
 	defer tk.MustExec("drop table if exists column_check")
-	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(1))
-	tk.MustQuery("show warnings").Check(testutil.RowsWithSep("|", "Warning|8231|CONSTRAINT CHECK is not supported"))
+	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(0))
+	tk.MustExec("insert into column_check values (1, 2)")
+	tk.MustGetErrCode("insert into column_check values (2, 1)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("create table column_check_other (pk int primary key, a int check (pk > 1))", errno.ErrColumnCheckConstraintReferencesOtherColumn)
 }
 
 func (s *testDBSuite5) TestAlterCheck(c *C) {
@@ -1946,9 +1948,14 @@ func (s *testDBSuite5) TestAlterCheck(c *C) {
 	tk.MustExec("drop table if exists alter_check")
 	tk.MustExec("create table alter_check (pk int primary key)")
 	defer tk.MustExec("drop table if exists alter_check")
+	tk.MustGetErrCode("alter table alter_check alter check crcn ENFORCED", errno.ErrCheckConstraintNotFound)
+	tk.MustExec("alter table alter_check add constraint crcn check (pk > 1) NOT ENFORCED")
+	tk.MustExec("insert into alter_check values (1)")
+	// The existing rows are validated when enforcing the constraint.
+	tk.MustGetErrCode("alter table alter_check alter check crcn ENFORCED", errno.ErrCheckConstraintViolated)
+	tk.MustExec("delete from alter_check")
 	tk.MustExec("alter table alter_check alter check crcn ENFORCED")
-	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(1))
-	tk.MustQuery("show warnings").Check(testutil.RowsWithSep("|", "Warning|8231|ALTER CHECK is not supported"))
+	tk.MustGetErrCode("insert into alter_check values (1)", errno.ErrCheckConstraintViolated)
 }
 
 func (s *testSerialDBSuite) TestDDLJobErrorCount(c *C) {
@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -384,7 +385,7 @@ func TestCreateTableWithHashPartition(t *testing.T) {
 ) PARTITION BY HASH(store_id) PARTITIONS 102400000000;`, tmysql.ErrTooManyPartitions)
 
 	tk.MustExec("CREATE TABLE t_linear (a int, b varchar(128)) PARTITION BY LINEAR HASH(a) PARTITIONS 4")
-	tk.MustGetErrCode("select * from t_linear partition (p0)", tmysql.ErrPartitionClauseOnNonpartitioned)
+	tk.MustQuery("select * from t_linear partition (p0)").Check(testkit.Rows())
 
 	tk.MustExec(`CREATE TABLE t_sub (a int, b varchar(128)) PARTITION BY RANGE( a ) SUBPARTITION BY HASH( a )
                                    SUBPARTITIONS 2 (
@@ -1361,7 +1362,100 @@ func TestCreateTableWithKeyPartition(t *testing.T) {
 	partition by key(s1) partitions 10;`)
 
 	tk.MustExec(`drop table if exists tm2`)
-	tk.MustExec(`create table tm2 (a char(5), unique key(a(5))) partition by key() partitions 5;`)
+	// KEY() uses a unique key only if all its columns are NOT NULL.
+	tk.MustGetErrCode(`create table tm2 (a char(5), unique key(a(5))) partition by key() partitions 5;`, tmysql.ErrFieldNotFoundPart)
+	tk.MustExec(`create table tm2 (a char(5) not null, b int, unique key(a)) partition by key() partitions 5;`)
+	tk.MustQuery("show create table tm2").Check(testkit.Rows("tm2 CREATE TABLE `tm2` (\n" +
+		"  `a` char(5) NOT NULL,\n" +
+		"  `b` int(11) DEFAULT NULL,\n" +
+		"  UNIQUE KEY `a` (`a`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
+		"PARTITION BY KEY () PARTITIONS 5"))
+	tm2 := external.GetTableByName(t, tk, "test", "tm2").Meta()
+	require.Equal(t, model.PartitionTypeKey, tm2.Partition.Type)
+	require.Equal(t, []model.CIStr{model.NewCIStr("a")}, tm2.Partition.Columns)
+
+	tk.MustExec(`drop table if exists tm3`)
+	tk.MustExec(`create table tm3 (a int, b varchar(10), c int, primary key (a, b)) partition by key() partitions 3`)
+	require.Equal(t, []model.CIStr{model.NewCIStr("a"), model.NewCIStr("b")}, external.GetTableByName(t, tk, "test", "tm3").Meta().Partition.Columns)
+	tk.MustGetErrCode(`create table tm4 (a int, b varchar(10), unique key (b)) partition by key() partitions 3`, tmysql.ErrFieldNotFoundPart)
+	tk.MustGetErrCode(`create table tm4 (a int, b text) partition by key(b) partitions 3`, tmysql.ErrBlobFieldInPartFunc)
+	tk.MustGetErrCode(`create table tm4 (a int, b json) partition by key(b) partitions 3`, tmysql.ErrBlobFieldInPartFunc)
+	tk.MustGetErrCode(`create table tm4 (a int, b int) partition by key(c) partitions 3`, tmysql.ErrFieldNotFoundPart)
+	tk.MustGetErrCode(`create table tm4 (a int, b int) partition by key(a, a) partitions 3`, tmysql.ErrSameNamePartitionField)
+	tk.MustGetErrCode(`create table tm4 (a int, b int, unique key (b)) partition by key(a) partitions 3`, tmysql.ErrUniqueKeyNeedAllFieldsInPf)
+
+	tk.MustExec(`create table tm4 (a datetime(3), b varchar(10) collate utf8mb4_general_ci, c decimal(10, 2))
+	partition by linear key algorithm = 1 (a, b, c) (partition p0, partition p1 comment 'p1', partition p2)`)
+	tk.MustQuery("show create table tm4").Check(testkit.Rows("tm4 CREATE TABLE `tm4` (\n" +
+		"  `a` datetime(3) DEFAULT NULL,\n" +
+		"  `b` varchar(10) COLLATE utf8mb4_general_ci DEFAULT NULL,\n" +
+		"  `c` decimal(10,2) DEFAULT NULL\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
+		"PARTITION BY LINEAR KEY ALGORITHM = 1 (`a`,`b`,`c`)\n" +
+		"(PARTITION `p0`,\n" +
+		" PARTITION `p1` COMMENT 'p1',\n" +
+		" PARTITION `p2`)"))
+	tk.MustQuery("select partition_method, partition_expression from information_schema.partitions where table_name = 'tm4' and partition_name = 'p0'").Check(testkit.Rows("LINEAR KEY a,b,c"))
+}
+
+func TestKeyPartitionReadWrite(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec(`create table t (id varchar(36) primary key, a int, b datetime, index idx_a (a)) partition by key(id) partitions 4`)
+	for i := 0; i < 100; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t values ('%08d-uuid', %d, '2022-01-01 00:00:%02d')", i, i, i%60))
+	}
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("100"))
+	// Every row can be found in the partition it is located in.
+	for _, mode := range []string{"static", "dynamic"} {
+		tk.MustExec(fmt.Sprintf("set @@tidb_partition_prune_mode = '%s'", mode))
+		for i := 0; i < 100; i += 7 {
+			id := fmt.Sprintf("%08d-uuid", i)
+			tk.MustQuery(fmt.Sprintf("select a from t where id = '%s'", id)).Check(testkit.Rows(strconv.Itoa(i)))
+			tk.MustQuery(fmt.Sprintf("select a from t use index (idx_a) where id = '%s' and a = %d", id, i)).Check(testkit.Rows(strconv.Itoa(i)))
+		}
+		tk.MustQuery("select count(*) from t where id in ('00000001-uuid', '00000002-uuid', 'no such id')").Check(testkit.Rows("2"))
+	}
+	tk.MustExec("set @@tidb_partition_prune_mode = default")
+	tk.MustExec("update t set id = concat(id, '-new') where a < 50")
+	tk.MustQuery("select count(*) from t where id like '%-new'").Check(testkit.Rows("50"))
+	tk.MustQuery("select a from t where id = '00000049-uuid-new'").Check(testkit.Rows("49"))
+	tk.MustExec("delete from t where id = '00000049-uuid-new'")
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("99"))
+	tk.MustExec("admin check table t")
+
+	// The rows are redistributed when the number of partitions changes.
+	tk.MustExec("alter table t add partition partitions 3")
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select a from t where id = '00000061-uuid'").Check(testkit.Rows("61"))
+	tk.MustExec("alter table t coalesce partition 5")
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select a from t where id = '00000061-uuid'").Check(testkit.Rows("61"))
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("99"))
+
+	// LINEAR HASH and LINEAR KEY locate the rows by the powers-of-two algorithm.
+	tk.MustExec("create table t_lh (a int, b int) partition by linear hash(a) partitions 5")
+	tk.MustExec("create table t_lk (a int, b int) partition by linear key(a) partitions 5")
+	for i := -10; i < 30; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t_lh values (%d, %d)", i, i))
+		tk.MustExec(fmt.Sprintf("insert into t_lk values (%d, %d)", i, i))
+	}
+	// 5 partitions use the mask 7, and 5, 6 and 7 are folded by the mask 3.
+	tk.MustQuery("select a from t_lh partition (p1) where a >= 0 order by a").Check(testkit.Rows("1", "5", "9", "13", "17", "21", "25", "29"))
+	tk.MustQuery("select a from t_lh partition (p4) order by a").Check(testkit.Rows("-4", "4", "12", "20", "28"))
+	for _, tbl := range []string{"t_lh", "t_lk"} {
+		for i := -10; i < 30; i += 3 {
+			tk.MustQuery(fmt.Sprintf("select b from %s where a = %d", tbl, i)).Check(testkit.Rows(strconv.Itoa(i)))
+		}
+		tk.MustQuery(fmt.Sprintf("select count(*) from %s", tbl)).Check(testkit.Rows("40"))
+	}
+	tk.MustExec("set @@tidb_enable_exchange_partition = 1")
+	tk.MustExec("create table t_plain (a int, b int)")
+	tk.MustGetErrCode("alter table t_lh exchange partition p0 with table t_plain", tmysql.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t_lk exchange partition p0 with table t_plain", tmysql.ErrUnsupportedDDLOperation)
 }
 
 func TestAlterTableAddPartition(t *testing.T) {
@@ -1681,6 +1775,208 @@ func TestMultiPartitionDropAndTruncate(t *testing.T) {
 	result.Check(testkit.Rows(`2010`))
 }
 
+func TestReorganizeRangePartition(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec(`create table t (a int unsigned primary key nonclustered, b varchar(255), c int, key (b), key (c, b))
+	partition by range (a) (
+		partition p0 values less than (10),
+		partition p1 values less than (20),
+		partition p2 values less than (30),
+		partition pMax values less than (MAXVALUE)
+	)`)
+	tk.MustExec(`insert into t values (1, "1", 1), (12, "12", 21), (23, "23", 32), (34, "34", 43), (45, "45", 54), (56, "56", 65)`)
+
+	tk.MustGetErrCode("alter table t reorganize partition", errno.ErrReorgNoParam)
+	tk.MustGetErrCode("alter table t reorganize partition pNonExisting into (partition p0 values less than (10))", errno.ErrDropPartitionNonExistent)
+	tk.MustGetErrCode("alter table t reorganize partition p0, p2 into (partition p0 values less than (30))", errno.ErrConsecutiveReorgPartitions)
+	tk.MustGetErrCode("alter table t reorganize partition p0, p1 into (partition p0 values less than (15))", errno.ErrReorgOutsideRange)
+	tk.MustGetErrCode("alter table t reorganize partition p0, p1 into (partition p0 values less than (25))", errno.ErrReorgOutsideRange)
+	tk.MustGetErrCode("alter table t reorganize partition pMax into (partition p3 values less than (40), partition p4 values less than (50))", errno.ErrReorgOutsideRange)
+	tk.MustGetErrCode("alter table t reorganize partition p1 into (partition p1a values less than (15), partition p1b values less than (15))", errno.ErrRangeNotIncreasing)
+
+	// Split a partition.
+	tk.MustExec("alter table t reorganize partition pMax into (partition p3 values less than (40), partition p4 values less than (50), partition pMax values less than (MAXVALUE))")
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select * from t partition (p3)").Check(testkit.Rows("34 34 43"))
+	tk.MustQuery("select * from t partition (p4)").Check(testkit.Rows("45 45 54"))
+	tk.MustQuery("select * from t partition (pMax)").Check(testkit.Rows("56 56 65"))
+	tk.MustQuery("select a from t use index (c) where c > 40").Sort().Check(testkit.Rows("34", "45", "56"))
+
+	// Merge partitions, reusing one of the old partition names.
+	tk.MustExec("alter table t reorganize partition p0, p1, p2 into (partition p0 values less than (30))")
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select * from t partition (p0)").Sort().Check(testkit.Rows("1 1 1", "12 12 21", "23 23 32"))
+	tk.MustQuery("select a from t use index (b) where b < '3'").Sort().Check(testkit.Rows("1", "12", "23"))
+	tbl := external.GetTableByName(t, tk, "test", "t")
+	pi := tbl.Meta().GetPartitionInfo()
+	require.Len(t, pi.Definitions, 4)
+	require.Equal(t, "p0", pi.Definitions[0].Name.L)
+	require.Equal(t, "pmax", pi.Definitions[3].Name.L)
+	require.Len(t, pi.AddingDefinitions, 0)
+	require.Len(t, pi.DroppingDefinitions, 0)
+	require.False(t, pi.IsReorganizing())
+
+	// The last partition may be extended.
+	tk.MustExec("drop table t")
+	tk.MustExec(`create table t (a int, b int) partition by range columns (a) (
+		partition p0 values less than (10),
+		partition p1 values less than (20))`)
+	tk.MustExec("insert into t values (1, 1), (11, 11), (19, 19)")
+	tk.MustExec("alter table t reorganize partition p1 into (partition p1 values less than (15), partition p2 values less than (MAXVALUE))")
+	tk.MustExec("insert into t values (100, 100)")
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select * from t partition (p2)").Sort().Check(testkit.Rows("100 100", "19 19"))
+}
+
+func TestReorganizeListPartition(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@session.tidb_enable_list_partition = ON")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec(`create table t (a int primary key, b int, key (b)) partition by list (a) (
+		partition p0 values in (1, 2, 3),
+		partition p1 values in (4, 5, 6),
+		partition p2 values in (7, 8, 9))`)
+	tk.MustExec("insert into t values (1, 1), (2, 2), (4, 4), (5, 5), (7, 7)")
+
+	tk.MustGetErrCode("alter table t reorganize partition p0 into (partition p0 values in (1, 2, 4))", errno.ErrMultipleDefConstInListPart)
+	tk.MustGetErrCode("alter table t reorganize partition p0 into (partition p0 values in (1, 3))", errno.ErrNoPartitionForGivenValue)
+	tk.MustQuery("select * from t partition (p0)").Sort().Check(testkit.Rows("1 1", "2 2"))
+
+	tk.MustExec("alter table t reorganize partition p0, p1 into (partition pEven values in (2, 4, 6), partition pOdd values in (1, 3, 5, 11))")
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select * from t partition (pEven)").Sort().Check(testkit.Rows("2 2", "4 4"))
+	tk.MustQuery("select * from t partition (pOdd)").Sort().Check(testkit.Rows("1 1", "5 5"))
+	tk.MustExec("insert into t values (11, 11)")
+	tk.MustQuery("select * from t partition (pOdd)").Sort().Check(testkit.Rows("1 1", "11 11", "5 5"))
+	tk.MustQuery("select b from t use index (b) where b < 6").Sort().Check(testkit.Rows("1", "2", "4", "5"))
+}
+
+func TestAddAndCoalesceHashPartition(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int primary key nonclustered, b varchar(255), c int, key (b), key (c)) partition by hash (a) partitions 2")
+	for i := 0; i < 20; i++ {
+		tk.MustExec(fmt.Sprintf(`insert into t values (%d, "%d", %d)`, i, i, i%5))
+	}
+
+	tk.MustGetErrCode("alter table t add partition partitions 0", errno.ErrAddPartitionNoNewPartition)
+	tk.MustGetErrCode("alter table t add partition (partition p0)", errno.ErrSameNamePartition)
+	tk.MustGetErrCode("alter table t add partition (partition p2 values less than (10))", errno.ErrPartitionWrongValues)
+	tk.MustGetErrCode("alter table t coalesce partition 0", errno.ErrCoalescePartitionNoPartition)
+	tk.MustGetErrCode("alter table t coalesce partition 2", errno.ErrDropLastPartition)
+
+	checkHashPartitions := func(num int) {
+		tk.MustExec("admin check table t")
+		tbl := external.GetTableByName(t, tk, "test", "t")
+		pi := tbl.Meta().GetPartitionInfo()
+		require.Equal(t, uint64(num), pi.Num)
+		require.Len(t, pi.Definitions, num)
+		require.False(t, pi.IsReorganizing())
+		for i := 0; i < num; i++ {
+			rows := tk.MustQuery(fmt.Sprintf("select a from t partition (%s)", pi.Definitions[i].Name.O)).Rows()
+			for _, row := range rows {
+				a, err := strconv.Atoi(row[0].(string))
+				require.NoError(t, err)
+				require.Equal(t, i, a%num)
+			}
+		}
+		tk.MustQuery("select count(*) from t").Check(testkit.Rows("20"))
+		tk.MustQuery("select a from t use index (c) where c = 1").Sort().Check(testkit.Rows("1", "11", "16", "6"))
+		tk.MustQuery("select c from t where a = 13").Check(testkit.Rows("3"))
+	}
+
+	tk.MustExec("alter table t add partition partitions 3")
+	checkHashPartitions(5)
+	tk.MustQuery("select a from t partition (p4)").Sort().Check(testkit.Rows("14", "19", "4", "9"))
+	tk.MustExec("alter table t add partition (partition pNew comment 'new')")
+	checkHashPartitions(6)
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `a` int(11) NOT NULL,\n" +
+		"  `b` varchar(255) DEFAULT NULL,\n" +
+		"  `c` int(11) DEFAULT NULL,\n" +
+		"  KEY `b` (`b`),\n" +
+		"  KEY `c` (`c`),\n" +
+		"  PRIMARY KEY (`a`) /*T![clustered_index] NONCLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
+		"PARTITION BY HASH (`a`)\n" +
+		"(PARTITION `p0`,\n" +
+		" PARTITION `p1`,\n" +
+		" PARTITION `p2`,\n" +
+		" PARTITION `p3`,\n" +
+		" PARTITION `p4`,\n" +
+		" PARTITION `pNew` COMMENT 'new')"))
+	tk.MustExec("alter table t coalesce partition 3")
+	checkHashPartitions(3)
+	tk.MustExec("alter table t coalesce partition 2")
+	checkHashPartitions(1)
+}
+
+func TestRehashPartitionWithConcurrentDML(t *testing.T) {
+	store, dom, clean := testkit.CreateMockStoreAndDomain(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int primary key, b int, key (b)) partition by hash (a) partitions 3")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3), (4, 4), (5, 5), (6, 6)")
+
+	tk2 := testkit.NewTestKit(t, store)
+	tk2.MustExec("use test")
+	originHook := dom.DDL().GetHook()
+	defer dom.DDL().SetHook(originHook)
+	hook := &ddl.TestDDLCallback{Do: dom}
+	var checkErr error
+	states := make([]model.SchemaState, 0, 4)
+	next := 100
+	hook.OnJobRunBeforeExported = func(job *model.Job) {
+		if job.Type != model.ActionReorganizePartition || checkErr != nil {
+			return
+		}
+		if len(states) > 0 && states[len(states)-1] == job.SchemaState {
+			return
+		}
+		states = append(states, job.SchemaState)
+		// The table is readable and writable in every state of the job.
+		sqls := []string{
+			fmt.Sprintf("insert into t values (%d, %d)", next, next),
+			fmt.Sprintf("update t set b = b + 1 where a = %d", len(states)),
+			fmt.Sprintf("delete from t where a = %d", len(states)+3),
+			"select * from t",
+		}
+		next++
+		for _, sql := range sqls {
+			if _, checkErr = tk2.Exec(sql); checkErr != nil {
+				return
+			}
+		}
+	}
+	dom.DDL().SetHook(hook)
+	tk.MustExec("alter table t add partition partitions 2")
+	require.NoError(t, checkErr)
+	require.Equal(t, []model.SchemaState{model.StateNone, model.StateDeleteOnly, model.StateWriteOnly, model.StateWriteReorganization, model.StateDeleteReorganization}, states)
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select * from t").Sort().Check(testkit.Rows("1 2", "100 100", "101 101", "102 102", "103 103", "104 104", "2 3", "3 4"))
+	tk.MustQuery("select a from t use index (b) where b > 3").Sort().Check(testkit.Rows("100", "101", "102", "103", "104", "3"))
+	tk.MustQuery("select * from t partition (p4)").Sort().Check(testkit.Rows("104 104"))
+
+	states = states[:0]
+	tk.MustExec("alter table t coalesce partition 3")
+	require.NoError(t, checkErr)
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select * from t partition (p0)").Sort().Check(testkit.Rows("100 100", "102 102", "104 104", "106 106", "108 108", "2 4"))
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("13"))
+}
+
 func TestDropPartitionWithGlobalIndex(t *testing.T) {
 	restore := config.RestoreFunc()
 	defer restore()
@@ -3142,11 +3438,10 @@ func TestPartitionErrorCode(t *testing.T) {
 	)
 	partition by hash(store_id)
 	partitions 4;`)
-	_, err := tk.Exec("alter table employees add partition partitions 8;")
-	require.True(t, dbterror.ErrUnsupportedAddPartition.Equal(err))
+	tk.MustExec("alter table employees add partition partitions 8;")
 
-	_, err = tk.Exec("alter table employees add partition (partition p5 values less than (42));")
-	require.True(t, dbterror.ErrUnsupportedAddPartition.Equal(err))
+	_, err := tk.Exec("alter table employees add partition (partition p5 values less than (42));")
+	require.True(t, ast.ErrPartitionWrongValues.Equal(err))
 
 	// coalesce partition
 	tk.MustExec(`create table clients (
@@ -3157,8 +3452,7 @@ func TestPartitionErrorCode(t *testing.T) {
 	)
 	partition by hash( month(signed) )
 	partitions 12;`)
-	_, err = tk.Exec("alter table clients coalesce partition 4;")
-	require.True(t, dbterror.ErrUnsupportedCoalescePartition.Equal(err))
+	tk.MustExec("alter table clients coalesce partition 4;")
 
 	tk.MustExec(`create table t_part (a int key)
 		partition by range(a) (
@@ -3168,7 +3462,7 @@ func TestPartitionErrorCode(t *testing.T) {
 	_, err = tk.Exec("alter table t_part coalesce partition 4;")
 	require.True(t, dbterror.ErrCoalesceOnlyOnHashPartition.Equal(err))
 
-	tk.MustGetErrCode(`alter table t_part reorganize partition p0, p1 into (
+	tk.MustGetErrCode(`alter table clients reorganize partition p0, p1 into (
 			partition p0 values less than (1980));`, tmysql.ErrUnsupportedDDLOperation)
 
 	tk.MustGetErrCode("alter table t_part check partition p0, p1;", tmysql.ErrUnsupportedDDLOperation)
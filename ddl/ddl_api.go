@@ -644,16 +644,26 @@ func buildColumnsAndConstraints(
 		}
 	}
 	cols := make([]*table.Column, 0, len(colDefs))
+	// The column-level check constraints are put in front of the table-level constraints, so that the unnamed
+	// ones are numbered in the statement order as MySQL does, given the columns are defined first.
+	var colChecks, colConstraints []*ast.Constraint
 	for i, colDef := range colDefs {
 		col, cts, err := buildColumnAndConstraint(ctx, i, colDef, outPriKeyConstraint, tblCharset, tblCollate)
 		if err != nil {
 			return nil, nil, errors.Trace(err)
 		}
 		col.State = model.StatePublic
-		constraints = append(constraints, cts...)
+		for _, ct := range cts {
+			if ct.Tp == ast.ConstraintCheck {
+				colChecks = append(colChecks, ct)
+			} else {
+				colConstraints = append(colConstraints, ct)
+			}
+		}
 		cols = append(cols, col)
 		colMap[colDef.Name.Name.L] = col
 	}
+	constraints = append(append(colChecks, constraints...), colConstraints...)
 	// Traverse table Constraints and set col.flag.
 	for _, v := range constraints {
 		setColumnFlagWithConstraint(colMap, v)
@@ -1024,7 +1034,15 @@ func columnDefToCol(ctx sessionctx.Context, offset int, colDef *ast.ColumnDef, o
 			case ast.ColumnOptionFulltext:
 				ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt.GenWithStackByArgs())
 			case ast.ColumnOptionCheck:
-				ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedConstraintCheck.GenWithStackByArgs("CONSTRAINT CHECK"))
+				constraint := &ast.Constraint{
+					Tp:           ast.ConstraintCheck,
+					Name:         v.ConstraintName,
+					Expr:         v.Expr,
+					Enforced:     v.Enforced,
+					InColumn:     true,
+					InColumnName: colDef.Name.Name.O,
+				}
+				constraints = append(constraints, constraint)
 			}
 		}
 	}
@@ -1563,6 +1581,10 @@ func checkConstraintNames(constraints []*ast.Constraint) error {
 
 	// Check not empty constraint name whether is duplicated.
 	for _, constr := range constraints {
+		if constr.Tp == ast.ConstraintCheck {
+			// Check constraints have their own namespace, they are named in buildTableInfo.
+			continue
+		}
 		if constr.Tp == ast.ConstraintForeignKey {
 			err := checkDuplicateConstraint(fkNames, constr.Name, true)
 			if err != nil {
@@ -1578,6 +1600,9 @@ func checkConstraintNames(constraints []*ast.Constraint) error {
 
 	// Set empty constraint names.
 	for _, constr := range constraints {
+		if constr.Tp == ast.ConstraintCheck {
+			continue
+		}
 		if constr.Tp == ast.ConstraintForeignKey {
 			setEmptyConstraintName(fkNames, constr, true)
 		} else {
@@ -1729,6 +1754,12 @@ func buildTableInfo(
 		tbInfo.Columns = append(tbInfo.Columns, v.ToInfo())
 		tblColumns = append(tblColumns, table.ToColumn(v.ToInfo()))
 	}
+	// Check the uniqueness of the check constraint names, then name the unnamed ones.
+	existConstrNames := make(map[string]struct{})
+	if err = checkConstraintNamesNotExists(constraints, existConstrNames); err != nil {
+		return nil, errors.Trace(err)
+	}
+	setNameForConstraintInfo(tableName.L, constraints, existConstrNames)
 	for _, constr := range constraints {
 		// Build hidden columns if necessary.
 		hiddenCols, err := buildHiddenColumnInfo(ctx, constr.Keys, model.NewCIStr(constr.Name), tbInfo, tblColumns)
@@ -1792,7 +1823,12 @@ func buildTableInfo(
 			continue
 		}
 		if constr.Tp == ast.ConstraintCheck {
-			ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedConstraintCheck.GenWithStackByArgs("CONSTRAINT CHECK"))
+			constraintInfo, err := buildConstraintInfo(ctx, tbInfo, constr, model.StatePublic)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			constraintInfo.ID = allocateConstraintID(tbInfo)
+			tbInfo.Constraints = append(tbInfo.Constraints, constraintInfo)
 			continue
 		}
 		// build index info.
@@ -1834,6 +1870,24 @@ func buildTableInfo(
 		idxInfo.ID = allocateIndexID(tbInfo)
 		tbInfo.Indices = append(tbInfo.Indices, idxInfo)
 	}
+	// The foreign key needs an index on its columns to look up the referring rows,
+	// the index is created with the name of the foreign key if there isn't one, the same as MySQL.
+	for _, fk := range tbInfo.ForeignKeys {
+		keys := buildForeignKeyIndexKeys(tbInfo, fk)
+		if keys == nil {
+			continue
+		}
+		if tbInfo.FindIndexByName(fk.Name.L) != nil {
+			return nil, dbterror.ErrDupKeyName.GenWithStackByArgs(fk.Name.O)
+		}
+		idxInfo, err := buildIndexInfo(tbInfo, fk.Name, keys, model.StatePublic)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		idxInfo.Tp = model.IndexTypeBtree
+		idxInfo.ID = allocateIndexID(tbInfo)
+		tbInfo.Indices = append(tbInfo.Indices, idxInfo)
+	}
 
 	return
 }
@@ -1961,7 +2015,7 @@ func checkPartitionDefinitionConstraints(ctx sessionctx.Context, tbInfo *model.T
 	switch tbInfo.Partition.Type {
 	case model.PartitionTypeRange:
 		err = checkPartitionByRange(ctx, tbInfo)
-	case model.PartitionTypeHash:
+	case model.PartitionTypeHash, model.PartitionTypeKey:
 		err = checkPartitionByHash(ctx, tbInfo)
 	case model.PartitionTypeList:
 		err = checkPartitionByList(ctx, tbInfo)
@@ -2000,6 +2054,7 @@ func buildTableInfoWithLike(ctx sessionctx.Context, ident ast.Ident, referTblInf
 	tblInfo.Name = ident.Name
 	tblInfo.AutoIncID = 0
 	tblInfo.ForeignKeys = nil
+	tblInfo.Triggers = nil
 	// Ignore TiFlash replicas for temporary tables.
 	if s.TemporaryKeyword != ast.TemporaryNone {
 		tblInfo.TiFlashReplica = nil
@@ -2179,6 +2234,11 @@ func (d *ddl) CreateTable(ctx sessionctx.Context, s *ast.CreateTableStmt) (err e
 	if err = checkTableInfoValidWithStmt(ctx, tbInfo, s); err != nil {
 		return err
 	}
+	for _, fk := range tbInfo.ForeignKeys {
+		if err = checkForeignKeyParentIndex(ctx, is, schema.Name, tbInfo, fk); err != nil {
+			return err
+		}
+	}
 
 	onExist := OnExistError
 	if s.IfNotExists {
@@ -2953,10 +3013,13 @@ func resolveAlterTableSpec(ctx sessionctx.Context, specs []*ast.AlterTableSpec)
 }
 
 func isSameTypeMultiSpecs(specs []*ast.AlterTableSpec) bool {
+	isDropIndex := func(tp ast.AlterTableType) bool {
+		return tp == ast.AlterTableDropPrimaryKey || tp == ast.AlterTableDropIndex
+	}
 	specType := specs[0].Tp
 	for _, spec := range specs {
 		// We think AlterTableDropPrimaryKey and AlterTableDropIndex are the same types.
-		if spec.Tp == ast.AlterTableDropPrimaryKey || spec.Tp == ast.AlterTableDropIndex {
+		if isDropIndex(spec.Tp) && isDropIndex(specType) {
 			continue
 		}
 		if spec.Tp != specType {
@@ -2974,14 +3037,26 @@ func checkMultiSpecs(sctx sessionctx.Context, specs []*ast.AlterTableSpec) error
 		if len(specs) == 1 && len(specs[0].NewColumns) > 1 && specs[0].Tp == ast.AlterTableAddColumns {
 			return dbterror.ErrRunMultiSchemaChanges
 		}
-	} else {
-		if len(specs) > 1 && !isSameTypeMultiSpecs(specs) {
-			return dbterror.ErrRunMultiSchemaChanges
-		}
 	}
 	return nil
 }
 
+func hasCheckConstraintInNewColumns(specs []*ast.AlterTableSpec) bool {
+	for _, spec := range specs {
+		if spec.Tp != ast.AlterTableAddColumns {
+			continue
+		}
+		for _, colDef := range spec.NewColumns {
+			for _, option := range colDef.Options {
+				if option.Tp == ast.ColumnOptionCheck {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast.Ident, specs []*ast.AlterTableSpec) (err error) {
 	validSpecs, err := resolveAlterTableSpec(sctx, specs)
 	if err != nil {
@@ -2998,38 +3073,54 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 		return err
 	}
 
-	if len(validSpecs) > 1 {
-		switch validSpecs[0].Tp {
-		case ast.AlterTableAddColumns:
-			err = d.AddColumns(sctx, ident, validSpecs)
-		case ast.AlterTableDropColumn:
-			err = d.DropColumns(sctx, ident, validSpecs)
-		case ast.AlterTableDropPrimaryKey, ast.AlterTableDropIndex:
-			err = d.DropIndexes(sctx, ident, validSpecs)
-		default:
-			return dbterror.ErrRunMultiSchemaChanges
-		}
-		if err != nil {
-			return errors.Trace(err)
+	// The columns with check constraints are added by individual sub-jobs in the multi-schema change,
+	// since the job adding multiple columns doesn't carry the check constraints.
+	addColumnsWithCheck := hasCheckConstraintInNewColumns(validSpecs)
+	if len(validSpecs) > 1 || len(validSpecs) == 1 && len(validSpecs[0].NewColumns) > 1 && addColumnsWithCheck {
+		if isSameTypeMultiSpecs(validSpecs) {
+			switch validSpecs[0].Tp {
+			case ast.AlterTableAddColumns:
+				if !addColumnsWithCheck {
+					return errors.Trace(d.AddColumns(sctx, ident, validSpecs))
+				}
+			case ast.AlterTableDropColumn:
+				return errors.Trace(d.DropColumns(sctx, ident, validSpecs))
+			case ast.AlterTableDropPrimaryKey, ast.AlterTableDropIndex:
+				return errors.Trace(d.DropIndexes(sctx, ident, validSpecs))
+			}
 		}
-		return nil
+		// The sub-jobs of the specs are collected by doDDLJob,
+		// and then they are run together in one multi-schema change job.
+		sctx.GetSessionVars().StmtCtx.MultiSchemaInfo = model.NewMultiSchemaInfo()
+		defer func() {
+			sctx.GetSessionVars().StmtCtx.MultiSchemaInfo = nil
+		}()
 	}
 
 	for _, spec := range validSpecs {
 		var handledCharsetOrCollate bool
 		switch spec.Tp {
 		case ast.AlterTableAddColumns:
-			if len(spec.NewColumns) != 1 {
-				err = d.AddColumns(sctx, ident, []*ast.AlterTableSpec{spec})
-			} else {
+			if len(spec.NewColumns) == 1 {
 				err = d.AddColumn(sctx, ident, spec)
+			} else if sctx.GetSessionVars().StmtCtx.MultiSchemaInfo != nil {
+				// Each column is added by an individual sub-job in the multi-schema change.
+				for _, newColumn := range spec.NewColumns {
+					subSpec := *spec
+					subSpec.NewColumns = []*ast.ColumnDef{newColumn}
+					if err = d.AddColumn(sctx, ident, &subSpec); err != nil {
+						break
+					}
+				}
+			} else {
+				err = d.AddColumns(sctx, ident, []*ast.AlterTableSpec{spec})
 			}
 		case ast.AlterTableAddPartitions:
 			err = d.AddTablePartitions(sctx, ident, spec)
 		case ast.AlterTableCoalescePartitions:
 			err = d.CoalescePartitions(sctx, ident, spec)
 		case ast.AlterTableReorganizePartition:
-			err = errors.Trace(dbterror.ErrUnsupportedReorganizePartition)
+			err = d.ReorganizePartitions(sctx, ident, spec)
 		case ast.AlterTableCheckPartitions:
 			err = errors.Trace(dbterror.ErrUnsupportedCheckPartition)
 		case ast.AlterTableRebuildPartition:
@@ -3090,7 +3181,7 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 			case ast.ConstraintFulltext:
 				sctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt)
 			case ast.ConstraintCheck:
-				sctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedConstraintCheck.GenWithStackByArgs("ADD CONSTRAINT CHECK"))
+				err = d.CreateCheckConstraint(sctx, ident, constr)
 			default:
 				// Nothing to do now.
 			}
@@ -3172,9 +3263,9 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 		case ast.AlterTableIndexInvisible:
 			err = d.AlterIndexVisibility(sctx, ident, spec.IndexName, spec.Visibility)
 		case ast.AlterTableAlterCheck:
-			sctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedConstraintCheck.GenWithStackByArgs("ALTER CHECK"))
+			err = d.AlterCheckConstraint(sctx, ident, model.NewCIStr(spec.Constraint.Name), spec.Constraint.Enforced)
 		case ast.AlterTableDropCheck:
-			sctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedConstraintCheck.GenWithStackByArgs("DROP CHECK"))
+			err = d.DropCheckConstraint(sctx, ident, model.NewCIStr(spec.Constraint.Name))
 		case ast.AlterTableWithValidation:
 			sctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedAlterTableWithValidation)
 		case ast.AlterTableWithoutValidation:
@@ -3202,6 +3293,14 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 		}
 	}
 
+	if info := sctx.GetSessionVars().StmtCtx.MultiSchemaInfo; info != nil {
+		sctx.GetSessionVars().StmtCtx.MultiSchemaInfo = nil
+		err = d.multiSchemaChange(sctx, ident, info)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	return nil
 }
 
@@ -3334,10 +3433,10 @@ func checkUnsupportedColumnConstraint(col *ast.ColumnDef, ti ast.Ident) error {
 	return nil
 }
 
-func checkAndCreateNewColumn(ctx sessionctx.Context, ti ast.Ident, schema *model.DBInfo, spec *ast.AlterTableSpec, t table.Table, specNewColumn *ast.ColumnDef) (*table.Column, error) {
+func checkAndCreateNewColumn(ctx sessionctx.Context, ti ast.Ident, schema *model.DBInfo, spec *ast.AlterTableSpec, t table.Table, specNewColumn *ast.ColumnDef) (*table.Column, []*ast.Constraint, error) {
 	err := checkUnsupportedColumnConstraint(specNewColumn, ti)
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 
 	colName := specNewColumn.Name.Name.O
@@ -3347,15 +3446,15 @@ func checkAndCreateNewColumn(ctx sessionctx.Context, ti ast.Ident, schema *model
 		err = infoschema.ErrColumnExists.GenWithStackByArgs(colName)
 		if spec.IfNotExists {
 			ctx.GetSessionVars().StmtCtx.AppendNote(err)
-			return nil, nil
+			return nil, nil, nil
 		}
-		return nil, err
+		return nil, nil, err
 	}
 	if err = checkColumnAttributes(colName, specNewColumn.Tp); err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 	if utf8.RuneCountInString(colName) > mysql.MaxColumnNameLength {
-		return nil, dbterror.ErrTooLongIdent.GenWithStackByArgs(colName)
+		return nil, nil, dbterror.ErrTooLongIdent.GenWithStackByArgs(colName)
 	}
 
 	// If new column is a generated column, do validation.
@@ -3364,17 +3463,17 @@ func checkAndCreateNewColumn(ctx sessionctx.Context, ti ast.Ident, schema *model
 	for _, option := range specNewColumn.Options {
 		if option.Tp == ast.ColumnOptionGenerated {
 			if err := checkIllegalFn4Generated(specNewColumn.Name.Name.L, typeColumn, option.Expr); err != nil {
-				return nil, errors.Trace(err)
+				return nil, nil, errors.Trace(err)
 			}
 
 			if option.Stored {
-				return nil, dbterror.ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs("Adding generated stored column through ALTER TABLE")
+				return nil, nil, dbterror.ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs("Adding generated stored column through ALTER TABLE")
 			}
 
 			_, dependColNames := findDependedColumnNames(specNewColumn)
 			if !ctx.GetSessionVars().EnableAutoIncrementInGenerated {
 				if err = checkAutoIncrementRef(specNewColumn.Name.Name.L, dependColNames, t.Meta()); err != nil {
-					return nil, errors.Trace(err)
+					return nil, nil, errors.Trace(err)
 				}
 			}
 			duplicateColNames := make(map[string]struct{}, len(dependColNames))
@@ -3384,11 +3483,11 @@ func checkAndCreateNewColumn(ctx sessionctx.Context, ti ast.Ident, schema *model
 			cols := t.Cols()
 
 			if err = checkDependedColExist(dependColNames, cols); err != nil {
-				return nil, errors.Trace(err)
+				return nil, nil, errors.Trace(err)
 			}
 
 			if err = verifyColumnGenerationSingle(duplicateColNames, cols, spec.Position); err != nil {
-				return nil, errors.Trace(err)
+				return nil, nil, errors.Trace(err)
 			}
 		}
 		// Specially, since sequence has been supported, if a newly added column has a
@@ -3398,10 +3497,10 @@ func checkAndCreateNewColumn(ctx sessionctx.Context, ti ast.Ident, schema *model
 		if option.Tp == ast.ColumnOptionDefaultValue {
 			_, isSeqExpr, err := tryToGetSequenceDefaultValue(option)
 			if err != nil {
-				return nil, errors.Trace(err)
+				return nil, nil, errors.Trace(err)
 			}
 			if isSeqExpr {
-				return nil, errors.Trace(dbterror.ErrAddColumnWithSequenceAsDefault.GenWithStackByArgs(specNewColumn.Name.Name.O))
+				return nil, nil, errors.Trace(dbterror.ErrAddColumnWithSequenceAsDefault.GenWithStackByArgs(specNewColumn.Name.Name.O))
 			}
 		}
 	}
@@ -3411,11 +3510,11 @@ func checkAndCreateNewColumn(ctx sessionctx.Context, ti ast.Ident, schema *model
 		ast.CharsetOpt{Chs: schema.Charset, Col: schema.Collate},
 	)
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 	// Ignore table constraints now, they will be checked later.
 	// We use length(t.Cols()) as the default offset firstly, we will change the column's offset later.
-	col, _, err = buildColumnAndConstraint(
+	col, cts, err := buildColumnAndConstraint(
 		ctx,
 		len(t.Cols()),
 		specNewColumn,
@@ -3424,16 +3523,16 @@ func checkAndCreateNewColumn(ctx sessionctx.Context, ti ast.Ident, schema *model
 		tableCollate,
 	)
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 
 	originDefVal, err := generateOriginDefaultValue(col.ToInfo(), ctx)
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 
 	err = col.SetOriginDefaultValue(originDefVal)
-	return col, err
+	return col, cts, err
 }
 
 // AddColumn will add a new column to the table.
@@ -3446,7 +3545,7 @@ func (d *ddl) AddColumn(ctx sessionctx.Context, ti ast.Ident, spec *ast.AlterTab
 	if err = checkAddColumnTooManyColumns(len(t.Cols()) + 1); err != nil {
 		return errors.Trace(err)
 	}
-	col, err := checkAndCreateNewColumn(ctx, ti, schema, spec, t, specNewColumn)
+	col, cts, err := checkAndCreateNewColumn(ctx, ti, schema, spec, t, specNewColumn)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -3454,6 +3553,10 @@ func (d *ddl) AddColumn(ctx sessionctx.Context, ti ast.Ident, spec *ast.AlterTab
 	if col == nil {
 		return nil
 	}
+	constraints, err := buildConstraintInfosForNewColumn(ctx, t.Meta(), col, cts)
+	if err != nil {
+		return errors.Trace(err)
+	}
 
 	job := &model.Job{
 		SchemaID:   schema.ID,
@@ -3461,7 +3564,7 @@ func (d *ddl) AddColumn(ctx sessionctx.Context, ti ast.Ident, spec *ast.AlterTab
 		SchemaName: schema.Name.L,
 		Type:       model.ActionAddColumn,
 		BinlogInfo: &model.HistoryInfo{},
-		Args:       []interface{}{col, spec.Position, 0},
+		Args:       []interface{}{col, spec.Position, 0, constraints},
 	}
 
 	err = d.doDDLJob(ctx, job)
@@ -3509,7 +3612,8 @@ func (d *ddl) AddColumns(ctx sessionctx.Context, ti ast.Ident, specs []*ast.Alte
 				ctx.GetSessionVars().StmtCtx.AppendNote(err)
 				continue
 			}
-			col, err := checkAndCreateNewColumn(ctx, ti, schema, spec, t, specNewColumn)
+			// The columns with check constraints are added by the multi-schema change, see AlterTable.
+			col, _, err := checkAndCreateNewColumn(ctx, ti, schema, spec, t, specNewColumn)
 			if err != nil {
 				return errors.Trace(err)
 			}
@@ -3566,6 +3670,23 @@ func (d *ddl) AddTablePartitions(ctx sessionctx.Context, ident ast.Ident, spec *
 		return errors.Trace(dbterror.ErrPartitionMgmtOnNonpartitioned)
 	}
 
+	if pi.Type == model.PartitionTypeHash || pi.Type == model.PartitionTypeKey {
+		// The global indexes and TiFlash replicas of the new partitions are not maintained yet.
+		if hasGlobalIndex(meta) || meta.TiFlashReplica != nil {
+			return errors.Trace(dbterror.ErrUnsupportedAddPartition)
+		}
+		defs, err := buildAddedHashPartitionDefinitions(meta, spec)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		err = d.rehashPartitions(ctx, schema, meta, defs)
+		if dbterror.ErrSameNamePartition.Equal(err) && spec.IfNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return errors.Trace(err)
+	}
+
 	partInfo, err := buildAddedPartitionInfo(ctx, meta, spec)
 	if err != nil {
 		return errors.Trace(err)
@@ -3631,18 +3752,188 @@ func (d *ddl) CoalescePartitions(ctx sessionctx.Context, ident ast.Ident, spec *
 	}
 
 	switch meta.Partition.Type {
-	// We don't support coalesce partitions hash type partition now.
-	case model.PartitionTypeHash:
-		return errors.Trace(dbterror.ErrUnsupportedCoalescePartition)
-
-	// Key type partition cannot be constructed currently, ignoring it for now.
-	case model.PartitionTypeKey:
+	case model.PartitionTypeHash, model.PartitionTypeKey:
 
 	// Coalesce partition can only be used on hash/key partitions.
 	default:
 		return errors.Trace(dbterror.ErrCoalesceOnlyOnHashPartition)
 	}
 
+	// The global indexes and TiFlash replicas of the new partitions are not maintained yet.
+	if hasGlobalIndex(meta) || meta.TiFlashReplica != nil {
+		return errors.Trace(dbterror.ErrUnsupportedCoalescePartition)
+	}
+	defs := meta.Partition.Definitions
+	if spec.Num == 0 {
+		return errors.Trace(dbterror.ErrCoalescePartitionNoPartition)
+	}
+	if spec.Num >= uint64(len(defs)) {
+		return errors.Trace(dbterror.ErrDropLastPartition)
+	}
+	// The last partitions are removed, and the rows are redistributed into the remaining ones.
+	newDefs := make([]model.PartitionDefinition, len(defs)-int(spec.Num))
+	copy(newDefs, defs)
+	return errors.Trace(d.rehashPartitions(ctx, schema, meta, newDefs))
+}
+
+// buildAddedHashPartitionDefinitions returns the partition definitions of a HASH partitioned
+// table after adding the partitions in spec to the existing ones.
+func buildAddedHashPartitionDefinitions(meta *model.TableInfo, spec *ast.AlterTableSpec) ([]model.PartitionDefinition, error) {
+	oldDefs := meta.Partition.Definitions
+	if len(spec.PartDefinitions) == 0 {
+		if spec.Num == 0 {
+			return nil, errors.Trace(dbterror.ErrAddPartitionNoNewPartition)
+		}
+		if err := checkAddPartitionTooManyPartitions(uint64(len(oldDefs)) + spec.Num); err != nil {
+			return nil, errors.Trace(err)
+		}
+		defs := make([]model.PartitionDefinition, len(oldDefs), len(oldDefs)+int(spec.Num))
+		copy(defs, oldDefs)
+		for i := len(oldDefs); i < cap(defs); i++ {
+			defs = append(defs, model.PartitionDefinition{Name: model.NewCIStr(fmt.Sprintf("p%v", i))})
+		}
+		return defs, nil
+	}
+
+	defs := make([]model.PartitionDefinition, len(oldDefs), len(oldDefs)+len(spec.PartDefinitions))
+	copy(defs, oldDefs)
+	for _, def := range spec.PartDefinitions {
+		if err := def.Clause.Validate(model.PartitionTypeHash, len(meta.Partition.Columns)); err != nil {
+			return nil, errors.Trace(err)
+		}
+		newDef := model.PartitionDefinition{Name: def.Name}
+		newDef.Comment, _ = def.Comment()
+		if err := setPartitionPlacementFromOptions(&newDef, def.Options); err != nil {
+			return nil, errors.Trace(err)
+		}
+		defs = append(defs, newDef)
+	}
+	return defs, nil
+}
+
+// rehashPartitions changes the partitions of a HASH partitioned table into defs. Since the rows
+// are hashed by the number of partitions, all the partitions are reorganized into new partitions
+// with the definitions in defs, and the rows are redistributed online by the reorganize job.
+func (d *ddl) rehashPartitions(ctx sessionctx.Context, schema *model.DBInfo, meta *model.TableInfo, defs []model.PartitionDefinition) error {
+	pi := meta.Partition
+	partInfo := &model.PartitionInfo{
+		Type:           pi.Type,
+		Expr:           pi.Expr,
+		Columns:        pi.Columns,
+		Enable:         pi.Enable,
+		Num:            uint64(len(defs)),
+		IsLinear:       pi.IsLinear,
+		KeyAlgorithm:   pi.KeyAlgorithm,
+		IsEmptyColumns: pi.IsEmptyColumns,
+		Definitions:    defs,
+	}
+	if err := d.assignPartitionIDs(partInfo.Definitions); err != nil {
+		return errors.Trace(err)
+	}
+
+	clonedMeta := meta.Clone()
+	clonedMeta.Partition = partInfo
+	if err := checkPartitionDefinitionConstraints(ctx, clonedMeta); err != nil {
+		return errors.Trace(err)
+	}
+	if err := handlePartitionPlacement(ctx, partInfo); err != nil {
+		return errors.Trace(err)
+	}
+
+	partNames := make([]string, 0, len(pi.Definitions))
+	for _, def := range pi.Definitions {
+		partNames = append(partNames, def.Name.L)
+	}
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    meta.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionReorganizePartition,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{partNames, partInfo},
+	}
+
+	err := d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// ReorganizePartitions reorganizes the consecutive partitions of a RANGE or LIST partitioned table into new partitions.
+func (d *ddl) ReorganizePartitions(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
+	is := d.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(ident.Schema)
+	if !ok {
+		return errors.Trace(infoschema.ErrDatabaseNotExists.GenWithStackByArgs(schema))
+	}
+	t, err := is.TableByName(ident.Schema, ident.Name)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(ident.Schema, ident.Name))
+	}
+
+	meta := t.Meta()
+	pi := meta.GetPartitionInfo()
+	if pi == nil {
+		return errors.Trace(dbterror.ErrPartitionMgmtOnNonpartitioned)
+	}
+	switch pi.Type {
+	case model.PartitionTypeRange, model.PartitionTypeList:
+	default:
+		return errors.Trace(dbterror.ErrUnsupportedReorganizePartition)
+	}
+	if spec.OnAllPartitions {
+		return errors.Trace(dbterror.ErrReorgNoParam)
+	}
+	// The global indexes and TiFlash replicas of the new partitions are not maintained yet.
+	if hasGlobalIndex(meta) || meta.TiFlashReplica != nil {
+		return errors.Trace(dbterror.ErrUnsupportedReorganizePartition)
+	}
+
+	partNames := make([]string, len(spec.PartitionNames))
+	for i, partCIName := range spec.PartitionNames {
+		partNames[i] = partCIName.L
+	}
+	sourceDefs, err := getReorgSourceDefinitions(meta, partNames)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	partInfo, err := buildAddedPartitionInfo(ctx, meta, spec)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := d.assignPartitionIDs(partInfo.Definitions); err != nil {
+		return errors.Trace(err)
+	}
+
+	// Check the new partitions together with the partitions that are not reorganized.
+	clonedMeta := meta.Clone()
+	tmp := *pi
+	tmp.Definitions = pi.ReplaceDefinitions(sourceDefs, partInfo.Definitions)
+	clonedMeta.Partition = &tmp
+	if err := checkPartitionDefinitionConstraints(ctx, clonedMeta); err != nil {
+		return errors.Trace(err)
+	}
+	if pi.Type == model.PartitionTypeRange {
+		if err := checkReorgPartitionRange(ctx, meta, sourceDefs, partInfo.Definitions); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if err = handlePartitionPlacement(ctx, partInfo); err != nil {
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    meta.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionReorganizePartition,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{partNames, partInfo},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
 	return errors.Trace(err)
 }
 
@@ -4336,6 +4627,9 @@ func (d *ddl) getModifiableColumnJob(ctx context.Context, sctx sessionctx.Contex
 		if c != nil {
 			return nil, infoschema.ErrColumnExists.GenWithStackByArgs(newColName)
 		}
+		if ok, constrName := hasDependentByCheckConstraint(t.Meta(), originalColName); ok {
+			return nil, dbterror.ErrDependentByCheckConstraint.GenWithStackByArgs(constrName, originalColName.O)
+		}
 	}
 
 	// Constraints in the new column means adding new constraints. Errors should thrown,
@@ -4688,6 +4982,9 @@ func (d *ddl) RenameColumn(ctx sessionctx.Context, ident ast.Ident, spec *ast.Al
 	if fkInfo := getColumnForeignKeyInfo(oldColName.L, tbl.Meta().ForeignKeys); fkInfo != nil {
 		return dbterror.ErrFKIncompatibleColumns.GenWithStackByArgs(oldColName, fkInfo.Name)
 	}
+	if ok, constrName := hasDependentByCheckConstraint(tbl.Meta(), oldColName); ok {
+		return dbterror.ErrDependentByCheckConstraint.GenWithStackByArgs(constrName, oldColName.O)
+	}
 
 	// Check generated expression.
 	for _, col := range allCols {
@@ -5398,6 +5695,12 @@ func extractTblInfos(is infoschema.InfoSchema, oldIdent, newIdent ast.Ident, isA
 		return nil, 0, errors.Trace(err)
 	}
 	oldTableID := getTableID(is, oldIdent, tables)
+	// Triggers must be in the same schema as their table.
+	if oldSchema.ID != newSchema.ID {
+		if tbl, ok := is.TableByID(oldTableID); ok && len(tbl.Meta().Triggers) > 0 {
+			return nil, 0, dbterror.ErrTrgInWrongSchema
+		}
+	}
 	oldIdentKey := getIdentKey(oldIdent)
 	tables[oldIdentKey] = tableNotExist
 	newIdentKey := getIdentKey(newIdent)
@@ -5488,7 +5791,7 @@ func (d *ddl) CreatePrimaryKey(ctx sessionctx.Context, ti ast.Ident, indexName m
 		}
 	}
 
-	tblInfo := t.Meta()
+	tblInfo := tableInfoInMultiSchemaChange(ctx, t.Meta())
 	// Check before the job is put to the queue.
 	// This check is redundant, but useful. If DDL check fail before the job is put
 	// to job queue, the fail path logic is super fast.
@@ -5672,7 +5975,7 @@ func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 		return errors.Trace(err)
 	}
 
-	tblInfo := t.Meta()
+	tblInfo := tableInfoInMultiSchemaChange(ctx, t.Meta())
 
 	// Build hidden columns if necessary.
 	hiddenCols, err := buildHiddenColumnInfo(ctx, indexPartSpecifications, indexName, t.Meta(), t.Cols())
@@ -5759,9 +6062,10 @@ func buildFKInfo(fkName model.CIStr, keys []*ast.IndexPartSpecification, refer *
 	}
 
 	fkInfo := &model.FKInfo{
-		Name:     fkName,
-		RefTable: refer.Table.Name,
-		Cols:     make([]model.CIStr, len(keys)),
+		Name:      fkName,
+		RefSchema: refer.Table.Schema,
+		RefTable:  refer.Table.Name,
+		Cols:      make([]model.CIStr, len(keys)),
 	}
 
 	for i, key := range keys {
@@ -5839,10 +6143,25 @@ func (d *ddl) CreateForeignKey(ctx sessionctx.Context, ti ast.Ident, fkName mode
 		}
 	}
 
-	fkInfo, err := buildFKInfo(fkName, keys, refer, t.Cols(), t.Meta())
+	// The foreign key can be built on the columns added by the previous sub-jobs.
+	tblInfo := tableInfoInMultiSchemaChange(ctx, t.Meta())
+	cols := make([]*table.Column, 0, len(tblInfo.Columns))
+	for _, colInfo := range tblInfo.Cols() {
+		cols = append(cols, table.ToColumn(colInfo))
+	}
+	fkInfo, err := buildFKInfo(fkName, keys, refer, cols, tblInfo)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err = checkForeignKeyParentIndex(ctx, is, schema.Name, tblInfo, fkInfo); err != nil {
+		return err
+	}
+	// The index on the foreign key columns is created first if there isn't one, the same as MySQL.
+	if idxKeys := buildForeignKeyIndexKeys(tblInfo, fkInfo); idxKeys != nil {
+		if err = d.CreateIndex(ctx, ti, ast.IndexKeyTypeNone, fkName, idxKeys, nil, false); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	job := &model.Job{
 		SchemaID:   schema.ID,
@@ -5884,6 +6203,107 @@ func (d *ddl) DropForeignKey(ctx sessionctx.Context, ti ast.Ident, fkName model.
 	return errors.Trace(err)
 }
 
+func (d *ddl) CreateCheckConstraint(ctx sessionctx.Context, ti ast.Ident, constr *ast.Constraint) error {
+	is := d.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(ti.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(ti.Schema)
+	}
+
+	t, err := is.TableByName(ti.Schema, ti.Name)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(ti.Schema, ti.Name))
+	}
+	tblInfo := t.Meta()
+	if tblInfo.TempTableType != model.TempTableNone {
+		return dbterror.ErrOptOnTemporaryTable.GenWithStackByArgs("check constraint")
+	}
+
+	// Check the uniqueness of the constraint name, then name it if it's unnamed.
+	existNames := existConstraintNames(ctx, tblInfo)
+	constraints := []*ast.Constraint{constr}
+	if err = checkConstraintNamesNotExists(constraints, existNames); err != nil {
+		return errors.Trace(err)
+	}
+	setNameForConstraintInfo(tblInfo.Name.L, constraints, existNames)
+
+	constraintInfo, err := buildConstraintInfo(ctx, tblInfo, constr, model.StateNone)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tblInfo.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionAddCheckConstraint,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{constraintInfo},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+func (d *ddl) DropCheckConstraint(ctx sessionctx.Context, ti ast.Ident, constrName model.CIStr) error {
+	is := d.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(ti.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(ti.Schema)
+	}
+
+	t, err := is.TableByName(ti.Schema, ti.Name)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(ti.Schema, ti.Name))
+	}
+	if t.Meta().FindConstraintInfoByName(constrName.L) == nil {
+		return dbterror.ErrCheckConstraintNotFound.GenWithStackByArgs(constrName.O)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    t.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionDropCheckConstraint,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{constrName},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+func (d *ddl) AlterCheckConstraint(ctx sessionctx.Context, ti ast.Ident, constrName model.CIStr, enforced bool) error {
+	is := d.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(ti.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(ti.Schema)
+	}
+
+	t, err := is.TableByName(ti.Schema, ti.Name)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(ti.Schema, ti.Name))
+	}
+	if t.Meta().FindConstraintInfoByName(constrName.L) == nil {
+		return dbterror.ErrCheckConstraintNotFound.GenWithStackByArgs(constrName.O)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    t.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionAlterCheckConstraint,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{constrName, enforced},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
 func (d *ddl) DropIndex(ctx sessionctx.Context, ti ast.Ident, indexName model.CIStr, ifExists bool) error {
 	is := d.infoCache.GetLatest()
 	schema, ok := is.SchemaByName(ti.Schema)
@@ -5919,6 +6339,13 @@ func (d *ddl) DropIndex(ctx sessionctx.Context, ti ast.Ident, indexName model.CI
 	if err != nil {
 		return errors.Trace(err)
 	}
+	// The foreign keys are checked with all the dropped indexes when the multi-schema change is submitted.
+	if ctx.GetSessionVars().StmtCtx.MultiSchemaInfo == nil {
+		err = checkDropIndexesNeededInForeignKey(is, schema.Name, t.Meta(), []*model.IndexInfo{indexInfo}, nil)
+		if err != nil {
+			return err
+		}
+	}
 
 	jobTp := model.ActionDropIndex
 	if isPK {
@@ -5955,6 +6382,7 @@ func (d *ddl) DropIndexes(ctx sessionctx.Context, ti ast.Ident, specs []*ast.Alt
 	}
 	indexNames := make([]model.CIStr, 0, len(specs))
 	ifExists := make([]bool, 0, len(specs))
+	droppedIdxInfos := make([]*model.IndexInfo, 0, len(specs))
 	for _, spec := range specs {
 		var indexName model.CIStr
 		if spec.Tp == ast.AlterTableDropPrimaryKey {
@@ -5972,11 +6400,16 @@ func (d *ddl) DropIndexes(ctx sessionctx.Context, ti ast.Ident, specs []*ast.Alt
 			if err := checkDropIndexOnAutoIncrementColumn(t.Meta(), indexInfo); err != nil {
 				return errors.Trace(err)
 			}
+			droppedIdxInfos = append(droppedIdxInfos, indexInfo)
 		}
 
 		indexNames = append(indexNames, indexName)
 		ifExists = append(ifExists, spec.IfExists)
 	}
+	err = checkDropIndexesNeededInForeignKey(d.infoCache.GetLatest(), schema.Name, t.Meta(), droppedIdxInfos, nil)
+	if err != nil {
+		return err
+	}
 
 	job := &model.Job{
 		SchemaID:   schema.ID,
@@ -6022,6 +6455,9 @@ func isDroppableColumn(multiSchemaChange bool, tblInfo *model.TableInfo, colName
 		}
 		return dbterror.ErrDependentByGeneratedColumn.GenWithStackByArgs(dep)
 	}
+	if err := checkDropColumnWithCheckConstraint(tblInfo, colName); err != nil {
+		return err
+	}
 
 	if len(tblInfo.Columns) == 1 {
 		return dbterror.ErrCantRemoveAllFields.GenWithStack("can't drop only column %s in table %s",
@@ -6888,6 +7324,383 @@ func (d *ddl) AlterPlacementPolicy(ctx sessionctx.Context, stmt *ast.AlterPlacem
 	return errors.Trace(err)
 }
 
+func (d *ddl) CreateRoutine(ctx sessionctx.Context, stmt *ast.CreateRoutineStmt) (err error) {
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, ok := is.SchemaByName(stmt.Name.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(stmt.Name.Schema)
+	}
+	if _, ok = is.RoutineByName(stmt.Name.Schema, stmt.Name.Name, stmt.Type); ok {
+		err = infoschema.ErrRoutineExists.GenWithStackByArgs(stmt.Type.String(), stmt.Name.Name)
+		if stmt.IfNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	routineInfo, err := buildRoutineInfo(ctx, schema, stmt)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	genIDs, err := d.genGlobalIDs(1)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	routineInfo.ID = genIDs[0]
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionCreateRoutine,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{routineInfo},
+	}
+	err = d.doDDLJob(ctx, job)
+	if infoschema.ErrRoutineExists.Equal(err) && stmt.IfNotExists {
+		ctx.GetSessionVars().StmtCtx.AppendNote(err)
+		err = nil
+	}
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+func buildRoutineInfo(ctx sessionctx.Context, schema *model.DBInfo, stmt *ast.CreateRoutineStmt) (*model.RoutineInfo, error) {
+	sessVars := ctx.GetSessionVars()
+	routineInfo := &model.RoutineInfo{
+		Name:          stmt.Name.Name,
+		Type:          stmt.Type,
+		Params:        make([]*model.RoutineParam, 0, len(stmt.Params)),
+		Body:          stmt.Body.Text(),
+		Definer:       stmt.Definer,
+		Security:      stmt.Security,
+		DataAccess:    stmt.DataAccess,
+		Deterministic: stmt.Deterministic,
+		Comment:       stmt.Comment,
+		Created:       time.Now(),
+	}
+	routineInfo.SQLMode, _ = sessVars.GetSystemVar(variable.SQLModeVar)
+	routineInfo.Charset, _ = sessVars.GetSystemVar(variable.CharacterSetClient)
+	routineInfo.Collate, _ = sessVars.GetSystemVar(variable.CollationConnection)
+
+	// Parameters and the return value use the default charset of the database
+	// if their charset is not specified, like columns do.
+	resolveType := func(tp *types.FieldType, name string) error {
+		chs, coll, err := ResolveCharsetCollation(
+			ast.CharsetOpt{Chs: tp.Charset, Col: tp.Collate},
+			ast.CharsetOpt{Chs: schema.Charset, Col: schema.Collate},
+		)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return setCharsetCollationFlenDecimal(tp, name, chs, coll, sessVars)
+	}
+	names := make(map[string]struct{}, len(stmt.Params))
+	for _, param := range stmt.Params {
+		name := model.NewCIStr(param.Name)
+		if _, ok := names[name.L]; ok {
+			return nil, dbterror.ErrSpDupParam.GenWithStackByArgs(param.Name)
+		}
+		names[name.L] = struct{}{}
+		tp := param.Tp.Clone()
+		if err := resolveType(tp, param.Name); err != nil {
+			return nil, err
+		}
+		routineInfo.Params = append(routineInfo.Params, &model.RoutineParam{Name: name, Mode: param.Mode, Tp: tp})
+	}
+
+	checker := &routineBodyChecker{tp: stmt.Type}
+	stmt.Body.Accept(checker)
+	if checker.err != nil {
+		return nil, checker.err
+	}
+	if stmt.Type == model.RoutineFunction {
+		if !checker.hasReturn {
+			return nil, dbterror.ErrSpNoreturn.GenWithStackByArgs(stmt.Name.Name.O)
+		}
+		routineInfo.ReturnType = stmt.ReturnType.Clone()
+		if err := resolveType(routineInfo.ReturnType, ""); err != nil {
+			return nil, err
+		}
+	} else if checker.hasReturn {
+		return nil, dbterror.ErrSpBadreturn
+	}
+	return routineInfo, nil
+}
+
+// routineBodyChecker checks the labels and the statements used in a routine body.
+type routineBodyChecker struct {
+	tp        model.RoutineType
+	labels    []string
+	loops     []bool
+	hasReturn bool
+	err       error
+}
+
+// Enter implements ast.Visitor interface.
+func (c *routineBodyChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch x := in.(type) {
+	case *ast.BlockStmt:
+		c.pushLabel(x.Label, false)
+	case *ast.LoopStmt:
+		c.pushLabel(x.Label, true)
+	case *ast.WhileStmt:
+		c.pushLabel(x.Label, true)
+	case *ast.RepeatStmt:
+		c.pushLabel(x.Label, true)
+	case *ast.LeaveStmt:
+		c.checkLabel(x.Label, "LEAVE", false)
+	case *ast.IterateStmt:
+		c.checkLabel(x.Label, "ITERATE", true)
+	case *ast.ReturnStmt:
+		c.hasReturn = true
+		return in, true
+	case ast.ExprNode:
+		return in, true
+	case *ast.DeclareVarStmt, *ast.DeclareHandlerStmt, *ast.IfStmt, *ast.SignalStmt, *ast.SetStmt,
+		*ast.OpenCursorStmt, *ast.FetchCursorStmt, *ast.CloseCursorStmt:
+	case *ast.DeclareCursorStmt:
+		// The query of a cursor doesn't return a result set to the client.
+		return in, true
+	default:
+		if _, ok := in.(ast.StmtNode); ok {
+			if c.tp == model.RoutineFunction {
+				c.checkFunctionStmt(in)
+			}
+			// The SQL statements can't contain any statements of the routine body.
+			return in, true
+		}
+	}
+	return in, c.err != nil
+}
+
+// checkFunctionStmt checks the SQL statement in a stored function, which can't
+// return a result set or commit the transaction.
+func (c *routineBodyChecker) checkFunctionStmt(in ast.Node) {
+	switch x := in.(type) {
+	case *ast.SelectStmt:
+		if x.SelectIntoOpt == nil {
+			c.err = dbterror.ErrSpNoRetset.GenWithStackByArgs("function")
+		}
+	case *ast.SetOprStmt, *ast.ShowStmt, *ast.ExplainStmt:
+		c.err = dbterror.ErrSpNoRetset.GenWithStackByArgs("function")
+	case ast.DDLNode, *ast.BeginStmt, *ast.CommitStmt, *ast.RollbackStmt:
+		c.err = dbterror.ErrCommitNotAllowedInSfOrTrg
+	}
+}
+
+func (c *routineBodyChecker) pushLabel(label string, isLoop bool) {
+	c.labels = append(c.labels, strings.ToLower(label))
+	c.loops = append(c.loops, isLoop)
+}
+
+func (c *routineBodyChecker) checkLabel(label, stmt string, needLoop bool) {
+	label = strings.ToLower(label)
+	for i := len(c.labels) - 1; i >= 0; i-- {
+		if c.labels[i] == label && (c.loops[i] || !needLoop) {
+			return
+		}
+	}
+	c.err = dbterror.ErrSpLilabelMismatch.GenWithStackByArgs(stmt, label)
+}
+
+// Leave implements ast.Visitor interface.
+func (c *routineBodyChecker) Leave(in ast.Node) (ast.Node, bool) {
+	switch in.(type) {
+	case *ast.BlockStmt, *ast.LoopStmt, *ast.WhileStmt, *ast.RepeatStmt:
+		c.labels = c.labels[:len(c.labels)-1]
+		c.loops = c.loops[:len(c.loops)-1]
+	}
+	return in, c.err == nil
+}
+
+func (d *ddl) DropRoutine(ctx sessionctx.Context, stmt *ast.DropRoutineStmt) (err error) {
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, ok := is.SchemaByName(stmt.Name.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(stmt.Name.Schema)
+	}
+	routine, ok := is.RoutineByName(stmt.Name.Schema, stmt.Name.Name, stmt.Type)
+	if !ok {
+		err = infoschema.ErrRoutineNotExists.GenWithStackByArgs(stmt.Type.String(), ast.Ident{Schema: stmt.Name.Schema, Name: stmt.Name.Name})
+		if stmt.IfExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionDropRoutine,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{routine.ID},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+func (d *ddl) CreateTrigger(ctx sessionctx.Context, stmt *ast.CreateTriggerStmt) (err error) {
+	ident := ast.Ident{Schema: stmt.Table.Schema, Name: stmt.Table.Name}
+	schema, tb, err := d.getSchemaAndTableByIdent(ctx, ident)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tbInfo := tb.Meta()
+	if util.IsMemOrSysDB(schema.Name.L) {
+		return dbterror.ErrNoTriggersOnSystemSchema
+	}
+	if tbInfo.IsView() || tbInfo.IsSequence() || tbInfo.TempTableType != model.TempTableNone {
+		return dbterror.ErrTrgOnViewOrTempTable.GenWithStackByArgs(tbInfo.Name.O)
+	}
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	if _, _, ok := is.TriggerByName(stmt.Name.Schema, stmt.Name.Name); ok {
+		if stmt.IfNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(infoschema.ErrTriggerExists)
+			return nil
+		}
+		return infoschema.ErrTriggerExists
+	}
+
+	triggerInfo, err := buildTriggerInfo(ctx, tbInfo, stmt)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	genIDs, err := d.genGlobalIDs(1)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	triggerInfo.ID = genIDs[0]
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tbInfo.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionCreateTrigger,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{triggerInfo, stmt.Order, model.NewCIStr(stmt.OtherTrigger)},
+	}
+	err = d.doDDLJob(ctx, job)
+	if infoschema.ErrTriggerExists.Equal(err) && stmt.IfNotExists {
+		ctx.GetSessionVars().StmtCtx.AppendNote(err)
+		err = nil
+	}
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+func buildTriggerInfo(ctx sessionctx.Context, tbInfo *model.TableInfo, stmt *ast.CreateTriggerStmt) (*model.TriggerInfo, error) {
+	sessVars := ctx.GetSessionVars()
+	triggerInfo := &model.TriggerInfo{
+		Name:    stmt.Name.Name,
+		Timing:  stmt.Timing,
+		Event:   stmt.Event,
+		Body:    stmt.Body.Text(),
+		Definer: stmt.Definer,
+		Created: time.Now(),
+	}
+	triggerInfo.SQLMode, _ = sessVars.GetSystemVar(variable.SQLModeVar)
+	triggerInfo.Charset, _ = sessVars.GetSystemVar(variable.CharacterSetClient)
+	triggerInfo.Collate, _ = sessVars.GetSystemVar(variable.CollationConnection)
+
+	// The body of a trigger is checked like the body of a stored procedure.
+	checker := &routineBodyChecker{tp: model.RoutineProcedure}
+	stmt.Body.Accept(checker)
+	if checker.err != nil {
+		return nil, checker.err
+	}
+	if checker.hasReturn {
+		return nil, dbterror.ErrSpBadreturn
+	}
+	rowChecker := &triggerRowChecker{tbInfo: tbInfo, timing: stmt.Timing, event: stmt.Event}
+	stmt.Body.Accept(rowChecker)
+	if rowChecker.err != nil {
+		return nil, rowChecker.err
+	}
+	return triggerInfo, nil
+}
+
+// triggerRowChecker checks the NEW and OLD rows used in a trigger body.
+type triggerRowChecker struct {
+	tbInfo *model.TableInfo
+	timing model.TriggerTiming
+	event  model.TriggerEvent
+	err    error
+}
+
+// Enter implements ast.Visitor interface.
+func (c *triggerRowChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch x := in.(type) {
+	case *ast.VariableAssignment:
+		// SET NEW.col = expr is parsed as the assignment of a system variable.
+		idx := strings.IndexByte(x.Name, '.')
+		if !x.IsSystem || x.IsGlobal || idx < 0 {
+			break
+		}
+		row, col := strings.ToUpper(x.Name[:idx]), x.Name[idx+1:]
+		switch {
+		case row == "OLD":
+			c.err = dbterror.ErrTrgCantChangeRow.GenWithStackByArgs("OLD", "")
+		case row != "NEW":
+		case c.timing == model.TriggerAfter:
+			c.err = dbterror.ErrTrgCantChangeRow.GenWithStackByArgs("NEW", "after ")
+		default:
+			c.checkRow(row, col)
+		}
+	case *ast.ColumnNameExpr:
+		if x.Name.Schema.L == "" {
+			c.checkRow(strings.ToUpper(x.Name.Table.O), x.Name.Name.O)
+		}
+	}
+	return in, c.err != nil
+}
+
+func (c *triggerRowChecker) checkRow(row, col string) {
+	switch {
+	case row == "NEW" && c.event == model.TriggerDelete, row == "OLD" && c.event == model.TriggerInsert:
+		c.err = dbterror.ErrTrgNoSuchRowInTrg.GenWithStackByArgs(row, c.event.String())
+	case row == "NEW" || row == "OLD":
+		if model.FindColumnInfo(c.tbInfo.Columns, strings.ToLower(col)) == nil {
+			c.err = dbterror.ErrBadField.GenWithStackByArgs(col, row)
+		}
+	}
+}
+
+// Leave implements ast.Visitor interface.
+func (c *triggerRowChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, c.err == nil
+}
+
+func (d *ddl) DropTrigger(ctx sessionctx.Context, stmt *ast.DropTriggerStmt) (err error) {
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, ok := is.SchemaByName(stmt.Name.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(stmt.Name.Schema)
+	}
+	tb, _, ok := is.TriggerByName(stmt.Name.Schema, stmt.Name.Name)
+	if !ok {
+		if stmt.IfExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(infoschema.ErrTriggerNotExists)
+			return nil
+		}
+		return infoschema.ErrTriggerNotExists
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tb.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionDropTrigger,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{stmt.Name.Name},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
 func (d *ddl) AlterTableCache(ctx sessionctx.Context, ti ast.Ident) (err error) {
 	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
 	if err != nil {
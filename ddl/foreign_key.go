@@ -18,7 +18,10 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/dbterror"
 )
 
@@ -35,6 +38,11 @@ func onCreateForeignKey(t *meta.Meta, job *model.Job) (ver int64, _ error) {
 		job.State = model.JobStateCancelled
 		return ver, errors.Trace(err)
 	}
+	if job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible {
+		// The foreign key is added together with the other sub-jobs in the non-revertible phase.
+		job.MarkNonRevertible()
+		return ver, nil
+	}
 	fkInfo.ID = allocateIndexID(tblInfo)
 	tblInfo.ForeignKeys = append(tblInfo.ForeignKeys, &fkInfo)
 
@@ -85,6 +93,11 @@ func onDropForeignKey(t *meta.Meta, job *model.Job) (ver int64, _ error) {
 		job.State = model.JobStateCancelled
 		return ver, infoschema.ErrForeignKeyNotExists.GenWithStackByArgs(fkName)
 	}
+	if job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible {
+		// The foreign key is dropped together with the other sub-jobs in the non-revertible phase.
+		job.MarkNonRevertible()
+		return ver, nil
+	}
 
 	nfks := tblInfo.ForeignKeys[:0]
 	for _, fk := range tblInfo.ForeignKeys {
@@ -112,3 +125,116 @@ func onDropForeignKey(t *meta.Meta, job *model.Job) (ver int64, _ error) {
 	}
 
 }
+
+// hasIndexOnColumns returns whether the table has a public index whose leading columns are cols,
+// which is used to look up the rows by the foreign key. The indexes in skipIdxs are ignored.
+func hasIndexOnColumns(tblInfo *model.TableInfo, cols []model.CIStr, skipIdxs []*model.IndexInfo) bool {
+	if len(cols) == 1 && tblInfo.PKIsHandle {
+		if pkCol := tblInfo.GetPkColInfo(); pkCol != nil && pkCol.Name.L == cols[0].L {
+			return true
+		}
+	}
+	for _, idxInfo := range tblInfo.Indices {
+		if idxInfo.State != model.StatePublic || len(idxInfo.Columns) < len(cols) || containsIndex(skipIdxs, idxInfo) {
+			continue
+		}
+		match := true
+		for i, col := range cols {
+			if idxInfo.Columns[i].Name.L != col.L {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// buildForeignKeyIndexKeys builds the keys of the index created for the foreign key,
+// if the table has no index on the foreign key columns. It returns nil if the index isn't needed.
+func buildForeignKeyIndexKeys(tblInfo *model.TableInfo, fk *model.FKInfo) []*ast.IndexPartSpecification {
+	if hasIndexOnColumns(tblInfo, fk.Cols, nil) {
+		return nil
+	}
+	keys := make([]*ast.IndexPartSpecification, 0, len(fk.Cols))
+	for _, col := range fk.Cols {
+		keys = append(keys, &ast.IndexPartSpecification{Column: &ast.ColumnName{Name: col}, Length: types.UnspecifiedLength})
+	}
+	return keys
+}
+
+// checkForeignKeyParentIndex checks the referenced table has an index on the referenced columns, which is skipped
+// if `foreign_key_checks` is off or the referenced table doesn't exist, the same as MySQL.
+// The table referring to itself is checked with tblInfo.
+func checkForeignKeyParentIndex(ctx sessionctx.Context, is infoschema.InfoSchema, schema model.CIStr, tblInfo *model.TableInfo,
+	fk *model.FKInfo) error {
+	if !ctx.GetSessionVars().ForeignKeyChecks {
+		return nil
+	}
+	parent := tblInfo
+	if refSchema := fk.GetRefSchema(schema); refSchema.L != schema.L || fk.RefTable.L != tblInfo.Name.L {
+		t, err := is.TableByName(refSchema, fk.RefTable)
+		if err != nil {
+			return nil
+		}
+		parent = t.Meta()
+	}
+	if !hasIndexOnColumns(parent, fk.RefCols, nil) {
+		return dbterror.ErrFkNoIndexParent.GenWithStackByArgs(fk.Name.O, fk.RefTable.O)
+	}
+	return nil
+}
+
+func containsIndex(idxInfos []*model.IndexInfo, idxInfo *model.IndexInfo) bool {
+	for _, idx := range idxInfos {
+		if idx == idxInfo {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDropIndexesNeededInForeignKey checks the indexes aren't the only indexes used by the foreign keys of the table,
+// or by the foreign keys referring to the table. The foreign keys of the table in droppedFKs are ignored.
+func checkDropIndexesNeededInForeignKey(is infoschema.InfoSchema, schema model.CIStr, tblInfo *model.TableInfo,
+	idxInfos []*model.IndexInfo, droppedFKs map[string]struct{}) error {
+	if len(idxInfos) == 0 {
+		return nil
+	}
+	checkNeeded := func(cols []model.CIStr) error {
+		if !hasIndexOnColumns(tblInfo, cols, nil) || hasIndexOnColumns(tblInfo, cols, idxInfos) {
+			return nil
+		}
+		for _, idxInfo := range idxInfos {
+			if !hasIndexOnColumns(tblInfo, cols, []*model.IndexInfo{idxInfo}) {
+				return dbterror.ErrDropIndexFk.GenWithStackByArgs(idxInfo.Name.O)
+			}
+		}
+		return dbterror.ErrDropIndexFk.GenWithStackByArgs(idxInfos[0].Name.O)
+	}
+	for _, fk := range tblInfo.ForeignKeys {
+		if _, ok := droppedFKs[fk.Name.L]; ok {
+			continue
+		}
+		if err := checkNeeded(fk.Cols); err != nil {
+			return err
+		}
+	}
+	for _, rfk := range is.ReferredForeignKeys(schema, tblInfo.Name) {
+		child, err := is.TableByName(rfk.ChildSchema, rfk.ChildTable)
+		if err != nil {
+			continue
+		}
+		for _, fk := range child.Meta().ForeignKeys {
+			if fk.Name.L != rfk.ChildFKName.L {
+				continue
+			}
+			if err := checkNeeded(fk.RefCols); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
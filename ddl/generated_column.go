@@ -272,6 +272,7 @@ type illegalFunctionChecker struct {
 	hasAggFunc           bool
 	hasRowVal            bool // hasRowVal checks whether the functional index refers to a row value
 	hasWindowFunc        bool
+	hasVariable          bool
 	hasNotGAFunc4ExprIdx bool
 	otherErr             error
 }
@@ -294,10 +295,15 @@ func (c *illegalFunctionChecker) Enter(inNode ast.Node) (outNode ast.Node, skipC
 		if !isFuncGA {
 			c.hasNotGAFunc4ExprIdx = true
 		}
-	case *ast.SubqueryExpr, *ast.ValuesExpr, *ast.VariableExpr:
-		// Subquery & `values(x)` & variable is not allowed
+	case *ast.SubqueryExpr, *ast.ValuesExpr:
+		// Subquery & `values(x)` is not allowed
 		c.hasIllegalFunc = true
 		return inNode, true
+	case *ast.VariableExpr:
+		// Variable is not allowed
+		c.hasIllegalFunc = true
+		c.hasVariable = true
+		return inNode, true
 	case *ast.AggregateFuncExpr:
 		// Aggregate function is not allowed
 		c.hasAggFunc = true
@@ -319,6 +325,7 @@ func (c *illegalFunctionChecker) Leave(inNode ast.Node) (node ast.Node, ok bool)
 const (
 	typeColumn = iota
 	typeIndex
+	typeCheckConstraint
 )
 
 func checkIllegalFn4Generated(name string, genType int, expr ast.ExprNode) error {
@@ -333,12 +340,18 @@ func checkIllegalFn4Generated(name string, genType int, expr ast.ExprNode) error
 			return dbterror.ErrGeneratedColumnFunctionIsNotAllowed.GenWithStackByArgs(name)
 		case typeIndex:
 			return dbterror.ErrFunctionalIndexFunctionIsNotAllowed.GenWithStackByArgs(name)
+		case typeCheckConstraint:
+			if c.hasVariable {
+				return dbterror.ErrCheckConstraintVariables.GenWithStackByArgs(name)
+			}
+			return dbterror.ErrCheckConstraintFunctionIsNotAllowed.GenWithStackByArgs(name)
 		}
 	}
 	if c.hasAggFunc {
 		return dbterror.ErrInvalidGroupFuncUse
 	}
 	if c.hasRowVal {
+		// Row values are allowed in check constraints, e.g. `check ((a, b) > (1, 2))`.
 		switch genType {
 		case typeColumn:
 			return dbterror.ErrGeneratedColumnRowValueIsNotAllowed.GenWithStackByArgs(name)
@@ -0,0 +1,464 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"github.com/pingcap/errors"
+	ddlutil "github.com/pingcap/tidb/ddl/util"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/util/dbterror"
+)
+
+// multiSchemaChange submits the sub-jobs collected from an ALTER TABLE statement
+// as one DDL job, which makes the sub-jobs public or rolls them back together.
+// The columns, indexes (including the expression indexes), check constraints and
+// foreign keys can be added, dropped, modified, renamed or altered in any combination,
+// with the following limits:
+//   - a column, an index or a constraint can't be operated by more than one spec;
+//   - a dropped or modified column can't be referenced by AFTER, or by a new index or constraint;
+//   - a dropped column can't be referenced by an index operated by the other specs,
+//     and neither can a modified column unless the index is dropped;
+//   - the expression indexes and the table-level check constraints can only refer to
+//     the existing columns;
+//   - the partitions can't be changed together with other specs, the same as MySQL.
+func (d *ddl) multiSchemaChange(ctx sessionctx.Context, ti ast.Ident, info *model.MultiSchemaInfo) error {
+	if len(info.SubJobs) == 0 {
+		return nil
+	}
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err = checkMultiSchemaInfo(d.infoCache.GetLatest(), schema.Name, info, t); err != nil {
+		return errors.Trace(err)
+	}
+
+	tzName, tzOffset := ddlutil.GetTimeZone(ctx)
+	job := &model.Job{
+		SchemaID:        schema.ID,
+		TableID:         t.Meta().ID,
+		SchemaName:      schema.Name.L,
+		Type:            model.ActionMultiSchemaChange,
+		BinlogInfo:      &model.HistoryInfo{},
+		MultiSchemaInfo: info,
+		ReorgMeta: &model.DDLReorgMeta{
+			SQLMode:       ctx.GetSessionVars().SQLMode,
+			Warnings:      make(map[errors.ErrorID]*terror.Error),
+			WarningsCount: make(map[errors.ErrorID]int64),
+			Location:      &model.TimeZoneLocation{Name: tzName, Offset: tzOffset},
+		},
+		Priority: ctx.GetSessionVars().DDLReorgPriority,
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// appendToSubJobs collects the job as a sub-job of the multi-schema change.
+func appendToSubJobs(m *model.MultiSchemaInfo, job *model.Job) error {
+	err := fillMultiSchemaInfo(m, job)
+	if err != nil {
+		return err
+	}
+	m.SubJobs = append(m.SubJobs, &model.SubJob{
+		Type:        job.Type,
+		Args:        job.Args,
+		RawArgs:     job.RawArgs,
+		SchemaState: job.SchemaState,
+		SnapshotVer: job.SnapshotVer,
+		Revertible:  true,
+		CtxVars:     job.CtxVars,
+	})
+	return nil
+}
+
+// fillMultiSchemaInfo records the columns, indexes and constraints changed by the job,
+// which are used to check the conflicts between the sub-jobs.
+func fillMultiSchemaInfo(info *model.MultiSchemaInfo, job *model.Job) error {
+	switch job.Type {
+	case model.ActionAddColumn:
+		col := job.Args[0].(*table.Column)
+		pos := job.Args[1].(*ast.ColumnPosition)
+		info.AddColumns = append(info.AddColumns, col.Name)
+		if pos != nil && pos.Tp == ast.ColumnPositionAfter {
+			info.RelativeColumns = append(info.RelativeColumns, pos.RelativeColumn.Name)
+		}
+		if len(job.Args) > 3 {
+			for _, constr := range job.Args[3].([]*model.ConstraintInfo) {
+				info.AddConstraints = append(info.AddConstraints, constr.Name)
+			}
+		}
+	case model.ActionDropColumn:
+		colName := job.Args[0].(model.CIStr)
+		info.DropColumns = append(info.DropColumns, colName)
+	case model.ActionModifyColumn:
+		var newColName model.CIStr
+		switch newCol := job.Args[0].(type) {
+		case **table.Column:
+			newColName = (*newCol).Name
+		case **model.ColumnInfo:
+			newColName = (*newCol).Name
+		}
+		oldColName := job.Args[1].(model.CIStr)
+		pos := job.Args[2].(*ast.ColumnPosition)
+		info.ModifyColumns = append(info.ModifyColumns, oldColName)
+		if newColName.L != oldColName.L {
+			info.ModifyColumns = append(info.ModifyColumns, newColName)
+		}
+		if pos != nil && pos.Tp == ast.ColumnPositionAfter {
+			info.RelativeColumns = append(info.RelativeColumns, pos.RelativeColumn.Name)
+		}
+	case model.ActionSetDefaultValue:
+		col := job.Args[0].(*table.Column)
+		info.ModifyColumns = append(info.ModifyColumns, col.Name)
+	case model.ActionAddIndex, model.ActionAddPrimaryKey:
+		indexName := job.Args[1].(model.CIStr)
+		indexPartSpecifications := job.Args[2].([]*ast.IndexPartSpecification)
+		info.AddIndexes = append(info.AddIndexes, indexName)
+		for _, indexPartSpecification := range indexPartSpecifications {
+			info.RelativeColumns = append(info.RelativeColumns, indexPartSpecification.Column.Name)
+		}
+		if job.Type == model.ActionAddIndex {
+			// The hidden columns of the expression index depend on the columns in the expressions.
+			for _, hiddenCol := range job.Args[4].([]*model.ColumnInfo) {
+				for colName := range hiddenCol.Dependences {
+					info.RelativeColumns = append(info.RelativeColumns, model.NewCIStr(colName))
+				}
+			}
+		}
+	case model.ActionDropIndex, model.ActionDropPrimaryKey:
+		indexName := job.Args[0].(model.CIStr)
+		info.DropIndexes = append(info.DropIndexes, indexName)
+	case model.ActionRenameIndex:
+		from := job.Args[0].(model.CIStr)
+		to := job.Args[1].(model.CIStr)
+		info.AlterIndexes = append(info.AlterIndexes, from)
+		info.AddIndexes = append(info.AddIndexes, to)
+	case model.ActionAlterIndexVisibility:
+		indexName := job.Args[0].(model.CIStr)
+		info.AlterIndexes = append(info.AlterIndexes, indexName)
+	case model.ActionAddCheckConstraint:
+		constr := job.Args[0].(*model.ConstraintInfo)
+		info.AddConstraints = append(info.AddConstraints, constr.Name)
+		info.RelativeColumns = append(info.RelativeColumns, constr.ConstraintCols...)
+	case model.ActionDropCheckConstraint, model.ActionDropForeignKey:
+		constrName := job.Args[0].(model.CIStr)
+		info.DropConstraints = append(info.DropConstraints, constrName)
+	case model.ActionAlterCheckConstraint:
+		constrName := job.Args[0].(model.CIStr)
+		info.AlterConstraints = append(info.AlterConstraints, constrName)
+	case model.ActionAddForeignKey:
+		fkInfo := job.Args[0].(*model.FKInfo)
+		info.AddConstraints = append(info.AddConstraints, fkInfo.Name)
+		info.RelativeColumns = append(info.RelativeColumns, fkInfo.Cols...)
+	default:
+		return dbterror.ErrRunMultiSchemaChanges
+	}
+	return nil
+}
+
+func checkMultiSchemaInfo(is infoschema.InfoSchema, schema model.CIStr, info *model.MultiSchemaInfo, t table.Table) error {
+	err := checkOperateSameColumn(info)
+	if err != nil {
+		return err
+	}
+	err = checkOperateSameIndex(info, t.Meta())
+	if err != nil {
+		return err
+	}
+	err = checkOperateSameConstraint(info, t.Meta())
+	if err != nil {
+		return err
+	}
+	err = checkForeignKeysInMultiSchemaChange(is, schema, info, t.Meta())
+	if err != nil {
+		return err
+	}
+	err = checkDropVisibleColumnCnt(t, len(info.DropColumns)-len(info.AddColumns))
+	if err != nil {
+		return err
+	}
+	return checkAddColumnTooManyColumns(len(t.Cols()) + len(info.AddColumns) - len(info.DropColumns))
+}
+
+func checkOperateSameColumn(info *model.MultiSchemaInfo) error {
+	modifyCols := make(map[string]struct{})
+	for _, colNames := range [][]model.CIStr{info.AddColumns, info.DropColumns, info.ModifyColumns} {
+		for _, colName := range colNames {
+			if _, ok := modifyCols[colName.L]; ok {
+				return dbterror.ErrOperateSameColumn.GenWithStackByArgs(colName.O)
+			}
+			modifyCols[colName.L] = struct{}{}
+		}
+	}
+	// The relative columns can be the new added columns, but they can't be changed by the other sub-jobs.
+	for _, colNames := range [][]model.CIStr{info.DropColumns, info.ModifyColumns} {
+		for _, colName := range colNames {
+			for _, relative := range info.RelativeColumns {
+				if colName.L == relative.L {
+					return dbterror.ErrOperateSameColumn.GenWithStackByArgs(colName.O)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func checkOperateSameIndex(info *model.MultiSchemaInfo, tblInfo *model.TableInfo) error {
+	modifyIdx := make(map[string]struct{})
+	for _, idxNames := range [][]model.CIStr{info.AddIndexes, info.DropIndexes, info.AlterIndexes} {
+		for _, idxName := range idxNames {
+			if _, ok := modifyIdx[idxName.L]; ok {
+				return dbterror.ErrOperateSameIndex.GenWithStackByArgs(idxName.O)
+			}
+			modifyIdx[idxName.L] = struct{}{}
+		}
+	}
+	droppedCols := make(map[string]struct{})
+	for _, colName := range info.DropColumns {
+		droppedCols[colName.L] = struct{}{}
+	}
+	modifiedCols := make(map[string]struct{})
+	for _, colName := range info.ModifyColumns {
+		modifiedCols[colName.L] = struct{}{}
+	}
+	droppedIdx := make(map[string]struct{})
+	for _, idxName := range info.DropIndexes {
+		droppedIdx[idxName.L] = struct{}{}
+	}
+	for _, idxInfo := range tblInfo.Indices {
+		if _, ok := modifyIdx[idxInfo.Name.L]; !ok {
+			continue
+		}
+		_, isDropped := droppedIdx[idxInfo.Name.L]
+		for _, idxCol := range idxInfo.Columns {
+			// The indexes on the dropped or modified columns are changed by those sub-jobs implicitly.
+			// The modified column replaces its indexes by name, so they can still be dropped.
+			if _, ok := droppedCols[idxCol.Name.L]; ok {
+				return dbterror.ErrOperateSameIndex.GenWithStackByArgs(idxInfo.Name.O)
+			}
+			if _, ok := modifiedCols[idxCol.Name.L]; ok && !isDropped {
+				return dbterror.ErrOperateSameIndex.GenWithStackByArgs(idxInfo.Name.O)
+			}
+		}
+	}
+	return nil
+}
+
+func checkOperateSameConstraint(info *model.MultiSchemaInfo, tblInfo *model.TableInfo) error {
+	modifyConstr := make(map[string]struct{})
+	for _, constrNames := range [][]model.CIStr{info.AddConstraints, info.DropConstraints, info.AlterConstraints} {
+		for _, constrName := range constrNames {
+			if _, ok := modifyConstr[constrName.L]; ok {
+				return dbterror.ErrOperateSameConstraint.GenWithStackByArgs(constrName.O)
+			}
+			modifyConstr[constrName.L] = struct{}{}
+		}
+	}
+	changedCols := make(map[string]struct{})
+	for _, colNames := range [][]model.CIStr{info.DropColumns, info.ModifyColumns} {
+		for _, colName := range colNames {
+			changedCols[colName.L] = struct{}{}
+		}
+	}
+	for _, constr := range tblInfo.Constraints {
+		if _, ok := modifyConstr[constr.Name.L]; !ok {
+			continue
+		}
+		for _, colName := range constr.ConstraintCols {
+			// The check constraints on the dropped columns are dropped by those sub-jobs implicitly.
+			if _, ok := changedCols[colName.L]; ok {
+				return dbterror.ErrOperateSameConstraint.GenWithStackByArgs(constr.Name.O)
+			}
+		}
+	}
+	return nil
+}
+
+// checkForeignKeysInMultiSchemaChange checks the foreign keys still have the indexes on their columns
+// after the indexes and the foreign keys are dropped by the sub-jobs.
+func checkForeignKeysInMultiSchemaChange(is infoschema.InfoSchema, schema model.CIStr, info *model.MultiSchemaInfo,
+	tblInfo *model.TableInfo) error {
+	droppedIdxInfos := make([]*model.IndexInfo, 0, len(info.DropIndexes))
+	for _, idxName := range info.DropIndexes {
+		if idxInfo := tblInfo.FindIndexByName(idxName.L); idxInfo != nil {
+			droppedIdxInfos = append(droppedIdxInfos, idxInfo)
+		}
+	}
+	droppedFKs := make(map[string]struct{}, len(info.DropConstraints))
+	for _, constrName := range info.DropConstraints {
+		droppedFKs[constrName.L] = struct{}{}
+	}
+	return checkDropIndexesNeededInForeignKey(is, schema, tblInfo, droppedIdxInfos, droppedFKs)
+}
+
+// tableInfoInMultiSchemaChange returns the table info with the columns added by the previous sub-jobs
+// of the multi-schema change, so that the indexes can be built on the new columns.
+func tableInfoInMultiSchemaChange(ctx sessionctx.Context, tblInfo *model.TableInfo) *model.TableInfo {
+	info := ctx.GetSessionVars().StmtCtx.MultiSchemaInfo
+	if info == nil || len(info.AddColumns) == 0 {
+		return tblInfo
+	}
+	newTblInfo := tblInfo.Clone()
+	for _, sub := range info.SubJobs {
+		if sub.Type != model.ActionAddColumn {
+			continue
+		}
+		colInfo := sub.Args[0].(*table.Column).ToInfo().Clone()
+		colInfo.State = model.StatePublic
+		colInfo.Offset = len(newTblInfo.Columns)
+		newTblInfo.Columns = append(newTblInfo.Columns, colInfo)
+	}
+	return newTblInfo
+}
+
+func onMultiSchemaChange(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	if job.MultiSchemaInfo.Revertible {
+		// Handle the rolling back job.
+		if job.IsRollingback() {
+			// Rollback/cancel the sub-jobs in reverse order.
+			for i := len(job.MultiSchemaInfo.SubJobs) - 1; i >= 0; i-- {
+				sub := job.MultiSchemaInfo.SubJobs[i]
+				if sub.IsFinished() {
+					continue
+				}
+				proxyJob := sub.ToProxyJob(job)
+				ver, err = w.runDDLJob(d, t, &proxyJob)
+				sub.FromProxyJob(&proxyJob)
+				if dbterror.ErrCancelledDDLJob.Equal(err) {
+					// The sub-job is cancelled normally, keep the original error of the job.
+					err = nil
+				}
+				if err != nil {
+					return ver, err
+				}
+				break
+			}
+			if !allSubJobsFinished(job) {
+				return ver, nil
+			}
+			tblInfo, err := getTableInfo(t, job.TableID, job.SchemaID)
+			if err != nil {
+				return ver, errors.Trace(err)
+			}
+			job.FinishTableJob(model.JobStateRollbackDone, model.StateNone, ver, tblInfo)
+			return ver, nil
+		}
+
+		// Run the first executable sub-job until it reaches its last revertible state.
+		for i, sub := range job.MultiSchemaInfo.SubJobs {
+			if !sub.Revertible || sub.IsFinished() {
+				continue
+			}
+			proxyJob := sub.ToProxyJob(job)
+			ver, err = w.runDDLJob(d, t, &proxyJob)
+			sub.FromProxyJob(&proxyJob)
+			handleRevertibleException(job, sub, i, proxyJob.Error)
+			return ver, err
+		}
+		// All the sub-jobs are non-revertible, the job can't be rolled back from now on.
+		job.MarkNonRevertible()
+	}
+
+	// Run all the non-revertible sub-jobs one step forward in the same transaction,
+	// so that the changes of them become visible at the same schema version.
+	snapshot := make([]model.SubJob, len(job.MultiSchemaInfo.SubJobs))
+	for i, sub := range job.MultiSchemaInfo.SubJobs {
+		snapshot[i] = *sub
+	}
+	for _, sub := range job.MultiSchemaInfo.SubJobs {
+		if sub.IsFinished() {
+			continue
+		}
+		proxyJob := sub.ToProxyJob(job)
+		var subVer int64
+		subVer, err = w.runDDLJob(d, t, &proxyJob)
+		sub.FromProxyJob(&proxyJob)
+		if err != nil {
+			// The table info changes are discarded when the transaction is reset,
+			// so restore the sub-jobs to run them again.
+			for i, sub := range job.MultiSchemaInfo.SubJobs {
+				*sub = snapshot[i]
+			}
+			return ver, errors.Trace(err)
+		}
+		if subVer != 0 {
+			ver = subVer
+		}
+	}
+	if !allSubJobsFinished(job) {
+		job.SchemaState = model.StateWriteReorganization
+		return ver, nil
+	}
+	tblInfo, err := getTableInfo(t, job.TableID, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+func allSubJobsFinished(job *model.Job) bool {
+	for _, sub := range job.MultiSchemaInfo.SubJobs {
+		if !sub.IsFinished() {
+			return false
+		}
+	}
+	return true
+}
+
+// handleRevertibleException rolls back the job if the sub-job at idx fails in the revertible phase.
+func handleRevertibleException(job *model.Job, subJob *model.SubJob, idx int, err *terror.Error) {
+	if subJob.IsNormal() {
+		return
+	}
+	job.State = model.JobStateRollingback
+	job.Error = err
+	// The previous sub-jobs have been run, they should be rolled back;
+	// the following sub-jobs haven't been run, they can be cancelled directly.
+	for i, sub := range job.MultiSchemaInfo.SubJobs {
+		if i < idx {
+			sub.State = model.JobStateCancelling
+		}
+		if i > idx {
+			sub.State = model.JobStateCancelled
+		}
+	}
+}
+
+func rollingBackMultiSchemaChange(job *model.Job) error {
+	if !job.MultiSchemaInfo.Revertible {
+		// The sub-jobs have reached the non-revertible states, resume the job to run.
+		job.State = model.JobStateRunning
+		return nil
+	}
+	// Mark the running sub-jobs to be cancelling and the others to be cancelled.
+	for _, sub := range job.MultiSchemaInfo.SubJobs {
+		switch sub.State {
+		case model.JobStateRunning:
+			sub.State = model.JobStateCancelling
+		case model.JobStateNone:
+			sub.State = model.JobStateCancelled
+		}
+	}
+	job.State = model.JobStateRollingback
+	return dbterror.ErrCancelledDDLJob
+}
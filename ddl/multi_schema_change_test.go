@@ -0,0 +1,266 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/tidb/ddl"
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiSchemaChangeMixedSpecs(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int, b char(10), c int, d int, g int, key idx_c(c), key idx_d(d))")
+	tk.MustExec("insert into t values (1, '11', 1, 1, 1), (2, '22', 2, 2, 2)")
+
+	tk.MustExec("alter table t add column e int default 5, add index idx_e(e), modify column b int, drop column d, " +
+		"add unique index idx_a(a), alter column g set default 10, rename index idx_c to idx_c1, add column f int first")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `f` int(11) DEFAULT NULL,\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` int(11) DEFAULT NULL,\n" +
+		"  `c` int(11) DEFAULT NULL,\n" +
+		"  `g` int(11) DEFAULT '10',\n" +
+		"  `e` int(11) DEFAULT '5',\n" +
+		"  KEY `idx_c1` (`c`),\n" +
+		"  KEY `idx_e` (`e`),\n" +
+		"  UNIQUE KEY `idx_a` (`a`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("<nil> 1 11 1 1 5", "<nil> 2 22 2 2 5"))
+	tk.MustQuery("select a from t use index(idx_e) where e = 5 order by a").Check(testkit.Rows("1", "2"))
+	tk.MustExec("admin check table t")
+	tk.MustExec("insert into t (a, b) values (3, 33)")
+	tk.MustQuery("select * from t where a = 3").Check(testkit.Rows("<nil> 3 33 <nil> 10 5"))
+	tk.MustGetErrCode("insert into t (a) values (1)", errno.ErrDupEntry)
+
+	// The columns added by the same statement can be referenced by each other.
+	tk.MustExec("alter table t add column h int after e, add column i int after h, add index idx_hi(h, i), drop index idx_c1")
+	tk.MustQuery("select column_name from information_schema.columns where table_name = 't' order by ordinal_position").Check(
+		testkit.Rows("f", "a", "b", "c", "g", "e", "h", "i"))
+	tk.MustQuery("select key_name from information_schema.tidb_indexes where table_name = 't' order by key_name").Check(
+		testkit.Rows("idx_a", "idx_e", "idx_hi", "idx_hi"))
+	tk.MustExec("admin check table t")
+}
+
+func TestMultiSchemaChangeRollback(t *testing.T) {
+	store, dom, clean := testkit.CreateMockStoreAndDomain(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int, b int, c int, key idx_c(c))")
+	tk.MustExec("insert into t values (1, 1, 1), (2, 1, 2)")
+	createSQL := tk.MustQuery("show create table t").Rows()
+
+	// The unique index fails to be added, and the other sub-jobs are rolled back too.
+	tk.MustGetErrCode("alter table t add column d int, modify column a bigint not null, "+
+		"modify column c char(10), add unique index idx_b(b)", errno.ErrDupEntry)
+	tk.MustQuery("show create table t").Check(createSQL)
+	tk.MustExec("admin check table t")
+	tk.MustExec("insert into t values (null, 3, 3)")
+	tk.MustQuery("select * from t order by b, c").Check(testkit.Rows("1 1 1", "2 1 2", "<nil> 3 3"))
+
+	tk.MustGetErrCode("alter table t add column d int, modify column a int not null", errno.WarnDataTruncated)
+	tk.MustQuery("show create table t").Check(createSQL)
+	tk.MustExec("admin check table t")
+
+	// The job is cancelled before the sub-jobs become non-revertible.
+	tk.MustExec("delete from t where a is null")
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+	var jobID int64
+	var visibleCols int
+	hook := &ddl.TestDDLCallback{Do: dom}
+	hook.OnJobRunBeforeExported = func(job *model.Job) {
+		if jobID != 0 || job.Type != model.ActionMultiSchemaChange || job.State != model.JobStateRunning ||
+			job.MultiSchemaInfo.SubJobs[2].SchemaState != model.StateWriteOnly {
+			return
+		}
+		jobID = job.ID
+		// The added columns aren't visible before all the sub-jobs are done.
+		visibleCols = len(tk1.MustQuery("select * from t").Rows()[0])
+		tk1.MustQuery(fmt.Sprintf("admin cancel ddl jobs %d", job.ID)).Check(testkit.Rows(fmt.Sprintf("%d successful", job.ID)))
+	}
+	originalHook := dom.DDL().GetHook()
+	dom.DDL().SetHook(hook)
+	defer dom.DDL().SetHook(originalHook)
+	tk.MustGetErrCode("alter table t add column d int, add column e int, add index idx_d(d)", errno.ErrCancelledDDLJob)
+	require.NotZero(t, jobID)
+	require.Equal(t, 3, visibleCols)
+	tk.MustQuery("show create table t").Check(createSQL)
+	tk.MustExec("admin check table t")
+}
+
+func TestMultiSchemaChangeVariable(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustQuery("select @@global.tidb_enable_change_multi_schema").Check(testkit.Rows("1"))
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int, b int)")
+
+	// The multi-schema change is enabled by default.
+	tk.MustExec("alter table t add column c int, add index idx_c(c), modify column b bigint")
+	tk.MustQuery("select column_name, data_type from information_schema.columns where table_name = 't' order by ordinal_position").Check(
+		testkit.Rows("a int", "b bigint", "c int"))
+
+	tk.MustExec("set global tidb_enable_change_multi_schema = off")
+	tk.MustGetErrMsg("alter table t add column d int, add index idx_d(d)", "[ddl:8200]Unsupported multi schema change")
+	tk.MustGetErrMsg("alter table t add column (d int, e int)", "[ddl:8200]Unsupported multi schema change")
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+	tk1.MustGetErrMsg("alter table t add column d int, add index idx_d(d)", "[ddl:8200]Unsupported multi schema change")
+	tk.MustExec("set global tidb_enable_change_multi_schema = on")
+	tk.MustExec("alter table t add column d int, add index idx_d(d)")
+	tk.MustExec("alter table t drop index idx_c, drop column d")
+	tk.MustQuery("select column_name from information_schema.columns where table_name = 't' order by ordinal_position").Check(
+		testkit.Rows("a", "b", "c"))
+}
+
+func TestMultiSchemaChangeConstraints(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t, t1")
+	tk.MustExec("create table t1 (a int primary key)")
+	tk.MustExec("insert into t1 values (1), (2)")
+	tk.MustExec("create table t (a int, b int, c int, constraint chk_b check (b > 0))")
+	tk.MustExec("insert into t values (1, 1, 1), (2, 2, 2)")
+
+	// The check constraints and the foreign keys are added and dropped together with the other specs.
+	tk.MustExec("alter table t add column d int check (d > 0), add constraint chk_c check (c < 10), drop check chk_b, " +
+		"add foreign key fk_a(a) references t1(a), add column e int")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` int(11) DEFAULT NULL,\n" +
+		"  `c` int(11) DEFAULT NULL,\n" +
+		"  `d` int(11) DEFAULT NULL,\n" +
+		"  `e` int(11) DEFAULT NULL,\n" +
+		"  KEY `fk_a` (`a`),\n" +
+		"  CONSTRAINT `fk_a` FOREIGN KEY (`a`) REFERENCES `t1` (`a`),\n" +
+		"  CONSTRAINT `chk_c` CHECK ((`c` < 10)),\n" +
+		"  CONSTRAINT `t_chk_1` CHECK ((`d` > 0))\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustGetErrCode("insert into t (a, c) values (1, 10)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("insert into t (a, d) values (1, 0)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("insert into t (a) values (3)", errno.ErrNoReferencedRow2)
+	tk.MustExec("insert into t (a, b) values (1, 0)")
+
+	tk.MustExec("alter table t alter check chk_c not enforced, add column f int, add foreign key fk_f(f) references t1(a)")
+	tk.MustExec("insert into t (a, c) values (1, 10)")
+	tk.MustGetErrCode("insert into t (a, f) values (1, 3)", errno.ErrNoReferencedRow2)
+	tk.MustExec("alter table t drop foreign key fk_f, drop index fk_f, drop check t_chk_1")
+	tk.MustQuery("select constraint_name from information_schema.check_constraints").Check(testkit.Rows("chk_c"))
+	tk.MustQuery("select count(*) from information_schema.tidb_indexes where table_name = 't' and key_name = 'fk_f'").Check(testkit.Rows("0"))
+	tk.MustExec("insert into t (a, f) values (1, 3)")
+
+	// The index used by the foreign key can't be dropped unless the foreign key is dropped too.
+	tk.MustGetErrCode("alter table t add column g int, drop index fk_a", errno.ErrDropIndexFk)
+	tk.MustExec("alter table t drop foreign key fk_a, drop index fk_a")
+	tk.MustQuery("select count(*) from information_schema.key_column_usage where table_name = 't'").Check(testkit.Rows("0"))
+
+	// The constraint violated by the existing rows rolls back the other sub-jobs.
+	createSQL := tk.MustQuery("show create table t").Rows()
+	tk.MustGetErrCode("alter table t add column g int, add index idx_a(a), add constraint chk_a check (a < 2)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("alter table t modify column b bigint, alter check chk_c enforced", errno.ErrCheckConstraintViolated)
+	tk.MustQuery("show create table t").Check(createSQL)
+	tk.MustExec("admin check table t")
+}
+
+func TestMultiSchemaChangeExpressionIndex(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int, b int, key idx_ab((a + b)))")
+	tk.MustExec("insert into t values (1, 1), (2, 2)")
+
+	tk.MustExec("alter table t add column c int default 3, add index idx_a((a * 2)), drop index idx_ab, add index idx_c(c)")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` int(11) DEFAULT NULL,\n" +
+		"  `c` int(11) DEFAULT '3',\n" +
+		"  KEY `idx_a` ((`a` * 2)),\n" +
+		"  KEY `idx_c` (`c`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustQuery("select a from t use index(idx_a) where a * 2 = 4").Check(testkit.Rows("2"))
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 1 3", "2 2 3"))
+	tk.MustExec("admin check table t")
+
+	tk.MustExec("alter table t drop index idx_a, add column d int, add index idx_b((b + 1)), drop column c")
+	tk.MustQuery("select a from t use index(idx_b) where b + 1 = 2").Check(testkit.Rows("1"))
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 1 <nil>", "2 2 <nil>"))
+	tk.MustExec("admin check table t")
+
+	// The expression index can't refer to the dropped or modified columns.
+	tk.MustGetErrCode("alter table t drop column a, add index idx_a((a + 1))", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t modify column b bigint, add index idx_b1((b * 2))", errno.ErrUnsupportedDDLOperation)
+}
+
+func TestMultiSchemaChangeConflicts(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int, b int, c int, key idx_a(a), key idx_bc(b, c), constraint chk_c check (c > 0))")
+
+	tk.MustGetErrMsg("alter table t modify column a bigint, drop column a", "[ddl:8200]Unsupported operate same column 'a'")
+	tk.MustGetErrCode("alter table t change column a d int, change column b d int", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t add column d int after a, drop column a", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t drop column c, add index idx_c(c)", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrMsg("alter table t add index idx_d(a), rename index idx_a to idx_d", "[ddl:8200]Unsupported operate same index 'idx_d'")
+	tk.MustGetErrMsg("alter table t modify column c char(10), rename index idx_bc to idx_bc1", "[ddl:8200]Unsupported operate same index 'idx_bc'")
+	tk.MustGetErrMsg("alter table t drop column a, drop index idx_a", "[ddl:8200]Unsupported operate same index 'idx_a'")
+	tk.MustGetErrMsg("alter table t drop check chk_c, alter check chk_c not enforced", "[ddl:8200]Unsupported operate same constraint 'chk_c'")
+	tk.MustGetErrMsg("alter table t modify column c bigint, drop check chk_c", "[ddl:8200]Unsupported operate same constraint 'chk_c'")
+	tk.MustGetErrCode("alter table t drop column b, add constraint chk_b check (b > 0)", errno.ErrUnsupportedDDLOperation)
+	// The partitions can't be changed with other specs, the same as MySQL.
+	tk.MustExec("create table t2 (a int) partition by range (a) (partition p0 values less than (10))")
+	tk.MustGetErrMsg("alter table t2 add column d int, add partition (partition p1 values less than (20))", "[ddl:8200]Unsupported multi schema change")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` int(11) DEFAULT NULL,\n" +
+		"  `c` int(11) DEFAULT NULL,\n" +
+		"  KEY `idx_a` (`a`),\n" +
+		"  KEY `idx_bc` (`b`,`c`),\n" +
+		"  CONSTRAINT `chk_c` CHECK ((`c` > 0))\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+
+	// The indexes on the modified column can be dropped together.
+	tk.MustExec("insert into t values (1, 1, 1)")
+	tk.MustExec("alter table t modify column c char(10), drop index idx_bc")
+	tk.MustQuery("select key_name from information_schema.tidb_indexes where table_name = 't'").Check(testkit.Rows("idx_a"))
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 1 1"))
+	tk.MustExec("admin check table t")
+}
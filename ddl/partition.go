@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -33,6 +34,7 @@ import (
 	"github.com/pingcap/tidb/domain/infosync"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta"
 	"github.com/pingcap/tidb/metrics"
 	"github.com/pingcap/tidb/parser"
@@ -42,6 +44,7 @@ import (
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/tablecodec"
 	"github.com/pingcap/tidb/types"
 	driver "github.com/pingcap/tidb/types/parser_driver"
@@ -51,8 +54,10 @@ import (
 	"github.com/pingcap/tidb/util/dbterror"
 	"github.com/pingcap/tidb/util/hack"
 	"github.com/pingcap/tidb/util/logutil"
+	decoder "github.com/pingcap/tidb/util/rowDecoder"
 	"github.com/pingcap/tidb/util/slice"
 	"github.com/pingcap/tidb/util/sqlexec"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tikv/client-go/v2/tikv"
 	"go.uber.org/zap"
 )
@@ -400,10 +405,9 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 				enable = true
 			}
 		}
-	case model.PartitionTypeHash:
-		// Partition by hash is enabled by default.
-		// Note that linear hash is not enabled.
-		if !s.Linear && s.Sub == nil {
+	case model.PartitionTypeHash, model.PartitionTypeKey:
+		// Partition by [linear] hash and [linear] key is enabled by default.
+		if s.Sub == nil {
 			enable = true
 		}
 	case model.PartitionTypeList:
@@ -417,12 +421,20 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 	}
 
 	pi := &model.PartitionInfo{
-		Type:   s.Tp,
-		Enable: enable,
-		Num:    s.Num,
+		Type:     s.Tp,
+		Enable:   enable,
+		Num:      s.Num,
+		IsLinear: s.Linear,
+	}
+	if s.KeyAlgorithm != nil {
+		pi.KeyAlgorithm = s.KeyAlgorithm.Type
 	}
 	tbInfo.Partition = pi
-	if s.Expr != nil {
+	if s.Tp == model.PartitionTypeKey {
+		if err := buildKeyPartitionColumns(tbInfo, s.ColumnNames); err != nil {
+			return errors.Trace(err)
+		}
+	} else if s.Expr != nil {
 		if err := checkPartitionFuncValid(ctx, tbInfo, s.Expr); err != nil {
 			return errors.Trace(err)
 		}
@@ -451,12 +463,76 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 	return nil
 }
 
+// buildKeyPartitionColumns sets the partitioning columns of KEY partitioning. Like MySQL,
+// `PARTITION BY KEY()` uses the primary key, or a unique key on NOT NULL columns if the table
+// has no primary key.
+func buildKeyPartitionColumns(tbInfo *model.TableInfo, colNames []*ast.ColumnName) error {
+	pi := tbInfo.Partition
+	if len(colNames) == 0 {
+		pi.IsEmptyColumns = true
+		pi.Columns = getKeyPartitionDefaultColumns(tbInfo)
+		if len(pi.Columns) == 0 {
+			return errors.Trace(dbterror.ErrFieldNotFoundPart)
+		}
+	} else {
+		pi.Columns = make([]model.CIStr, 0, len(colNames))
+		for _, cn := range colNames {
+			pi.Columns = append(pi.Columns, cn.Name)
+		}
+	}
+	for _, col := range pi.Columns {
+		colInfo := getColumnInfoByName(tbInfo, col.L)
+		if colInfo == nil {
+			return errors.Trace(dbterror.ErrFieldNotFoundPart)
+		}
+		switch colInfo.Tp {
+		case mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob, mysql.TypeJSON, mysql.TypeGeometry:
+			return errors.Trace(dbterror.ErrBlobFieldInPartFunc)
+		}
+	}
+	return nil
+}
+
+func getKeyPartitionDefaultColumns(tbInfo *model.TableInfo) []model.CIStr {
+	if tbInfo.PKIsHandle {
+		return []model.CIStr{tbInfo.GetPkName()}
+	}
+	if pk := tables.FindPrimaryIndex(tbInfo); pk != nil {
+		return indexColumnNames(pk)
+	}
+	for _, idx := range tbInfo.Indices {
+		if !idx.Unique {
+			continue
+		}
+		usable := true
+		for _, idxCol := range idx.Columns {
+			colInfo := tbInfo.Columns[idxCol.Offset]
+			if idxCol.Length != types.UnspecifiedLength || !mysql.HasNotNullFlag(colInfo.Flag) {
+				usable = false
+				break
+			}
+		}
+		if usable {
+			return indexColumnNames(idx)
+		}
+	}
+	return nil
+}
+
+func indexColumnNames(idx *model.IndexInfo) []model.CIStr {
+	names := make([]model.CIStr, 0, len(idx.Columns))
+	for _, idxCol := range idx.Columns {
+		names = append(names, idxCol.Name)
+	}
+	return names
+}
+
 // buildPartitionDefinitionsInfo build partition definitions info without assign partition id. tbInfo will be constant
 func buildPartitionDefinitionsInfo(ctx sessionctx.Context, defs []*ast.PartitionDefinition, tbInfo *model.TableInfo) (partitions []model.PartitionDefinition, err error) {
 	switch tbInfo.Partition.Type {
 	case model.PartitionTypeRange:
 		partitions, err = buildRangePartitionDefinitions(ctx, defs, tbInfo)
-	case model.PartitionTypeHash:
+	case model.PartitionTypeHash, model.PartitionTypeKey:
 		partitions, err = buildHashPartitionDefinitions(ctx, defs, tbInfo)
 	case model.PartitionTypeList:
 		partitions, err = buildListPartitionDefinitions(ctx, defs, tbInfo)
@@ -698,7 +774,7 @@ func checkAndOverridePartitionID(newTableInfo, oldTableInfo *model.TableInfo) er
 		return dbterror.ErrRepairTableFail.GenWithStackByArgs("Partition type should be the same")
 	}
 	// Check whether partitionType is hash partition.
-	if newTableInfo.Partition.Type == model.PartitionTypeHash {
+	if newTableInfo.Partition.Type == model.PartitionTypeHash || newTableInfo.Partition.Type == model.PartitionTypeKey {
 		if newTableInfo.Partition.Num != oldTableInfo.Partition.Num {
 			return dbterror.ErrRepairTableFail.GenWithStackByArgs("Hash partition num should be the same")
 		}
@@ -1167,6 +1243,502 @@ func (w *worker) onDropTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (
 	return ver, errors.Trace(err)
 }
 
+// getReorgSourceDefinitions returns the definitions of the partitions to be reorganized
+// in the order of the table, the partitions must exist and be consecutive.
+func getReorgSourceDefinitions(tblInfo *model.TableInfo, partLowerNames []string) ([]model.PartitionDefinition, error) {
+	defs := tblInfo.Partition.Definitions
+	if len(partLowerNames) > len(defs) {
+		return nil, errors.Trace(dbterror.ErrReorgPartitionNotExist)
+	}
+	offsets := make(map[string]int, len(defs))
+	for i, def := range defs {
+		offsets[def.Name.L] = i
+	}
+	idxs := make([]int, 0, len(partLowerNames))
+	dupCheck := make(map[string]struct{}, len(partLowerNames))
+	for _, pn := range partLowerNames {
+		idx, ok := offsets[pn]
+		if _, dup := dupCheck[pn]; !ok || dup {
+			return nil, errors.Trace(dbterror.ErrDropPartitionNonExistent.GenWithStackByArgs("REORGANIZE"))
+		}
+		dupCheck[pn] = struct{}{}
+		idxs = append(idxs, idx)
+	}
+	sort.Ints(idxs)
+	for i := 1; i < len(idxs); i++ {
+		if idxs[i] != idxs[i-1]+1 {
+			return nil, errors.Trace(dbterror.ErrConsecutiveReorgPartitions)
+		}
+	}
+	sourceDefs := make([]model.PartitionDefinition, 0, len(idxs))
+	return append(sourceDefs, defs[idxs[0]:idxs[len(idxs)-1]+1]...), nil
+}
+
+// checkReorgPartitionRange checks the new range partitions cover the same range as the
+// reorganized partitions, only the range of the last partition of the table can be extended.
+func checkReorgPartitionRange(ctx sessionctx.Context, tblInfo *model.TableInfo, sourceDefs, newDefs []model.PartitionDefinition) error {
+	pi := tblInfo.Partition
+	oldLast, newLast := &sourceDefs[len(sourceDefs)-1], &newDefs[len(newDefs)-1]
+	isLast := oldLast.ID == pi.Definitions[len(pi.Definitions)-1].ID
+
+	var extended, shrunk bool
+	if len(pi.Columns) == 0 {
+		oldMax := strings.EqualFold(oldLast.LessThan[0], partitionMaxValue)
+		newMax := strings.EqualFold(newLast.LessThan[0], partitionMaxValue)
+		if oldMax || newMax {
+			extended, shrunk = newMax && !oldMax, oldMax && !newMax
+		} else {
+			unsigned := isColUnsigned(tblInfo.Columns, pi)
+			oldVal, _, err := getRangeValue(ctx, oldLast.LessThan[0], unsigned)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			newVal, _, err := getRangeValue(ctx, newLast.LessThan[0], unsigned)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if unsigned {
+				extended, shrunk = newVal.(uint64) > oldVal.(uint64), newVal.(uint64) < oldVal.(uint64)
+			} else {
+				extended, shrunk = newVal.(int64) > oldVal.(int64), newVal.(int64) < oldVal.(int64)
+			}
+		}
+	} else {
+		// checkTwoRangeColumns reports true for both orders if the ranges are equal MAXVALUEs.
+		newGreater, err := checkTwoRangeColumns(ctx, newLast, oldLast, pi, tblInfo)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		oldGreater, err := checkTwoRangeColumns(ctx, oldLast, newLast, pi, tblInfo)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		extended, shrunk = newGreater && !oldGreater, oldGreater && !newGreater
+	}
+	if shrunk || (extended && !isLast) {
+		return errors.Trace(dbterror.ErrReorgOutsideRange)
+	}
+	return nil
+}
+
+// getReorganizedTableInfo builds the table info in which the new partitions have replaced
+// the reorganized partitions, it's used to locate the partitions of the backfilled rows.
+func getReorganizedTableInfo(tblInfo *model.TableInfo) *model.TableInfo {
+	nt := tblInfo.Clone()
+	np := *tblInfo.Partition
+	np.Definitions = np.ReplaceDefinitions(np.DroppingDefinitions, np.AddingDefinitions)
+	if np.Type == model.PartitionTypeHash || np.Type == model.PartitionTypeKey {
+		np.Num = uint64(len(np.Definitions))
+	}
+	np.AddingDefinitions = nil
+	np.DroppingDefinitions = nil
+	np.DDLState = model.StateNone
+	nt.Partition = &np
+	return nt
+}
+
+// onReorganizePartition reorganizes the partitions in DroppingDefinitions into the
+// partitions in AddingDefinitions. The partition info goes through the states:
+// none -> delete only -> write only -> write reorganization -> delete reorganization -> none.
+// Before the delete reorganization state, the source partitions are public and the writes
+// are mirrored into the new partitions; after it the new partitions are public and the
+// writes are mirrored into the source partitions, which are dropped when the job is done.
+func (w *worker) onReorganizePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	// Handle the rolling back job
+	if job.IsRollingback() {
+		ver, err := w.onRollbackReorganizePartition(t, job)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		return ver, nil
+	}
+
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	pi := tblInfo.GetPartitionInfo()
+	if pi == nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(dbterror.ErrPartitionMgmtOnNonpartitioned)
+	}
+
+	originalState := job.SchemaState
+	switch job.SchemaState {
+	case model.StateNone:
+		var partNames []string
+		partInfo := &model.PartitionInfo{}
+		if err = job.DecodeArgs(&partNames, &partInfo); err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+		sourceDefs, err := getReorgSourceDefinitions(tblInfo, partNames)
+		if err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+		err = checkAddPartitionTooManyPartitions(uint64(len(pi.Definitions) - len(sourceDefs) + len(partInfo.Definitions)))
+		if err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+
+		for _, def := range partInfo.Definitions {
+			if _, err = checkPlacementPolicyRefValidAndCanNonValidJob(t, job, def.PlacementPolicyRef); err != nil {
+				return ver, errors.Trace(err)
+			}
+		}
+		bundles, err := alterTablePartitionBundles(t, tblInfo, partInfo.Definitions)
+		if err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+		if err = infosync.PutRuleBundlesWithDefaultRetry(context.TODO(), bundles); err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Wrapf(err, "failed to notify PD the placement rules")
+		}
+
+		updateAddingPartitionInfo(partInfo, tblInfo)
+		pi.DroppingDefinitions = sourceDefs
+		// none -> delete only
+		pi.DDLState = model.StateDeleteOnly
+		job.SchemaState = model.StateDeleteOnly
+		ver, err = updateVersionAndTableInfoWithCheck(t, job, tblInfo, true)
+	case model.StateDeleteOnly:
+		// Once all servers mirror the deletes into the new partitions, no stale row can be
+		// left in them by mirroring the writes as well.
+		// delete only -> write only
+		pi.DDLState = model.StateWriteOnly
+		job.SchemaState = model.StateWriteOnly
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != job.SchemaState)
+	case model.StateWriteOnly:
+		// write only -> write reorganization
+		pi.DDLState = model.StateWriteReorganization
+		job.SchemaState = model.StateWriteReorganization
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != job.SchemaState)
+	case model.StateWriteReorganization:
+		tbl, err := getTable(d.store, job.SchemaID, tblInfo)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		physicalTableIDs := getPartitionIDsFromDefinitions(pi.DroppingDefinitions)
+		// The element is only used to record the reorganization handle.
+		elements := []*meta.Element{{ID: tblInfo.ID, TypeKey: meta.PartitionElementKey}}
+		reorgInfo, err := getReorgInfoFromPartitions(d, t, job, tbl, physicalTableIDs, elements)
+		if err != nil || reorgInfo.first {
+			// If we run reorg firstly, we should update the job snapshot version
+			// and then run the reorg next time.
+			return ver, errors.Trace(err)
+		}
+		err = w.runReorgJob(t, reorgInfo, tbl.Meta(), d.lease, func() (reorgErr error) {
+			defer tidbutil.Recover(metrics.LabelDDL, "onReorganizePartition",
+				func() {
+					reorgErr = dbterror.ErrCancelledDDLJob.GenWithStack("reorganize partition for table `%v` panic", tblInfo.Name)
+				}, false)
+			return w.reorgPartitionDataAndIndex(tbl.(table.PartitionedTable), physicalTableIDs, reorgInfo)
+		})
+		if err != nil {
+			if dbterror.ErrWaitReorgTimeout.Equal(err) {
+				// if timeout, we should return, check for the owner and re-wait job done.
+				return ver, nil
+			}
+			if dbterror.ErrCancelledDDLJob.Equal(err) || dbterror.ErrCantDecodeRecord.Equal(err) || table.ErrNoPartitionForGivenValue.Equal(err) {
+				logutil.BgLogger().Warn("[ddl] run reorganize partition job failed, convert job to rollback", zap.String("job", job.String()), zap.Error(err))
+				job.State = model.JobStateRollingback
+				if err1 := t.RemoveDDLReorgHandle(job, reorgInfo.elements); err1 != nil {
+					logutil.BgLogger().Warn("[ddl] run reorganize partition job failed, convert job to rollback, RemoveDDLReorgHandle failed", zap.String("job", job.String()), zap.Error(err1))
+				}
+			}
+			// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
+			w.reorgCtx.cleanNotifyReorgCancel()
+			return ver, errors.Trace(err)
+		}
+		// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
+		w.reorgCtx.cleanNotifyReorgCancel()
+
+		// The new partitions take the place of the source partitions, which are kept up to
+		// date until no server reads them anymore.
+		// write reorganization -> delete reorganization
+		pi.Definitions = pi.ReplaceDefinitions(pi.DroppingDefinitions, pi.AddingDefinitions)
+		if pi.Type == model.PartitionTypeHash || pi.Type == model.PartitionTypeKey {
+			// The rows are hashed by the new number of partitions from now on.
+			pi.Num = uint64(len(pi.Definitions))
+		}
+		pi.DDLState = model.StateDeleteReorganization
+		job.SchemaState = model.StateDeleteReorganization
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != job.SchemaState)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+	case model.StateDeleteReorganization:
+		physicalTableIDs := getPartitionIDsFromDefinitions(pi.DroppingDefinitions)
+		newNames := make(map[string]struct{}, len(pi.AddingDefinitions))
+		for _, def := range pi.AddingDefinitions {
+			newNames[def.Name.L] = struct{}{}
+		}
+		droppedNames := make([]string, 0, len(pi.DroppingDefinitions))
+		for _, def := range pi.DroppingDefinitions {
+			if _, ok := newNames[def.Name.L]; !ok {
+				droppedNames = append(droppedNames, def.Name.L)
+			}
+		}
+		if err = dropLabelRules(d, job.SchemaName, tblInfo.Name.L, droppedNames); err != nil {
+			return ver, errors.Wrapf(err, "failed to notify PD the label rules")
+		}
+
+		partInfo := &model.PartitionInfo{Definitions: pi.AddingDefinitions}
+		pi.AddingDefinitions = nil
+		pi.DroppingDefinitions = nil
+		pi.DDLState = model.StateNone
+		// used by ApplyDiff in updateSchemaVersion
+		job.CtxVars = []interface{}{physicalTableIDs}
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
+		asyncNotifyEvent(d, &util.Event{Tp: model.ActionReorganizePartition, TableInfo: tblInfo, PartInfo: partInfo})
+		// A background job will be created to delete old partition data.
+		job.Args = []interface{}{physicalTableIDs}
+	default:
+		err = dbterror.ErrInvalidDDLState.GenWithStackByArgs("partition", job.SchemaState)
+	}
+	return ver, errors.Trace(err)
+}
+
+// onRollbackReorganizePartition removes the new partitions of the reorganize partition job
+// before they become public, the source partitions are left untouched.
+func (w *worker) onRollbackReorganizePartition(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	physicalTableIDs, _, rollbackBundles := rollbackAddingPartitionInfo(tblInfo)
+	if err = infosync.PutRuleBundlesWithDefaultRetry(context.TODO(), rollbackBundles); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Wrapf(err, "failed to notify PD the placement rules")
+	}
+	tblInfo.Partition.DroppingDefinitions = nil
+	tblInfo.Partition.DDLState = model.StateNone
+	// used by ApplyDiff in updateSchemaVersion
+	job.CtxVars = []interface{}{physicalTableIDs}
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateRollbackDone, model.StateNone, ver, tblInfo)
+	// A background job will be created to delete the data of the new partitions.
+	job.Args = []interface{}{physicalTableIDs}
+	return ver, nil
+}
+
+// reorgPartitionDataAndIndex copies the rows of the source partitions into the new partitions.
+func (w *worker) reorgPartitionDataAndIndex(t table.PartitionedTable, physicalTableIDs []int64, reorgInfo *reorgInfo) error {
+	var err error
+	var finish bool
+	for !finish {
+		p := t.GetPartition(reorgInfo.PhysicalTableID)
+		if p == nil {
+			return dbterror.ErrCancelledDDLJob.GenWithStack("Can not find partition id %d for table %d", reorgInfo.PhysicalTableID, t.Meta().ID)
+		}
+		logutil.BgLogger().Info("[ddl] start to reorganize partition", zap.String("job", reorgInfo.Job.String()), zap.String("reorgInfo", reorgInfo.String()))
+		err = w.writePhysicalTableRecord(p, typeReorgPartitionWorker, nil, nil, nil, reorgInfo)
+		if err != nil {
+			break
+		}
+		finish, err = w.updateReorgInfoForPartitions(t, reorgInfo, physicalTableIDs)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	return errors.Trace(err)
+}
+
+type reorgPartitionWorker struct {
+	*backfillWorker
+	metricCounter prometheus.Counter
+
+	// reorgedTbl is the table with the new partitions in place of the source partitions.
+	reorgedTbl table.PartitionedTable
+	// newPartitions is the IDs of the new partitions.
+	newPartitions map[int64]struct{}
+
+	// The following attributes are used to reduce memory allocation.
+	rowRecords  []*reorgPartitionRecord
+	rowDecoder  *decoder.RowDecoder
+	rowMap      map[int64]types.Datum
+	defaultVals []types.Datum
+}
+
+type reorgPartitionRecord struct {
+	key    kv.Key // It's the record key in the source partition, used to lock the record.
+	handle kv.Handle
+	vals   []byte // It's the raw record, which is the same in the new partition.
+	row    []types.Datum
+	// newPart is the new partition the record is copied into.
+	newPart table.PhysicalTable
+}
+
+func newReorgPartitionWorker(sessCtx sessionctx.Context, worker *worker, id int, t table.PhysicalTable, decodeColMap map[int64]decoder.Column) (*reorgPartitionWorker, error) {
+	reorgedTbl, err := tables.TableFromMeta(nil, getReorganizedTableInfo(t.Meta()))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	pi := t.Meta().GetPartitionInfo()
+	newPartitions := make(map[int64]struct{}, len(pi.AddingDefinitions))
+	for _, def := range pi.AddingDefinitions {
+		newPartitions[def.ID] = struct{}{}
+	}
+	return &reorgPartitionWorker{
+		backfillWorker: newBackfillWorker(sessCtx, worker, id, t),
+		metricCounter:  metrics.BackfillTotalCounter.WithLabelValues("reorg_partition_rate"),
+		reorgedTbl:     reorgedTbl.(table.PartitionedTable),
+		newPartitions:  newPartitions,
+		rowDecoder:     decoder.NewRowDecoder(t, t.WritableCols(), decodeColMap),
+		rowMap:         make(map[int64]types.Datum, len(decodeColMap)),
+		defaultVals:    make([]types.Datum, len(t.WritableCols())),
+	}, nil
+}
+
+func (w *reorgPartitionWorker) AddMetricInfo(cnt float64) {
+	w.metricCounter.Add(cnt)
+}
+
+func (w *reorgPartitionWorker) getRowRecord(handle kv.Handle, recordKey kv.Key, rawRow []byte) error {
+	sysTZ := w.sessCtx.GetSessionVars().StmtCtx.TimeZone
+	_, err := w.rowDecoder.DecodeAndEvalRowWithMap(w.sessCtx, handle, rawRow, sysTZ, w.rowMap)
+	if err != nil {
+		return errors.Trace(dbterror.ErrCantDecodeRecord.GenWithStackByArgs("partition", err))
+	}
+	defer func() {
+		for id := range w.rowMap {
+			delete(w.rowMap, id)
+		}
+	}()
+
+	cols := w.table.WritableCols()
+	row := make([]types.Datum, len(w.table.Meta().Columns))
+	for _, col := range cols {
+		val, ok := w.rowMap[col.ID]
+		if !ok {
+			val, err = tables.GetColDefaultValue(w.sessCtx, col, w.defaultVals)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+		row[col.Offset] = val
+	}
+	newPart, err := w.reorgedTbl.GetPartitionByRow(w.sessCtx, row)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := w.newPartitions[newPart.GetPhysicalID()]; !ok {
+		return errors.Errorf("[ddl] the row %s of partition %d is not located in the new partitions", handle, w.table.(table.PhysicalTable).GetPhysicalID())
+	}
+	w.rowRecords = append(w.rowRecords, &reorgPartitionRecord{key: recordKey, handle: handle, vals: rawRow, row: row, newPart: newPart})
+	return nil
+}
+
+func (w *reorgPartitionWorker) fetchRowColVals(txn kv.Transaction, taskRange reorgBackfillTask) ([]*reorgPartitionRecord, kv.Key, bool, error) {
+	w.rowRecords = w.rowRecords[:0]
+	startTime := time.Now()
+
+	// taskDone means that the added handle is out of taskRange.endHandle.
+	taskDone := false
+	var lastAccessedHandle kv.Key
+	oprStartTime := startTime
+	err := iterateSnapshotRows(w.sessCtx.GetStore(), w.priority, w.table, txn.StartTS(), taskRange.startKey, taskRange.endKey,
+		func(handle kv.Handle, recordKey kv.Key, rawRow []byte) (bool, error) {
+			oprEndTime := time.Now()
+			logSlowOperations(oprEndTime.Sub(oprStartTime), "iterateSnapshotRows in reorgPartitionWorker fetchRowColVals", 0)
+			oprStartTime = oprEndTime
+
+			taskDone = recordKey.Cmp(taskRange.endKey) > 0
+
+			if taskDone || len(w.rowRecords) >= w.batchCnt {
+				return false, nil
+			}
+
+			if err1 := w.getRowRecord(handle, recordKey, rawRow); err1 != nil {
+				return false, errors.Trace(err1)
+			}
+			lastAccessedHandle = recordKey
+			if recordKey.Cmp(taskRange.endKey) == 0 {
+				// If taskRange.endIncluded == false, we will not reach here when handle == taskRange.endHandle.
+				taskDone = true
+				return false, nil
+			}
+			return true, nil
+		})
+
+	if len(w.rowRecords) == 0 {
+		taskDone = true
+	}
+
+	nextKey := taskRange.endKey.Next()
+	if !taskDone {
+		// The task is not done. So we need to pick the last processed entry's handle and add one.
+		nextKey = lastAccessedHandle.Next()
+	}
+	logutil.BgLogger().Debug("[ddl] txn fetches handle info", zap.Uint64("txnStartTS", txn.StartTS()), zap.String("taskRange", taskRange.String()), zap.Duration("takeTime", time.Since(startTime)))
+	return w.rowRecords, nextKey, taskDone, errors.Trace(err)
+}
+
+// BackfillDataInTxn copies the records and their index entries into the new partitions in a transaction.
+// The source records are locked, so the txn will rollback and retry if they are changed concurrently.
+func (w *reorgPartitionWorker) BackfillDataInTxn(handleRange reorgBackfillTask) (taskCtx backfillTaskContext, errInTxn error) {
+	oprStartTime := time.Now()
+	errInTxn = kv.RunInNewTxn(context.Background(), w.sessCtx.GetStore(), true, func(ctx context.Context, txn kv.Transaction) error {
+		taskCtx.addedCount = 0
+		taskCtx.scanCount = 0
+		txn.SetOption(kv.Priority, w.priority)
+
+		rowRecords, nextKey, taskDone, err := w.fetchRowColVals(txn, handleRange)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		taskCtx.nextKey = nextKey
+		taskCtx.done = taskDone
+
+		for _, record := range rowRecords {
+			taskCtx.scanCount++
+
+			err = txn.LockKeys(context.Background(), new(kv.LockCtx), record.key)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			err = txn.Set(tablecodec.EncodeRecordKey(record.newPart.RecordPrefix(), record.handle), record.vals)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			for _, idx := range record.newPart.Indices() {
+				if w.table.Meta().IsCommonHandle && idx.Meta().Primary {
+					continue
+				}
+				vals, err := idx.FetchValues(record.row, nil)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				rsData := tables.TryGetHandleRestoredDataWrapper(record.newPart, record.row, nil, idx.Meta())
+				handle, err := idx.Create(w.sessCtx, txn, vals, record.handle, rsData, table.WithIgnoreAssertion)
+				if err != nil {
+					if kv.ErrKeyExists.Equal(err) && record.handle.Equal(handle) {
+						// The index entry is already written by a mirrored write.
+						continue
+					}
+					return errors.Trace(err)
+				}
+			}
+			taskCtx.addedCount++
+		}
+		return nil
+	})
+	logSlowOperations(time.Since(oprStartTime), "ReorgPartitionBackfillDataInTxn", 3000)
+
+	return
+}
+
 // onTruncateTablePartition truncates old partition meta.
 func onTruncateTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (int64, error) {
 	var ver int64
@@ -1543,6 +2115,9 @@ func checkExchangePartitionRecordValidation(w *worker, pt *model.TableInfo, inde
 		if pi.Num == 1 {
 			return nil
 		}
+		if pi.IsLinear {
+			return dbterror.ErrUnsupportedPartitionType.GenWithStackByArgs(pt.Name.O)
+		}
 		var buf strings.Builder
 		buf.WriteString("select 1 from %n.%n where mod(")
 		buf.WriteString(pi.Expr)
@@ -1741,6 +2316,13 @@ func checkPartitioningKeysConstraints(sctx sessionctx.Context, s *ast.CreateTabl
 		partCols = columnInfoSlice(partColumns)
 	} else if len(s.Partition.ColumnNames) > 0 {
 		partCols = columnNameSlice(s.Partition.ColumnNames)
+	} else if tblInfo.Partition.IsEmptyColumns {
+		// `PARTITION BY KEY()` uses the columns of the primary key or a unique key.
+		partColumns := make([]*model.ColumnInfo, 0, len(tblInfo.Partition.Columns))
+		for _, col := range tblInfo.Partition.Columns {
+			partColumns = append(partColumns, getColumnInfoByName(tblInfo, col.L))
+		}
+		partCols = columnInfoSlice(partColumns)
 	} else {
 		// TODO: Check keys constraints for list, key partition type and so on.
 		return nil
@@ -111,6 +111,11 @@ func convertNotStartAddIdxJob2RollbackJob(t *meta.Meta, job *model.Job, occuredE
 // normal-type has only two states:    None -> Public
 // reorg-type has five states:         None -> Delete-only -> Write-only -> Write-org -> Public
 func rollingbackModifyColumn(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	if job.MultiSchemaInfo != nil && !job.MultiSchemaInfo.Revertible {
+		// The sub-job of multi-schema change has finished the reorganization, roll it back directly.
+		job.State = model.JobStateRollingback
+		return ver, dbterror.ErrCancelledDDLJob
+	}
 	// If the value of SnapshotVer isn't zero, it means the reorg workers have been started.
 	if job.SchemaState == model.StateWriteReorganization && job.SnapshotVer != 0 {
 		// column type change workers are started. we have to ask them to exit.
@@ -151,28 +156,32 @@ func rollingbackModifyColumn(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job)
 	return ver, dbterror.ErrCancelledDDLJob
 }
 
-func rollingbackAddColumn(t *meta.Meta, job *model.Job) (ver int64, err error) {
-	tblInfo, columnInfo, col, _, _, err := checkAddColumn(t, job)
-	if err != nil {
-		return ver, errors.Trace(err)
-	}
-	if columnInfo == nil {
-		job.State = model.JobStateCancelled
-		return ver, dbterror.ErrCancelledDDLJob
-	}
-
+// convertAddColumnJob2RollbackJob makes the column being added delete-only and rolls back the job with the occurred error.
+func convertAddColumnJob2RollbackJob(t *meta.Meta, job *model.Job, tblInfo *model.TableInfo, columnInfo *model.ColumnInfo, occurredErr error) (ver int64, err error) {
 	originalState := columnInfo.State
 	columnInfo.State = model.StateDeleteOnly
 	job.SchemaState = model.StateDeleteOnly
 
-	job.Args = []interface{}{col.Name}
+	job.Args = []interface{}{columnInfo.Name}
 	ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != columnInfo.State)
 	if err != nil {
 		return ver, errors.Trace(err)
 	}
 
 	job.State = model.JobStateRollingback
-	return ver, dbterror.ErrCancelledDDLJob
+	return ver, errors.Trace(occurredErr)
+}
+
+func rollingbackAddColumn(t *meta.Meta, job *model.Job) (ver int64, err error) {
+	tblInfo, columnInfo, _, _, _, _, err := checkAddColumn(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if columnInfo == nil {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrCancelledDDLJob
+	}
+	return convertAddColumnJob2RollbackJob(t, job, tblInfo, columnInfo, dbterror.ErrCancelledDDLJob)
 }
 
 func rollingbackAddColumns(t *meta.Meta, job *model.Job) (ver int64, err error) {
@@ -325,6 +334,10 @@ func rollingbackDropIndexes(t *meta.Meta, job *model.Job) (ver int64, err error)
 }
 
 func rollingbackAddIndex(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job, isPK bool) (ver int64, err error) {
+	if job.MultiSchemaInfo != nil && !job.MultiSchemaInfo.Revertible {
+		// The sub-job of multi-schema change has finished the backfilling, remove the indexInfo in tableInfo directly.
+		return convertNotStartAddIdxJob2RollbackJob(t, job, dbterror.ErrCancelledDDLJob)
+	}
 	// If the value of SnapshotVer isn't zero, it means the work is backfilling the indexes.
 	if job.SchemaState == model.StateWriteReorganization && job.SnapshotVer != 0 {
 		// add index workers are started. need to ask them to exit.
@@ -367,6 +380,34 @@ func rollingbackAddTablePartition(t *meta.Meta, job *model.Job) (ver int64, err
 	return convertAddTablePartitionJob2RollbackJob(t, job, dbterror.ErrCancelledDDLJob, tblInfo)
 }
 
+// rollingbackReorganizePartition converts the reorganize partition job into rolling back state,
+// which is only possible before the new partitions become public.
+func rollingbackReorganizePartition(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	// If the value of SnapshotVer isn't zero, it means the reorg workers have been started.
+	if job.SchemaState == model.StateWriteReorganization && job.SnapshotVer != 0 {
+		// reorganize partition workers are started. we have to ask them to exit.
+		logutil.Logger(w.logCtx).Info("[ddl] run the cancelling DDL job", zap.String("job", job.String()))
+		w.reorgCtx.notifyReorgCancel()
+		// Give the this kind of ddl one more round to run, the dbterror.ErrCancelledDDLJob should be fetched from the bottom up.
+		return w.onReorganizePartition(d, t, job)
+	}
+	_, err = getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	switch job.SchemaState {
+	case model.StateNone:
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrCancelledDDLJob
+	case model.StateDeleteReorganization:
+		// The new partitions are public, the job can't be rolled back.
+		job.State = model.JobStateRunning
+		return ver, nil
+	}
+	job.State = model.JobStateRollingback
+	return ver, dbterror.ErrCancelledDDLJob
+}
+
 func rollingbackDropTableOrView(t *meta.Meta, job *model.Job) error {
 	tblInfo, err := checkTableExistAndCancelNonExistJob(t, job, job.SchemaID)
 	if err != nil {
@@ -452,6 +493,8 @@ func convertJob2RollbackJob(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job)
 		ver, err = rollingbackAddIndex(w, d, t, job, true)
 	case model.ActionAddTablePartition:
 		ver, err = rollingbackAddTablePartition(t, job)
+	case model.ActionReorganizePartition:
+		ver, err = rollingbackReorganizePartition(w, d, t, job)
 	case model.ActionDropColumn:
 		ver, err = rollingbackDropColumn(t, job)
 	case model.ActionDropColumns:
@@ -472,12 +515,19 @@ func convertJob2RollbackJob(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job)
 		ver, err = rollingbackTruncateTable(t, job)
 	case model.ActionModifyColumn:
 		ver, err = rollingbackModifyColumn(w, d, t, job)
+	case model.ActionAddCheckConstraint:
+		ver, err = rollingbackAddCheckConstraint(t, job)
+	case model.ActionAlterCheckConstraint:
+		ver, err = rollingbackAlterCheckConstraint(t, job)
+	case model.ActionMultiSchemaChange:
+		err = rollingBackMultiSchemaChange(job)
 	case model.ActionRebaseAutoID, model.ActionShardRowID, model.ActionAddForeignKey,
 		model.ActionDropForeignKey, model.ActionRenameTable, model.ActionRenameTables,
 		model.ActionModifyTableCharsetAndCollate, model.ActionTruncateTablePartition,
 		model.ActionModifySchemaCharsetAndCollate, model.ActionRepairTable,
 		model.ActionModifyTableAutoIdCache, model.ActionAlterIndexVisibility,
-		model.ActionExchangeTablePartition, model.ActionModifySchemaDefaultPlacement:
+		model.ActionExchangeTablePartition, model.ActionModifySchemaDefaultPlacement,
+		model.ActionDropCheckConstraint:
 		ver, err = cancelOnlyNotHandledJob(job)
 	default:
 		job.State = model.JobStateCancelled
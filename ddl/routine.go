@@ -0,0 +1,104 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/util/dbterror"
+)
+
+func onCreateRoutine(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	schemaID := job.SchemaID
+	routineInfo := &model.RoutineInfo{}
+	if err := job.DecodeArgs(routineInfo); err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	routineInfo.State = model.StateNone
+
+	existRoutine, err := getRoutineByName(t, schemaID, routineInfo.Name, routineInfo.Type)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	if existRoutine != nil {
+		job.State = model.JobStateCancelled
+		return ver, infoschema.ErrRoutineExists.GenWithStackByArgs(routineInfo.Type.String(), routineInfo.Name)
+	}
+
+	switch routineInfo.State {
+	case model.StateNone:
+		// none -> public
+		routineInfo.State = model.StatePublic
+		err = t.CreateRoutine(schemaID, routineInfo)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		ver, err = updateSchemaVersion(t, job)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		// Finish this job.
+		job.FinishDBJob(model.JobStateDone, model.StatePublic, ver, nil)
+		return ver, nil
+	default:
+		// We can't enter here.
+		return ver, dbterror.ErrInvalidDDLState.GenWithStackByArgs("routine", routineInfo.State)
+	}
+}
+
+func onDropRoutine(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	schemaID := job.SchemaID
+	var routineID int64
+	if err := job.DecodeArgs(&routineID); err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	err := t.DropRoutine(schemaID, routineID)
+	if err != nil {
+		if meta.ErrRoutineNotExists.Equal(err) || meta.ErrDBNotExists.Equal(err) {
+			job.State = model.JobStateCancelled
+		}
+		return ver, errors.Trace(err)
+	}
+	ver, err = updateSchemaVersion(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	// Finish this job.
+	job.FinishDBJob(model.JobStateDone, model.StateNone, ver, nil)
+	return ver, nil
+}
+
+// getRoutineByName checks the routine in meta directly, the info schema may be
+// outdated when the job is running.
+func getRoutineByName(t *meta.Meta, schemaID int64, name model.CIStr, tp model.RoutineType) (*model.RoutineInfo, error) {
+	routines, err := t.ListRoutines(schemaID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, r := range routines {
+		if r.Type == tp && r.Name.L == name.L {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/util/dbterror"
+)
+
+func onCreateTrigger(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	triggerInfo := &model.TriggerInfo{}
+	var order model.TriggerOrder
+	var otherTrigger model.CIStr
+	if err := job.DecodeArgs(triggerInfo, &order, &otherTrigger); err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	// Trigger names are unique in a schema, check all tables in meta directly,
+	// the info schema may be outdated when the job is running.
+	tables, err := t.ListTables(job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	for _, tbl := range tables {
+		if tbl.FindTriggerIndex(triggerInfo.Name.L) >= 0 {
+			job.State = model.JobStateCancelled
+			return ver, infoschema.ErrTriggerExists
+		}
+	}
+
+	// By default a new trigger is activated after the existing triggers which
+	// have the same timing and event.
+	pos := len(tblInfo.Triggers)
+	if order != model.TriggerOrderNone {
+		idx := tblInfo.FindTriggerIndex(otherTrigger.L)
+		if idx < 0 || tblInfo.Triggers[idx].Timing != triggerInfo.Timing || tblInfo.Triggers[idx].Event != triggerInfo.Event {
+			job.State = model.JobStateCancelled
+			return ver, dbterror.ErrReferencedTrgDoesNotExist.GenWithStackByArgs(otherTrigger.O)
+		}
+		pos = idx
+		if order == model.TriggerFollows {
+			pos++
+		}
+	}
+	triggers := make([]*model.TriggerInfo, 0, len(tblInfo.Triggers)+1)
+	triggers = append(triggers, tblInfo.Triggers[:pos]...)
+	triggers = append(triggers, triggerInfo)
+	tblInfo.Triggers = append(triggers, tblInfo.Triggers[pos:]...)
+
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+func onDropTrigger(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	var name model.CIStr
+	if err := job.DecodeArgs(&name); err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	idx := tblInfo.FindTriggerIndex(name.L)
+	if idx < 0 {
+		job.State = model.JobStateCancelled
+		return ver, infoschema.ErrTriggerNotExists
+	}
+	tblInfo.Triggers = append(tblInfo.Triggers[:idx], tblInfo.Triggers[idx+1:]...)
+
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
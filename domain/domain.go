@@ -264,6 +264,12 @@ func (do *Domain) fetchSchemasWithTables(schemas []*model.DBInfo, m *meta.Meta,
 			}
 			di.Tables = append(di.Tables, tbl)
 		}
+		routines, err := m.ListRoutines(di.ID)
+		if err != nil {
+			done <- err
+			return
+		}
+		di.Routines = routines
 	}
 	done <- nil
 }
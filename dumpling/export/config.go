@@ -45,6 +45,7 @@ const (
 	flagConsistency              = "consistency"
 	flagSnapshot                 = "snapshot"
 	flagNoViews                  = "no-views"
+	flagNoTriggers               = "no-triggers"
 	flagSortByPk                 = "order-by-primary-key"
 	flagStatusAddr               = "status-addr"
 	flagRows                     = "rows"
@@ -71,6 +72,7 @@ const (
 	flagReadTimeout              = "read-timeout"
 	flagTransactionalConsistency = "transactional-consistency"
 	flagCompress                 = "compress"
+	flagMasterKey                = "master-key"
 
 	// FlagHelp represents the help flag
 	FlagHelp = "help"
@@ -83,6 +85,7 @@ type Config struct {
 	AllowCleartextPasswords  bool
 	SortByPk                 bool
 	NoViews                  bool
+	NoTriggers               bool
 	NoHeader                 bool
 	NoSchemas                bool
 	NoData                   bool
@@ -111,6 +114,7 @@ type Config struct {
 	LogFile       string
 	LogFormat     string
 	OutputDirPath string
+	MasterKey     string
 	StatusAddr    string
 	Snapshot      string
 	Consistency   string
@@ -230,13 +234,14 @@ func (conf *Config) DefineFlags(flags *pflag.FlagSet) {
 	flags.String(flagConsistency, consistencyTypeAuto, "Consistency level during dumping: {auto|none|flush|lock|snapshot}")
 	flags.String(flagSnapshot, "", "Snapshot position (uint64 or MySQL style string timestamp). Valid only when consistency=snapshot")
 	flags.BoolP(flagNoViews, "W", true, "Do not dump views")
+	flags.Bool(flagNoTriggers, false, "Do not dump triggers")
 	flags.Bool(flagSortByPk, true, "Sort dump results by primary key through order by sql")
 	flags.String(flagStatusAddr, ":8281", "dumpling API server and pprof addr")
 	flags.Uint64P(flagRows, "r", UnspecifiedSize, "If specified, dumpling will split table into chunks and concurrently dump them to different files to improve efficiency. For TiDB v3.0+, specify this will make dumpling split table with each file one TiDB region(no matter how many rows is).\n"+
 		"If not specified, dumpling will dump table without inner-concurrency which could be relatively slow. default unlimited")
 	flags.String(flagWhere, "", "Dump only selected records")
 	flags.Bool(flagEscapeBackslash, true, "use backslash to escape special characters")
-	flags.String(flagFiletype, "", "The type of export file (sql/csv)")
+	flags.String(flagFiletype, "", "The type of export file (sql/csv/parquet/jsonl)")
 	flags.Bool(flagNoHeader, false, "whether not to dump CSV table header")
 	flags.BoolP(flagNoSchemas, "m", false, "Do not dump table schemas with the data")
 	flags.BoolP(flagNoData, "d", false, "Do not dump table data")
@@ -260,7 +265,8 @@ func (conf *Config) DefineFlags(flags *pflag.FlagSet) {
 	_ = flags.MarkHidden(flagReadTimeout)
 	flags.Bool(flagTransactionalConsistency, true, "Only support transactional consistency")
 	_ = flags.MarkHidden(flagTransactionalConsistency)
-	flags.StringP(flagCompress, "c", "", "Compress output file type, support 'gzip', 'no-compression' now")
+	flags.StringP(flagCompress, "c", "", "Compress output file type, support 'gzip', 'snappy', 'zstd', 'lz4', 'no-compression' now")
+	flags.String(flagMasterKey, "", "The URI of the master key used to encrypt the output files, eg: '/path/to/master.key'")
 }
 
 // ParseFromFlags parses dumpling's export.Config from flags
@@ -327,6 +333,10 @@ func (conf *Config) ParseFromFlags(flags *pflag.FlagSet) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	conf.NoTriggers, err = flags.GetBool(flagNoTriggers)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	conf.SortByPk, err = flags.GetBool(flagSortByPk)
 	if err != nil {
 		return errors.Trace(err)
@@ -479,6 +489,10 @@ func (conf *Config) ParseFromFlags(flags *pflag.FlagSet) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	conf.MasterKey, err = flags.GetString(flagMasterKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
 
 	for k, v := range params {
 		conf.SessionParams[k] = v
@@ -535,6 +549,12 @@ func ParseCompressType(compressType string) (storage.CompressType, error) {
 		return storage.NoCompression, nil
 	case "gzip", "gz":
 		return storage.Gzip, nil
+	case "snappy":
+		return storage.Snappy, nil
+	case "zstd", "zst":
+		return storage.Zstd, nil
+	case "lz4":
+		return storage.LZ4, nil
 	default:
 		return storage.NoCompression, errors.Errorf("unknown compress type %s", compressType)
 	}
@@ -547,7 +567,19 @@ func (conf *Config) createExternalStorage(ctx context.Context) (storage.External
 	}
 
 	// TODO: support setting httpClient with certification later
-	return storage.New(ctx, b, &storage.ExternalStorageOptions{})
+	s, err := storage.New(ctx, b, &storage.ExternalStorageOptions{})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if conf.MasterKey == "" {
+		return s, nil
+	}
+	// the output files are compressed before being encrypted.
+	masterKey, err := storage.NewMasterKey(ctx, conf.MasterKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return storage.WithEncryption(s, masterKey), nil
 }
 
 const (
@@ -642,7 +674,18 @@ func adjustFileFormat(conf *Config) error {
 		if conf.SQL != "" {
 			return errors.Errorf("unsupported config.FileType '%s' when we specify --sql, please unset --filetype or set it to 'csv'", conf.FileType)
 		}
-	case FileFormatCSVString:
+	case FileFormatCSVString, FileFormatJSONLinesString:
+	case FileFormatParquetString:
+		// parquet files are compressed by pages internally.
+		if conf.CompressType != storage.NoCompression {
+			return errors.Errorf("unsupported config.FileType '%s' when we specify --compress, please unset --compress", conf.FileType)
+		}
+		// dump TIMESTAMP columns in UTC because they are stored as instants
+		// adjusted to UTC in parquet, so the time_zone can't be overridden.
+		if conf.SessionParams == nil {
+			conf.SessionParams = make(map[string]interface{})
+		}
+		conf.SessionParams["time_zone"] = "+00:00"
 	default:
 		return errors.Errorf("unknown config.FileType '%s'", conf.FileType)
 	}
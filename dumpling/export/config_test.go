@@ -3,10 +3,15 @@
 package export
 
 import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tidb/br/pkg/version"
 	tcontext "github.com/pingcap/tidb/dumpling/context"
+	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/require"
 )
 
@@ -17,6 +22,44 @@ func TestCreateExternalStorage(t *testing.T) {
 	require.Regexp(t, "^file:", loc.URI())
 }
 
+func TestCreateExternalStorageWithMasterKey(t *testing.T) {
+	ctx := tcontext.Background()
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "master.key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(hex.EncodeToString(make([]byte, 32))), 0o600))
+
+	mockConfig := defaultConfigForTest(t)
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	mockConfig.DefineFlags(flags)
+	require.NoError(t, flags.Parse([]string{"--master-key", keyFile}))
+	masterKey, err := flags.GetString(flagMasterKey)
+	require.NoError(t, err)
+	mockConfig.MasterKey = masterKey
+	mockConfig.OutputDirPath = filepath.Join(dir, "output")
+	require.NoError(t, os.Mkdir(mockConfig.OutputDirPath, 0o755))
+
+	// the output files are compressed and then encrypted.
+	s, err := mockConfig.createExternalStorage(ctx)
+	require.NoError(t, err)
+	content := []byte("INSERT INTO `t` VALUES (1);\n")
+	w, err := storage.WithCompression(s, storage.Gzip).Create(ctx, "test.t.000000000.sql.gz")
+	require.NoError(t, err)
+	_, err = w.Write(ctx, content)
+	require.NoError(t, err)
+	require.NoError(t, w.Close(ctx))
+
+	raw, err := os.ReadFile(filepath.Join(mockConfig.OutputDirPath, "test.t.000000000.sql.gz"))
+	require.NoError(t, err)
+	require.Equal(t, "TENC", string(raw[:4]))
+	data, err := storage.WithCompression(s, storage.Gzip).ReadFile(ctx, "test.t.000000000.sql.gz")
+	require.NoError(t, err)
+	require.Equal(t, content, data)
+
+	mockConfig.MasterKey = filepath.Join(dir, "not-exists.key")
+	_, err = mockConfig.createExternalStorage(ctx)
+	require.Error(t, err)
+}
+
 func TestMatchMysqlBugVersion(t *testing.T) {
 	cases := []struct {
 		serverInfo version.ServerInfo
@@ -32,3 +75,26 @@ func TestMatchMysqlBugVersion(t *testing.T) {
 		require.Equalf(t, x.expected, matchMysqlBugversion(x.serverInfo), "server info: %s", x.serverInfo)
 	}
 }
+
+func TestParseCompressType(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected storage.CompressType
+		suffix   string
+	}{
+		{"", storage.NoCompression, ""},
+		{"no-compression", storage.NoCompression, ""},
+		{"gzip", storage.Gzip, ".gz"},
+		{"snappy", storage.Snappy, ".snappy"},
+		{"zstd", storage.Zstd, ".zst"},
+		{"lz4", storage.LZ4, ".lz4"},
+	}
+	for _, c := range cases {
+		compressType, err := ParseCompressType(c.name)
+		require.NoError(t, err)
+		require.Equal(t, c.expected, compressType)
+		require.Equal(t, c.suffix, compressFileSuffix(compressType))
+	}
+	_, err := ParseCompressType("xz")
+	require.EqualError(t, err, "unknown compress type xz")
+}
@@ -402,11 +402,56 @@ func (d *Dumper) dumpDatabases(tctx *tcontext.Context, metaConn *BaseConn, taskC
 				}
 			}
 		}
+
+		// triggers are dumped after the tables, so they are not activated when the data is loaded
+		if !conf.NoSchemas && !conf.NoTriggers {
+			err := d.dumpTriggers(tctx, metaConn, dbName, tables, taskChan)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
 	}
 
 	return nil
 }
 
+// dumpTriggers dumps the triggers of the base tables to dump in a database, one file per table.
+func (d *Dumper) dumpTriggers(tctx *tcontext.Context, metaConn *BaseConn, dbName string, tables []*TableInfo, taskChan chan<- Task) error {
+	hasBaseTable := false
+	for _, table := range tables {
+		if table.Type == TableTypeBase {
+			hasBaseTable = true
+			break
+		}
+	}
+	if !hasBaseTable {
+		return nil
+	}
+	triggers, err := ListTriggers(tctx, metaConn, dbName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, table := range tables {
+		if table.Type != TableTypeBase || len(triggers[table.Name]) == 0 {
+			continue
+		}
+		var createTriggerSQL strings.Builder
+		for _, trigger := range triggers[table.Name] {
+			createSQL, err := ShowCreateTrigger(tctx, metaConn, dbName, trigger)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			createTriggerSQL.WriteString(createSQL)
+		}
+		task := NewTaskTriggerMeta(dbName, table.Name, createTriggerSQL.String())
+		ctxDone := d.sendTaskToChan(tctx, task, taskChan)
+		if ctxDone {
+			return tctx.Err()
+		}
+	}
+	return nil
+}
+
 // adjustDatabaseCollation adjusts db collation and return new create sql and collation
 func adjustDatabaseCollation(tctx *tcontext.Context, collationCompatible string, parser *parser.Parser, originSQL string, charsetAndDefaultCollationMap map[string]string) (string, error) {
 	if collationCompatible != StrictCollationCompatible {
@@ -1107,6 +1152,7 @@ func dumpTableMeta(tctx *tcontext.Context, conf *Config, conn *BaseConn, db stri
 	var (
 		colTypes         []*sql.ColumnType
 		hasImplicitRowID bool
+		unsignedColumns  map[string]struct{}
 	)
 	if conf.ServerInfo.ServerType == version.ServerTypeTiDB {
 		hasImplicitRowID, err = SelectTiDBRowID(tctx, conn, db, tbl)
@@ -1124,6 +1170,13 @@ func dumpTableMeta(tctx *tcontext.Context, conf *Config, conn *BaseConn, db stri
 	if err != nil {
 		return nil, err
 	}
+	// parquet needs the signedness of the integer columns in its schema.
+	if conf.FileType == FileFormatParquetString {
+		unsignedColumns, err = GetUnsignedColumns(tctx, conn, db, tbl)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	meta := &tableMeta{
 		avgRowLength:     table.AvgRowLength,
@@ -1133,6 +1186,7 @@ func dumpTableMeta(tctx *tcontext.Context, conf *Config, conn *BaseConn, db stri
 		selectedField:    selectField,
 		selectedLen:      selectLen,
 		hasImplicitRowID: hasImplicitRowID,
+		unsignedColumns:  unsignedColumns,
 		specCmts: []string{
 			"/*!40101 SET NAMES binary*/;",
 		},
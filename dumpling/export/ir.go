@@ -28,6 +28,7 @@ type TableMeta interface {
 	ColumnCount() uint
 	ColumnTypes() []string
 	ColumnNames() []string
+	ColumnTypeInfos() []ColumnTypeInfo
 	SelectedField() string
 	SelectedLen() int
 	SpecialComments() StringIter
@@ -37,6 +38,15 @@ type TableMeta interface {
 	HasImplicitRowID() bool
 }
 
+// ColumnTypeInfo contains the type information of a column other than its type name.
+type ColumnTypeInfo struct {
+	// Precision and Scale are only set for the decimal columns.
+	Precision int64
+	Scale     int64
+	// Unsigned is set for the integer columns that are known to be unsigned.
+	Unsigned bool
+}
+
 // SQLRowIter is the iterator on a collection of sql.Row.
 type SQLRowIter interface {
 	Decode(RowReceiver) error
@@ -53,10 +63,11 @@ type RowReceiverStringer interface {
 	Stringer
 }
 
-// Stringer is an interface which represents sql types that support writing to buffer in sql/csv type
+// Stringer is an interface which represents sql types that support writing to buffer in sql/csv/jsonl type
 type Stringer interface {
 	WriteToBuffer(*bytes.Buffer, bool)
 	WriteToBufferInCsv(*bytes.Buffer, bool, *csvOption)
+	WriteToBufferInJSON(*bytes.Buffer)
 }
 
 // RowReceiver is an interface which represents sql types that support bind address for *sql.Rows
@@ -259,6 +259,7 @@ type tableMeta struct {
 	showCreateView   string
 	avgRowLength     uint64
 	hasImplicitRowID bool
+	unsignedColumns  map[string]struct{}
 }
 
 func (tm *tableMeta) ColumnTypes() []string {
@@ -277,6 +278,19 @@ func (tm *tableMeta) ColumnNames() []string {
 	return colNames
 }
 
+func (tm *tableMeta) ColumnTypeInfos() []ColumnTypeInfo {
+	infos := make([]ColumnTypeInfo, len(tm.colTypes))
+	for i, ct := range tm.colTypes {
+		if precision, scale, ok := ct.DecimalSize(); ok {
+			infos[i].Precision, infos[i].Scale = precision, scale
+		}
+		// the driver only reports the unsigned integer types for NOT NULL
+		// columns, so the signedness is read from the table schema.
+		_, infos[i].Unsigned = tm.unsignedColumns[ct.Name()]
+	}
+	return infos
+}
+
 func (tm *tableMeta) DatabaseName() string {
 	return tm.database
 }
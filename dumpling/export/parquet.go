@@ -0,0 +1,386 @@
+// Copyright 2022 PingCAP, Inc. Licensed under Apache-2.0.
+
+package export
+
+import (
+	"database/sql"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/xitongsys/parquet-go-source/writerfile"
+	"github.com/xitongsys/parquet-go/marshal"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/br/pkg/summary"
+	tcontext "github.com/pingcap/tidb/dumpling/context"
+	"github.com/pingcap/tidb/dumpling/log"
+)
+
+const (
+	// defaultParquetRowGroupSize is the size of the row groups if --filesize
+	// isn't specified or larger.
+	defaultParquetRowGroupSize = 128 * 1024 * 1024
+	// maxParquetInt64DecimalPrecision is the max precision of the decimals
+	// which can be stored in INT64.
+	maxParquetInt64DecimalPrecision = 18
+	parquetDateLayout               = "2006-01-02"
+	parquetDatetimeLayout           = "2006-01-02 15:04:05"
+)
+
+var (
+	parquetIntTypes = map[string]struct{}{
+		"INTEGER": {}, "BIGINT": {}, "TINYINT": {}, "SMALLINT": {}, "MEDIUMINT": {},
+		"INT": {}, "INT1": {}, "INT2": {}, "INT3": {}, "INT8": {}, "BOOL": {}, "BOOLEAN": {},
+	}
+	parquetDecimalTypes = map[string]struct{}{
+		"DECIMAL": {}, "NUMERIC": {}, "FIXED": {},
+	}
+	parquetDoubleTypes = map[string]struct{}{
+		"DOUBLE": {}, "REAL": {}, "DOUBLE PRECISION": {},
+	}
+)
+
+// parquetColumn converts the values of a column in text protocol to the
+// values of its parquet type.
+type parquetColumn struct {
+	name    string
+	schema  *parquet.SchemaElement
+	convert func([]byte) (interface{}, error)
+}
+
+func newParquetColumn(name, colType string, info ColumnTypeInfo) *parquetColumn {
+	schema := parquet.NewSchemaElement()
+	schema.Name = name
+	schema.RepetitionType = parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_OPTIONAL)
+	col := &parquetColumn{name: name, schema: schema}
+
+	setType := func(tp parquet.Type, convertedType *parquet.ConvertedType, logicalType *parquet.LogicalType) {
+		schema.Type = parquet.TypePtr(tp)
+		schema.ConvertedType = convertedType
+		schema.LogicalType = logicalType
+	}
+	colType = strings.ToUpper(colType)
+	switch {
+	case isParquetType(parquetIntTypes, colType):
+		if info.Unsigned {
+			setType(parquet.Type_INT64, parquet.ConvertedTypePtr(parquet.ConvertedType_UINT_64),
+				&parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 64, IsSigned: false}})
+			col.convert = parseParquetUint64
+		} else {
+			setType(parquet.Type_INT64, parquet.ConvertedTypePtr(parquet.ConvertedType_INT_64),
+				&parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 64, IsSigned: true}})
+			col.convert = parseParquetInt64
+		}
+	case colType == "YEAR" || colType == "SQL_TSI_YEAR":
+		setType(parquet.Type_INT32, parquet.ConvertedTypePtr(parquet.ConvertedType_INT_16),
+			&parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 16, IsSigned: true}})
+		col.convert = func(v []byte) (interface{}, error) {
+			year, err := strconv.ParseInt(string(v), 10, 16)
+			return int32(year), err
+		}
+	case colType == "FLOAT":
+		setType(parquet.Type_FLOAT, nil, nil)
+		col.convert = func(v []byte) (interface{}, error) {
+			f, err := strconv.ParseFloat(string(v), 32)
+			return float32(f), err
+		}
+	case isParquetType(parquetDoubleTypes, colType):
+		setType(parquet.Type_DOUBLE, nil, nil)
+		col.convert = func(v []byte) (interface{}, error) {
+			return strconv.ParseFloat(string(v), 64)
+		}
+	case isParquetType(parquetDecimalTypes, colType) && info.Precision > 0 && info.Scale <= info.Precision:
+		precision, scale := int32(info.Precision), int32(info.Scale)
+		schema.Precision, schema.Scale = &precision, &scale
+		logicalType := &parquet.LogicalType{DECIMAL: &parquet.DecimalType{Precision: precision, Scale: scale}}
+		if precision <= maxParquetInt64DecimalPrecision {
+			setType(parquet.Type_INT64, parquet.ConvertedTypePtr(parquet.ConvertedType_DECIMAL), logicalType)
+			col.convert = func(v []byte) (interface{}, error) {
+				unscaled, err := unscaledDecimal(v, int(scale))
+				if err != nil {
+					return nil, err
+				}
+				return strconv.ParseInt(unscaled, 10, 64)
+			}
+		} else {
+			setType(parquet.Type_BYTE_ARRAY, parquet.ConvertedTypePtr(parquet.ConvertedType_DECIMAL), logicalType)
+			col.convert = func(v []byte) (interface{}, error) {
+				unscaled, err := unscaledDecimal(v, int(scale))
+				if err != nil {
+					return nil, err
+				}
+				i, ok := new(big.Int).SetString(unscaled, 10)
+				if !ok {
+					return nil, errors.Errorf("invalid decimal '%s'", v)
+				}
+				return string(bigIntToTwosComplement(i)), nil
+			}
+		}
+	case colType == "DATE":
+		setType(parquet.Type_INT32, parquet.ConvertedTypePtr(parquet.ConvertedType_DATE),
+			&parquet.LogicalType{DATE: parquet.NewDateType()})
+		col.convert = func(v []byte) (interface{}, error) {
+			if isZeroDate(v) {
+				return nil, nil
+			}
+			t, err := time.Parse(parquetDateLayout, string(v))
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return int32(t.Unix() / (24 * 60 * 60)), nil
+		}
+	case colType == "DATETIME" || colType == "TIMESTAMP":
+		// TIMESTAMP is dumped in UTC, see adjustFileFormat, while DATETIME is
+		// local time without time zone. The writer requires a converted type,
+		// the readers should respect the logical type if it's set.
+		setType(parquet.Type_INT64, parquet.ConvertedTypePtr(parquet.ConvertedType_TIMESTAMP_MICROS),
+			&parquet.LogicalType{TIMESTAMP: &parquet.TimestampType{
+				IsAdjustedToUTC: colType == "TIMESTAMP",
+				Unit:            &parquet.TimeUnit{MICROS: parquet.NewMicroSeconds()},
+			}})
+		col.convert = func(v []byte) (interface{}, error) {
+			if isZeroDate(v) {
+				return nil, nil
+			}
+			t, err := time.Parse(parquetDatetimeLayout, string(v))
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return t.Unix()*1e6 + int64(t.Nanosecond()/1e3), nil
+		}
+	case colType == "JSON":
+		setType(parquet.Type_BYTE_ARRAY, parquet.ConvertedTypePtr(parquet.ConvertedType_JSON),
+			&parquet.LogicalType{JSON: parquet.NewJsonType()})
+		col.convert = parseParquetString
+	case colType == "ENUM":
+		// the writer doesn't support the converted type ENUM.
+		setType(parquet.Type_BYTE_ARRAY, parquet.ConvertedTypePtr(parquet.ConvertedType_UTF8),
+			&parquet.LogicalType{ENUM: parquet.NewEnumType()})
+		col.convert = parseParquetString
+	case isParquetType(dataTypeBin, colType):
+		setType(parquet.Type_BYTE_ARRAY, nil, nil)
+		col.convert = parseParquetString
+	default:
+		// the strings, SET and TIME, which may be out of the range of a day,
+		// and the decimals without known precision are dumped as strings.
+		setType(parquet.Type_BYTE_ARRAY, parquet.ConvertedTypePtr(parquet.ConvertedType_UTF8),
+			&parquet.LogicalType{STRING: parquet.NewStringType()})
+		col.convert = parseParquetString
+	}
+	return col
+}
+
+func isParquetType(types map[string]struct{}, colType string) bool {
+	_, ok := types[colType]
+	return ok
+}
+
+// isZeroDate returns whether the date has a zero month or day, such as
+// '0000-00-00', which can't be represented in parquet and is written as NULL.
+func isZeroDate(v []byte) bool {
+	return len(v) >= len(parquetDateLayout) && (string(v[5:7]) == "00" || string(v[8:10]) == "00")
+}
+
+func parseParquetInt64(v []byte) (interface{}, error) {
+	return strconv.ParseInt(string(v), 10, 64)
+}
+
+func parseParquetUint64(v []byte) (interface{}, error) {
+	u, err := strconv.ParseUint(string(v), 10, 64)
+	return int64(u), err
+}
+
+func parseParquetString(v []byte) (interface{}, error) {
+	return string(v), nil
+}
+
+// unscaledDecimal returns the decimal string multiplied by 10^scale.
+func unscaledDecimal(v []byte, scale int) (string, error) {
+	s := string(v)
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+	if len(fracPart) > scale {
+		return "", errors.Errorf("decimal '%s' has more than %d fractional digits", s, scale)
+	}
+	return intPart + fracPart + strings.Repeat("0", scale-len(fracPart)), nil
+}
+
+// bigIntToTwosComplement returns the minimal big-endian two's complement
+// representation of the integer.
+func bigIntToTwosComplement(i *big.Int) []byte {
+	if i.Sign() >= 0 {
+		b := i.Bytes()
+		if len(b) == 0 || b[0]&0x80 != 0 {
+			b = append([]byte{0}, b...)
+		}
+		return b
+	}
+	// -i-1 in two's complement is the bitwise not of i.
+	b := new(big.Int).Sub(new(big.Int).Neg(i), big.NewInt(1)).Bytes()
+	for j := range b {
+		b[j] = ^b[j]
+	}
+	if len(b) == 0 || b[0]&0x80 == 0 {
+		b = append([]byte{0xff}, b...)
+	}
+	return b
+}
+
+// parquetRowReceiver receives the raw bytes of the columns.
+type parquetRowReceiver []sql.RawBytes
+
+// BindAddress implements RowReceiver.BindAddress
+func (r parquetRowReceiver) BindAddress(args []interface{}) {
+	for i := range args {
+		args[i] = &r[i]
+	}
+}
+
+// parquetFileWriter writes the parquet file to a storage.ExternalFileWriter.
+type parquetFileWriter struct {
+	tctx    *tcontext.Context
+	w       storage.ExternalFileWriter
+	written uint64
+}
+
+func (w *parquetFileWriter) Write(p []byte) (int, error) {
+	if err := writeBytes(w.tctx, w.w, p); err != nil {
+		return 0, err
+	}
+	w.written += uint64(len(p))
+	return len(p), nil
+}
+
+func newParquetWriter(meta TableMeta, fileWriter *parquetFileWriter, fileSize uint64) (*writer.ParquetWriter, []*parquetColumn, error) {
+	colTypes, colNames, infos := meta.ColumnTypes(), meta.ColumnNames(), meta.ColumnTypeInfos()
+	if len(colTypes) == 0 || len(colNames) != len(colTypes) {
+		return nil, nil, errors.Errorf("can't dump table %s.%s without column names in parquet",
+			meta.DatabaseName(), meta.TableName())
+	}
+	columns := make([]*parquetColumn, 0, len(colTypes))
+	numColumns := int32(len(colTypes))
+	schemas := []*parquet.SchemaElement{{
+		Name:           "schema",
+		RepetitionType: parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_REQUIRED),
+		NumChildren:    &numColumns,
+	}}
+	for i, colType := range colTypes {
+		var info ColumnTypeInfo
+		if i < len(infos) {
+			info = infos[i]
+		}
+		col := newParquetColumn(colNames[i], colType, info)
+		columns = append(columns, col)
+		schemas = append(schemas, col.schema)
+	}
+
+	pw, err := writer.NewParquetWriter(writerfile.NewWriterFile(fileWriter), schemas, 1)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	pw.MarshalFunc = marshal.MarshalCSV
+	pw.RowGroupSize = defaultParquetRowGroupSize
+	if fileSize != UnspecifiedSize && fileSize < defaultParquetRowGroupSize {
+		pw.RowGroupSize = int64(fileSize)
+	}
+	return pw, columns, nil
+}
+
+// WriteInsertInParquet writes TableDataIR to a storage.ExternalFileWriter in parquet type
+func WriteInsertInParquet(pCtx *tcontext.Context, cfg *Config, meta TableMeta, tblIR TableDataIR, w storage.ExternalFileWriter) (n uint64, err error) {
+	fileRowIter := tblIR.Rows()
+	if !fileRowIter.HasNext() {
+		return 0, fileRowIter.Error()
+	}
+
+	fileWriter := &parquetFileWriter{tctx: pCtx, w: w}
+	pw, columns, err := newParquetWriter(meta, fileWriter, cfg.FileSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		row         = make(parquetRowReceiver, len(columns))
+		counter     uint64
+		lastCounter uint64
+		lastWritten uint64
+	)
+
+	defer func() {
+		if err != nil {
+			pCtx.L().Warn("fail to dumping table(chunk), will revert some metrics and start a retry if possible",
+				zap.String("database", meta.DatabaseName()),
+				zap.String("table", meta.TableName()),
+				zap.Uint64("finished rows", lastCounter),
+				zap.Uint64("finished size", fileWriter.written),
+				log.ShortError(err))
+			SubGauge(finishedRowsGauge, cfg.Labels, float64(lastCounter))
+			SubGauge(finishedSizeGauge, cfg.Labels, float64(lastWritten))
+		} else {
+			pCtx.L().Debug("finish dumping table(chunk)",
+				zap.String("database", meta.DatabaseName()),
+				zap.String("table", meta.TableName()),
+				zap.Uint64("finished rows", counter),
+				zap.Uint64("finished size", fileWriter.written))
+			summary.CollectSuccessUnit(summary.TotalBytes, 1, fileWriter.written)
+			summary.CollectSuccessUnit("total rows", 1, counter)
+		}
+	}()
+
+	updateMetrics := func() {
+		AddGauge(finishedRowsGauge, cfg.Labels, float64(counter-lastCounter))
+		AddGauge(finishedSizeGauge, cfg.Labels, float64(fileWriter.written-lastWritten))
+		lastCounter, lastWritten = counter, fileWriter.written
+	}
+
+	for fileRowIter.HasNext() {
+		if err = fileRowIter.Decode(row); err != nil {
+			return counter, errors.Trace(err)
+		}
+		record := make([]interface{}, len(columns))
+		for i, col := range columns {
+			if row[i] == nil {
+				continue
+			}
+			if record[i], err = col.convert(row[i]); err != nil {
+				return counter, errors.Annotatef(err, "failed to convert column %s of table %s.%s to parquet",
+					col.name, meta.DatabaseName(), meta.TableName())
+			}
+		}
+		if err = pw.Write(record); err != nil {
+			return counter, errors.Trace(err)
+		}
+		counter++
+		if fileWriter.written != lastWritten {
+			updateMetrics()
+		}
+
+		select {
+		case <-pCtx.Done():
+			return counter, pCtx.Err()
+		default:
+		}
+		fileRowIter.Next()
+		// pw.Size is the size of the encoded pages and pw.ObjsSize is the
+		// estimated size of the buffered rows.
+		if cfg.FileSize != UnspecifiedSize && fileWriter.written+uint64(pw.Size+pw.ObjsSize) >= cfg.FileSize {
+			break
+		}
+	}
+	if err = pw.WriteStop(); err != nil {
+		return counter, errors.Trace(err)
+	}
+	updateMetrics()
+	if err = fileRowIter.Error(); err != nil {
+		return counter, errors.Trace(err)
+	}
+	return counter, nil
+}
@@ -0,0 +1,199 @@
+// Copyright 2022 PingCAP, Inc. Licensed under Apache-2.0.
+
+package export
+
+import (
+	"database/sql/driver"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+
+	"github.com/pingcap/tidb/br/pkg/storage"
+	tcontext "github.com/pingcap/tidb/dumpling/context"
+)
+
+func readParquetColumns(t *testing.T, data []byte) (*reader.ParquetReader, [][]interface{}) {
+	pFile, err := buffer.NewBufferFile(data)
+	require.NoError(t, err)
+	pr, err := reader.NewParquetColumnReader(pFile, 1)
+	require.NoError(t, err)
+	numRows := pr.GetNumRows()
+	columns := make([][]interface{}, 0, len(pr.SchemaHandler.SchemaElements)-1)
+	for i := 0; i < len(pr.SchemaHandler.SchemaElements)-1; i++ {
+		values, _, _, err := pr.ReadColumnByIndex(int64(i), numRows)
+		require.NoError(t, err)
+		columns = append(columns, values)
+	}
+	return pr, columns
+}
+
+func TestWriteInsertInParquet(t *testing.T) {
+	cfg, clean := createMockConfig(t)
+	defer clean()
+
+	data := [][]driver.Value{
+		{"1", "18446744073709551615", "-12.50", "-123456789012345678901234.56789", "2022-03-04", "2022-03-04 05:06:07.123456", "1970-01-01 00:00:01", `{"a": [1, 2]}`, "male", "a,b", "\x00\x01", "1.5", "2022", "12:00:00"},
+		{"-2", "0", "0.05", "1", "1970-01-02", "1969-12-31 23:59:59", "2038-01-19 03:14:07", "null", "female", "", "", "-0.25", "1901", "-838:59:59"},
+		{nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+	}
+	colTypes := []string{"INT", "BIGINT", "DECIMAL", "DECIMAL", "DATE", "DATETIME", "TIMESTAMP", "JSON", "ENUM", "SET", "VARBINARY", "DOUBLE", "YEAR", "TIME"}
+	tableIR := newMockTableIR("test", "t", data, nil, colTypes)
+	tableIR.colNames = []string{"id", "u", "d1", "d2", "date", "dt", "ts", "j", "e", "s", "b", "f", "y", "tm"}
+	tableIR.colTypeInfos = make([]ColumnTypeInfo, len(colTypes))
+	tableIR.colTypeInfos[1].Unsigned = true
+	tableIR.colTypeInfos[2] = ColumnTypeInfo{Precision: 10, Scale: 2}
+	tableIR.colTypeInfos[3] = ColumnTypeInfo{Precision: 30, Scale: 5}
+	bf := storage.NewBufferWriter()
+
+	conf := *cfg
+	conf.FileType = FileFormatParquetString
+	n, err := WriteInsertInParquet(tcontext.Background(), &conf, tableIR, tableIR, bf)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), n)
+	require.Equal(t, float64(len(data)), ReadGauge(finishedRowsGauge, conf.Labels))
+	require.Equal(t, float64(len(bf.Bytes())), ReadGauge(finishedSizeGauge, conf.Labels))
+	RemoveLabelValuesWithTaskInMetrics(conf.Labels)
+
+	pr, columns := readParquetColumns(t, bf.Bytes())
+	require.Equal(t, int64(3), pr.GetNumRows())
+	schemas := pr.SchemaHandler.SchemaElements[1:]
+	for i, name := range tableIR.colNames {
+		require.Equal(t, name, pr.SchemaHandler.Infos[i+1].ExName)
+		require.Equal(t, parquet.FieldRepetitionType_OPTIONAL, schemas[i].GetRepetitionType())
+	}
+	expectedTypes := []parquet.Type{
+		parquet.Type_INT64, parquet.Type_INT64, parquet.Type_INT64, parquet.Type_BYTE_ARRAY,
+		parquet.Type_INT32, parquet.Type_INT64, parquet.Type_INT64, parquet.Type_BYTE_ARRAY,
+		parquet.Type_BYTE_ARRAY, parquet.Type_BYTE_ARRAY, parquet.Type_BYTE_ARRAY, parquet.Type_DOUBLE,
+		parquet.Type_INT32, parquet.Type_BYTE_ARRAY,
+	}
+	for i, tp := range expectedTypes {
+		require.Equal(t, tp, schemas[i].GetType(), tableIR.colNames[i])
+	}
+	require.Equal(t, parquet.ConvertedType_UINT_64, schemas[1].GetConvertedType())
+	require.Equal(t, int32(10), schemas[2].GetPrecision())
+	require.Equal(t, int32(2), schemas[2].GetScale())
+	require.Equal(t, parquet.ConvertedType_DECIMAL, schemas[3].GetConvertedType())
+	require.Equal(t, parquet.ConvertedType_DATE, schemas[4].GetConvertedType())
+	require.False(t, schemas[5].GetLogicalType().GetTIMESTAMP().GetIsAdjustedToUTC())
+	require.True(t, schemas[6].GetLogicalType().GetTIMESTAMP().GetIsAdjustedToUTC())
+	require.Equal(t, parquet.ConvertedType_JSON, schemas[7].GetConvertedType())
+	require.True(t, schemas[8].GetLogicalType().IsSetENUM())
+	require.Equal(t, parquet.ConvertedType_UTF8, schemas[9].GetConvertedType())
+	require.False(t, schemas[10].IsSetConvertedType())
+	require.Equal(t, parquet.ConvertedType_UTF8, schemas[13].GetConvertedType())
+
+	d2, ok := new(big.Int).SetString("-12345678901234567890123456789", 10)
+	require.True(t, ok)
+	expected := [][]interface{}{
+		{int64(1), int64(-2), nil},
+		{int64(-1), int64(0), nil},
+		{int64(-1250), int64(5), nil},
+		{string(bigIntToTwosComplement(d2)), string([]byte{0x01, 0x86, 0xa0}), nil},
+		{int32(19055), int32(1), nil},
+		{int64(1646370367123456), int64(-1000000), nil},
+		{int64(1000000), int64(2147483647000000), nil},
+		{`{"a": [1, 2]}`, "null", nil},
+		{"male", "female", nil},
+		{"a,b", "", nil},
+		{"\x00\x01", "", nil},
+		{1.5, -0.25, nil},
+		{int32(2022), int32(1901), nil},
+		{"12:00:00", "-838:59:59", nil},
+	}
+	require.Equal(t, expected, columns)
+}
+
+func TestWriteInsertInParquetWithFileSize(t *testing.T) {
+	cfg, clean := createMockConfig(t)
+	defer clean()
+
+	data := make([][]driver.Value, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		data = append(data, []driver.Value{"1234567890", "abcdefghijklmnopqrstuvwxyz"})
+	}
+	tableIR := newMockTableIR("test", "t", data, nil, []string{"INT", "VARCHAR"})
+	tableIR.colNames = []string{"a", "b"}
+	conf := *cfg
+	conf.FileType = FileFormatParquetString
+	conf.FileSize = 1024
+
+	var total uint64
+	for i := 0; total < uint64(len(data)); i++ {
+		bf := storage.NewBufferWriter()
+		n, err := WriteInsertInParquet(tcontext.Background(), &conf, tableIR, tableIR, bf)
+		require.NoError(t, err)
+		require.Greater(t, n, uint64(0))
+		require.Less(t, n, uint64(len(data)))
+		total += n
+
+		pr, columns := readParquetColumns(t, bf.Bytes())
+		require.Equal(t, int64(n), pr.GetNumRows())
+		require.Len(t, columns[0], int(n))
+		require.Equal(t, int64(1234567890), columns[0][0])
+		require.Equal(t, "abcdefghijklmnopqrstuvwxyz", columns[1][n-1])
+	}
+	require.Equal(t, uint64(len(data)), total)
+	RemoveLabelValuesWithTaskInMetrics(conf.Labels)
+
+	// there is nothing left to write.
+	bf := storage.NewBufferWriter()
+	n, err := WriteInsertInParquet(tcontext.Background(), &conf, tableIR, tableIR, bf)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), n)
+	require.Empty(t, bf.Bytes())
+}
+
+func TestWriteInsertInParquetZeroDate(t *testing.T) {
+	cfg, clean := createMockConfig(t)
+	defer clean()
+
+	data := [][]driver.Value{
+		{"0000-00-00", "0000-00-00 00:00:00", "0000-00-00 00:00:00"},
+		{"2022-00-01", "2022-01-00 12:00:00", "1970-01-01 00:00:01"},
+	}
+	tableIR := newMockTableIR("test", "t", data, nil, []string{"DATE", "DATETIME", "TIMESTAMP"})
+	tableIR.colNames = []string{"d", "dt", "ts"}
+	bf := storage.NewBufferWriter()
+	conf := *cfg
+	n, err := WriteInsertInParquet(tcontext.Background(), &conf, tableIR, tableIR, bf)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), n)
+	RemoveLabelValuesWithTaskInMetrics(conf.Labels)
+
+	_, columns := readParquetColumns(t, bf.Bytes())
+	expected := [][]interface{}{
+		{nil, nil},
+		{nil, nil},
+		{nil, int64(1000000)},
+	}
+	require.Equal(t, expected, columns)
+}
+
+func TestWriteInsertInParquetInvalidValue(t *testing.T) {
+	cfg, clean := createMockConfig(t)
+	defer clean()
+
+	data := [][]driver.Value{{"2022-13-01"}}
+	tableIR := newMockTableIR("test", "t", data, nil, []string{"DATE"})
+	tableIR.colNames = []string{"d"}
+	conf := *cfg
+	n, err := WriteInsertInParquet(tcontext.Background(), &conf, tableIR, tableIR, storage.NewBufferWriter())
+	require.Equal(t, uint64(0), n)
+	require.Regexp(t, "failed to convert column d of table test.t to parquet", err.Error())
+	RemoveLabelValuesWithTaskInMetrics(conf.Labels)
+
+	tableIR = newMockTableIR("test", "t", [][]driver.Value{{"18446744073709551615"}}, nil, []string{"BIGINT"})
+	tableIR.colNames = []string{"u"}
+	_, err = WriteInsertInParquet(tcontext.Background(), &conf, tableIR, tableIR, storage.NewBufferWriter())
+	require.Regexp(t, "value out of range", err.Error())
+	RemoveLabelValuesWithTaskInMetrics(conf.Labels)
+
+	tableIR = newMockTableIR("test", "t", data, nil, []string{"DATE"})
+	_, err = WriteInsertInParquet(tcontext.Background(), &conf, tableIR, tableIR, storage.NewBufferWriter())
+	require.Regexp(t, "can't dump table test.t without column names in parquet", err.Error())
+}
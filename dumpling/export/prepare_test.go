@@ -11,6 +11,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/pingcap/tidb/br/pkg/storage"
 	tcontext "github.com/pingcap/tidb/dumpling/context"
 )
 
@@ -296,6 +297,25 @@ func TestConfigValidation(t *testing.T) {
 
 	conf.FileType = "rand_str"
 	require.EqualError(t, adjustFileFormat(conf), "unknown config.FileType 'rand_str'")
+
+	conf.FileType = "Parquet"
+	conf.CompressType = storage.Gzip
+	err = adjustFileFormat(conf)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "please unset --compress")
+	conf.CompressType = storage.NoCompression
+	conf.SessionParams = nil
+	require.NoError(t, adjustFileFormat(conf))
+	require.Equal(t, FileFormatParquetString, conf.FileType)
+	require.Equal(t, "+00:00", conf.SessionParams["time_zone"])
+	conf.SessionParams["time_zone"] = "Asia/Shanghai"
+	require.NoError(t, adjustFileFormat(conf))
+	require.Equal(t, "+00:00", conf.SessionParams["time_zone"])
+
+	conf.FileType = "JSONL"
+	conf.SQL = "select * from t"
+	require.NoError(t, adjustFileFormat(conf))
+	require.Equal(t, FileFormatJSONLinesString, conf.FileType)
 }
 
 func TestValidateResolveAutoConsistency(t *testing.T) {
@@ -174,6 +174,58 @@ func ShowCreateView(tctx *tcontext.Context, db *BaseConn, database, view string)
 	return createTableSQL.String(), createViewSQL.String(), nil
 }
 
+// ListTriggers lists the names of the triggers in a database, grouped by the tables they belong to.
+// The triggers of a table are listed in the order they are activated.
+func ListTriggers(tctx *tcontext.Context, db *BaseConn, database string) (map[string][]string, error) {
+	triggers := make(map[string][]string)
+	query := fmt.Sprintf("SHOW TRIGGERS FROM `%s`", escapeString(database))
+	err := db.QuerySQL(tctx, func(rows *sql.Rows) error {
+		// The result of `show triggers` has 11 columns, the first is the trigger name and the third is the table name.
+		var oneRow [11]sql.NullString
+		scanArgs := make([]interface{}, len(oneRow))
+		for i := range oneRow {
+			scanArgs[i] = &oneRow[i]
+		}
+		if err := rows.Scan(scanArgs...); err != nil {
+			return errors.Trace(err)
+		}
+		triggers[oneRow[2].String] = append(triggers[oneRow[2].String], oneRow[0].String)
+		return nil
+	}, func() {
+		triggers = make(map[string][]string)
+	}, query)
+	return triggers, errors.Annotatef(err, "sql: %s", query)
+}
+
+// ShowCreateTrigger constructs the create trigger SQL for a specified trigger
+func ShowCreateTrigger(tctx *tcontext.Context, db *BaseConn, database, trigger string) (string, error) {
+	// The result for `show create trigger` SQL has 7 columns
+	// | Trigger | sql_mode | SQL Original Statement | character_set_client | collation_connection | Database Collation | Created |
+	var oneRow [7]sql.NullString
+	handleOneRow := func(rows *sql.Rows) error {
+		return rows.Scan(&oneRow[0], &oneRow[1], &oneRow[2], &oneRow[3], &oneRow[4], &oneRow[5], &oneRow[6])
+	}
+	query := fmt.Sprintf("SHOW CREATE TRIGGER `%s`.`%s`", escapeString(database), escapeString(trigger))
+	err := db.QuerySQL(tctx, handleOneRow, func() {
+		oneRow = [7]sql.NullString{}
+	}, query)
+	if err != nil {
+		return "", err
+	}
+
+	var createTriggerSQL strings.Builder
+	fmt.Fprintf(&createTriggerSQL, "DROP TRIGGER IF EXISTS `%s`;\n", escapeString(trigger))
+	SetCharset(&createTriggerSQL, oneRow[3].String, oneRow[4].String)
+	// The trigger body is parsed with the sql_mode in effect when the trigger was created.
+	createTriggerSQL.WriteString("SET @PREV_SQL_MODE=@@SQL_MODE;\n")
+	fmt.Fprintf(&createTriggerSQL, "SET SQL_MODE='%s';\n", escapeString(oneRow[1].String))
+	createTriggerSQL.WriteString(oneRow[2].String)
+	createTriggerSQL.WriteString(";\n")
+	createTriggerSQL.WriteString("SET SQL_MODE=@PREV_SQL_MODE;\n")
+	RestoreCharset(&createTriggerSQL)
+	return createTriggerSQL.String(), nil
+}
+
 // SetCharset builds the set charset SQLs
 func SetCharset(w *strings.Builder, characterSet, collationConnection string) {
 	w.WriteString("SET @PREV_CHARACTER_SET_CLIENT=@@CHARACTER_SET_CLIENT;\n")
@@ -878,6 +930,23 @@ func buildSelectField(tctx *tcontext.Context, db *BaseConn, dbName, tableName st
 	return "*", len(availableFields), nil
 }
 
+// GetUnsignedColumns returns the names of the unsigned columns of the table.
+func GetUnsignedColumns(tctx *tcontext.Context, db *BaseConn, dbName, tableName string) (map[string]struct{}, error) {
+	query := fmt.Sprintf("SHOW COLUMNS FROM `%s`.`%s`", escapeString(dbName), escapeString(tableName))
+	results, err := db.QuerySQLWithColumns(tctx, []string{"FIELD", "TYPE"}, query)
+	if err != nil {
+		return nil, err
+	}
+	unsignedColumns := make(map[string]struct{})
+	for _, oneRow := range results {
+		fieldName, tp := oneRow[0], oneRow[1]
+		if strings.Contains(strings.ToLower(tp), "unsigned") {
+			unsignedColumns[fieldName] = struct{}{}
+		}
+	}
+	return unsignedColumns, nil
+}
+
 func buildWhereClauses(handleColNames []string, handleVals [][]string) []string {
 	if len(handleColNames) == 0 || len(handleVals) == 0 {
 		return nil
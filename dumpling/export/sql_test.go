@@ -313,6 +313,30 @@ func TestBuildSelectField(t *testing.T) {
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestGetUnsignedColumns(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+
+	conn, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	tctx := tcontext.Background().WithLogger(appLogger)
+	baseConn := newBaseConn(conn, true, nil)
+
+	mock.ExpectQuery("SHOW COLUMNS FROM `test`.`t`").
+		WillReturnRows(sqlmock.NewRows([]string{"Field", "Type", "Null", "Key", "Default", "Extra"}).
+			AddRow("id", "bigint(20) unsigned", "NO", "PRI", nil, "").
+			AddRow("a", "int(11)", "YES", "", nil, "").
+			AddRow("b", "TINYINT UNSIGNED", "YES", "", nil, ""))
+
+	unsignedColumns, err := GetUnsignedColumns(tctx, baseConn, "test", "t")
+	require.NoError(t, err)
+	require.Equal(t, map[string]struct{}{"id": {}, "b": {}}, unsignedColumns)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestParseSnapshotToTSO(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -368,6 +392,35 @@ func TestShowCreateView(t *testing.T) {
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestShowCreateTrigger(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+
+	conn, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	tctx := tcontext.Background().WithLogger(appLogger)
+	baseConn := newBaseConn(conn, true, nil)
+
+	mock.ExpectQuery("SHOW TRIGGERS FROM `test`").
+		WillReturnRows(sqlmock.NewRows([]string{"Trigger", "Event", "Table", "Statement", "Timing", "Created", "sql_mode", "Definer", "character_set_client", "collation_connection", "Database Collation"}).
+			AddRow("t_bi", "INSERT", "t", "set new.a = 1", "BEFORE", "2022-03-01 00:00:00.00", "", "root@%", "utf8mb4", "utf8mb4_bin", "utf8mb4_bin").
+			AddRow("t_ai", "INSERT", "t", "set @a = 1", "AFTER", "2022-03-01 00:00:00.00", "", "root@%", "utf8mb4", "utf8mb4_bin", "utf8mb4_bin"))
+	triggers, err := ListTriggers(tctx, baseConn, "test")
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{"t": {"t_bi", "t_ai"}}, triggers)
+
+	mock.ExpectQuery("SHOW CREATE TRIGGER `test`.`t_bi`").
+		WillReturnRows(sqlmock.NewRows([]string{"Trigger", "sql_mode", "SQL Original Statement", "character_set_client", "collation_connection", "Database Collation", "Created"}).
+			AddRow("t_bi", "STRICT_TRANS_TABLES", "CREATE DEFINER=`root`@`%` TRIGGER `t_bi` BEFORE INSERT ON `t` FOR EACH ROW set new.a = 1", "utf8mb4", "utf8mb4_bin", "utf8mb4_bin", "2022-03-01 00:00:00.00"))
+	createTriggerSQL, err := ShowCreateTrigger(tctx, baseConn, "test", "t_bi")
+	require.NoError(t, err)
+	require.Equal(t, "DROP TRIGGER IF EXISTS `t_bi`;\nSET @PREV_CHARACTER_SET_CLIENT=@@CHARACTER_SET_CLIENT;\nSET @PREV_CHARACTER_SET_RESULTS=@@CHARACTER_SET_RESULTS;\nSET @PREV_COLLATION_CONNECTION=@@COLLATION_CONNECTION;\nSET character_set_client = utf8mb4;\nSET character_set_results = utf8mb4;\nSET collation_connection = utf8mb4_bin;\nSET @PREV_SQL_MODE=@@SQL_MODE;\nSET SQL_MODE='STRICT_TRANS_TABLES';\nCREATE DEFINER=`root`@`%` TRIGGER `t_bi` BEFORE INSERT ON `t` FOR EACH ROW set new.a = 1;\nSET SQL_MODE=@PREV_SQL_MODE;\nSET character_set_client = @PREV_CHARACTER_SET_CLIENT;\nSET character_set_results = @PREV_CHARACTER_SET_RESULTS;\nSET collation_connection = @PREV_COLLATION_CONNECTION;\n", createTriggerSQL)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestShowCreatePolicy(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
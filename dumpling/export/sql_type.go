@@ -5,6 +5,7 @@ package export
 import (
 	"bytes"
 	"database/sql"
+	"encoding/base64"
 	"fmt"
 )
 
@@ -12,6 +13,7 @@ var colTypeRowReceiverMap = map[string]func() RowReceiverStringer{}
 
 var (
 	nullValue         = "NULL"
+	jsonNullValue     = "null"
 	quotationMark     = []byte{'\''}
 	twoQuotationMarks = []byte{'\'', '\''}
 )
@@ -27,7 +29,7 @@ func initColTypeRowReceiverMap() {
 		"CHAR", "NCHAR", "VARCHAR", "NVARCHAR", "CHARACTER", "VARCHARACTER",
 		"TIMESTAMP", "DATETIME", "DATE", "TIME", "YEAR", "SQL_TSI_YEAR",
 		"TEXT", "TINYTEXT", "MEDIUMTEXT", "LONGTEXT",
-		"ENUM", "SET", "JSON", "NULL", "VAR_STRING",
+		"ENUM", "SET", "NULL", "VAR_STRING",
 	}
 
 	dataTypeIntArr := []string{
@@ -61,6 +63,8 @@ func initColTypeRowReceiverMap() {
 		dataTypeBin[s] = struct{}{}
 		colTypeRowReceiverMap[s] = SQLTypeBytesMaker
 	}
+	dataTypeString["JSON"] = struct{}{}
+	colTypeRowReceiverMap["JSON"] = SQLTypeJSONMaker
 }
 
 var dataTypeString, dataTypeInt, dataTypeBin = make(map[string]struct{}), make(map[string]struct{}), make(map[string]struct{})
@@ -158,6 +162,35 @@ func escapeCSV(s []byte, bf *bytes.Buffer, escapeBackslash bool, opt *csvOption)
 	}
 }
 
+// escapeJSON writes s as the content of a JSON string. The bytes which are not
+// valid UTF-8 are kept as they are, so that the strings in other character sets
+// can be restored.
+func escapeJSON(s []byte, bf *bytes.Buffer) {
+	last := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 0x20 && c != '"' && c != '\\' {
+			continue
+		}
+		bf.Write(s[last:i])
+		switch c {
+		case '"', '\\':
+			bf.WriteByte('\\')
+			bf.WriteByte(c)
+		case '\n':
+			bf.WriteString(`\n`)
+		case '\r':
+			bf.WriteString(`\r`)
+		case '\t':
+			bf.WriteString(`\t`)
+		default:
+			fmt.Fprintf(bf, `\u%04x`, c)
+		}
+		last = i + 1
+	}
+	bf.Write(s[last:])
+}
+
 // SQLTypeStringMaker returns a SQLTypeString
 func SQLTypeStringMaker() RowReceiverStringer {
 	return &SQLTypeString{}
@@ -168,6 +201,11 @@ func SQLTypeBytesMaker() RowReceiverStringer {
 	return &SQLTypeBytes{}
 }
 
+// SQLTypeJSONMaker returns a SQLTypeJSON
+func SQLTypeJSONMaker() RowReceiverStringer {
+	return &SQLTypeJSON{}
+}
+
 // SQLTypeNumberMaker returns a SQLTypeNumber
 func SQLTypeNumberMaker() RowReceiverStringer {
 	return &SQLTypeNumber{}
@@ -228,6 +266,20 @@ func (r RowReceiverArr) WriteToBufferInCsv(bf *bytes.Buffer, escapeBackslash boo
 	}
 }
 
+// WriteToBufferInJSONLines writes the row as a JSON object, keys are the
+// escaped column names with the colons.
+func (r RowReceiverArr) WriteToBufferInJSONLines(bf *bytes.Buffer, keys [][]byte) {
+	bf.WriteByte('{')
+	for i, receiver := range r.receivers {
+		bf.Write(keys[i])
+		receiver.WriteToBufferInJSON(bf)
+		if i != len(r.receivers)-1 {
+			bf.WriteByte(',')
+		}
+	}
+	bf.WriteByte('}')
+}
+
 // SQLTypeNumber implements RowReceiverStringer which represents numeric type columns in database
 type SQLTypeNumber struct {
 	SQLTypeString
@@ -251,6 +303,15 @@ func (s SQLTypeNumber) WriteToBufferInCsv(bf *bytes.Buffer, _ bool, opt *csvOpti
 	}
 }
 
+// WriteToBufferInJSON implements Stringer.WriteToBufferInJSON
+func (s SQLTypeNumber) WriteToBufferInJSON(bf *bytes.Buffer) {
+	if s.RawBytes != nil {
+		bf.Write(s.RawBytes)
+	} else {
+		bf.WriteString(jsonNullValue)
+	}
+}
+
 // SQLTypeString implements RowReceiverStringer which represents string type columns in database
 type SQLTypeString struct {
 	sql.RawBytes
@@ -283,6 +344,33 @@ func (s *SQLTypeString) WriteToBufferInCsv(bf *bytes.Buffer, escapeBackslash boo
 	}
 }
 
+// WriteToBufferInJSON implements Stringer.WriteToBufferInJSON
+func (s *SQLTypeString) WriteToBufferInJSON(bf *bytes.Buffer) {
+	if s.RawBytes != nil {
+		bf.WriteByte('"')
+		escapeJSON(s.RawBytes, bf)
+		bf.WriteByte('"')
+	} else {
+		bf.WriteString(jsonNullValue)
+	}
+}
+
+// SQLTypeJSON implements RowReceiverStringer which represents JSON type columns in database
+type SQLTypeJSON struct {
+	SQLTypeString
+}
+
+// WriteToBufferInJSON implements Stringer.WriteToBufferInJSON
+// The JSON objects and arrays are written as they are, while the JSON scalars
+// are written as strings to distinguish them from the SQL NULL.
+func (s *SQLTypeJSON) WriteToBufferInJSON(bf *bytes.Buffer) {
+	if len(s.RawBytes) > 0 && (s.RawBytes[0] == '{' || s.RawBytes[0] == '[') {
+		bf.Write(s.RawBytes)
+	} else {
+		s.SQLTypeString.WriteToBufferInJSON(bf)
+	}
+}
+
 // SQLTypeBytes implements RowReceiverStringer which represents bytes type columns in database
 type SQLTypeBytes struct {
 	sql.RawBytes
@@ -312,3 +400,17 @@ func (s *SQLTypeBytes) WriteToBufferInCsv(bf *bytes.Buffer, escapeBackslash bool
 		bf.WriteString(opt.nullValue)
 	}
 }
+
+// WriteToBufferInJSON implements Stringer.WriteToBufferInJSON
+// The binary values are written as base64 encoded strings.
+func (s *SQLTypeBytes) WriteToBufferInJSON(bf *bytes.Buffer) {
+	if s.RawBytes != nil {
+		bf.WriteByte('"')
+		encoder := base64.NewEncoder(base64.StdEncoding, bf)
+		_, _ = encoder.Write(s.RawBytes)
+		_ = encoder.Close()
+		bf.WriteByte('"')
+	} else {
+		bf.WriteString(jsonNullValue)
+	}
+}
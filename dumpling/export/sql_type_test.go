@@ -52,4 +52,8 @@ func TestEscape(t *testing.T) {
 	bf.Reset()
 	escapeCSV(str, &bf, false, opt)
 	require.Equal(t, expectedStrWithoutDelimiter, bf.String())
+
+	bf.Reset()
+	escapeJSON([]byte("a\"b\\c\r\n\td\x01\x1f\xfe中"), &bf)
+	require.Equal(t, `a\"b\\c\r\n\td\u0001\u001f`+"\xfe中", bf.String())
 }
@@ -4,7 +4,7 @@ package export
 
 import "fmt"
 
-// Task is a file dump task for dumpling, it could either be dumping database/table/view/policy metadata, table data
+// Task is a file dump task for dumpling, it could either be dumping database/table/view/trigger/policy metadata, table data
 type Task interface {
 	// Brief is the brief for a dumping task
 	Brief() string
@@ -34,6 +34,14 @@ type TaskViewMeta struct {
 	CreateViewSQL  string
 }
 
+// TaskTriggerMeta is a dumping trigger metadata task, it contains all the triggers of a table
+type TaskTriggerMeta struct {
+	Task
+	DatabaseName     string
+	TableName        string
+	CreateTriggerSQL string
+}
+
 // TaskPolicyMeta is a dumping view metadata task
 type TaskPolicyMeta struct {
 	Task
@@ -77,6 +85,15 @@ func NewTaskViewMeta(dbName, tblName, createTableSQL, createViewSQL string) *Tas
 	}
 }
 
+// NewTaskTriggerMeta returns a new dumping trigger metadata task
+func NewTaskTriggerMeta(dbName, tblName, createTriggerSQL string) *TaskTriggerMeta {
+	return &TaskTriggerMeta{
+		DatabaseName:     dbName,
+		TableName:        tblName,
+		CreateTriggerSQL: createTriggerSQL,
+	}
+}
+
 // NewTaskPolicyMeta returns a new dumping placement policy metadata task
 func NewTaskPolicyMeta(policyName, createPolicySQL string) *TaskPolicyMeta {
 	return &TaskPolicyMeta{
@@ -110,6 +127,11 @@ func (t *TaskViewMeta) Brief() string {
 	return fmt.Sprintf("meta of view '%s'.'%s'", t.DatabaseName, t.ViewName)
 }
 
+// Brief implements task.Brief
+func (t *TaskTriggerMeta) Brief() string {
+	return fmt.Sprintf("meta of triggers of table '%s'.'%s'", t.DatabaseName, t.TableName)
+}
+
 // Brief implements task.Brief
 func (t *TaskPolicyMeta) Brief() string {
 	return fmt.Sprintf("meta of placement policy '%s'", t.PolicyName)
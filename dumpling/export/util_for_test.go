@@ -67,6 +67,7 @@ type mockTableIR struct {
 	specCmt          []string
 	colTypes         []string
 	colNames         []string
+	colTypeInfos     []ColumnTypeInfo
 	escapeBackSlash  bool
 	hasImplicitRowID bool
 	rowErr           error
@@ -122,6 +123,10 @@ func (m *mockTableIR) ColumnNames() []string {
 	return m.colNames
 }
 
+func (m *mockTableIR) ColumnTypeInfos() []ColumnTypeInfo {
+	return m.colTypeInfos
+}
+
 func (m *mockTableIR) SelectedField() string {
 	return m.selectedField
 }
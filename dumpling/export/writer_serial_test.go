@@ -233,6 +233,41 @@ func TestWriteInsertInCsvReturnsError(t *testing.T) {
 	RemoveLabelValuesWithTaskInMetrics(conf.Labels)
 }
 
+func TestWriteInsertInJSONLines(t *testing.T) {
+	cfg, clean := createMockConfig(t)
+	defer clean()
+
+	data := [][]driver.Value{
+		{"1", "-12.50", "bob \"the\" builder\n", "\x00\xff", `{"a": [1, 2]}`, `"str"`},
+		{"2", "0.05", "", "", "[]", "null"},
+		{nil, nil, nil, nil, nil, nil},
+	}
+	colTypes := []string{"INT", "DECIMAL", "VARCHAR", "BLOB", "JSON", "JSON"}
+	tableIR := newMockTableIR("test", "t", data, nil, colTypes)
+	tableIR.colNames = []string{"id", "amount", "name", "b", "j1", "J\"2"}
+	bf := storage.NewBufferWriter()
+
+	conf := *cfg
+	conf.FileType = FileFormatJSONLinesString
+	n, err := WriteInsertInJSONLines(tcontext.Background(), &conf, tableIR, tableIR, bf)
+	require.Equal(t, uint64(3), n)
+	require.NoError(t, err)
+
+	expected := `{"id":1,"amount":-12.50,"name":"bob \"the\" builder\n","b":"AP8=","j1":{"a": [1, 2]},"J\"2":"\"str\""}` + "\n" +
+		`{"id":2,"amount":0.05,"name":"","b":"","j1":[],"J\"2":"null"}` + "\n" +
+		`{"id":null,"amount":null,"name":null,"b":null,"j1":null,"J\"2":null}` + "\n"
+	require.Equal(t, expected, bf.String())
+	require.Equal(t, float64(len(data)), ReadGauge(finishedRowsGauge, conf.Labels))
+	require.Equal(t, float64(len(expected)), ReadGauge(finishedSizeGauge, conf.Labels))
+
+	RemoveLabelValuesWithTaskInMetrics(conf.Labels)
+
+	// column names are required to write the keys.
+	tableIR = newMockTableIR("test", "t", data, nil, colTypes)
+	_, err = WriteInsertInJSONLines(tcontext.Background(), &conf, tableIR, tableIR, storage.NewBufferWriter())
+	require.Regexp(t, "can't dump table test.t without column names in jsonl", err.Error())
+}
+
 func TestSQLDataTypes(t *testing.T) {
 	cfg, clean := createMockConfig(t)
 	defer clean()
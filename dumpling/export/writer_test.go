@@ -14,6 +14,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/pingcap/tidb/br/pkg/storage"
 	tcontext "github.com/pingcap/tidb/dumpling/context"
 )
 
@@ -76,6 +77,28 @@ func TestWriteTableMeta(t *testing.T) {
 	require.Equal(t, "/*!40101 SET NAMES binary*/;\nCREATE TABLE t (a INT);\n", string(bytes))
 }
 
+func TestWriteTableMetaWithCompression(t *testing.T) {
+	dir := t.TempDir()
+
+	config := defaultConfigForTest(t)
+	config.OutputDirPath = dir
+
+	for _, compressType := range []storage.CompressType{storage.Gzip, storage.Snappy, storage.Zstd, storage.LZ4} {
+		config.CompressType = compressType
+		writer, clean := createTestWriter(config, t)
+
+		err := writer.WriteTableMeta("test", "t", "CREATE TABLE t (a INT)")
+		require.NoError(t, err)
+		fileName := "test.t-schema.sql" + compressFileSuffix(compressType)
+		_, err = os.Stat(path.Join(dir, fileName))
+		require.NoError(t, err)
+		bytes, err := storage.WithCompression(writer.extStorage, compressType).ReadFile(context.Background(), fileName)
+		require.NoError(t, err)
+		require.Equal(t, "/*!40101 SET NAMES binary*/;\nCREATE TABLE t (a INT);\n", string(bytes))
+		clean()
+	}
+}
+
 func TestWriteViewMeta(t *testing.T) {
 	dir := t.TempDir()
 	config := defaultConfigForTest(t)
@@ -212,3 +212,16 @@ type MaxMinSlidingWindowAggFunc interface {
 	// SetWindowStart sets the start position of window
 	SetWindowStart(start uint64)
 }
+
+// SpillableAggFunc is the interface implemented by the aggregate functions whose
+// partial results can be spilled to disk by the parallel hash aggregation.
+type SpillableAggFunc interface {
+	// SerializePartialResult encodes the partial result, appends it to buf and
+	// returns the extended buffer.
+	SerializePartialResult(pr PartialResult, buf []byte) []byte
+
+	// DeserializePartialResult allocates a partial result and restores it from
+	// the data encoded by SerializePartialResult. The returned value is the
+	// memDelta used to trace memory usage.
+	DeserializePartialResult(data []byte) (pr PartialResult, memDelta int64)
+}
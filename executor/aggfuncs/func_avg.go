@@ -459,3 +459,31 @@ func (e *avgOriginal4DistinctFloat64) AppendFinalResult2Chunk(sctx sessionctx.Co
 	chk.AppendFloat64(e.ordinal, p.sum/float64(p.count))
 	return nil
 }
+
+func (*baseAvgDecimal) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	p := (*partialResult4AvgDecimal)(pr)
+	buf = spillDecimal(buf, &p.sum)
+	return spillInt64(buf, p.count)
+}
+
+func (*baseAvgDecimal) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	d := spillDecoder{data}
+	p := new(partialResult4AvgDecimal)
+	d.decimal(&p.sum)
+	p.count = d.int64()
+	return PartialResult(p), DefPartialResult4AvgDecimalSize
+}
+
+func (*baseAvgFloat64) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	p := (*partialResult4AvgFloat64)(pr)
+	buf = spillFloat64(buf, p.sum)
+	return spillInt64(buf, p.count)
+}
+
+func (*baseAvgFloat64) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	d := spillDecoder{data}
+	p := new(partialResult4AvgFloat64)
+	p.sum = d.float64()
+	p.count = d.int64()
+	return PartialResult(p), DefPartialResult4AvgFloat64Size
+}
@@ -161,3 +161,14 @@ func (*bitAndUint64) MergePartialResult(sctx sessionctx.Context, src, dst Partia
 	*p2 &= *p1
 	return memDelta, nil
 }
+
+func (*baseBitAggFunc) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	return spillUint64(buf, *(*partialResult4BitFunc)(pr))
+}
+
+func (*baseBitAggFunc) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	d := spillDecoder{data}
+	p := new(partialResult4BitFunc)
+	*p = d.uint64()
+	return PartialResult(p), DefPartialResult4BitFuncSize
+}
@@ -415,3 +415,81 @@ func (*countPartial) MergePartialResult(sctx sessionctx.Context, src, dst Partia
 	*p2 += *p1
 	return 0, nil
 }
+
+// The partial results of COUNT without DISTINCT can be spilled to disk, the functions below
+// are shared by them.
+
+func serializeCount(pr PartialResult, buf []byte) []byte {
+	return spillInt64(buf, *(*partialResult4Count)(pr))
+}
+
+func deserializeCount(data []byte) (PartialResult, int64) {
+	d := spillDecoder{data}
+	p := new(partialResult4Count)
+	*p = d.int64()
+	return PartialResult(p), DefPartialResult4CountSize
+}
+
+func (*countOriginal4Int) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	return serializeCount(pr, buf)
+}
+
+func (*countOriginal4Int) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	return deserializeCount(data)
+}
+
+func (*countOriginal4Real) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	return serializeCount(pr, buf)
+}
+
+func (*countOriginal4Real) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	return deserializeCount(data)
+}
+
+func (*countOriginal4Decimal) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	return serializeCount(pr, buf)
+}
+
+func (*countOriginal4Decimal) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	return deserializeCount(data)
+}
+
+func (*countOriginal4Time) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	return serializeCount(pr, buf)
+}
+
+func (*countOriginal4Time) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	return deserializeCount(data)
+}
+
+func (*countOriginal4Duration) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	return serializeCount(pr, buf)
+}
+
+func (*countOriginal4Duration) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	return deserializeCount(data)
+}
+
+func (*countOriginal4JSON) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	return serializeCount(pr, buf)
+}
+
+func (*countOriginal4JSON) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	return deserializeCount(data)
+}
+
+func (*countOriginal4String) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	return serializeCount(pr, buf)
+}
+
+func (*countOriginal4String) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	return deserializeCount(data)
+}
+
+func (*countPartial) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	return serializeCount(pr, buf)
+}
+
+func (*countPartial) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	return deserializeCount(data)
+}
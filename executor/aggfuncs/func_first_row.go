@@ -594,3 +594,115 @@ func (e *firstRow4Set) AppendFinalResult2Chunk(sctx sessionctx.Context, pr Parti
 	chk.AppendSet(e.ordinal, p.val)
 	return nil
 }
+
+func (*firstRow4Int) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	p := (*partialResult4FirstRowInt)(pr)
+	buf = spillBool(buf, p.isNull)
+	buf = spillBool(buf, p.gotFirstRow)
+	return spillInt64(buf, p.val)
+}
+
+func (*firstRow4Int) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	d := spillDecoder{data}
+	p := new(partialResult4FirstRowInt)
+	p.isNull = d.bool()
+	p.gotFirstRow = d.bool()
+	p.val = d.int64()
+	return PartialResult(p), DefPartialResult4FirstRowIntSize
+}
+
+func (*firstRow4Float32) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	p := (*partialResult4FirstRowFloat32)(pr)
+	buf = spillBool(buf, p.isNull)
+	buf = spillBool(buf, p.gotFirstRow)
+	return spillFloat32(buf, p.val)
+}
+
+func (*firstRow4Float32) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	d := spillDecoder{data}
+	p := new(partialResult4FirstRowFloat32)
+	p.isNull = d.bool()
+	p.gotFirstRow = d.bool()
+	p.val = d.float32()
+	return PartialResult(p), DefPartialResult4FirstRowFloat32Size
+}
+
+func (*firstRow4Float64) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	p := (*partialResult4FirstRowFloat64)(pr)
+	buf = spillBool(buf, p.isNull)
+	buf = spillBool(buf, p.gotFirstRow)
+	return spillFloat64(buf, p.val)
+}
+
+func (*firstRow4Float64) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	d := spillDecoder{data}
+	p := new(partialResult4FirstRowFloat64)
+	p.isNull = d.bool()
+	p.gotFirstRow = d.bool()
+	p.val = d.float64()
+	return PartialResult(p), DefPartialResult4FirstRowFloat64Size
+}
+
+func (*firstRow4String) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	p := (*partialResult4FirstRowString)(pr)
+	buf = spillBool(buf, p.isNull)
+	buf = spillBool(buf, p.gotFirstRow)
+	return spillString(buf, p.val)
+}
+
+func (*firstRow4String) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	d := spillDecoder{data}
+	p := new(partialResult4FirstRowString)
+	p.isNull = d.bool()
+	p.gotFirstRow = d.bool()
+	p.val = d.string()
+	return PartialResult(p), DefPartialResult4FirstRowStringSize + int64(len(p.val))
+}
+
+func (*firstRow4Time) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	p := (*partialResult4FirstRowTime)(pr)
+	buf = spillBool(buf, p.isNull)
+	buf = spillBool(buf, p.gotFirstRow)
+	return spillTime(buf, p.val)
+}
+
+func (*firstRow4Time) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	d := spillDecoder{data}
+	p := new(partialResult4FirstRowTime)
+	p.isNull = d.bool()
+	p.gotFirstRow = d.bool()
+	p.val = d.time()
+	return PartialResult(p), DefPartialResult4FirstRowTimeSize
+}
+
+func (*firstRow4Duration) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	p := (*partialResult4FirstRowDuration)(pr)
+	buf = spillBool(buf, p.isNull)
+	buf = spillBool(buf, p.gotFirstRow)
+	return spillDuration(buf, p.val)
+}
+
+func (*firstRow4Duration) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	d := spillDecoder{data}
+	p := new(partialResult4FirstRowDuration)
+	p.isNull = d.bool()
+	p.gotFirstRow = d.bool()
+	p.val = d.duration()
+	return PartialResult(p), DefPartialResult4FirstRowDurationSize
+}
+
+func (*firstRow4Decimal) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	p := (*partialResult4FirstRowDecimal)(pr)
+	buf = spillBool(buf, p.isNull)
+	buf = spillBool(buf, p.gotFirstRow)
+	return spillDecimal(buf, &p.val)
+}
+
+func (*firstRow4Decimal) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	d := spillDecoder{data}
+	p := new(partialResult4FirstRowDecimal)
+	p.isNull = d.bool()
+	p.gotFirstRow = d.bool()
+	d.decimal(&p.val)
+	return PartialResult(p), DefPartialResult4FirstRowDecimalSize
+}
@@ -1587,3 +1587,115 @@ func (e *maxMin4Set) MergePartialResult(sctx sessionctx.Context, src, dst Partia
 	}
 	return 0, nil
 }
+
+func (*maxMin4Int) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	p := (*partialResult4MaxMinInt)(pr)
+	buf = spillBool(buf, p.isNull)
+	return spillInt64(buf, p.val)
+}
+
+func (*maxMin4Int) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	d := spillDecoder{data}
+	p := new(partialResult4MaxMinInt)
+	p.isNull = d.bool()
+	p.val = d.int64()
+	return PartialResult(p), DefPartialResult4MaxMinIntSize
+}
+
+func (*maxMin4Uint) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	p := (*partialResult4MaxMinUint)(pr)
+	buf = spillBool(buf, p.isNull)
+	return spillUint64(buf, p.val)
+}
+
+func (*maxMin4Uint) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	d := spillDecoder{data}
+	p := new(partialResult4MaxMinUint)
+	p.isNull = d.bool()
+	p.val = d.uint64()
+	return PartialResult(p), DefPartialResult4MaxMinUintSize
+}
+
+func (*maxMin4Float32) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	p := (*partialResult4MaxMinFloat32)(pr)
+	buf = spillBool(buf, p.isNull)
+	return spillFloat32(buf, p.val)
+}
+
+func (*maxMin4Float32) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	d := spillDecoder{data}
+	p := new(partialResult4MaxMinFloat32)
+	p.isNull = d.bool()
+	p.val = d.float32()
+	return PartialResult(p), DefPartialResult4MaxMinFloat32Size
+}
+
+func (*maxMin4Float64) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	p := (*partialResult4MaxMinFloat64)(pr)
+	buf = spillBool(buf, p.isNull)
+	return spillFloat64(buf, p.val)
+}
+
+func (*maxMin4Float64) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	d := spillDecoder{data}
+	p := new(partialResult4MaxMinFloat64)
+	p.isNull = d.bool()
+	p.val = d.float64()
+	return PartialResult(p), DefPartialResult4MaxMinFloat64Size
+}
+
+func (*maxMin4Decimal) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	p := (*partialResult4MaxMinDecimal)(pr)
+	buf = spillBool(buf, p.isNull)
+	return spillDecimal(buf, &p.val)
+}
+
+func (*maxMin4Decimal) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	d := spillDecoder{data}
+	p := new(partialResult4MaxMinDecimal)
+	p.isNull = d.bool()
+	d.decimal(&p.val)
+	return PartialResult(p), DefPartialResult4MaxMinDecimalSize
+}
+
+func (*maxMin4String) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	p := (*partialResult4MaxMinString)(pr)
+	buf = spillBool(buf, p.isNull)
+	return spillString(buf, p.val)
+}
+
+func (*maxMin4String) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	d := spillDecoder{data}
+	p := new(partialResult4MaxMinString)
+	p.isNull = d.bool()
+	p.val = d.string()
+	return PartialResult(p), DefPartialResult4MaxMinStringSize + int64(len(p.val))
+}
+
+func (*maxMin4Time) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	p := (*partialResult4MaxMinTime)(pr)
+	buf = spillBool(buf, p.isNull)
+	return spillTime(buf, p.val)
+}
+
+func (*maxMin4Time) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	d := spillDecoder{data}
+	p := new(partialResult4MaxMinTime)
+	p.isNull = d.bool()
+	p.val = d.time()
+	return PartialResult(p), DefPartialResult4MaxMinTimeSize
+}
+
+func (*maxMin4Duration) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	p := (*partialResult4MaxMinDuration)(pr)
+	buf = spillBool(buf, p.isNull)
+	return spillDuration(buf, p.val)
+}
+
+func (*maxMin4Duration) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	d := spillDecoder{data}
+	p := new(partialResult4MaxMinDuration)
+	p.isNull = d.bool()
+	p.val = d.duration()
+	return PartialResult(p), DefPartialResult4MaxMinDurationSize
+}
@@ -381,3 +381,31 @@ func (e *sum4DistinctDecimal) AppendFinalResult2Chunk(sctx sessionctx.Context, p
 	chk.AppendMyDecimal(e.ordinal, &p.val)
 	return nil
 }
+
+func (*baseSum4Float64) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	p := (*partialResult4SumFloat64)(pr)
+	buf = spillFloat64(buf, p.val)
+	return spillInt64(buf, p.notNullRowCount)
+}
+
+func (*baseSum4Float64) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	d := spillDecoder{data}
+	p := new(partialResult4SumFloat64)
+	p.val = d.float64()
+	p.notNullRowCount = d.int64()
+	return PartialResult(p), DefPartialResult4SumFloat64Size
+}
+
+func (*sum4Decimal) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	p := (*partialResult4SumDecimal)(pr)
+	buf = spillDecimal(buf, &p.val)
+	return spillInt64(buf, p.notNullRowCount)
+}
+
+func (*sum4Decimal) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	d := spillDecoder{data}
+	p := new(partialResult4SumDecimal)
+	d.decimal(&p.val)
+	p.notNullRowCount = d.int64()
+	return PartialResult(p), DefPartialResult4SumDecimalSize
+}
@@ -179,3 +179,19 @@ func (e *varPop4DistinctFloat64) UpdatePartialResult(sctx sessionctx.Context, ro
 	}
 	return memDelta, nil
 }
+
+func (*varPop4Float64) SerializePartialResult(pr PartialResult, buf []byte) []byte {
+	p := (*partialResult4VarPopFloat64)(pr)
+	buf = spillInt64(buf, p.count)
+	buf = spillFloat64(buf, p.sum)
+	return spillFloat64(buf, p.variance)
+}
+
+func (*varPop4Float64) DeserializePartialResult(data []byte) (PartialResult, int64) {
+	d := spillDecoder{data}
+	p := new(partialResult4VarPopFloat64)
+	p.count = d.int64()
+	p.sum = d.float64()
+	p.variance = d.float64()
+	return PartialResult(p), DefPartialResult4VarPopFloat64Size
+}
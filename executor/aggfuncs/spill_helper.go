@@ -0,0 +1,131 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggfuncs
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+	"unsafe"
+
+	"github.com/pingcap/tidb/types"
+)
+
+// The helpers below encode the fields of the partial results for spilling. The encoded
+// data is only read back by the same process, so the values are encoded in the
+// native layout and no compatibility is guaranteed.
+
+const sizeOfTime = int(unsafe.Sizeof(types.Time{}))
+
+func spillInt64(buf []byte, v int64) []byte {
+	return spillUint64(buf, uint64(v))
+}
+
+func spillUint64(buf []byte, v uint64) []byte {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	return append(buf, b[:]...)
+}
+
+func spillFloat32(buf []byte, v float32) []byte {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], math.Float32bits(v))
+	return append(buf, b[:]...)
+}
+
+func spillFloat64(buf []byte, v float64) []byte {
+	return spillUint64(buf, math.Float64bits(v))
+}
+
+func spillBool(buf []byte, v bool) []byte {
+	if v {
+		return append(buf, 1)
+	}
+	return append(buf, 0)
+}
+
+func spillDecimal(buf []byte, v *types.MyDecimal) []byte {
+	return append(buf, (*[types.MyDecimalStructSize]byte)(unsafe.Pointer(v))[:]...)
+}
+
+func spillTime(buf []byte, v types.Time) []byte {
+	return append(buf, (*[sizeOfTime]byte)(unsafe.Pointer(&v))[:]...)
+}
+
+func spillDuration(buf []byte, v types.Duration) []byte {
+	buf = spillInt64(buf, int64(v.Duration))
+	return spillInt64(buf, int64(v.Fsp))
+}
+
+func spillString(buf []byte, v string) []byte {
+	buf = spillUint64(buf, uint64(len(v)))
+	return append(buf, v...)
+}
+
+// spillDecoder reads the fields encoded by the spillXXX helpers in order.
+type spillDecoder struct {
+	data []byte
+}
+
+func (d *spillDecoder) int64() int64 {
+	return int64(d.uint64())
+}
+
+func (d *spillDecoder) uint64() uint64 {
+	v := binary.LittleEndian.Uint64(d.data)
+	d.data = d.data[8:]
+	return v
+}
+
+func (d *spillDecoder) float32() float32 {
+	v := math.Float32frombits(binary.LittleEndian.Uint32(d.data))
+	d.data = d.data[4:]
+	return v
+}
+
+func (d *spillDecoder) float64() float64 {
+	return math.Float64frombits(d.uint64())
+}
+
+func (d *spillDecoder) bool() bool {
+	v := d.data[0] != 0
+	d.data = d.data[1:]
+	return v
+}
+
+func (d *spillDecoder) decimal(v *types.MyDecimal) {
+	copy((*[types.MyDecimalStructSize]byte)(unsafe.Pointer(v))[:], d.data)
+	d.data = d.data[types.MyDecimalStructSize:]
+}
+
+func (d *spillDecoder) time() (v types.Time) {
+	copy((*[sizeOfTime]byte)(unsafe.Pointer(&v))[:], d.data)
+	d.data = d.data[sizeOfTime:]
+	return v
+}
+
+func (d *spillDecoder) duration() (v types.Duration) {
+	v.Duration = time.Duration(d.int64())
+	v.Fsp = int(d.int64())
+	return v
+}
+
+// string returns a copy of the encoded string, so that the returned string doesn't refer to the spilled data.
+func (d *spillDecoder) string() string {
+	n := d.uint64()
+	v := string(d.data[:n])
+	d.data = d.data[n:]
+	return v
+}
@@ -0,0 +1,98 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggfuncs_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/tidb/executor/aggfuncs"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/expression/aggregation"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/collate"
+	"github.com/pingcap/tidb/util/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpillPartialResult(t *testing.T) {
+	tests := []aggTest{
+		buildAggTester(ast.AggFuncCount, mysql.TypeLonglong, 5, 5),
+		buildAggTester(ast.AggFuncCount, mysql.TypeString, 5, 5),
+		buildAggTester(ast.AggFuncSum, mysql.TypeDouble, 5, 10.0),
+		buildAggTester(ast.AggFuncSum, mysql.TypeNewDecimal, 5, types.NewDecFromInt(10)),
+		buildAggTester(ast.AggFuncAvg, mysql.TypeDouble, 5, 2.0),
+		buildAggTester(ast.AggFuncAvg, mysql.TypeNewDecimal, 5, types.NewDecFromInt(2)),
+		buildAggTester(ast.AggFuncBitOr, mysql.TypeLonglong, 5, uint64(7)),
+		buildAggTester(ast.AggFuncVarPop, mysql.TypeDouble, 5, 2.0),
+		buildAggTester(ast.AggFuncMax, mysql.TypeLonglong, 5, 4),
+		buildAggTester(ast.AggFuncMax, mysql.TypeString, 5, "4"),
+		buildAggTester(ast.AggFuncMin, mysql.TypeNewDecimal, 5, types.NewDecFromInt(0)),
+		buildAggTester(ast.AggFuncMin, mysql.TypeDate, 5, types.TimeFromDays(365)),
+		buildAggTester(ast.AggFuncMax, mysql.TypeDuration, 5, types.Duration{Duration: 4}),
+		buildAggTester(ast.AggFuncFirstRow, mysql.TypeDouble, 5, 0.0),
+		buildAggTester(ast.AggFuncFirstRow, mysql.TypeString, 5, "0"),
+	}
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%s_%d", test.funcName, i), func(t *testing.T) {
+			testSpillPartialResult(t, test)
+		})
+	}
+}
+
+// testSpillPartialResult checks that the partial result restored from the spilled data gives the same final result.
+func testSpillPartialResult(t *testing.T, p aggTest) {
+	ctx := mock.NewContext()
+	srcChk := p.genSrcChk()
+	iter := chunk.NewIterator4Chunk(srcChk)
+
+	args := []expression.Expression{&expression.Column{RetType: p.dataType, Index: 0}}
+	desc, err := aggregation.NewAggFuncDesc(ctx, p.funcName, args, false)
+	require.NoError(t, err)
+	_, finalDesc := desc.Split([]int{0, 1})
+	partialDesc := desc.Clone()
+	partialDesc.Mode = aggregation.Partial1Mode
+
+	partialFunc := aggfuncs.Build(ctx, partialDesc, 0)
+	finalFunc := aggfuncs.Build(ctx, finalDesc, 0)
+	spillable, ok := partialFunc.(aggfuncs.SpillableAggFunc)
+	require.True(t, ok)
+	_, ok = finalFunc.(aggfuncs.SpillableAggFunc)
+	require.True(t, ok)
+
+	partialResult, _ := partialFunc.AllocPartialResult()
+	for row := iter.Begin(); row != iter.End(); row = iter.Next() {
+		_, err = partialFunc.UpdatePartialResult(ctx, []chunk.Row{row}, partialResult)
+		require.NoError(t, err)
+	}
+	data := spillable.SerializePartialResult(partialResult, nil)
+	restored, memDelta := spillable.DeserializePartialResult(data)
+	require.Greater(t, memDelta, int64(0))
+
+	// The restored partial result is merged by the final function as the parallel HashAgg does.
+	finalPr, _ := finalFunc.AllocPartialResult()
+	_, err = finalFunc.MergePartialResult(ctx, restored, finalPr)
+	require.NoError(t, err)
+	resultChk := chunk.NewChunkWithCapacity([]*types.FieldType{finalDesc.RetTp}, 1)
+	err = finalFunc.AppendFinalResult2Chunk(ctx, finalPr, resultChk)
+	require.NoError(t, err)
+	dt := resultChk.GetRow(0).GetDatum(0, finalDesc.RetTp)
+	result, err := dt.Compare(ctx.GetSessionVars().StmtCtx, &p.results[0], collate.GetCollator(finalDesc.RetTp.Collate))
+	require.NoError(t, err)
+	require.Equalf(t, 0, result, "%v != %v", dt.String(), p.results[0])
+}
@@ -97,6 +97,10 @@ type HashAggPartialWorker struct {
 	// chk stores the input data from child,
 	// and is reused by childExec and partial worker.
 	chk *chunk.Chunk
+	// spillHelper is nil if the partial results can't be spilled.
+	spillHelper *parallelHashAggSpillHelper
+	// spillRound is the last round of spilling handled by this worker.
+	spillRound uint32
 }
 
 // HashAggFinalWorker indicates the final workers of parallel hash agg execution,
@@ -112,6 +116,10 @@ type HashAggFinalWorker struct {
 	outputCh            chan *AfFinalResult
 	finalResultHolderCh chan *chunk.Chunk
 	groupKeys           [][]byte
+	// idx is the index of the final worker, which decides the spilled partitions it merges.
+	idx         int
+	spillHelper *parallelHashAggSpillHelper
+	spillRound  uint32
 }
 
 // AfFinalResult indicates aggregation functions final result.
@@ -218,6 +226,9 @@ type HashAggExec struct {
 	spillAction *AggSpillDiskAction
 	// isChildDrained indicates whether the all data from child has been taken out.
 	isChildDrained bool
+	// spillHelper manages the partial results spilled by the workers of parallel execution,
+	// it's nil if the spilling is disabled or not supported by the aggregate functions.
+	spillHelper *parallelHashAggSpillHelper
 }
 
 // HashAggInput indicates the input of hash agg exec.
@@ -294,6 +305,9 @@ func (e *HashAggExec) Close() error {
 		if e.memTracker != nil {
 			e.memTracker.ReplaceBytesUsed(0)
 		}
+		if e.spillHelper != nil {
+			e.spillHelper.close()
+		}
 	}
 	return e.baseExecutor.Close()
 }
@@ -380,10 +394,25 @@ func (e *HashAggExec) initForParallelExec(ctx sessionctx.Context) {
 	e.finalWorkers = make([]HashAggFinalWorker, finalConcurrency)
 	e.initRuntimeStats()
 
+	e.spillHelper = nil
+	if sessionVars.TrackAggregateMemoryUsage && config.GetGlobalConfig().OOMUseTmpStorage && e.isSpillSupported() {
+		e.diskTracker = disk.NewTracker(e.id, -1)
+		e.diskTracker.AttachTo(sessionVars.StmtCtx.DiskTracker)
+		e.spillHelper = newParallelHashAggSpillHelper(len(e.PartialAggFuncs), finalConcurrency, e.maxChunkSize, e.diskTracker)
+		// The executor may be reopened, e.g. as the inner side of an Apply, and the action
+		// always spills through the current spillHelper, so it is only registered once.
+		if e.spillAction == nil {
+			sessionVars.StmtCtx.MemTracker.FallbackOldAndSetNewActionForSoftLimit(e.ActionSpill())
+		}
+	}
+
 	// Init partial workers.
 	for i := 0; i < partialConcurrency; i++ {
+		// Each worker tracks its memory usage separately, so that the usage can be released when it spills.
+		memTracker := memory.NewTracker(memory.LabelForHashAggPartialWorker, -1)
+		memTracker.AttachTo(e.memTracker)
 		w := HashAggPartialWorker{
-			baseHashAggWorker: newBaseHashAggWorker(e.ctx, e.finishCh, e.PartialAggFuncs, e.maxChunkSize, e.memTracker),
+			baseHashAggWorker: newBaseHashAggWorker(e.ctx, e.finishCh, e.PartialAggFuncs, e.maxChunkSize, memTracker),
 			inputCh:           e.partialInputChs[i],
 			outputChs:         e.partialOutputChs,
 			giveBackCh:        e.inputCh,
@@ -392,15 +421,16 @@ func (e *HashAggExec) initForParallelExec(ctx sessionctx.Context) {
 			groupByItems:      e.GroupByItems,
 			chk:               newFirstChunk(e.children[0]),
 			groupKey:          make([][]byte, 0, 8),
+			spillHelper:       e.spillHelper,
 		}
 		// There is a bucket in the empty partialResultsMap.
 		failpoint.Inject("ConsumeRandomPanic", nil)
-		e.memTracker.Consume(defBucketMemoryUsage * (1 << w.BInMap))
+		w.memTracker.Consume(defBucketMemoryUsage * (1 << w.BInMap))
 		if e.stats != nil {
 			w.stats = &AggWorkerStat{}
 			e.stats.PartialStats = append(e.stats.PartialStats, w.stats)
 		}
-		e.memTracker.Consume(w.chk.MemoryUsage())
+		w.memTracker.Consume(w.chk.MemoryUsage())
 		e.partialWorkers[i] = w
 		input := &HashAggInput{
 			chk:        newFirstChunk(e.children[0]),
@@ -412,9 +442,11 @@ func (e *HashAggExec) initForParallelExec(ctx sessionctx.Context) {
 
 	// Init final workers.
 	for i := 0; i < finalConcurrency; i++ {
+		memTracker := memory.NewTracker(memory.LabelForHashAggFinalWorker, -1)
+		memTracker.AttachTo(e.memTracker)
 		groupSet, setSize := set.NewStringSetWithMemoryUsage()
 		w := HashAggFinalWorker{
-			baseHashAggWorker:   newBaseHashAggWorker(e.ctx, e.finishCh, e.FinalAggFuncs, e.maxChunkSize, e.memTracker),
+			baseHashAggWorker:   newBaseHashAggWorker(e.ctx, e.finishCh, e.FinalAggFuncs, e.maxChunkSize, memTracker),
 			partialResultMap:    make(aggPartialResultMapper),
 			groupSet:            groupSet,
 			inputCh:             e.partialOutputChs[i],
@@ -423,9 +455,11 @@ func (e *HashAggExec) initForParallelExec(ctx sessionctx.Context) {
 			rowBuffer:           make([]types.Datum, 0, e.Schema().Len()),
 			mutableRow:          chunk.MutRowFromTypes(retTypes(e)),
 			groupKeys:           make([][]byte, 0, 8),
+			idx:                 i,
+			spillHelper:         e.spillHelper,
 		}
 		// There is a bucket in the empty partialResultsMap.
-		e.memTracker.Consume(defBucketMemoryUsage*(1<<w.BInMap) + setSize)
+		w.memTracker.Consume(defBucketMemoryUsage*(1<<w.BInMap) + setSize)
 		if e.stats != nil {
 			w.stats = &AggWorkerStat{}
 			e.stats.FinalStats = append(e.stats.FinalStats, w.stats)
@@ -468,7 +502,15 @@ func (w *HashAggPartialWorker) run(ctx sessionctx.Context, waitGroup *sync.WaitG
 			recoveryHashAgg(w.globalOutputCh, r)
 		}
 		if needShuffle {
-			w.shuffleIntermData(sc, finalConcurrency)
+			if w.spillHelper != nil && w.spillHelper.isSpillTriggered() {
+				// The memory is insufficient, spill the partial results directly rather than
+				// sending them to the final workers.
+				if err := w.spillPartialResults(); err != nil {
+					w.globalOutputCh <- &AfFinalResult{err: err}
+				}
+			} else {
+				w.shuffleIntermData(sc, finalConcurrency)
+			}
 		}
 		w.memTracker.Consume(-w.chk.MemoryUsage())
 		if w.stats != nil {
@@ -490,6 +532,12 @@ func (w *HashAggPartialWorker) run(ctx sessionctx.Context, waitGroup *sync.WaitG
 			w.globalOutputCh <- &AfFinalResult{err: err}
 			return
 		}
+		if w.spillHelper != nil && w.spillHelper.needSpill(&w.spillRound) {
+			if err := w.spillPartialResults(); err != nil {
+				w.globalOutputCh <- &AfFinalResult{err: err}
+				return
+			}
+		}
 		if w.stats != nil {
 			w.stats.ExecTime += int64(time.Since(execStart))
 			w.stats.TaskNum += 1
@@ -536,6 +584,17 @@ func (w *HashAggPartialWorker) updatePartialResult(ctx sessionctx.Context, sc *s
 	return nil
 }
 
+// spillPartialResults spills all the partial results to disk and releases their memory.
+func (w *HashAggPartialWorker) spillPartialResults() error {
+	if err := w.spillHelper.spill(w.partialResultsMap, w.aggFuncs); err != nil {
+		return err
+	}
+	w.partialResultsMap = make(aggPartialResultMapper)
+	w.BInMap = 0
+	w.memTracker.ReplaceBytesUsed(w.chk.MemoryUsage() + getGroupKeyMemUsage(w.groupKey) + defBucketMemoryUsage)
+	return nil
+}
+
 // shuffleIntermData shuffles the intermediate data of partial workers to corresponded final workers.
 // We only support parallel execution for single-machine, so process of encode and decode can be skipped.
 func (w *HashAggPartialWorker) shuffleIntermData(sc *stmtctx.StatementContext, finalConcurrency int) {
@@ -685,6 +744,11 @@ func (w *HashAggFinalWorker) consumeIntermData(sctx sessionctx.Context) (err err
 			}
 			w.memTracker.Consume(allMemDelta)
 		}
+		if w.spillHelper != nil && w.spillHelper.needSpill(&w.spillRound) {
+			if err := w.spillFinalResults(); err != nil {
+				return err
+			}
+		}
 		if w.stats != nil {
 			w.stats.ExecTime += int64(time.Since(execStart))
 			w.stats.TaskNum += 1
@@ -692,14 +756,95 @@ func (w *HashAggFinalWorker) consumeIntermData(sctx sessionctx.Context) (err err
 	}
 }
 
-func (w *HashAggFinalWorker) getFinalResult(sctx sessionctx.Context) {
+// spillFinalResults spills the partial results merged by the final worker to disk and releases their memory.
+func (w *HashAggFinalWorker) spillFinalResults() error {
+	if len(w.partialResultMap) > 0 {
+		if err := w.spillHelper.spill(w.partialResultMap, w.aggFuncs); err != nil {
+			return err
+		}
+	}
+	w.resetPartialResults()
+	return nil
+}
+
+// resetPartialResults clears the merged partial results and releases their memory.
+func (w *HashAggFinalWorker) resetPartialResults() {
+	var setSize int64
+	w.groupSet, setSize = set.NewStringSetWithMemoryUsage()
+	w.partialResultMap = make(aggPartialResultMapper)
+	w.BInMap = 0
+	w.memTracker.ReplaceBytesUsed(getGroupKeyMemUsage(w.groupKeys) + defBucketMemoryUsage + setSize)
+}
+
+// mergeSpilledPartition restores the partial results of the spilled sub-partition and merges them.
+func (w *HashAggFinalWorker) mergeSpilledPartition(sctx sessionctx.Context, partitionIdx int) error {
+	sc := sctx.GetSessionVars().StmtCtx
+	for i := 0; i < w.spillHelper.numChunks(partitionIdx); i++ {
+		chk, err := w.spillHelper.getChunk(partitionIdx, i)
+		if err != nil {
+			return err
+		}
+		numRows := chk.NumRows()
+		memSize := getGroupKeyMemUsage(w.groupKeys)
+		w.groupKeys = w.groupKeys[:0]
+		for j := 0; j < numRows; j++ {
+			w.groupKeys = append(w.groupKeys, []byte(chk.GetRow(j).GetString(0)))
+		}
+		w.memTracker.Consume(getGroupKeyMemUsage(w.groupKeys) - memSize)
+		finalPartialResults := w.getPartialResult(sc, w.groupKeys, w.partialResultMap)
+		allMemDelta := int64(0)
+		for j := 0; j < numRows; j++ {
+			row := chk.GetRow(j)
+			groupKey := string(w.groupKeys[j])
+			if !w.groupSet.Exist(groupKey) {
+				allMemDelta += w.groupSet.Insert(groupKey)
+			}
+			for k, af := range w.aggFuncs {
+				pr, _ := af.(aggfuncs.SpillableAggFunc).DeserializePartialResult(row.GetBytes(k + 1))
+				memDelta, err := af.MergePartialResult(sctx, pr, finalPartialResults[j][k])
+				if err != nil {
+					return err
+				}
+				allMemDelta += memDelta
+			}
+		}
+		w.memTracker.Consume(allMemDelta)
+	}
+	return nil
+}
+
+// mergeAndOutputSpilledResults merges the spilled partitions of the final worker one by one and
+// outputs the final results of each partition, so that only one partition is kept in memory.
+func (w *HashAggFinalWorker) mergeAndOutputSpilledResults(sctx sessionctx.Context) error {
+	// Spill the partial results in memory first, they will be merged with the spilled ones of the same partition.
+	if err := w.spillFinalResults(); err != nil {
+		return err
+	}
+	for _, idx := range w.spillHelper.partitionsOf(w.idx) {
+		if w.spillHelper.numChunks(idx) == 0 {
+			continue
+		}
+		if err := w.mergeSpilledPartition(sctx, idx); err != nil {
+			return err
+		}
+		if finished := w.getFinalResult(sctx); finished {
+			return nil
+		}
+		w.resetPartialResults()
+	}
+	return nil
+}
+
+// getFinalResult evaluates the final results and sends them to the main thread, it returns whether
+// the execution is finished.
+func (w *HashAggFinalWorker) getFinalResult(sctx sessionctx.Context) (finished bool) {
 	waitStart := time.Now()
 	result, finished := w.receiveFinalResultHolder()
 	if w.stats != nil {
 		w.stats.WaitTime += int64(time.Since(waitStart))
 	}
 	if finished {
-		return
+		return true
 	}
 	execStart := time.Now()
 	memSize := getGroupKeyMemUsage(w.groupKeys)
@@ -723,7 +868,7 @@ func (w *HashAggFinalWorker) getFinalResult(sctx sessionctx.Context) {
 			w.outputCh <- &AfFinalResult{chk: result, giveBackCh: w.finalResultHolderCh}
 			result, finished = w.receiveFinalResultHolder()
 			if finished {
-				return
+				return true
 			}
 		}
 	}
@@ -731,6 +876,7 @@ func (w *HashAggFinalWorker) getFinalResult(sctx sessionctx.Context) {
 	if w.stats != nil {
 		w.stats.ExecTime += int64(time.Since(execStart))
 	}
+	return false
 }
 
 func (w *HashAggFinalWorker) receiveFinalResultHolder() (*chunk.Chunk, bool) {
@@ -753,9 +899,16 @@ func (w *HashAggFinalWorker) run(ctx sessionctx.Context, waitGroup *sync.WaitGro
 		}
 		waitGroup.Done()
 	}()
-	if err := w.consumeIntermData(ctx); err != nil {
+	err := w.consumeIntermData(ctx)
+	if err != nil {
 		w.outputCh <- &AfFinalResult{err: err}
 	}
+	if err == nil && w.spillHelper != nil && w.spillHelper.hasSpilledData(w.idx) {
+		if err := w.mergeAndOutputSpilledResults(ctx); err != nil {
+			w.outputCh <- &AfFinalResult{err: err}
+		}
+		return
+	}
 	w.getFinalResult(ctx)
 }
 
@@ -1880,6 +2033,177 @@ func (e *vecGroupChecker) reset() {
 	}
 }
 
+// isSpillSupported indicates whether the partial results of all the aggregate functions can be spilled.
+func (e *HashAggExec) isSpillSupported() bool {
+	for _, aggFuncs := range [][]aggfuncs.AggFunc{e.PartialAggFuncs, e.FinalAggFuncs} {
+		for _, af := range aggFuncs {
+			if _, ok := af.(aggfuncs.SpillableAggFunc); !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// spillSubPartitionNum is the number of the sub-partitions of each final worker's partition.
+// The final worker restores and merges its sub-partitions one by one, so a larger number
+// results in less memory usage but more spilled files.
+const spillSubPartitionNum = 8
+
+// parallelHashAggSpillHelper manages the partial results spilled by the workers of the parallel HashAggExec.
+// The partial results are partitioned by the hash of the group keys in the same way as the partial workers
+// shuffle them, so the spilled partial results of a group can only be merged by the final worker which the
+// group belongs to. Each partition is further divided into spillSubPartitionNum sub-partitions.
+type parallelHashAggSpillHelper struct {
+	// spillRound is increased every time the memory quota is exceeded, the workers spill their partial
+	// results once they find it changed. handledRound is the latest round that has been handled.
+	spillRound   uint32
+	handledRound uint32
+
+	finalConcurrency int
+	maxChunkSize     int
+	// fieldTypes is the schema of the spilled rows, which consists of the group key and the
+	// serialized partial results of all the aggregate functions.
+	fieldTypes []*types.FieldType
+	// lists[i] stores the partial results of the i-th sub-partition, and the i-th sub-partition
+	// belongs to the (i / spillSubPartitionNum)-th final worker.
+	lists []*chunk.ListInDisk
+	locks []sync.Mutex
+}
+
+func newParallelHashAggSpillHelper(aggFuncNum, finalConcurrency, maxChunkSize int, diskTracker *disk.Tracker) *parallelHashAggSpillHelper {
+	fieldTypes := make([]*types.FieldType, 0, aggFuncNum+1)
+	for i := 0; i <= aggFuncNum; i++ {
+		fieldTypes = append(fieldTypes, types.NewFieldType(mysql.TypeVarString))
+	}
+	h := &parallelHashAggSpillHelper{
+		finalConcurrency: finalConcurrency,
+		maxChunkSize:     maxChunkSize,
+		fieldTypes:       fieldTypes,
+		lists:            make([]*chunk.ListInDisk, finalConcurrency*spillSubPartitionNum),
+		locks:            make([]sync.Mutex, finalConcurrency*spillSubPartitionNum),
+	}
+	for i := range h.lists {
+		h.lists[i] = chunk.NewListInDisk(fieldTypes)
+		h.lists[i].GetDiskTracker().AttachTo(diskTracker)
+	}
+	return h
+}
+
+// triggerSpill starts a new round of spilling, it does nothing if the last round hasn't been handled.
+func (h *parallelHashAggSpillHelper) triggerSpill() bool {
+	round := atomic.LoadUint32(&h.spillRound)
+	if atomic.LoadUint32(&h.handledRound) != round {
+		return false
+	}
+	return atomic.CompareAndSwapUint32(&h.spillRound, round, round+1)
+}
+
+// isSpillTriggered indicates whether the memory quota has been exceeded during the execution.
+func (h *parallelHashAggSpillHelper) isSpillTriggered() bool {
+	return atomic.LoadUint32(&h.spillRound) > 0
+}
+
+// needSpill checks whether a new round of spilling is triggered after the last round handled by the worker.
+func (h *parallelHashAggSpillHelper) needSpill(lastRound *uint32) bool {
+	round := atomic.LoadUint32(&h.spillRound)
+	if round == *lastRound {
+		return false
+	}
+	*lastRound = round
+	atomic.StoreUint32(&h.handledRound, round)
+	return true
+}
+
+// partitionIdx returns the index of the sub-partition which the group key belongs to.
+func (h *parallelHashAggSpillHelper) partitionIdx(groupKey string) int {
+	hash := int(murmur3.Sum32([]byte(groupKey)))
+	// The final worker index must be the same as the one in shuffleIntermData.
+	return hash%h.finalConcurrency*spillSubPartitionNum + hash/h.finalConcurrency%spillSubPartitionNum
+}
+
+// partitionsOf returns the indexes of the sub-partitions which belong to the final worker.
+func (h *parallelHashAggSpillHelper) partitionsOf(finalWorkerIdx int) []int {
+	idxes := make([]int, 0, spillSubPartitionNum)
+	for i := 0; i < spillSubPartitionNum; i++ {
+		idxes = append(idxes, finalWorkerIdx*spillSubPartitionNum+i)
+	}
+	return idxes
+}
+
+// hasSpilledData indicates whether there are any partial results spilled for the final worker.
+func (h *parallelHashAggSpillHelper) hasSpilledData(finalWorkerIdx int) bool {
+	for _, idx := range h.partitionsOf(finalWorkerIdx) {
+		if h.numChunks(idx) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// The partial workers may be still spilling when the execution is cancelled, so the sub-partitions
+// are always accessed with the lock.
+
+func (h *parallelHashAggSpillHelper) numChunks(idx int) int {
+	h.locks[idx].Lock()
+	defer h.locks[idx].Unlock()
+	return h.lists[idx].NumChunks()
+}
+
+func (h *parallelHashAggSpillHelper) getChunk(idx, chkIdx int) (*chunk.Chunk, error) {
+	h.locks[idx].Lock()
+	defer h.locks[idx].Unlock()
+	return h.lists[idx].GetChunk(chkIdx)
+}
+
+// spill serializes the partial results and appends them to the sub-partitions which they belong to.
+func (h *parallelHashAggSpillHelper) spill(mapper aggPartialResultMapper, aggFuncs []aggfuncs.AggFunc) error {
+	groupKeysSlice := make([][]string, len(h.lists))
+	for groupKey := range mapper {
+		idx := h.partitionIdx(groupKey)
+		groupKeysSlice[idx] = append(groupKeysSlice[idx], groupKey)
+	}
+	chk := chunk.NewChunkWithCapacity(h.fieldTypes, h.maxChunkSize)
+	var buf []byte
+	for idx, groupKeys := range groupKeysSlice {
+		for _, groupKey := range groupKeys {
+			chk.AppendString(0, groupKey)
+			partialResults := mapper[groupKey]
+			for i, af := range aggFuncs {
+				buf = af.(aggfuncs.SpillableAggFunc).SerializePartialResult(partialResults[i], buf[:0])
+				chk.AppendBytes(i+1, buf)
+			}
+			if chk.NumRows() >= h.maxChunkSize {
+				if err := h.add(idx, chk); err != nil {
+					return err
+				}
+				chk.Reset()
+			}
+		}
+		if chk.NumRows() > 0 {
+			if err := h.add(idx, chk); err != nil {
+				return err
+			}
+			chk.Reset()
+		}
+	}
+	return nil
+}
+
+func (h *parallelHashAggSpillHelper) add(idx int, chk *chunk.Chunk) error {
+	h.locks[idx].Lock()
+	defer h.locks[idx].Unlock()
+	return h.lists[idx].Add(chk)
+}
+
+func (h *parallelHashAggSpillHelper) close() {
+	for i, list := range h.lists {
+		h.locks[i].Lock()
+		terror.Log(list.Close())
+		h.locks[i].Unlock()
+	}
+}
+
 // ActionSpill returns a AggSpillDiskAction for spilling intermediate data for hashAgg.
 func (e *HashAggExec) ActionSpill() *AggSpillDiskAction {
 	if e.spillAction == nil {
@@ -1893,7 +2217,7 @@ func (e *HashAggExec) ActionSpill() *AggSpillDiskAction {
 // maxSpillTimes indicates how many times the data can spill at most.
 const maxSpillTimes = 10
 
-// AggSpillDiskAction implements memory.ActionOnExceed for unparalleled HashAgg.
+// AggSpillDiskAction implements memory.ActionOnExceed for HashAgg.
 // If the memory quota of a query is exceeded, AggSpillDiskAction.Action is
 // triggered.
 type AggSpillDiskAction struct {
@@ -1904,6 +2228,10 @@ type AggSpillDiskAction struct {
 
 // Action set HashAggExec spill mode.
 func (a *AggSpillDiskAction) Action(t *memory.Tracker) {
+	if !a.e.isUnparallelExec {
+		a.parallelAction(t)
+		return
+	}
 	// Guarantee that processed data is at least 20% of the threshold, to avoid spilling too frequently.
 	if atomic.LoadUint32(&a.e.inSpillMode) == 0 && a.spillTimes < maxSpillTimes && a.e.memTracker.BytesConsumed() >= t.GetBytesLimit()/5 {
 		a.spillTimes++
@@ -1919,6 +2247,23 @@ func (a *AggSpillDiskAction) Action(t *memory.Tracker) {
 	}
 }
 
+// parallelAction notifies the workers of parallel HashAgg to spill their partial results.
+func (a *AggSpillDiskAction) parallelAction(t *memory.Tracker) {
+	spillHelper := a.e.spillHelper
+	if spillHelper != nil && a.e.memTracker.BytesConsumed() >= t.GetBytesLimit()/5 {
+		if spillHelper.triggerSpill() {
+			logutil.BgLogger().Info("memory exceeds quota, spill the partial results of parallel aggregation",
+				zap.Uint32("spillRound", atomic.LoadUint32(&spillHelper.spillRound)),
+				zap.Int64("consumed", t.BytesConsumed()),
+				zap.Int64("quota", t.GetBytesLimit()))
+		}
+		return
+	}
+	if fallback := a.GetFallback(); fallback != nil {
+		fallback.Action(t)
+	}
+}
+
 // GetPriority get the priority of the Action
 func (a *AggSpillDiskAction) GetPriority() int64 {
 	return memory.DefSpillPriority
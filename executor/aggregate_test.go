@@ -1540,6 +1540,44 @@ func TestAggInDisk(t *testing.T) {
 	tk.MustQuery("select /*+ HASH_AGG() */ count(c) from t group by c1;").Check(testkit.Rows())
 }
 
+func TestParallelAggInDisk(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set tidb_hashagg_final_concurrency = 4;")
+	tk.MustExec("set tidb_hashagg_partial_concurrency = 4;")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int, b varchar(32))")
+	sql := "insert into t values (0, '0')"
+	for i := 1; i <= 200; i++ {
+		sql += fmt.Sprintf(",(%v, '%v')", i, i)
+	}
+	sql += ";"
+	tk.MustExec(sql)
+
+	query := "select /*+ HASH_AGG() */ count(*) c, sum(t1.a) s, avg(t2.a) av, max(t1.b) mx, min(t2.b) mn, bit_or(t1.a) bo, var_pop(t2.a) vp from t t1 join t t2 group by t1.a, t2.a"
+	expected := tk.MustQuery(fmt.Sprintf("select sum(c), sum(s), sum(av), max(mx), min(mn), sum(bo), sum(vp) from (%s) tt", query)).Rows()
+
+	tk.MustExec("set tidb_mem_quota_query = 4194304")
+	rows := tk.MustQuery("desc analyze " + query).Rows()
+	for _, row := range rows {
+		length := len(row)
+		line := fmt.Sprintf("%v", row)
+		disk := fmt.Sprintf("%v", row[length-1])
+		if strings.Contains(line, "HashAgg") {
+			require.False(t, strings.Contains(disk, "0 Bytes"))
+			require.True(t, strings.Contains(disk, "MB") ||
+				strings.Contains(disk, "KB") ||
+				strings.Contains(disk, "Bytes"))
+		}
+	}
+	tk.MustQuery(fmt.Sprintf("select sum(c), sum(s), sum(av), max(mx), min(mn), sum(bo), sum(vp) from (%s) tt", query)).Check(expected)
+
+	// The aggregate functions which don't support spilling still run in memory.
+	tk.MustQuery("select /*+ HASH_AGG() */ count(distinct t1.a) from t t1 join t t2 group by t1.a % 2").Sort().Check(testkit.Rows("100", "101"))
+}
+
 func TestRandomPanicAggConsume(t *testing.T) {
 	store, clean := testkit.CreateMockStore(t)
 	defer clean()
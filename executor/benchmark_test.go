@@ -1340,6 +1340,7 @@ func prepare4IndexInnerHashJoin(tc *indexJoinTestCase, outerDS *mockDataSource,
 		workerWg:      new(sync.WaitGroup),
 		joiner:        newJoiner(tc.ctx, 0, false, defaultValues, nil, leftTypes, rightTypes, nil),
 		isOuterJoin:   false,
+		concurrency:   tc.concurrency,
 		keyOff2IdxOff: keyOff2IdxOff,
 		lastColHelper: nil,
 	}
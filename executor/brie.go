@@ -15,6 +15,7 @@
 package executor
 
 import (
+	"bytes"
 	"context"
 	"net/url"
 	"strings"
@@ -42,6 +43,7 @@ import (
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/printer"
@@ -497,6 +499,33 @@ func (gs *tidbGlueSession) CreateTable(ctx context.Context, dbName model.CIStr,
 	return d.CreateTableWithInfo(gs.se, dbName, table, ddl.OnExistIgnore)
 }
 
+// CreateRoutine implements glue.Session
+func (gs *tidbGlueSession) CreateRoutine(ctx context.Context, dbName model.CIStr, routine *model.RoutineInfo) error {
+	return CreateRoutineWithInfo(ctx, gs.se, dbName, routine)
+}
+
+// CreateRoutineWithInfo creates the stored routine in the database by executing
+// the result of show create procedure or function. The sql_mode the routine was
+// created with is used, since the routine body is parsed with it.
+func CreateRoutineWithInfo(ctx context.Context, sctx sessionctx.Context, dbName model.CIStr, routine *model.RoutineInfo) error {
+	var buf bytes.Buffer
+	ConstructResultOfShowCreateRoutine(routine, &buf)
+
+	vars := sctx.GetSessionVars()
+	originDB := vars.CurrentDB
+	originSQLMode, _ := vars.GetSystemVar(variable.SQLModeVar)
+	vars.CurrentDB = dbName.O
+	defer func() {
+		vars.CurrentDB = originDB
+		terror.Log(vars.SetSystemVar(variable.SQLModeVar, originSQLMode))
+	}()
+	if err := vars.SetSystemVar(variable.SQLModeVar, routine.SQLMode); err != nil {
+		return errors.Trace(err)
+	}
+	_, err := sctx.(sqlexec.SQLExecutor).ExecuteInternal(ctx, buf.String())
+	return errors.Trace(err)
+}
+
 // Close implements glue.Session
 func (gs *tidbGlueSession) Close() {
 }
@@ -259,10 +259,14 @@ func (b *executorBuilder) build(p plannercore.Plan) Executor {
 		return b.buildMemTable(v)
 	case *plannercore.PhysicalTableDual:
 		return b.buildTableDual(v)
+	case *plannercore.PhysicalJSONTable:
+		return b.buildJSONTable(v)
 	case *plannercore.PhysicalApply:
 		return b.buildApply(v)
 	case *plannercore.PhysicalMaxOneRow:
 		return b.buildMaxOneRow(v)
+	case *plannercore.PhysicalExpand:
+		return b.buildExpand(v)
 	case *plannercore.Analyze:
 		return b.buildAnalyze(v)
 	case *plannercore.PhysicalTableReader:
@@ -850,6 +854,12 @@ func (b *executorBuilder) buildInsert(v *plannercore.Insert) Executor {
 		b.err = err
 		return nil
 	}
+	ivs.constraints, err = table.BuildWritableConstraints(b.ctx, v.Table.Meta())
+	if err != nil {
+		b.err = err
+		return nil
+	}
+	ivs.fkChecker = newForeignKeyChecker(b.ctx, b.is)
 
 	if v.IsReplace {
 		return b.buildReplace(ivs)
@@ -893,6 +903,12 @@ func (b *executorBuilder) buildLoadData(v *plannercore.LoadData) Executor {
 		b.err = err
 		return nil
 	}
+	insertVal.constraints, err = table.BuildWritableConstraints(b.ctx, tbl.Meta())
+	if err != nil {
+		b.err = err
+		return nil
+	}
+	insertVal.fkChecker = newForeignKeyChecker(b.ctx, b.is)
 	loadDataExec := &LoadDataExec{
 		baseExecutor: newBaseExecutor(b.ctx, nil, v.ID()),
 		IsLocal:      v.IsLocal,
@@ -1262,6 +1278,10 @@ func (b *executorBuilder) buildHashJoin(v *plannercore.PhysicalHashJoin) Executo
 		isOuterJoin:     v.JoinType.IsOuterJoin(),
 		useOuterToBuild: v.UseOuterToBuild,
 	}
+	// Stored functions are evaluated in the session, which can't be shared by the workers.
+	if expression.ContainsStoredFunction(v.OtherConditions) {
+		e.concurrency = 1
+	}
 	defaultValues := v.DefaultValues
 	lhsTypes, rhsTypes := retTypes(leftExec), retTypes(rightExec)
 	if v.InnerChildIdx == 1 {
@@ -1384,7 +1404,7 @@ func (b *executorBuilder) buildHashAgg(v *plannercore.PhysicalHashAgg) Executor
 		e.defaultVal = chunk.NewChunkWithCapacity(retTypes(e), 1)
 	}
 	for _, aggDesc := range v.AggFuncs {
-		if aggDesc.HasDistinct || len(aggDesc.OrderByItems) > 0 {
+		if aggDesc.HasDistinct || len(aggDesc.OrderByItems) > 0 || expression.ContainsStoredFunction(aggDesc.Args) {
 			e.isUnparallelExec = true
 		}
 	}
@@ -1491,6 +1511,11 @@ func (b *executorBuilder) buildProjection(v *plannercore.PhysicalProjection) Exe
 	if b.inUpdateStmt || b.inDeleteStmt || b.inInsertStmt || b.hasLock {
 		e.numWorkers = 0
 	}
+
+	// Stored functions are evaluated in the session, which can't be shared by the workers.
+	if expression.ContainsStoredFunction(v.Exprs) {
+		e.numWorkers = 0
+	}
 	return e
 }
 
@@ -1508,6 +1533,14 @@ func (b *executorBuilder) buildTableDual(v *plannercore.PhysicalTableDual) Execu
 	return e
 }
 
+func (b *executorBuilder) buildJSONTable(v *plannercore.PhysicalJSONTable) Executor {
+	return &JSONTableExec{
+		baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID()),
+		expr:         v.Expr,
+		root:         v.Root,
+	}
+}
+
 // `getSnapshotTS` returns for-update-ts if in insert/update/delete/lock statement otherwise the isolation read ts
 // Please notice that in RC isolation, the above two ts are the same
 func (b *executorBuilder) getSnapshotTS() (uint64, error) {
@@ -1688,6 +1721,7 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) Executo
 			strings.ToLower(infoschema.TableViews),
 			strings.ToLower(infoschema.TableTables),
 			strings.ToLower(infoschema.TableReferConst),
+			strings.ToLower(infoschema.TableCheckConstraints),
 			strings.ToLower(infoschema.TableSequences),
 			strings.ToLower(infoschema.TablePartitions),
 			strings.ToLower(infoschema.TableEngines),
@@ -1706,6 +1740,9 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) Executo
 			strings.ToLower(infoschema.TableTiDBHotRegions),
 			strings.ToLower(infoschema.TableSessionVar),
 			strings.ToLower(infoschema.TableConstraints),
+			strings.ToLower(infoschema.TableTriggers),
+			strings.ToLower(infoschema.TableRoutines),
+			strings.ToLower(infoschema.TableParameters),
 			strings.ToLower(infoschema.TableTiFlashReplica),
 			strings.ToLower(infoschema.TableTiDBServersInfo),
 			strings.ToLower(infoschema.TableTiKVStoreStatus),
@@ -1715,7 +1752,8 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) Executo
 			strings.ToLower(infoschema.TableClientErrorsSummaryByUser),
 			strings.ToLower(infoschema.TableClientErrorsSummaryByHost),
 			strings.ToLower(infoschema.TableAttributes),
-			strings.ToLower(infoschema.TablePlacementPolicies):
+			strings.ToLower(infoschema.TablePlacementPolicies),
+			strings.ToLower(infoschema.TableMemoryUsageOpsHistory):
 			return &MemTableReaderExec{
 				baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID()),
 				table:        v.Table,
@@ -1905,8 +1943,8 @@ func (b *executorBuilder) buildApply(v *plannercore.PhysicalApply) Executor {
 	}
 	executorCounterNestedLoopApplyExec.Inc()
 
-	// try parallel mode
-	if v.Concurrency > 1 {
+	// try parallel mode, stored functions are evaluated in the session, which can't be shared by the workers.
+	if v.Concurrency > 1 && !expression.ContainsStoredFunction(otherConditions) {
 		innerExecs := make([]Executor, 0, v.Concurrency)
 		innerFilters := make([]expression.CNFExprs, 0, v.Concurrency)
 		corCols := make([][]*expression.CorrelatedColumn, 0, v.Concurrency)
@@ -1960,6 +1998,36 @@ func (b *executorBuilder) buildMaxOneRow(v *plannercore.PhysicalMaxOneRow) Execu
 	return e
 }
 
+func (b *executorBuilder) buildExpand(v *plannercore.PhysicalExpand) Executor {
+	childExec := b.build(v.Children()[0])
+	if b.err != nil {
+		return nil
+	}
+	e := &ExpandExec{
+		baseExecutor:    newBaseExecutor(b.ctx, v.Schema(), v.ID(), childExec),
+		groupingOffsets: make([]int, 0, len(v.GroupingCols)),
+		inSets:          make([][]bool, 0, len(v.GroupingSets)),
+		groupingIDs:     make([]uint64, 0, len(v.GroupingSets)),
+	}
+	childLen := v.Schema().Len() - len(v.GroupingCols) - 1
+	e.childOffsets = make([]int, 0, childLen)
+	for _, col := range v.Schema().Columns[:childLen] {
+		e.childOffsets = append(e.childOffsets, col.Index)
+	}
+	for _, col := range v.GroupingCols {
+		e.groupingOffsets = append(e.groupingOffsets, col.Index)
+	}
+	for _, set := range v.GroupingSets {
+		in := make([]bool, len(v.GroupingCols))
+		for _, offset := range set {
+			in[offset] = true
+		}
+		e.inSets = append(e.inSets, in)
+		e.groupingIDs = append(e.groupingIDs, plannercore.GroupingIDOfSet(len(v.GroupingCols), set))
+	}
+	return e
+}
+
 func (b *executorBuilder) buildUnionAll(v *plannercore.PhysicalUnionAll) Executor {
 	childExecs := make([]Executor, len(v.Children()))
 	for i, child := range v.Children() {
@@ -1972,9 +2040,87 @@ func (b *executorBuilder) buildUnionAll(v *plannercore.PhysicalUnionAll) Executo
 		baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID(), childExecs...),
 		concurrency:  b.ctx.GetSessionVars().UnionConcurrency(),
 	}
+	// Stored functions are evaluated in the session, which can't be shared by the workers.
+	for _, child := range v.Children() {
+		if containsStoredFunction(child) {
+			e.concurrency = 1
+			break
+		}
+	}
 	return e
 }
 
+// containsStoredFunction checks whether the plan calls any stored function. The
+// stored functions are never pushed down, so only the root operators are checked.
+func containsStoredFunction(p plannercore.PhysicalPlan) bool {
+	var exprs []expression.Expression
+	switch x := p.(type) {
+	case *plannercore.PhysicalSelection:
+		exprs = x.Conditions
+	case *plannercore.PhysicalProjection:
+		exprs = x.Exprs
+	case *plannercore.PhysicalUnionScan:
+		exprs = x.Conditions
+	case *plannercore.PhysicalHashJoin:
+		exprs = joinExprs(x.LeftConditions, x.RightConditions, x.OtherConditions)
+	case *plannercore.PhysicalMergeJoin:
+		exprs = joinExprs(x.LeftConditions, x.RightConditions, x.OtherConditions)
+	case *plannercore.PhysicalIndexJoin:
+		exprs = joinExprs(x.LeftConditions, x.RightConditions, x.OtherConditions)
+	case *plannercore.PhysicalIndexHashJoin:
+		exprs = joinExprs(x.LeftConditions, x.RightConditions, x.OtherConditions)
+	case *plannercore.PhysicalIndexMergeJoin:
+		exprs = joinExprs(x.LeftConditions, x.RightConditions, x.OtherConditions)
+	case *plannercore.PhysicalApply:
+		exprs = joinExprs(x.LeftConditions, x.RightConditions, x.OtherConditions)
+	case *plannercore.PhysicalHashAgg:
+		exprs = aggExprs(x.AggFuncs, x.GroupByItems)
+	case *plannercore.PhysicalStreamAgg:
+		exprs = aggExprs(x.AggFuncs, x.GroupByItems)
+	case *plannercore.PhysicalSort:
+		exprs = byItemExprs(x.ByItems)
+	case *plannercore.PhysicalTopN:
+		exprs = byItemExprs(x.ByItems)
+	case *plannercore.PhysicalWindow:
+		for _, desc := range x.WindowFuncDescs {
+			exprs = append(exprs, desc.Args...)
+		}
+	}
+	if expression.ContainsStoredFunction(exprs) {
+		return true
+	}
+	for _, child := range p.Children() {
+		if containsStoredFunction(child) {
+			return true
+		}
+	}
+	return false
+}
+
+func joinExprs(conditions ...[]expression.Expression) []expression.Expression {
+	var exprs []expression.Expression
+	for _, conds := range conditions {
+		exprs = append(exprs, conds...)
+	}
+	return exprs
+}
+
+func aggExprs(aggFuncs []*aggregation.AggFuncDesc, groupByItems []expression.Expression) []expression.Expression {
+	exprs := append([]expression.Expression(nil), groupByItems...)
+	for _, aggFunc := range aggFuncs {
+		exprs = append(exprs, aggFunc.Args...)
+	}
+	return exprs
+}
+
+func byItemExprs(byItems []*plannerutil.ByItems) []expression.Expression {
+	exprs := make([]expression.Expression, 0, len(byItems))
+	for _, item := range byItems {
+		exprs = append(exprs, item.Expr)
+	}
+	return exprs
+}
+
 func buildHandleColsForSplit(sc *stmtctx.StatementContext, tbInfo *model.TableInfo) plannercore.HandleCols {
 	if tbInfo.IsCommonHandle {
 		primaryIdx := tables.FindPrimaryIndex(tbInfo)
@@ -2036,6 +2182,7 @@ func (b *executorBuilder) buildSplitRegion(v *plannercore.SplitRegion) Executor
 func (b *executorBuilder) buildUpdate(v *plannercore.Update) Executor {
 	b.inUpdateStmt = true
 	tblID2table := make(map[int64]table.Table, len(v.TblColPosInfos))
+	tblID2Constraints := make(map[int64][]*table.Constraint, len(v.TblColPosInfos))
 	multiUpdateOnSameTable := make(map[int64]bool)
 	for _, info := range v.TblColPosInfos {
 		tbl, _ := b.is.TableByID(info.TblID)
@@ -2043,6 +2190,10 @@ func (b *executorBuilder) buildUpdate(v *plannercore.Update) Executor {
 			multiUpdateOnSameTable[info.TblID] = true
 		}
 		tblID2table[info.TblID] = tbl
+		tblID2Constraints[info.TblID], b.err = table.BuildWritableConstraints(b.ctx, tbl.Meta())
+		if b.err != nil {
+			return nil
+		}
 		if len(v.PartitionedTable) > 0 {
 			// The v.PartitionedTable collects the partitioned table.
 			// Replace the original table with the partitioned table to support partition selection.
@@ -2082,6 +2233,8 @@ func (b *executorBuilder) buildUpdate(v *plannercore.Update) Executor {
 		virtualAssignmentsOffset:  v.VirtualAssignmentsOffset,
 		multiUpdateOnSameTable:    multiUpdateOnSameTable,
 		tblID2table:               tblID2table,
+		tblID2Constraints:         tblID2Constraints,
+		fkChecker:                 newForeignKeyChecker(b.ctx, b.is),
 		tblColPosInfos:            v.TblColPosInfos,
 		assignFlag:                assignFlag,
 	}
@@ -2127,6 +2280,7 @@ func (b *executorBuilder) buildDelete(v *plannercore.Delete) Executor {
 		tblID2Table:    tblID2table,
 		IsMultiTable:   v.IsMultiTable,
 		tblColPosInfos: v.TblColPosInfos,
+		fkChecker:      newForeignKeyChecker(b.ctx, b.is),
 	}
 	return deleteExec
 }
@@ -2930,11 +3084,16 @@ func (b *executorBuilder) buildIndexLookUpJoin(v *plannercore.PhysicalIndexJoin)
 		},
 		workerWg:      new(sync.WaitGroup),
 		isOuterJoin:   v.JoinType.IsOuterJoin(),
+		concurrency:   b.ctx.GetSessionVars().IndexLookupJoinConcurrency(),
 		indexRanges:   v.Ranges,
 		keyOff2IdxOff: v.KeyOff2IdxOff,
 		lastColHelper: v.CompareFilters,
 		finished:      &atomic.Value{},
 	}
+	// Stored functions are evaluated in the session, which can't be shared by the workers.
+	if expression.ContainsStoredFunction(v.OtherConditions) {
+		e.concurrency = 1
+	}
 	childrenUsedSchema := markChildrenUsedCols(v.Schema(), v.Children()[0].Schema(), v.Children()[1].Schema())
 	e.joiner = newJoiner(b.ctx, v.JoinType, v.InnerChildIdx == 0, defaultValues, v.OtherConditions, leftTypes, rightTypes, childrenUsedSchema)
 	outerKeyCols := make([]int, len(v.OuterJoinKeys))
@@ -3058,7 +3217,12 @@ func (b *executorBuilder) buildIndexLookUpMergeJoin(v *plannercore.PhysicalIndex
 		lastColHelper: v.CompareFilters,
 	}
 	childrenUsedSchema := markChildrenUsedCols(v.Schema(), v.Children()[0].Schema(), v.Children()[1].Schema())
-	joiners := make([]joiner, e.ctx.GetSessionVars().IndexLookupJoinConcurrency())
+	concurrency := e.ctx.GetSessionVars().IndexLookupJoinConcurrency()
+	// Stored functions are evaluated in the session, which can't be shared by the workers.
+	if expression.ContainsStoredFunction(v.OtherConditions) {
+		concurrency = 1
+	}
+	joiners := make([]joiner, concurrency)
 	for i := 0; i < len(joiners); i++ {
 		joiners[i] = newJoiner(b.ctx, v.JoinType, v.InnerChildIdx == 0, defaultValues, v.OtherConditions, leftTypes, rightTypes, childrenUsedSchema)
 	}
@@ -3072,9 +3236,8 @@ func (b *executorBuilder) buildIndexNestedLoopHashJoin(v *plannercore.PhysicalIn
 		IndexLookUpJoin: *e,
 		keepOuterOrder:  v.KeepOuterOrder,
 	}
-	concurrency := e.ctx.GetSessionVars().IndexLookupJoinConcurrency()
-	idxHash.joiners = make([]joiner, concurrency)
-	for i := 0; i < concurrency; i++ {
+	idxHash.joiners = make([]joiner, e.concurrency)
+	for i := 0; i < e.concurrency; i++ {
 		idxHash.joiners[i] = e.joiner.Clone()
 	}
 	return idxHash
@@ -3734,6 +3897,7 @@ func buildNoRangeIndexMergeReader(b *executorBuilder, v *plannercore.PhysicalInd
 		isCorColInPartialFilters: isCorColInPartialFilters,
 		isCorColInTableFilter:    isCorColInTableFilter,
 		isCorColInPartialAccess:  isCorColInPartialAccess,
+		isIntersection:           v.IsIntersectionType,
 	}
 	collectTable := false
 	e.tableRequest.CollectRangeCounts = &collectTable
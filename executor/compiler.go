@@ -379,8 +379,12 @@ func GetStmtLabel(stmtNode ast.StmtNode) string {
 		return "Insert"
 	case *ast.LoadDataStmt:
 		return "LoadData"
+	case *ast.ReleaseSavepointStmt:
+		return "ReleaseSavepoint"
 	case *ast.RollbackStmt:
 		return "RollBack"
+	case *ast.SavepointStmt:
+		return "Savepoint"
 	case *ast.SelectStmt:
 		return "Select"
 	case *ast.SetStmt, *ast.SetPwdStmt:
@@ -87,6 +87,8 @@ type lookupTableTask struct {
 
 	// partitionTable indicates whether this task belongs to a partition table and which partition table it is.
 	partitionTable table.PhysicalTable
+	// partialWorkerID is the ID of the partial worker of IndexMergeReaderExecutor which fetches the handles of this task.
+	partialWorkerID int
 
 	// memUsage records the memory usage of this task calculated by table worker.
 	// memTracker is used to release memUsage after task is done and unused.
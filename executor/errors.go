@@ -53,6 +53,12 @@ var (
 	ErrIllegalPrivilegeLevel         = dbterror.ClassExecutor.NewStd(mysql.ErrIllegalPrivilegeLevel)
 	ErrInvalidSplitRegionRanges      = dbterror.ClassExecutor.NewStd(mysql.ErrInvalidSplitRegionRanges)
 	ErrViewInvalid                   = dbterror.ClassExecutor.NewStd(mysql.ErrViewInvalid)
+	ErrRowIsReferenced               = dbterror.ClassExecutor.NewStd(mysql.ErrRowIsReferenced2)
+	ErrNoReferencedRow               = dbterror.ClassExecutor.NewStd(mysql.ErrNoReferencedRow2)
+	ErrForeignKeyCascadeDepth        = dbterror.ClassExecutor.NewStd(mysql.ErrForeignKeyCascadeDepthExceeded)
+	ErrMissingJSONTableValue         = dbterror.ClassExecutor.NewStd(mysql.ErrMissingJSONTableValue)
+	ErrWrongJSONTableValue           = dbterror.ClassExecutor.NewStd(mysql.ErrWrongJSONTableValue)
+	ErrSavepointNotExists            = dbterror.ClassExecutor.NewStd(mysql.ErrSpDoesNotExist)
 
 	ErrBRIEBackupFailed      = dbterror.ClassExecutor.NewStd(mysql.ErrBRIEBackupFailed)
 	ErrBRIERestoreFailed     = dbterror.ClassExecutor.NewStd(mysql.ErrBRIERestoreFailed)
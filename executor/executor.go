@@ -1747,9 +1747,10 @@ func (e *UnionExec) Close() error {
 func ResetContextOfStmt(ctx sessionctx.Context, s ast.StmtNode) (err error) {
 	vars := ctx.GetSessionVars()
 	var sc *stmtctx.StatementContext
-	if vars.TxnCtx.CouldRetry {
+	if vars.TxnCtx.CouldRetry || vars.NestedStmtLevel > 0 {
 		// Must construct new statement context object, the retry history need context for every statement.
 		// TODO: Maybe one day we can get rid of transaction retry, then this logic can be deleted.
+		// The statement contexts of the outer statements are still in use by the nested statements.
 		sc = &stmtctx.StatementContext{}
 	} else {
 		sc = vars.InitStatementContext()
@@ -20,7 +20,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pingcap/tidb/errno"
 	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
 )
 
 func TestInvalidReadTemporaryTable(t *testing.T) {
@@ -250,3 +252,115 @@ func TestInvalidReadCacheTable(t *testing.T) {
 
 	}
 }
+
+func TestSavepoint(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v int)")
+
+	for _, mode := range []string{"optimistic", "pessimistic"} {
+		tk.MustExec("delete from t")
+		tk.MustExec("begin " + mode)
+		tk.MustExec("insert into t values (1, 1)")
+		tk.MustExec("savepoint s1")
+		tk.MustExec("insert into t values (2, 2)")
+		tk.MustExec("update t set v = 10 where id = 1")
+		tk.MustExec("savepoint s2")
+		tk.MustExec("insert into t values (3, 3)")
+		require.Equal(t, []string{"s1", "s2"}, tk.Session().TxnInfo().Savepoints)
+		txn, err := tk.Session().Txn(false)
+		require.NoError(t, err)
+		require.Contains(t, fmt.Sprintf("%#v", txn), "savepoints=[s1 s2]")
+		tk.MustQuery("select * from t").Check(testkit.Rows("1 10", "2 2", "3 3"))
+		tk.MustExec("rollback to savepoint s2")
+		tk.MustQuery("select * from t").Check(testkit.Rows("1 10", "2 2"))
+		// The savepoint is kept after rolling back to it.
+		tk.MustExec("insert into t values (4, 4)")
+		tk.MustExec("rollback to s2")
+		tk.MustQuery("select * from t").Check(testkit.Rows("1 10", "2 2"))
+		tk.MustExec("rollback to S1")
+		tk.MustQuery("select * from t").Check(testkit.Rows("1 1"))
+		require.Equal(t, []string{"s1"}, tk.Session().TxnInfo().Savepoints)
+		tk.MustGetErrCode("rollback to s2", errno.ErrSpDoesNotExist)
+		tk.MustExec("insert into t values (5, 5)")
+		tk.MustExec("commit")
+		tk.MustQuery("select * from t").Check(testkit.Rows("1 1", "5 5"))
+	}
+
+	// The old values of the keys modified both before and after the savepoint are restored.
+	tk.MustExec("begin")
+	tk.MustExec("insert into t values (6, 6)")
+	tk.MustExec("update t set v = 2 where id = 1")
+	tk.MustExec("savepoint s1")
+	tk.MustExec("delete from t where id = 6")
+	tk.MustExec("update t set v = 3 where id = 1")
+	tk.MustExec("update t set v = 4 where id = 1")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 4", "5 5"))
+	tk.MustExec("rollback to s1")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 2", "5 5", "6 6"))
+	tk.MustExec("rollback")
+
+	// Release a savepoint and the later ones, the modifications are kept.
+	tk.MustExec("delete from t")
+	tk.MustExec("begin")
+	tk.MustExec("savepoint s1")
+	tk.MustExec("insert into t values (1, 1)")
+	tk.MustExec("savepoint s2")
+	tk.MustExec("insert into t values (2, 2)")
+	tk.MustExec("savepoint s3")
+	tk.MustExec("release savepoint s2")
+	require.Equal(t, []string{"s1"}, tk.Session().TxnInfo().Savepoints)
+	tk.MustGetErrCode("rollback to s3", errno.ErrSpDoesNotExist)
+	tk.MustGetErrCode("release savepoint s2", errno.ErrSpDoesNotExist)
+	tk.MustExec("commit")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 1", "2 2"))
+
+	// A savepoint with the same name replaces the old one.
+	tk.MustExec("begin")
+	tk.MustExec("savepoint s1")
+	tk.MustExec("insert into t values (3, 3)")
+	tk.MustExec("savepoint s2")
+	tk.MustExec("savepoint s1")
+	tk.MustExec("insert into t values (4, 4)")
+	require.Equal(t, []string{"s2", "s1"}, tk.Session().TxnInfo().Savepoints)
+	tk.MustExec("rollback to s1")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 1", "2 2", "3 3"))
+	tk.MustExec("rollback to s2")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 1", "2 2", "3 3"))
+	require.Equal(t, []string{"s2"}, tk.Session().TxnInfo().Savepoints)
+	tk.MustExec("rollback")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 1", "2 2"))
+
+	// The transaction is committed when all the modifications are after a savepoint.
+	tk.MustExec("begin")
+	tk.MustExec("savepoint s1")
+	tk.MustExec("delete from t where id = 2")
+	tk.MustExec("commit")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 1"))
+
+	// The savepoints are discarded when the transaction ends.
+	tk.MustExec("savepoint s1")
+	tk.MustGetErrCode("rollback to s1", errno.ErrSpDoesNotExist)
+	tk.MustGetErrCode("release savepoint s1", errno.ErrSpDoesNotExist)
+	tk.MustExec("begin")
+	tk.MustExec("savepoint s1")
+	tk.MustExec("commit")
+	tk.MustGetErrCode("rollback to s1", errno.ErrSpDoesNotExist)
+
+	// The pessimistic locks acquired after the savepoint are retained until the transaction ends.
+	tk2 := testkit.NewTestKit(t, store)
+	tk2.MustExec("use test")
+	tk.MustExec("begin pessimistic")
+	tk.MustExec("savepoint s1")
+	tk.MustExec("update t set v = 10 where id = 1")
+	tk.MustExec("rollback to s1")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 1"))
+	tk2.MustExec("begin pessimistic")
+	tk2.MustGetErrCode("select * from t where id = 1 for update nowait", errno.ErrLockAcquireFailAndNoWaitSet)
+	tk.MustExec("commit")
+	tk2.MustQuery("select * from t where id = 1 for update nowait").Check(testkit.Rows("1 1"))
+	tk2.MustExec("commit")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 1"))
+}
@@ -0,0 +1,96 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+var _ Executor = &ExpandExec{}
+
+// ExpandExec outputs each child row once for every grouping set of GROUP BY ... WITH ROLLUP. The output row
+// consists of the child row, the grouping columns in which the ones not in the set are NULL, and the grouping id.
+type ExpandExec struct {
+	baseExecutor
+
+	// childOffsets are the offsets of the passed through columns in the child schema.
+	childOffsets []int
+	// groupingOffsets are the offsets of the grouping columns in the child schema.
+	groupingOffsets []int
+	// inSets[i][j] indicates whether the j-th grouping column is in the i-th grouping set.
+	inSets      [][]bool
+	groupingIDs []uint64
+
+	childResult *chunk.Chunk
+	setIdx      int
+	rowIdx      int
+}
+
+// Open implements the Executor Open interface.
+func (e *ExpandExec) Open(ctx context.Context) error {
+	if err := e.baseExecutor.Open(ctx); err != nil {
+		return err
+	}
+	e.childResult = newFirstChunk(e.children[0])
+	// Fetch the first child chunk in the first call of Next.
+	e.setIdx = len(e.groupingIDs)
+	e.rowIdx = 0
+	return nil
+}
+
+// Next implements the Executor Next interface.
+func (e *ExpandExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	for !req.IsFull() {
+		if e.setIdx >= len(e.groupingIDs) {
+			if err := Next(ctx, e.children[0], e.childResult); err != nil {
+				return err
+			}
+			if e.childResult.NumRows() == 0 {
+				return nil
+			}
+			e.setIdx, e.rowIdx = 0, 0
+		}
+		for ; e.rowIdx < e.childResult.NumRows() && !req.IsFull(); e.rowIdx++ {
+			e.appendRow(req, e.childResult.GetRow(e.rowIdx))
+		}
+		if e.rowIdx >= e.childResult.NumRows() {
+			e.setIdx++
+			e.rowIdx = 0
+		}
+	}
+	return nil
+}
+
+func (e *ExpandExec) appendRow(req *chunk.Chunk, row chunk.Row) {
+	req.AppendPartialRowByColIdxs(0, row, e.childOffsets)
+	colOff := len(e.childOffsets)
+	for j, in := range e.inSets[e.setIdx] {
+		if in {
+			req.AppendPartialRowByColIdxs(colOff+j, row, e.groupingOffsets[j:j+1])
+		} else {
+			req.AppendNull(colOff + j)
+		}
+	}
+	req.AppendUint64(colOff+len(e.groupingOffsets), e.groupingIDs[e.setIdx])
+}
+
+// Close implements the Executor Close interface.
+func (e *ExpandExec) Close() error {
+	e.childResult = nil
+	return e.baseExecutor.Close()
+}
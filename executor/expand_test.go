@@ -0,0 +1,83 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/testkit"
+)
+
+func TestGroupByWithRollup(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, c int)")
+	tk.MustExec("insert into t values (1, 1, 10), (1, 2, 20), (2, 1, 30), (2, null, 40)")
+
+	tk.MustQuery("select a, b, sum(c), grouping(a), grouping(b), grouping(a, b) from t group by a, b with rollup").Sort().Check(testkit.Rows(
+		"1 1 10 0 0 0",
+		"1 2 20 0 0 0",
+		"1 <nil> 30 0 1 1",
+		"2 1 30 0 0 0",
+		"2 <nil> 40 0 0 0",
+		"2 <nil> 70 0 1 1",
+		"<nil> <nil> 100 1 1 3"))
+	// The aggregate functions and the expressions on the grouping columns.
+	tk.MustQuery("select a, sum(a), a + 1, count(*) from t group by a with rollup").Sort().Check(testkit.Rows(
+		"1 2 2 2",
+		"2 4 3 2",
+		"<nil> 6 <nil> 4"))
+	tk.MustQuery("select a, count(*) from t group by a with rollup having grouping(a) = 1").Check(testkit.Rows("<nil> 4"))
+	tk.MustQuery("select a, count(*) from t group by a with rollup having a > 1").Check(testkit.Rows("2 2"))
+	tk.MustQuery("select a, count(*) from t group by a with rollup order by a desc").Check(testkit.Rows("2 2", "1 2", "<nil> 4"))
+	tk.MustQuery("select a, count(*) from t group by a with rollup order by a limit 1").Check(testkit.Rows("<nil> 4"))
+	tk.MustQuery("select b, grouping(b) g from t group by b with rollup order by g, b").Check(testkit.Rows("<nil> 0", "1 0", "2 0", "<nil> 1"))
+	tk.MustQuery("select a, a from t group by a, a with rollup").Sort().Check(testkit.Rows("1 1", "1 1", "2 2", "2 2", "<nil> <nil>"))
+	tk.MustQuery("select /*+ stream_agg() */ a, count(*) from t group by a with rollup").Sort().Check(testkit.Rows("1 2", "2 2", "<nil> 4"))
+	tk.MustQuery("select /*+ hash_agg() */ a, count(*) from t group by a with rollup").Sort().Check(testkit.Rows("1 2", "2 2", "<nil> 4"))
+	tk.MustQuery("select count(*) from t where a > 2 group by a with rollup").Check(testkit.Rows())
+	tk.MustQuery("select * from (select a, sum(c) s from t group by a with rollup) dt where dt.a is null").Check(testkit.Rows("<nil> 100"))
+
+	// The GROUP BY items which are not columns.
+	tk.MustQuery("select a + 1, grouping(a + 1), count(*) from t group by a + 1 with rollup").Sort().Check(testkit.Rows(
+		"2 0 2",
+		"3 0 2",
+		"<nil> 1 4"))
+	tk.MustQuery("select a, b % 2, sum(c) from t group by a, b % 2 with rollup").Sort().Check(testkit.Rows(
+		"1 0 20",
+		"1 1 10",
+		"1 <nil> 30",
+		"2 1 30",
+		"2 <nil> 40",
+		"2 <nil> 70",
+		"<nil> <nil> 100"))
+	tk.MustQuery("select sum(c) from t group by abs(a - 2) with rollup").Sort().Check(testkit.Rows("100", "30", "70"))
+
+	tk.MustGetErrCode("select grouping(a) from t group by a", errno.ErrInvalidGroupFuncUse)
+	tk.MustGetErrCode("select grouping(a) from t group by b", errno.ErrFieldInGroupingNotGroupBy)
+	tk.MustGetErrCode("select b, grouping(b, a) from t group by b", errno.ErrFieldInGroupingNotGroupBy)
+	tk.MustGetErrCode("select grouping(a + 1) from t group by a + 1", errno.ErrInvalidGroupFuncUse)
+	tk.MustGetErrCode("select grouping(a) from t", errno.ErrInvalidGroupFuncUse)
+	tk.MustGetErrCode("select a from t where grouping(a) = 0 group by a with rollup", errno.ErrInvalidGroupFuncUse)
+	tk.MustExec("set @@sql_mode = ''")
+	tk.MustGetErrCode("select a, grouping(b) from t group by a with rollup", errno.ErrFieldInGroupingNotGroupBy)
+	tk.MustGetErrCode("select grouping(a + 2) from t group by a + 1 with rollup", errno.ErrFieldInGroupingNotGroupBy)
+	// The expressions on the GROUP BY expressions get NULL in the super-aggregate rows too.
+	tk.MustQuery("select (a + 1) * 2, count(*) from t group by a + 1 with rollup").Sort().Check(testkit.Rows("4 2", "6 2", "<nil> 4"))
+}
@@ -0,0 +1,611 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/table/tables"
+	"github.com/pingcap/tidb/tablecodec"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/codec"
+	"github.com/pingcap/tidb/util/collate"
+)
+
+// maxForeignKeyCascadeDepth is the max depth of the cascading referential actions, the same as MySQL.
+const maxForeignKeyCascadeDepth = 15
+
+// foreignKeyChecker checks the foreign key constraints when rows are written,
+// and performs the referential actions on the referring rows when the referenced rows are deleted or updated.
+// It's only built when `foreign_key_checks` is enabled.
+type foreignKeyChecker struct {
+	sctx sessionctx.Context
+	is   infoschema.InfoSchema
+
+	// depth is the depth of the current cascading referential action.
+	depth int
+	// referredBy caches the foreign keys referring to the table, the key is the referenced table ID.
+	referredBy map[int64][]*referringFK
+	// genExprs caches the expressions of the public generated columns of the tables, the key is the table ID.
+	genExprs map[int64][]expression.Expression
+}
+
+// referringFK is a foreign key of the child table referring to the parent table.
+type referringFK struct {
+	dbName  model.CIStr
+	child   table.Table
+	fk      *model.FKInfo
+	cols    []*table.Column
+	refCols []*table.Column
+}
+
+func newForeignKeyChecker(sctx sessionctx.Context, is infoschema.InfoSchema) *foreignKeyChecker {
+	if !sctx.GetSessionVars().ForeignKeyChecks {
+		return nil
+	}
+	return &foreignKeyChecker{
+		sctx:       sctx,
+		is:         is,
+		referredBy: make(map[int64][]*referringFK),
+		genExprs:   make(map[int64][]expression.Expression),
+	}
+}
+
+// checkRowReferTo checks the rows referenced by the row of t exist, and locks the referenced rows
+// so that they can't be deleted or changed by other transactions before the transaction commits.
+// If modified is not nil, only the foreign keys containing the modified columns are checked.
+func (fc *foreignKeyChecker) checkRowReferTo(ctx context.Context, t table.Table, row []types.Datum, modified []bool) error {
+	tblInfo := t.Meta()
+	if len(tblInfo.ForeignKeys) == 0 {
+		return nil
+	}
+	dbInfo, ok := fc.is.SchemaByTable(tblInfo)
+	if !ok {
+		return nil
+	}
+	for _, fk := range tblInfo.ForeignKeys {
+		if fk.State != model.StatePublic {
+			continue
+		}
+		cols, ok := findFKColumns(t, fk.Cols)
+		if !ok || (modified != nil && !isAnyColumnModified(cols, modified)) {
+			continue
+		}
+		vals, hasNull := getColumnValues(row, cols)
+		if hasNull {
+			continue
+		}
+		found, err := fc.checkReferencedRowExists(ctx, dbInfo.Name, t, row, fk, vals)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return ErrNoReferencedRow.GenWithStackByArgs(foreignKeyDescription(dbInfo.Name, tblInfo, fk))
+		}
+	}
+	return nil
+}
+
+func (fc *foreignKeyChecker) checkReferencedRowExists(ctx context.Context, dbName model.CIStr, t table.Table, row []types.Datum,
+	fk *model.FKInfo, vals []types.Datum) (bool, error) {
+	parent, err := fc.is.TableByName(fk.GetRefSchema(dbName), fk.RefTable)
+	if err != nil {
+		return false, nil
+	}
+	refCols, ok := findFKColumns(parent, fk.RefCols)
+	if !ok {
+		return false, nil
+	}
+	sc := fc.sctx.GetSessionVars().StmtCtx
+	refVals := make([]types.Datum, 0, len(vals))
+	for i, val := range vals {
+		v, err := val.ConvertTo(sc, &refCols[i].FieldType)
+		if err != nil {
+			return false, err
+		}
+		refVals = append(refVals, v)
+	}
+	// The row referring to itself is inserted or updated.
+	if parent.Meta().ID == t.Meta().ID {
+		selfVals, _ := getColumnValues(row, refCols)
+		equal, err := equalColumnValues(sc, refCols, selfVals, refVals)
+		if err != nil || equal {
+			return equal, err
+		}
+	}
+	for _, tbl := range getPhysicalTables(parent) {
+		handles, err := fc.findRows(ctx, tbl, refCols, refVals, 1)
+		if err != nil {
+			return false, err
+		}
+		if len(handles) > 0 {
+			lockWaitTime := fc.sctx.GetSessionVars().LockWaitTimeout
+			err = LockKeys(ctx, fc.sctx, lockWaitTime, tablecodec.EncodeRecordKey(tbl.RecordPrefix(), handles[0]))
+			return err == nil, err
+		}
+	}
+	return false, nil
+}
+
+// onDeleteRow performs the ON DELETE referential actions on the rows referring to the row of t which is being deleted.
+func (fc *foreignKeyChecker) onDeleteRow(ctx context.Context, t table.Table, h kv.Handle, row []types.Datum) error {
+	return fc.onRowChanged(ctx, t, h, row, nil)
+}
+
+// onUpdateRow performs the ON UPDATE referential actions on the rows referring to the row of t which is being updated.
+func (fc *foreignKeyChecker) onUpdateRow(ctx context.Context, t table.Table, h kv.Handle, oldRow, newRow []types.Datum) error {
+	return fc.onRowChanged(ctx, t, h, oldRow, newRow)
+}
+
+func (fc *foreignKeyChecker) onRowChanged(ctx context.Context, t table.Table, h kv.Handle, oldRow, newRow []types.Datum) error {
+	fks := fc.getReferringFKs(t)
+	if len(fks) == 0 {
+		return nil
+	}
+	if fc.depth > 0 {
+		return fc.handleReferringRows(ctx, fks, t, h, oldRow, newRow)
+	}
+	// Use the staging buffer so that the cascading changes are discarded when the referential actions fail,
+	// e.g. the error is turned into a warning by `UPDATE IGNORE`.
+	txn, err := fc.sctx.Txn(true)
+	if err != nil {
+		return err
+	}
+	memBuffer := txn.GetMemBuffer()
+	sh := memBuffer.Staging()
+	defer memBuffer.Cleanup(sh)
+	if err = fc.handleReferringRows(ctx, fks, t, h, oldRow, newRow); err != nil {
+		return err
+	}
+	memBuffer.Release(sh)
+	return nil
+}
+
+func (fc *foreignKeyChecker) handleReferringRows(ctx context.Context, fks []*referringFK, t table.Table, h kv.Handle,
+	oldRow, newRow []types.Datum) error {
+	sc := fc.sctx.GetSessionVars().StmtCtx
+	for _, rfk := range fks {
+		oldVals, hasNull := getColumnValues(oldRow, rfk.refCols)
+		if hasNull {
+			continue
+		}
+		action := ast.ReferOptionType(rfk.fk.OnDelete)
+		var newVals []types.Datum
+		if newRow != nil {
+			newVals, _ = getColumnValues(newRow, rfk.refCols)
+			// Compare in binary collation, so that the referring rows are updated when the case of the value is changed.
+			equal, err := equalColumnValues(sc, nil, oldVals, newVals)
+			if err != nil {
+				return err
+			}
+			if equal {
+				continue
+			}
+			action = ast.ReferOptionType(rfk.fk.OnUpdate)
+		}
+		vals := make([]types.Datum, 0, len(oldVals))
+		for i, val := range oldVals {
+			v, err := val.ConvertTo(sc, &rfk.cols[i].FieldType)
+			if err != nil {
+				return err
+			}
+			vals = append(vals, v)
+		}
+		if err := fc.handleReferringRowsByFK(ctx, rfk, action, t, h, vals, newVals); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (fc *foreignKeyChecker) handleReferringRowsByFK(ctx context.Context, rfk *referringFK, action ast.ReferOptionType,
+	parent table.Table, parentHandle kv.Handle, vals, newVals []types.Datum) error {
+	limit := 0
+	if action != ast.ReferOptionCascade && action != ast.ReferOptionSetNull {
+		// RESTRICT, NO ACTION and SET DEFAULT(which is rejected by InnoDB) only need to know whether there is any referring row.
+		limit = 2
+	}
+	txn, err := fc.sctx.Txn(true)
+	if err != nil {
+		return err
+	}
+	for _, tbl := range getPhysicalTables(rfk.child) {
+		handles, err := fc.findRows(ctx, tbl, rfk.cols, vals, limit)
+		if err != nil {
+			return err
+		}
+		for _, h := range handles {
+			// Skip the row referring to itself.
+			if tbl.Meta().ID == parent.Meta().ID && h.Equal(parentHandle) {
+				continue
+			}
+			if limit > 0 {
+				return ErrRowIsReferenced.GenWithStackByArgs(foreignKeyDescription(rfk.dbName, rfk.child.Meta(), rfk.fk))
+			}
+			if fc.depth >= maxForeignKeyCascadeDepth {
+				return ErrForeignKeyCascadeDepth.GenWithStackByArgs(maxForeignKeyCascadeDepth)
+			}
+			genExprs, err := fc.getGeneratedExprs(rfk.child)
+			if err != nil {
+				return err
+			}
+			row, err := getOldRow(ctx, fc.sctx, txn, tbl, h, genExprs)
+			if err != nil {
+				return err
+			}
+			fc.depth++
+			if newVals == nil && action == ast.ReferOptionCascade {
+				err = fc.deleteReferringRow(ctx, rfk.child, h, row)
+			} else {
+				err = fc.updateReferringRow(ctx, rfk, h, row, newVals, action)
+			}
+			fc.depth--
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (fc *foreignKeyChecker) deleteReferringRow(ctx context.Context, t table.Table, h kv.Handle, row []types.Datum) error {
+	if err := fc.onDeleteRow(ctx, t, h, row); err != nil {
+		return err
+	}
+	return t.RemoveRecord(fc.sctx, h, row)
+}
+
+func (fc *foreignKeyChecker) updateReferringRow(ctx context.Context, rfk *referringFK, h kv.Handle, oldRow, newVals []types.Datum,
+	action ast.ReferOptionType) error {
+	sc := fc.sctx.GetSessionVars().StmtCtx
+	tblInfo := rfk.child.Meta()
+	newRow := make([]types.Datum, len(oldRow))
+	copy(newRow, oldRow)
+	touched := make([]bool, len(oldRow))
+	handleChanged := false
+	for i, col := range rfk.cols {
+		var val types.Datum
+		if action == ast.ReferOptionCascade {
+			val = newVals[i]
+		}
+		val, err := table.CastValue(fc.sctx, val, col.ToInfo(), false, false)
+		if err != nil {
+			return err
+		}
+		if err = col.HandleBadNull(&val, sc); err != nil {
+			return err
+		}
+		newRow[col.Offset] = val
+		touched[col.Offset] = true
+		if col.IsPKHandleColumn(tblInfo) || col.IsCommonHandleColumn(tblInfo) {
+			handleChanged = true
+		}
+	}
+	if err := fc.onUpdateRow(ctx, rfk.child, h, oldRow, newRow); err != nil {
+		return err
+	}
+	if handleChanged {
+		if err := rfk.child.RemoveRecord(fc.sctx, h, oldRow); err != nil {
+			return err
+		}
+		_, err := rfk.child.AddRecord(fc.sctx, newRow, table.IsUpdate, table.WithCtx(ctx))
+		return err
+	}
+	return rfk.child.UpdateRecord(ctx, fc.sctx, h, oldRow, newRow, touched)
+}
+
+// findRows returns the handles of the rows in tbl whose cols equal to vals, at most limit handles are returned if limit > 0.
+// It looks up the handle or an index whose leading columns are cols if possible, otherwise it scans the whole table.
+func (fc *foreignKeyChecker) findRows(ctx context.Context, tbl table.PhysicalTable, cols []*table.Column, vals []types.Datum,
+	limit int) ([]kv.Handle, error) {
+	txn, err := fc.sctx.Txn(true)
+	if err != nil {
+		return nil, err
+	}
+	tblInfo := tbl.Meta()
+	if len(cols) == 1 && cols[0].IsPKHandleColumn(tblInfo) {
+		h := kv.IntHandle(vals[0].GetInt64())
+		_, err := txn.Get(ctx, tablecodec.EncodeRecordKey(tbl.RecordPrefix(), h))
+		if kv.ErrNotExist.Equal(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return []kv.Handle{h}, nil
+	}
+
+	sc := fc.sctx.GetSessionVars().StmtCtx
+	idxInfo := findIndexByLeadingColumns(tblInfo, cols)
+	if idxInfo == nil {
+		return fc.scanRows(txn, tbl, tbl.RecordPrefix(), cols, vals, limit)
+	}
+	indexedValues := make([]types.Datum, len(vals))
+	copy(indexedValues, vals)
+	if idxInfo.Primary && tblInfo.IsCommonHandle {
+		// The leading columns of the clustered index are the prefix of the encoded handles.
+		tablecodec.TruncateIndexValues(tblInfo, idxInfo, indexedValues)
+		handlePrefix, err := codec.EncodeKey(sc, nil, indexedValues...)
+		if err != nil {
+			return nil, err
+		}
+		return fc.scanRows(txn, tbl, tablecodec.EncodeRowKey(tbl.GetPhysicalID(), handlePrefix), cols, vals, limit)
+	}
+
+	var handles []kv.Handle
+	prefix, _, err := tablecodec.GenIndexKey(sc, tblInfo, idxInfo, tbl.GetPhysicalID(), indexedValues, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	// The prefix index only stores the leading part of the values, so the full values of the rows are rechecked.
+	recheck := isPrefixIndex(idxInfo, len(cols))
+	it, err := txn.Iter(prefix, kv.Key(prefix).PrefixNext())
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	for ; it.Valid() && (limit <= 0 || len(handles) < limit); err = it.Next() {
+		if err != nil {
+			return nil, err
+		}
+		h, err := tablecodec.DecodeIndexHandle(it.Key(), it.Value(), len(idxInfo.Columns))
+		if err != nil {
+			return nil, err
+		}
+		if recheck {
+			rowVal, err := txn.Get(ctx, tablecodec.EncodeRecordKey(tbl.RecordPrefix(), h))
+			if err != nil {
+				return nil, err
+			}
+			equal, err := fc.rowEqual(tblInfo, h, rowVal, cols, vals)
+			if err != nil {
+				return nil, err
+			}
+			if !equal {
+				continue
+			}
+		}
+		handles = append(handles, h)
+	}
+	return handles, err
+}
+
+// scanRows scans the rows whose keys start with prefix, and returns the handles of the rows whose cols equal to vals.
+func (fc *foreignKeyChecker) scanRows(txn kv.Transaction, tbl table.PhysicalTable, prefix kv.Key, cols []*table.Column,
+	vals []types.Datum, limit int) ([]kv.Handle, error) {
+	var handles []kv.Handle
+	it, err := txn.Iter(prefix, prefix.PrefixNext())
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	for ; it.Valid() && (limit <= 0 || len(handles) < limit); err = it.Next() {
+		if err != nil {
+			return nil, err
+		}
+		_, h, err := tablecodec.DecodeRecordKey(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		equal, err := fc.rowEqual(tbl.Meta(), h, it.Value(), cols, vals)
+		if err != nil {
+			return nil, err
+		}
+		if equal {
+			handles = append(handles, h)
+		}
+	}
+	return handles, err
+}
+
+// rowEqual decodes the cols of the row and returns whether they equal to vals.
+func (fc *foreignKeyChecker) rowEqual(tblInfo *model.TableInfo, h kv.Handle, rowVal []byte, cols []*table.Column,
+	vals []types.Datum) (bool, error) {
+	// The default values are filled by the column offsets, so the decoded columns are placed at their offsets.
+	decodeCols := make([]*table.Column, len(tblInfo.Columns))
+	for _, col := range cols {
+		decodeCols[col.Offset] = col
+	}
+	row, _, err := tables.DecodeRawRowData(fc.sctx, tblInfo, h, decodeCols, rowVal)
+	if err != nil {
+		return false, err
+	}
+	rowVals, _ := getColumnValues(row, cols)
+	return equalColumnValues(fc.sctx.GetSessionVars().StmtCtx, cols, rowVals, vals)
+}
+
+// getReferringFKs returns the foreign keys referring to t.
+func (fc *foreignKeyChecker) getReferringFKs(t table.Table) []*referringFK {
+	tblInfo := t.Meta()
+	if fks, ok := fc.referredBy[tblInfo.ID]; ok {
+		return fks
+	}
+	var fks []*referringFK
+	if dbInfo, ok := fc.is.SchemaByTable(tblInfo); ok {
+		for _, rfk := range fc.is.ReferredForeignKeys(dbInfo.Name, tblInfo.Name) {
+			child, err := fc.is.TableByName(rfk.ChildSchema, rfk.ChildTable)
+			if err != nil {
+				continue
+			}
+			for _, fk := range child.Meta().ForeignKeys {
+				if fk.State != model.StatePublic || fk.Name.L != rfk.ChildFKName.L {
+					continue
+				}
+				cols, ok := findFKColumns(child, fk.Cols)
+				if !ok {
+					continue
+				}
+				refCols, ok := findFKColumns(t, fk.RefCols)
+				if !ok {
+					continue
+				}
+				fks = append(fks, &referringFK{dbName: rfk.ChildSchema, child: child, fk: fk, cols: cols, refCols: refCols})
+			}
+		}
+	}
+	fc.referredBy[tblInfo.ID] = fks
+	return fks
+}
+
+// getGeneratedExprs returns the expressions of the public generated columns of t, which are used to fill back
+// the virtual generated columns when the rows are read.
+func (fc *foreignKeyChecker) getGeneratedExprs(t table.Table) ([]expression.Expression, error) {
+	tblInfo := t.Meta()
+	if exprs, ok := fc.genExprs[tblInfo.ID]; ok {
+		return exprs, nil
+	}
+	var exprs []expression.Expression
+	for _, col := range t.Cols() {
+		if !col.IsGenerated() {
+			continue
+		}
+		expr, err := expression.ParseSimpleExprWithTableInfo(fc.sctx, col.GeneratedExprString, tblInfo)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+	fc.genExprs[tblInfo.ID] = exprs
+	return exprs, nil
+}
+
+func findFKColumns(t table.Table, names []model.CIStr) ([]*table.Column, bool) {
+	cols := make([]*table.Column, 0, len(names))
+	for _, name := range names {
+		col := table.FindCol(t.Cols(), name.L)
+		if col == nil {
+			return nil, false
+		}
+		cols = append(cols, col)
+	}
+	return cols, true
+}
+
+// findIndexByLeadingColumns finds a public index whose leading columns are cols,
+// the index without prefix columns is preferred.
+func findIndexByLeadingColumns(tblInfo *model.TableInfo, cols []*table.Column) *model.IndexInfo {
+	var prefixIdx *model.IndexInfo
+	for _, idxInfo := range tblInfo.Indices {
+		if idxInfo.State != model.StatePublic || idxInfo.Global || len(idxInfo.Columns) < len(cols) {
+			continue
+		}
+		match := true
+		for i, col := range cols {
+			if idxInfo.Columns[i].Name.L != col.Name.L {
+				match = false
+				break
+			}
+		}
+		if !match {
+			continue
+		}
+		if !isPrefixIndex(idxInfo, len(cols)) {
+			return idxInfo
+		}
+		if prefixIdx == nil {
+			prefixIdx = idxInfo
+		}
+	}
+	return prefixIdx
+}
+
+// isPrefixIndex returns whether any of the leading n columns of the index is a prefix column.
+func isPrefixIndex(idxInfo *model.IndexInfo, n int) bool {
+	for _, idxCol := range idxInfo.Columns[:n] {
+		if idxCol.Length != types.UnspecifiedLength {
+			return true
+		}
+	}
+	return false
+}
+
+func getPhysicalTables(t table.Table) []table.PhysicalTable {
+	pt, ok := t.(table.PartitionedTable)
+	if !ok {
+		return []table.PhysicalTable{t.(table.PhysicalTable)}
+	}
+	pi := t.Meta().GetPartitionInfo()
+	tbls := make([]table.PhysicalTable, 0, len(pi.Definitions))
+	for _, def := range pi.Definitions {
+		tbls = append(tbls, pt.GetPartition(def.ID))
+	}
+	return tbls
+}
+
+func getColumnValues(row []types.Datum, cols []*table.Column) (vals []types.Datum, hasNull bool) {
+	vals = make([]types.Datum, 0, len(cols))
+	for _, col := range cols {
+		if row[col.Offset].IsNull() {
+			hasNull = true
+		}
+		vals = append(vals, row[col.Offset])
+	}
+	return vals, hasNull
+}
+
+func isAnyColumnModified(cols []*table.Column, modified []bool) bool {
+	for _, col := range cols {
+		if modified[col.Offset] {
+			return true
+		}
+	}
+	return false
+}
+
+// equalColumnValues compares the values with the collations of cols, or binary collation if cols is nil.
+func equalColumnValues(sc *stmtctx.StatementContext, cols []*table.Column, a, b []types.Datum) (bool, error) {
+	for i := range a {
+		collator := collate.GetBinaryCollator()
+		if cols != nil {
+			collator = collate.GetCollator(cols[i].Collate)
+		}
+		cmp, err := a[i].Compare(sc, &b[i], collator)
+		if err != nil || cmp != 0 {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func foreignKeyDescription(dbName model.CIStr, tblInfo *model.TableInfo, fk *model.FKInfo) string {
+	quote := func(names []model.CIStr) string {
+		quoted := make([]string, 0, len(names))
+		for _, name := range names {
+			quoted = append(quoted, "`"+name.O+"`")
+		}
+		return strings.Join(quoted, ", ")
+	}
+	refTable := "`" + fk.RefTable.O + "`"
+	if refSchema := fk.GetRefSchema(dbName); refSchema.L != dbName.L {
+		refTable = "`" + refSchema.O + "`." + refTable
+	}
+	return fmt.Sprintf("`%s`.`%s`, CONSTRAINT `%s` FOREIGN KEY (%s) REFERENCES %s (%s)",
+		dbName.O, tblInfo.Name.O, fk.Name.O, quote(fk.Cols), refTable, quote(fk.RefCols))
+}
+
+// isForeignKeyError returns whether the error is a foreign key constraint failure, which is turned into a warning by IGNORE.
+func isForeignKeyError(err error) bool {
+	return ErrNoReferencedRow.Equal(err) || ErrRowIsReferenced.Equal(err)
+}
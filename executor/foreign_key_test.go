@@ -0,0 +1,263 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForeignKeyCheckOnWriteChild(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	parents := []string{
+		// The referenced column is the int handle.
+		"create table parent (id int primary key, name varchar(10))",
+		// The referenced column is the leading column of the clustered index, which is looked up by the handle prefix.
+		"create table parent (id int, name varchar(10), primary key (id, name) clustered)",
+		// The referenced column is the leading column of a secondary index.
+		"create table parent (id int, name varchar(10), key (id, name))",
+		// The referenced column has no index, which is scanned.
+		"create table parent (id int, name varchar(10))",
+		"create table parent (id int, name varchar(10)) partition by hash(id) partitions 3",
+	}
+	for _, parent := range parents {
+		// The foreign keys are not checked when foreign_key_checks is off, which also allows the parent without index.
+		tk.MustExec("set @@foreign_key_checks = 0")
+		tk.MustExec("drop table if exists child, parent")
+		tk.MustExec(parent)
+		tk.MustExec("create table child (id int primary key, pid int, constraint fk_1 foreign key (pid) references parent (id))")
+		tk.MustExec("insert into parent values (1, 'a'), (2, 'b')")
+		tk.MustExec("insert into child values (100, 100)")
+		tk.MustExec("delete from child")
+
+		tk.MustExec("set @@foreign_key_checks = 1")
+		tk.MustExec("insert into child values (1, 1), (2, null), (3, 2)")
+		tk.MustGetErrMsg("insert into child values (4, 3)", "[executor:1452]Cannot add or update a child row: a foreign key constraint fails (`test`.`child`, CONSTRAINT `fk_1` FOREIGN KEY (`pid`) REFERENCES `parent` (`id`))")
+		tk.MustGetErrCode("update child set pid = 3 where id = 1", errno.ErrNoReferencedRow2)
+		tk.MustGetErrCode("replace into child values (1, 3)", errno.ErrNoReferencedRow2)
+		tk.MustGetErrCode("insert into child values (1, 1) on duplicate key update pid = 3", errno.ErrNoReferencedRow2)
+		tk.MustExec("update child set pid = 1 where id = 3")
+		tk.MustExec("update child set id = 4 where id = 3")
+		tk.MustQuery("select * from child order by id").Check(testkit.Rows("1 1", "2 <nil>", "4 1"))
+
+		tk.MustExec("insert ignore into child values (5, 3), (6, 2)")
+		tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1452 Cannot add or update a child row: a foreign key constraint fails (`test`.`child`, CONSTRAINT `fk_1` FOREIGN KEY (`pid`) REFERENCES `parent` (`id`))"))
+		tk.MustExec("update ignore child set pid = pid + 1")
+		tk.MustQuery("select * from child order by id").Check(testkit.Rows("1 2", "2 <nil>", "4 2", "6 2"))
+	}
+}
+
+func TestForeignKeyChecksByDefault(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	// Like MySQL, the foreign keys are checked by default.
+	tk.MustQuery("select @@session.foreign_key_checks, @@global.foreign_key_checks").Check(testkit.Rows("1 1"))
+	tk.MustExec("create table parent (id int, key(id))")
+	tk.MustExec("create table child (id int primary key, pid int, constraint fk_1 foreign key (pid) references parent (id))")
+	tk.MustExec("insert into parent values (1)")
+	tk.MustExec("insert into child values (1, 1)")
+	tk.MustGetErrCode("insert into child values (2, 2)", errno.ErrNoReferencedRow2)
+	tk.MustGetErrCode("delete from parent", errno.ErrRowIsReferenced2)
+	// The parent table needs an index on the referenced columns.
+	tk.MustExec("create table parent2 (id int)")
+	tk.MustGetErrCode("create table child2 (id int, constraint fk_2 foreign key (id) references parent2 (id))", errno.ErrFkNoIndexParent)
+	tk.MustQuery("select * from child").Check(testkit.Rows("1 1"))
+}
+
+func TestForeignKeyReferentialActions(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@foreign_key_checks = 1")
+
+	// RESTRICT and NO ACTION.
+	tk.MustExec("create table parent (id int primary key)")
+	tk.MustExec("create table child (id int primary key, pid int, key (pid), constraint fk_1 foreign key (pid) references parent (id) on update no action)")
+	tk.MustExec("insert into parent values (1), (2), (3)")
+	tk.MustExec("insert into child values (1, 1), (2, 2)")
+	tk.MustGetErrMsg("delete from parent where id = 1", "[executor:1451]Cannot delete or update a parent row: a foreign key constraint fails (`test`.`child`, CONSTRAINT `fk_1` FOREIGN KEY (`pid`) REFERENCES `parent` (`id`))")
+	tk.MustGetErrCode("update parent set id = 10 where id = 2", errno.ErrRowIsReferenced2)
+	tk.MustExec("delete from parent where id = 3")
+	tk.MustExec("update ignore parent set id = id + 10")
+	tk.MustQuery("show warnings").Check(testkit.Rows(
+		"Warning 1451 Cannot delete or update a parent row: a foreign key constraint fails (`test`.`child`, CONSTRAINT `fk_1` FOREIGN KEY (`pid`) REFERENCES `parent` (`id`))",
+		"Warning 1451 Cannot delete or update a parent row: a foreign key constraint fails (`test`.`child`, CONSTRAINT `fk_1` FOREIGN KEY (`pid`) REFERENCES `parent` (`id`))"))
+	tk.MustQuery("select * from parent order by id").Check(testkit.Rows("1", "2"))
+	tk.MustExec("delete from child where id = 1")
+	tk.MustExec("delete from parent where id = 1")
+
+	// CASCADE and SET NULL.
+	tk.MustExec("drop table child, parent")
+	tk.MustExec("create table parent (id int primary key, name varchar(10), unique key (name))")
+	tk.MustExec("create table child (id int primary key, pid int, name varchar(10), " +
+		"foreign key (pid) references parent (id) on delete cascade on update cascade, " +
+		"foreign key (name) references parent (name) on delete set null on update set null)")
+	tk.MustExec("create table grandchild (id int primary key, cid int, foreign key (cid) references child (id) on delete cascade)")
+	tk.MustExec("insert into parent values (1, 'a'), (2, 'b')")
+	tk.MustExec("insert into child values (1, 1, 'a'), (2, 1, 'b'), (3, 2, 'b')")
+	tk.MustExec("insert into grandchild values (1, 1), (2, 2), (3, 3)")
+	tk.MustExec("update parent set id = 10 where id = 1")
+	tk.MustQuery("select * from child order by id").Check(testkit.Rows("1 10 a", "2 10 b", "3 2 b"))
+	tk.MustExec("update parent set name = 'c' where id = 2")
+	tk.MustQuery("select * from child order by id").Check(testkit.Rows("1 10 a", "2 10 <nil>", "3 2 <nil>"))
+	tk.MustExec("delete from parent where id = 10")
+	// The affected rows only count the rows of the target table.
+	require.Equal(t, uint64(1), tk.Session().AffectedRows())
+	tk.MustQuery("select * from child order by id").Check(testkit.Rows("3 2 <nil>"))
+	tk.MustQuery("select * from grandchild order by id").Check(testkit.Rows("3 3"))
+	tk.MustExec("drop table grandchild, child, parent")
+
+	// Self-referencing table.
+	tk.MustExec("create table t (id int primary key, pid int, key (pid), foreign key (pid) references t (id) on delete cascade)")
+	tk.MustExec("insert into t values (1, 1), (2, 1)")
+	for i := 3; i <= 20; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t values (%d, %d)", i, i-1))
+	}
+	tk.MustGetErrCode("insert into t values (21, 22)", errno.ErrNoReferencedRow2)
+	tk.MustGetErrMsg("delete from t where id = 1", "[executor:3008]Foreign key cascade delete/update exceeds max depth of 15.")
+	tk.MustExec("delete from t where id = 10")
+	tk.MustQuery("select count(*), max(id) from t").Check(testkit.Rows("9 9"))
+	tk.MustExec("delete from t where id = 1")
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("0"))
+}
+
+func TestForeignKeyCrossDatabase(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("create database d1")
+	tk.MustExec("create database d2")
+	tk.MustExec("use test")
+	tk.MustExec("create table d1.parent (id int primary key)")
+	// The table with the same name in the database of the child isn't referenced.
+	tk.MustExec("create table d2.parent (id int primary key)")
+	tk.MustExec("create table d2.child (id int primary key, pid int, constraint fk_1 foreign key (pid) references d1.parent (id) on delete cascade on update cascade)")
+	tk.MustExec("create table d2.child2 (id int primary key, pid int, constraint fk_2 foreign key (pid) references parent (id))")
+	tk.MustExec("insert into d1.parent values (1), (2)")
+	tk.MustExec("insert into d2.parent values (3)")
+	tk.MustExec("insert into d2.child values (1, 1), (2, 2)")
+	tk.MustGetErrMsg("insert into d2.child values (3, 3)", "[executor:1452]Cannot add or update a child row: a foreign key constraint fails (`d2`.`child`, CONSTRAINT `fk_1` FOREIGN KEY (`pid`) REFERENCES `d1`.`parent` (`id`))")
+	tk.MustExec("insert into d2.child2 values (1, 3)")
+	tk.MustGetErrCode("insert into d2.child2 values (2, 1)", errno.ErrNoReferencedRow2)
+
+	tk.MustExec("update d1.parent set id = 10 where id = 1")
+	tk.MustExec("delete from d1.parent where id = 2")
+	tk.MustQuery("select * from d2.child").Check(testkit.Rows("1 10"))
+	tk.MustGetErrCode("delete from d2.parent", errno.ErrRowIsReferenced2)
+
+	tk.MustQuery("show create table d2.child").Check(testkit.Rows("child CREATE TABLE `child` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `pid` int(11) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  KEY `fk_1` (`pid`),\n" +
+		"  CONSTRAINT `fk_1` FOREIGN KEY (`pid`) REFERENCES `d1`.`parent` (`id`) ON DELETE CASCADE ON UPDATE CASCADE\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustQuery("select referenced_table_schema, referenced_table_name from information_schema.key_column_usage where table_schema = 'd2' and referenced_table_name = 'parent' order by table_name").Check(
+		testkit.Rows("d1 parent", "d2 parent"))
+
+	// The table referring to a table with the same name in another database isn't self-referencing.
+	tk.MustExec("create table d1.t (id int)")
+	tk.MustGetErrCode("create table d2.t (id int primary key, constraint fk_3 foreign key (id) references d1.t (id))", errno.ErrFkNoIndexParent)
+}
+
+func TestForeignKeyPrefixIndex(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@foreign_key_checks = 1")
+	// The prefix indexes only store the leading part of the values, the full values are rechecked.
+	tk.MustExec("create table parent (id int primary key, name varchar(10), key (name(2)))")
+	tk.MustExec("create table child (id int primary key, name varchar(10), key (name(2)), " +
+		"constraint fk_1 foreign key (name) references parent (name) on delete cascade)")
+	tk.MustExec("insert into parent values (1, 'abc'), (2, 'abd')")
+	tk.MustExec("insert into child values (1, 'abc')")
+	tk.MustGetErrCode("insert into child values (2, 'abx')", errno.ErrNoReferencedRow2)
+	tk.MustExec("delete from parent where id = 2")
+	tk.MustQuery("select * from child").Check(testkit.Rows("1 abc"))
+	tk.MustExec("delete from parent where id = 1")
+	tk.MustQuery("select count(*) from child").Check(testkit.Rows("0"))
+}
+
+func TestForeignKeyCheckLock(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+	tk1.MustExec("set @@foreign_key_checks = 1")
+	tk1.MustExec("create table parent (id int primary key)")
+	tk1.MustExec("create table child (id int primary key, pid int, foreign key (pid) references parent (id))")
+	tk1.MustExec("insert into parent values (1), (2)")
+	tk2 := testkit.NewTestKit(t, store)
+	tk2.MustExec("use test")
+	tk2.MustExec("set @@foreign_key_checks = 1")
+
+	// The referenced row is locked by the child row, so it can't be deleted until the transaction ends.
+	tk1.MustExec("begin pessimistic")
+	tk1.MustExec("insert into child values (1, 1)")
+	tk2.MustExec("begin pessimistic")
+	ch := make(chan error, 1)
+	go func() {
+		_, err := tk2.Exec("delete from parent where id = 1")
+		ch <- err
+	}()
+	select {
+	case err := <-ch:
+		require.FailNow(t, "the delete should be blocked", "err: %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+	tk1.MustExec("rollback")
+	require.NoError(t, <-ch)
+	tk2.MustExec("commit")
+
+	// The child row can't be inserted if the referenced row is deleted by another transaction.
+	tk1.MustExec("begin pessimistic")
+	tk1.MustExec("delete from parent where id = 2")
+	tk2.MustExec("begin pessimistic")
+	go func() {
+		_, err := tk2.Exec("insert into child values (2, 2)")
+		ch <- err
+	}()
+	select {
+	case err := <-ch:
+		require.FailNow(t, "the insert should be blocked", "err: %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+	tk1.MustExec("commit")
+	err := <-ch
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "[executor:1452]")
+	tk2.MustExec("commit")
+	tk1.MustQuery("select count(*) from parent").Check(testkit.Rows("0"))
+	tk1.MustQuery("select count(*) from child").Check(testkit.Rows("0"))
+}
@@ -0,0 +1,457 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/terror"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/codec"
+	"github.com/pingcap/tidb/util/disk"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/memory"
+	"go.uber.org/zap"
+)
+
+// The grace hash join works as follows when the memory quota is exceeded
+// during building the hash table:
+//  1. the rows of the build side are hashed into graceHashJoinFanout partitions
+//     in disk by their join keys, and the in-memory hash table is released;
+//  2. the rows of the probe side are hashed into the partitions in the same way;
+//  3. every pair of partitions is joined by building a hash table for the build
+//     side partition and probing it with the probe side partition. If the memory
+//     quota is exceeded again when building the hash table for a partition, the
+//     pair is split into graceHashJoinFanout smaller pairs recursively.
+const (
+	// graceHashJoinFanout is the number of partitions one partition is split into.
+	graceHashJoinFanout = 16
+	// maxGraceHashJoinDepth is the maximum times a partition can be split.
+	maxGraceHashJoinDepth = 3
+)
+
+const (
+	// hashJoinSpillDisabled means the hash join can not spill now.
+	hashJoinSpillDisabled uint32 = iota
+	// hashJoinSpillArmed means the hash join spills once the memory quota is exceeded.
+	hashJoinSpillArmed
+	// hashJoinSpillRequested means the memory quota is exceeded and the spill is pending.
+	hashJoinSpillRequested
+)
+
+// graceHashJoinPartitionIdx returns the partition of the row in the given depth.
+// The hash value is mixed with the depth so that the rows of a partition are
+// distributed to different partitions when it is split again.
+func graceHashJoinPartitionIdx(hashVal uint64, depth int) int {
+	h := hashVal + uint64(depth+1)*0x9e3779b97f4a7c15
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return int(h % graceHashJoinFanout)
+}
+
+// hashJoinPartitioner hashes the rows of one side of the hash join into
+// partitions stored in disk.
+type hashJoinPartitioner struct {
+	sc           *stmtctx.StatementContext
+	hCtx         *hashContext
+	isNullEQ     []bool
+	fieldTypes   []*types.FieldType
+	depth        int
+	maxChunkSize int
+	diskTracker  *disk.Tracker
+
+	// bufs buffers the rows of every partition before writing them into disk.
+	bufs  []*chunk.Chunk
+	lists []*chunk.ListInDisk
+}
+
+func (e *HashJoinExec) newHashJoinPartitioner(isBuildSide bool, depth int) *hashJoinPartitioner {
+	p := &hashJoinPartitioner{
+		sc:           e.ctx.GetSessionVars().StmtCtx,
+		isNullEQ:     e.isNullEQ,
+		depth:        depth,
+		maxChunkSize: e.maxChunkSize,
+		diskTracker:  e.diskTracker,
+		bufs:         make([]*chunk.Chunk, graceHashJoinFanout),
+		lists:        make([]*chunk.ListInDisk, graceHashJoinFanout),
+	}
+	if isBuildSide {
+		p.fieldTypes = retTypes(e.buildSideExec)
+		p.hCtx = &hashContext{allTypes: e.buildTypes, keyColIdx: getJoinKeyColIdx(e.buildKeys)}
+	} else {
+		p.fieldTypes = retTypes(e.probeSideExec)
+		p.hCtx = &hashContext{allTypes: e.probeTypes, keyColIdx: getJoinKeyColIdx(e.probeKeys)}
+	}
+	return p
+}
+
+func getJoinKeyColIdx(keys []*expression.Column) []int {
+	keyColIdx := make([]int, len(keys))
+	for i := range keys {
+		keyColIdx[i] = keys[i].Index
+	}
+	return keyColIdx
+}
+
+// add hashes the rows of chk into the partitions, chk can be reused after add returns.
+func (p *hashJoinPartitioner) add(chk *chunk.Chunk) error {
+	numRows := chk.NumRows()
+	p.hCtx.initHash(numRows)
+	for keyIdx, colIdx := range p.hCtx.keyColIdx {
+		// The rows are hashed in the same way as building and probing the hash table,
+		// the rows which can not be matched are handled when joining the partitions.
+		ignoreNull := len(p.isNullEQ) > keyIdx && p.isNullEQ[keyIdx]
+		err := codec.HashChunkSelected(p.sc, p.hCtx.hashVals, chk, p.hCtx.allTypes[keyIdx], colIdx, p.hCtx.buf, p.hCtx.hasNull, nil, ignoreNull)
+		if err != nil {
+			return err
+		}
+	}
+	for i := 0; i < numRows; i++ {
+		idx := graceHashJoinPartitionIdx(p.hCtx.hashVals[i].Sum64(), p.depth)
+		if p.bufs[idx] == nil {
+			p.bufs[idx] = chunk.NewChunkWithCapacity(p.fieldTypes, p.maxChunkSize)
+		}
+		p.bufs[idx].AppendRow(chk.GetRow(i))
+		if p.bufs[idx].NumRows() >= p.maxChunkSize {
+			if err := p.flush(idx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *hashJoinPartitioner) flush(idx int) error {
+	if p.lists[idx] == nil {
+		p.lists[idx] = chunk.NewListInDisk(p.fieldTypes)
+		p.lists[idx].GetDiskTracker().AttachTo(p.diskTracker)
+	}
+	err := p.lists[idx].Add(p.bufs[idx])
+	p.bufs[idx] = nil
+	return err
+}
+
+// finish writes the buffered rows into disk, it should be called after all the rows are added.
+func (p *hashJoinPartitioner) finish() error {
+	for idx := range p.bufs {
+		if p.bufs[idx] == nil || p.bufs[idx].NumRows() == 0 {
+			continue
+		}
+		if err := p.flush(idx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *hashJoinPartitioner) close() {
+	for _, l := range p.lists {
+		if l != nil {
+			terror.Log(l.Close())
+		}
+	}
+	p.bufs, p.lists = nil, nil
+}
+
+// hashJoinSpillHelper keeps the partitions of both sides in the grace hash join mode.
+type hashJoinSpillHelper struct {
+	build *hashJoinPartitioner
+	probe *hashJoinPartitioner
+}
+
+func (h *hashJoinSpillHelper) close() {
+	h.build.close()
+	h.probe.close()
+}
+
+// spillBuildSide moves the rows in the hash table into the build side partitions
+// and releases the memory of the hash table.
+func (e *HashJoinExec) spillBuildSide() error {
+	atomic.StoreUint32(&e.spillState, hashJoinSpillDisabled)
+	logutil.BgLogger().Info("memory exceeds quota, partition the build side of hash join into disk",
+		zap.Int64("consumed", e.memTracker.BytesConsumed()))
+	e.spillHelper = &hashJoinSpillHelper{
+		build: e.newHashJoinPartitioner(true, 0),
+		probe: e.newHashJoinPartitioner(false, 0),
+	}
+	for i := 0; i < e.rowContainer.NumChunks(); i++ {
+		chk, err := e.rowContainer.GetChunk(i)
+		if err != nil {
+			return err
+		}
+		if err = e.spillHelper.build.add(chk); err != nil {
+			return err
+		}
+	}
+	e.releaseOuterMatchedStatus()
+	// Drop the old hash table referenced by the shallow copies, the probe workers
+	// never use them after the build side is spilled.
+	err := e.rowContainer.Close()
+	e.rowContainer.hashTable = newConcurrentMapHashTable()
+	for i := range e.rowContainerForProbe {
+		e.rowContainerForProbe[i] = e.rowContainer
+	}
+	return err
+}
+
+func (e *HashJoinExec) releaseOuterMatchedStatus() {
+	for _, bitMap := range e.outerMatchedStatus {
+		e.memTracker.Consume(-bitMap.BytesConsumed())
+	}
+	e.outerMatchedStatus = e.outerMatchedStatus[:0]
+}
+
+// partitionProbeSideChunks hashes all the rows of the probe side into the probe
+// side partitions, probeSideResult is the first chunk fetched from the probe side.
+func (e *HashJoinExec) partitionProbeSideChunks(ctx context.Context, probeSideResult *chunk.Chunk) {
+	probe := e.spillHelper.probe
+	for probeSideResult.NumRows() > 0 {
+		if e.finished.Load().(bool) {
+			return
+		}
+		err := probe.add(probeSideResult)
+		if err == nil {
+			err = Next(ctx, e.probeSideExec, probeSideResult)
+		}
+		if err != nil {
+			e.joinResultCh <- &hashjoinWorkerResult{
+				err: err,
+			}
+			return
+		}
+	}
+	if err := probe.finish(); err != nil {
+		e.joinResultCh <- &hashjoinWorkerResult{
+			err: err,
+		}
+		return
+	}
+	e.probeSideSpilled = true
+}
+
+// joinSpilledPartitions joins every pair of the partitions with the resources of the first join worker.
+func (e *HashJoinExec) joinSpilledPartitions() {
+	ok, joinResult := e.getNewJoinResult(0)
+	if !ok {
+		return
+	}
+	build, probe := e.spillHelper.build, e.spillHelper.probe
+	for i := 0; i < graceHashJoinFanout && ok; i++ {
+		ok, joinResult = e.joinPartition(build.lists[i], probe.lists[i], 0, joinResult)
+	}
+	if joinResult == nil {
+		return
+	} else if joinResult.err != nil || (joinResult.chk != nil && joinResult.chk.NumRows() > 0) {
+		e.joinResultCh <- joinResult
+	} else if joinResult.chk != nil && joinResult.chk.NumRows() == 0 {
+		e.joinChkResourceCh[0] <- joinResult.chk
+	}
+}
+
+// joinPartition joins a pair of the partitions, build or probe is nil if the partition is empty.
+func (e *HashJoinExec) joinPartition(build, probe *chunk.ListInDisk, depth int, joinResult *hashjoinWorkerResult) (bool, *hashjoinWorkerResult) {
+	if e.finished.Load().(bool) {
+		return false, joinResult
+	}
+	buildEmpty, probeEmpty := build == nil || build.Len() == 0, probe == nil || probe.Len() == 0
+	if buildEmpty && (e.useOuterToBuild || e.joinType == plannercore.InnerJoin || e.joinType == plannercore.SemiJoin) {
+		return true, joinResult
+	}
+	if probeEmpty && !e.useOuterToBuild {
+		return true, joinResult
+	}
+	rowContainer, needSplit, err := e.loadBuildPartition(build, depth)
+	if err != nil {
+		joinResult.err = err
+		return false, joinResult
+	}
+	if needSplit {
+		return e.splitAndJoinPartition(build, probe, depth, joinResult)
+	}
+	defer func() {
+		e.releaseOuterMatchedStatus()
+		terror.Call(rowContainer.Close)
+	}()
+
+	ok := true
+	hCtx := &hashContext{allTypes: e.probeTypes, keyColIdx: getJoinKeyColIdx(e.probeKeys)}
+	selected := make([]bool, 0, chunk.InitialCapacity)
+	for i := 0; !probeEmpty && i < probe.NumChunks(); i++ {
+		probeSideChk, err := probe.GetChunk(i)
+		if err != nil {
+			joinResult.err = err
+			return false, joinResult
+		}
+		if e.useOuterToBuild {
+			ok, joinResult = e.join2ChunkForOuterHashJoin(0, probeSideChk, hCtx, rowContainer, joinResult)
+		} else {
+			ok, joinResult = e.join2Chunk(0, probeSideChk, hCtx, rowContainer, joinResult, selected)
+		}
+		if !ok {
+			return false, joinResult
+		}
+	}
+	if e.useOuterToBuild {
+		return e.joinUnmatchedRowsOfPartition(rowContainer, joinResult)
+	}
+	return true, joinResult
+}
+
+// loadBuildPartition builds the hash table for a build side partition. needSplit
+// is true if the memory quota is exceeded and the partition should be split.
+func (e *HashJoinExec) loadBuildPartition(build *chunk.ListInDisk, depth int) (_ *hashRowContainer, needSplit bool, err error) {
+	hCtx := &hashContext{allTypes: e.buildTypes, keyColIdx: getJoinKeyColIdx(e.buildKeys)}
+	rowContainer := newHashRowContainer(e.ctx, 0, hCtx, retTypes(e.buildSideExec))
+	rowContainer.GetMemTracker().AttachTo(e.memTracker)
+	rowContainer.GetMemTracker().SetLabel(memory.LabelForBuildSideResult)
+	if build == nil {
+		return rowContainer, false, nil
+	}
+	if depth < maxGraceHashJoinDepth {
+		atomic.StoreUint32(&e.spillState, hashJoinSpillArmed)
+		defer atomic.StoreUint32(&e.spillState, hashJoinSpillDisabled)
+	}
+	var selected []bool
+	for i := 0; i < build.NumChunks(); i++ {
+		chk, err := build.GetChunk(i)
+		if err == nil {
+			selected, err = e.putBuildSideChunk(rowContainer, chk, selected)
+		}
+		if err != nil {
+			e.releaseOuterMatchedStatus()
+			terror.Call(rowContainer.Close)
+			return nil, false, err
+		}
+		if atomic.LoadUint32(&e.spillState) == hashJoinSpillRequested {
+			e.releaseOuterMatchedStatus()
+			terror.Call(rowContainer.Close)
+			return nil, true, nil
+		}
+	}
+	return rowContainer, false, nil
+}
+
+// splitAndJoinPartition splits a pair of the partitions into smaller ones and joins them.
+func (e *HashJoinExec) splitAndJoinPartition(build, probe *chunk.ListInDisk, depth int, joinResult *hashjoinWorkerResult) (bool, *hashjoinWorkerResult) {
+	logutil.BgLogger().Info("memory exceeds quota, split the partition of hash join",
+		zap.Int("depth", depth), zap.Int("buildRows", build.Len()))
+	buildPartitioner, probePartitioner := e.newHashJoinPartitioner(true, depth+1), e.newHashJoinPartitioner(false, depth+1)
+	defer func() {
+		buildPartitioner.close()
+		probePartitioner.close()
+	}()
+	for _, item := range []struct {
+		src *chunk.ListInDisk
+		dst *hashJoinPartitioner
+	}{{build, buildPartitioner}, {probe, probePartitioner}} {
+		for i := 0; item.src != nil && i < item.src.NumChunks(); i++ {
+			chk, err := item.src.GetChunk(i)
+			if err == nil {
+				err = item.dst.add(chk)
+			}
+			if err != nil {
+				joinResult.err = err
+				return false, joinResult
+			}
+		}
+		if err := item.dst.finish(); err != nil {
+			joinResult.err = err
+			return false, joinResult
+		}
+	}
+	ok := true
+	for i := 0; i < graceHashJoinFanout && ok; i++ {
+		ok, joinResult = e.joinPartition(buildPartitioner.lists[i], probePartitioner.lists[i], depth+1, joinResult)
+	}
+	return ok, joinResult
+}
+
+// joinUnmatchedRowsOfPartition handles the unmatched rows of a build side partition in the outer hash join.
+func (e *HashJoinExec) joinUnmatchedRowsOfPartition(rowContainer *hashRowContainer, joinResult *hashjoinWorkerResult) (ok bool, _ *hashjoinWorkerResult) {
+	for i := 0; i < rowContainer.NumChunks(); i++ {
+		chk, err := rowContainer.GetChunk(i)
+		if err != nil {
+			joinResult.err = err
+			return false, joinResult
+		}
+		for j := 0; j < chk.NumRows(); j++ {
+			if !e.outerMatchedStatus[i].UnsafeIsSet(j) {
+				e.joiners[0].onMissMatch(false, chk.GetRow(j), joinResult.chk)
+			}
+			if joinResult.chk.IsFull() {
+				e.joinResultCh <- joinResult
+				ok, joinResult = e.getNewJoinResult(0)
+				if !ok {
+					return false, joinResult
+				}
+			}
+		}
+	}
+	return true, joinResult
+}
+
+// ActionSpill returns a HashJoinSpillDiskAction for partitioning the hash join into disk.
+func (e *HashJoinExec) ActionSpill() *HashJoinSpillDiskAction {
+	if e.spillAction == nil {
+		e.spillAction = &HashJoinSpillDiskAction{
+			e: e,
+		}
+	}
+	return e.spillAction
+}
+
+// HashJoinSpillDiskAction implements memory.ActionOnExceed for the grace hash join.
+// If the memory quota of a query is exceeded, HashJoinSpillDiskAction.Action is
+// triggered.
+type HashJoinSpillDiskAction struct {
+	memory.BaseOOMAction
+	e *HashJoinExec
+}
+
+// Action requests the hash join to partition its data into disk.
+func (a *HashJoinSpillDiskAction) Action(t *memory.Tracker) {
+	state := atomic.LoadUint32(&a.e.spillState)
+	// Guarantee that the hash table is at least 20% of the threshold, to avoid spilling a small one.
+	if state == hashJoinSpillArmed && a.e.memTracker.BytesConsumed() >= t.GetBytesLimit()/5 &&
+		atomic.CompareAndSwapUint32(&a.e.spillState, hashJoinSpillArmed, hashJoinSpillRequested) {
+		logutil.BgLogger().Info("memory exceeds quota, request hash join to spill",
+			zap.Int64("consumed", t.BytesConsumed()),
+			zap.Int64("quota", t.GetBytesLimit()))
+		return
+	}
+	if state == hashJoinSpillRequested {
+		// The hash join releases the memory soon.
+		return
+	}
+	if fallback := a.GetFallback(); fallback != nil {
+		fallback.Action(t)
+	}
+}
+
+// GetPriority get the priority of the Action
+func (a *HashJoinSpillDiskAction) GetPriority() int64 {
+	return memory.DefSpillPriority
+}
+
+// SetLogHook sets the hook, it does nothing just to form the memory.ActionOnExceed interface.
+func (a *HashJoinSpillDiskAction) SetLogHook(hook func(uint64)) {}
@@ -72,8 +72,16 @@ func (e *GrantExec) Next(ctx context.Context, req *chunk.Chunk) error {
 		dbName = e.ctx.GetSessionVars().CurrentDB
 	}
 
-	// For table & column level, check whether table exists and privilege is valid
-	if e.Level.Level == ast.GrantLevelTable {
+	// For stored routine level, check whether routine exists and privilege is valid
+	if routineType, ok := routineTypeOfObject(e.ObjectType); ok {
+		if err := checkRoutinePrivs(e.Level, e.Privs); err != nil {
+			return err
+		}
+		if _, ok := getTargetRoutineName(e.is, dbName, e.Level.TableName, routineType); !ok {
+			return infoschema.ErrRoutineNotExists.GenWithStackByArgs(routineType.String(), dbName+"."+e.Level.TableName)
+		}
+	} else if e.Level.Level == ast.GrantLevelTable {
+		// For table & column level, check whether table exists and privilege is valid
 		// Return if privilege is invalid, to fail before not existing table, see issue #29302
 		for _, p := range e.Privs {
 			if len(p.Cols) == 0 {
@@ -181,6 +189,7 @@ func (e *GrantExec) Next(ctx context.Context, req *chunk.Chunk) error {
 		// DB scope:			mysql.DB
 		// Table scope:			mysql.Tables_priv
 		// Column scope:		mysql.Columns_priv
+		// Routine scope:		mysql.procs_priv
 		if e.TLSOptions != nil {
 			err = checkAndInitGlobalPriv(internalSession, user.User.Username, user.User.Hostname)
 			if err != nil {
@@ -194,7 +203,13 @@ func (e *GrantExec) Next(ctx context.Context, req *chunk.Chunk) error {
 				return err
 			}
 		case ast.GrantLevelTable:
-			err := checkAndInitTablePriv(internalSession, dbName, e.Level.TableName, e.is, user.User.Username, user.User.Hostname)
+			var err error
+			if routineType, ok := routineTypeOfObject(e.ObjectType); ok {
+				routineName, _ := getTargetRoutineName(e.is, dbName, e.Level.TableName, routineType)
+				err = checkAndInitProcsPriv(internalSession, dbName, routineName, routineType, user.User.Username, user.User.Hostname)
+			} else {
+				err = checkAndInitTablePriv(internalSession, dbName, e.Level.TableName, e.is, user.User.Username, user.User.Hostname)
+			}
 			if err != nil {
 				return err
 			}
@@ -292,6 +307,56 @@ func checkAndInitTablePriv(ctx sessionctx.Context, dbName, tblName string, is in
 	return initTablePrivEntry(ctx, user, host, dbName, tblName)
 }
 
+// checkAndInitProcsPriv checks if stored routine scope privilege entry exists in mysql.procs_priv.
+// If unexists, insert a new one.
+func checkAndInitProcsPriv(ctx sessionctx.Context, dbName, routineName string, routineType model.RoutineType, user string, host string) error {
+	ok, err := procsPrivEntryExists(ctx, user, host, dbName, routineName, routineType)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return nil
+	}
+	// Entry does not exist for user-host-db-routine. Insert a new entry.
+	return initProcsPrivEntry(ctx, user, host, dbName, routineName, routineType)
+}
+
+// routineTypeOfObject returns the stored routine type of the object of GRANT
+// and REVOKE, ok is false if the object isn't a stored routine.
+func routineTypeOfObject(tp ast.ObjectTypeType) (model.RoutineType, bool) {
+	switch tp {
+	case ast.ObjectTypeFunction:
+		return model.RoutineFunction, true
+	case ast.ObjectTypeProcedure:
+		return model.RoutineProcedure, true
+	}
+	return 0, false
+}
+
+// checkRoutinePrivs checks whether the privileges can be granted on or revoked
+// from a stored routine.
+func checkRoutinePrivs(level *ast.GrantLevel, privs []*ast.PrivElem) error {
+	if level.Level != ast.GrantLevelTable {
+		return ErrIllegalGrantForTable
+	}
+	for _, p := range privs {
+		if len(p.Cols) > 0 || !mysql.AllRoutinePrivs.Has(p.Priv) && p.Priv != mysql.AllPriv && p.Priv != mysql.UsagePriv && p.Priv != mysql.GrantPriv {
+			return ErrIllegalGrantForTable
+		}
+	}
+	return nil
+}
+
+// getTargetRoutineName returns the name of the stored routine in the info
+// schema, ok is false if the routine doesn't exist.
+func getTargetRoutineName(is infoschema.InfoSchema, dbName, name string, routineType model.RoutineType) (string, bool) {
+	routine, ok := is.RoutineByName(model.NewCIStr(dbName), model.NewCIStr(name), routineType)
+	if !ok {
+		return name, false
+	}
+	return routine.Name.O, true
+}
+
 // checkAndInitColumnPriv checks if column scope privilege entry exists in mysql.Columns_priv.
 // If unexists, insert a new one.
 func (e *GrantExec) checkAndInitColumnPriv(user string, host string, cols []*ast.ColumnName, internalSession sessionctx.Context) error {
@@ -338,6 +403,12 @@ func initTablePrivEntry(ctx sessionctx.Context, user string, host string, db str
 	return err
 }
 
+// initProcsPrivEntry inserts a new row into mysql.procs_priv with empty privilege.
+func initProcsPrivEntry(ctx sessionctx.Context, user string, host string, db string, routine string, routineType model.RoutineType) error {
+	_, err := ctx.(sqlexec.SQLExecutor).ExecuteInternal(context.Background(), `INSERT INTO %n.%n (Host, User, DB, Routine_name, Routine_type, Proc_priv) VALUES (%?, %?, %?, %?, %?, '')`, mysql.SystemDB, mysql.ProcsPrivTable, host, user, db, routine, routineType.String())
+	return err
+}
+
 // initColumnPrivEntry inserts a new row into mysql.Columns_priv with empty privilege.
 func initColumnPrivEntry(ctx sessionctx.Context, user string, host string, db string, tbl string, col string) error {
 	_, err := ctx.(sqlexec.SQLExecutor).ExecuteInternal(context.Background(), `INSERT INTO %n.%n (Host, User, DB, Table_name, Column_name, Column_priv) VALUES (%?, %?, %?, %?, %?, '')`, mysql.SystemDB, mysql.ColumnPrivTable, host, user, db, tbl, col)
@@ -447,6 +518,9 @@ func (e *GrantExec) grantLevelPriv(priv *ast.PrivElem, user *ast.UserSpec, inter
 	case ast.GrantLevelDB:
 		return e.grantDBLevel(priv, user, internalSession)
 	case ast.GrantLevelTable:
+		if routineType, ok := routineTypeOfObject(e.ObjectType); ok {
+			return e.grantRoutineLevel(priv, user, internalSession, routineType)
+		}
 		if len(priv.Cols) == 0 {
 			return e.grantTableLevel(priv, user, internalSession)
 		}
@@ -552,6 +626,34 @@ func (e *GrantExec) grantTableLevel(priv *ast.PrivElem, user *ast.UserSpec, inte
 	return err
 }
 
+// grantRoutineLevel manipulates mysql.procs_priv table.
+func (e *GrantExec) grantRoutineLevel(priv *ast.PrivElem, user *ast.UserSpec, internalSession sessionctx.Context, routineType model.RoutineType) error {
+	if priv.Priv == mysql.UsagePriv {
+		return nil
+	}
+	dbName := e.Level.DBName
+	if len(dbName) == 0 {
+		dbName = e.ctx.GetSessionVars().CurrentDB
+	}
+	routineName, _ := getTargetRoutineName(e.is, dbName, e.Level.TableName, routineType)
+
+	currProcPriv, err := getProcPriv(internalSession, user.User.Username, user.User.Hostname, dbName, routineName, routineType)
+	if err != nil {
+		return err
+	}
+	newProcPriv := SetFromString(currProcPriv)
+	if priv.Priv == mysql.AllPriv {
+		for _, p := range mysql.AllRoutinePrivs {
+			newProcPriv = addToSet(newProcPriv, p.SetString())
+		}
+	} else {
+		newProcPriv = addToSet(newProcPriv, priv.Priv.SetString())
+	}
+	_, err = internalSession.(sqlexec.SQLExecutor).ExecuteInternal(context.Background(), `UPDATE %n.%n SET Proc_priv=%?, Grantor=%? WHERE User=%? AND Host=%? AND DB=%? AND Routine_name=%? AND Routine_type=%?`,
+		mysql.SystemDB, mysql.ProcsPrivTable, setToString(newProcPriv), e.ctx.GetSessionVars().User.String(), user.User.Username, user.User.Hostname, dbName, routineName, routineType.String())
+	return err
+}
+
 // grantColumnLevel manipulates mysql.tables_priv table.
 func (e *GrantExec) grantColumnLevel(priv *ast.PrivElem, user *ast.UserSpec, internalSession sessionctx.Context) error {
 	dbName, tbl, err := getTargetSchemaAndTable(e.ctx, e.Level.DBName, e.Level.TableName, e.is)
@@ -723,6 +825,28 @@ func columnPrivEntryExists(ctx sessionctx.Context, name string, host string, db
 	return recordExists(ctx, `SELECT * FROM %n.%n WHERE User=%? AND Host=%? AND DB=%? AND Table_name=%? AND Column_name=%?;`, mysql.SystemDB, mysql.ColumnPrivTable, name, host, db, tbl, col)
 }
 
+// procsPrivEntryExists checks if there is an entry with key user-host-db-routine in mysql.procs_priv.
+func procsPrivEntryExists(ctx sessionctx.Context, name string, host string, db string, routine string, routineType model.RoutineType) (bool, error) {
+	return recordExists(ctx, `SELECT * FROM %n.%n WHERE User=%? AND Host=%? AND DB=%? AND Routine_name=%? AND Routine_type=%?;`, mysql.SystemDB, mysql.ProcsPrivTable, name, host, db, routine, routineType.String())
+}
+
+// getProcPriv gets current stored routine scope privilege set from mysql.procs_priv.
+// Return Proc_priv.
+func getProcPriv(ctx sessionctx.Context, name string, host string, db string, routine string, routineType model.RoutineType) (string, error) {
+	rs, err := ctx.(sqlexec.SQLExecutor).ExecuteInternal(context.Background(), `SELECT Proc_priv FROM %n.%n WHERE User=%? AND Host=%? AND DB=%? AND Routine_name=%? AND Routine_type=%?`, mysql.SystemDB, mysql.ProcsPrivTable, name, host, db, routine, routineType.String())
+	if err != nil {
+		return "", err
+	}
+	rows, _, err := getRowsAndFields(ctx, rs)
+	if err != nil {
+		return "", errors.Errorf("get routine privilege fail for %s %s %s %s: %v", name, host, db, routine, err)
+	}
+	if len(rows) < 1 {
+		return "", errors.Errorf("get routine privilege fail for %s %s %s %s", name, host, db, routine)
+	}
+	return rows[0].GetSet(0).Name, nil
+}
+
 // getTablePriv gets current table scope privilege set from mysql.Tables_priv.
 // Return Table_priv and Column_priv.
 func getTablePriv(ctx sessionctx.Context, name string, host string, db string, tbl string) (string, string, error) {
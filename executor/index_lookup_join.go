@@ -72,6 +72,7 @@ type IndexLookUpJoin struct {
 
 	joiner      joiner
 	isOuterJoin bool
+	concurrency int
 
 	requiredRows int64
 
@@ -179,7 +180,7 @@ func (e *IndexLookUpJoin) Open(ctx context.Context) error {
 }
 
 func (e *IndexLookUpJoin) startWorkers(ctx context.Context) {
-	concurrency := e.ctx.GetSessionVars().IndexLookupJoinConcurrency()
+	concurrency := e.concurrency
 	if e.stats != nil {
 		e.stats.concurrency = concurrency
 	}
@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 	"runtime/trace"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -52,7 +53,7 @@ var (
 // IndexMergeReaderExecutor accesses a table with multiple index/table scan.
 // There are three types of workers:
 // 1. partialTableWorker/partialIndexWorker, which are used to fetch the handles
-// 2. indexMergeProcessWorker, which is used to do the `Union` operation.
+// 2. indexMergeProcessWorker, which is used to do the `Union` or `Intersection` operation.
 // 3. indexMergeTableScanWorker, which is used to get the table tuples with the given handles.
 //
 // The execution flow is really like IndexLookUpReader. However, it uses multiple index scans
@@ -64,6 +65,9 @@ var (
 //    1. check whether it has been accessed.
 //    2. if not, record it and send it to the indexMergeTableScanWorker.
 //    3. if accessed, just ignore it.
+//    For the `Intersection` operation, indexMergeProcessWorker records the handles fetched by every
+//    partial worker, and sends the handles fetched by all the partial workers to the
+//    indexMergeTableScanWorker after all the partial workers finish.
 type IndexMergeReaderExecutor struct {
 	baseExecutor
 
@@ -116,6 +120,9 @@ type IndexMergeReaderExecutor struct {
 	isCorColInPartialFilters []bool
 	isCorColInTableFilter    bool
 	isCorColInPartialAccess  []bool
+
+	// isIntersection indicates whether the handles fetched by the partial workers are intersected.
+	isIntersection bool
 }
 
 // Table implements the dataSourceExecutor interface.
@@ -250,7 +257,11 @@ func (e *IndexMergeReaderExecutor) startIndexMergeProcessWorker(ctx context.Cont
 		defer trace.StartRegion(ctx, "IndexMergeProcessWorker").End()
 		util.WithRecovery(
 			func() {
-				idxMergeProcessWorker.fetchLoop(ctx, fetch, workCh, e.resultCh, e.finished)
+				if e.isIntersection {
+					idxMergeProcessWorker.fetchLoopIntersection(ctx, fetch, workCh, e.resultCh, e.finished)
+				} else {
+					idxMergeProcessWorker.fetchLoop(ctx, fetch, workCh, e.resultCh, e.finished)
+				}
 			},
 			idxMergeProcessWorker.handleLoopFetcherPanic(ctx, e.resultCh),
 		)
@@ -284,6 +295,7 @@ func (e *IndexMergeReaderExecutor) startPartialIndexWorker(ctx context.Context,
 			func() {
 				worker := &partialIndexWorker{
 					stats:        e.stats,
+					workID:       workID,
 					idxID:        e.getPartitalPlanID(workID),
 					sc:           e.ctx,
 					batchSize:    e.maxChunkSize,
@@ -394,6 +406,7 @@ func (e *IndexMergeReaderExecutor) startPartialTableWorker(ctx context.Context,
 
 				worker := &partialTableWorker{
 					stats:        e.stats,
+					workID:       workID,
 					sc:           e.ctx,
 					batchSize:    e.maxChunkSize,
 					maxBatchSize: e.ctx.GetSessionVars().IndexLookupSize,
@@ -481,6 +494,7 @@ func (e *IndexMergeReaderExecutor) getTablePlanRootID() int {
 
 type partialTableWorker struct {
 	stats        *IndexMergeRuntimeStat
+	workID       int
 	sc           sessionctx.Context
 	batchSize    int
 	maxBatchSize int
@@ -566,7 +580,8 @@ func (w *partialTableWorker) buildTableTask(handles []kv.Handle, retChk *chunk.C
 		handles: handles,
 		idxRows: retChk,
 
-		partitionTable: w.partition,
+		partialWorkerID: w.workID,
+		partitionTable:  w.partition,
 	}
 
 	task.doneCh = make(chan error, 1)
@@ -765,6 +780,110 @@ func (w *indexMergeProcessWorker) fetchLoop(ctx context.Context, fetchCh <-chan
 	}
 }
 
+// fetchLoopIntersection records the handles fetched by every partial worker, and sends the handles
+// fetched by all the partial workers to the indexMergeTableScanWorker after all the partial workers finish.
+func (w *indexMergeProcessWorker) fetchLoopIntersection(ctx context.Context, fetchCh <-chan *lookupTableTask,
+	workCh chan<- *lookupTableTask, resultCh chan<- *lookupTableTask, finished <-chan struct{}) {
+	defer func() {
+		close(workCh)
+		close(resultCh)
+	}()
+
+	partialWorkerCnt := len(w.indexMerge.partialPlans)
+	// handleMaps records the handles fetched by every partial worker for every physical table.
+	handleMaps := make(map[int64][]*kv.HandleMap)
+	partitionTables := make(map[int64]table.PhysicalTable)
+	// memUsage records the memory usage of the handle maps, it's released after all the handles are sent.
+	var memUsage int64
+	memTracker := w.indexMerge.memTracker
+	defer func() {
+		memTracker.Consume(-memUsage)
+	}()
+	for task := range fetchCh {
+		start := time.Now()
+		var tblID int64
+		if w.indexMerge.partitionTableMode {
+			tblID = getPhysicalTableID(task.partitionTable)
+		} else {
+			tblID = getPhysicalTableID(w.indexMerge.table)
+		}
+		hMaps, ok := handleMaps[tblID]
+		if !ok {
+			hMaps = make([]*kv.HandleMap, partialWorkerCnt)
+			for i := range hMaps {
+				hMaps[i] = kv.NewHandleMap()
+			}
+			handleMaps[tblID] = hMaps
+			partitionTables[tblID] = task.partitionTable
+		}
+		hMap := hMaps[task.partialWorkerID]
+		var taskMemUsage int64
+		for _, h := range task.handles {
+			if _, ok := hMap.Get(h); ok {
+				continue
+			}
+			hMap.Set(h, true)
+			taskMemUsage += handleMapEntryMemUsage(h)
+		}
+		memUsage += taskMemUsage
+		memTracker.Consume(taskMemUsage)
+		if w.stats != nil {
+			w.stats.IndexMergeProcess += time.Since(start)
+		}
+	}
+
+	batchSize := w.indexMerge.ctx.GetSessionVars().IndexLookupSize
+	for tblID, hMaps := range handleMaps {
+		start := time.Now()
+		// Iterate the smallest map and check whether the handle is in all the other maps.
+		sort.Slice(hMaps, func(i, j int) bool {
+			return hMaps[i].Len() < hMaps[j].Len()
+		})
+		fhs := make([]kv.Handle, 0, batchSize)
+		hMaps[0].Range(func(h kv.Handle, _ interface{}) bool {
+			for _, hMap := range hMaps[1:] {
+				if _, ok := hMap.Get(h); !ok {
+					return true
+				}
+			}
+			fhs = append(fhs, h)
+			return true
+		})
+		if w.stats != nil {
+			w.stats.IndexMergeProcess += time.Since(start)
+		}
+		for len(fhs) > 0 {
+			n := mathutil.Min(batchSize, len(fhs))
+			task := &lookupTableTask{
+				handles: fhs[:n:n],
+				doneCh:  make(chan error, 1),
+
+				partitionTable: partitionTables[tblID],
+			}
+			fhs = fhs[n:]
+			select {
+			case <-ctx.Done():
+				return
+			case <-finished:
+				return
+			case workCh <- task:
+				resultCh <- task
+			}
+		}
+	}
+}
+
+// handleMapEntryMemUsage estimates the memory usage of recording the handle in a kv.HandleMap.
+func handleMapEntryMemUsage(h kv.Handle) int64 {
+	// An int handle is stored as an int64 key with an interface{} value.
+	memUsage := int64(8 + 16)
+	if !h.IsInt() {
+		// A common handle is stored with its encoded key and the handle itself.
+		memUsage += int64(len(h.Encoded()))*2 + int64(unsafe.Sizeof(kv.CommonHandle{}))
+	}
+	return memUsage
+}
+
 func (w *indexMergeProcessWorker) handleLoopFetcherPanic(ctx context.Context, resultCh chan<- *lookupTableTask) func(r interface{}) {
 	return func(r interface{}) {
 		if r == nil {
@@ -783,6 +902,7 @@ func (w *indexMergeProcessWorker) handleLoopFetcherPanic(ctx context.Context, re
 
 type partialIndexWorker struct {
 	stats        *IndexMergeRuntimeStat
+	workID       int
 	sc           sessionctx.Context
 	idxID        int
 	batchSize    int
@@ -877,7 +997,8 @@ func (w *partialIndexWorker) buildTableTask(handles []kv.Handle, retChk *chunk.C
 		handles: handles,
 		idxRows: retChk,
 
-		partitionTable: w.partition,
+		partialWorkerID: w.workID,
+		partitionTable:  w.partition,
 	}
 
 	task.doneCh = make(chan error, 1)
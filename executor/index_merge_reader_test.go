@@ -189,6 +189,52 @@ func TestPartitionTableRandomIndexMerge(t *testing.T) {
 	}
 }
 
+func TestIntersectionIndexMerge(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@tidb_enable_index_merge=1")
+	tk.MustExec("set @@tidb_partition_prune_mode='dynamic'")
+	tk.MustExec("create table t (a int, b int, c int, key(a), key(b), key(c)) partition by hash(a) partitions 4")
+	tk.MustExec("create table tpk (a int primary key clustered, b int, c int, key(b), key(c))")
+	tk.MustExec("create table tnormal (a int, b int, c int)")
+
+	values := make([]string, 0, 256)
+	for i := 0; i < 256; i++ {
+		values = append(values, fmt.Sprintf("(%v, %v, %v)", i, rand.Intn(8), rand.Intn(8)))
+	}
+	for _, tbl := range []string{"t", "tpk", "tnormal"} {
+		tk.MustExec(fmt.Sprintf("insert into %v values %v", tbl, strings.Join(values, ", ")))
+	}
+
+	rows := tk.MustQuery("explain select /*+ use_index_merge_intersection(t, b, c) */ * from t where b = 1 and c = 2").Rows()
+	require.Regexp(t, "^IndexMerge", rows[0][0])
+	require.Equal(t, "type: intersection", rows[0][4])
+	rows = tk.MustQuery("explain select /*+ use_index_merge(t, b, c) */ * from t where b = 1 or c = 2").Rows()
+	require.Equal(t, "", rows[0][4])
+	// The intersection type IndexMerge is also chosen by the cost without the hint.
+	rows = tk.MustQuery("explain select * from tpk where b = 1 and c = 2").Rows()
+	require.Regexp(t, "^IndexMerge", rows[0][0])
+	require.Equal(t, "type: intersection", rows[0][4])
+	tk.MustQuery("select * from tpk where b = 1 and c = 2").Sort().Check(
+		tk.MustQuery("select * from tnormal where b = 1 and c = 2").Sort().Rows())
+
+	// Use a small batch size so the intersected handles are sent in several tasks.
+	tk.MustExec("set @@tidb_index_lookup_size = 3")
+	for i := 0; i < 64; i++ {
+		lb, rb := rand.Intn(8), rand.Intn(8)
+		cond := fmt.Sprintf("a > %v and b = %v and c <= %v", rand.Intn(256), lb, rb)
+		result := tk.MustQuery("select * from tnormal where " + cond).Sort().Rows()
+		tk.MustQuery("select /*+ use_index_merge_intersection(t, a, b, c) */ * from t where " + cond).Sort().Check(result)
+		tk.MustQuery("select /*+ use_index_merge_intersection(tpk, primary, b, c) */ * from tpk where " + cond).Sort().Check(result)
+	}
+
+	// The intersection can't be applied when there is only one partial path.
+	tk.MustQuery("select /*+ use_index_merge_intersection(t, b) */ count(*) from t where b = 1 and c = 2")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1105 IndexMerge is inapplicable."))
+}
+
 func TestIndexMergeWithPreparedStmt(t *testing.T) {
 	store, clean := testkit.CreateMockStore(t)
 	defer clean()
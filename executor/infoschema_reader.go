@@ -62,6 +62,7 @@ import (
 	"github.com/pingcap/tidb/util/codec"
 	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tidb/util/deadlockhistory"
+	"github.com/pingcap/tidb/util/expensivequery"
 	"github.com/pingcap/tidb/util/keydecoder"
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/pdapi"
@@ -108,6 +109,8 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			err = e.setDataFromTables(ctx, sctx, dbs)
 		case infoschema.TableReferConst:
 			err = e.setDataFromReferConst(ctx, sctx, dbs)
+		case infoschema.TableCheckConstraints:
+			e.setDataFromCheckConstraints(sctx, dbs)
 		case infoschema.TableSequences:
 			e.setDataFromSequences(sctx, dbs)
 		case infoschema.TablePartitions:
@@ -146,6 +149,12 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			err = e.setDataForTiDBHotRegions(sctx)
 		case infoschema.TableConstraints:
 			e.setDataFromTableConstraints(sctx, dbs)
+		case infoschema.TableTriggers:
+			e.setDataFromTriggers(sctx, dbs)
+		case infoschema.TableRoutines:
+			e.setDataFromRoutines(sctx, dbs)
+		case infoschema.TableParameters:
+			e.setDataFromParameters(sctx, dbs)
 		case infoschema.TableSessionVar:
 			err = e.setDataFromSessionVar(sctx)
 		case infoschema.TableTiDBServersInfo:
@@ -165,6 +174,8 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			err = e.setDataForAttributes(sctx, is)
 		case infoschema.TablePlacementPolicies:
 			err = e.setDataFromPlacementPolicies(sctx)
+		case infoschema.TableMemoryUsageOpsHistory:
+			err = e.setDataForMemoryUsageOpsHistory(sctx)
 		}
 		if err != nil {
 			return nil, err
@@ -482,25 +493,53 @@ func (e *memtableRetriever) setDataFromReferConst(ctx context.Context, sctx sess
 				if ast.ReferOptionType(fk.OnDelete) != 0 {
 					deleteRule = ast.ReferOptionType(fk.OnDelete).String()
 				}
+				record := types.MakeDatums(
+					infoschema.CatalogVal,          // CONSTRAINT_CATALOG
+					schema.Name.O,                  // CONSTRAINT_SCHEMA
+					fk.Name.O,                      // CONSTRAINT_NAME
+					infoschema.CatalogVal,          // UNIQUE_CONSTRAINT_CATALOG
+					fk.GetRefSchema(schema.Name).O, // UNIQUE_CONSTRAINT_SCHEMA
+					"PRIMARY",                      // UNIQUE_CONSTRAINT_NAME
+					"NONE",                         // MATCH_OPTION
+					updateRule,                     // UPDATE_RULE
+					deleteRule,                     // DELETE_RULE
+					table.Name.O,                   // TABLE_NAME
+					fk.RefTable.O,                  // REFERENCED_TABLE_NAME
+				)
+				rows = append(rows, record)
+			}
+		}
+	}
+	e.rows = rows
+	return nil
+}
+
+func (e *memtableRetriever) setDataFromCheckConstraints(sctx sessionctx.Context, schemas []*model.DBInfo) {
+	checker := privilege.GetPrivilegeManager(sctx)
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		for _, table := range schema.Tables {
+			if len(table.Constraints) == 0 {
+				continue
+			}
+			if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, schema.Name.L, table.Name.L, "", mysql.AllPrivMask) {
+				continue
+			}
+			for _, constraint := range table.Constraints {
+				if constraint.State != model.StatePublic {
+					continue
+				}
 				record := types.MakeDatums(
 					infoschema.CatalogVal, // CONSTRAINT_CATALOG
 					schema.Name.O,         // CONSTRAINT_SCHEMA
-					fk.Name.O,             // CONSTRAINT_NAME
-					infoschema.CatalogVal, // UNIQUE_CONSTRAINT_CATALOG
-					schema.Name.O,         // UNIQUE_CONSTRAINT_SCHEMA
-					"PRIMARY",             // UNIQUE_CONSTRAINT_NAME
-					"NONE",                // MATCH_OPTION
-					updateRule,            // UPDATE_RULE
-					deleteRule,            // DELETE_RULE
-					table.Name.O,          // TABLE_NAME
-					fk.RefTable.O,         // REFERENCED_TABLE_NAME
+					constraint.Name.O,     // CONSTRAINT_NAME
+					fmt.Sprintf("(%s)", constraint.ExprString), // CHECK_CLAUSE
 				)
 				rows = append(rows, record)
 			}
 		}
 	}
 	e.rows = rows
-	return nil
 }
 
 func (e *memtableRetriever) setDataFromTables(ctx context.Context, sctx sessionctx.Context, schemas []*model.DBInfo) error {
@@ -742,53 +781,7 @@ ForColumnsTag:
 			}
 		}
 
-		var charMaxLen, charOctLen, numericPrecision, numericScale, datetimePrecision interface{}
-		colLen, decimal := col.Flen, col.Decimal
-		defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(col.Tp)
-		if decimal == types.UnspecifiedLength {
-			decimal = defaultDecimal
-		}
-		if colLen == types.UnspecifiedLength {
-			colLen = defaultFlen
-		}
-		if col.Tp == mysql.TypeSet {
-			// Example: In MySQL set('a','bc','def','ghij') has length 13, because
-			// len('a')+len('bc')+len('def')+len('ghij')+len(ThreeComma)=13
-			// Reference link: https://bugs.mysql.com/bug.php?id=22613
-			colLen = 0
-			for _, ele := range col.Elems {
-				colLen += len(ele)
-			}
-			if len(col.Elems) != 0 {
-				colLen += (len(col.Elems) - 1)
-			}
-			charMaxLen = colLen
-			charOctLen = calcCharOctLength(colLen, col.Charset)
-		} else if col.Tp == mysql.TypeEnum {
-			// Example: In MySQL enum('a', 'ab', 'cdef') has length 4, because
-			// the longest string in the enum is 'cdef'
-			// Reference link: https://bugs.mysql.com/bug.php?id=22613
-			colLen = 0
-			for _, ele := range col.Elems {
-				if len(ele) > colLen {
-					colLen = len(ele)
-				}
-			}
-			charMaxLen = colLen
-			charOctLen = calcCharOctLength(colLen, col.Charset)
-		} else if types.IsString(col.Tp) {
-			charMaxLen = colLen
-			charOctLen = calcCharOctLength(colLen, col.Charset)
-		} else if types.IsTypeFractionable(col.Tp) {
-			datetimePrecision = decimal
-		} else if types.IsTypeNumeric(col.Tp) {
-			numericPrecision = colLen
-			if col.Tp != mysql.TypeFloat && col.Tp != mysql.TypeDouble {
-				numericScale = decimal
-			} else if decimal != -1 {
-				numericScale = decimal
-			}
-		}
+		charMaxLen, charOctLen, numericPrecision, numericScale, datetimePrecision := fieldTypeAttrs(&col.FieldType)
 		columnType := col.FieldType.InfoSchemaStr()
 		columnDesc := table.NewColDesc(table.ToColumn(col))
 		var columnDefault interface{}
@@ -827,6 +820,58 @@ ForColumnsTag:
 	}
 }
 
+// fieldTypeAttrs returns the CHARACTER_MAXIMUM_LENGTH, CHARACTER_OCTET_LENGTH,
+// NUMERIC_PRECISION, NUMERIC_SCALE and DATETIME_PRECISION of the field type.
+func fieldTypeAttrs(ft *types.FieldType) (charMaxLen, charOctLen, numericPrecision, numericScale, datetimePrecision interface{}) {
+	colLen, decimal := ft.Flen, ft.Decimal
+	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(ft.Tp)
+	if decimal == types.UnspecifiedLength {
+		decimal = defaultDecimal
+	}
+	if colLen == types.UnspecifiedLength {
+		colLen = defaultFlen
+	}
+	if ft.Tp == mysql.TypeSet {
+		// Example: In MySQL set('a','bc','def','ghij') has length 13, because
+		// len('a')+len('bc')+len('def')+len('ghij')+len(ThreeComma)=13
+		// Reference link: https://bugs.mysql.com/bug.php?id=22613
+		colLen = 0
+		for _, ele := range ft.Elems {
+			colLen += len(ele)
+		}
+		if len(ft.Elems) != 0 {
+			colLen += (len(ft.Elems) - 1)
+		}
+		charMaxLen = colLen
+		charOctLen = calcCharOctLength(colLen, ft.Charset)
+	} else if ft.Tp == mysql.TypeEnum {
+		// Example: In MySQL enum('a', 'ab', 'cdef') has length 4, because
+		// the longest string in the enum is 'cdef'
+		// Reference link: https://bugs.mysql.com/bug.php?id=22613
+		colLen = 0
+		for _, ele := range ft.Elems {
+			if len(ele) > colLen {
+				colLen = len(ele)
+			}
+		}
+		charMaxLen = colLen
+		charOctLen = calcCharOctLength(colLen, ft.Charset)
+	} else if types.IsString(ft.Tp) {
+		charMaxLen = colLen
+		charOctLen = calcCharOctLength(colLen, ft.Charset)
+	} else if types.IsTypeFractionable(ft.Tp) {
+		datetimePrecision = decimal
+	} else if types.IsTypeNumeric(ft.Tp) {
+		numericPrecision = colLen
+		if ft.Tp != mysql.TypeFloat && ft.Tp != mysql.TypeDouble {
+			numericScale = decimal
+		} else if decimal != -1 {
+			numericScale = decimal
+		}
+	}
+	return
+}
+
 func calcCharOctLength(lenInChar int, cs string) int {
 	lenInBytes := lenInChar
 	if desc, err := charset.GetCharsetInfo(cs); err == nil {
@@ -926,8 +971,20 @@ func (e *memtableRetriever) setDataFromPartitions(ctx context.Context, sctx sess
 					}
 
 					partitionMethod := table.Partition.Type.String()
+					if table.Partition.IsLinear {
+						partitionMethod = "LINEAR " + partitionMethod
+					}
 					partitionExpr := table.Partition.Expr
-					if table.Partition.Type == model.PartitionTypeRange && len(table.Partition.Columns) > 0 {
+					if table.Partition.Type == model.PartitionTypeKey {
+						buf := bytes.NewBuffer(nil)
+						for i, col := range table.Partition.Columns {
+							if i > 0 {
+								buf.WriteString(",")
+							}
+							buf.WriteString(col.String())
+						}
+						partitionExpr = buf.String()
+					} else if table.Partition.Type == model.PartitionTypeRange && len(table.Partition.Columns) > 0 {
 						partitionMethod = "RANGE COLUMNS"
 						partitionExpr = table.Partition.Columns[0].String()
 					} else if table.Partition.Type == model.PartitionTypeList && len(table.Partition.Columns) > 0 {
@@ -1463,18 +1520,18 @@ func keyColumnUsageInTable(schema *model.DBInfo, table *model.TableInfo) [][]typ
 		for i, key := range fk.Cols {
 			col := nameToCol[key.L]
 			record := types.MakeDatums(
-				infoschema.CatalogVal, // CONSTRAINT_CATALOG
-				schema.Name.O,         // CONSTRAINT_SCHEMA
-				fk.Name.O,             // CONSTRAINT_NAME
-				infoschema.CatalogVal, // TABLE_CATALOG
-				schema.Name.O,         // TABLE_SCHEMA
-				table.Name.O,          // TABLE_NAME
-				col.Name.O,            // COLUMN_NAME
-				i+1,                   // ORDINAL_POSITION,
-				1,                     // POSITION_IN_UNIQUE_CONSTRAINT
-				schema.Name.O,         // REFERENCED_TABLE_SCHEMA
-				fk.RefTable.O,         // REFERENCED_TABLE_NAME
-				fkRefCol,              // REFERENCED_COLUMN_NAME
+				infoschema.CatalogVal,          // CONSTRAINT_CATALOG
+				schema.Name.O,                  // CONSTRAINT_SCHEMA
+				fk.Name.O,                      // CONSTRAINT_NAME
+				infoschema.CatalogVal,          // TABLE_CATALOG
+				schema.Name.O,                  // TABLE_SCHEMA
+				table.Name.O,                   // TABLE_NAME
+				col.Name.O,                     // COLUMN_NAME
+				i+1,                            // ORDINAL_POSITION,
+				1,                              // POSITION_IN_UNIQUE_CONSTRAINT
+				fk.GetRefSchema(schema.Name).O, // REFERENCED_TABLE_SCHEMA
+				fk.RefTable.O,                  // REFERENCED_TABLE_NAME
+				fkRefCol,                       // REFERENCED_COLUMN_NAME
 			)
 			rows = append(rows, record)
 		}
@@ -1599,6 +1656,170 @@ func (e *memtableRetriever) setDataForHotRegionByMetrics(metrics []helper.HotTab
 	e.rows = append(e.rows, rows...)
 }
 
+// setDataFromTriggers constructs data for table information_schema.triggers. See https://dev.mysql.com/doc/refman/8.0/en/information-schema-triggers-table.html
+func (e *memtableRetriever) setDataFromTriggers(ctx sessionctx.Context, schemas []*model.DBInfo) {
+	checker := privilege.GetPrivilegeManager(ctx)
+	loc := ctx.GetSessionVars().Location()
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		for _, tbl := range schema.Tables {
+			if len(tbl.Triggers) == 0 {
+				continue
+			}
+			if checker != nil && !checker.RequestVerification(ctx.GetSessionVars().ActiveRoles, schema.Name.L, tbl.Name.L, "", mysql.TriggerPriv) {
+				continue
+			}
+			// ACTION_ORDER is the position of the trigger among the triggers
+			// with the same timing and event.
+			orders := make(map[[2]int]int)
+			for _, trigger := range tbl.Triggers {
+				key := [2]int{int(trigger.Timing), int(trigger.Event)}
+				orders[key]++
+				created := types.NewTime(types.FromGoTime(trigger.Created.In(loc)), mysql.TypeDatetime, 2)
+				record := types.MakeDatums(
+					infoschema.CatalogVal,    // TRIGGER_CATALOG
+					schema.Name.O,            // TRIGGER_SCHEMA
+					trigger.Name.O,           // TRIGGER_NAME
+					trigger.Event.String(),   // EVENT_MANIPULATION
+					infoschema.CatalogVal,    // EVENT_OBJECT_CATALOG
+					schema.Name.O,            // EVENT_OBJECT_SCHEMA
+					tbl.Name.O,               // EVENT_OBJECT_TABLE
+					orders[key],              // ACTION_ORDER
+					nil,                      // ACTION_CONDITION
+					trigger.Body,             // ACTION_STATEMENT
+					"ROW",                    // ACTION_ORIENTATION
+					trigger.Timing.String(),  // ACTION_TIMING
+					nil,                      // ACTION_REFERENCE_OLD_TABLE
+					nil,                      // ACTION_REFERENCE_NEW_TABLE
+					"OLD",                    // ACTION_REFERENCE_OLD_ROW
+					"NEW",                    // ACTION_REFERENCE_NEW_ROW
+					created,                  // CREATED
+					trigger.SQLMode,          // SQL_MODE
+					trigger.Definer.String(), // DEFINER
+					trigger.Charset,          // CHARACTER_SET_CLIENT
+					trigger.Collate,          // COLLATION_CONNECTION
+					schema.Collate,           // DATABASE_COLLATION
+				)
+				rows = append(rows, record)
+			}
+		}
+	}
+	e.rows = rows
+}
+
+func (e *memtableRetriever) setDataFromRoutines(ctx sessionctx.Context, schemas []*model.DBInfo) {
+	loc := ctx.GetSessionVars().Location()
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		for _, routine := range schema.Routines {
+			if !routineVisible(ctx, schema.Name.L, routine) {
+				continue
+			}
+			var dataType, dtdIdentifier, charset, collation interface{}
+			var charMaxLen, charOctLen, numericPrecision, numericScale, datetimePrecision interface{}
+			dataType = ""
+			if routine.Type == model.RoutineFunction {
+				dataType, dtdIdentifier = types.TypeToStr(routine.ReturnType.Tp, routine.ReturnType.Charset), routine.ReturnType.InfoSchemaStr()
+				charMaxLen, charOctLen, numericPrecision, numericScale, datetimePrecision = fieldTypeAttrs(routine.ReturnType)
+				charset, collation = routineTypeCharset(routine.ReturnType)
+			}
+			deterministic := "NO"
+			if routine.Deterministic {
+				deterministic = "YES"
+			}
+			created := types.NewTime(types.FromGoTime(routine.Created.In(loc)), mysql.TypeDatetime, 0)
+			record := types.MakeDatums(
+				routine.Name.O,              // SPECIFIC_NAME
+				infoschema.CatalogVal,       // ROUTINE_CATALOG
+				schema.Name.O,               // ROUTINE_SCHEMA
+				routine.Name.O,              // ROUTINE_NAME
+				routine.Type.String(),       // ROUTINE_TYPE
+				dataType,                    // DATA_TYPE
+				charMaxLen,                  // CHARACTER_MAXIMUM_LENGTH
+				charOctLen,                  // CHARACTER_OCTET_LENGTH
+				numericPrecision,            // NUMERIC_PRECISION
+				numericScale,                // NUMERIC_SCALE
+				datetimePrecision,           // DATETIME_PRECISION
+				charset,                     // CHARACTER_SET_NAME
+				collation,                   // COLLATION_NAME
+				dtdIdentifier,               // DTD_IDENTIFIER
+				"SQL",                       // ROUTINE_BODY
+				routine.Body,                // ROUTINE_DEFINITION
+				nil,                         // EXTERNAL_NAME
+				"SQL",                       // EXTERNAL_LANGUAGE
+				"SQL",                       // PARAMETER_STYLE
+				deterministic,               // IS_DETERMINISTIC
+				routine.DataAccess.String(), // SQL_DATA_ACCESS
+				nil,                         // SQL_PATH
+				strings.ToUpper(routine.Security.String()), // SECURITY_TYPE
+				created,                  // CREATED
+				created,                  // LAST_ALTERED
+				routine.SQLMode,          // SQL_MODE
+				routine.Comment,          // ROUTINE_COMMENT
+				routine.Definer.String(), // DEFINER
+				routine.Charset,          // CHARACTER_SET_CLIENT
+				routine.Collate,          // COLLATION_CONNECTION
+				schema.Collate,           // DATABASE_COLLATION
+			)
+			rows = append(rows, record)
+		}
+	}
+	e.rows = rows
+}
+
+func (e *memtableRetriever) setDataFromParameters(ctx sessionctx.Context, schemas []*model.DBInfo) {
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		for _, routine := range schema.Routines {
+			if !routineVisible(ctx, schema.Name.L, routine) {
+				continue
+			}
+			appendParam := func(pos int, mode, name interface{}, tp *types.FieldType) {
+				charMaxLen, charOctLen, numericPrecision, numericScale, datetimePrecision := fieldTypeAttrs(tp)
+				charset, collation := routineTypeCharset(tp)
+				record := types.MakeDatums(
+					infoschema.CatalogVal,              // SPECIFIC_CATALOG
+					schema.Name.O,                      // SPECIFIC_SCHEMA
+					routine.Name.O,                     // SPECIFIC_NAME
+					pos,                                // ORDINAL_POSITION
+					mode,                               // PARAMETER_MODE
+					name,                               // PARAMETER_NAME
+					types.TypeToStr(tp.Tp, tp.Charset), // DATA_TYPE
+					charMaxLen,                         // CHARACTER_MAXIMUM_LENGTH
+					charOctLen,                         // CHARACTER_OCTET_LENGTH
+					numericPrecision,                   // NUMERIC_PRECISION
+					numericScale,                       // NUMERIC_SCALE
+					datetimePrecision,                  // DATETIME_PRECISION
+					charset,                            // CHARACTER_SET_NAME
+					collation,                          // COLLATION_NAME
+					tp.InfoSchemaStr(),                 // DTD_IDENTIFIER
+					routine.Type.String(),              // ROUTINE_TYPE
+				)
+				rows = append(rows, record)
+			}
+			// The return value of a function is the parameter at position 0
+			// without mode and name, like MySQL.
+			if routine.Type == model.RoutineFunction {
+				appendParam(0, nil, nil, routine.ReturnType)
+			}
+			for i, param := range routine.Params {
+				appendParam(i+1, param.Mode.String(), param.Name.O, param.Tp)
+			}
+		}
+	}
+	e.rows = rows
+}
+
+// routineTypeCharset returns the CHARACTER_SET_NAME and COLLATION_NAME of the
+// type of a routine parameter or return value, they are NULL for the types
+// other than strings.
+func routineTypeCharset(tp *types.FieldType) (charset, collation interface{}) {
+	if !types.IsString(tp.Tp) || tp.Charset == "" {
+		return nil, nil
+	}
+	return tp.Charset, tp.Collate
+}
+
 // setDataFromTableConstraints constructs data for table information_schema.constraints.See https://dev.mysql.com/doc/refman/5.7/en/table-constraints-table.html
 func (e *memtableRetriever) setDataFromTableConstraints(ctx sessionctx.Context, schemas []*model.DBInfo) {
 	checker := privilege.GetPrivilegeManager(ctx)
@@ -2877,6 +3098,34 @@ func (e *memtableRetriever) setDataFromPlacementPolicies(sctx sessionctx.Context
 	return nil
 }
 
+func (e *memtableRetriever) setDataForMemoryUsageOpsHistory(sctx sessionctx.Context) error {
+	// The history may contain the queries of other users, so the PROCESS privilege is required.
+	if !hasPriv(sctx, mysql.ProcessPriv) {
+		return plannercore.ErrSpecificAccessDenied.GenWithStackByArgs("PROCESS")
+	}
+	history := expensivequery.GetServerMemoryLimitOpsHistory()
+	rows := make([][]types.Datum, 0, len(history))
+	for _, op := range history {
+		row := types.MakeDatums(
+			types.NewTime(types.FromGoTime(op.Time.In(sctx.GetSessionVars().Location())), mysql.TypeDatetime, 0),
+			op.Op,
+			op.MemoryLimit,
+			op.MemoryCurrent,
+			op.ProcessID,
+			op.Mem,
+			op.Disk,
+			op.Client,
+			op.DB,
+			op.User,
+			op.SQLDigest,
+			op.SQLText,
+		)
+		rows = append(rows, row)
+	}
+	e.rows = rows
+	return nil
+}
+
 func checkRule(rule *label.Rule) (dbName, tableName string, partitionName string, err error) {
 	s := strings.Split(rule.ID, "/")
 	if len(s) < 3 {
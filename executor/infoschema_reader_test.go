@@ -631,3 +631,23 @@ func TestTablesPKType(t *testing.T) {
 	tk.MustQuery("SELECT TIDB_PK_TYPE FROM information_schema.tables where table_schema = 'test' and table_name = 't_common'").Check(testkit.Rows("CLUSTERED"))
 	tk.MustQuery("SELECT TIDB_PK_TYPE FROM information_schema.tables where table_schema = 'INFORMATION_SCHEMA' and table_name = 'TABLES'").Check(testkit.Rows("NONCLUSTERED"))
 }
+
+func TestMemoryUsageOpsHistory(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustQuery("select count(*) from information_schema.columns where table_schema = 'information_schema' and table_name = 'memory_usage_ops_history'").Check(testkit.Rows("12"))
+	tk.MustQuery("select * from information_schema.memory_usage_ops_history").Check(testkit.Rows())
+
+	// The PROCESS privilege is required.
+	tk.MustExec("create user memory_usage_tester")
+	tester := testkit.NewTestKit(t, store)
+	require.True(t, tester.Session().Auth(&auth.UserIdentity{
+		Username: "memory_usage_tester",
+		Hostname: "127.0.0.1",
+	}, nil, nil))
+	err := tester.QueryToErr("select * from information_schema.memory_usage_ops_history")
+	require.EqualError(t, err, "[planner:1227]Access denied; you need (at least one of) the PROCESS privilege(s) for this operation")
+	tk.MustExec("grant process on *.* to memory_usage_tester")
+	tester.MustQuery("select * from information_schema.memory_usage_ops_history").Check(testkit.Rows())
+}
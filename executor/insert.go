@@ -201,7 +201,7 @@ func (e *InsertExec) updateDupRow(ctx context.Context, idxInBatch int, txn kv.Tr
 	}
 
 	err = e.doDupRowUpdate(ctx, handle, oldRow, row.row, e.OnDuplicate)
-	if e.ctx.GetSessionVars().StmtCtx.DupKeyAsWarning && kv.ErrKeyExists.Equal(err) {
+	if e.ctx.GetSessionVars().StmtCtx.DupKeyAsWarning && (kv.ErrKeyExists.Equal(err) || table.ErrCheckConstraintViolated.Equal(err) || isForeignKeyError(err)) {
 		e.ctx.GetSessionVars().StmtCtx.AppendWarning(err)
 		return nil
 	}
@@ -389,10 +389,20 @@ func (e *InsertExec) doDupRowUpdate(ctx context.Context, handle kv.Handle, oldRo
 
 	// Update old row when the key is duplicated.
 	e.evalBuffer4Dup.SetDatums(e.row4Update...)
+	writableCols := e.Table.WritableCols()
+	triggered := false
 	for _, col := range cols {
 		if col.LazyErr != nil {
 			return col.LazyErr
 		}
+		if !triggered && writableCols[col.Col.Index].IsGenerated() {
+			// The BEFORE UPDATE triggers may change the values of the new row,
+			// the generated columns are evaluated after them.
+			if err := e.runBeforeUpdateTriggers(ctx, oldRow); err != nil {
+				return err
+			}
+			triggered = true
+		}
 		val, err1 := col.Expr.Eval(e.evalBuffer4Dup.ToRow())
 		if err1 != nil {
 			return err1
@@ -405,14 +415,30 @@ func (e *InsertExec) doDupRowUpdate(ctx context.Context, handle kv.Handle, oldRo
 		assignFlag[col.Col.Index] = true
 	}
 
+	if !triggered {
+		if err := e.runBeforeUpdateTriggers(ctx, oldRow); err != nil {
+			return err
+		}
+	}
+
 	newData := e.row4Update[:len(oldRow)]
-	_, err := updateRecord(ctx, e.ctx, handle, oldRow, newData, assignFlag, e.Table, true, e.memTracker)
+	_, err := updateRecord(ctx, e.ctx, handle, oldRow, newData, assignFlag, e.Table, e.constraints, e.fkChecker, true, e.memTracker)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// runBeforeUpdateTriggers runs the BEFORE UPDATE triggers for the duplicated
+// row, and refreshes the evaluation buffer with the new row they changed.
+func (e *InsertExec) runBeforeUpdateTriggers(ctx context.Context, oldRow []types.Datum) error {
+	if err := runTriggers(ctx, e.ctx, e.Table, model.TriggerBefore, model.TriggerUpdate, oldRow, e.row4Update[:len(oldRow)]); err != nil {
+		return err
+	}
+	e.evalBuffer4Dup.SetDatums(e.row4Update...)
+	return nil
+}
+
 // setMessage sets info message(ERR_INSERT_INFO) generated by INSERT statement
 func (e *InsertExec) setMessage() {
 	stmtCtx := e.ctx.GetSessionVars().StmtCtx
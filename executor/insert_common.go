@@ -68,6 +68,11 @@ type InsertValues struct {
 
 	insertColumns []*table.Column
 
+	// constraints are the enforced check constraints the inserted rows should satisfy.
+	constraints []*table.Constraint
+	// fkChecker checks the foreign key constraints, it's nil if foreign_key_checks is disabled.
+	fkChecker *foreignKeyChecker
+
 	// colDefaultVals is used to store casted default value.
 	// Because not every insert statement needs colDefaultVals, so we will init the buffer lazily.
 	colDefaultVals  []defaultVal
@@ -242,6 +247,7 @@ func insertRows(ctx context.Context, base insertCommon) (err error) {
 	rows := make([][]types.Datum, 0, len(e.Lists))
 	memUsageOfRows := int64(0)
 	memTracker := e.memTracker
+	insertEachRow := hasTriggers(e.Table, model.TriggerBefore, model.TriggerInsert)
 	for i, list := range e.Lists {
 		e.rowCount++
 		var row []types.Datum
@@ -250,9 +256,13 @@ func insertRows(ctx context.Context, base insertCommon) (err error) {
 			return err
 		}
 		rows = append(rows, row)
-		if batchInsert && e.rowCount%uint64(batchSize) == 0 {
+		batchFull := batchInsert && e.rowCount%uint64(batchSize) == 0
+		if batchFull || insertEachRow {
 			memUsageOfRows = types.EstimatedMemUsage(rows[0], len(rows))
 			memTracker.Consume(memUsageOfRows)
+			if err = e.runBeforeInsertTriggers(ctx, rows); err != nil {
+				return err
+			}
 			// Before batch insert, fill the batch allocated autoIDs.
 			rows, err = e.lazyAdjustAutoIncrementDatum(ctx, rows)
 			if err != nil {
@@ -264,6 +274,8 @@ func insertRows(ctx context.Context, base insertCommon) (err error) {
 			rows = rows[:0]
 			memTracker.Consume(-memUsageOfRows)
 			memUsageOfRows = 0
+		}
+		if batchFull {
 			if err = e.doBatchInsert(ctx); err != nil {
 				return err
 			}
@@ -273,6 +285,9 @@ func insertRows(ctx context.Context, base insertCommon) (err error) {
 		memUsageOfRows = types.EstimatedMemUsage(rows[0], len(rows))
 		memTracker.Consume(memUsageOfRows)
 	}
+	if err = e.runBeforeInsertTriggers(ctx, rows); err != nil {
+		return err
+	}
 	// Fill the batch allocated autoIDs.
 	rows, err = e.lazyAdjustAutoIncrementDatum(ctx, rows)
 	if err != nil {
@@ -439,6 +454,7 @@ func insertRowsFromSelect(ctx context.Context, base insertCommon) error {
 	memUsageOfExtraCols := int64(0)
 	memTracker := e.memTracker
 	extraColsInSel := make([][]types.Datum, 0, chk.Capacity())
+	insertEachRow := hasTriggers(e.Table, model.TriggerBefore, model.TriggerInsert)
 	// In order to ensure the correctness of the `transaction write throughput` SLI statistics,
 	// just ignore the transaction which contain `insert|replace into ... select ... from ...` statement.
 	e.ctx.GetTxnWriteThroughputSLI().SetInvalid()
@@ -461,11 +477,15 @@ func insertRowsFromSelect(ctx context.Context, base insertCommon) error {
 			}
 			extraColsInSel = append(extraColsInSel, innerRow[e.rowLen:])
 			rows = append(rows, row)
-			if batchInsert && e.rowCount%uint64(batchSize) == 0 {
+			batchFull := batchInsert && e.rowCount%uint64(batchSize) == 0
+			if batchFull || insertEachRow {
 				memUsageOfRows = types.EstimatedMemUsage(rows[0], len(rows))
 				memUsageOfExtraCols = types.EstimatedMemUsage(extraColsInSel[0], len(extraColsInSel))
 				memTracker.Consume(memUsageOfRows + memUsageOfExtraCols)
 				e.ctx.GetSessionVars().CurrInsertBatchExtraCols = extraColsInSel
+				if err = e.runBeforeInsertTriggers(ctx, rows); err != nil {
+					return err
+				}
 				if err = base.exec(ctx, rows); err != nil {
 					return err
 				}
@@ -474,6 +494,8 @@ func insertRowsFromSelect(ctx context.Context, base insertCommon) error {
 				memTracker.Consume(-memUsageOfRows)
 				memTracker.Consume(-memUsageOfExtraCols)
 				memUsageOfRows = 0
+			}
+			if batchFull {
 				if err = e.doBatchInsert(ctx); err != nil {
 					return err
 				}
@@ -486,6 +508,9 @@ func insertRowsFromSelect(ctx context.Context, base insertCommon) error {
 			memTracker.Consume(memUsageOfRows + memUsageOfExtraCols)
 			e.ctx.GetSessionVars().CurrInsertBatchExtraCols = extraColsInSel
 		}
+		if err = e.runBeforeInsertTriggers(ctx, rows); err != nil {
+			return err
+		}
 		err = base.exec(ctx, rows)
 		if err != nil {
 			return err
@@ -631,22 +656,63 @@ func (e *InsertValues) fillRow(ctx context.Context, row []types.Datum, hasValue
 			}
 		}
 	}
+	// The BEFORE INSERT triggers may change the values of the row, the generated
+	// columns are evaluated after them, see runBeforeInsertTriggers.
+	if len(e.Table.Meta().Triggers) > 0 {
+		return row, nil
+	}
+	if err := e.evalGeneratedColumns(row, gCols); err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+func (e *InsertValues) evalGeneratedColumns(row []types.Datum, gCols []*table.Column) error {
 	for i, gCol := range gCols {
 		colIdx := gCol.ColumnInfo.Offset
 		val, err := e.GenExprs[i].Eval(chunk.MutRowFromDatums(row).ToRow())
 		if e.ctx.GetSessionVars().StmtCtx.HandleTruncate(err) != nil {
-			return nil, err
+			return err
 		}
 		row[colIdx], err = table.CastValue(e.ctx, val, gCol.ToInfo(), false, false)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		// Handle the bad null error.
 		if err = gCol.HandleBadNull(&row[colIdx], e.ctx.GetSessionVars().StmtCtx); err != nil {
-			return nil, err
+			return err
 		}
 	}
-	return row, nil
+	return nil
+}
+
+// runBeforeInsertTriggers runs the BEFORE INSERT triggers for the rows right
+// before they're added, and evaluates the generated columns after the triggers.
+// The rows are added one by one if the table has BEFORE INSERT triggers, so the
+// triggers can see the rows added before.
+func (e *InsertValues) runBeforeInsertTriggers(ctx context.Context, rows [][]types.Datum) error {
+	if len(e.Table.Meta().Triggers) == 0 {
+		return nil
+	}
+	gCols := make([]*table.Column, 0)
+	for _, c := range e.Table.Cols() {
+		if c.IsGenerated() {
+			gCols = append(gCols, c)
+		}
+	}
+	for _, row := range rows {
+		// The rows failed to be loaded by LOAD DATA are nil.
+		if row == nil {
+			continue
+		}
+		if err := runTriggers(ctx, e.ctx, e.Table, model.TriggerBefore, model.TriggerInsert, nil, row); err != nil {
+			return err
+		}
+		if err := e.evalGeneratedColumns(row, gCols); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // isAutoNull can help judge whether a datum is AutoIncrement Null quickly.
@@ -1193,6 +1259,22 @@ func (e *InsertValues) addRecord(ctx context.Context, row []types.Datum) error {
 
 func (e *InsertValues) addRecordWithAutoIDHint(ctx context.Context, row []types.Datum, reserveAutoIDCount int) (err error) {
 	vars := e.ctx.GetSessionVars()
+	if err = table.CheckRowConstraint(e.ctx, e.constraints, row); err != nil {
+		if vars.StmtCtx.DupKeyAsWarning && table.ErrCheckConstraintViolated.Equal(err) {
+			vars.StmtCtx.AppendWarning(err)
+			return nil
+		}
+		return err
+	}
+	if e.fkChecker != nil {
+		if err = e.fkChecker.checkRowReferTo(ctx, e.Table, row, nil); err != nil {
+			if vars.StmtCtx.DupKeyAsWarning && isForeignKeyError(err) {
+				vars.StmtCtx.AppendWarning(err)
+				return nil
+			}
+			return err
+		}
+	}
 	if !vars.ConstraintCheckInPlace {
 		vars.PresumeKeyNotExists = true
 	}
@@ -1209,7 +1291,7 @@ func (e *InsertValues) addRecordWithAutoIDHint(ctx context.Context, row []types.
 	if e.lastInsertID != 0 {
 		vars.SetLastInsertID(e.lastInsertID)
 	}
-	return nil
+	return runTriggers(ctx, e.ctx, e.Table, model.TriggerAfter, model.TriggerInsert, nil, row)
 }
 
 // InsertRuntimeStat record the stat about insert and check
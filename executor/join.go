@@ -89,6 +89,15 @@ type HashJoinExec struct {
 	prepared    bool
 	isOuterJoin bool
 
+	// spillState indicates whether the hash join accepts a spill request now,
+	// it's only used in the grace hash join mode.
+	spillState  uint32
+	spillAction *HashJoinSpillDiskAction
+	// spillHelper is not nil after the build side has been partitioned into disk.
+	spillHelper *hashJoinSpillHelper
+	// probeSideSpilled indicates the probe side has been partitioned into disk as well.
+	probeSideSpilled bool
+
 	// joinWorkerWaitGroup is for sync multiple join workers.
 	joinWorkerWaitGroup sync.WaitGroup
 	finished            atomic.Value
@@ -146,6 +155,10 @@ func (e *HashJoinExec) Close() error {
 		e.probeChkResourceCh = nil
 		e.joinChkResourceCh = nil
 		terror.Call(e.rowContainer.Close)
+		if e.spillHelper != nil {
+			e.spillHelper.close()
+			e.spillHelper = nil
+		}
 	}
 	e.outerMatchedStatus = e.outerMatchedStatus[:0]
 
@@ -162,6 +175,8 @@ func (e *HashJoinExec) Open(ctx context.Context) error {
 		return err
 	}
 	e.prepared = false
+	atomic.StoreUint32(&e.spillState, hashJoinSpillDisabled)
+	e.spillHelper, e.probeSideSpilled = nil, false
 	e.memTracker = memory.NewTracker(e.id, -1)
 	e.memTracker.AttachTo(e.ctx.GetSessionVars().StmtCtx.MemTracker)
 
@@ -236,6 +251,10 @@ func (e *HashJoinExec) fetchProbeSideChunks(ctx context.Context) {
 			} else if emptyBuild {
 				return
 			}
+			if e.spillHelper != nil {
+				e.partitionProbeSideChunks(ctx, probeSideResult)
+				return
+			}
 			hasWaitedForBuild = true
 		}
 
@@ -256,7 +275,7 @@ func (e *HashJoinExec) wait4BuildSide() (emptyBuild bool, err error) {
 			return false, err
 		}
 	}
-	if e.rowContainer.Len() == uint64(0) && (e.joinType == plannercore.InnerJoin || e.joinType == plannercore.SemiJoin) {
+	if e.spillHelper == nil && e.rowContainer.Len() == uint64(0) && (e.joinType == plannercore.InnerJoin || e.joinType == plannercore.SemiJoin) {
 		return true, nil
 	}
 	return false, nil
@@ -409,7 +428,12 @@ func (e *HashJoinExec) handleUnmatchedRowsFromHashTable(workerID uint) {
 
 func (e *HashJoinExec) waitJoinWorkersAndCloseResultChan() {
 	e.joinWorkerWaitGroup.Wait()
-	if e.useOuterToBuild {
+	if e.probeSideSpilled {
+		// Join the partitions of both sides after all the rows have been partitioned into disk.
+		e.joinWorkerWaitGroup.Add(1)
+		go util.WithRecovery(e.joinSpilledPartitions, e.handleJoinWorkerPanic)
+		e.joinWorkerWaitGroup.Wait()
+	} else if e.useOuterToBuild {
 		// Concurrently handling unmatched rows from the hash table at the tail
 		for i := uint(0); i < e.concurrency; i++ {
 			var workerID = i
@@ -765,7 +789,11 @@ func (e *HashJoinExec) buildHashTableForList(buildSideResultCh <-chan *chunk.Chu
 	e.rowContainer.GetMemTracker().SetLabel(memory.LabelForBuildSideResult)
 	e.rowContainer.GetDiskTracker().AttachTo(e.diskTracker)
 	e.rowContainer.GetDiskTracker().SetLabel(memory.LabelForBuildSideResult)
-	if config.GetGlobalConfig().OOMUseTmpStorage {
+	if config.GetGlobalConfig().OOMUseTmpStorage && e.ctx.GetSessionVars().EnableGraceHashJoin {
+		atomic.StoreUint32(&e.spillState, hashJoinSpillArmed)
+		defer atomic.StoreUint32(&e.spillState, hashJoinSpillDisabled)
+		e.ctx.GetSessionVars().StmtCtx.MemTracker.FallbackOldAndSetNewAction(e.ActionSpill())
+	} else if config.GetGlobalConfig().OOMUseTmpStorage {
 		actionSpill := e.rowContainer.ActionSpill()
 		failpoint.Inject("testRowContainerSpill", func(val failpoint.Value) {
 			if val.(bool) {
@@ -779,29 +807,44 @@ func (e *HashJoinExec) buildHashTableForList(buildSideResultCh <-chan *chunk.Chu
 		if e.finished.Load().(bool) {
 			return nil
 		}
-		if !e.useOuterToBuild {
-			err = e.rowContainer.PutChunk(chk, e.isNullEQ)
+		if e.spillHelper != nil {
+			err = e.spillHelper.build.add(chk)
 		} else {
-			var bitMap = bitmap.NewConcurrentBitmap(chk.NumRows())
-			e.outerMatchedStatus = append(e.outerMatchedStatus, bitMap)
-			e.memTracker.Consume(bitMap.BytesConsumed())
-			if len(e.outerFilter) == 0 {
-				err = e.rowContainer.PutChunk(chk, e.isNullEQ)
-			} else {
-				selected, err = expression.VectorizedFilter(e.ctx, e.outerFilter, chunk.NewIterator4Chunk(chk), selected)
-				if err != nil {
-					return err
-				}
-				err = e.rowContainer.PutChunkSelected(chk, selected, e.isNullEQ)
-			}
+			selected, err = e.putBuildSideChunk(e.rowContainer, chk, selected)
 		}
 		if err != nil {
 			return err
 		}
+		if e.spillHelper == nil && atomic.LoadUint32(&e.spillState) == hashJoinSpillRequested {
+			if err = e.spillBuildSide(); err != nil {
+				return err
+			}
+		}
+	}
+	if e.spillHelper != nil {
+		return e.spillHelper.build.finish()
 	}
 	return nil
 }
 
+// putBuildSideChunk puts a build side chunk into rowContainer and builds the hash table for it.
+func (e *HashJoinExec) putBuildSideChunk(rowContainer *hashRowContainer, chk *chunk.Chunk, selected []bool) (_ []bool, err error) {
+	if !e.useOuterToBuild {
+		return selected, rowContainer.PutChunk(chk, e.isNullEQ)
+	}
+	var bitMap = bitmap.NewConcurrentBitmap(chk.NumRows())
+	e.outerMatchedStatus = append(e.outerMatchedStatus, bitMap)
+	e.memTracker.Consume(bitMap.BytesConsumed())
+	if len(e.outerFilter) == 0 {
+		return selected, rowContainer.PutChunk(chk, e.isNullEQ)
+	}
+	selected, err = expression.VectorizedFilter(e.ctx, e.outerFilter, chunk.NewIterator4Chunk(chk), selected)
+	if err != nil {
+		return selected, err
+	}
+	return selected, rowContainer.PutChunkSelected(chk, selected, e.isNullEQ)
+}
+
 // NestedLoopApplyExec is the executor for apply.
 type NestedLoopApplyExec struct {
 	baseExecutor
@@ -87,6 +87,67 @@ func TestJoinInDisk(t *testing.T) {
 	result.Check(testkit.Rows("2 2 2 3"))
 }
 
+func TestGraceHashJoinInDisk(t *testing.T) {
+	origin := config.RestoreFunc()
+	defer origin()
+	config.UpdateGlobal(func(conf *config.Config) {
+		conf.OOMUseTmpStorage = true
+		conf.OOMAction = config.OOMActionLog
+	})
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2")
+	tk.MustExec("create table t1(a int, b int)")
+	tk.MustExec("create table t2(a int, b int)")
+	values1, values2 := make([]string, 0, 2000), make([]string, 0, 2000)
+	for i := 0; i < 2000; i++ {
+		values1 = append(values1, fmt.Sprintf("(%d, %d)", i%500, i))
+		if i%100 == 0 {
+			values2 = append(values2, fmt.Sprintf("(null, %d)", i))
+		} else {
+			values2 = append(values2, fmt.Sprintf("(%d, %d)", i%700, i))
+		}
+	}
+	tk.MustExec("insert into t1 values " + strings.Join(values1, ","))
+	tk.MustExec("insert into t2 values " + strings.Join(values2, ","))
+
+	sqls := []string{
+		"select /*+ TIDB_HJ(t1, t2) */ * from t1 join t2 on t1.a = t2.a",
+		"select /*+ TIDB_HJ(t1, t2) */ * from t1 left join t2 on t1.a = t2.a and t2.b > 1000",
+		"select /*+ TIDB_HJ(t1, t2) */ * from t1 right join t2 on t1.a = t2.a and t1.b < 1500",
+		"select /*+ TIDB_HJ(t1, t2) */ * from t1 left join t2 on t1.a <=> t2.a where t1.b > 100",
+		"select * from t2 where t2.a in (select a from t1 where t1.b > t2.b)",
+		"select * from t2 where t2.a not in (select a from t1)",
+		"select * from t2 where not exists (select 1 from t1 where t1.a = t2.a and t1.b < 1000)",
+		"select t2.a, t2.a in (select a from t1 where t1.b > 1000) from t2",
+		"select t2.a, t2.a not in (select a from t1 where t1.b > 1000) from t2",
+	}
+	check := func() {
+		results := make([][][]interface{}, 0, len(sqls))
+		tk.MustExec("set @@tidb_enable_grace_hash_join = 0")
+		tk.MustExec("set @@tidb_mem_quota_query = default")
+		for _, sql := range sqls {
+			results = append(results, tk.MustQuery(sql).Sort().Rows())
+		}
+		tk.MustExec("set @@tidb_enable_grace_hash_join = 1")
+		tk.MustExec("set @@tidb_mem_quota_query = 1")
+		for i, sql := range sqls {
+			tk.MustQuery(sql).Sort().Check(results[i])
+		}
+	}
+	check()
+	plannercore.ForceUseOuterBuild4Test = true
+	defer func() { plannercore.ForceUseOuterBuild4Test = false }()
+	check()
+
+	rows := tk.MustQuery("explain analyze select /*+ TIDB_HJ(t1, t2) */ * from t1 join t2 on t1.a = t2.a").Rows()
+	require.Contains(t, rows[0][0], "HashJoin")
+	require.NotEqual(t, "0 Bytes", rows[0][len(rows[0])-1])
+}
+
 func TestJoin2(t *testing.T) {
 	store, clean := testkit.CreateMockStore(t)
 	defer clean()
@@ -0,0 +1,219 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/cznic/mathutil"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+var _ Executor = &JSONTableExec{}
+
+// JSONTableExec generates rows from a JSON document for the JSON_TABLE table function.
+// All the rows are generated in Open, since the apply executor reopens it for every outer row.
+type JSONTableExec struct {
+	baseExecutor
+
+	expr expression.Expression
+	root *plannercore.JSONTablePath
+
+	// sc is used to convert the JSON values to the column types, the conversion errors are
+	// always returned so that they can be handled by the ON ERROR clauses.
+	sc     *stmtctx.StatementContext
+	row    []types.Datum
+	result *chunk.Chunk
+	cursor int
+}
+
+// Open implements the Executor Open interface.
+func (e *JSONTableExec) Open(ctx context.Context) error {
+	if err := e.baseExecutor.Open(ctx); err != nil {
+		return err
+	}
+	e.sc = &stmtctx.StatementContext{TimeZone: e.ctx.GetSessionVars().Location()}
+	e.row = make([]types.Datum, e.schema.Len())
+	if e.result == nil {
+		e.result = newFirstChunk(e)
+	} else {
+		e.result.Reset()
+	}
+	e.cursor = 0
+
+	doc, isNull, err := e.expr.EvalJSON(e.ctx, chunk.Row{})
+	if err != nil || isNull {
+		return err
+	}
+	return e.appendPathRows(e.root, doc.ExtractAll(e.root.Path))
+}
+
+// Next implements the Executor Next interface.
+func (e *JSONTableExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.GrowAndReset(e.maxChunkSize)
+	if e.cursor >= e.result.NumRows() {
+		return nil
+	}
+	numCurBatch := mathutil.Min(req.Capacity(), e.result.NumRows()-e.cursor)
+	req.Append(e.result, e.cursor, e.cursor+numCurBatch)
+	e.cursor += numCurBatch
+	return nil
+}
+
+// appendPathRows appends the rows generated by the values matched by the row path.
+func (e *JSONTableExec) appendPathRows(path *plannercore.JSONTablePath, matches []json.BinaryJSON) error {
+	for i, val := range matches {
+		for _, col := range path.Columns {
+			if err := e.fillColumn(col, val, i+1); err != nil {
+				return err
+			}
+		}
+		if err := e.appendNestedRows(path.Nested, val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendNestedRows appends the rows of the sibling nested paths one after another. When the rows of
+// a nested path are generated, the columns of its siblings are NULL. If none of the nested paths
+// matches, a single row whose nested columns are all NULL is appended.
+func (e *JSONTableExec) appendNestedRows(nested []*plannercore.JSONTablePath, val json.BinaryJSON) error {
+	for _, path := range nested {
+		e.setPathNull(path)
+	}
+	matched := false
+	for _, path := range nested {
+		matches := val.ExtractAll(path.Path)
+		if len(matches) == 0 {
+			continue
+		}
+		matched = true
+		if err := e.appendPathRows(path, matches); err != nil {
+			return err
+		}
+		e.setPathNull(path)
+	}
+	if !matched {
+		for i := range e.row {
+			e.result.AppendDatum(i, &e.row[i])
+		}
+	}
+	return nil
+}
+
+func (e *JSONTableExec) setPathNull(path *plannercore.JSONTablePath) {
+	for _, col := range path.Columns {
+		e.row[col.Offset].SetNull()
+	}
+	for _, nested := range path.Nested {
+		e.setPathNull(nested)
+	}
+}
+
+func (e *JSONTableExec) fillColumn(col *plannercore.JSONTableColumn, val json.BinaryJSON, ordinality int) error {
+	ft := e.schema.Columns[col.Offset].RetType
+	switch col.Type {
+	case ast.JSONTableColumnOrdinality:
+		e.row[col.Offset].SetUint64(uint64(ordinality))
+		return nil
+	case ast.JSONTableColumnExists:
+		exists := types.NewIntDatum(0)
+		if len(val.ExtractAll(col.Path)) > 0 {
+			exists.SetInt64(1)
+		}
+		d, err := exists.ConvertTo(e.sc, ft)
+		if err != nil {
+			return err
+		}
+		e.row[col.Offset] = d
+		return nil
+	}
+
+	value, found := val.Extract([]json.PathExpression{col.Path})
+	if !found {
+		return e.fillResponse(col, col.OnEmpty, ErrMissingJSONTableValue.GenWithStackByArgs(col.Name.O))
+	}
+	d, err := e.convertJSONValue(col, value)
+	if err != nil {
+		return e.fillResponse(col, col.OnError, err)
+	}
+	e.row[col.Offset] = d
+	return nil
+}
+
+// fillResponse fills the column according to the ON EMPTY or the ON ERROR response.
+func (e *JSONTableExec) fillResponse(col *plannercore.JSONTableColumn, response plannercore.JSONTableOnResponse, err error) error {
+	switch response.Type {
+	case ast.JSONTableOnResponseError:
+		return err
+	case ast.JSONTableOnResponseDefault:
+		d, err := e.convertJSONValue(col, response.Default)
+		if err != nil {
+			return err
+		}
+		e.row[col.Offset] = d
+	default:
+		e.row[col.Offset].SetNull()
+	}
+	return nil
+}
+
+// convertJSONValue converts the JSON value to the type of the path column.
+func (e *JSONTableExec) convertJSONValue(col *plannercore.JSONTableColumn, value json.BinaryJSON) (types.Datum, error) {
+	ft := e.schema.Columns[col.Offset].RetType
+	if ft.Tp == mysql.TypeJSON {
+		return types.NewJSONDatum(value), nil
+	}
+	var d types.Datum
+	switch value.TypeCode {
+	case json.TypeCodeObject, json.TypeCodeArray:
+		return d, ErrWrongJSONTableValue.GenWithStackByArgs(col.Name.O)
+	case json.TypeCodeLiteral:
+		switch value.Value[0] {
+		case json.LiteralNil:
+			return d, nil
+		case json.LiteralTrue:
+			d.SetInt64(1)
+		default:
+			d.SetInt64(0)
+		}
+		if types.IsString(ft.Tp) {
+			d.SetString(value.String(), mysql.DefaultCollationName)
+		}
+	case json.TypeCodeInt64:
+		d.SetInt64(value.GetInt64())
+	case json.TypeCodeUint64:
+		d.SetUint64(value.GetUint64())
+	case json.TypeCodeFloat64:
+		d.SetFloat64(value.GetFloat64())
+	case json.TypeCodeString:
+		d.SetString(string(value.GetString()), mysql.DefaultCollationName)
+	default:
+		str, err := value.Unquote()
+		if err != nil {
+			return d, err
+		}
+		d.SetString(str, mysql.DefaultCollationName)
+	}
+	return d.ConvertTo(e.sc, ft)
+}
@@ -0,0 +1,88 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/testkit"
+)
+
+func TestJSONTable(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustQuery(`select * from json_table('[{"a": 1, "b": "x"}, {"a": 2}, {"b": "z"}]', '$[*]' columns (` +
+		`id for ordinality, a int path '$.a', b varchar(10) path '$.b', c json path '$')) as jt`).Check(testkit.Rows(
+		`1 1 x {"a": 1, "b": "x"}`,
+		`2 2 <nil> {"a": 2}`,
+		`3 <nil> z {"b": "z"}`))
+	tk.MustQuery(`select * from json_table(null, '$[*]' columns (a int path '$')) as jt`).Check(testkit.Rows())
+	tk.MustQuery(`select * from json_table('{"a": 1}', '$.b' columns (a int path '$')) as jt`).Check(testkit.Rows())
+
+	// EXISTS PATH.
+	tk.MustQuery(`select * from json_table('[{"a": 1}, {"b": 2}]', '$[*]' columns (` +
+		`a int exists path '$.a', b varchar(5) exists path '$.b')) as jt`).Check(testkit.Rows("1 0", "0 1"))
+
+	// ON EMPTY and ON ERROR.
+	tk.MustQuery(`select * from json_table('[{"a": 1}, {"a": [1, 2]}, {"a": "x"}, {}]', '$[*]' columns (` +
+		`a int path '$.a' default '10' on empty default '20' on error)) as jt`).Check(testkit.Rows("1", "20", "20", "10"))
+	tk.MustQuery(`select * from json_table('[{"a": [1, 2]}, {}]', '$[*]' columns (` +
+		`a int path '$.a' null on empty null on error, b json path '$.a')) as jt`).Check(testkit.Rows("<nil> [1, 2]", "<nil> <nil>"))
+	tk.MustGetErrCode(`select * from json_table('[{}]', '$[*]' columns (a int path '$.a' error on empty)) as jt`,
+		errno.ErrMissingJSONTableValue)
+	tk.MustGetErrCode(`select * from json_table('[{"a": {"b": 1}}]', '$[*]' columns (a int path '$.a' error on error)) as jt`,
+		errno.ErrWrongJSONTableValue)
+	tk.MustGetErrCode(`select * from json_table('[{"a": "x"}]', '$[*]' columns (a int path '$.a' error on error)) as jt`,
+		errno.ErrTruncatedWrongValue)
+
+	// NESTED PATH.
+	doc := `'[{"a": 1, "b": [11, 12], "c": ["x"]}, {"a": 2, "b": [], "c": []}, {"a": 3, "c": ["y", "z"]}]'`
+	tk.MustQuery(`select * from json_table(` + doc + `, '$[*]' columns (id for ordinality, a int path '$.a', ` +
+		`nested path '$.b[*]' columns (bid for ordinality, b int path '$'), ` +
+		`nested path '$.c[*]' columns (c varchar(5) path '$'))) as jt`).Check(testkit.Rows(
+		"1 1 1 11 <nil>",
+		"1 1 2 12 <nil>",
+		"1 1 <nil> <nil> x",
+		"2 2 <nil> <nil> <nil>",
+		"3 3 <nil> <nil> y",
+		"3 3 <nil> <nil> z"))
+	tk.MustQuery(`select * from json_table('{"a": [{"b": [1, 2]}, {"b": [3]}]}', '$' columns (` +
+		`nested path '$.a[*]' columns (x for ordinality, nested path '$.b[*]' columns (b int path '$')))) as jt`).Check(
+		testkit.Rows("1 1", "1 2", "2 3"))
+
+	// The document refers to the columns of the tables on the left.
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (id int, j json)")
+	tk.MustExec(`insert into t values (1, '[1, 2]'), (2, '[]'), (3, '[3]'), (4, null)`)
+	tk.MustQuery("select t.id, jt.v from t, json_table(t.j, '$[*]' columns (v int path '$')) as jt order by t.id, jt.v").Check(
+		testkit.Rows("1 1", "1 2", "3 3"))
+	tk.MustQuery("select t.id, jt.v from t join json_table(t.j, '$[*]' columns (v int path '$')) as jt on jt.v > 1 order by t.id, jt.v").Check(
+		testkit.Rows("1 2", "3 3"))
+	tk.MustQuery("select t.id, jt.v from t left join json_table(t.j, '$[*]' columns (v int path '$')) as jt on true order by t.id, jt.v").Check(
+		testkit.Rows("1 1", "1 2", "2 <nil>", "3 3", "4 <nil>"))
+	tk.MustQuery("select id, (select sum(v) from json_table(t.j, '$[*]' columns (v int path '$')) as jt) from t order by id").Check(
+		testkit.Rows("1 3", "2 <nil>", "3 3", "4 <nil>"))
+	tk.MustGetErrCode("select * from json_table(t.j, '$[*]' columns (v int path '$')) as jt right join t on true",
+		errno.ErrBadField)
+	tk.MustGetErrCode("select * from t right join json_table(t.j, '$[*]' columns (v int path '$')) as jt on true",
+		errno.ErrTFForbiddenJoinType)
+	tk.MustGetErrCode("select * from json_table('[]', '$[*]' columns (a int path '$', a int path '$')) as jt",
+		errno.ErrDupFieldName)
+}
@@ -565,9 +565,22 @@ func (e *LoadDataInfo) CheckAndInsertOneBatch(ctx context.Context, rows [][]type
 		replace = true
 	}
 
-	err = e.batchCheckAndInsert(ctx, rows[0:cnt], e.addRecordLD, replace)
-	if err != nil {
-		return err
+	// The rows are added one by one if the table has BEFORE INSERT triggers.
+	step := cnt
+	if hasTriggers(e.Table, model.TriggerBefore, model.TriggerInsert) {
+		step = 1
+	}
+	for i := uint64(0); i < cnt; i += step {
+		end := i + step
+		if end > cnt {
+			end = cnt
+		}
+		if err = e.runBeforeInsertTriggers(ctx, rows[i:end]); err != nil {
+			return err
+		}
+		if err = e.batchCheckAndInsert(ctx, rows[i:end], e.addRecordLD, replace); err != nil {
+			return err
+		}
 	}
 	return err
 }
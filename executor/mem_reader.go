@@ -388,7 +388,16 @@ func iterTxnMemBuffer(ctx sessionctx.Context, cacheTable kv.MemBuffer, kvRanges
 	}
 
 	for _, rg := range kvRanges {
-		iter := txn.GetMemBuffer().SnapshotIter(rg.StartKey, rg.EndKey)
+		var iter kv.Iterator
+		if ctx.GetSessionVars().NestedStmtLevel > 0 {
+			// A nested statement of a trigger or a stored function reads the changes
+			// made by the statement before it.
+			if iter, err = txn.GetMemBuffer().Iter(rg.StartKey, rg.EndKey); err != nil {
+				return err
+			}
+		} else {
+			iter = txn.GetMemBuffer().SnapshotIter(rg.StartKey, rg.EndKey)
+		}
 		snapCacheIter, err := getSnapIter(ctx, cacheTable, rg)
 		if err != nil {
 			return err
@@ -22,17 +22,14 @@ import (
 	"github.com/pingcap/tidb/executor/aggfuncs"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/disk"
+	"github.com/pingcap/tidb/util/memory"
 )
 
-type dataInfo struct {
-	chk         *chunk.Chunk
-	remaining   uint64
-	accumulated uint64
-}
-
 // PipelinedWindowExec is the executor for window functions.
 type PipelinedWindowExec struct {
 	baseExecutor
@@ -44,17 +41,16 @@ type PipelinedWindowExec struct {
 	end                *core.FrameBound
 	groupChecker       *vecGroupChecker
 
-	// childResult stores the child chunk. Note that even if remaining is 0, e.rows might still references rows in data[0].chk after returned it to upper executor, since there is no guarantee what the upper executor will do to the returned chunk, it might destroy the data (as in the benchmark test, it reused the chunk to pull data, and it will be chk.Reset(), causing panicking). So dataIdx, accumulated and dropped are added to ensure that chunk will only be returned if there is no row reference.
+	// childResult stores the child chunk, the rows in it are copied into partition,
+	// so it can be reused to fetch the next chunk.
 	childResult *chunk.Chunk
-	data        []dataInfo
-	dataIdx     int
+	// partition buffers the rows of the current partition which are not dropped yet, it can spill to disk.
+	partition *windowPartitionRows
+	// inputColIdxs are the indices of the child columns returned along with the window functions.
+	inputColIdxs []int
 
 	// done indicates the child executor is drained or something unexpected happened.
-	done         bool
-	accumulated  uint64
-	dropped      uint64
-	rowToConsume uint64
-	newPartition bool
+	done bool
 
 	curRowIdx uint64
 	// curStartRow and curEndRow defines the current frame range
@@ -62,194 +58,137 @@ type PipelinedWindowExec struct {
 	lastEndRow     uint64
 	stagedStartRow uint64
 	stagedEndRow   uint64
-	rowStart       uint64
 	orderByCols    []*expression.Column
 	// expectedCmpResult is used to decide if one value is included in the frame.
 	expectedCmpResult int64
 
-	// rows keeps rows starting from curStartRow
-	rows                     []chunk.Row
 	rowCnt                   uint64
 	whole                    bool
 	isRangeFrame             bool
 	emptyFrame               bool
 	initializedSlidingWindow bool
+
+	memTracker  *memory.Tracker
+	diskTracker *disk.Tracker
 }
 
 // Close implements the Executor Close interface.
 func (e *PipelinedWindowExec) Close() error {
+	if e.partition != nil {
+		terror.Call(e.partition.close)
+		e.partition = nil
+	}
+	e.childResult = nil
 	return errors.Trace(e.baseExecutor.Close())
 }
 
 // Open implements the Executor Open interface
 func (e *PipelinedWindowExec) Open(ctx context.Context) (err error) {
-	e.rowToConsume = 0
 	e.done = false
-	e.accumulated = 0
-	e.dropped = 0
-	e.data = make([]dataInfo, 0)
-	e.dataIdx = 0
 	e.slidingWindowFuncs = make([]aggfuncs.SlidingWindowAggFunc, len(e.windowFuncs))
 	for i, windowFunc := range e.windowFuncs {
 		if slidingWindowAggFunc, ok := windowFunc.(aggfuncs.SlidingWindowAggFunc); ok {
 			e.slidingWindowFuncs[i] = slidingWindowAggFunc
 		}
 	}
-	e.rows = make([]chunk.Row, 0)
-	return e.baseExecutor.Open(ctx)
-}
-
-func (e *PipelinedWindowExec) firstResultChunkNotReady() bool {
-	if !e.done && len(e.data) == 0 {
-		return true
+	if err = e.baseExecutor.Open(ctx); err != nil {
+		return err
 	}
-	// chunk can't be ready unless, 1. all of the rows in the chunk is filled, 2. e.rows doesn't contain rows in the chunk
-	return len(e.data) > 0 && (e.data[0].remaining != 0 || e.data[0].accumulated > e.dropped)
+	e.groupChecker.reset()
+	e.memTracker = memory.NewTracker(e.id, -1)
+	e.memTracker.AttachTo(e.ctx.GetSessionVars().StmtCtx.MemTracker)
+	e.diskTracker = disk.NewTracker(e.id, -1)
+	e.diskTracker.AttachTo(e.ctx.GetSessionVars().StmtCtx.DiskTracker)
+	e.partition = newWindowPartitionRows(e.ctx, retTypes(e.children[0]), e.maxChunkSize, e.memTracker, e.diskTracker)
+	e.inputColIdxs = windowInputColIdxs(e.Schema(), e.numWindowFuncs)
+	return e.reset()
 }
 
 // Next implements the Executor Next interface.
 func (e *PipelinedWindowExec) Next(ctx context.Context, chk *chunk.Chunk) (err error) {
 	chk.Reset()
 
-	for e.firstResultChunkNotReady() {
-		// we firstly gathering enough rows and consume them, until we are able to produce.
-		// for unbounded frame, it needs consume the whole partition before being able to produce, in this case
-		// e.p.enoughToProduce will be false until so.
+	for !chk.IsFull() {
+		// we firstly gathering enough rows, until we are able to produce.
+		// for unbounded frame, it needs the whole partition before being able to produce, in this case
+		// e.enoughToProduce will be false until so.
 		var enough bool
 		enough, err = e.enoughToProduce(e.ctx)
 		if err != nil {
-			return
+			return err
 		}
-		if !enough {
-			if !e.done && e.rowToConsume == 0 {
-				err = e.getRowsInPartition(ctx)
-				if err != nil {
-					return err
-				}
-			}
-			if e.done || e.newPartition {
-				e.finish()
-				// if we continued, the rows will not be consumed, so next time we should consume it instead of calling e.getRowsInPartition
-				enough, err = e.enoughToProduce(e.ctx)
-				if err != nil {
-					return
-				}
-				if enough {
-					continue
-				}
-				e.newPartition = false
-				e.reset()
-				if e.rowToConsume == 0 {
-					// no more data
-					break
-				}
+		if enough {
+			if _, err = e.produce(e.ctx, chk, uint64(chk.RequiredRows()-chk.NumRows())); err != nil {
+				return err
 			}
-			e.rowCnt += e.rowToConsume
-			e.rowToConsume = 0
+			continue
 		}
-
-		// e.p is ready to produce data
-		if len(e.data) > e.dataIdx && e.data[e.dataIdx].remaining != 0 {
-			produced, err := e.produce(e.ctx, e.data[e.dataIdx].chk, e.data[e.dataIdx].remaining)
-			if err != nil {
+		if !e.whole {
+			if err = e.fetchRowsInPartition(ctx); err != nil {
 				return err
 			}
-			e.data[e.dataIdx].remaining -= produced
-			if e.data[e.dataIdx].remaining == 0 {
-				e.dataIdx++
-			}
+			continue
+		}
+		// the whole partition is produced
+		if e.done {
+			break
+		}
+		if err = e.reset(); err != nil {
+			return err
 		}
-	}
-	if len(e.data) > 0 {
-		chk.SwapColumns(e.data[0].chk)
-		e.data = e.data[1:]
-		e.dataIdx--
 	}
 	return nil
 }
 
-func (e *PipelinedWindowExec) getRowsInPartition(ctx context.Context) (err error) {
-	e.newPartition = true
-	if len(e.rows) == 0 {
-		// if getRowsInPartition is called for the first time, we ignore it as a new partition
-		e.newPartition = false
-	}
-
+// fetchRowsInPartition appends the next group of rows in the current partition
+// into e.partition, or marks the partition as whole if there are no more rows in it.
+func (e *PipelinedWindowExec) fetchRowsInPartition(ctx context.Context) (err error) {
 	if e.groupChecker.isExhausted() {
 		var drained, samePartition bool
 		drained, err = e.fetchChild(ctx)
 		if err != nil {
 			return errors.Trace(err)
 		}
-		// we return immediately to use a combination of true newPartition but 0 in e.rowToConsume to indicate the data source is drained,
 		if drained {
 			e.done = true
+			e.whole = true
 			return nil
 		}
 		samePartition, err = e.groupChecker.splitIntoGroups(e.childResult)
-		if samePartition {
-			// the only case that when getRowsInPartition gets called, it is not a new partition.
-			e.newPartition = false
-		}
 		if err != nil {
 			return errors.Trace(err)
 		}
+		if !samePartition && e.rowCnt > 0 {
+			e.whole = true
+			return nil
+		}
+	} else if e.rowCnt > 0 {
+		// the remaining groups in childResult belong to the next partitions.
+		e.whole = true
+		return nil
 	}
 	begin, end := e.groupChecker.getNextGroup()
-	e.rowToConsume += uint64(end - begin)
-	for i := begin; i < end; i++ {
-		e.rows = append(e.rows, e.childResult.GetRow(i))
+	if err = e.partition.appendRows(e.childResult, begin, end); err != nil {
+		return err
 	}
-	return
+	e.rowCnt = e.partition.Len()
+	return nil
 }
 
 func (e *PipelinedWindowExec) fetchChild(ctx context.Context) (EOF bool, err error) {
-	// TODO: reuse chunks
-	childResult := newFirstChunk(e.children[0])
-	err = Next(ctx, e.children[0], childResult)
+	if e.childResult == nil {
+		e.childResult = newFirstChunk(e.children[0])
+	}
+	err = Next(ctx, e.children[0], e.childResult)
 	if err != nil {
 		return false, errors.Trace(err)
 	}
 	// No more data.
-	numRows := childResult.NumRows()
-	if numRows == 0 {
-		return true, nil
-	}
-
-	// TODO: reuse chunks
-	resultChk := chunk.New(e.retFieldTypes, 0, numRows)
-	err = e.copyChk(childResult, resultChk)
-	if err != nil {
-		return false, err
-	}
-	e.accumulated += uint64(numRows)
-	e.data = append(e.data, dataInfo{chk: resultChk, remaining: uint64(numRows), accumulated: e.accumulated})
-
-	e.childResult = childResult
-	return false, nil
-}
-
-func (e *PipelinedWindowExec) copyChk(src, dst *chunk.Chunk) error {
-	columns := e.Schema().Columns[:len(e.Schema().Columns)-e.numWindowFuncs]
-	for i, col := range columns {
-		if err := dst.MakeRefTo(i, src, col.Index); err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (e *PipelinedWindowExec) getRow(i uint64) chunk.Row {
-	return e.rows[i-e.rowStart]
+	return e.childResult.NumRows() == 0, nil
 }
 
-func (e *PipelinedWindowExec) getRows(start, end uint64) []chunk.Row {
-	return e.rows[start-e.rowStart : end-e.rowStart]
-}
-
-// finish is called upon a whole partition is consumed
-func (e *PipelinedWindowExec) finish() {
-	e.whole = true
+func (e *PipelinedWindowExec) getRow(i uint64) (chunk.Row, error) {
+	return e.partition.getRow(i)
 }
 
 func (e *PipelinedWindowExec) getStart(ctx sessionctx.Context) (uint64, error) {
@@ -260,9 +199,16 @@ func (e *PipelinedWindowExec) getStart(ctx sessionctx.Context) (uint64, error) {
 		var start uint64
 		for start = mathutil.MaxUint64(e.lastStartRow, e.stagedStartRow); start < e.rowCnt; start++ {
 			var res int64
-			var err error
+			startRow, err := e.getRow(start)
+			if err != nil {
+				return 0, err
+			}
+			curRow, err := e.getRow(e.curRowIdx)
+			if err != nil {
+				return 0, err
+			}
 			for i := range e.orderByCols {
-				res, _, err = e.start.CmpFuncs[i](ctx, e.orderByCols[i], e.start.CalcFuncs[i], e.getRow(start), e.getRow(e.curRowIdx))
+				res, _, err = e.start.CmpFuncs[i](ctx, e.orderByCols[i], e.start.CalcFuncs[i], startRow, curRow)
 				if err != nil {
 					return 0, err
 				}
@@ -300,9 +246,16 @@ func (e *PipelinedWindowExec) getEnd(ctx sessionctx.Context) (uint64, error) {
 		var end uint64
 		for end = mathutil.MaxUint64(e.lastEndRow, e.stagedEndRow); end < e.rowCnt; end++ {
 			var res int64
-			var err error
+			curRow, err := e.getRow(e.curRowIdx)
+			if err != nil {
+				return 0, err
+			}
+			endRow, err := e.getRow(end)
+			if err != nil {
+				return 0, err
+			}
 			for i := range e.orderByCols {
-				res, _, err = e.end.CmpFuncs[i](ctx, e.end.CalcFuncs[i], e.orderByCols[i], e.getRow(e.curRowIdx), e.getRow(end))
+				res, _, err = e.end.CmpFuncs[i](ctx, e.end.CalcFuncs[i], e.orderByCols[i], curRow, endRow)
 				if err != nil {
 					return 0, err
 				}
@@ -362,6 +315,12 @@ func (e *PipelinedWindowExec) produce(ctx sessionctx.Context, chk *chunk.Chunk,
 		if start >= e.rowCnt {
 			start = e.rowCnt
 		}
+		var row chunk.Row
+		row, err = e.getRow(e.curRowIdx)
+		if err != nil {
+			return
+		}
+		chk.AppendPartialRowByColIdxs(0, row, e.inputColIdxs)
 		// if start >= end, we should return a default value, and we reset the frame to empty.
 		if start >= end {
 			for i, wf := range e.windowFuncs {
@@ -383,17 +342,14 @@ func (e *PipelinedWindowExec) produce(ctx sessionctx.Context, chk *chunk.Chunk,
 				slidingWindowAggFunc := e.slidingWindowFuncs[i]
 				if e.lastStartRow != start || e.lastEndRow != end {
 					if slidingWindowAggFunc != nil && e.initializedSlidingWindow {
-						err = slidingWindowAggFunc.Slide(ctx, e.getRow, e.lastStartRow, e.lastEndRow, start-e.lastStartRow, end-e.lastEndRow, e.partialResults[i])
-					} else {
-						// For MinMaxSlidingWindowAggFuncs, it needs the absolute value of each start of window, to compare
-						// whether elements inside deque are out of current window.
-						if minMaxSlidingWindowAggFunc, ok := wf.(aggfuncs.MaxMinSlidingWindowAggFunc); ok {
-							// Store start inside MaxMinSlidingWindowAggFunc.windowInfo
-							minMaxSlidingWindowAggFunc.SetWindowStart(start)
+						err = slidingWindowAggFunc.Slide(ctx, e.partition.mustGetRow, e.lastStartRow, e.lastEndRow, start-e.lastStartRow, end-e.lastEndRow, e.partialResults[i])
+						if err == nil {
+							err = e.partition.takeErr()
 						}
+					} else {
 						// TODO(zhifeng): track memory usage here
 						wf.ResetPartialResult(e.partialResults[i])
-						_, err = wf.UpdatePartialResult(ctx, e.getRows(start, end), e.partialResults[i])
+						err = e.partition.updatePartialResult(ctx, wf, start, end, e.partialResults[i])
 					}
 				}
 				if err != nil {
@@ -412,13 +368,8 @@ func (e *PipelinedWindowExec) produce(ctx sessionctx.Context, chk *chunk.Chunk,
 		produced++
 		remained--
 	}
-	extend := mathutil.MinUint64Val(e.curRowIdx, e.lastEndRow, e.lastStartRow)
-	if extend > e.rowStart {
-		numDrop := extend - e.rowStart
-		e.dropped += numDrop
-		e.rows = e.rows[numDrop:]
-		e.rowStart = extend
-	}
+	// the rows before the current frame and the current row are not needed any more.
+	err = e.partition.dropBefore(mathutil.MinUint64Val(e.curRowIdx, e.lastEndRow, e.lastStartRow))
 	return
 }
 
@@ -440,8 +391,8 @@ func (e *PipelinedWindowExec) enoughToProduce(ctx sessionctx.Context) (enough bo
 	return end < e.rowCnt && start < e.rowCnt, nil
 }
 
-// reset resets the processor
-func (e *PipelinedWindowExec) reset() {
+// reset resets the processor and the buffered rows to process the next partition.
+func (e *PipelinedWindowExec) reset() error {
 	e.lastStartRow = 0
 	e.lastEndRow = 0
 	e.stagedStartRow = 0
@@ -449,13 +400,10 @@ func (e *PipelinedWindowExec) reset() {
 	e.emptyFrame = false
 	e.curRowIdx = 0
 	e.whole = false
-	numDrop := e.rowCnt - e.rowStart
-	e.dropped += numDrop
-	e.rows = e.rows[numDrop:]
-	e.rowStart = 0
 	e.rowCnt = 0
 	e.initializedSlidingWindow = false
 	for i, windowFunc := range e.windowFuncs {
 		windowFunc.ResetPartialResult(e.partialResults[i])
 	}
+	return e.partition.reset()
 }
@@ -247,6 +247,7 @@ func (e *PrepareExec) Next(ctx context.Context, req *chunk.Chunk) error {
 		StmtDB:              e.ctx.GetSessionVars().CurrentDB,
 		StmtText:            stmt.Text(),
 		VisitInfos:          destBuilder.GetVisitInfo(),
+		RoutineVisitInfos:   destBuilder.GetRoutineVisitInfo(),
 		NormalizedSQL:       normalizedSQL,
 		SQLDigest:           digest,
 		ForUpdateRead:       destBuilder.GetIsForUpdateRead(),
@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta/autoid"
+	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/tablecodec"
@@ -85,12 +86,20 @@ func (e *ReplaceExec) removeRow(ctx context.Context, txn kv.Transaction, handle
 		return true, nil
 	}
 
+	if e.fkChecker != nil {
+		if err = e.fkChecker.onDeleteRow(ctx, r.t, handle, oldRow); err != nil {
+			return false, err
+		}
+	}
+	if err = runTriggers(ctx, e.ctx, r.t, model.TriggerBefore, model.TriggerDelete, oldRow, nil); err != nil {
+		return false, err
+	}
 	err = r.t.RemoveRecord(e.ctx, handle, oldRow)
 	if err != nil {
 		return false, err
 	}
 	e.ctx.GetSessionVars().StmtCtx.AddAffectedRows(1)
-	return false, nil
+	return false, runTriggers(ctx, e.ctx, r.t, model.TriggerAfter, model.TriggerDelete, oldRow, nil)
 }
 
 // EqualDatumsAsBinary compare if a and b contains the same datum values in binary collation.
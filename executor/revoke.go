@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/tidb/domain"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/privilege"
@@ -148,6 +149,27 @@ func (e *RevokeExec) revokeOneUser(internalSession sessionctx.Context, user, hos
 	// DB scope:		mysql.DB
 	// Table scope:		mysql.Tables_priv
 	// Column scope:	mysql.Columns_priv
+	// Routine scope:	mysql.procs_priv
+	if routineType, ok := routineTypeOfObject(e.ObjectType); ok {
+		if err := checkRoutinePrivs(e.Level, e.Privs); err != nil {
+			return err
+		}
+		// Allow REVOKE on non-existent routine, like tables.
+		routineName, _ := getTargetRoutineName(e.is, dbName, e.Level.TableName, routineType)
+		ok, err := procsPrivEntryExists(internalSession, user, host, dbName, routineName, routineType)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.Errorf("There is no such grant defined for user '%s' on host '%s' on routine %s.%s", user, host, dbName, e.Level.TableName)
+		}
+		for _, priv := range e.Privs {
+			if err := e.revokeRoutinePriv(internalSession, priv, user, host, dbName, routineName, routineType); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 	switch e.Level.Level {
 	case ast.GrantLevelDB:
 		ok, err := dbUserExists(internalSession, user, host, dbName)
@@ -263,6 +285,31 @@ func (e *RevokeExec) revokeTablePriv(internalSession sessionctx.Context, priv *a
 	return err
 }
 
+func (e *RevokeExec) revokeRoutinePriv(internalSession sessionctx.Context, priv *ast.PrivElem, user, host, dbName, routineName string, routineType model.RoutineType) error {
+	if priv.Priv == mysql.UsagePriv {
+		return nil
+	}
+	currProcPriv, err := getProcPriv(internalSession, user, host, dbName, routineName, routineType)
+	if err != nil {
+		return err
+	}
+	newProcPriv := SetFromString(currProcPriv)
+	if priv.Priv == mysql.AllPriv {
+		// Revoke ALL does not revoke the Grant option.
+		for _, p := range mysql.AllRoutinePrivs {
+			newProcPriv = deleteFromSet(newProcPriv, p.SetString())
+		}
+	} else {
+		newProcPriv, err = privUpdateForRevoke(newProcPriv, priv.Priv)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = internalSession.(sqlexec.SQLExecutor).ExecuteInternal(context.Background(), `UPDATE %n.%n SET Proc_priv=%?, Grantor=%? WHERE User=%? AND Host=%? AND DB=%? AND Routine_name=%? AND Routine_type=%?`,
+		mysql.SystemDB, mysql.ProcsPrivTable, setToString(newProcPriv), e.ctx.GetSessionVars().User.String(), user, host, dbName, routineName, routineType.String())
+	return err
+}
+
 func (e *RevokeExec) revokeColumnPriv(internalSession sessionctx.Context, priv *ast.PrivElem, user, host string) error {
 	dbName, tbl, err := getTargetSchemaAndTable(e.ctx, e.Level.DBName, e.Level.TableName, e.is)
 	if err != nil {
@@ -406,6 +406,21 @@ func TestSetVar(t *testing.T) {
 	tk.MustQuery(`select @@global.tidb_mem_quota_bind_cache`).Check(testkit.Rows("123"))
 	tk.MustQuery(`select @@global.tidb_mem_quota_bind_cache`).Check(testkit.Rows("123"))
 
+	// test for tidb_server_memory_limit and tidb_server_memory_limit_sess_min_size
+	tk.MustQuery(`select @@global.tidb_server_memory_limit`).Check(testkit.Rows("0"))
+	tk.MustExec(`set global tidb_server_memory_limit = 1073741824`)
+	tk.MustQuery(`select @@global.tidb_server_memory_limit`).Check(testkit.Rows("1073741824"))
+	require.Equal(t, uint64(1073741824), variable.ServerMemoryLimit.Load())
+	tk.MustExec(`set global tidb_server_memory_limit = 0`)
+	require.Equal(t, uint64(0), variable.ServerMemoryLimit.Load())
+	require.Error(t, tk.ExecToErr(`set tidb_server_memory_limit = 1`))
+	defVal = fmt.Sprintf("%v", variable.DefTiDBServerMemoryLimitSessMinSize)
+	tk.MustQuery(`select @@global.tidb_server_memory_limit_sess_min_size`).Check(testkit.Rows(defVal))
+	tk.MustExec(`set global tidb_server_memory_limit_sess_min_size = 1024`)
+	require.Equal(t, uint64(1024), variable.ServerMemoryLimitSessMinSize.Load())
+	tk.MustExec(`set global tidb_server_memory_limit_sess_min_size = default`)
+	tk.MustQuery(`select @@global.tidb_server_memory_limit_sess_min_size`).Check(testkit.Rows(defVal))
+
 	// test for tidb_enable_parallel_apply
 	tk.MustQuery(`select @@tidb_enable_parallel_apply`).Check(testkit.Rows("0"))
 	tk.MustExec(`set global tidb_enable_parallel_apply = 1`)
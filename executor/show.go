@@ -160,7 +160,15 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 	case ast.ShowIndex:
 		return e.fetchShowIndex()
 	case ast.ShowProcedureStatus:
-		return e.fetchShowProcedureStatus()
+		return e.fetchShowRoutineStatus(model.RoutineProcedure)
+	case ast.ShowFunctionStatus:
+		return e.fetchShowRoutineStatus(model.RoutineFunction)
+	case ast.ShowCreateProcedure:
+		return e.fetchShowCreateRoutine(model.RoutineProcedure)
+	case ast.ShowCreateFunction:
+		return e.fetchShowCreateRoutine(model.RoutineFunction)
+	case ast.ShowCreateTrigger:
+		return e.fetchShowCreateTrigger()
 	case ast.ShowPumpStatus:
 		return e.fetchShowPumpOrDrainerStatus(node.PumpNode)
 	case ast.ShowStatus:
@@ -1013,6 +1021,13 @@ func ConstructResultOfShowCreateTable(ctx sessionctx.Context, tableInfo *model.T
 
 	// Foreign Keys are supported by data dictionary even though
 	// they are not enforced by DDL. This is still helpful to applications.
+	// The referenced table is qualified by its schema if it's in another schema.
+	var dbName model.CIStr
+	if is, ok := ctx.GetInfoSchema().(infoschema.InfoSchema); ok && len(tableInfo.ForeignKeys) > 0 {
+		if dbInfo, ok := is.SchemaByTable(tableInfo); ok {
+			dbName = dbInfo.Name
+		}
+	}
 	for _, fk := range tableInfo.ForeignKeys {
 		buf.WriteString(fmt.Sprintf(",\n  CONSTRAINT %s FOREIGN KEY ", stringutil.Escape(fk.Name.O, sqlMode)))
 		colNames := make([]string, 0, len(fk.Cols))
@@ -1020,7 +1035,11 @@ func ConstructResultOfShowCreateTable(ctx sessionctx.Context, tableInfo *model.T
 			colNames = append(colNames, stringutil.Escape(col.O, sqlMode))
 		}
 		buf.WriteString(fmt.Sprintf("(%s)", strings.Join(colNames, ",")))
-		buf.WriteString(fmt.Sprintf(" REFERENCES %s ", stringutil.Escape(fk.RefTable.O, sqlMode)))
+		buf.WriteString(" REFERENCES ")
+		if refSchema := fk.GetRefSchema(dbName); refSchema.L != dbName.L {
+			buf.WriteString(stringutil.Escape(refSchema.O, sqlMode) + ".")
+		}
+		buf.WriteString(fmt.Sprintf("%s ", stringutil.Escape(fk.RefTable.O, sqlMode)))
 		refColNames := make([]string, 0, len(fk.Cols))
 		for _, refCol := range fk.RefCols {
 			refColNames = append(refColNames, stringutil.Escape(refCol.O, sqlMode))
@@ -1034,6 +1053,16 @@ func ConstructResultOfShowCreateTable(ctx sessionctx.Context, tableInfo *model.T
 		}
 	}
 
+	for _, constr := range tableInfo.Constraints {
+		if constr.State != model.StatePublic {
+			continue
+		}
+		buf.WriteString(fmt.Sprintf(",\n  CONSTRAINT %s CHECK ((%s))", stringutil.Escape(constr.Name.O, sqlMode), constr.ExprString))
+		if !constr.Enforced {
+			buf.WriteString(" /*!80016 NOT ENFORCED */")
+		}
+	}
+
 	buf.WriteString("\n")
 
 	buf.WriteString(") ENGINE=InnoDB")
@@ -1250,7 +1279,25 @@ func appendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer,
 	// include the /*!50100 or /*!50500 comments for TiDB.
 	// This also solves the issue with comments within comments that would happen for
 	// PLACEMENT POLICY options.
-	if partitionInfo.Type == model.PartitionTypeHash {
+	method := partitionInfo.Type.String()
+	if partitionInfo.IsLinear {
+		method = "LINEAR " + method
+	}
+	partitionExpr := partitionInfo.Expr
+	if partitionInfo.Type == model.PartitionTypeKey {
+		if partitionInfo.KeyAlgorithm != 0 {
+			method = fmt.Sprintf("%s ALGORITHM = %d", method, partitionInfo.KeyAlgorithm)
+		}
+		// `PARTITION BY KEY()` is shown as it is, not with the columns it resolves to.
+		cols := make([]string, 0, len(partitionInfo.Columns))
+		if !partitionInfo.IsEmptyColumns {
+			for _, col := range partitionInfo.Columns {
+				cols = append(cols, stringutil.Escape(col.O, sqlMode))
+			}
+		}
+		partitionExpr = strings.Join(cols, ",")
+	}
+	if partitionInfo.Type == model.PartitionTypeHash || partitionInfo.Type == model.PartitionTypeKey {
 		defaultPartitionDefinitions := true
 		for i, def := range partitionInfo.Definitions {
 			if def.Name.O != fmt.Sprintf("p%d", i) {
@@ -1264,12 +1311,12 @@ func appendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer,
 		}
 
 		if defaultPartitionDefinitions {
-			fmt.Fprintf(buf, "\nPARTITION BY HASH (%s) PARTITIONS %d", partitionInfo.Expr, partitionInfo.Num)
+			fmt.Fprintf(buf, "\nPARTITION BY %s (%s) PARTITIONS %d", method, partitionExpr, partitionInfo.Num)
 			return
 		}
 	}
 	// this if statement takes care of lists/range columns case
-	if partitionInfo.Columns != nil {
+	if partitionInfo.Columns != nil && partitionInfo.Type != model.PartitionTypeKey {
 		// partitionInfo.Type == model.PartitionTypeRange || partitionInfo.Type == model.PartitionTypeList
 		// Notice that MySQL uses two spaces between LIST and COLUMNS...
 		fmt.Fprintf(buf, "\nPARTITION BY %s COLUMNS(", partitionInfo.Type.String())
@@ -1281,7 +1328,7 @@ func appendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer,
 		}
 		buf.WriteString(")\n(")
 	} else {
-		fmt.Fprintf(buf, "\nPARTITION BY %s (%s)\n(", partitionInfo.Type.String(), partitionInfo.Expr)
+		fmt.Fprintf(buf, "\nPARTITION BY %s (%s)\n(", method, partitionExpr)
 	}
 
 	for i, def := range partitionInfo.Definitions {
@@ -1561,13 +1608,179 @@ func (e *ShowExec) fetchShowPrivileges() error {
 }
 
 func (e *ShowExec) fetchShowTriggers() error {
+	db, ok := e.is.SchemaByName(model.NewCIStr(e.DBName.O))
+	if !ok {
+		return ErrBadDB.GenWithStackByArgs(e.DBName)
+	}
+	tables := e.is.SchemaTables(db.Name)
+	sort.Slice(tables, func(i, j int) bool { return tables[i].Meta().Name.L < tables[j].Meta().Name.L })
+	for _, tbl := range tables {
+		tblInfo := tbl.Meta()
+		if len(tblInfo.Triggers) == 0 || !e.triggerVisible(db.Name.L, tblInfo.Name.L) {
+			continue
+		}
+		for _, trigger := range tblInfo.Triggers {
+			e.appendRow([]interface{}{
+				trigger.Name.O,
+				trigger.Event.String(),
+				tblInfo.Name.O,
+				trigger.Body,
+				trigger.Timing.String(),
+				types.NewTime(types.FromGoTime(trigger.Created.In(e.ctx.GetSessionVars().Location())), mysql.TypeDatetime, 2),
+				trigger.SQLMode,
+				trigger.Definer.String(),
+				trigger.Charset,
+				trigger.Collate,
+				db.Collate,
+			})
+		}
+	}
+	return nil
+}
+
+// triggerVisible checks whether the current user has the TRIGGER privilege on
+// the table of the trigger.
+func (e *ShowExec) triggerVisible(db, tbl string) bool {
+	checker := privilege.GetPrivilegeManager(e.ctx)
+	if checker == nil || e.ctx.GetSessionVars().User == nil {
+		return true
+	}
+	return checker.RequestVerification(e.ctx.GetSessionVars().ActiveRoles, db, tbl, "", mysql.TriggerPriv)
+}
+
+func (e *ShowExec) fetchShowCreateTrigger() error {
+	db, ok := e.is.SchemaByName(e.Table.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(e.Table.Schema.O)
+	}
+	tbl, trigger, ok := e.is.TriggerByName(e.Table.Schema, e.Table.Name)
+	if !ok || !e.triggerVisible(db.Name.L, tbl.Meta().Name.L) {
+		return infoschema.ErrTriggerNotExists.GenWithStackByArgs()
+	}
+	var buf bytes.Buffer
+	constructResultOfShowCreateTrigger(tbl.Meta(), trigger, &buf)
+	created := types.NewTime(types.FromGoTime(trigger.Created.In(e.ctx.GetSessionVars().Location())), mysql.TypeDatetime, 2)
+	e.appendRow([]interface{}{trigger.Name.O, trigger.SQLMode, buf.String(), trigger.Charset, trigger.Collate, db.Collate, created})
+	return nil
+}
+
+// constructResultOfShowCreateTrigger constructs the CREATE TRIGGER statement of the trigger.
+func constructResultOfShowCreateTrigger(tblInfo *model.TableInfo, trigger *model.TriggerInfo, buf *bytes.Buffer) {
+	sqlMode, _ := mysql.GetSQLMode(trigger.SQLMode)
+	buf.WriteString("CREATE ")
+	if trigger.Definer != nil {
+		fmt.Fprintf(buf, "DEFINER=%s@%s ", stringutil.Escape(trigger.Definer.Username, sqlMode), stringutil.Escape(trigger.Definer.Hostname, sqlMode))
+	}
+	fmt.Fprintf(buf, "TRIGGER %s %s %s ON %s FOR EACH ROW %s", stringutil.Escape(trigger.Name.O, sqlMode),
+		trigger.Timing.String(), trigger.Event.String(), stringutil.Escape(tblInfo.Name.O, sqlMode), trigger.Body)
+}
+
+func (e *ShowExec) fetchShowRoutineStatus(tp model.RoutineType) error {
+	dbs := e.is.AllSchemas()
+	sort.Slice(dbs, func(i, j int) bool { return dbs[i].Name.L < dbs[j].Name.L })
+	for _, db := range dbs {
+		routines := make([]*model.RoutineInfo, 0, len(db.Routines))
+		for _, routine := range db.Routines {
+			if routine.Type == tp && routineVisible(e.ctx, db.Name.L, routine) {
+				routines = append(routines, routine)
+			}
+		}
+		sort.Slice(routines, func(i, j int) bool { return routines[i].Name.L < routines[j].Name.L })
+		for _, routine := range routines {
+			created := types.NewTime(types.FromGoTime(routine.Created.In(e.ctx.GetSessionVars().Location())), mysql.TypeDatetime, 0)
+			e.appendRow([]interface{}{
+				db.Name.O,
+				routine.Name.O,
+				routine.Type.String(),
+				routine.Definer.String(),
+				created,
+				created,
+				strings.ToUpper(routine.Security.String()),
+				routine.Comment,
+				routine.Charset,
+				routine.Collate,
+				db.Collate,
+			})
+		}
+	}
 	return nil
 }
 
-func (e *ShowExec) fetchShowProcedureStatus() error {
+// routineVisible checks whether the current user is the definer of the routine or has any
+// privilege on it, like MySQL does.
+func routineVisible(sctx sessionctx.Context, db string, routine *model.RoutineInfo) bool {
+	checker := privilege.GetPrivilegeManager(sctx)
+	user := sctx.GetSessionVars().User
+	if checker == nil || user == nil || routine.Definer.String() == user.String() {
+		return true
+	}
+	activeRoles := sctx.GetSessionVars().ActiveRoles
+	for _, priv := range []mysql.PrivilegeType{mysql.CreateRoutinePriv, mysql.AlterRoutinePriv, mysql.ExecutePriv} {
+		if checker.RequestRoutineVerification(activeRoles, db, routine.Name.L, routine.Type, priv) {
+			return true
+		}
+	}
+	return checker.RequestVerification(activeRoles, "", "", "", mysql.SelectPriv)
+}
+
+func (e *ShowExec) fetchShowCreateRoutine(tp model.RoutineType) error {
+	db, ok := e.is.SchemaByName(e.Table.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(e.Table.Schema.O)
+	}
+	routine, ok := e.is.RoutineByName(e.Table.Schema, e.Table.Name, tp)
+	if !ok || !routineVisible(e.ctx, db.Name.L, routine) {
+		return infoschema.ErrRoutineNotExists.GenWithStackByArgs(tp.String(), e.Table.Name.O)
+	}
+	var buf bytes.Buffer
+	ConstructResultOfShowCreateRoutine(routine, &buf)
+	e.appendRow([]interface{}{routine.Name.O, routine.SQLMode, buf.String(), routine.Charset, routine.Collate, db.Collate})
 	return nil
 }
 
+// ConstructResultOfShowCreateRoutine constructs the result for show create procedure
+// and show create function.
+func ConstructResultOfShowCreateRoutine(routine *model.RoutineInfo, buf *bytes.Buffer) {
+	sqlMode, _ := mysql.GetSQLMode(routine.SQLMode)
+	typeString := func(tp *types.FieldType) string {
+		if types.IsString(tp.Tp) && tp.Charset != "" && tp.Charset != charset.CharsetBin {
+			return fmt.Sprintf("%s CHARSET %s", tp.InfoSchemaStr(), tp.Charset)
+		}
+		return tp.InfoSchemaStr()
+	}
+	buf.WriteString("CREATE ")
+	if routine.Definer != nil {
+		fmt.Fprintf(buf, "DEFINER=%s@%s ", stringutil.Escape(routine.Definer.Username, sqlMode), stringutil.Escape(routine.Definer.Hostname, sqlMode))
+	}
+	fmt.Fprintf(buf, "%s %s(", routine.Type.String(), stringutil.Escape(routine.Name.O, sqlMode))
+	for i, param := range routine.Params {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		if routine.Type == model.RoutineProcedure {
+			fmt.Fprintf(buf, "%s ", param.Mode.String())
+		}
+		fmt.Fprintf(buf, "%s %s", stringutil.Escape(param.Name.O, sqlMode), typeString(param.Tp))
+	}
+	buf.WriteString(")\n")
+	if routine.Type == model.RoutineFunction {
+		fmt.Fprintf(buf, "    RETURNS %s\n", typeString(routine.ReturnType))
+	}
+	if routine.Deterministic {
+		buf.WriteString("    DETERMINISTIC\n")
+	}
+	if routine.DataAccess != model.RoutineContainsSQL {
+		fmt.Fprintf(buf, "    %s\n", routine.DataAccess.String())
+	}
+	if routine.Security == model.SecurityInvoker {
+		buf.WriteString("    SQL SECURITY INVOKER\n")
+	}
+	if routine.Comment != "" {
+		fmt.Fprintf(buf, "    COMMENT '%s'\n", format.OutputFormat(routine.Comment))
+	}
+	buf.WriteString(routine.Body)
+}
+
 func (e *ShowExec) fetchShowPlugins() error {
 	tiPlugins := plugin.GetAll()
 	for _, ps := range tiPlugins {
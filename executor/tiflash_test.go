@@ -770,6 +770,47 @@ func TestMppUnionAll(t *testing.T) {
 
 }
 
+func TestMppExpand(t *testing.T) {
+	store, clean := createTiFlashStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t, s")
+	tk.MustExec("create table t(a int, b int, c int)")
+	tk.MustExec("alter table t set tiflash replica 1")
+	tk.MustExec("create table s(a int, d int)")
+	tk.MustExec("alter table s set tiflash replica 1")
+	tb := external.GetTableByName(t, tk, "test", "t")
+	err := domain.GetDomain(tk.Session()).DDL().UpdateTableReplicaInfo(tk.Session(), tb.Meta().ID, true)
+	require.NoError(t, err)
+	tb = external.GetTableByName(t, tk, "test", "s")
+	err = domain.GetDomain(tk.Session()).DDL().UpdateTableReplicaInfo(tk.Session(), tb.Meta().ID, true)
+	require.NoError(t, err)
+
+	tk.MustExec("insert into t values (1, 1, 10), (1, 2, 20), (2, 1, 30), (2, null, 40)")
+	tk.MustExec("insert into s values (1, 100), (2, 200), (2, 300)")
+	tk.MustExec("set @@session.tidb_isolation_read_engines = 'tiflash'")
+	tk.MustExec("set @@session.tidb_enforce_mpp = 1")
+	tk.MustQuery("select a, b, sum(c), grouping(a, b) from t group by a, b with rollup").Sort().Check(testkit.Rows(
+		"1 1 10 0",
+		"1 2 20 0",
+		"1 <nil> 30 1",
+		"2 1 30 0",
+		"2 <nil> 40 0",
+		"2 <nil> 70 1",
+		"<nil> <nil> 100 3"))
+	tk.MustQuery("select a + 1, count(*) from t group by a + 1 with rollup").Sort().Check(testkit.Rows("2 2", "3 2", "<nil> 4"))
+	// Every grouping set reads all the rows of the shuffled join below the expand.
+	tk.MustExec("set @@session.tidb_broadcast_join_threshold_size = 0")
+	tk.MustExec("set @@session.tidb_broadcast_join_threshold_count = 0")
+	tk.MustQuery("select t.a, sum(s.d) from t join s on t.a = s.a group by t.a with rollup").Sort().Check(testkit.Rows(
+		"1 200",
+		"2 1000",
+		"<nil> 1200"))
+	// The aggregation which can't be pushed down runs on TiDB, so does the expand.
+	tk.MustQuery("select a, bit_or(c) from t group by a with rollup").Sort().Check(testkit.Rows("1 30", "2 62", "<nil> 62"))
+}
+
 func TestUnionWithEmptyDualTable(t *testing.T) {
 	store, clean := createTiFlashStore(t)
 	defer clean()
@@ -0,0 +1,59 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/types"
+)
+
+// RunTriggers runs the triggers of the table activated by the event at the
+// timing for a row. oldRow is nil for INSERT and newRow is nil for DELETE, the
+// BEFORE triggers may change the values in newRow. It's called for every row
+// written to a table with triggers, even if none of them is activated, so the
+// tables used by the running triggers can be protected from being modified.
+// Note: initialized in session
+var RunTriggers func(ctx context.Context, sctx sessionctx.Context, tbl table.Table, timing model.TriggerTiming, event model.TriggerEvent, oldRow, newRow []types.Datum) error
+
+// runTriggers runs the triggers of the table for a row. The rows may contain
+// the values of the columns which are not public, they are not visible to triggers.
+func runTriggers(ctx context.Context, sctx sessionctx.Context, tbl table.Table, timing model.TriggerTiming, event model.TriggerEvent, oldRow, newRow []types.Datum) error {
+	if RunTriggers == nil || len(tbl.Meta().Triggers) == 0 {
+		return nil
+	}
+	n := len(tbl.Cols())
+	if len(oldRow) > n {
+		oldRow = oldRow[:n]
+	}
+	if len(newRow) > n {
+		newRow = newRow[:n]
+	}
+	return RunTriggers(ctx, sctx, tbl, timing, event, oldRow, newRow)
+}
+
+// hasTriggers checks whether the table has any trigger activated by the event
+// at the timing.
+func hasTriggers(tbl table.Table, timing model.TriggerTiming, event model.TriggerEvent) bool {
+	for _, trigger := range tbl.Meta().Triggers {
+		if trigger.Timing == timing && trigger.Event == event {
+			return true
+		}
+	}
+	return false
+}
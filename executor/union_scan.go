@@ -111,6 +111,11 @@ func (us *UnionScanExec) open(ctx context.Context) error {
 
 	us.memBuf = mb
 	us.memBufSnap = mb.SnapshotGetter()
+	if us.ctx.GetSessionVars().NestedStmtLevel > 0 {
+		// A nested statement of a trigger or a stored function reads the changes
+		// made by the statement before it.
+		us.memBufSnap = mb
+	}
 
 	// 1. select without virtual columns
 	// 2. build virtual columns and select with virtual columns
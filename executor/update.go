@@ -43,8 +43,10 @@ type UpdateExec struct {
 
 	// updatedRowKeys is a map for unique (TableAlias, handle) pair.
 	// The value is true if the row is changed, or false otherwise
-	updatedRowKeys map[int]*kv.HandleMap
-	tblID2table    map[int64]table.Table
+	updatedRowKeys    map[int]*kv.HandleMap
+	tblID2table       map[int64]table.Table
+	tblID2Constraints map[int64][]*table.Constraint
+	fkChecker         *foreignKeyChecker
 	// mergedRowData is a map for unique (Table, handle) pair.
 	// The value is cached table row
 	mergedRowData          map[int64]*kv.HandleMap
@@ -189,14 +191,14 @@ func (e *UpdateExec) exec(ctx context.Context, schema *expression.Schema, row, n
 		flags := bAssignFlag[content.Start:content.End]
 
 		// Update row
-		changed, err1 := updateRecord(ctx, e.ctx, handle, oldData, newTableData, flags, tbl, false, e.memTracker)
+		changed, err1 := updateRecord(ctx, e.ctx, handle, oldData, newTableData, flags, tbl, e.tblID2Constraints[content.TblID], e.fkChecker, false, e.memTracker)
 		if err1 == nil {
 			e.updatedRowKeys[content.Start].Set(handle, changed)
 			continue
 		}
 
 		sc := e.ctx.GetSessionVars().StmtCtx
-		if kv.ErrKeyExists.Equal(err1) && sc.DupKeyAsWarning {
+		if (kv.ErrKeyExists.Equal(err1) || table.ErrCheckConstraintViolated.Equal(err1) || isForeignKeyError(err1)) && sc.DupKeyAsWarning {
 			sc.AppendWarning(err1)
 			continue
 		}
@@ -205,6 +207,21 @@ func (e *UpdateExec) exec(ctx context.Context, schema *expression.Schema, row, n
 	return nil
 }
 
+// runBeforeTriggers runs the BEFORE UPDATE triggers of the tables for the rows
+// to be updated. The generated columns are composed after them.
+func (e *UpdateExec) runBeforeTriggers(ctx context.Context, row, newData []types.Datum) error {
+	for i, content := range e.tblColPosInfos {
+		if !e.tableUpdatable[i] || e.changed[i] {
+			continue
+		}
+		tbl := e.tblID2table[content.TblID]
+		if err := runTriggers(ctx, e.ctx, tbl, model.TriggerBefore, model.TriggerUpdate, row[content.Start:content.End], newData[content.Start:content.End]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // unmatchedOuterRow checks the tableCols of a record to decide whether that record
 // can not be updated. The handle is NULL only when it is the inner side of an
 // outer join: the outer row can not match any inner rows, and in this scenario
@@ -297,6 +314,10 @@ func (e *UpdateExec) updateRows(ctx context.Context) (int, error) {
 			if err := e.merge(datumRow, newRow, false); err != nil {
 				return 0, err
 			}
+			// run BEFORE UPDATE triggers, they may change the non-generated columns
+			if err := e.runBeforeTriggers(ctx, datumRow, newRow); err != nil {
+				return 0, err
+			}
 			if e.virtualAssignmentsOffset < len(e.OrderedList) {
 				// compose generated columns
 				newRow, err = e.composeGeneratedColumns(globalRowIdx, newRow, colsInfo)
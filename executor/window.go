@@ -22,9 +22,12 @@ import (
 	"github.com/pingcap/tidb/executor/aggfuncs"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/disk"
+	"github.com/pingcap/tidb/util/memory"
 )
 
 // WindowExec is the executor for window functions.
@@ -36,162 +39,155 @@ type WindowExec struct {
 	childResult *chunk.Chunk
 	// executed indicates the child executor is drained or something unexpected happened.
 	executed bool
-	// resultChunks stores the chunks to return
-	resultChunks []*chunk.Chunk
-	// remainingRowsInChunk indicates how many rows the resultChunks[i] is not prepared.
-	remainingRowsInChunk []int
+	// partition buffers the rows of the current partition, it can spill to disk.
+	partition *windowPartitionRows
+	// produced is the number of rows in the current partition which have been returned.
+	produced uint64
+	// inputColIdxs are the indices of the child columns returned along with the window functions.
+	inputColIdxs []int
 
 	numWindowFuncs int
 	processor      windowProcessor
+
+	memTracker  *memory.Tracker
+	diskTracker *disk.Tracker
+}
+
+// Open implements the Executor Open interface.
+func (e *WindowExec) Open(ctx context.Context) error {
+	if err := e.baseExecutor.Open(ctx); err != nil {
+		return err
+	}
+	e.executed = false
+	e.produced = 0
+	e.groupChecker.reset()
+	e.memTracker = memory.NewTracker(e.id, -1)
+	e.memTracker.AttachTo(e.ctx.GetSessionVars().StmtCtx.MemTracker)
+	e.diskTracker = disk.NewTracker(e.id, -1)
+	e.diskTracker.AttachTo(e.ctx.GetSessionVars().StmtCtx.DiskTracker)
+	e.partition = newWindowPartitionRows(e.ctx, retTypes(e.children[0]), e.maxChunkSize, e.memTracker, e.diskTracker)
+	e.inputColIdxs = windowInputColIdxs(e.Schema(), e.numWindowFuncs)
+	return nil
 }
 
 // Close implements the Executor Close interface.
 func (e *WindowExec) Close() error {
+	if e.partition != nil {
+		terror.Call(e.partition.close)
+		e.partition = nil
+	}
+	e.childResult = nil
 	return errors.Trace(e.baseExecutor.Close())
 }
 
 // Next implements the Executor Next interface.
 func (e *WindowExec) Next(ctx context.Context, chk *chunk.Chunk) error {
 	chk.Reset()
-	for !e.executed && !e.preparedChunkAvailable() {
-		err := e.consumeOneGroup(ctx)
-		if err != nil {
-			e.executed = true
+	for !chk.IsFull() {
+		if e.produced == e.partition.Len() {
+			if e.executed {
+				break
+			}
+			if err := e.fetchPartition(ctx); err != nil {
+				e.executed = true
+				return err
+			}
+			continue
+		}
+		remained := mathutil.MinUint64(e.partition.Len()-e.produced, uint64(chk.RequiredRows()-chk.NumRows()))
+		if err := e.appendResult2Chunk(chk, int(remained)); err != nil {
 			return err
 		}
 	}
-	if len(e.resultChunks) > 0 {
-		chk.SwapColumns(e.resultChunks[0])
-		e.resultChunks[0] = nil // GC it. TODO: Reuse it.
-		e.resultChunks = e.resultChunks[1:]
-		e.remainingRowsInChunk = e.remainingRowsInChunk[1:]
-	}
 	return nil
 }
 
-func (e *WindowExec) preparedChunkAvailable() bool {
-	return len(e.resultChunks) > 0 && e.remainingRowsInChunk[0] == 0
-}
-
-func (e *WindowExec) consumeOneGroup(ctx context.Context) error {
-	var groupRows []chunk.Row
-	if e.groupChecker.isExhausted() {
-		eof, err := e.fetchChild(ctx)
-		if err != nil {
-			return errors.Trace(err)
-		}
-		if eof {
-			e.executed = true
-			return e.consumeGroupRows(groupRows)
-		}
-		_, err = e.groupChecker.splitIntoGroups(e.childResult)
-		if err != nil {
-			return errors.Trace(err)
-		}
+// fetchPartition buffers all the rows of the next partition and consumes them.
+func (e *WindowExec) fetchPartition(ctx context.Context) error {
+	e.processor.resetPartialResult()
+	e.produced = 0
+	if err := e.partition.reset(); err != nil {
+		return err
 	}
-	begin, end := e.groupChecker.getNextGroup()
-	for i := begin; i < end; i++ {
-		groupRows = append(groupRows, e.childResult.GetRow(i))
-	}
-
-	for meetLastGroup := end == e.childResult.NumRows(); meetLastGroup; {
-		meetLastGroup = false
-		eof, err := e.fetchChild(ctx)
-		if err != nil {
-			return errors.Trace(err)
-		}
-		if eof {
-			e.executed = true
-			return e.consumeGroupRows(groupRows)
+	for {
+		if e.groupChecker.isExhausted() {
+			eof, err := e.fetchChild(ctx)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if eof {
+				e.executed = true
+				break
+			}
+			isFirstGroupSameAsPrev, err := e.groupChecker.splitIntoGroups(e.childResult)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if e.partition.Len() > 0 && !isFirstGroupSameAsPrev {
+				break
+			}
 		}
-
-		isFirstGroupSameAsPrev, err := e.groupChecker.splitIntoGroups(e.childResult)
-		if err != nil {
-			return errors.Trace(err)
+		begin, end := e.groupChecker.getNextGroup()
+		if err := e.partition.appendRows(e.childResult, begin, end); err != nil {
+			return err
 		}
-
-		if isFirstGroupSameAsPrev {
-			begin, end = e.groupChecker.getNextGroup()
-			for i := begin; i < end; i++ {
-				groupRows = append(groupRows, e.childResult.GetRow(i))
-			}
-			meetLastGroup = end == e.childResult.NumRows()
+		if end != e.childResult.NumRows() {
+			break
 		}
 	}
-	return e.consumeGroupRows(groupRows)
-}
-
-func (e *WindowExec) consumeGroupRows(groupRows []chunk.Row) (err error) {
-	remainingRowsInGroup := len(groupRows)
-	if remainingRowsInGroup == 0 {
+	if e.partition.Len() == 0 {
 		return nil
 	}
-	for i := 0; i < len(e.resultChunks); i++ {
-		remained := mathutil.Min(e.remainingRowsInChunk[i], remainingRowsInGroup)
-		e.remainingRowsInChunk[i] -= remained
-		remainingRowsInGroup -= remained
-
-		// TODO: Combine these three methods.
-		// The old implementation needs the processor has these three methods
-		// but now it does not have to.
-		groupRows, err = e.processor.consumeGroupRows(e.ctx, groupRows)
-		if err != nil {
-			return errors.Trace(err)
-		}
-		_, err = e.processor.appendResult2Chunk(e.ctx, groupRows, e.resultChunks[i], remained)
+	return errors.Trace(e.processor.consumeGroupRows(e.ctx, e.partition))
+}
+
+// appendResult2Chunk appends the next remained rows of the current partition
+// and their window function results to chk.
+func (e *WindowExec) appendResult2Chunk(chk *chunk.Chunk, remained int) error {
+	for i := 0; i < remained; i++ {
+		row, err := e.partition.getRow(e.produced + uint64(i))
 		if err != nil {
-			return errors.Trace(err)
-		}
-		if remainingRowsInGroup == 0 {
-			e.processor.resetPartialResult()
-			break
+			return err
 		}
+		chk.AppendPartialRowByColIdxs(0, row, e.inputColIdxs)
+	}
+	if err := e.processor.appendResult2Chunk(e.ctx, e.partition, chk, remained); err != nil {
+		return errors.Trace(err)
 	}
+	e.produced += uint64(remained)
 	return nil
 }
 
 func (e *WindowExec) fetchChild(ctx context.Context) (EOF bool, err error) {
-	childResult := newFirstChunk(e.children[0])
-	err = Next(ctx, e.children[0], childResult)
+	if e.childResult == nil {
+		e.childResult = newFirstChunk(e.children[0])
+	}
+	err = Next(ctx, e.children[0], e.childResult)
 	if err != nil {
 		return false, errors.Trace(err)
 	}
 	// No more data.
-	numRows := childResult.NumRows()
-	if numRows == 0 {
-		return true, nil
-	}
-
-	resultChk := chunk.New(e.retFieldTypes, 0, numRows)
-	err = e.copyChk(childResult, resultChk)
-	if err != nil {
-		return false, err
-	}
-	e.resultChunks = append(e.resultChunks, resultChk)
-	e.remainingRowsInChunk = append(e.remainingRowsInChunk, numRows)
-
-	e.childResult = childResult
-	return false, nil
+	return e.childResult.NumRows() == 0, nil
 }
 
-func (e *WindowExec) copyChk(src, dst *chunk.Chunk) error {
-	columns := e.Schema().Columns[:len(e.Schema().Columns)-e.numWindowFuncs]
-	for i, col := range columns {
-		if err := dst.MakeRefTo(i, src, col.Index); err != nil {
-			return err
-		}
+// windowInputColIdxs returns the indices of the child columns in the schema of a window executor.
+func windowInputColIdxs(schema *expression.Schema, numWindowFuncs int) []int {
+	columns := schema.Columns[:schema.Len()-numWindowFuncs]
+	colIdxs := make([]int, 0, len(columns))
+	for _, col := range columns {
+		colIdxs = append(colIdxs, col.Index)
 	}
-	return nil
+	return colIdxs
 }
 
 // windowProcessor is the interface for processing different kinds of windows.
 type windowProcessor interface {
 	// consumeGroupRows updates the result for an window function using the input rows
 	// which belong to the same partition.
-	consumeGroupRows(ctx sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error)
-	// appendResult2Chunk appends the final results to chunk.
+	consumeGroupRows(ctx sessionctx.Context, rows *windowPartitionRows) error
+	// appendResult2Chunk appends the final results of the next remained rows to chunk.
 	// It is called when there are no more rows in current partition.
-	appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error)
+	appendResult2Chunk(ctx sessionctx.Context, rows *windowPartitionRows, chk *chunk.Chunk, remained int) error
 	// resetPartialResult resets the partial result to the original state for a specific window function.
 	resetPartialResult()
 }
@@ -201,30 +197,29 @@ type aggWindowProcessor struct {
 	partialResults []aggfuncs.PartialResult
 }
 
-func (p *aggWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error) {
+func (p *aggWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows *windowPartitionRows) error {
 	for i, windowFunc := range p.windowFuncs {
 		// @todo Add memory trace
-		_, err := windowFunc.UpdatePartialResult(ctx, rows, p.partialResults[i])
+		err := rows.updatePartialResult(ctx, windowFunc, 0, rows.Len(), p.partialResults[i])
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
-	rows = rows[:0]
-	return rows, nil
+	return nil
 }
 
-func (p *aggWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
+func (p *aggWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows *windowPartitionRows, chk *chunk.Chunk, remained int) error {
 	for remained > 0 {
 		for i, windowFunc := range p.windowFuncs {
 			// TODO: We can extend the agg func interface to avoid the `for` loop  here.
 			err := windowFunc.AppendFinalResult2Chunk(ctx, p.partialResults[i], chk)
 			if err != nil {
-				return nil, err
+				return err
 			}
 		}
 		remained--
 	}
-	return rows, nil
+	return nil
 }
 
 func (p *aggWindowProcessor) resetPartialResult() {
@@ -287,12 +282,12 @@ func (p *rowFrameWindowProcessor) getEndOffset(numRows uint64) uint64 {
 	return 0
 }
 
-func (p *rowFrameWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error) {
-	return rows, nil
+func (p *rowFrameWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows *windowPartitionRows) error {
+	return nil
 }
 
-func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
-	numRows := uint64(len(rows))
+func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows *windowPartitionRows, chk *chunk.Chunk, remained int) error {
+	numRows := rows.Len()
 	var (
 		err                      error
 		initializedSlidingWindow bool
@@ -320,16 +315,17 @@ func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, row
 			for i, windowFunc := range p.windowFuncs {
 				slidingWindowAggFunc := slidingWindowAggFuncs[i]
 				if slidingWindowAggFunc != nil && initializedSlidingWindow {
-					err = slidingWindowAggFunc.Slide(ctx, func(u uint64) chunk.Row {
-						return rows[u]
-					}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+					err = slidingWindowAggFunc.Slide(ctx, rows.mustGetRow, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+					if err == nil {
+						err = rows.takeErr()
+					}
 					if err != nil {
-						return nil, err
+						return err
 					}
 				}
 				err = windowFunc.AppendFinalResult2Chunk(ctx, p.partialResults[i], chk)
 				if err != nil {
-					return nil, err
+					return err
 				}
 			}
 			continue
@@ -338,24 +334,19 @@ func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, row
 		for i, windowFunc := range p.windowFuncs {
 			slidingWindowAggFunc := slidingWindowAggFuncs[i]
 			if slidingWindowAggFunc != nil && initializedSlidingWindow {
-				err = slidingWindowAggFunc.Slide(ctx, func(u uint64) chunk.Row {
-					return rows[u]
-				}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
-			} else {
-				// For MinMaxSlidingWindowAggFuncs, it needs the absolute value of each start of window, to compare
-				// whether elements inside deque are out of current window.
-				if minMaxSlidingWindowAggFunc, ok := windowFunc.(aggfuncs.MaxMinSlidingWindowAggFunc); ok {
-					// Store start inside MaxMinSlidingWindowAggFunc.windowInfo
-					minMaxSlidingWindowAggFunc.SetWindowStart(start)
+				err = slidingWindowAggFunc.Slide(ctx, rows.mustGetRow, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+				if err == nil {
+					err = rows.takeErr()
 				}
-				_, err = windowFunc.UpdatePartialResult(ctx, rows[start:end], p.partialResults[i])
+			} else {
+				err = rows.updatePartialResult(ctx, windowFunc, start, end, p.partialResults[i])
 			}
 			if err != nil {
-				return nil, err
+				return err
 			}
 			err = windowFunc.AppendFinalResult2Chunk(ctx, p.partialResults[i], chk)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			if slidingWindowAggFunc == nil {
 				windowFunc.ResetPartialResult(p.partialResults[i])
@@ -368,7 +359,7 @@ func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, row
 	for i, windowFunc := range p.windowFuncs {
 		windowFunc.ResetPartialResult(p.partialResults[i])
 	}
-	return rows, nil
+	return nil
 }
 
 func (p *rowFrameWindowProcessor) resetPartialResult() {
@@ -388,16 +379,23 @@ type rangeFrameWindowProcessor struct {
 	expectedCmpResult int64
 }
 
-func (p *rangeFrameWindowProcessor) getStartOffset(ctx sessionctx.Context, rows []chunk.Row) (uint64, error) {
+func (p *rangeFrameWindowProcessor) getStartOffset(ctx sessionctx.Context, rows *windowPartitionRows) (uint64, error) {
 	if p.start.UnBounded {
 		return 0, nil
 	}
-	numRows := uint64(len(rows))
+	numRows := rows.Len()
 	for ; p.lastStartOffset < numRows; p.lastStartOffset++ {
 		var res int64
-		var err error
+		startRow, err := rows.getRow(p.lastStartOffset)
+		if err != nil {
+			return 0, err
+		}
+		curRow, err := rows.getRow(p.curRowIdx)
+		if err != nil {
+			return 0, err
+		}
 		for i := range p.orderByCols {
-			res, _, err = p.start.CmpFuncs[i](ctx, p.orderByCols[i], p.start.CalcFuncs[i], rows[p.lastStartOffset], rows[p.curRowIdx])
+			res, _, err = p.start.CmpFuncs[i](ctx, p.orderByCols[i], p.start.CalcFuncs[i], startRow, curRow)
 			if err != nil {
 				return 0, err
 			}
@@ -414,16 +412,23 @@ func (p *rangeFrameWindowProcessor) getStartOffset(ctx sessionctx.Context, rows
 	return p.lastStartOffset, nil
 }
 
-func (p *rangeFrameWindowProcessor) getEndOffset(ctx sessionctx.Context, rows []chunk.Row) (uint64, error) {
-	numRows := uint64(len(rows))
+func (p *rangeFrameWindowProcessor) getEndOffset(ctx sessionctx.Context, rows *windowPartitionRows) (uint64, error) {
+	numRows := rows.Len()
 	if p.end.UnBounded {
 		return numRows, nil
 	}
 	for ; p.lastEndOffset < numRows; p.lastEndOffset++ {
 		var res int64
-		var err error
+		curRow, err := rows.getRow(p.curRowIdx)
+		if err != nil {
+			return 0, err
+		}
+		endRow, err := rows.getRow(p.lastEndOffset)
+		if err != nil {
+			return 0, err
+		}
 		for i := range p.orderByCols {
-			res, _, err = p.end.CmpFuncs[i](ctx, p.end.CalcFuncs[i], p.orderByCols[i], rows[p.curRowIdx], rows[p.lastEndOffset])
+			res, _, err = p.end.CmpFuncs[i](ctx, p.end.CalcFuncs[i], p.orderByCols[i], curRow, endRow)
 			if err != nil {
 				return 0, err
 			}
@@ -440,7 +445,7 @@ func (p *rangeFrameWindowProcessor) getEndOffset(ctx sessionctx.Context, rows []
 	return p.lastEndOffset, nil
 }
 
-func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
+func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows *windowPartitionRows, chk *chunk.Chunk, remained int) error {
 	var (
 		err                      error
 		initializedSlidingWindow bool
@@ -460,11 +465,11 @@ func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, r
 	for ; remained > 0; lastStart, lastEnd = start, end {
 		start, err = p.getStartOffset(ctx, rows)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		end, err = p.getEndOffset(ctx, rows)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		p.curRowIdx++
 		remained--
@@ -474,16 +479,17 @@ func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, r
 			for i, windowFunc := range p.windowFuncs {
 				slidingWindowAggFunc := slidingWindowAggFuncs[i]
 				if slidingWindowAggFunc != nil && initializedSlidingWindow {
-					err = slidingWindowAggFunc.Slide(ctx, func(u uint64) chunk.Row {
-						return rows[u]
-					}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+					err = slidingWindowAggFunc.Slide(ctx, rows.mustGetRow, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+					if err == nil {
+						err = rows.takeErr()
+					}
 					if err != nil {
-						return nil, err
+						return err
 					}
 				}
 				err = windowFunc.AppendFinalResult2Chunk(ctx, p.partialResults[i], chk)
 				if err != nil {
-					return nil, err
+					return err
 				}
 			}
 			continue
@@ -492,21 +498,19 @@ func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, r
 		for i, windowFunc := range p.windowFuncs {
 			slidingWindowAggFunc := slidingWindowAggFuncs[i]
 			if slidingWindowAggFunc != nil && initializedSlidingWindow {
-				err = slidingWindowAggFunc.Slide(ctx, func(u uint64) chunk.Row {
-					return rows[u]
-				}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
-			} else {
-				if minMaxSlidingWindowAggFunc, ok := windowFunc.(aggfuncs.MaxMinSlidingWindowAggFunc); ok {
-					minMaxSlidingWindowAggFunc.SetWindowStart(start)
+				err = slidingWindowAggFunc.Slide(ctx, rows.mustGetRow, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+				if err == nil {
+					err = rows.takeErr()
 				}
-				_, err = windowFunc.UpdatePartialResult(ctx, rows[start:end], p.partialResults[i])
+			} else {
+				err = rows.updatePartialResult(ctx, windowFunc, start, end, p.partialResults[i])
 			}
 			if err != nil {
-				return nil, err
+				return err
 			}
 			err = windowFunc.AppendFinalResult2Chunk(ctx, p.partialResults[i], chk)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			if slidingWindowAggFunc == nil {
 				windowFunc.ResetPartialResult(p.partialResults[i])
@@ -519,11 +523,11 @@ func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, r
 	for i, windowFunc := range p.windowFuncs {
 		windowFunc.ResetPartialResult(p.partialResults[i])
 	}
-	return rows, nil
+	return nil
 }
 
-func (p *rangeFrameWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error) {
-	return rows, nil
+func (p *rangeFrameWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows *windowPartitionRows) error {
+	return nil
 }
 
 func (p *rangeFrameWindowProcessor) resetPartialResult() {
@@ -0,0 +1,236 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"github.com/cznic/mathutil"
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/config"
+	"github.com/pingcap/tidb/executor/aggfuncs"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/disk"
+	"github.com/pingcap/tidb/util/memory"
+)
+
+// windowPartitionRows buffers the rows of the current window partition in a
+// RowContainer which can spill to disk. The rows are identified by their
+// offsets in the partition.
+//
+// The rows are copied into chunks of exactly chunkSize rows, so the chunk of a
+// row can be located by its offset directly. The latest chunk which is not full
+// yet is kept in tail and added into the container once it is full.
+type windowPartitionRows struct {
+	container *chunk.RowContainer
+	chunkSize int
+	// offset is the offset of the first row in container, it's not 0 after the
+	// leading rows are dropped by dropBefore.
+	offset  uint64
+	numRows uint64
+	tail    *chunk.Chunk
+
+	// cachedChks caches the recently read chunks of container, reading a chunk
+	// from disk is expensive and the frames are evaluated on adjacent rows.
+	cachedChks   [2]*chunk.Chunk
+	cachedChkIdx [2]int
+	nextCacheIdx int
+
+	rowsBuf []chunk.Row
+	// nullRow is returned by mustGetRow when reading a row fails.
+	nullRow chunk.Row
+	err     error
+}
+
+func newWindowPartitionRows(sctx sessionctx.Context, fieldTypes []*types.FieldType, chunkSize int,
+	memTracker *memory.Tracker, diskTracker *disk.Tracker) *windowPartitionRows {
+	r := &windowPartitionRows{
+		container: chunk.NewRowContainer(fieldTypes, chunkSize),
+		chunkSize: chunkSize,
+		nullRow:   chunk.MutRowFromTypes(fieldTypes).ToRow(),
+	}
+	r.container.GetMemTracker().AttachTo(memTracker)
+	r.container.GetMemTracker().SetLabel(memory.LabelForRowContainer)
+	r.container.GetDiskTracker().AttachTo(diskTracker)
+	r.container.GetDiskTracker().SetLabel(memory.LabelForRowContainer)
+	if config.GetGlobalConfig().OOMUseTmpStorage {
+		actionSpill := r.container.ActionSpill()
+		failpoint.Inject("testWindowRowContainerSpill", func(val failpoint.Value) {
+			if val.(bool) {
+				actionSpill = r.container.ActionSpillForTest()
+			}
+		})
+		sctx.GetSessionVars().StmtCtx.MemTracker.FallbackOldAndSetNewAction(actionSpill)
+	}
+	r.resetCache()
+	return r
+}
+
+// Len returns the number of rows appended into the partition.
+func (r *windowPartitionRows) Len() uint64 {
+	return r.numRows
+}
+
+// appendRows copies the rows in [begin, end) of chk into the partition.
+func (r *windowPartitionRows) appendRows(chk *chunk.Chunk, begin, end int) error {
+	for begin < end {
+		if r.tail == nil {
+			r.tail = r.container.AllocChunk()
+		}
+		n := mathutil.Min(end-begin, r.chunkSize-r.tail.NumRows())
+		r.tail.Append(chk, begin, begin+n)
+		begin += n
+		r.numRows += uint64(n)
+		if r.tail.NumRows() == r.chunkSize {
+			if err := r.container.Add(r.tail); err != nil {
+				return err
+			}
+			r.tail = nil
+		}
+	}
+	return nil
+}
+
+// tailOffset returns the offset of the first row in tail.
+func (r *windowPartitionRows) tailOffset() uint64 {
+	return r.offset + uint64(r.container.NumChunks()*r.chunkSize)
+}
+
+// getRow returns the row at offset i of the partition.
+func (r *windowPartitionRows) getRow(i uint64) (chunk.Row, error) {
+	chk, rowIdx, err := r.locate(i)
+	if err != nil {
+		return chunk.Row{}, err
+	}
+	return chk.GetRow(rowIdx), nil
+}
+
+// mustGetRow is used where an error can't be returned, the error is kept in r.err
+// and should be checked by takeErr.
+func (r *windowPartitionRows) mustGetRow(i uint64) chunk.Row {
+	row, err := r.getRow(i)
+	if err != nil {
+		if r.err == nil {
+			r.err = err
+		}
+		return r.nullRow
+	}
+	return row
+}
+
+func (r *windowPartitionRows) takeErr() error {
+	err := r.err
+	r.err = nil
+	return err
+}
+
+// getRows returns the rows in [start, end) of the partition, all of them should
+// be in the same chunk. The returned slice is reused by the next call.
+func (r *windowPartitionRows) getRows(start, end uint64) ([]chunk.Row, error) {
+	r.rowsBuf = r.rowsBuf[:0]
+	if start >= end {
+		return r.rowsBuf, nil
+	}
+	chk, rowIdx, err := r.locate(start)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < int(end-start); i++ {
+		r.rowsBuf = append(r.rowsBuf, chk.GetRow(rowIdx+i))
+	}
+	return r.rowsBuf, nil
+}
+
+// chunkEnd returns the offset next to the last row in the chunk of row i.
+func (r *windowPartitionRows) chunkEnd(i uint64) uint64 {
+	size := uint64(r.chunkSize)
+	return r.offset + ((i-r.offset)/size+1)*size
+}
+
+func (r *windowPartitionRows) locate(i uint64) (*chunk.Chunk, int, error) {
+	if i >= r.tailOffset() {
+		return r.tail, int(i - r.tailOffset()), nil
+	}
+	chkIdx, rowIdx := int((i-r.offset)/uint64(r.chunkSize)), int((i-r.offset)%uint64(r.chunkSize))
+	for j := range r.cachedChkIdx {
+		if r.cachedChkIdx[j] == chkIdx {
+			return r.cachedChks[j], rowIdx, nil
+		}
+	}
+	chk, err := r.container.GetChunk(chkIdx)
+	if err != nil {
+		return nil, 0, err
+	}
+	r.cachedChks[r.nextCacheIdx], r.cachedChkIdx[r.nextCacheIdx] = chk, chkIdx
+	r.nextCacheIdx = (r.nextCacheIdx + 1) % len(r.cachedChks)
+	return chk, rowIdx, nil
+}
+
+// updatePartialResult updates pr with the rows in [start, end) chunk by chunk,
+// so that the rows of a spilled partition are not read into memory at once.
+func (r *windowPartitionRows) updatePartialResult(sctx sessionctx.Context, windowFunc aggfuncs.AggFunc, start, end uint64, pr aggfuncs.PartialResult) error {
+	for start < end {
+		batchEnd := mathutil.MinUint64(end, r.chunkEnd(start))
+		rows, err := r.getRows(start, batchEnd)
+		if err != nil {
+			return err
+		}
+		// For MinMaxSlidingWindowAggFuncs, it needs the absolute value of each start of window, to compare
+		// whether elements inside deque are out of current window.
+		if minMaxSlidingWindowAggFunc, ok := windowFunc.(aggfuncs.MaxMinSlidingWindowAggFunc); ok {
+			// Store start inside MaxMinSlidingWindowAggFunc.windowInfo
+			minMaxSlidingWindowAggFunc.SetWindowStart(start)
+		}
+		if _, err = windowFunc.UpdatePartialResult(sctx, rows, pr); err != nil {
+			return err
+		}
+		start = batchEnd
+	}
+	return nil
+}
+
+// dropBefore tells the rows before offset i are not used any more. The memory
+// is released only when all of them are added into the container.
+func (r *windowPartitionRows) dropBefore(i uint64) error {
+	if r.container.NumChunks() == 0 || i < r.tailOffset() {
+		return nil
+	}
+	r.offset = r.tailOffset()
+	r.resetCache()
+	return r.container.Reset()
+}
+
+// reset clears the rows to buffer a new partition.
+func (r *windowPartitionRows) reset() error {
+	r.offset, r.numRows = 0, 0
+	if r.tail != nil {
+		r.tail.Reset()
+	}
+	r.resetCache()
+	return r.container.Reset()
+}
+
+func (r *windowPartitionRows) resetCache() {
+	for j := range r.cachedChks {
+		r.cachedChks[j], r.cachedChkIdx[j] = nil, -1
+	}
+	r.rowsBuf = r.rowsBuf[:0]
+}
+
+func (r *windowPartitionRows) close() error {
+	r.tail = nil
+	r.resetCache()
+	return r.container.Close()
+}
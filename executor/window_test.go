@@ -16,9 +16,13 @@ package executor_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/config"
 	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWindowFunctions(t *testing.T) {
@@ -251,6 +255,67 @@ func TestWindowFunctionsDataReference(t *testing.T) {
 		Check(testkit.Rows("1 1", "2 1", "3 1"))
 }
 
+func TestWindowFunctionsInDisk(t *testing.T) {
+	origin := config.RestoreFunc()
+	defer origin()
+	config.UpdateGlobal(func(conf *config.Config) {
+		conf.OOMUseTmpStorage = true
+		conf.OOMAction = config.OOMActionLog
+	})
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/executor/testWindowRowContainerSpill", "return(true)"))
+	defer func() {
+		require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/executor/testWindowRowContainerSpill"))
+	}()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@tidb_max_chunk_size = 32")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int, b int, c int)")
+	values := make([]string, 0, 3000)
+	for i := 0; i < 3000; i++ {
+		// most of the rows are in the partition a = 0.
+		values = append(values, fmt.Sprintf("(%d, %d, %d)", (i%10)/7*(i%5), i, i%97))
+	}
+	tk.MustExec("insert into t values " + strings.Join(values, ","))
+
+	sqls := []string{
+		"select a, b, row_number() over (partition by a order by b) from t",
+		"select a, b, rank() over (partition by a order by c, b) from t",
+		"select a, b, sum(c) over (partition by a order by b rows between 3 preceding and 5 following) from t",
+		"select a, b, max(c) over (partition by a order by b rows between 100 preceding and current row) from t",
+		"select a, b, count(c) over (partition by a order by c range between 2 preceding and 1 following) from t",
+		"select a, b, avg(c) over (partition by a) from t",
+		"select a, b, first_value(c) over w, last_value(c) over w from t window w as (partition by a order by b rows between 50 preceding and 50 following)",
+	}
+	for _, pipelined := range []string{"0", "1"} {
+		for _, concurrency := range []string{"1", "4"} {
+			tk.MustExec("set @@tidb_enable_pipelined_window_function = " + pipelined)
+			tk.MustExec("set @@tidb_window_concurrency = " + concurrency)
+			results := make([][][]interface{}, 0, len(sqls))
+			tk.MustExec("set @@tidb_mem_quota_query = default")
+			for _, sql := range sqls {
+				results = append(results, tk.MustQuery(sql).Sort().Rows())
+			}
+			tk.MustExec("set @@tidb_mem_quota_query = 1")
+			for i, sql := range sqls {
+				tk.MustQuery(sql).Sort().Check(results[i])
+			}
+		}
+	}
+
+	tk.MustExec("set @@tidb_window_concurrency = 1")
+	for _, pipelined := range []string{"0", "1"} {
+		tk.MustExec("set @@tidb_enable_pipelined_window_function = " + pipelined)
+		rows := tk.MustQuery("explain analyze select a, b, sum(c) over (partition by a order by b rows between 3 preceding and 5 following) from t").Rows()
+		require.Contains(t, rows[1][0], "Window")
+		require.NotEqual(t, "0 Bytes", rows[1][len(rows[1])-1])
+	}
+	tk.MustExec("set @@tidb_enable_pipelined_window_function = 1")
+}
+
 func TestSlidingWindowFunctions(t *testing.T) {
 	store, clean := testkit.CreateMockStore(t)
 	defer clean()
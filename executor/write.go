@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta/autoid"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/sessionctx"
@@ -50,7 +51,7 @@ var (
 //     1. changed (bool) : does the update really change the row values. e.g. update set i = 1 where i = 1;
 //     2. err (error) : error in the update.
 func updateRecord(ctx context.Context, sctx sessionctx.Context, h kv.Handle, oldData, newData []types.Datum, modified []bool, t table.Table,
-	onDup bool, memTracker *memory.Tracker) (bool, error) {
+	constraints []*table.Constraint, fkChecker *foreignKeyChecker, onDup bool, memTracker *memory.Tracker) (bool, error) {
 	if span := opentracing.SpanFromContext(ctx); span != nil && span.Tracer() != nil {
 		span1 := span.Tracer().StartSpan("executor.updateRecord", opentracing.ChildOf(span.Context()))
 		defer span1.Finish()
@@ -153,7 +154,7 @@ func updateRecord(ctx context.Context, sctx sessionctx.Context, h kv.Handle, old
 		if txnCtx.IsPessimistic {
 			txnCtx.AddUnchangedRowKey(unchangedRowKey)
 		}
-		return false, nil
+		return false, runTriggers(ctx, sctx, t, model.TriggerAfter, model.TriggerUpdate, oldData, newData)
 	}
 
 	// 4. Fill values into on-update-now fields, only if they are really changed.
@@ -168,7 +169,22 @@ func updateRecord(ctx context.Context, sctx sessionctx.Context, h kv.Handle, old
 		}
 	}
 
-	// 5. If handle changed, remove the old then add the new record, otherwise update the record.
+	// 5. Check the new row against the check constraints.
+	if err = table.CheckRowConstraint(sctx, constraints, newData); err != nil {
+		return false, err
+	}
+
+	// 6. Check the foreign key constraints, and perform the referential actions on the rows referring to the old row.
+	if fkChecker != nil {
+		if err = fkChecker.checkRowReferTo(ctx, t, newData, modified); err != nil {
+			return false, err
+		}
+		if err = fkChecker.onUpdateRow(ctx, t, h, oldData, newData); err != nil {
+			return false, err
+		}
+	}
+
+	// 7. If handle changed, remove the old then add the new record, otherwise update the record.
 	if handleChanged {
 		// For `UPDATE IGNORE`/`INSERT IGNORE ON DUPLICATE KEY UPDATE`
 		// we use the staging buffer so that we don't need to precheck the existence of handle or unique keys by sending
@@ -216,7 +232,7 @@ func updateRecord(ctx context.Context, sctx sessionctx.Context, h kv.Handle, old
 	sc.AddUpdatedRows(1)
 	sc.AddCopiedRows(1)
 
-	return true, nil
+	return true, runTriggers(ctx, sctx, t, model.TriggerAfter, model.TriggerUpdate, oldData, newData)
 }
 
 func rebaseAutoRandomValue(ctx context.Context, sctx sessionctx.Context, t table.Table, newData *types.Datum, col *table.Column) error {
@@ -1898,6 +1898,28 @@ func TestLoadDataMissingColumn(t *testing.T) {
 	checkCases(tests, ld, t, tk, ctx, selectSQL, deleteSQL)
 }
 
+func TestLoadDataBeforeInsertTrigger(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table load_data_trigger (id int, n int)")
+	// The trigger for a row sees the rows loaded before it.
+	tk.MustExec("create trigger t_bi before insert on load_data_trigger for each row " +
+		"begin declare c int; select count(*) from load_data_trigger into c; set new.n = c; end")
+	tk.MustExec("load data local infile '/tmp/nonexistence.csv' into table load_data_trigger (id)")
+	ctx := tk.Session().(sessionctx.Context)
+	ld, ok := ctx.Value(executor.LoadDataVarKey).(*executor.LoadDataInfo)
+	require.True(t, ok)
+	defer ctx.SetValue(executor.LoadDataVarKey, nil)
+	require.NotNil(t, ld)
+
+	tests := []testCase{
+		{nil, []byte("1\n2\n3\n"), []string{"1|0", "2|1", "3|2"}, nil, "Records: 3  Deleted: 0  Skipped: 0  Warnings: 0"},
+	}
+	checkCases(tests, ld, t, tk, ctx, "select * from load_data_trigger order by id", "delete from load_data_trigger")
+}
+
 func TestIssue18681(t *testing.T) {
 	store, clean := testkit.CreateMockStore(t)
 	defer clean()
@@ -0,0 +1,575 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/collate"
+	"github.com/pingcap/tipb/go-tipb"
+)
+
+var (
+	_ functionClass = &regexpLikeFunctionClass{}
+	_ functionClass = &regexpInStrFunctionClass{}
+	_ functionClass = &regexpSubstrFunctionClass{}
+	_ functionClass = &regexpReplaceFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinRegexpLikeFuncSig{}
+	_ builtinFunc = &builtinRegexpInStrFuncSig{}
+	_ builtinFunc = &builtinRegexpSubstrFuncSig{}
+	_ builtinFunc = &builtinRegexpReplaceFuncSig{}
+)
+
+// regexpBaseFuncSig is the shared part of the REGEXP_* functions.
+type regexpBaseFuncSig struct {
+	baseBuiltinFunc
+	// matchTypeIdx is the offset of the match_type argument.
+	matchTypeIdx int
+
+	// memorizedRegexp and memorizedErr are not serialized, they are the cache of the compiled
+	// regexp when both the pattern and the match type are constant.
+	memorizedRegexp *regexp.Regexp
+	memorizedErr    error
+	once            sync.Once
+}
+
+func newRegexpBaseFuncSig(bf baseBuiltinFunc, matchTypeIdx int) regexpBaseFuncSig {
+	return regexpBaseFuncSig{baseBuiltinFunc: bf, matchTypeIdx: matchTypeIdx}
+}
+
+func (b *regexpBaseFuncSig) clone(from *regexpBaseFuncSig) {
+	b.cloneFrom(&from.baseBuiltinFunc)
+	b.matchTypeIdx = from.matchTypeIdx
+}
+
+// isBinary indicates whether the strings are matched as binary strings, whose positions are counted in bytes.
+// It's decided by the signature since the collation of the return type isn't always pushed down to the coprocessor.
+func (b *regexpBaseFuncSig) isBinary() bool {
+	switch b.PbCode() {
+	case tipb.ScalarFuncSig_RegexpLikeSig, tipb.ScalarFuncSig_RegexpInStrSig,
+		tipb.ScalarFuncSig_RegexpSubstrSig, tipb.ScalarFuncSig_RegexpReplaceSig:
+		return true
+	}
+	return false
+}
+
+// canMemorize indicates whether the pattern and the match type are constant.
+func (b *regexpBaseFuncSig) canMemorize() bool {
+	sc := b.ctx.GetSessionVars().StmtCtx
+	if !b.args[1].ConstItem(sc) {
+		return false
+	}
+	return len(b.args) <= b.matchTypeIdx || b.args[b.matchTypeIdx].ConstItem(sc)
+}
+
+// buildRegexp compiles the pattern with the flags specified by the match type.
+// See https://dev.mysql.com/doc/refman/8.0/en/regexp.html#function_regexp-like
+func (b *regexpBaseFuncSig) buildRegexp(pat string, matchType string) (*regexp.Regexp, error) {
+	if len(pat) == 0 {
+		return nil, ErrRegexpIllegalArgument.GenWithStackByArgs()
+	}
+	caseInsensitive := collate.IsCICollation(b.collation)
+	multiLine, dotAll := false, false
+	for _, flag := range matchType {
+		switch flag {
+		case 'c':
+			caseInsensitive = false
+		case 'i':
+			caseInsensitive = true
+		case 'm':
+			multiLine = true
+		case 'n':
+			dotAll = true
+		case 'u':
+			// Go regexp only treats '\n' as the line terminator, which is the same as the Unix-only line endings.
+		default:
+			return nil, ErrRegexpInvalidFlag.GenWithStackByArgs()
+		}
+	}
+	var flags strings.Builder
+	if caseInsensitive {
+		flags.WriteByte('i')
+	}
+	if multiLine {
+		flags.WriteByte('m')
+	}
+	if dotAll {
+		flags.WriteByte('s')
+	}
+	if flags.Len() > 0 {
+		pat = "(?" + flags.String() + ")" + pat
+	}
+	compiled, err := regexp.Compile(pat)
+	if err != nil {
+		return nil, ErrRegexp.GenWithStackByArgs(err.Error())
+	}
+	return compiled, nil
+}
+
+// getRegexp returns the compiled regexp, which is only compiled once if it can be memorized.
+func (b *regexpBaseFuncSig) getRegexp(pat string, matchType string) (*regexp.Regexp, error) {
+	if !b.canMemorize() {
+		return b.buildRegexp(pat, matchType)
+	}
+	b.once.Do(func() {
+		b.memorizedRegexp, b.memorizedErr = b.buildRegexp(pat, matchType)
+	})
+	return b.memorizedRegexp, b.memorizedErr
+}
+
+// evalMatchType evaluates the optional match type, an empty string is returned if it's omitted.
+func (b *regexpBaseFuncSig) evalMatchType(row chunk.Row) (string, bool, error) {
+	if len(b.args) <= b.matchTypeIdx {
+		return "", false, nil
+	}
+	return b.args[b.matchTypeIdx].EvalString(b.ctx, row)
+}
+
+// evalOptionalInt evaluates the optional integer argument at idx, def is returned if it's omitted.
+func (b *regexpBaseFuncSig) evalOptionalInt(row chunk.Row, idx int, def int64) (int64, bool, error) {
+	if len(b.args) <= idx {
+		return def, false, nil
+	}
+	return b.args[idx].EvalInt(b.ctx, row)
+}
+
+// startOffset converts the 1-based position to the byte offset in expr.
+func (b *regexpBaseFuncSig) startOffset(expr string, pos int64) (int, error) {
+	if pos < 1 {
+		return 0, ErrRegexpIndexOutOfBounds.GenWithStackByArgs()
+	}
+	if b.isBinary() {
+		if pos > int64(len(expr))+1 {
+			return 0, ErrRegexpIndexOutOfBounds.GenWithStackByArgs()
+		}
+		return int(pos - 1), nil
+	}
+	offset := 0
+	for i := int64(1); i < pos; i++ {
+		if offset >= len(expr) {
+			return 0, ErrRegexpIndexOutOfBounds.GenWithStackByArgs()
+		}
+		_, size := utf8.DecodeRuneInString(expr[offset:])
+		offset += size
+	}
+	return offset, nil
+}
+
+// length returns the length of s in bytes or characters according to the collation.
+func (b *regexpBaseFuncSig) length(s string) int64 {
+	if b.isBinary() {
+		return int64(len(s))
+	}
+	return int64(utf8.RuneCountInString(s))
+}
+
+// findOccurrence finds the occurrence-th match of the regexp in expr which starts from offset.
+// The returned indexes are relative to expr[offset:], and nil is returned if it's not found.
+func findOccurrence(compiled *regexp.Regexp, expr string, offset int, occurrence int64) []int {
+	if occurrence < 1 {
+		occurrence = 1
+	}
+	matches := compiled.FindAllStringIndex(expr[offset:], int(occurrence))
+	if int64(len(matches)) < occurrence {
+		return nil
+	}
+	return matches[occurrence-1]
+}
+
+type regexpLikeFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *regexpLikeFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTp := []types.EvalType{types.ETString, types.ETString}
+	if len(args) == 3 {
+		argTp = append(argTp, types.ETString)
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, argTp...)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.Flen = 1
+	sig := newBuiltinRegexpLikeFuncSig(bf)
+	if bf.collation == charset.CollationBin {
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpLikeSig)
+	} else {
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpLikeUTF8Sig)
+	}
+	return sig, nil
+}
+
+type builtinRegexpLikeFuncSig struct {
+	regexpBaseFuncSig
+}
+
+func newBuiltinRegexpLikeFuncSig(bf baseBuiltinFunc) *builtinRegexpLikeFuncSig {
+	return &builtinRegexpLikeFuncSig{newRegexpBaseFuncSig(bf, 2)}
+}
+
+func (b *builtinRegexpLikeFuncSig) Clone() builtinFunc {
+	newSig := &builtinRegexpLikeFuncSig{}
+	newSig.clone(&b.regexpBaseFuncSig)
+	return newSig
+}
+
+// evalInt evals `REGEXP_LIKE(expr, pat[, match_type])`.
+// See https://dev.mysql.com/doc/refman/8.0/en/regexp.html#function_regexp-like
+func (b *builtinRegexpLikeFuncSig) evalInt(row chunk.Row) (int64, bool, error) {
+	expr, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	pat, isNull, err := b.args[1].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	matchType, isNull, err := b.evalMatchType(row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	return b.regexpLike(expr, pat, matchType)
+}
+
+func (b *builtinRegexpLikeFuncSig) regexpLike(expr, pat, matchType string) (int64, bool, error) {
+	compiled, err := b.getRegexp(pat, matchType)
+	if err != nil {
+		return 0, true, err
+	}
+	return boolToInt64(compiled.MatchString(expr)), false, nil
+}
+
+type regexpInStrFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *regexpInStrFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTp := []types.EvalType{types.ETString, types.ETString, types.ETInt, types.ETInt, types.ETInt, types.ETString}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, argTp[:len(args)]...)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.Flen = mysql.MaxIntWidth
+	sig := newBuiltinRegexpInStrFuncSig(bf)
+	if bf.collation == charset.CollationBin {
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpInStrSig)
+	} else {
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpInStrUTF8Sig)
+	}
+	return sig, nil
+}
+
+type builtinRegexpInStrFuncSig struct {
+	regexpBaseFuncSig
+}
+
+func newBuiltinRegexpInStrFuncSig(bf baseBuiltinFunc) *builtinRegexpInStrFuncSig {
+	return &builtinRegexpInStrFuncSig{newRegexpBaseFuncSig(bf, 5)}
+}
+
+func (b *builtinRegexpInStrFuncSig) Clone() builtinFunc {
+	newSig := &builtinRegexpInStrFuncSig{}
+	newSig.clone(&b.regexpBaseFuncSig)
+	return newSig
+}
+
+// evalInt evals `REGEXP_INSTR(expr, pat[, pos[, occurrence[, return_option[, match_type]]]])`.
+// See https://dev.mysql.com/doc/refman/8.0/en/regexp.html#function_regexp-instr
+func (b *builtinRegexpInStrFuncSig) evalInt(row chunk.Row) (int64, bool, error) {
+	expr, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	pat, isNull, err := b.args[1].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	pos, isNull, err := b.evalOptionalInt(row, 2, 1)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	occurrence, isNull, err := b.evalOptionalInt(row, 3, 1)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	returnOption, isNull, err := b.evalOptionalInt(row, 4, 0)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	matchType, isNull, err := b.evalMatchType(row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	return b.regexpInStr(expr, pat, pos, occurrence, returnOption, matchType)
+}
+
+func (b *builtinRegexpInStrFuncSig) regexpInStr(expr, pat string, pos, occurrence, returnOption int64, matchType string) (int64, bool, error) {
+	if returnOption != 0 && returnOption != 1 {
+		return 0, true, ErrRegexpIllegalArgument.GenWithStackByArgs()
+	}
+	compiled, err := b.getRegexp(pat, matchType)
+	if err != nil {
+		return 0, true, err
+	}
+	offset, err := b.startOffset(expr, pos)
+	if err != nil {
+		return 0, true, err
+	}
+	match := findOccurrence(compiled, expr, offset, occurrence)
+	if match == nil {
+		return 0, false, nil
+	}
+	return pos + b.length(expr[offset:offset+match[returnOption]]), false, nil
+}
+
+type regexpSubstrFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *regexpSubstrFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTp := []types.EvalType{types.ETString, types.ETString, types.ETInt, types.ETInt, types.ETString}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTp[:len(args)]...)
+	if err != nil {
+		return nil, err
+	}
+	argType := args[0].GetType()
+	bf.tp.Flen = argType.Flen
+	SetBinFlagOrBinStr(argType, bf.tp)
+	sig := newBuiltinRegexpSubstrFuncSig(bf)
+	if bf.collation == charset.CollationBin {
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpSubstrSig)
+	} else {
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpSubstrUTF8Sig)
+	}
+	return sig, nil
+}
+
+type builtinRegexpSubstrFuncSig struct {
+	regexpBaseFuncSig
+}
+
+func newBuiltinRegexpSubstrFuncSig(bf baseBuiltinFunc) *builtinRegexpSubstrFuncSig {
+	return &builtinRegexpSubstrFuncSig{newRegexpBaseFuncSig(bf, 4)}
+}
+
+func (b *builtinRegexpSubstrFuncSig) Clone() builtinFunc {
+	newSig := &builtinRegexpSubstrFuncSig{}
+	newSig.clone(&b.regexpBaseFuncSig)
+	return newSig
+}
+
+// evalString evals `REGEXP_SUBSTR(expr, pat[, pos[, occurrence[, match_type]]])`.
+// See https://dev.mysql.com/doc/refman/8.0/en/regexp.html#function_regexp-substr
+func (b *builtinRegexpSubstrFuncSig) evalString(row chunk.Row) (string, bool, error) {
+	expr, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	pat, isNull, err := b.args[1].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	pos, isNull, err := b.evalOptionalInt(row, 2, 1)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	occurrence, isNull, err := b.evalOptionalInt(row, 3, 1)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	matchType, isNull, err := b.evalMatchType(row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	return b.regexpSubstr(expr, pat, pos, occurrence, matchType)
+}
+
+func (b *builtinRegexpSubstrFuncSig) regexpSubstr(expr, pat string, pos, occurrence int64, matchType string) (string, bool, error) {
+	compiled, err := b.getRegexp(pat, matchType)
+	if err != nil {
+		return "", true, err
+	}
+	offset, err := b.startOffset(expr, pos)
+	if err != nil {
+		return "", true, err
+	}
+	match := findOccurrence(compiled, expr, offset, occurrence)
+	if match == nil {
+		return "", true, nil
+	}
+	return expr[offset+match[0] : offset+match[1]], false, nil
+}
+
+type regexpReplaceFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *regexpReplaceFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTp := []types.EvalType{types.ETString, types.ETString, types.ETString, types.ETInt, types.ETInt, types.ETString}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTp[:len(args)]...)
+	if err != nil {
+		return nil, err
+	}
+	// The replacement may refer to the captured groups, so the length of the result can't be inferred.
+	bf.tp.Flen = mysql.MaxBlobWidth
+	SetBinFlagOrBinStr(args[0].GetType(), bf.tp)
+	sig := newBuiltinRegexpReplaceFuncSig(bf)
+	if bf.collation == charset.CollationBin {
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpReplaceSig)
+	} else {
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpReplaceUTF8Sig)
+	}
+	return sig, nil
+}
+
+type builtinRegexpReplaceFuncSig struct {
+	regexpBaseFuncSig
+}
+
+func newBuiltinRegexpReplaceFuncSig(bf baseBuiltinFunc) *builtinRegexpReplaceFuncSig {
+	return &builtinRegexpReplaceFuncSig{newRegexpBaseFuncSig(bf, 5)}
+}
+
+func (b *builtinRegexpReplaceFuncSig) Clone() builtinFunc {
+	newSig := &builtinRegexpReplaceFuncSig{}
+	newSig.clone(&b.regexpBaseFuncSig)
+	return newSig
+}
+
+// evalString evals `REGEXP_REPLACE(expr, pat, repl[, pos[, occurrence[, match_type]]])`.
+// See https://dev.mysql.com/doc/refman/8.0/en/regexp.html#function_regexp-replace
+func (b *builtinRegexpReplaceFuncSig) evalString(row chunk.Row) (string, bool, error) {
+	expr, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	pat, isNull, err := b.args[1].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	repl, isNull, err := b.args[2].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	pos, isNull, err := b.evalOptionalInt(row, 3, 1)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	occurrence, isNull, err := b.evalOptionalInt(row, 4, 0)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	matchType, isNull, err := b.evalMatchType(row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	return b.regexpReplace(expr, pat, repl, pos, occurrence, matchType)
+}
+
+// regexpReplace replaces the occurrence-th match, or all the matches if occurrence is 0.
+// The replacement can refer to the captured groups by `$1`.
+func (b *builtinRegexpReplaceFuncSig) regexpReplace(expr, pat, repl string, pos, occurrence int64, matchType string) (string, bool, error) {
+	compiled, err := b.getRegexp(pat, matchType)
+	if err != nil {
+		return "", true, err
+	}
+	offset, err := b.startOffset(expr, pos)
+	if err != nil {
+		return "", true, err
+	}
+	if occurrence < 0 {
+		occurrence = 1
+	}
+	n := -1
+	if occurrence > 0 {
+		n = int(occurrence)
+	}
+	src := expr[offset:]
+	matches := compiled.FindAllStringSubmatchIndex(src, n)
+	if occurrence > 0 {
+		if int64(len(matches)) < occurrence {
+			return expr, false, nil
+		}
+		matches = matches[occurrence-1:]
+	}
+	template := convertReplacement(repl)
+	result := make([]byte, 0, len(expr))
+	result = append(result, expr[:offset]...)
+	last := 0
+	for _, match := range matches {
+		result = append(result, src[last:match[0]]...)
+		result = compiled.ExpandString(result, template, src, match)
+		last = match[1]
+	}
+	result = append(result, src[last:]...)
+	return string(result), false, nil
+}
+
+// convertReplacement converts the replacement of MySQL, in which `$n` refers to the n-th captured group
+// and `\` escapes the next character, to the template used by regexp.Expand.
+func convertReplacement(repl string) string {
+	if strings.IndexAny(repl, "$\\") < 0 {
+		return repl
+	}
+	var template strings.Builder
+	for i := 0; i < len(repl); i++ {
+		switch c := repl[i]; {
+		case c == '\\' && i+1 < len(repl):
+			i++
+			if repl[i] == '$' {
+				template.WriteString("$$")
+			} else {
+				template.WriteByte(repl[i])
+			}
+		case c == '$':
+			j := i + 1
+			for j < len(repl) && repl[j] >= '0' && repl[j] <= '9' {
+				j++
+			}
+			if j == i+1 {
+				template.WriteString("$$")
+				continue
+			}
+			template.WriteString("${" + repl[i+1:j] + "}")
+			i = j - 1
+		default:
+			template.WriteByte(c)
+		}
+	}
+	return template.String()
+}
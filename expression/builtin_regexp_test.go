@@ -0,0 +1,146 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/testkit/testutil"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/stretchr/testify/require"
+)
+
+type regexpTestCase struct {
+	args   []interface{}
+	expect interface{}
+	err    error
+}
+
+func testRegexpFunc(t *testing.T, funcName string, tests []regexpTestCase) {
+	ctx := createContext(t)
+	for _, tt := range tests {
+		comment := fmt.Sprintf("%s%v", funcName, tt.args)
+		f, err := funcs[funcName].getFunction(ctx, primitiveValsToConstants(ctx, tt.args))
+		require.NoError(t, err, comment)
+		result, err := evalBuiltinFunc(f, chunk.Row{})
+		if tt.err != nil {
+			require.True(t, terror.ErrorEqual(err, tt.err), comment)
+			continue
+		}
+		require.NoError(t, err, comment)
+		testutil.DatumEqual(t, types.NewDatum(tt.expect), result, comment)
+	}
+}
+
+func TestRegexpLike(t *testing.T) {
+	testRegexpFunc(t, ast.RegexpLike, []regexpTestCase{
+		{[]interface{}{"abc", "b"}, int64(1), nil},
+		{[]interface{}{"abc", "^b"}, int64(0), nil},
+		{[]interface{}{"abc", "B"}, int64(0), nil},
+		{[]interface{}{"abc", "B", "i"}, int64(1), nil},
+		{[]interface{}{"abc", "B", "ic"}, int64(0), nil},
+		{[]interface{}{"a\nb", "^b"}, int64(0), nil},
+		{[]interface{}{"a\nb", "^b", "m"}, int64(1), nil},
+		{[]interface{}{"a\nb", "a.b"}, int64(0), nil},
+		{[]interface{}{"a\nb", "a.b", "n"}, int64(1), nil},
+		{[]interface{}{"你好", "^.{2}$"}, int64(1), nil},
+		{[]interface{}{nil, "a"}, nil, nil},
+		{[]interface{}{"a", nil}, nil, nil},
+		{[]interface{}{"a", "a", nil}, nil, nil},
+		{[]interface{}{"a", "a", "x"}, nil, ErrRegexpInvalidFlag},
+		{[]interface{}{"a", "("}, nil, ErrRegexp},
+		{[]interface{}{"a", ""}, nil, ErrRegexpIllegalArgument},
+	})
+}
+
+func TestRegexpInStr(t *testing.T) {
+	testRegexpFunc(t, ast.RegexpInStr, []regexpTestCase{
+		{[]interface{}{"dog cat dog", "dog"}, int64(1), nil},
+		{[]interface{}{"dog cat dog", "dog", 2}, int64(9), nil},
+		{[]interface{}{"dog cat dog", "dog", 1, 2}, int64(9), nil},
+		{[]interface{}{"dog cat dog", "dog", 1, 3}, int64(0), nil},
+		{[]interface{}{"dog cat dog", "dog", 1, 1, 1}, int64(4), nil},
+		{[]interface{}{"dog cat dog", "DOG", 1, 2, 0, "i"}, int64(9), nil},
+		{[]interface{}{"你好世界好", "好", 3}, int64(5), nil},
+		{[]interface{}{"你好世界好", "好", 1, 1, 1}, int64(3), nil},
+		{[]interface{}{"abc", "c", 4}, int64(0), nil},
+		{[]interface{}{"abc", "c", 5}, nil, ErrRegexpIndexOutOfBounds},
+		{[]interface{}{"abc", "c", 0}, nil, ErrRegexpIndexOutOfBounds},
+		{[]interface{}{"abc", "c", 1, 1, 2}, nil, ErrRegexpIllegalArgument},
+		{[]interface{}{"abc", "c", nil}, nil, nil},
+	})
+}
+
+func TestRegexpSubstr(t *testing.T) {
+	testRegexpFunc(t, ast.RegexpSubstr, []regexpTestCase{
+		{[]interface{}{"abc def ghi", "[a-z]+"}, "abc", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", 1, 3}, "ghi", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", 2, 2}, "def", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", 1, 4}, nil, nil},
+		{[]interface{}{"abc def ghi", "[A-Z]+", 1, 1, "i"}, "abc", nil},
+		{[]interface{}{"你好世界", ".{2}", 2}, "好世", nil},
+		{[]interface{}{"abc", "x"}, nil, nil},
+		{[]interface{}{"abc", "c", 5}, nil, ErrRegexpIndexOutOfBounds},
+	})
+}
+
+func TestRegexpReplace(t *testing.T) {
+	testRegexpFunc(t, ast.RegexpReplace, []regexpTestCase{
+		{[]interface{}{"a b c", "b", "X"}, "a X c", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", "X"}, "X X X", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", "X", 1, 3}, "abc def X", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", "X", 5}, "abc X X", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", "X", 1, 4}, "abc def ghi", nil},
+		{[]interface{}{"abc def", "([a-z])([a-z]+)", "$2$1"}, "bca efd", nil},
+		{[]interface{}{"ABC abc", "b", "X", 1, 0, "i"}, "AXC aXc", nil},
+		{[]interface{}{"你好你好", "好", "们", 3}, "你好你们", nil},
+		{[]interface{}{"abc", "b", nil}, nil, nil},
+		{[]interface{}{"abc", "b", "X", 0}, nil, ErrRegexpIndexOutOfBounds},
+	})
+}
+
+func TestRegexpCollation(t *testing.T) {
+	ctx := createContext(t)
+	tests := []struct {
+		charset   string
+		collation string
+		funcName  string
+		args      []interface{}
+		expect    interface{}
+	}{
+		{charset.CharsetUTF8MB4, charset.CollationUTF8MB4, ast.RegexpLike, []interface{}{"ABC", "b"}, int64(0)},
+		{charset.CharsetUTF8MB4, "utf8mb4_general_ci", ast.RegexpLike, []interface{}{"ABC", "b"}, int64(1)},
+		{charset.CharsetUTF8MB4, "utf8mb4_general_ci", ast.RegexpLike, []interface{}{"ABC", "b", "c"}, int64(0)},
+		{charset.CharsetBin, charset.CollationBin, ast.RegexpInStr, []interface{}{"你好", "好"}, int64(4)},
+		{charset.CharsetUTF8MB4, charset.CollationUTF8MB4, ast.RegexpInStr, []interface{}{"你好", "好"}, int64(2)},
+	}
+	for _, tt := range tests {
+		comment := fmt.Sprintf("%s%v collate %s", tt.funcName, tt.args, tt.collation)
+		args := primitiveValsToConstants(ctx, tt.args)
+		for _, arg := range args {
+			arg.GetType().Charset, arg.GetType().Collate = tt.charset, tt.collation
+		}
+		f, err := funcs[tt.funcName].getFunction(ctx, args)
+		require.NoError(t, err, comment)
+		result, err := evalBuiltinFunc(f, chunk.Row{})
+		require.NoError(t, err, comment)
+		testutil.DatumEqual(t, types.NewDatum(tt.expect), result, comment)
+	}
+}
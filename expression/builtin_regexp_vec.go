@@ -0,0 +1,190 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// vecEvalArgs evaluates all the arguments into the buffers. The caller must release the buffers by
+// putArgBufs, even if an error is returned.
+func (b *regexpBaseFuncSig) vecEvalArgs(input *chunk.Chunk) ([]*chunk.Column, error) {
+	bufs := make([]*chunk.Column, 0, len(b.args))
+	for _, arg := range b.args {
+		buf, err := b.bufAllocator.get()
+		if err != nil {
+			return bufs, err
+		}
+		bufs = append(bufs, buf)
+		if arg.GetType().EvalType() == types.ETInt {
+			err = arg.VecEvalInt(b.ctx, input, buf)
+		} else {
+			err = arg.VecEvalString(b.ctx, input, buf)
+		}
+		if err != nil {
+			return bufs, err
+		}
+	}
+	return bufs, nil
+}
+
+func (b *regexpBaseFuncSig) putArgBufs(bufs []*chunk.Column) {
+	for _, buf := range bufs {
+		b.bufAllocator.put(buf)
+	}
+}
+
+// getOptionalInt returns the optional integer argument at idx of the i-th row, def is returned if it's omitted.
+func getOptionalInt(bufs []*chunk.Column, idx int, i int, def int64) int64 {
+	if len(bufs) <= idx {
+		return def
+	}
+	return bufs[idx].GetInt64(i)
+}
+
+// getMatchType returns the match type of the i-th row, an empty string is returned if it's omitted.
+func (b *regexpBaseFuncSig) getMatchType(bufs []*chunk.Column, i int) string {
+	if len(bufs) <= b.matchTypeIdx {
+		return ""
+	}
+	return bufs[b.matchTypeIdx].GetString(i)
+}
+
+func (b *builtinRegexpLikeFuncSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRegexpLikeFuncSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	bufs, err := b.vecEvalArgs(input)
+	defer b.putArgBufs(bufs)
+	if err != nil {
+		return err
+	}
+
+	result.ResizeInt64(n, false)
+	result.MergeNulls(bufs...)
+	i64s := result.Int64s()
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		res, _, err := b.regexpLike(bufs[0].GetString(i), bufs[1].GetString(i), b.getMatchType(bufs, i))
+		if err != nil {
+			return err
+		}
+		i64s[i] = res
+	}
+	return nil
+}
+
+func (b *builtinRegexpInStrFuncSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRegexpInStrFuncSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	bufs, err := b.vecEvalArgs(input)
+	defer b.putArgBufs(bufs)
+	if err != nil {
+		return err
+	}
+
+	result.ResizeInt64(n, false)
+	result.MergeNulls(bufs...)
+	i64s := result.Int64s()
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		res, _, err := b.regexpInStr(bufs[0].GetString(i), bufs[1].GetString(i), getOptionalInt(bufs, 2, i, 1),
+			getOptionalInt(bufs, 3, i, 1), getOptionalInt(bufs, 4, i, 0), b.getMatchType(bufs, i))
+		if err != nil {
+			return err
+		}
+		i64s[i] = res
+	}
+	return nil
+}
+
+func (b *builtinRegexpSubstrFuncSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRegexpSubstrFuncSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	bufs, err := b.vecEvalArgs(input)
+	defer b.putArgBufs(bufs)
+	if err != nil {
+		return err
+	}
+
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		if hasNullInBufs(bufs, i) {
+			result.AppendNull()
+			continue
+		}
+		res, isNull, err := b.regexpSubstr(bufs[0].GetString(i), bufs[1].GetString(i), getOptionalInt(bufs, 2, i, 1),
+			getOptionalInt(bufs, 3, i, 1), b.getMatchType(bufs, i))
+		if err != nil {
+			return err
+		}
+		if isNull {
+			result.AppendNull()
+			continue
+		}
+		result.AppendString(res)
+	}
+	return nil
+}
+
+func (b *builtinRegexpReplaceFuncSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRegexpReplaceFuncSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	bufs, err := b.vecEvalArgs(input)
+	defer b.putArgBufs(bufs)
+	if err != nil {
+		return err
+	}
+
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		if hasNullInBufs(bufs, i) {
+			result.AppendNull()
+			continue
+		}
+		res, _, err := b.regexpReplace(bufs[0].GetString(i), bufs[1].GetString(i), bufs[2].GetString(i),
+			getOptionalInt(bufs, 3, i, 1), getOptionalInt(bufs, 4, i, 0), b.getMatchType(bufs, i))
+		if err != nil {
+			return err
+		}
+		result.AppendString(res)
+	}
+	return nil
+}
+
+func hasNullInBufs(bufs []*chunk.Column, i int) bool {
+	for _, buf := range bufs {
+		if buf.IsNull(i) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// EvalStoredFunction calls the stored function with the evaluated arguments.
+// Note: initialized in session
+var EvalStoredFunction func(sctx sessionctx.Context, schema model.CIStr, routine *model.RoutineInfo, args []types.Datum) (types.Datum, error)
+
+// BuildStoredFunction builds a ScalarFunction which calls the stored function of the schema.
+// The function is never folded or pushed down, it's evaluated row by row in TiDB.
+func BuildStoredFunction(ctx sessionctx.Context, schema model.CIStr, routine *model.RoutineInfo, args []Expression) (Expression, error) {
+	if EvalStoredFunction == nil {
+		return nil, errors.New("stored functions are not supported in this context")
+	}
+	funcArgs := make([]Expression, len(args))
+	copy(funcArgs, args)
+	retType := routine.ReturnType.Clone()
+	bf, err := newBaseBuiltinFuncWithFieldType(ctx, retType, funcArgs)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp = retType
+	sf := &ScalarFunction{
+		FuncName: model.NewCIStr(schema.O + "." + routine.Name.O),
+		RetType:  retType,
+		Function: &builtinStoredFuncSig{baseBuiltinFunc: bf, schema: schema, routine: routine},
+	}
+	if retType.EvalType() == types.ETString {
+		sf.SetCoercibility(CoercibilityImplicit)
+	} else {
+		sf.SetCoercibility(CoercibilityNumeric)
+	}
+	return sf, nil
+}
+
+type builtinStoredFuncSig struct {
+	baseBuiltinFunc
+	schema  model.CIStr
+	routine *model.RoutineInfo
+}
+
+func (b *builtinStoredFuncSig) Clone() builtinFunc {
+	newSig := &builtinStoredFuncSig{schema: b.schema, routine: b.routine}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinStoredFuncSig) call(row chunk.Row) (types.Datum, error) {
+	args := make([]types.Datum, 0, len(b.args))
+	for _, arg := range b.args {
+		d, err := arg.Eval(row)
+		if err != nil {
+			return types.Datum{}, err
+		}
+		args = append(args, d)
+	}
+	return EvalStoredFunction(b.ctx, b.schema, b.routine, args)
+}
+
+func (b *builtinStoredFuncSig) evalInt(row chunk.Row) (int64, bool, error) {
+	d, err := b.call(row)
+	if err != nil || d.IsNull() {
+		return 0, true, err
+	}
+	return d.GetInt64(), false, nil
+}
+
+func (b *builtinStoredFuncSig) evalReal(row chunk.Row) (float64, bool, error) {
+	d, err := b.call(row)
+	if err != nil || d.IsNull() {
+		return 0, true, err
+	}
+	return d.GetFloat64(), false, nil
+}
+
+func (b *builtinStoredFuncSig) evalDecimal(row chunk.Row) (*types.MyDecimal, bool, error) {
+	d, err := b.call(row)
+	if err != nil || d.IsNull() {
+		return nil, true, err
+	}
+	return d.GetMysqlDecimal(), false, nil
+}
+
+func (b *builtinStoredFuncSig) evalString(row chunk.Row) (string, bool, error) {
+	d, err := b.call(row)
+	if err != nil || d.IsNull() {
+		return "", true, err
+	}
+	s, err := d.ToString()
+	return s, false, err
+}
+
+func (b *builtinStoredFuncSig) evalTime(row chunk.Row) (types.Time, bool, error) {
+	d, err := b.call(row)
+	if err != nil || d.IsNull() {
+		return types.ZeroTime, true, err
+	}
+	return d.GetMysqlTime(), false, nil
+}
+
+func (b *builtinStoredFuncSig) evalDuration(row chunk.Row) (types.Duration, bool, error) {
+	d, err := b.call(row)
+	if err != nil || d.IsNull() {
+		return types.Duration{}, true, err
+	}
+	return d.GetMysqlDuration(), false, nil
+}
+
+func (b *builtinStoredFuncSig) evalJSON(row chunk.Row) (json.BinaryJSON, bool, error) {
+	d, err := b.call(row)
+	if err != nil || d.IsNull() {
+		return json.BinaryJSON{}, true, err
+	}
+	return d.GetMysqlJSON(), false, nil
+}
+
+// ContainsStoredFunction checks whether the expressions call any stored function.
+func ContainsStoredFunction(exprs []Expression) bool {
+	for _, expr := range exprs {
+		f, ok := expr.(*ScalarFunction)
+		if !ok {
+			continue
+		}
+		if _, ok := f.Function.(*builtinStoredFuncSig); ok || ContainsStoredFunction(f.GetArgs()) {
+			return true
+		}
+	}
+	return false
+}
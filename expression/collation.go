@@ -206,6 +206,9 @@ func deriveCollation(ctx sessionctx.Context, funcName string, args []Expression,
 		}
 	case ast.Locate, ast.Instr, ast.Position:
 		return CheckAndDeriveCollationFromExprs(ctx, funcName, retType, args[0], args[1])
+	case ast.RegexpLike, ast.RegexpInStr, ast.RegexpSubstr, ast.RegexpReplace:
+		// The optional position, occurrence and match type arguments don't take part in the collation derivation.
+		return CheckAndDeriveCollationFromExprs(ctx, funcName, retType, args[0], args[1])
 	case ast.GE, ast.LE, ast.GT, ast.LT, ast.EQ, ast.NE, ast.NullEQ, ast.Strcmp:
 		// if compare type is string, we should determine which collation should be used.
 		if argTps[0] == types.ETString {
@@ -138,6 +138,10 @@ func tryToReplaceCond(ctx sessionctx.Context, src *Column, tgt *Column, cond Exp
 	if _, ok := inequalFunctions[sf.FuncName.L]; ok {
 		return false, true, cond
 	}
+	// The stored functions may have side effects, they're not copied to the other side.
+	if _, ok := sf.Function.(*builtinStoredFuncSig); ok {
+		return false, true, cond
+	}
 	// See
 	//	https://github.com/pingcap/tidb/issues/15782
 	//  https://github.com/pingcap/tidb/issues/17817
@@ -179,7 +183,7 @@ func tryToReplaceCond(ctx sessionctx.Context, src *Column, tgt *Column, cond Exp
 		}
 	}
 	if replaced {
-		return true, false, NewFunctionInternal(ctx, sf.FuncName.L, sf.GetType(), args...)
+		return true, false, NewFunctionWithArgs(sf, sf.GetType(), args...)
 	}
 	return false, false, cond
 }
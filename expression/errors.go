@@ -28,6 +28,9 @@ var (
 	ErrIncorrectParameterCount     = dbterror.ClassExpression.NewStd(mysql.ErrWrongParamcountToNativeFct)
 	ErrDivisionByZero              = dbterror.ClassExpression.NewStd(mysql.ErrDivisionByZero)
 	ErrRegexp                      = dbterror.ClassExpression.NewStd(mysql.ErrRegexp)
+	ErrRegexpIllegalArgument       = dbterror.ClassExpression.NewStd(mysql.ErrRegexpIllegalArgument)
+	ErrRegexpIndexOutOfBounds      = dbterror.ClassExpression.NewStd(mysql.ErrRegexpIndexOutOfBounds)
+	ErrRegexpInvalidFlag           = dbterror.ClassExpression.NewStd(mysql.ErrRegexpInvalidFlag)
 	ErrOperandColumns              = dbterror.ClassExpression.NewStd(mysql.ErrOperandColumns)
 	ErrCutValueGroupConcat         = dbterror.ClassExpression.NewStd(mysql.ErrCutValueGroupConcat)
 	ErrFunctionsNoopImpl           = dbterror.ClassExpression.NewStdErr(mysql.ErrNotSupportedYet, pmysql.Message("function %s has only noop implementation in tidb now, use tidb_enable_noop_functions to enable these functions", nil))
@@ -3026,7 +3026,8 @@ func TestForeignKeyVar(t *testing.T) {
 	tk := testkit.NewTestKit(t, store)
 
 	tk.MustExec("SET FOREIGN_KEY_CHECKS=1")
-	tk.MustQuery("SHOW WARNINGS").Check(testkit.Rows("Warning 8047 variable 'foreign_key_checks' does not yet support value: 1"))
+	tk.MustQuery("SHOW WARNINGS").Check(testkit.Rows())
+	tk.MustQuery("SELECT @@foreign_key_checks").Check(testkit.Rows("1"))
 }
 
 func TestUserVarMockWindFunc(t *testing.T) {
@@ -7075,3 +7076,35 @@ func TestIssue32488(t *testing.T) {
 	tk.MustQuery("select binary upper(a), lower(a) from t order by upper(a);").Check([][]interface{}{{"İ i"}, {"Ʞ ʞ"}})
 	tk.MustQuery("select distinct upper(a), lower(a) from t order by upper(a);").Check([][]interface{}{{"İ i"}, {"Ʞ ʞ"}})
 }
+
+func TestRegexpFunctions(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a varchar(20) collate utf8mb4_bin, b varchar(20) collate utf8mb4_general_ci, c varbinary(20))")
+	tk.MustExec("insert into t values ('dog cat dog', 'Dog Cat', 'dog cat dog'), ('你好世界好', '你好', '你好世界好'), (null, null, null)")
+
+	tk.MustQuery("select regexp_like(a, 'D'), regexp_like(b, 'd'), regexp_like(b, 'd', 'c'), regexp_like(a, 'D', 'i') from t").
+		Check(testkit.Rows("0 1 0 1", "0 0 0 0", "<nil> <nil> <nil> <nil>"))
+	tk.MustQuery("select regexp_instr(a, '好'), regexp_instr(c, '好'), regexp_instr(a, 'dog', 1, 2), regexp_instr(a, 'dog', 1, 1, 1) from t").
+		Check(testkit.Rows("0 0 9 4", "2 4 0 0", "<nil> <nil> <nil> <nil>"))
+	tk.MustQuery("select regexp_substr(a, '[a-z]+', 1, 2), regexp_substr(a, '.', 3), regexp_replace(a, 'dog', 'fox', 1, 2), regexp_replace(a, '(d)og', '$1ig') from t").
+		Check(testkit.Rows("cat g dog cat fox dig cat dig", "<nil> 世 你好世界好 你好世界好", "<nil> <nil> <nil> <nil>"))
+
+	// TiKV doesn't implement the REGEXP_* functions, so they are evaluated in TiDB.
+	rows := tk.MustQuery("explain select a from t where regexp_like(a, 'cat') and regexp_instr(b, 'c') > 0").Rows()
+	require.Equal(t, "root", rows[1][2])
+	require.Contains(t, rows[1][4], "regexp_like")
+	tk.MustQuery("select a from t where regexp_like(a, 'cat') and regexp_instr(b, 'c') > 0").Check(testkit.Rows("dog cat dog"))
+	tk.MustQuery("select c from t where regexp_substr(c, '界好') = '界好'").Check(testkit.Rows("你好世界好"))
+
+	err := tk.QueryToErr("select regexp_like(a, 'a', 'x') from t")
+	require.True(t, expression.ErrRegexpInvalidFlag.Equal(err))
+	err = tk.QueryToErr("select regexp_instr(a, 'a', 30) from t")
+	require.True(t, expression.ErrRegexpIndexOutOfBounds.Equal(err))
+	err = tk.QueryToErr("select regexp_instr(a, 'a', 1, 1, 2) from t")
+	require.True(t, expression.ErrRegexpIllegalArgument.Equal(err))
+}
@@ -271,6 +271,18 @@ func NewFunctionInternal(ctx sessionctx.Context, funcName string, retType *types
 	return expr
 }
 
+// NewFunctionWithArgs is similar to NewFunctionInternal, but it creates the function of sf with
+// the new arguments. The calls of stored functions can't be created by the function name, they're
+// built from the routine of sf.
+func NewFunctionWithArgs(sf *ScalarFunction, retType *types.FieldType, args ...Expression) Expression {
+	if sig, ok := sf.Function.(*builtinStoredFuncSig); ok {
+		expr, err := BuildStoredFunction(sf.GetCtx(), sig.schema, sig.routine, args)
+		terror.Log(err)
+		return expr
+	}
+	return NewFunctionInternal(sf.GetCtx(), sf.FuncName.L, retType, args...)
+}
+
 // ScalarFuncs2Exprs converts []*ScalarFunction to []Expression.
 func ScalarFuncs2Exprs(funcs []*ScalarFunction) []Expression {
 	result := make([]Expression, 0, len(funcs))
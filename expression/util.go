@@ -283,7 +283,7 @@ func ColumnSubstituteImpl(expr Expression, schema *Schema, newExprs []Expression
 			}
 		}
 		if substituted {
-			return true, NewFunctionInternal(v.GetCtx(), v.FuncName.L, v.RetType, refExprArr.Result()...)
+			return true, NewFunctionWithArgs(v, v.RetType, refExprArr.Result()...)
 		}
 	}
 	return false, expr
@@ -376,7 +376,7 @@ func SubstituteCorCol2Constant(expr Expression) (Expression, error) {
 		if x.FuncName.L == ast.Cast {
 			newSf = BuildCastFunction(x.GetCtx(), newArgs[0], x.RetType)
 		} else {
-			newSf = NewFunctionInternal(x.GetCtx(), x.FuncName.L, x.GetType(), newArgs...)
+			newSf = NewFunctionWithArgs(x, x.GetType(), newArgs...)
 		}
 		return newSf, nil
 	case *CorrelatedColumn:
@@ -868,6 +868,9 @@ func IsMutableEffectsExpr(expr Expression) bool {
 		if _, ok := mutableEffectsFunctions[x.FuncName.L]; ok {
 			return true
 		}
+		if sig, ok := x.Function.(*builtinStoredFuncSig); ok && !sig.routine.Deterministic {
+			return true
+		}
 		for _, arg := range x.GetArgs() {
 			if IsMutableEffectsExpr(arg) {
 				return true
@@ -73,12 +73,14 @@ func (b *Builder) ApplyDiff(m *meta.Meta, diff *model.SchemaDiff) ([]int64, erro
 		return b.applyAlterPolicy(m, diff)
 	case model.ActionTruncateTablePartition, model.ActionTruncateTable:
 		return b.applyTruncateTableOrPartition(m, diff)
-	case model.ActionDropTable, model.ActionDropTablePartition:
+	case model.ActionDropTable, model.ActionDropTablePartition, model.ActionReorganizePartition:
 		return b.applyDropTableOrParition(m, diff)
 	case model.ActionRecoverTable:
 		return b.applyRecoverTable(m, diff)
 	case model.ActionCreateTables:
 		return b.applyCreateTables(m, diff)
+	case model.ActionCreateRoutine, model.ActionDropRoutine:
+		return nil, b.applyRoutineChange(m, diff)
 	default:
 		return b.applyDefaultAction(m, diff)
 	}
@@ -395,6 +397,22 @@ func (b *Builder) applyModifySchemaDefaultPlacement(m *meta.Meta, diff *model.Sc
 	return nil
 }
 
+func (b *Builder) applyRoutineChange(m *meta.Meta, diff *model.SchemaDiff) error {
+	di, ok := b.is.SchemaByID(diff.SchemaID)
+	if !ok {
+		return ErrDatabaseNotExists.GenWithStackByArgs(
+			fmt.Sprintf("(Schema ID %d)", diff.SchemaID),
+		)
+	}
+	routines, err := m.ListRoutines(diff.SchemaID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newDbInfo := b.getSchemaAndCopyIfNecessary(di.Name.L)
+	newDbInfo.Routines = routines
+	return nil
+}
+
 func (b *Builder) applyDropPolicy(PolicyID int64) []int64 {
 	po, ok := b.is.PolicyByID(PolicyID)
 	if !ok {
@@ -515,6 +533,7 @@ func (b *Builder) applyCreateTable(m *meta.Meta, dbInfo *model.DBInfo, tableID i
 	}
 	tableNames := b.is.schemaMap[dbInfo.Name.L]
 	tableNames.tables[tblInfo.Name.L] = tbl
+	b.is.addReferredForeignKeys(dbInfo.Name, tblInfo)
 	bucketIdx := tableBucketIdx(tableID)
 	sortedTbls := b.is.sortedTablesBuckets[bucketIdx]
 	sortedTbls = append(sortedTbls, tbl)
@@ -566,11 +585,12 @@ func (b *Builder) applyDropTable(dbInfo *model.DBInfo, tableID int64, affected [
 	if idx == -1 {
 		return affected
 	}
+	tblInfo := sortedTbls[idx].Meta()
 	if tableNames, ok := b.is.schemaMap[dbInfo.Name.L]; ok {
-		tblInfo := sortedTbls[idx].Meta()
 		delete(tableNames.tables, tblInfo.Name.L)
 		affected = appendAffectedIDs(affected, tblInfo)
 	}
+	b.is.deleteReferredForeignKeys(dbInfo.Name, tblInfo)
 	// Remove the table in sorted table slice.
 	b.is.sortedTablesBuckets[bucketIdx] = append(sortedTbls[0:idx], sortedTbls[idx+1:]...)
 
@@ -618,6 +638,7 @@ func (b *Builder) InitWithOldInfoSchema(oldSchema InfoSchema) *Builder {
 	b.copySchemasMap(oldIS)
 	b.copyBundlesMap(oldIS)
 	b.copyPoliciesMap(oldIS)
+	b.copyReferredFKMap(oldIS)
 
 	copy(b.is.sortedTablesBuckets, oldIS.sortedTablesBuckets)
 	return b
@@ -643,6 +664,12 @@ func (b *Builder) copyPoliciesMap(oldIS *infoSchema) {
 	}
 }
 
+func (b *Builder) copyReferredFKMap(oldIS *infoSchema) {
+	for k, v := range oldIS.referredFKMap {
+		b.is.referredFKMap[k] = v
+	}
+}
+
 // getSchemaAndCopyIfNecessary creates a new schemaTables instance when a table in the database has changed.
 // It also does modifications on the new one because old schemaTables must be read-only.
 // And it will only copy the changed database once in the lifespan of the Builder.
@@ -735,6 +762,7 @@ func (b *Builder) createSchemaTablesForDB(di *model.DBInfo, tableFromMeta tableF
 			return errors.Wrap(err, fmt.Sprintf("Build table `%s`.`%s` schema failed", di.Name.O, t.Name.O))
 		}
 		schTbls.tables[t.Name.L] = tbl
+		b.is.addReferredForeignKeys(di.Name, t)
 		sortedTbls := b.is.sortedTablesBuckets[tableBucketIdx(t.ID)]
 		b.is.sortedTablesBuckets[tableBucketIdx(t.ID)] = append(sortedTbls, tbl)
 	}
@@ -762,6 +790,7 @@ func NewBuilder(store kv.Storage, factory func() (pools.Resource, error)) *Build
 			policyMap:           map[string]*model.PolicyInfo{},
 			ruleBundleMap:       map[string]*placement.Bundle{},
 			sortedTablesBuckets: make([]sortedTables, bucketCount),
+			referredFKMap:       map[schemaAndTableName][]*ReferredFKInfo{},
 		},
 		dirtyDB: make(map[string]bool),
 		factory: factory,
@@ -80,4 +80,12 @@ var (
 	ErrAdminCheckTable = dbterror.ClassSchema.NewStd(mysql.ErrAdminCheckTable)
 	// ErrEmptyDatabase returns when the database is unexpectedly empty.
 	ErrEmptyDatabase = dbterror.ClassSchema.NewStd(mysql.ErrBadDB)
+	// ErrRoutineExists returns for stored procedure or function already exists.
+	ErrRoutineExists = dbterror.ClassSchema.NewStd(mysql.ErrSpAlreadyExists)
+	// ErrRoutineNotExists returns for stored procedure or function not exists.
+	ErrRoutineNotExists = dbterror.ClassSchema.NewStd(mysql.ErrSpDoesNotExist)
+	// ErrTriggerExists returns for trigger already exists.
+	ErrTriggerExists = dbterror.ClassSchema.NewStd(mysql.ErrTrgAlreadyExists)
+	// ErrTriggerNotExists returns for trigger not exists.
+	ErrTriggerNotExists = dbterror.ClassSchema.NewStd(mysql.ErrTrgDoesNotExist)
 )
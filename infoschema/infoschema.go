@@ -59,6 +59,27 @@ type InfoSchema interface {
 	RuleBundles() []*placement.Bundle
 	// AllPlacementPolicies returns all placement policies
 	AllPlacementPolicies() []*model.PolicyInfo
+	// RoutineByName is used to find the stored procedure or function.
+	RoutineByName(schema, name model.CIStr, tp model.RoutineType) (*model.RoutineInfo, bool)
+	// SchemaRoutines returns all stored procedures and functions of the schema.
+	SchemaRoutines(schema model.CIStr) []*model.RoutineInfo
+	// TriggerByName is used to find the trigger and the table it belongs to.
+	TriggerByName(schema, name model.CIStr) (table.Table, *model.TriggerInfo, bool)
+	// ReferredForeignKeys returns the foreign keys referring to the table.
+	ReferredForeignKeys(schema, table model.CIStr) []*ReferredFKInfo
+}
+
+// ReferredFKInfo is a foreign key of the child table referring to a table.
+type ReferredFKInfo struct {
+	ChildSchema model.CIStr
+	ChildTable  model.CIStr
+	ChildFKName model.CIStr
+}
+
+// schemaAndTableName is the lower case names of the schema and the table.
+type schemaAndTableName struct {
+	schema string
+	table  string
 }
 
 type sortedTables []table.Table
@@ -106,6 +127,10 @@ type infoSchema struct {
 	// sortedTablesBuckets is a slice of sortedTables, a table's bucket index is (tableID % bucketCount).
 	sortedTablesBuckets []sortedTables
 
+	// referredFKMap stores the foreign keys referring to the tables, the key is the referenced table.
+	// The slices are read-only, they're copied when a foreign key is added or deleted.
+	referredFKMap map[schemaAndTableName][]*ReferredFKInfo
+
 	// schemaMetaVersion is the version of schema, and we should check version when change schema.
 	schemaMetaVersion int64
 }
@@ -117,6 +142,7 @@ func MockInfoSchema(tbList []*model.TableInfo) InfoSchema {
 	result.policyMap = make(map[string]*model.PolicyInfo)
 	result.ruleBundleMap = make(map[string]*placement.Bundle)
 	result.sortedTablesBuckets = make([]sortedTables, bucketCount)
+	result.referredFKMap = make(map[schemaAndTableName][]*ReferredFKInfo)
 	dbInfo := &model.DBInfo{ID: 0, Name: model.NewCIStr("test"), Tables: tbList}
 	tableNames := &schemaTables{
 		dbInfo: dbInfo,
@@ -126,6 +152,7 @@ func MockInfoSchema(tbList []*model.TableInfo) InfoSchema {
 	for _, tb := range tbList {
 		tbl := table.MockTableFromMeta(tb)
 		tableNames.tables[tb.Name.L] = tbl
+		result.addReferredForeignKeys(dbInfo.Name, tb)
 		bucketIdx := tableBucketIdx(tb.ID)
 		result.sortedTablesBuckets[bucketIdx] = append(result.sortedTablesBuckets[bucketIdx], tbl)
 	}
@@ -142,6 +169,7 @@ func MockInfoSchemaWithSchemaVer(tbList []*model.TableInfo, schemaVer int64) Inf
 	result.policyMap = make(map[string]*model.PolicyInfo)
 	result.ruleBundleMap = make(map[string]*placement.Bundle)
 	result.sortedTablesBuckets = make([]sortedTables, bucketCount)
+	result.referredFKMap = make(map[schemaAndTableName][]*ReferredFKInfo)
 	dbInfo := &model.DBInfo{ID: 0, Name: model.NewCIStr("test"), Tables: tbList}
 	tableNames := &schemaTables{
 		dbInfo: dbInfo,
@@ -151,6 +179,7 @@ func MockInfoSchemaWithSchemaVer(tbList []*model.TableInfo, schemaVer int64) Inf
 	for _, tb := range tbList {
 		tbl := table.MockTableFromMeta(tb)
 		tableNames.tables[tb.Name.L] = tbl
+		result.addReferredForeignKeys(dbInfo.Name, tb)
 		bucketIdx := tableBucketIdx(tb.ID)
 		result.sortedTablesBuckets[bucketIdx] = append(result.sortedTablesBuckets[bucketIdx], tbl)
 	}
@@ -368,6 +397,77 @@ func HasAutoIncrementColumn(tbInfo *model.TableInfo) (bool, string) {
 	return false, ""
 }
 
+// RoutineByName is used to find the stored procedure or function.
+func (is *infoSchema) RoutineByName(schema, name model.CIStr, tp model.RoutineType) (*model.RoutineInfo, bool) {
+	for _, r := range is.SchemaRoutines(schema) {
+		if r.Type == tp && r.Name.L == name.L {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+// SchemaRoutines returns all stored procedures and functions of the schema.
+func (is *infoSchema) SchemaRoutines(schema model.CIStr) []*model.RoutineInfo {
+	schemaTables, ok := is.schemaMap[schema.L]
+	if !ok {
+		return nil
+	}
+	return schemaTables.dbInfo.Routines
+}
+
+// TriggerByName is used to find the trigger and the table it belongs to, the
+// names of the triggers are unique in a schema.
+func (is *infoSchema) TriggerByName(schema, name model.CIStr) (table.Table, *model.TriggerInfo, bool) {
+	schemaTables, ok := is.schemaMap[schema.L]
+	if !ok {
+		return nil, nil, false
+	}
+	for _, tbl := range schemaTables.tables {
+		tblInfo := tbl.Meta()
+		if idx := tblInfo.FindTriggerIndex(name.L); idx >= 0 {
+			return tbl, tblInfo.Triggers[idx], true
+		}
+	}
+	return nil, nil, false
+}
+
+// ReferredForeignKeys returns the foreign keys referring to the table.
+func (is *infoSchema) ReferredForeignKeys(schema, table model.CIStr) []*ReferredFKInfo {
+	return is.referredFKMap[schemaAndTableName{schema: schema.L, table: table.L}]
+}
+
+// addReferredForeignKeys records the foreign keys of the table as referring to their referenced tables.
+func (is *infoSchema) addReferredForeignKeys(schema model.CIStr, tblInfo *model.TableInfo) {
+	for _, fk := range tblInfo.ForeignKeys {
+		key := schemaAndTableName{schema: fk.GetRefSchema(schema).L, table: fk.RefTable.L}
+		old := is.referredFKMap[key]
+		fks := make([]*ReferredFKInfo, 0, len(old)+1)
+		fks = append(fks, old...)
+		fks = append(fks, &ReferredFKInfo{ChildSchema: schema, ChildTable: tblInfo.Name, ChildFKName: fk.Name})
+		is.referredFKMap[key] = fks
+	}
+}
+
+// deleteReferredForeignKeys removes the foreign keys of the table from their referenced tables.
+func (is *infoSchema) deleteReferredForeignKeys(schema model.CIStr, tblInfo *model.TableInfo) {
+	for _, fk := range tblInfo.ForeignKeys {
+		key := schemaAndTableName{schema: fk.GetRefSchema(schema).L, table: fk.RefTable.L}
+		old := is.referredFKMap[key]
+		fks := make([]*ReferredFKInfo, 0, len(old))
+		for _, rfk := range old {
+			if rfk.ChildSchema.L != schema.L || rfk.ChildTable.L != tblInfo.Name.L || rfk.ChildFKName.L != fk.Name.L {
+				fks = append(fks, rfk)
+			}
+		}
+		if len(fks) == 0 {
+			delete(is.referredFKMap, key)
+		} else {
+			is.referredFKMap[key] = fks
+		}
+	}
+}
+
 // PolicyByName is used to find the policy.
 func (is *infoSchema) PolicyByName(name model.CIStr) (*model.PolicyInfo, bool) {
 	is.policyMutex.RLock()
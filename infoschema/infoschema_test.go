@@ -293,6 +293,7 @@ func TestInfoTables(t *testing.T) {
 		"TIDB_TRX",
 		"DEADLOCKS",
 		"PLACEMENT_POLICIES",
+		"MEMORY_USAGE_OPS_HISTORY",
 	}
 	for _, tbl := range infoTables {
 		tb, err1 := is.TableByName(util.InformationSchemaName, model.NewCIStr(tbl))
@@ -657,3 +658,29 @@ func TestIndexComment(t *testing.T) {
 	tk.MustExec("create table t1 (c1 VARCHAR(10) NOT NULL COMMENT 'Abcdefghijabcd', c2 INTEGER COMMENT 'aBcdefghijab',c3 INTEGER COMMENT '01234567890', c4 INTEGER, c5 INTEGER, c6 INTEGER, c7 INTEGER, c8 VARCHAR(100), c9 CHAR(50), c10 DATETIME, c11 DATETIME, c12 DATETIME,c13 DATETIME, INDEX i1 (c1) COMMENT 'i1 comment',INDEX i2(c2) ) COMMENT='ABCDEFGHIJabc';")
 	tk.MustQuery("SELECT index_comment,char_length(index_comment),COLUMN_NAME FROM information_schema.statistics WHERE table_name='t1' ORDER BY index_comment;").Check(testkit.Rows(" 0 c2", "i1 comment 10 c1"))
 }
+
+func TestReferredForeignKeys(t *testing.T) {
+	store, dom, clean := testkit.CreateMockStoreAndDomain(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table parent (id int primary key)")
+	tk.MustExec("create table child1 (id int, pid int, constraint fk_1 foreign key (pid) references parent (id))")
+	tk.MustExec("create table child2 (id int, pid int, constraint fk_2 foreign key (pid) references parent (id))")
+	referredFKs := func() []string {
+		var fks []string
+		for _, rfk := range dom.InfoSchema().ReferredForeignKeys(model.NewCIStr("test"), model.NewCIStr("parent")) {
+			fks = append(fks, rfk.ChildTable.L+"."+rfk.ChildFKName.L)
+		}
+		return fks
+	}
+	require.ElementsMatch(t, []string{"child1.fk_1", "child2.fk_2"}, referredFKs())
+
+	tk.MustExec("rename table child1 to child3")
+	require.ElementsMatch(t, []string{"child3.fk_1", "child2.fk_2"}, referredFKs())
+	tk.MustExec("alter table child2 drop foreign key fk_2")
+	require.ElementsMatch(t, []string{"child3.fk_1"}, referredFKs())
+	tk.MustExec("drop table child3")
+	require.Empty(t, referredFKs())
+}
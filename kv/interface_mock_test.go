@@ -149,6 +149,20 @@ func (t *mockTxn) ClearDiskFullOpt() {
 	// TODO nothing
 }
 
+func (t *mockTxn) Savepoint(_ string) {}
+
+func (t *mockTxn) RollbackToSavepoint(_ string) (bool, error) {
+	return false, nil
+}
+
+func (t *mockTxn) ReleaseSavepoint(_ string) bool {
+	return false
+}
+
+func (t *mockTxn) Savepoints() []string {
+	return nil
+}
+
 // newMockTxn new a mockTxn.
 func newMockTxn() Transaction {
 	return &mockTxn{
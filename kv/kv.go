@@ -235,6 +235,19 @@ type Transaction interface {
 	SetDiskFullOpt(level kvrpcpb.DiskFullOpt)
 	// clear allowed flag
 	ClearDiskFullOpt()
+
+	// Savepoint records a savepoint with the name, a savepoint with the same name is replaced.
+	Savepoint(name string)
+	// RollbackToSavepoint discards the modifications after the savepoint and removes the later savepoints,
+	// the savepoint itself is kept. The pessimistic locks acquired after the savepoint are retained until
+	// the transaction ends. It returns false if the savepoint doesn't exist.
+	// It must be called when the MemBuffer has no staging buffers.
+	RollbackToSavepoint(name string) (bool, error)
+	// ReleaseSavepoint removes the savepoint and the later savepoints without discarding any modification.
+	// It returns false if the savepoint doesn't exist.
+	ReleaseSavepoint(name string) bool
+	// Savepoints returns the names of the savepoints in the order they are created.
+	Savepoints() []string
 }
 
 // AssertionProto is an interface defined for the assertion protocol.
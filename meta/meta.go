@@ -55,6 +55,7 @@ var (
 //		Table:2 -> table meta data []byte
 //		TID:1 -> int64
 //		TID:2 -> int64
+//		Routine:3 -> stored routine meta data []byte
 //	}
 //
 
@@ -65,6 +66,7 @@ var (
 	mDBs              = []byte("DBs")
 	mDBPrefix         = "DB"
 	mTablePrefix      = "Table"
+	mRoutinePrefix    = "Routine"
 	mSequencePrefix   = "SID"
 	mSeqCyclePrefix   = "SequenceCycle"
 	mTableIDPrefix    = "TID"
@@ -106,6 +108,10 @@ var (
 	ErrTableExists = dbterror.ClassMeta.NewStd(mysql.ErrTableExists)
 	// ErrTableNotExists is the error for table not exists.
 	ErrTableNotExists = dbterror.ClassMeta.NewStd(mysql.ErrNoSuchTable)
+	// ErrRoutineExists is the error for stored routine exists.
+	ErrRoutineExists = dbterror.ClassMeta.NewStd(mysql.ErrSpAlreadyExists)
+	// ErrRoutineNotExists is the error for stored routine not exists.
+	ErrRoutineNotExists = dbterror.ClassMeta.NewStd(mysql.ErrSpDoesNotExist)
 	// ErrDDLReorgElementNotExist is the error for reorg element not exists.
 	ErrDDLReorgElementNotExist = dbterror.ClassMeta.NewStd(errno.ErrDDLReorgElementNotExist)
 )
@@ -207,6 +213,10 @@ func (m *Meta) tableKey(tableID int64) []byte {
 	return []byte(fmt.Sprintf("%s:%d", mTablePrefix, tableID))
 }
 
+func (m *Meta) routineKey(routineID int64) []byte {
+	return []byte(fmt.Sprintf("%s:%d", mRoutinePrefix, routineID))
+}
+
 func (m *Meta) sequenceKey(sequenceID int64) []byte {
 	return []byte(fmt.Sprintf("%s:%d", mSequencePrefix, sequenceID))
 }
@@ -543,6 +553,76 @@ func (m *Meta) ListTables(dbID int64) ([]*model.TableInfo, error) {
 	return tables, nil
 }
 
+// CreateRoutine creates a stored procedure or function in the database.
+func (m *Meta) CreateRoutine(dbID int64, routineInfo *model.RoutineInfo) error {
+	dbKey := m.dbKey(dbID)
+	if err := m.checkDBExists(dbKey); err != nil {
+		return errors.Trace(err)
+	}
+
+	routineKey := m.routineKey(routineInfo.ID)
+	v, err := m.txn.HGet(dbKey, routineKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if v != nil {
+		return ErrRoutineExists.GenWithStack("routine already exists")
+	}
+
+	data, err := json.Marshal(routineInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return m.txn.HSet(dbKey, routineKey, data)
+}
+
+// DropRoutine drops the stored procedure or function in the database.
+func (m *Meta) DropRoutine(dbID int64, routineID int64) error {
+	dbKey := m.dbKey(dbID)
+	if err := m.checkDBExists(dbKey); err != nil {
+		return errors.Trace(err)
+	}
+
+	routineKey := m.routineKey(routineID)
+	v, err := m.txn.HGet(dbKey, routineKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if v == nil {
+		return ErrRoutineNotExists.GenWithStack("routine doesn't exist")
+	}
+	return errors.Trace(m.txn.HDel(dbKey, routineKey))
+}
+
+// ListRoutines shows all stored procedures and functions in the database.
+func (m *Meta) ListRoutines(dbID int64) ([]*model.RoutineInfo, error) {
+	dbKey := m.dbKey(dbID)
+	if err := m.checkDBExists(dbKey); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	res, err := m.txn.HGetAll(dbKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	routines := make([]*model.RoutineInfo, 0)
+	for _, r := range res {
+		if !strings.HasPrefix(string(r.Field), mRoutinePrefix) {
+			continue
+		}
+
+		routineInfo := &model.RoutineInfo{}
+		err = json.Unmarshal(r.Value, routineInfo)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		routines = append(routines, routineInfo)
+	}
+
+	return routines, nil
+}
+
 // ListDatabases shows all databases.
 func (m *Meta) ListDatabases() ([]*model.DBInfo, error) {
 	res, err := m.txn.HGetAll(mDBs)
@@ -1022,6 +1102,8 @@ var (
 	ColumnElementKey ElementKeyType = []byte("_col_")
 	// IndexElementKey is the key for index element.
 	IndexElementKey ElementKeyType = []byte("_idx_")
+	// PartitionElementKey is the key for partition reorganization element.
+	PartitionElementKey ElementKeyType = []byte("_prt_")
 )
 
 const elementKeyLen = 5
@@ -1062,6 +1144,8 @@ func DecodeElement(b []byte) (*Element, error) {
 		tp = IndexElementKey
 	case string(ColumnElementKey):
 		tp = ColumnElementKey
+	case string(PartitionElementKey):
+		tp = PartitionElementKey
 	default:
 		return nil, errors.Errorf("invalid encoded element key prefix %q", prefix)
 	}
@@ -103,6 +103,63 @@ func TestPlacementPolicy(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestRoutine(t *testing.T) {
+	store, err := mockstore.NewMockStore()
+	require.NoError(t, err)
+
+	defer func() {
+		err := store.Close()
+		require.NoError(t, err)
+	}()
+
+	txn, err := store.Begin()
+	require.NoError(t, err)
+
+	m := meta.NewMeta(txn)
+	err = m.CreateDatabase(&model.DBInfo{ID: 1, Name: model.NewCIStr("a")})
+	require.NoError(t, err)
+	err = m.CreateTableOrView(1, &model.TableInfo{ID: 2, Name: model.NewCIStr("t")})
+	require.NoError(t, err)
+
+	routine := &model.RoutineInfo{
+		ID:      3,
+		Name:    model.NewCIStr("p"),
+		Type:    model.RoutineProcedure,
+		Params:  []*model.RoutineParam{},
+		Body:    "select 1",
+		Created: time.Unix(1, 0),
+	}
+	err = m.CreateRoutine(1, routine)
+	require.NoError(t, err)
+	err = m.CreateRoutine(1, routine)
+	require.True(t, meta.ErrRoutineExists.Equal(err))
+	err = m.CreateRoutine(4, routine)
+	require.True(t, meta.ErrDBNotExists.Equal(err))
+
+	routines, err := m.ListRoutines(1)
+	require.NoError(t, err)
+	require.Len(t, routines, 1)
+	require.Equal(t, routine.Name, routines[0].Name)
+	require.Equal(t, routine.Body, routines[0].Body)
+	require.True(t, routine.Created.Equal(routines[0].Created))
+
+	// the routines are not listed as tables.
+	tables, err := m.ListTables(1)
+	require.NoError(t, err)
+	require.Len(t, tables, 1)
+
+	err = m.DropRoutine(1, 3)
+	require.NoError(t, err)
+	err = m.DropRoutine(1, 3)
+	require.True(t, meta.ErrRoutineNotExists.Equal(err))
+	routines, err = m.ListRoutines(1)
+	require.NoError(t, err)
+	require.Len(t, routines, 0)
+
+	err = txn.Rollback()
+	require.NoError(t, err)
+}
+
 func TestBackupAndRestoreAutoIDs(t *testing.T) {
 	store, err := mockstore.NewMockStore()
 	require.NoError(t, err)
@@ -432,6 +489,7 @@ func TestElement(t *testing.T) {
 	checkElement(key, errors.Errorf(`invalid encoded element key prefix "_col\x00"`))
 	checkElement(meta.IndexElementKey, nil)
 	checkElement(meta.ColumnElementKey, nil)
+	checkElement(meta.PartitionElementKey, nil)
 	key = []byte("inexistent")
 	checkElement(key, errors.Errorf("invalid encoded element key prefix %q", key[:5]))
 
@@ -35,6 +35,14 @@ var (
 			Help:      "Counters of packet IO bytes.",
 		}, []string{LblType})
 
+	CompressedPacketIOCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb",
+			Subsystem: "server",
+			Name:      "compressed_packet_io_bytes",
+			Help:      "Counters of packet IO bytes of the compressed protocol, before (raw) and after (compressed) the compression.",
+		}, []string{LblType, "algorithm", "format"})
+
 	QueryDurationHistogram = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Namespace: "tidb",
@@ -254,6 +262,14 @@ var (
 			Name:      "cpu_profile_total",
 			Help:      "Counter of cpu profiling",
 		})
+
+	ServerMemoryLimitOpsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb",
+			Subsystem: "server",
+			Name:      "memory_limit_ops_total",
+			Help:      "Counter of the operations taken on queries when the memory usage exceeds tidb_server_memory_limit.",
+		}, []string{LblType})
 )
 
 // ExecuteErrorToLabel converts an execute error to label.
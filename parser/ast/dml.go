@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb/parser/format"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/types"
 )
 
 var (
@@ -513,7 +514,7 @@ type TableSource struct {
 	node
 
 	// Source is the source of the data, can be a TableName,
-	// a SelectStmt, a SetOprStmt, or a JoinNode.
+	// a SelectStmt, a SetOprStmt, a JoinNode, or a JSONTable.
 	Source ResultSetNode
 
 	// AsName is the alias name of the table source.
@@ -597,6 +598,166 @@ func (n *TableSource) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// JSONTableColumnType is the type of the column in JSON_TABLE.
+type JSONTableColumnType int
+
+// JSONTable column types.
+const (
+	JSONTableColumnPath JSONTableColumnType = iota
+	JSONTableColumnExists
+	JSONTableColumnOrdinality
+	JSONTableColumnNested
+)
+
+// JSONTableOnResponseType is the type of the ON EMPTY and ON ERROR clauses of the JSON_TABLE column.
+type JSONTableOnResponseType int
+
+// JSONTable on response types.
+const (
+	JSONTableOnResponseNull JSONTableOnResponseType = iota
+	JSONTableOnResponseError
+	JSONTableOnResponseDefault
+)
+
+// JSONTableOnResponse is the ON EMPTY or ON ERROR clause of the JSON_TABLE column.
+type JSONTableOnResponse struct {
+	Type JSONTableOnResponseType
+	// Default is the JSON string of `DEFAULT json_string`.
+	Default string
+}
+
+// Restore implements Node interface.
+func (n *JSONTableOnResponse) Restore(ctx *format.RestoreCtx) error {
+	switch n.Type {
+	case JSONTableOnResponseNull:
+		ctx.WriteKeyWord("NULL")
+	case JSONTableOnResponseError:
+		ctx.WriteKeyWord("ERROR")
+	case JSONTableOnResponseDefault:
+		ctx.WriteKeyWord("DEFAULT ")
+		ctx.WriteString(n.Default)
+	default:
+		return errors.New("JSONTableOnResponseType has an error while matching")
+	}
+	return nil
+}
+
+// JSONTableColumn is a column definition in the COLUMNS clause of JSON_TABLE.
+type JSONTableColumn struct {
+	Type JSONTableColumnType
+	Name model.CIStr
+	// Tp is the type of the path column or the exists column.
+	Tp *types.FieldType
+	// Path is the JSON path of the path column, the exists column or the nested columns.
+	Path    string
+	OnEmpty *JSONTableOnResponse
+	OnError *JSONTableOnResponse
+	// NestedColumns is the column list of `NESTED PATH path COLUMNS (...)`.
+	NestedColumns []*JSONTableColumn
+}
+
+// Restore implements Node interface.
+func (n *JSONTableColumn) Restore(ctx *format.RestoreCtx) error {
+	switch n.Type {
+	case JSONTableColumnOrdinality:
+		ctx.WriteName(n.Name.O)
+		ctx.WriteKeyWord(" FOR ORDINALITY")
+	case JSONTableColumnPath, JSONTableColumnExists:
+		ctx.WriteName(n.Name.O)
+		ctx.WritePlain(" ")
+		if err := n.Tp.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore JSONTableColumn.Tp")
+		}
+		if n.Type == JSONTableColumnExists {
+			ctx.WriteKeyWord(" EXISTS")
+		}
+		ctx.WriteKeyWord(" PATH ")
+		ctx.WriteString(n.Path)
+		if n.OnEmpty != nil {
+			ctx.WritePlain(" ")
+			if err := n.OnEmpty.Restore(ctx); err != nil {
+				return errors.Annotate(err, "An error occurred while restore JSONTableColumn.OnEmpty")
+			}
+			ctx.WriteKeyWord(" ON EMPTY")
+		}
+		if n.OnError != nil {
+			ctx.WritePlain(" ")
+			if err := n.OnError.Restore(ctx); err != nil {
+				return errors.Annotate(err, "An error occurred while restore JSONTableColumn.OnError")
+			}
+			ctx.WriteKeyWord(" ON ERROR")
+		}
+	case JSONTableColumnNested:
+		ctx.WriteKeyWord("NESTED PATH ")
+		ctx.WriteString(n.Path)
+		if err := restoreJSONTableColumns(ctx, n.NestedColumns); err != nil {
+			return errors.Annotate(err, "An error occurred while restore JSONTableColumn.NestedColumns")
+		}
+	default:
+		return errors.New("JSONTableColumnType has an error while matching")
+	}
+	return nil
+}
+
+func restoreJSONTableColumns(ctx *format.RestoreCtx, columns []*JSONTableColumn) error {
+	ctx.WriteKeyWord(" COLUMNS ")
+	ctx.WritePlain("(")
+	for i, col := range columns {
+		if i > 0 {
+			ctx.WritePlain(", ")
+		}
+		if err := col.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore JSONTableColumn: [%v]", i)
+		}
+	}
+	ctx.WritePlain(")")
+	return nil
+}
+
+// JSONTable is the JSON_TABLE table function, which extracts the data from a JSON document
+// and returns it as a relational table.
+// See https://dev.mysql.com/doc/refman/8.0/en/json-table-functions.html
+type JSONTable struct {
+	node
+
+	Expr    ExprNode
+	Path    string
+	Columns []*JSONTableColumn
+}
+
+func (*JSONTable) resultSet() {}
+
+// Restore implements Node interface.
+func (n *JSONTable) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("JSON_TABLE")
+	ctx.WritePlain("(")
+	if err := n.Expr.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore JSONTable.Expr")
+	}
+	ctx.WritePlain(", ")
+	ctx.WriteString(n.Path)
+	if err := restoreJSONTableColumns(ctx, n.Columns); err != nil {
+		return errors.Annotate(err, "An error occurred while restore JSONTable.Columns")
+	}
+	ctx.WritePlain(")")
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *JSONTable) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*JSONTable)
+	node, ok := n.Expr.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Expr = node.(ExprNode)
+	return v.Leave(n)
+}
+
 // SelectLockType is the lock type for SelectStmt.
 type SelectLockType int
 
@@ -835,6 +996,8 @@ func (n *ByItem) Accept(v Visitor) (Node, bool) {
 type GroupByClause struct {
 	node
 	Items []*ByItem
+	// Rollup indicates whether the clause has the `WITH ROLLUP` modifier.
+	Rollup bool
 }
 
 // Restore implements Node interface.
@@ -848,6 +1011,9 @@ func (n *GroupByClause) Restore(ctx *format.RestoreCtx) error {
 			return errors.Annotatef(err, "An error occurred while restore GroupByClause.Items[%d]", i)
 		}
 	}
+	if n.Rollup {
+		ctx.WriteKeyWord(" WITH ROLLUP")
+	}
 	return nil
 }
 
@@ -1499,6 +1665,14 @@ func (n *SelectStmt) Accept(v Visitor) (Node, bool) {
 		}
 	}
 
+	if n.SelectIntoOpt != nil {
+		node, ok := n.SelectIntoOpt.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.SelectIntoOpt = node.(*SelectIntoOption)
+	}
+
 	return v.Leave(n)
 }
 
@@ -2605,6 +2779,10 @@ const (
 	ShowPlacementForTable
 	ShowPlacementForPartition
 	ShowPlacementLabels
+	ShowFunctionStatus
+	ShowCreateProcedure
+	ShowCreateFunction
+	ShowCreateTrigger
 )
 
 const (
@@ -2710,6 +2888,21 @@ func (n *ShowStmt) Restore(ctx *format.RestoreCtx) error {
 	case ShowCreatePlacementPolicy:
 		ctx.WriteKeyWord("CREATE PLACEMENT POLICY ")
 		ctx.WriteName(n.DBName)
+	case ShowCreateProcedure:
+		ctx.WriteKeyWord("CREATE PROCEDURE ")
+		if err := n.Table.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore ShowStmt.PROCEDURE")
+		}
+	case ShowCreateFunction:
+		ctx.WriteKeyWord("CREATE FUNCTION ")
+		if err := n.Table.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore ShowStmt.FUNCTION")
+		}
+	case ShowCreateTrigger:
+		ctx.WriteKeyWord("CREATE TRIGGER ")
+		if err := n.Table.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore ShowStmt.TRIGGER")
+		}
 	case ShowCreateUser:
 		ctx.WriteKeyWord("CREATE USER ")
 		if err := n.User.Restore(ctx); err != nil {
@@ -2903,6 +3096,8 @@ func (n *ShowStmt) Restore(ctx *format.RestoreCtx) error {
 			restoreShowDatabaseNameOpt()
 		case ShowProcedureStatus:
 			ctx.WriteKeyWord("PROCEDURE STATUS")
+		case ShowFunctionStatus:
+			ctx.WriteKeyWord("FUNCTION STATUS")
 		case ShowEvents:
 			ctx.WriteKeyWord("EVENTS")
 			restoreShowDatabaseNameOpt()
@@ -3103,12 +3298,28 @@ type SelectIntoOption struct {
 	FileName   string
 	FieldsInfo *FieldsClause
 	LinesInfo  *LinesClause
+	// Variables are the targets of SELECT ... INTO var_list, each of them is
+	// either a *VariableExpr for a user variable or a *ColumnNameExpr for a
+	// local variable of a stored routine.
+	Variables []ExprNode
 }
 
 // Restore implements Node interface.
 func (n *SelectIntoOption) Restore(ctx *format.RestoreCtx) error {
+	if n.Tp == SelectIntoVars {
+		ctx.WriteKeyWord("INTO ")
+		for i, v := range n.Variables {
+			if i != 0 {
+				ctx.WritePlain(", ")
+			}
+			if err := v.Restore(ctx); err != nil {
+				return errors.Annotatef(err, "An error occurred while restore SelectInto.Variables[%d]", i)
+			}
+		}
+		return nil
+	}
 	if n.Tp != SelectIntoOutfile {
-		// only support SELECT/TABLE/VALUES ... INTO OUTFILE statement now
+		// only support SELECT/TABLE/VALUES ... INTO OUTFILE and INTO var_list statement now
 		return errors.New("Unsupported SelectionInto type")
 	}
 
@@ -3133,6 +3344,14 @@ func (n *SelectIntoOption) Accept(v Visitor) (Node, bool) {
 	if skipChildren {
 		return v.Leave(newNode)
 	}
+	n = newNode.(*SelectIntoOption)
+	for i, val := range n.Variables {
+		node, ok := val.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Variables[i] = node.(ExprNode)
+	}
 	return v.Leave(n)
 }
 
@@ -211,6 +211,10 @@ const (
 	Ord             = "ord"
 	Position        = "position"
 	Quote           = "quote"
+	RegexpInStr     = "regexp_instr"
+	RegexpLike      = "regexp_like"
+	RegexpReplace   = "regexp_replace"
+	RegexpSubstr    = "regexp_substr"
 	Repeat          = "repeat"
 	Replace         = "replace"
 	Reverse         = "reverse"
@@ -269,6 +273,7 @@ const (
 	// miscellaneous functions
 	AnyValue        = "any_value"
 	DefaultFunc     = "default_func"
+	Grouping        = "grouping"
 	InetAton        = "inet_aton"
 	InetNtoa        = "inet_ntoa"
 	Inet6Aton       = "inet6_aton"
@@ -245,6 +245,8 @@ func TestTableOptimizerHintRestore(t *testing.T) {
 		{"USE_INDEX_MERGE(t1 c1)", "USE_INDEX_MERGE(`t1` `c1`)"},
 		{"USE_INDEX_MERGE(@sel1 t1 c1)", "USE_INDEX_MERGE(@`sel1` `t1` `c1`)"},
 		{"USE_INDEX_MERGE(t1@sel1 c1)", "USE_INDEX_MERGE(`t1`@`sel1` `c1`)"},
+		{"USE_INDEX_MERGE_INTERSECTION(t1 c1, c2)", "USE_INDEX_MERGE_INTERSECTION(`t1` `c1`, `c2`)"},
+		{"USE_INDEX_MERGE_INTERSECTION(@sel1 t1 c1, c2)", "USE_INDEX_MERGE_INTERSECTION(@`sel1` `t1` `c1`, `c2`)"},
 		{"USE_TOJA(TRUE)", "USE_TOJA(TRUE)"},
 		{"USE_TOJA(FALSE)", "USE_TOJA(FALSE)"},
 		{"USE_TOJA(@sel1 TRUE)", "USE_TOJA(@`sel1` TRUE)"},
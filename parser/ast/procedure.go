@@ -0,0 +1,938 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/types"
+)
+
+var (
+	_ DDLNode = &CreateRoutineStmt{}
+	_ DDLNode = &DropRoutineStmt{}
+
+	_ StmtNode = &BlockStmt{}
+	_ StmtNode = &DeclareVarStmt{}
+	_ StmtNode = &DeclareCursorStmt{}
+	_ StmtNode = &DeclareHandlerStmt{}
+	_ StmtNode = &IfStmt{}
+	_ StmtNode = &LoopStmt{}
+	_ StmtNode = &WhileStmt{}
+	_ StmtNode = &RepeatStmt{}
+	_ StmtNode = &LeaveStmt{}
+	_ StmtNode = &IterateStmt{}
+	_ StmtNode = &ReturnStmt{}
+	_ StmtNode = &OpenCursorStmt{}
+	_ StmtNode = &FetchCursorStmt{}
+	_ StmtNode = &CloseCursorStmt{}
+	_ StmtNode = &SignalStmt{}
+
+	_ Node = &RoutineParam{}
+	_ Node = &IfBranch{}
+	_ Node = &HandlerCondition{}
+)
+
+// RoutineParam is a parameter of a stored procedure or function.
+type RoutineParam struct {
+	node
+
+	Mode model.RoutineParamMode
+	Name string
+	Tp   *types.FieldType
+}
+
+// Restore implements Node interface.
+func (n *RoutineParam) Restore(ctx *format.RestoreCtx) error {
+	switch n.Mode {
+	case model.RoutineParamOut:
+		ctx.WriteKeyWord("OUT ")
+	case model.RoutineParamInOut:
+		ctx.WriteKeyWord("INOUT ")
+	}
+	ctx.WriteName(n.Name)
+	ctx.WritePlain(" ")
+	if err := n.Tp.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore RoutineParam.Tp")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *RoutineParam) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*RoutineParam)
+	return v.Leave(n)
+}
+
+// CreateRoutineStmt is a statement to create a stored procedure or function.
+// See https://dev.mysql.com/doc/refman/8.0/en/create-procedure.html
+type CreateRoutineStmt struct {
+	ddlNode
+
+	Type        model.RoutineType
+	IfNotExists bool
+	Definer     *auth.UserIdentity
+	Name        *TableName
+	Params      []*RoutineParam
+	// ReturnType is only used by stored functions.
+	ReturnType *types.FieldType
+
+	Comment       string
+	Deterministic bool
+	DataAccess    model.RoutineDataAccess
+	Security      model.ViewSecurity
+
+	// Body is the routine body, its Text() is the original text of the body.
+	Body StmtNode
+}
+
+// Restore implements Node interface.
+func (n *CreateRoutineStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE ")
+	if n.Definer != nil && !n.Definer.CurrentUser {
+		ctx.WriteKeyWord("DEFINER")
+		ctx.WritePlain(" = ")
+		ctx.WriteName(n.Definer.Username)
+		if n.Definer.Hostname != "" {
+			ctx.WritePlain("@")
+			ctx.WriteName(n.Definer.Hostname)
+		}
+		ctx.WritePlain(" ")
+	}
+	ctx.WriteKeyWord(n.Type.String())
+	ctx.WritePlain(" ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	if err := n.Name.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateRoutineStmt.Name")
+	}
+	ctx.WritePlain("(")
+	for i, param := range n.Params {
+		if i != 0 {
+			ctx.WritePlain(", ")
+		}
+		if err := param.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore CreateRoutineStmt.Params[%d]", i)
+		}
+	}
+	ctx.WritePlain(")")
+	if n.Type == model.RoutineFunction {
+		ctx.WriteKeyWord(" RETURNS ")
+		if err := n.ReturnType.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore CreateRoutineStmt.ReturnType")
+		}
+	}
+	if n.Comment != "" {
+		ctx.WriteKeyWord(" COMMENT ")
+		ctx.WriteString(n.Comment)
+	}
+	if n.Deterministic {
+		ctx.WriteKeyWord(" DETERMINISTIC")
+	}
+	if n.DataAccess != model.RoutineContainsSQL {
+		ctx.WritePlain(" ")
+		ctx.WriteKeyWord(n.DataAccess.String())
+	}
+	if n.Security == model.SecurityInvoker {
+		ctx.WriteKeyWord(" SQL SECURITY INVOKER")
+	}
+	ctx.WritePlain(" ")
+	if err := n.Body.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateRoutineStmt.Body")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateRoutineStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateRoutineStmt)
+	node, ok := n.Name.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Name = node.(*TableName)
+	for i, param := range n.Params {
+		node, ok := param.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Params[i] = node.(*RoutineParam)
+	}
+	node, ok = n.Body.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Body = node.(StmtNode)
+	return v.Leave(n)
+}
+
+// DropRoutineStmt is a statement to drop a stored procedure or function.
+type DropRoutineStmt struct {
+	ddlNode
+
+	Type     model.RoutineType
+	IfExists bool
+	Name     *TableName
+}
+
+// Restore implements Node interface.
+func (n *DropRoutineStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP ")
+	ctx.WriteKeyWord(n.Type.String())
+	ctx.WritePlain(" ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	if err := n.Name.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore DropRoutineStmt.Name")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DropRoutineStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropRoutineStmt)
+	node, ok := n.Name.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Name = node.(*TableName)
+	return v.Leave(n)
+}
+
+func restoreRoutineStmts(ctx *format.RestoreCtx, stmts []StmtNode, name string) error {
+	for i, stmt := range stmts {
+		if err := stmt.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore %s[%d]", name, i)
+		}
+		ctx.WritePlain("; ")
+	}
+	return nil
+}
+
+func acceptRoutineStmts(v Visitor, stmts []StmtNode) bool {
+	for i, stmt := range stmts {
+		node, ok := stmt.Accept(v)
+		if !ok {
+			return false
+		}
+		stmts[i] = node.(StmtNode)
+	}
+	return true
+}
+
+func restoreRoutineLabel(ctx *format.RestoreCtx, label string) {
+	if label != "" {
+		ctx.WriteName(label)
+		ctx.WritePlain(": ")
+	}
+}
+
+func restoreRoutineEndLabel(ctx *format.RestoreCtx, label string) {
+	if label != "" {
+		ctx.WritePlain(" ")
+		ctx.WriteName(label)
+	}
+}
+
+// BlockStmt is a BEGIN ... END compound statement in a stored routine.
+// See https://dev.mysql.com/doc/refman/8.0/en/begin-end.html
+type BlockStmt struct {
+	stmtNode
+
+	Label string
+	// Decls are the DECLARE statements at the beginning of the block.
+	Decls []StmtNode
+	Stmts []StmtNode
+}
+
+// Restore implements Node interface.
+func (n *BlockStmt) Restore(ctx *format.RestoreCtx) error {
+	restoreRoutineLabel(ctx, n.Label)
+	ctx.WriteKeyWord("BEGIN ")
+	if err := restoreRoutineStmts(ctx, n.Decls, "BlockStmt.Decls"); err != nil {
+		return err
+	}
+	if err := restoreRoutineStmts(ctx, n.Stmts, "BlockStmt.Stmts"); err != nil {
+		return err
+	}
+	ctx.WriteKeyWord("END")
+	restoreRoutineEndLabel(ctx, n.Label)
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *BlockStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*BlockStmt)
+	if !acceptRoutineStmts(v, n.Decls) || !acceptRoutineStmts(v, n.Stmts) {
+		return n, false
+	}
+	return v.Leave(n)
+}
+
+// DeclareVarStmt declares local variables in a stored routine.
+// See https://dev.mysql.com/doc/refman/8.0/en/declare-local-variable.html
+type DeclareVarStmt struct {
+	stmtNode
+
+	Names   []string
+	Tp      *types.FieldType
+	Default ExprNode
+}
+
+// Restore implements Node interface.
+func (n *DeclareVarStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DECLARE ")
+	for i, name := range n.Names {
+		if i != 0 {
+			ctx.WritePlain(", ")
+		}
+		ctx.WriteName(name)
+	}
+	ctx.WritePlain(" ")
+	if err := n.Tp.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore DeclareVarStmt.Tp")
+	}
+	if n.Default != nil {
+		ctx.WriteKeyWord(" DEFAULT ")
+		if err := n.Default.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore DeclareVarStmt.Default")
+		}
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DeclareVarStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DeclareVarStmt)
+	if n.Default != nil {
+		node, ok := n.Default.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Default = node.(ExprNode)
+	}
+	return v.Leave(n)
+}
+
+// DeclareCursorStmt declares a cursor in a stored routine.
+// See https://dev.mysql.com/doc/refman/8.0/en/declare-cursor.html
+type DeclareCursorStmt struct {
+	stmtNode
+
+	Name   string
+	Select StmtNode
+}
+
+// Restore implements Node interface.
+func (n *DeclareCursorStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DECLARE ")
+	ctx.WriteName(n.Name)
+	ctx.WriteKeyWord(" CURSOR FOR ")
+	if err := n.Select.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore DeclareCursorStmt.Select")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DeclareCursorStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DeclareCursorStmt)
+	node, ok := n.Select.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Select = node.(StmtNode)
+	return v.Leave(n)
+}
+
+// HandlerAction is the action of a condition handler.
+type HandlerAction int
+
+// Condition handler actions.
+const (
+	HandlerContinue HandlerAction = iota
+	HandlerExit
+)
+
+// HandlerConditionType is the type of the condition of a handler.
+type HandlerConditionType int
+
+// Handler condition types.
+const (
+	HandlerConditionErrorCode HandlerConditionType = iota
+	HandlerConditionSQLState
+	HandlerConditionSQLWarning
+	HandlerConditionNotFound
+	HandlerConditionSQLException
+)
+
+// HandlerCondition is a condition which activates a handler.
+type HandlerCondition struct {
+	node
+
+	Tp        HandlerConditionType
+	ErrorCode uint64
+	SQLState  string
+}
+
+// Restore implements Node interface.
+func (n *HandlerCondition) Restore(ctx *format.RestoreCtx) error {
+	switch n.Tp {
+	case HandlerConditionErrorCode:
+		ctx.WritePlainf("%d", n.ErrorCode)
+	case HandlerConditionSQLState:
+		ctx.WriteKeyWord("SQLSTATE ")
+		ctx.WriteString(n.SQLState)
+	case HandlerConditionSQLWarning:
+		ctx.WriteKeyWord("SQLWARNING")
+	case HandlerConditionNotFound:
+		ctx.WriteKeyWord("NOT FOUND")
+	case HandlerConditionSQLException:
+		ctx.WriteKeyWord("SQLEXCEPTION")
+	default:
+		return errors.New("Unsupported handler condition type")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *HandlerCondition) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*HandlerCondition)
+	return v.Leave(n)
+}
+
+// DeclareHandlerStmt declares a condition handler in a stored routine.
+// See https://dev.mysql.com/doc/refman/8.0/en/declare-handler.html
+type DeclareHandlerStmt struct {
+	stmtNode
+
+	Action     HandlerAction
+	Conditions []*HandlerCondition
+	Body       StmtNode
+}
+
+// Restore implements Node interface.
+func (n *DeclareHandlerStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DECLARE ")
+	if n.Action == HandlerExit {
+		ctx.WriteKeyWord("EXIT")
+	} else {
+		ctx.WriteKeyWord("CONTINUE")
+	}
+	ctx.WriteKeyWord(" HANDLER FOR ")
+	for i, cond := range n.Conditions {
+		if i != 0 {
+			ctx.WritePlain(", ")
+		}
+		if err := cond.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore DeclareHandlerStmt.Conditions[%d]", i)
+		}
+	}
+	ctx.WritePlain(" ")
+	if err := n.Body.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore DeclareHandlerStmt.Body")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DeclareHandlerStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DeclareHandlerStmt)
+	for i, cond := range n.Conditions {
+		node, ok := cond.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Conditions[i] = node.(*HandlerCondition)
+	}
+	node, ok := n.Body.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Body = node.(StmtNode)
+	return v.Leave(n)
+}
+
+// IfBranch is the IF or an ELSEIF branch of an IF statement.
+type IfBranch struct {
+	node
+
+	Cond  ExprNode
+	Stmts []StmtNode
+}
+
+// Restore implements Node interface.
+func (n *IfBranch) Restore(ctx *format.RestoreCtx) error {
+	if err := n.Cond.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore IfBranch.Cond")
+	}
+	ctx.WriteKeyWord(" THEN ")
+	return restoreRoutineStmts(ctx, n.Stmts, "IfBranch.Stmts")
+}
+
+// Accept implements Node Accept interface.
+func (n *IfBranch) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*IfBranch)
+	node, ok := n.Cond.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Cond = node.(ExprNode)
+	if !acceptRoutineStmts(v, n.Stmts) {
+		return n, false
+	}
+	return v.Leave(n)
+}
+
+// IfStmt is the IF statement in a stored routine.
+// See https://dev.mysql.com/doc/refman/8.0/en/if.html
+type IfStmt struct {
+	stmtNode
+
+	// Branches are the IF branch followed by the ELSEIF branches.
+	Branches []*IfBranch
+	Else     []StmtNode
+}
+
+// Restore implements Node interface.
+func (n *IfStmt) Restore(ctx *format.RestoreCtx) error {
+	for i, branch := range n.Branches {
+		if i == 0 {
+			ctx.WriteKeyWord("IF ")
+		} else {
+			ctx.WriteKeyWord("ELSEIF ")
+		}
+		if err := branch.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore IfStmt.Branches[%d]", i)
+		}
+	}
+	if n.Else != nil {
+		ctx.WriteKeyWord("ELSE ")
+		if err := restoreRoutineStmts(ctx, n.Else, "IfStmt.Else"); err != nil {
+			return err
+		}
+	}
+	ctx.WriteKeyWord("END IF")
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *IfStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*IfStmt)
+	for i, branch := range n.Branches {
+		node, ok := branch.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Branches[i] = node.(*IfBranch)
+	}
+	if !acceptRoutineStmts(v, n.Else) {
+		return n, false
+	}
+	return v.Leave(n)
+}
+
+// LoopStmt is the LOOP statement in a stored routine.
+// See https://dev.mysql.com/doc/refman/8.0/en/loop.html
+type LoopStmt struct {
+	stmtNode
+
+	Label string
+	Stmts []StmtNode
+}
+
+// Restore implements Node interface.
+func (n *LoopStmt) Restore(ctx *format.RestoreCtx) error {
+	restoreRoutineLabel(ctx, n.Label)
+	ctx.WriteKeyWord("LOOP ")
+	if err := restoreRoutineStmts(ctx, n.Stmts, "LoopStmt.Stmts"); err != nil {
+		return err
+	}
+	ctx.WriteKeyWord("END LOOP")
+	restoreRoutineEndLabel(ctx, n.Label)
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *LoopStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*LoopStmt)
+	if !acceptRoutineStmts(v, n.Stmts) {
+		return n, false
+	}
+	return v.Leave(n)
+}
+
+// WhileStmt is the WHILE statement in a stored routine.
+// See https://dev.mysql.com/doc/refman/8.0/en/while.html
+type WhileStmt struct {
+	stmtNode
+
+	Label string
+	Cond  ExprNode
+	Stmts []StmtNode
+}
+
+// Restore implements Node interface.
+func (n *WhileStmt) Restore(ctx *format.RestoreCtx) error {
+	restoreRoutineLabel(ctx, n.Label)
+	ctx.WriteKeyWord("WHILE ")
+	if err := n.Cond.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore WhileStmt.Cond")
+	}
+	ctx.WriteKeyWord(" DO ")
+	if err := restoreRoutineStmts(ctx, n.Stmts, "WhileStmt.Stmts"); err != nil {
+		return err
+	}
+	ctx.WriteKeyWord("END WHILE")
+	restoreRoutineEndLabel(ctx, n.Label)
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *WhileStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*WhileStmt)
+	node, ok := n.Cond.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Cond = node.(ExprNode)
+	if !acceptRoutineStmts(v, n.Stmts) {
+		return n, false
+	}
+	return v.Leave(n)
+}
+
+// RepeatStmt is the REPEAT statement in a stored routine.
+// See https://dev.mysql.com/doc/refman/8.0/en/repeat.html
+type RepeatStmt struct {
+	stmtNode
+
+	Label string
+	Stmts []StmtNode
+	Cond  ExprNode
+}
+
+// Restore implements Node interface.
+func (n *RepeatStmt) Restore(ctx *format.RestoreCtx) error {
+	restoreRoutineLabel(ctx, n.Label)
+	ctx.WriteKeyWord("REPEAT ")
+	if err := restoreRoutineStmts(ctx, n.Stmts, "RepeatStmt.Stmts"); err != nil {
+		return err
+	}
+	ctx.WriteKeyWord("UNTIL ")
+	if err := n.Cond.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore RepeatStmt.Cond")
+	}
+	ctx.WriteKeyWord(" END REPEAT")
+	restoreRoutineEndLabel(ctx, n.Label)
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *RepeatStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*RepeatStmt)
+	if !acceptRoutineStmts(v, n.Stmts) {
+		return n, false
+	}
+	node, ok := n.Cond.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Cond = node.(ExprNode)
+	return v.Leave(n)
+}
+
+// LeaveStmt is the LEAVE statement in a stored routine.
+// See https://dev.mysql.com/doc/refman/8.0/en/leave.html
+type LeaveStmt struct {
+	stmtNode
+
+	Label string
+}
+
+// Restore implements Node interface.
+func (n *LeaveStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("LEAVE ")
+	ctx.WriteName(n.Label)
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *LeaveStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*LeaveStmt)
+	return v.Leave(n)
+}
+
+// IterateStmt is the ITERATE statement in a stored routine.
+// See https://dev.mysql.com/doc/refman/8.0/en/iterate.html
+type IterateStmt struct {
+	stmtNode
+
+	Label string
+}
+
+// Restore implements Node interface.
+func (n *IterateStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("ITERATE ")
+	ctx.WriteName(n.Label)
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *IterateStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*IterateStmt)
+	return v.Leave(n)
+}
+
+// ReturnStmt is the RETURN statement in a stored function.
+// See https://dev.mysql.com/doc/refman/8.0/en/return.html
+type ReturnStmt struct {
+	stmtNode
+
+	Expr ExprNode
+}
+
+// Restore implements Node interface.
+func (n *ReturnStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("RETURN ")
+	if err := n.Expr.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore ReturnStmt.Expr")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *ReturnStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ReturnStmt)
+	node, ok := n.Expr.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Expr = node.(ExprNode)
+	return v.Leave(n)
+}
+
+// OpenCursorStmt is the OPEN statement of a cursor.
+// See https://dev.mysql.com/doc/refman/8.0/en/open.html
+type OpenCursorStmt struct {
+	stmtNode
+
+	Name string
+}
+
+// Restore implements Node interface.
+func (n *OpenCursorStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("OPEN ")
+	ctx.WriteName(n.Name)
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *OpenCursorStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*OpenCursorStmt)
+	return v.Leave(n)
+}
+
+// FetchCursorStmt is the FETCH statement of a cursor.
+// See https://dev.mysql.com/doc/refman/8.0/en/fetch.html
+type FetchCursorStmt struct {
+	stmtNode
+
+	Name string
+	Vars []string
+}
+
+// Restore implements Node interface.
+func (n *FetchCursorStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("FETCH ")
+	ctx.WriteName(n.Name)
+	ctx.WriteKeyWord(" INTO ")
+	for i, name := range n.Vars {
+		if i != 0 {
+			ctx.WritePlain(", ")
+		}
+		ctx.WriteName(name)
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *FetchCursorStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*FetchCursorStmt)
+	return v.Leave(n)
+}
+
+// CloseCursorStmt is the CLOSE statement of a cursor.
+// See https://dev.mysql.com/doc/refman/8.0/en/close.html
+type CloseCursorStmt struct {
+	stmtNode
+
+	Name string
+}
+
+// Restore implements Node interface.
+func (n *CloseCursorStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CLOSE ")
+	ctx.WriteName(n.Name)
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *CloseCursorStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CloseCursorStmt)
+	return v.Leave(n)
+}
+
+// SignalStmt is the SIGNAL statement which raises an error in a stored routine.
+// See https://dev.mysql.com/doc/refman/8.0/en/signal.html
+type SignalStmt struct {
+	stmtNode
+
+	SQLState    string
+	MessageText ExprNode
+	MySQLErrno  ExprNode
+}
+
+// Restore implements Node interface.
+func (n *SignalStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("SIGNAL SQLSTATE ")
+	ctx.WriteString(n.SQLState)
+	if n.MessageText == nil && n.MySQLErrno == nil {
+		return nil
+	}
+	ctx.WriteKeyWord(" SET ")
+	if n.MessageText != nil {
+		ctx.WriteKeyWord("MESSAGE_TEXT")
+		ctx.WritePlain(" = ")
+		if err := n.MessageText.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore SignalStmt.MessageText")
+		}
+		if n.MySQLErrno != nil {
+			ctx.WritePlain(", ")
+		}
+	}
+	if n.MySQLErrno != nil {
+		ctx.WriteKeyWord("MYSQL_ERRNO")
+		ctx.WritePlain(" = ")
+		if err := n.MySQLErrno.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore SignalStmt.MySQLErrno")
+		}
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *SignalStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*SignalStmt)
+	if n.MessageText != nil {
+		node, ok := n.MessageText.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.MessageText = node.(ExprNode)
+	}
+	if n.MySQLErrno != nil {
+		node, ok := n.MySQLErrno.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.MySQLErrno = node.(ExprNode)
+	}
+	return v.Leave(n)
+}
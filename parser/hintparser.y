@@ -97,6 +97,7 @@ import (
 	hintStreamAgg             "STREAM_AGG"
 	hintSwapJoinInputs        "SWAP_JOIN_INPUTS"
 	hintUseIndexMerge         "USE_INDEX_MERGE"
+	hintUseIndexMergeInter    "USE_INDEX_MERGE_INTERSECTION"
 	hintUseIndex              "USE_INDEX"
 	hintUsePlanCache          "USE_PLAN_CACHE"
 	hintUseToja               "USE_TOJA"
@@ -554,6 +555,7 @@ SupportedIndexLevelOptimizerHintName:
 	"USE_INDEX"
 |	"IGNORE_INDEX"
 |	"USE_INDEX_MERGE"
+|	"USE_INDEX_MERGE_INTERSECTION"
 |	"FORCE_INDEX"
 
 SubqueryOptimizerHintName:
@@ -636,6 +638,7 @@ Identifier:
 |	"STREAM_AGG"
 |	"SWAP_JOIN_INPUTS"
 |	"USE_INDEX_MERGE"
+|	"USE_INDEX_MERGE_INTERSECTION"
 |	"USE_INDEX"
 |	"USE_PLAN_CACHE"
 |	"USE_TOJA"
@@ -193,6 +193,25 @@ func TestParseHint(t *testing.T) {
 				},
 			},
 		},
+		{
+			input: "USE_INDEX_MERGE_INTERSECTION(@qb1 tbl1 x, y) use_index_merge_intersection(tbl2 partition(p0) z, PRIMARY)",
+			output: []*ast.TableOptimizerHint{
+				{
+					HintName: model.NewCIStr("USE_INDEX_MERGE_INTERSECTION"),
+					Tables:   []ast.HintTable{{TableName: model.NewCIStr("tbl1")}},
+					QBName:   model.NewCIStr("qb1"),
+					Indexes:  []model.CIStr{model.NewCIStr("x"), model.NewCIStr("y")},
+				},
+				{
+					HintName: model.NewCIStr("use_index_merge_intersection"),
+					Tables: []ast.HintTable{{
+						TableName:     model.NewCIStr("tbl2"),
+						PartitionList: []model.CIStr{model.NewCIStr("p0")},
+					}},
+					Indexes: []model.CIStr{model.NewCIStr("z"), model.NewCIStr("PRIMARY")},
+				},
+			},
+		},
 		{
 			input: `SET_VAR(sbs = 16M) SET_VAR(fkc=OFF) SET_VAR(os="mcb=off") set_var(abc=1) set_var(os2='mcb2=off')`,
 			output: []*ast.TableOptimizerHint{
@@ -170,6 +170,7 @@ var tokenMap = map[string]int{
 	"BACKEND":                  backend,
 	"BACKUP":                   backup,
 	"BACKUPS":                  backups,
+	"BEFORE":                   before,
 	"BEGIN":                    begin,
 	"BETWEEN":                  between,
 	"BERNOULLI":                bernoulli,
@@ -216,6 +217,7 @@ var tokenMap = map[string]int{
 	"CLEANUP":                  cleanup,
 	"CLIENT":                   client,
 	"CLIENT_ERRORS_SUMMARY":    clientErrorsSummary,
+	"CLOSE":                    closeKwd,
 	"CLUSTERED":                clustered,
 	"CMSKETCH":                 cmSketch,
 	"COALESCE":                 coalesce,
@@ -238,7 +240,9 @@ var tokenMap = map[string]int{
 	"CONSISTENT":               consistent,
 	"CONSTRAINT":               constraint,
 	"CONSTRAINTS":              constraints,
+	"CONTAINS":                 contains,
 	"CONTEXT":                  context,
+	"CONTINUE":                 continueKwd,
 	"CONVERT":                  convert,
 	"COPY":                     copyKwd,
 	"CORRELATION":              correlation,
@@ -257,6 +261,7 @@ var tokenMap = map[string]int{
 	"CURRENT_TIME":             currentTime,
 	"CURRENT_TIMESTAMP":        currentTs,
 	"CURRENT_USER":             currentUser,
+	"CURSOR":                   cursor,
 	"CURRENT":                  current,
 	"CURTIME":                  curTime,
 	"CYCLE":                    cycle,
@@ -276,6 +281,7 @@ var tokenMap = map[string]int{
 	"DEALLOCATE":               deallocate,
 	"DEC":                      decimalType,
 	"DECIMAL":                  decimalType,
+	"DECLARE":                  declare,
 	"DEFAULT":                  defaultKwd,
 	"DEFINER":                  definer,
 	"DELAY_KEY_WRITE":          delayKeyWrite,
@@ -285,6 +291,7 @@ var tokenMap = map[string]int{
 	"DEPTH":                    depth,
 	"DESC":                     desc,
 	"DESCRIBE":                 describe,
+	"DETERMINISTIC":            deterministic,
 	"DIRECTORY":                directory,
 	"DISABLE":                  disable,
 	"DISABLED":                 disabled,
@@ -302,7 +309,10 @@ var tokenMap = map[string]int{
 	"DUMP":                     dump,
 	"DUPLICATE":                duplicate,
 	"DYNAMIC":                  dynamic,
+	"EACH":                     each,
 	"ELSE":                     elseKwd,
+	"ELSEIF":                   elseIfKwd,
+	"EMPTY":                    emptyKwd,
 	"ENABLE":                   enable,
 	"ENABLED":                  enabled,
 	"ENCLOSED":                 enclosed,
@@ -325,6 +335,7 @@ var tokenMap = map[string]int{
 	"EXCLUSIVE":                exclusive,
 	"EXECUTE":                  execute,
 	"EXISTS":                   exists,
+	"EXIT":                     exit,
 	"EXPANSION":                expansion,
 	"EXPIRE":                   expire,
 	"EXPLAIN":                  explain,
@@ -345,10 +356,12 @@ var tokenMap = map[string]int{
 	"FOLLOWERS":                followers,
 	"FOLLOWER_CONSTRAINTS":     followerConstraints,
 	"FOLLOWING":                following,
+	"FOLLOWS":                  follows,
 	"FOR":                      forKwd,
 	"FORCE":                    force,
 	"FOREIGN":                  foreign,
 	"FORMAT":                   format,
+	"FOUND":                    found,
 	"FROM":                     from,
 	"FULL":                     full,
 	"FULLTEXT":                 fulltext,
@@ -360,6 +373,7 @@ var tokenMap = map[string]int{
 	"GRANT":                    grant,
 	"GRANTS":                   grants,
 	"GROUP_CONCAT":             groupConcat,
+	"HANDLER":                  handler,
 	"GROUP":                    group,
 	"HASH":                     hash,
 	"HAVING":                   having,
@@ -384,6 +398,7 @@ var tokenMap = map[string]int{
 	"INDEXES":                  indexes,
 	"INFILE":                   infile,
 	"INNER":                    inner,
+	"INOUT":                    inout,
 	"INPLACE":                  inplace,
 	"INSERT_METHOD":            insertMethod,
 	"INSERT":                   insert,
@@ -407,9 +422,11 @@ var tokenMap = map[string]int{
 	"IS":                       is,
 	"ISOLATION":                isolation,
 	"ISSUER":                   issuer,
+	"ITERATE":                  iterate,
 	"JOB":                      job,
 	"JOBS":                     jobs,
 	"JOIN":                     join,
+	"JSON_TABLE":               jsonTable,
 	"JSON_ARRAYAGG":            jsonArrayagg,
 	"JSON_OBJECTAGG":           jsonObjectAgg,
 	"JSON":                     jsonType,
@@ -427,6 +444,7 @@ var tokenMap = map[string]int{
 	"LEADING":                  leading,
 	"LEARNER":                  learner,
 	"LEARNER_CONSTRAINTS":      learnerConstraints,
+	"LEAVE":                    leave,
 	"LEARNERS":                 learners,
 	"LEFT":                     left,
 	"LESS":                     less,
@@ -447,6 +465,7 @@ var tokenMap = map[string]int{
 	"LONG":                     long,
 	"LONGBLOB":                 longblobType,
 	"LONGTEXT":                 longtextType,
+	"LOOP":                     loop,
 	"LOW_PRIORITY":             lowPriority,
 	"MASTER":                   master,
 	"MATCH":                    match,
@@ -465,6 +484,7 @@ var tokenMap = map[string]int{
 	"MEDIUMTEXT":               mediumtextType,
 	"MEMORY":                   memory,
 	"MERGE":                    merge,
+	"MESSAGE_TEXT":             messageText,
 	"MICROSECOND":              microsecond,
 	"MIN_ROWS":                 minRows,
 	"MIN":                      min,
@@ -474,12 +494,15 @@ var tokenMap = map[string]int{
 	"MINVALUE":                 minValue,
 	"MOD":                      mod,
 	"MODE":                     mode,
+	"MODIFIES":                 modifies,
 	"MODIFY":                   modify,
 	"MONTH":                    month,
+	"MYSQL_ERRNO":              mysqlErrno,
 	"NAMES":                    names,
 	"NATIONAL":                 national,
 	"NATURAL":                  natural,
 	"NCHAR":                    ncharType,
+	"NESTED":                   nested,
 	"NEVER":                    never,
 	"NEXT_ROW_ID":              next_row_id,
 	"NEXT":                     next,
@@ -516,6 +539,8 @@ var tokenMap = map[string]int{
 	"OPTION":                   option,
 	"OPTIONAL":                 optional,
 	"OPTIONALLY":               optionally,
+	"ORDINALITY":               ordinality,
+	"OUT":                      out,
 	"OR":                       or,
 	"ORDER":                    order,
 	"OUTER":                    outer,
@@ -528,6 +553,7 @@ var tokenMap = map[string]int{
 	"PARTITIONING":             partitioning,
 	"PARTITIONS":               partitions,
 	"PASSWORD":                 password,
+	"PATH":                     pathKwd,
 	"PERCENT":                  percent,
 	"PER_DB":                   per_db,
 	"PER_TABLE":                per_table,
@@ -540,6 +566,7 @@ var tokenMap = map[string]int{
 	"POSITION":                 position,
 	"PRE_SPLIT_REGIONS":        preSplitRegions,
 	"PRECEDING":                preceding,
+	"PRECEDES":                 precedes,
 	"PREDICATE":                predicate,
 	"PRECISION":                precisionType,
 	"PREPARE":                  prepare,
@@ -562,6 +589,7 @@ var tokenMap = map[string]int{
 	"RANGE":                    rangeKwd,
 	"RATE_LIMIT":               rateLimit,
 	"READ":                     read,
+	"READS":                    reads,
 	"REAL":                     realType,
 	"REBUILD":                  rebuild,
 	"RECENT":                   recent,
@@ -599,6 +627,7 @@ var tokenMap = map[string]int{
 	"RLIKE":                    rlike,
 	"ROLE":                     role,
 	"ROLLBACK":                 rollback,
+	"ROLLUP":                   rollup,
 	"ROUTINE":                  routine,
 	"ROW_COUNT":                rowCount,
 	"ROW_FORMAT":               rowFormat,
@@ -606,11 +635,14 @@ var tokenMap = map[string]int{
 	"ROWS":                     rows,
 	"RTREE":                    rtree,
 	"RESUME":                   resume,
+	"RETURN":                   returnKwd,
+	"RETURNS":                  returns,
 	"RUNNING":                  running,
 	"S3":                       s3,
 	"SAMPLES":                  samples,
 	"SAMPLERATE":               sampleRate,
 	"SAN":                      san,
+	"SAVEPOINT":                savepoint,
 	"SCHEDULE":                 schedule,
 	"SCHEMA":                   database,
 	"SCHEMAS":                  databases,
@@ -634,6 +666,7 @@ var tokenMap = map[string]int{
 	"SHARED":                   shared,
 	"SHOW":                     show,
 	"SHUTDOWN":                 shutdown,
+	"SIGNAL":                   signal,
 	"SIGNED":                   signed,
 	"SIMPLE":                   simple,
 	"SKIP":                     skip,
@@ -661,6 +694,9 @@ var tokenMap = map[string]int{
 	"SQL_TSI_WEEK":             sqlTsiWeek,
 	"SQL_TSI_YEAR":             sqlTsiYear,
 	"SQL":                      sql,
+	"SQLEXCEPTION":             sqlexception,
+	"SQLSTATE":                 sqlstate,
+	"SQLWARNING":               sqlwarning,
 	"SSL":                      ssl,
 	"STALENESS":                staleness,
 	"START":                    start,
@@ -756,6 +792,7 @@ var tokenMap = map[string]int{
 	"UNKNOWN":                  unknown,
 	"UNLOCK":                   unlock,
 	"UNSIGNED":                 unsigned,
+	"UNTIL":                    until,
 	"UPDATE":                   update,
 	"USAGE":                    usage,
 	"USE":                      use,
@@ -787,6 +824,7 @@ var tokenMap = map[string]int{
 	"WEIGHT_STRING":            weightString,
 	"WHEN":                     when,
 	"WHERE":                    where,
+	"WHILE":                    while,
 	"WIDTH":                    width,
 	"WITH":                     with,
 	"WITHOUT":                  without,
@@ -905,31 +943,32 @@ var hintTokenMap = map[string]int{
 	"QB_NAME":               hintQBName,
 
 	// TiDB hint names
-	"AGG_TO_COP":              hintAggToCop,
-	"LIMIT_TO_COP":            hintLimitToCop,
-	"IGNORE_PLAN_CACHE":       hintIgnorePlanCache,
-	"HASH_AGG":                hintHashAgg,
-	"IGNORE_INDEX":            hintIgnoreIndex,
-	"INL_HASH_JOIN":           hintInlHashJoin,
-	"INL_JOIN":                hintInlJoin,
-	"INL_MERGE_JOIN":          hintInlMergeJoin,
-	"MEMORY_QUOTA":            hintMemoryQuota,
-	"NO_SWAP_JOIN_INPUTS":     hintNoSwapJoinInputs,
-	"QUERY_TYPE":              hintQueryType,
-	"READ_CONSISTENT_REPLICA": hintReadConsistentReplica,
-	"READ_FROM_STORAGE":       hintReadFromStorage,
-	"BROADCAST_JOIN":          hintBCJoin,
-	"MERGE_JOIN":              hintSMJoin,
-	"STREAM_AGG":              hintStreamAgg,
-	"SWAP_JOIN_INPUTS":        hintSwapJoinInputs,
-	"USE_INDEX_MERGE":         hintUseIndexMerge,
-	"USE_INDEX":               hintUseIndex,
-	"USE_PLAN_CACHE":          hintUsePlanCache,
-	"USE_TOJA":                hintUseToja,
-	"TIME_RANGE":              hintTimeRange,
-	"USE_CASCADES":            hintUseCascades,
-	"NTH_PLAN":                hintNthPlan,
-	"FORCE_INDEX":             hintForceIndex,
+	"AGG_TO_COP":                   hintAggToCop,
+	"LIMIT_TO_COP":                 hintLimitToCop,
+	"IGNORE_PLAN_CACHE":            hintIgnorePlanCache,
+	"HASH_AGG":                     hintHashAgg,
+	"IGNORE_INDEX":                 hintIgnoreIndex,
+	"INL_HASH_JOIN":                hintInlHashJoin,
+	"INL_JOIN":                     hintInlJoin,
+	"INL_MERGE_JOIN":               hintInlMergeJoin,
+	"MEMORY_QUOTA":                 hintMemoryQuota,
+	"NO_SWAP_JOIN_INPUTS":          hintNoSwapJoinInputs,
+	"QUERY_TYPE":                   hintQueryType,
+	"READ_CONSISTENT_REPLICA":      hintReadConsistentReplica,
+	"READ_FROM_STORAGE":            hintReadFromStorage,
+	"BROADCAST_JOIN":               hintBCJoin,
+	"MERGE_JOIN":                   hintSMJoin,
+	"STREAM_AGG":                   hintStreamAgg,
+	"SWAP_JOIN_INPUTS":             hintSwapJoinInputs,
+	"USE_INDEX_MERGE":              hintUseIndexMerge,
+	"USE_INDEX_MERGE_INTERSECTION": hintUseIndexMergeInter,
+	"USE_INDEX":                    hintUseIndex,
+	"USE_PLAN_CACHE":               hintUsePlanCache,
+	"USE_TOJA":                     hintUseToja,
+	"TIME_RANGE":                   hintTimeRange,
+	"USE_CASCADES":                 hintUseCascades,
+	"NTH_PLAN":                     hintNthPlan,
+	"FORCE_INDEX":                  hintForceIndex,
 
 	// TiDB hint aliases
 	"TIDB_HJ":   hintHashJoin,
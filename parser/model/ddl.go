@@ -95,6 +95,12 @@ const (
 	ActionAlterTableStatsOptions        ActionType = 58
 	ActionAlterNoCacheTable             ActionType = 59
 	ActionCreateTables                  ActionType = 60
+	ActionReorganizePartition           ActionType = 61
+	ActionMultiSchemaChange             ActionType = 62
+	ActionCreateRoutine                 ActionType = 63
+	ActionDropRoutine                   ActionType = 64
+	ActionCreateTrigger                 ActionType = 65
+	ActionDropTrigger                   ActionType = 66
 )
 
 var actionMap = map[ActionType]string{
@@ -157,6 +163,12 @@ var actionMap = map[ActionType]string{
 	ActionAlterCacheTable:               "alter table cache",
 	ActionAlterNoCacheTable:             "alter table nocache",
 	ActionAlterTableStatsOptions:        "alter table statistics options",
+	ActionReorganizePartition:           "alter table reorganize partition",
+	ActionMultiSchemaChange:             "alter table multi-schema change",
+	ActionCreateRoutine:                 "create routine",
+	ActionDropRoutine:                   "drop routine",
+	ActionCreateTrigger:                 "create trigger",
+	ActionDropTrigger:                   "drop trigger",
 
 	// `ActionAlterTableAlterPartition` is removed and will never be used.
 	// Just left a tombstone here for compatibility.
@@ -255,9 +267,110 @@ func NewDDLReorgMeta() *DDLReorgMeta {
 
 // MultiSchemaInfo keeps some information for multi schema change.
 type MultiSchemaInfo struct {
+	SubJobs    []*SubJob `json:"sub_jobs"`
+	Revertible bool      `json:"revertible"`
+
+	// The following fields are only used to check the conflicts
+	// between the sub-jobs when the job is submitted.
+	AddColumns    []CIStr `json:"-"`
+	DropColumns   []CIStr `json:"-"`
+	ModifyColumns []CIStr `json:"-"`
+	AddIndexes    []CIStr `json:"-"`
+	DropIndexes   []CIStr `json:"-"`
+	AlterIndexes  []CIStr `json:"-"`
+	// The constraints include the check constraints and the foreign keys.
+	AddConstraints   []CIStr `json:"-"`
+	DropConstraints  []CIStr `json:"-"`
+	AlterConstraints []CIStr `json:"-"`
+	// RelativeColumns are the columns that the sub-jobs depend on, like the columns of
+	// the new indexes and constraints and the columns in `AFTER` clauses.
+	RelativeColumns []CIStr `json:"-"`
+
 	Warnings []*errors.Error
 }
 
+// NewMultiSchemaInfo new a MultiSchemaInfo.
+func NewMultiSchemaInfo() *MultiSchemaInfo {
+	return &MultiSchemaInfo{
+		SubJobs:    nil,
+		Revertible: true,
+	}
+}
+
+// SubJob is a representation of one DDL schema change. A Job may contain zero
+// (when multi-schema change is not applicable) or more SubJobs.
+type SubJob struct {
+	Type        ActionType      `json:"type"`
+	Args        []interface{}   `json:"-"`
+	RawArgs     json.RawMessage `json:"raw_args"`
+	SchemaState SchemaState     `json:"schema_state"`
+	SnapshotVer uint64          `json:"snapshot_ver"`
+	Revertible  bool            `json:"revertible"`
+	State       JobState        `json:"state"`
+	RowCount    int64           `json:"row_count"`
+	CtxVars     []interface{}   `json:"-"`
+}
+
+// IsNormal returns true if the sub-job is normally running.
+func (sub *SubJob) IsNormal() bool {
+	switch sub.State {
+	case JobStateCancelling, JobStateCancelled,
+		JobStateRollingback, JobStateRollbackDone:
+		return false
+	default:
+		return true
+	}
+}
+
+// IsFinished returns true if the job is done.
+func (sub *SubJob) IsFinished() bool {
+	return sub.State == JobStateDone ||
+		sub.State == JobStateRollbackDone ||
+		sub.State == JobStateCancelled
+}
+
+// ToProxyJob converts a sub-job to a proxy job, so that it can be run by the
+// handler of the single schema change.
+func (sub *SubJob) ToProxyJob(parentJob *Job) Job {
+	return Job{
+		ID:              parentJob.ID,
+		Type:            sub.Type,
+		SchemaID:        parentJob.SchemaID,
+		TableID:         parentJob.TableID,
+		SchemaName:      parentJob.SchemaName,
+		State:           sub.State,
+		Error:           nil,
+		ErrorCount:      0,
+		RowCount:        sub.RowCount,
+		CtxVars:         sub.CtxVars,
+		Args:            sub.Args,
+		RawArgs:         sub.RawArgs,
+		SchemaState:     sub.SchemaState,
+		SnapshotVer:     sub.SnapshotVer,
+		RealStartTS:     parentJob.RealStartTS,
+		StartTS:         parentJob.StartTS,
+		DependencyID:    parentJob.DependencyID,
+		Query:           parentJob.Query,
+		BinlogInfo:      parentJob.BinlogInfo,
+		Version:         parentJob.Version,
+		ReorgMeta:       parentJob.ReorgMeta,
+		MultiSchemaInfo: &MultiSchemaInfo{Revertible: sub.Revertible},
+		Priority:        parentJob.Priority,
+		SeqNum:          parentJob.SeqNum,
+	}
+}
+
+// FromProxyJob converts a proxy job back to the sub-job.
+func (sub *SubJob) FromProxyJob(proxyJob *Job) {
+	sub.Revertible = proxyJob.MultiSchemaInfo.Revertible
+	sub.SchemaState = proxyJob.SchemaState
+	sub.SnapshotVer = proxyJob.SnapshotVer
+	sub.Args = proxyJob.Args
+	sub.RawArgs = proxyJob.RawArgs
+	sub.State = proxyJob.State
+	sub.RowCount = proxyJob.RowCount
+}
+
 // Job is for a DDL operation.
 type Job struct {
 	ID         int64         `json:"id"`
@@ -378,6 +491,18 @@ func (job *Job) Encode(updateRawArgs bool) ([]byte, error) {
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		if job.MultiSchemaInfo != nil {
+			for _, sub := range job.MultiSchemaInfo.SubJobs {
+				// Only update the args of executing sub-jobs.
+				if sub.Args == nil {
+					continue
+				}
+				sub.RawArgs, err = json.Marshal(sub.Args)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+			}
+		}
 	}
 
 	var b []byte
@@ -503,6 +628,15 @@ func (job *Job) IsRunning() bool {
 	return job.State == JobStateRunning
 }
 
+// MarkNonRevertible marks the current job to be non-revertible. It is used by
+// the sub-jobs of a multi-schema change to indicate that the job has reached
+// the last state that can be rolled back.
+func (job *Job) MarkNonRevertible() {
+	if job.MultiSchemaInfo != nil {
+		job.MultiSchemaInfo.Revertible = false
+	}
+}
+
 // JobState is for job state.
 type JobState byte
 
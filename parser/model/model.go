@@ -295,7 +295,10 @@ type TableInfo struct {
 	Indices     []*IndexInfo      `json:"index_info"`
 	Constraints []*ConstraintInfo `json:"constraint_info"`
 	ForeignKeys []*FKInfo         `json:"fk_info"`
-	State       SchemaState       `json:"state"`
+	// Triggers are listed in the order in which they are activated for the
+	// same timing and event.
+	Triggers []*TriggerInfo `json:"triggers,omitempty"`
+	State    SchemaState    `json:"state"`
 	// PKIsHandle is true when primary key is a single integer column.
 	PKIsHandle bool `json:"pk_is_handle"`
 	// IsCommonHandle is true when clustered index feature is
@@ -548,10 +551,24 @@ func (t *TableInfo) Clone() *TableInfo {
 		nt.Indices[i] = t.Indices[i].Clone()
 	}
 
+	if t.Constraints != nil {
+		nt.Constraints = make([]*ConstraintInfo, len(t.Constraints))
+		for i := range t.Constraints {
+			nt.Constraints[i] = t.Constraints[i].Clone()
+		}
+	}
+
 	for i := range t.ForeignKeys {
 		nt.ForeignKeys[i] = t.ForeignKeys[i].Clone()
 	}
 
+	if t.Triggers != nil {
+		nt.Triggers = make([]*TriggerInfo, len(t.Triggers))
+		for i := range t.Triggers {
+			nt.Triggers[i] = t.Triggers[i].Clone()
+		}
+	}
+
 	return &nt
 }
 
@@ -851,6 +868,49 @@ type PartitionInfo struct {
 	DroppingDefinitions []PartitionDefinition `json:"dropping_definitions"`
 	States              []PartitionState      `json:"states"`
 	Num                 uint64                `json:"num"`
+	// IsLinear is true for LINEAR HASH and LINEAR KEY partitioning, which use
+	// the powers-of-two algorithm instead of the modulus to locate a partition.
+	IsLinear bool `json:"is_linear,omitempty"`
+	// KeyAlgorithm is the ALGORITHM option of KEY partitioning. Algorithm 1
+	// hashes numeric and temporal columns the way MySQL 5.1 does, 0 and 2
+	// follow MySQL 5.5 and later.
+	KeyAlgorithm uint64 `json:"key_algorithm,omitempty"`
+	// IsEmptyColumns is true for `PARTITION BY KEY()`, in which case Columns
+	// holds the primary key (or unique key) columns the partitioning uses.
+	IsEmptyColumns bool `json:"is_empty_columns,omitempty"`
+	// DDLState is the state of an in-progress partition reorganization, which
+	// replaces DroppingDefinitions with AddingDefinitions. It is StateNone
+	// when no reorganization is running.
+	DDLState SchemaState `json:"ddl_state"`
+}
+
+// IsReorganizing reports whether the partitions are being reorganized.
+func (pi *PartitionInfo) IsReorganizing() bool {
+	return pi.DDLState != StateNone
+}
+
+// ReplaceDefinitions returns a copy of Definitions in which the partitions in
+// `from` are replaced by `to`. The partitions in `to` are placed at the position
+// of the first replaced partition, so the order of range partitions is kept as
+// long as the replaced partitions are consecutive.
+func (pi *PartitionInfo) ReplaceDefinitions(from, to []PartitionDefinition) []PartitionDefinition {
+	replaced := make(map[int64]struct{}, len(from))
+	for _, def := range from {
+		replaced[def.ID] = struct{}{}
+	}
+	defs := make([]PartitionDefinition, 0, len(pi.Definitions)-len(from)+len(to))
+	inserted := false
+	for _, def := range pi.Definitions {
+		if _, ok := replaced[def.ID]; !ok {
+			defs = append(defs, def)
+			continue
+		}
+		if !inserted {
+			defs = append(defs, to...)
+			inserted = true
+		}
+	}
+	return defs
 }
 
 // GetNameByID gets the partition name by ID.
@@ -1077,14 +1137,25 @@ func (t *TableInfo) FindConstraintInfoByName(constrName string) *ConstraintInfo
 
 // FKInfo provides meta data describing a foreign key constraint.
 type FKInfo struct {
-	ID       int64       `json:"id"`
-	Name     CIStr       `json:"fk_name"`
-	RefTable CIStr       `json:"ref_table"`
-	RefCols  []CIStr     `json:"ref_cols"`
-	Cols     []CIStr     `json:"cols"`
-	OnDelete int         `json:"on_delete"`
-	OnUpdate int         `json:"on_update"`
-	State    SchemaState `json:"state"`
+	ID   int64 `json:"id"`
+	Name CIStr `json:"fk_name"`
+	// RefSchema is the schema of the referenced table, it's empty for the foreign keys
+	// created before it was recorded, whose referenced tables are in the schema of the table.
+	RefSchema CIStr       `json:"ref_schema"`
+	RefTable  CIStr       `json:"ref_table"`
+	RefCols   []CIStr     `json:"ref_cols"`
+	Cols      []CIStr     `json:"cols"`
+	OnDelete  int         `json:"on_delete"`
+	OnUpdate  int         `json:"on_update"`
+	State     SchemaState `json:"state"`
+}
+
+// GetRefSchema returns the schema of the referenced table, schema is the schema of the table.
+func (fk *FKInfo) GetRefSchema(schema CIStr) CIStr {
+	if fk.RefSchema.L != "" {
+		return fk.RefSchema
+	}
+	return schema
 }
 
 // Clone clones FKInfo.
@@ -1106,6 +1177,7 @@ type DBInfo struct {
 	Charset            string         `json:"charset"`
 	Collate            string         `json:"collate"`
 	Tables             []*TableInfo   `json:"-"` // Tables in the DB.
+	Routines           []*RoutineInfo `json:"-"` // Stored procedures and functions in the DB.
 	State              SchemaState    `json:"state"`
 	PlacementPolicyRef *PolicyRefInfo `json:"policy_ref_info"`
 }
@@ -1117,6 +1189,12 @@ func (db *DBInfo) Clone() *DBInfo {
 	for i := range db.Tables {
 		newInfo.Tables[i] = db.Tables[i].Clone()
 	}
+	if db.Routines != nil {
+		newInfo.Routines = make([]*RoutineInfo, len(db.Routines))
+		for i := range db.Routines {
+			newInfo.Routines[i] = db.Routines[i].Clone()
+		}
+	}
 	return &newInfo
 }
 
@@ -1125,6 +1203,10 @@ func (db *DBInfo) Copy() *DBInfo {
 	newInfo := *db
 	newInfo.Tables = make([]*TableInfo, len(db.Tables))
 	copy(newInfo.Tables, db.Tables)
+	if db.Routines != nil {
+		newInfo.Routines = make([]*RoutineInfo, len(db.Routines))
+		copy(newInfo.Routines, db.Routines)
+	}
 	return &newInfo
 }
 
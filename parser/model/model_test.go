@@ -304,6 +304,7 @@ func TestString(t *testing.T) {
 		{ActionAlterTablePlacement, "alter table placement"},
 		{ActionAlterTablePartitionPlacement, "alter table partition placement"},
 		{ActionAlterNoCacheTable, "alter table nocache"},
+		{ActionMultiSchemaChange, "alter table multi-schema change"},
 	}
 
 	for _, v := range acts {
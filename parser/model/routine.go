@@ -0,0 +1,141 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/types"
+)
+
+// RoutineType is the type of a stored routine.
+type RoutineType int
+
+// Stored routine types.
+const (
+	RoutineProcedure RoutineType = iota + 1
+	RoutineFunction
+)
+
+// String implements fmt.Stringer interface.
+func (t RoutineType) String() string {
+	switch t {
+	case RoutineProcedure:
+		return "PROCEDURE"
+	case RoutineFunction:
+		return "FUNCTION"
+	default:
+		return ""
+	}
+}
+
+// RoutineParamMode is the mode of a stored procedure parameter.
+type RoutineParamMode int
+
+// Stored procedure parameter modes.
+const (
+	RoutineParamIn RoutineParamMode = iota
+	RoutineParamOut
+	RoutineParamInOut
+)
+
+// String implements fmt.Stringer interface.
+func (m RoutineParamMode) String() string {
+	switch m {
+	case RoutineParamOut:
+		return "OUT"
+	case RoutineParamInOut:
+		return "INOUT"
+	default:
+		return "IN"
+	}
+}
+
+// RoutineDataAccess is the SQL data access characteristic of a stored routine.
+// See https://dev.mysql.com/doc/refman/8.0/en/create-procedure.html
+type RoutineDataAccess int
+
+// Stored routine data access characteristics.
+const (
+	RoutineContainsSQL RoutineDataAccess = iota
+	RoutineNoSQL
+	RoutineReadsSQLData
+	RoutineModifiesSQLData
+)
+
+// String implements fmt.Stringer interface.
+func (d RoutineDataAccess) String() string {
+	switch d {
+	case RoutineNoSQL:
+		return "NO SQL"
+	case RoutineReadsSQLData:
+		return "READS SQL DATA"
+	case RoutineModifiesSQLData:
+		return "MODIFIES SQL DATA"
+	default:
+		return "CONTAINS SQL"
+	}
+}
+
+// RoutineParam is a parameter of a stored routine.
+type RoutineParam struct {
+	Name CIStr            `json:"name"`
+	Mode RoutineParamMode `json:"mode"`
+	Tp   *types.FieldType `json:"type"`
+}
+
+// RoutineInfo provides meta data describing a stored procedure or function.
+type RoutineInfo struct {
+	ID     int64           `json:"id"`
+	Name   CIStr           `json:"name"`
+	Type   RoutineType     `json:"type"`
+	Params []*RoutineParam `json:"params"`
+	// ReturnType is only used by stored functions.
+	ReturnType *types.FieldType `json:"return_type"`
+	// Body is the original text of the routine body.
+	Body          string             `json:"body"`
+	Definer       *auth.UserIdentity `json:"definer"`
+	Security      ViewSecurity       `json:"security"`
+	DataAccess    RoutineDataAccess  `json:"data_access"`
+	Deterministic bool               `json:"deterministic"`
+	Comment       string             `json:"comment"`
+	// SQLMode is the sql_mode in effect when the routine was created, the
+	// routine body is parsed and executed with it.
+	SQLMode string      `json:"sql_mode"`
+	Charset string      `json:"charset"`
+	Collate string      `json:"collate"`
+	Created time.Time   `json:"created"`
+	State   SchemaState `json:"state"`
+}
+
+// Clone clones RoutineInfo.
+func (r *RoutineInfo) Clone() *RoutineInfo {
+	nr := *r
+	nr.Params = make([]*RoutineParam, len(r.Params))
+	for i, p := range r.Params {
+		np := *p
+		np.Tp = p.Tp.Clone()
+		nr.Params[i] = &np
+	}
+	if r.ReturnType != nil {
+		nr.ReturnType = r.ReturnType.Clone()
+	}
+	if r.Definer != nil {
+		definer := *r.Definer
+		nr.Definer = &definer
+	}
+	return &nr
+}
@@ -0,0 +1,138 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/pingcap/tidb/parser/auth"
+)
+
+// TriggerTiming is the action time of a trigger.
+type TriggerTiming int
+
+// Trigger action times.
+const (
+	TriggerBefore TriggerTiming = iota + 1
+	TriggerAfter
+)
+
+// String implements fmt.Stringer interface.
+func (t TriggerTiming) String() string {
+	switch t {
+	case TriggerBefore:
+		return "BEFORE"
+	case TriggerAfter:
+		return "AFTER"
+	default:
+		return ""
+	}
+}
+
+// TriggerEvent is the kind of operation which activates a trigger.
+type TriggerEvent int
+
+// Trigger events.
+const (
+	TriggerInsert TriggerEvent = iota + 1
+	TriggerUpdate
+	TriggerDelete
+)
+
+// String implements fmt.Stringer interface.
+func (e TriggerEvent) String() string {
+	switch e {
+	case TriggerInsert:
+		return "INSERT"
+	case TriggerUpdate:
+		return "UPDATE"
+	case TriggerDelete:
+		return "DELETE"
+	default:
+		return ""
+	}
+}
+
+// TriggerOrder is the position of a new trigger relative to an existing trigger
+// with the same timing and event.
+type TriggerOrder int
+
+// Trigger orders.
+const (
+	TriggerOrderNone TriggerOrder = iota
+	TriggerFollows
+	TriggerPrecedes
+)
+
+// String implements fmt.Stringer interface.
+func (o TriggerOrder) String() string {
+	switch o {
+	case TriggerFollows:
+		return "FOLLOWS"
+	case TriggerPrecedes:
+		return "PRECEDES"
+	default:
+		return ""
+	}
+}
+
+// TriggerInfo provides meta data describing a trigger of a table.
+type TriggerInfo struct {
+	ID     int64         `json:"id"`
+	Name   CIStr         `json:"name"`
+	Timing TriggerTiming `json:"timing"`
+	Event  TriggerEvent  `json:"event"`
+	// Body is the original text of the trigger body.
+	Body    string             `json:"body"`
+	Definer *auth.UserIdentity `json:"definer"`
+	// SQLMode is the sql_mode in effect when the trigger was created, the
+	// trigger body is parsed and executed with it.
+	SQLMode string    `json:"sql_mode"`
+	Charset string    `json:"charset"`
+	Collate string    `json:"collate"`
+	Created time.Time `json:"created"`
+}
+
+// Clone clones TriggerInfo.
+func (t *TriggerInfo) Clone() *TriggerInfo {
+	nt := *t
+	if t.Definer != nil {
+		definer := *t.Definer
+		nt.Definer = &definer
+	}
+	return &nt
+}
+
+// FindTriggerIndex returns the index of the trigger in the triggers of the
+// table, or -1 if the table doesn't have the trigger.
+func (t *TableInfo) FindTriggerIndex(name string) int {
+	for i, trigger := range t.Triggers {
+		if trigger.Name.L == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// HasTrigger returns whether the table has a trigger activated by the event at
+// the timing.
+func (t *TableInfo) HasTrigger(timing TriggerTiming, event TriggerEvent) bool {
+	for _, trigger := range t.Triggers {
+		if trigger.Timing == timing && trigger.Event == event {
+			return true
+		}
+	}
+	return false
+}
@@ -317,5 +317,8 @@ var AllTablePrivs = Privileges{SelectPriv, InsertPriv, UpdatePriv, DeletePriv, C
 // AllColumnPrivs is all the privileges in column scope.
 var AllColumnPrivs = Privileges{SelectPriv, InsertPriv, UpdatePriv, ReferencesPriv}
 
+// AllRoutinePrivs is all the privileges in stored routine scope.
+var AllRoutinePrivs = Privileges{ExecutePriv, AlterRoutinePriv}
+
 // StaticGlobalOnlyPrivs is all the privileges only in global scope and different from dynamic privileges.
 var StaticGlobalOnlyPrivs = Privileges{ProcessPriv, ShowDBPriv, SuperPriv, CreateUserPriv, CreateTablespacePriv, ShutdownPriv, ReloadPriv, FilePriv, ReplicationClientPriv, ReplicationSlavePriv, ConfigPriv}
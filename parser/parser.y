@@ -52,6 +52,7 @@ import (
 	/*yy:token "%c"     */
 	identifier "identifier"
 	asof       "AS OF"
+	withRollup "WITH ROLLUP"
 
 	/*yy:token "_%c"    */
 	underscoreCS "UNDERSCORE_CHARSET"
@@ -94,6 +95,7 @@ import (
 	collate           "COLLATE"
 	column            "COLUMN"
 	constraint        "CONSTRAINT"
+	continueKwd       "CONTINUE"
 	convert           "CONVERT"
 	create            "CREATE"
 	cross             "CROSS"
@@ -103,6 +105,7 @@ import (
 	currentTs         "CURRENT_TIMESTAMP"
 	currentUser       "CURRENT_USER"
 	currentRole       "CURRENT_ROLE"
+	cursor            "CURSOR"
 	database          "DATABASE"
 	databases         "DATABASES"
 	dayHour           "DAY_HOUR"
@@ -110,12 +113,14 @@ import (
 	dayMinute         "DAY_MINUTE"
 	daySecond         "DAY_SECOND"
 	decimalType       "DECIMAL"
+	declare           "DECLARE"
 	defaultKwd        "DEFAULT"
 	delayed           "DELAYED"
 	deleteKwd         "DELETE"
 	denseRank         "DENSE_RANK"
 	desc              "DESC"
 	describe          "DESCRIBE"
+	deterministic     "DETERMINISTIC"
 	distinct          "DISTINCT"
 	distinctRow       "DISTINCTROW"
 	div               "DIV"
@@ -123,9 +128,11 @@ import (
 	drop              "DROP"
 	dual              "DUAL"
 	elseKwd           "ELSE"
+	elseIfKwd         "ELSEIF"
 	enclosed          "ENCLOSED"
 	escaped           "ESCAPED"
 	exists            "EXISTS"
+	exit              "EXIT"
 	explain           "EXPLAIN"
 	except            "EXCEPT"
 	falseKwd          "FALSE"
@@ -152,10 +159,16 @@ import (
 	index             "INDEX"
 	infile            "INFILE"
 	inner             "INNER"
+	inout             "INOUT"
 	integerType       "INTEGER"
 	intersect         "INTERSECT"
 	interval          "INTERVAL"
 	into              "INTO"
+	iterate           "ITERATE"
+	leave             "LEAVE"
+	loop              "LOOP"
+	modifies          "MODIFIES"
+	out               "OUT"
 	outfile           "OUTFILE"
 	is                "IS"
 	insert            "INSERT"
@@ -166,6 +179,7 @@ import (
 	int4Type          "INT4"
 	int8Type          "INT8"
 	join              "JOIN"
+	jsonTable         "JSON_TABLE"
 	key               "KEY"
 	keys              "KEYS"
 	kill              "KILL"
@@ -216,6 +230,7 @@ import (
 	rangeKwd          "RANGE"
 	rank              "RANK"
 	read              "READ"
+	reads             "READS"
 	realType          "REAL"
 	recursive         "RECURSIVE"
 	references        "REFERENCES"
@@ -226,6 +241,7 @@ import (
 	replace           "REPLACE"
 	require           "REQUIRE"
 	restrict          "RESTRICT"
+	returnKwd         "RETURN"
 	revoke            "REVOKE"
 	right             "RIGHT"
 	rlike             "RLIKE"
@@ -236,9 +252,13 @@ import (
 	selectKwd         "SELECT"
 	set               "SET"
 	show              "SHOW"
+	signal            "SIGNAL"
 	smallIntType      "SMALLINT"
 	spatial           "SPATIAL"
 	sql               "SQL"
+	sqlexception      "SQLEXCEPTION"
+	sqlstate          "SQLSTATE"
+	sqlwarning        "SQLWARNING"
 	sqlBigResult      "SQL_BIG_RESULT"
 	sqlCalcFoundRows  "SQL_CALC_FOUND_ROWS"
 	sqlSmallResult    "SQL_SMALL_RESULT"
@@ -262,6 +282,7 @@ import (
 	union             "UNION"
 	unlock            "UNLOCK"
 	unsigned          "UNSIGNED"
+	until             "UNTIL"
 	update            "UPDATE"
 	usage             "USAGE"
 	use               "USE"
@@ -278,6 +299,7 @@ import (
 	virtual           "VIRTUAL"
 	when              "WHEN"
 	where             "WHERE"
+	while             "WHILE"
 	write             "WRITE"
 	window            "WINDOW"
 	with              "WITH"
@@ -311,6 +333,7 @@ import (
 	backend               "BACKEND"
 	backup                "BACKUP"
 	backups               "BACKUPS"
+	before                "BEFORE"
 	begin                 "BEGIN"
 	bernoulli             "BERNOULLI"
 	binding               "BINDING"
@@ -334,6 +357,7 @@ import (
 	cleanup               "CLEANUP"
 	client                "CLIENT"
 	clientErrorsSummary   "CLIENT_ERRORS_SUMMARY"
+	closeKwd              "CLOSE"
 	coalesce              "COALESCE"
 	collation             "COLLATION"
 	columnFormat          "COLUMN_FORMAT"
@@ -349,6 +373,7 @@ import (
 	connection            "CONNECTION"
 	consistency           "CONSISTENCY"
 	consistent            "CONSISTENT"
+	contains              "CONTAINS"
 	context               "CONTEXT"
 	cpu                   "CPU"
 	csvBackslashEscape    "CSV_BACKSLASH_ESCAPE"
@@ -376,6 +401,8 @@ import (
 	do                    "DO"
 	duplicate             "DUPLICATE"
 	dynamic               "DYNAMIC"
+	each                  "EACH"
+	emptyKwd              "EMPTY"
 	enable                "ENABLE"
 	enabled               "ENABLED"
 	encryption            "ENCRYPTION"
@@ -402,12 +429,15 @@ import (
 	fixed                 "FIXED"
 	flush                 "FLUSH"
 	following             "FOLLOWING"
+	follows               "FOLLOWS"
 	format                "FORMAT"
+	found                 "FOUND"
 	full                  "FULL"
 	function              "FUNCTION"
 	general               "GENERAL"
 	global                "GLOBAL"
 	grants                "GRANTS"
+	handler               "HANDLER"
 	hash                  "HASH"
 	help                  "HELP"
 	histogram             "HISTOGRAM"
@@ -454,6 +484,7 @@ import (
 	mb                    "MB"
 	memory                "MEMORY"
 	merge                 "MERGE"
+	messageText           "MESSAGE_TEXT"
 	microsecond           "MICROSECOND"
 	minRows               "MIN_ROWS"
 	minute                "MINUTE"
@@ -461,9 +492,11 @@ import (
 	mode                  "MODE"
 	modify                "MODIFY"
 	month                 "MONTH"
+	mysqlErrno            "MYSQL_ERRNO"
 	names                 "NAMES"
 	national              "NATIONAL"
 	ncharType             "NCHAR"
+	nested                "NESTED"
 	never                 "NEVER"
 	next                  "NEXT"
 	nextval               "NEXTVAL"
@@ -485,6 +518,7 @@ import (
 	only                  "ONLY"
 	open                  "OPEN"
 	optional              "OPTIONAL"
+	ordinality            "ORDINALITY"
 	packKeys              "PACK_KEYS"
 	pageSym               "PAGE"
 	parser                "PARSER"
@@ -492,6 +526,7 @@ import (
 	partitioning          "PARTITIONING"
 	partitions            "PARTITIONS"
 	password              "PASSWORD"
+	pathKwd               "PATH"
 	percent               "PERCENT"
 	per_db                "PER_DB"
 	per_table             "PER_TABLE"
@@ -500,6 +535,7 @@ import (
 	policy                "POLICY"
 	preSplitRegions       "PRE_SPLIT_REGIONS"
 	preceding             "PRECEDING"
+	precedes              "PRECEDES"
 	prepare               "PREPARE"
 	preserve              "PRESERVE"
 	privileges            "PRIVILEGES"
@@ -531,14 +567,17 @@ import (
 	restore               "RESTORE"
 	restores              "RESTORES"
 	resume                "RESUME"
+	returns               "RETURNS"
 	reverse               "REVERSE"
 	role                  "ROLE"
 	rollback              "ROLLBACK"
+	rollup                "ROLLUP"
 	routine               "ROUTINE"
 	rowCount              "ROW_COUNT"
 	rowFormat             "ROW_FORMAT"
 	rtree                 "RTREE"
 	san                   "SAN"
+	savepoint             "SAVEPOINT"
 	second                "SECOND"
 	secondaryEngine       "SECONDARY_ENGINE"
 	secondaryLoad         "SECONDARY_LOAD"
@@ -856,6 +895,9 @@ import (
 	CommitStmt                 "COMMIT statement"
 	CreateTableStmt            "CREATE TABLE statement"
 	CreateViewStmt             "CREATE VIEW  statement"
+	CreateProcedureStmt        "CREATE PROCEDURE statement"
+	CreateFunctionStmt         "CREATE FUNCTION statement"
+	CreateTriggerStmt          "CREATE TRIGGER statement"
 	CreateUserStmt             "CREATE User statement"
 	CreateRoleStmt             "CREATE Role statement"
 	CreateDatabaseStmt         "Create Database Statement"
@@ -876,6 +918,15 @@ import (
 	DropUserStmt               "DROP USER"
 	DropRoleStmt               "DROP ROLE"
 	DropViewStmt               "DROP VIEW statement"
+	DropProcedureStmt          "DROP PROCEDURE statement"
+	DropFunctionStmt           "DROP FUNCTION statement"
+	DropTriggerStmt            "DROP TRIGGER statement"
+	RoutineStmt                "Statement in a stored routine body"
+	RoutineControlStmt         "Flow control statement in a stored routine body"
+	RoutineSQLStmt             "SQL statement in a stored routine body"
+	RoutineLabelableStmt       "Stored routine statement which can be labeled"
+	RoutineDecl                "DECLARE statement in a stored routine body"
+	RoutineCursorSelect        "SELECT statement of a cursor"
 	DropBindingStmt            "DROP BINDING  statement"
 	DropPolicyStmt             "DROP PLACEMENT POLICY statement"
 	DeallocateStmt             "Deallocate prepared statement"
@@ -910,7 +961,9 @@ import (
 	ResumeImportStmt           "RESUME IMPORT statement"
 	RevokeStmt                 "Revoke statement"
 	RevokeRoleStmt             "Revoke role statement"
+	ReleaseSavepointStmt       "RELEASE SAVEPOINT statement"
 	RollbackStmt               "ROLLBACK statement"
+	SavepointStmt              "SAVEPOINT statement"
 	SplitRegionStmt            "Split index region statement"
 	SetStmt                    "Set variable statement"
 	ChangeStmt                 "Change statement"
@@ -939,6 +992,33 @@ import (
 
 %type	<item>
 	AdminShowSlow                          "Admin Show Slow statement"
+	RoutineParamListOpt                    "Stored procedure parameter list optional"
+	RoutineParamList                       "Stored procedure parameter list"
+	RoutineParam                           "Stored procedure parameter"
+	RoutineParamModeOpt                    "Stored procedure parameter mode"
+	FunctionParamListOpt                   "Stored function parameter list optional"
+	FunctionParamList                      "Stored function parameter list"
+	FunctionParam                          "Stored function parameter"
+	RoutineCharacteristicListOpt           "Stored routine characteristic list optional"
+	RoutineCharacteristic                  "Stored routine characteristic"
+	RoutineStmtListOpt                     "Stored routine statement list optional"
+	RoutineStmtList                        "Stored routine statement list"
+	RoutineElseIfListOpt                   "ELSEIF branch list optional"
+	RoutineElseOpt                         "ELSE branch optional"
+	RoutineDeclList                        "DECLARE statement list"
+	RoutineVarList                         "Stored routine local variable list"
+	RoutineVarDefaultOpt                   "Stored routine local variable default value"
+	RoutineHandlerAction                   "Condition handler action"
+	RoutineHandlerConditionList            "Condition handler condition list"
+	RoutineHandlerCondition                "Condition handler condition"
+	RoutineSignalInfoOpt                   "SIGNAL information items optional"
+	RoutineSignalInfoList                  "SIGNAL information item list"
+	RoutineSignalInfo                      "SIGNAL information item"
+	SelectIntoVarList                      "SELECT INTO variable list"
+	SelectIntoVar                          "SELECT INTO variable"
+	TriggerTiming                          "Trigger action time"
+	TriggerEvent                           "Trigger event"
+	TriggerOrder                           "FOLLOWS or PRECEDES"
 	AllOrPartitionNameList                 "All or partition name list"
 	AlgorithmClause                        "Alter table algorithm"
 	AlterTablePartitionOpt                 "Alter table partition option"
@@ -1056,6 +1136,11 @@ import (
 	IndexPartSpecificationListOpt          "Optional list of index column name or expression"
 	InsertValues                           "Rest part of INSERT/REPLACE INTO statement"
 	JoinTable                              "join table"
+	JSONTableColumn                        "JSON_TABLE column definition"
+	JSONTableColumnList                    "JSON_TABLE column definition list"
+	JSONTableColumnsClause                 "JSON_TABLE COLUMNS clause"
+	JSONTableOnEmptyOnErrorOpt             "JSON_TABLE ON EMPTY and ON ERROR clauses"
+	JSONTableOnResponse                    "JSON_TABLE ON EMPTY or ON ERROR response"
 	JoinType                               "join type"
 	KillOrKillTiDB                         "Kill or Kill TiDB"
 	LocationLabelList                      "location label name list"
@@ -1145,6 +1230,7 @@ import (
 	SelectStmtFromDualTable                "SELECT statement from dual table"
 	SelectStmtFromTable                    "SELECT statement from table"
 	SelectStmtGroup                        "SELECT statement optional GROUP BY clause"
+	SelectStmtIntoClause                   "SELECT statement non-empty into clause"
 	SelectStmtIntoOption                   "SELECT statement into clause"
 	SequenceOption                         "Create sequence option"
 	SequenceOptionList                     "Create sequence option list"
@@ -1320,44 +1406,45 @@ import (
 	StatsOptionsOpt                        "Stats options"
 
 %type	<ident>
-	AsOpt             "AS or EmptyString"
-	KeyOrIndex        "{KEY|INDEX}"
-	ColumnKeywordOpt  "Column keyword or empty"
-	PrimaryOpt        "Optional primary keyword"
-	NowSym            "CURRENT_TIMESTAMP/LOCALTIME/LOCALTIMESTAMP"
-	NowSymFunc        "CURRENT_TIMESTAMP/LOCALTIME/LOCALTIMESTAMP/NOW"
-	DefaultKwdOpt     "optional DEFAULT keyword"
-	DatabaseSym       "DATABASE or SCHEMA"
-	ExplainSym        "EXPLAIN or DESCRIBE or DESC"
-	RegexpSym         "REGEXP or RLIKE"
-	IntoOpt           "INTO or EmptyString"
-	ValueSym          "Value or Values"
-	NotSym            "Not token"
-	Char              "{CHAR|CHARACTER}"
-	NChar             "{NCHAR|NATIONAL CHARACTER|NATIONAL CHAR}"
-	Varchar           "{VARCHAR|VARCHARACTER|CHARACTER VARYING|CHAR VARYING}"
-	NVarchar          "{NATIONAL VARCHAR|NATIONAL VARCHARACTER|NVARCHAR|NCHAR VARCHAR|NATIONAL CHARACTER VARYING|NATIONAL CHAR VARYING|NCHAR VARYING}"
-	Year              "{YEAR|SQL_TSI_YEAR}"
-	DeallocateSym     "Deallocate or drop"
-	OuterOpt          "optional OUTER clause"
-	CrossOpt          "Cross join option"
-	TablesTerminalSym "{TABLE|TABLES}"
-	IsolationLevel    "Isolation level"
-	ShowIndexKwd      "Show index/indexs/key keyword"
-	DistinctKwd       "DISTINCT/DISTINCTROW keyword"
-	FromOrIn          "From or In"
-	OptTable          "Optional table keyword"
-	OptInteger        "Optional Integer keyword"
-	CharsetKw         "charset or charater set"
-	CommaOpt          "optional comma"
-	logAnd            "logical and operator"
-	logOr             "logical or operator"
-	LinearOpt         "linear or empty"
-	FieldsOrColumns   "Fields or columns"
-	StorageMedia      "{DISK|MEMORY|DEFAULT}"
-	EncryptionOpt     "Encryption option 'Y' or 'N'"
-	FirstOrNext       "FIRST or NEXT"
-	RowOrRows         "ROW or ROWS"
+	AsOpt              "AS or EmptyString"
+	RoutineEndLabelOpt "Stored routine end label optional"
+	KeyOrIndex         "{KEY|INDEX}"
+	ColumnKeywordOpt   "Column keyword or empty"
+	PrimaryOpt         "Optional primary keyword"
+	NowSym             "CURRENT_TIMESTAMP/LOCALTIME/LOCALTIMESTAMP"
+	NowSymFunc         "CURRENT_TIMESTAMP/LOCALTIME/LOCALTIMESTAMP/NOW"
+	DefaultKwdOpt      "optional DEFAULT keyword"
+	DatabaseSym        "DATABASE or SCHEMA"
+	ExplainSym         "EXPLAIN or DESCRIBE or DESC"
+	RegexpSym          "REGEXP or RLIKE"
+	IntoOpt            "INTO or EmptyString"
+	ValueSym           "Value or Values"
+	NotSym             "Not token"
+	Char               "{CHAR|CHARACTER}"
+	NChar              "{NCHAR|NATIONAL CHARACTER|NATIONAL CHAR}"
+	Varchar            "{VARCHAR|VARCHARACTER|CHARACTER VARYING|CHAR VARYING}"
+	NVarchar           "{NATIONAL VARCHAR|NATIONAL VARCHARACTER|NVARCHAR|NCHAR VARCHAR|NATIONAL CHARACTER VARYING|NATIONAL CHAR VARYING|NCHAR VARYING}"
+	Year               "{YEAR|SQL_TSI_YEAR}"
+	DeallocateSym      "Deallocate or drop"
+	OuterOpt           "optional OUTER clause"
+	CrossOpt           "Cross join option"
+	TablesTerminalSym  "{TABLE|TABLES}"
+	IsolationLevel     "Isolation level"
+	ShowIndexKwd       "Show index/indexs/key keyword"
+	DistinctKwd        "DISTINCT/DISTINCTROW keyword"
+	FromOrIn           "From or In"
+	OptTable           "Optional table keyword"
+	OptInteger         "Optional Integer keyword"
+	CharsetKw          "charset or charater set"
+	CommaOpt           "optional comma"
+	logAnd             "logical and operator"
+	logOr              "logical or operator"
+	LinearOpt          "linear or empty"
+	FieldsOrColumns    "Fields or columns"
+	StorageMedia       "{DISK|MEMORY|DEFAULT}"
+	EncryptionOpt      "Encryption option 'Y' or 'N'"
+	FirstOrNext        "FIRST or NEXT"
+	RowOrRows          "ROW or ROWS"
 
 %type	<ident>
 	Identifier                      "identifier or unreserved keyword"
@@ -1397,6 +1484,7 @@ import (
 	Symbol                          "Constraint Symbol"
 
 %precedence empty
+%precedence into
 %precedence as
 %precedence placement
 %precedence lowerThanSelectOpt
@@ -4216,6 +4304,577 @@ CreateViewStmt:
 		$$ = x
 	}
 
+/*******************************************************************
+ *
+ *  Create Procedure / Function Statement
+ *
+ *  Example:
+ *      CREATE DEFINER = 'root'@'%' PROCEDURE p(IN a INT, OUT b INT) SQL SECURITY INVOKER
+ *          BEGIN SELECT a + 1 INTO b; END
+ *      CREATE FUNCTION f(a INT) RETURNS INT DETERMINISTIC RETURN a + 1
+ *
+ *  The prefix is shared with CREATE VIEW, OR REPLACE and ALGORITHM are
+ *  rejected in the actions.
+ *******************************************************************/
+CreateProcedureStmt:
+	"CREATE" OrReplace ViewAlgorithm ViewDefiner "PROCEDURE" IfNotExists TableName '(' RoutineParamListOpt ')' RoutineCharacteristicListOpt RoutineStmt
+	{
+		if $2.(bool) || $3.(model.ViewAlgorithm) != model.AlgorithmUndefined {
+			yylex.AppendError(ErrSyntax)
+			return 1
+		}
+		body := $12.(ast.StmtNode)
+		startOffset := parser.startOffset(&yyS[yypt])
+		body.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.endOffset(&parser.yylval)]))
+		x := &ast.CreateRoutineStmt{
+			Type:        model.RoutineProcedure,
+			IfNotExists: $6.(bool),
+			Definer:     $4.(*auth.UserIdentity),
+			Name:        $7.(*ast.TableName),
+			Params:      $9.([]*ast.RoutineParam),
+			Body:        body,
+		}
+		for _, apply := range $11.([]func(*ast.CreateRoutineStmt)) {
+			apply(x)
+		}
+		$$ = x
+	}
+
+CreateFunctionStmt:
+	"CREATE" OrReplace ViewAlgorithm ViewDefiner "FUNCTION" IfNotExists TableName '(' FunctionParamListOpt ')' "RETURNS" Type RoutineCharacteristicListOpt RoutineControlStmt
+	{
+		if $2.(bool) || $3.(model.ViewAlgorithm) != model.AlgorithmUndefined {
+			yylex.AppendError(ErrSyntax)
+			return 1
+		}
+		body := $14.(ast.StmtNode)
+		startOffset := parser.startOffset(&yyS[yypt])
+		body.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.endOffset(&parser.yylval)]))
+		x := &ast.CreateRoutineStmt{
+			Type:        model.RoutineFunction,
+			IfNotExists: $6.(bool),
+			Definer:     $4.(*auth.UserIdentity),
+			Name:        $7.(*ast.TableName),
+			Params:      $9.([]*ast.RoutineParam),
+			ReturnType:  $12.(*types.FieldType),
+			Body:        body,
+		}
+		for _, apply := range $13.([]func(*ast.CreateRoutineStmt)) {
+			apply(x)
+		}
+		$$ = x
+	}
+
+/*******************************************************************
+ *
+ *  Create Trigger Statement
+ *
+ *  Example:
+ *      CREATE TRIGGER t_bu BEFORE UPDATE ON t FOR EACH ROW SET NEW.updated_at = NOW()
+ *      CREATE TRIGGER t_au AFTER UPDATE ON t FOR EACH ROW FOLLOWS t_au0
+ *          UPDATE s SET total = total + NEW.v - OLD.v
+ *******************************************************************/
+CreateTriggerStmt:
+	"CREATE" OrReplace ViewAlgorithm ViewDefiner "TRIGGER" IfNotExists TableName TriggerTiming TriggerEvent "ON" TableName "FOR" "EACH" "ROW" RoutineStmt
+	{
+		if $2.(bool) || $3.(model.ViewAlgorithm) != model.AlgorithmUndefined {
+			yylex.AppendError(ErrSyntax)
+			return 1
+		}
+		body := $15.(ast.StmtNode)
+		startOffset := parser.startOffset(&yyS[yypt])
+		body.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.endOffset(&parser.yylval)]))
+		$$ = &ast.CreateTriggerStmt{
+			IfNotExists: $6.(bool),
+			Definer:     $4.(*auth.UserIdentity),
+			Name:        $7.(*ast.TableName),
+			Timing:      $8.(model.TriggerTiming),
+			Event:       $9.(model.TriggerEvent),
+			Table:       $11.(*ast.TableName),
+			Body:        body,
+		}
+	}
+|	"CREATE" OrReplace ViewAlgorithm ViewDefiner "TRIGGER" IfNotExists TableName TriggerTiming TriggerEvent "ON" TableName "FOR" "EACH" "ROW" TriggerOrder Identifier RoutineStmt
+	{
+		if $2.(bool) || $3.(model.ViewAlgorithm) != model.AlgorithmUndefined {
+			yylex.AppendError(ErrSyntax)
+			return 1
+		}
+		body := $17.(ast.StmtNode)
+		startOffset := parser.startOffset(&yyS[yypt])
+		body.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.endOffset(&parser.yylval)]))
+		$$ = &ast.CreateTriggerStmt{
+			IfNotExists:  $6.(bool),
+			Definer:      $4.(*auth.UserIdentity),
+			Name:         $7.(*ast.TableName),
+			Timing:       $8.(model.TriggerTiming),
+			Event:        $9.(model.TriggerEvent),
+			Table:        $11.(*ast.TableName),
+			Order:        $15.(model.TriggerOrder),
+			OtherTrigger: $16,
+			Body:         body,
+		}
+	}
+
+TriggerTiming:
+	"BEFORE"
+	{
+		$$ = model.TriggerBefore
+	}
+|	"AFTER"
+	{
+		$$ = model.TriggerAfter
+	}
+
+TriggerEvent:
+	"INSERT"
+	{
+		$$ = model.TriggerInsert
+	}
+|	"UPDATE"
+	{
+		$$ = model.TriggerUpdate
+	}
+|	"DELETE"
+	{
+		$$ = model.TriggerDelete
+	}
+
+TriggerOrder:
+	"FOLLOWS"
+	{
+		$$ = model.TriggerFollows
+	}
+|	"PRECEDES"
+	{
+		$$ = model.TriggerPrecedes
+	}
+
+RoutineParamListOpt:
+	/* EMPTY */
+	{
+		$$ = []*ast.RoutineParam{}
+	}
+|	RoutineParamList
+
+RoutineParamList:
+	RoutineParam
+	{
+		$$ = []*ast.RoutineParam{$1.(*ast.RoutineParam)}
+	}
+|	RoutineParamList ',' RoutineParam
+	{
+		$$ = append($1.([]*ast.RoutineParam), $3.(*ast.RoutineParam))
+	}
+
+RoutineParam:
+	RoutineParamModeOpt Identifier Type
+	{
+		$$ = &ast.RoutineParam{
+			Mode: $1.(model.RoutineParamMode),
+			Name: $2,
+			Tp:   $3.(*types.FieldType),
+		}
+	}
+
+RoutineParamModeOpt:
+	/* EMPTY */
+	{
+		$$ = model.RoutineParamIn
+	}
+|	"IN"
+	{
+		$$ = model.RoutineParamIn
+	}
+|	"OUT"
+	{
+		$$ = model.RoutineParamOut
+	}
+|	"INOUT"
+	{
+		$$ = model.RoutineParamInOut
+	}
+
+FunctionParamListOpt:
+	/* EMPTY */
+	{
+		$$ = []*ast.RoutineParam{}
+	}
+|	FunctionParamList
+
+FunctionParamList:
+	FunctionParam
+	{
+		$$ = []*ast.RoutineParam{$1.(*ast.RoutineParam)}
+	}
+|	FunctionParamList ',' FunctionParam
+	{
+		$$ = append($1.([]*ast.RoutineParam), $3.(*ast.RoutineParam))
+	}
+
+FunctionParam:
+	Identifier Type
+	{
+		$$ = &ast.RoutineParam{
+			Mode: model.RoutineParamIn,
+			Name: $1,
+			Tp:   $2.(*types.FieldType),
+		}
+	}
+
+RoutineCharacteristicListOpt:
+	/* EMPTY */
+	{
+		$$ = []func(*ast.CreateRoutineStmt){}
+	}
+|	RoutineCharacteristicListOpt RoutineCharacteristic
+	{
+		$$ = append($1.([]func(*ast.CreateRoutineStmt)), $2.(func(*ast.CreateRoutineStmt)))
+	}
+
+RoutineCharacteristic:
+	"COMMENT" stringLit
+	{
+		comment := $2
+		$$ = func(x *ast.CreateRoutineStmt) { x.Comment = comment }
+	}
+|	"LANGUAGE" "SQL"
+	{
+		$$ = func(*ast.CreateRoutineStmt) {}
+	}
+|	"DETERMINISTIC"
+	{
+		$$ = func(x *ast.CreateRoutineStmt) { x.Deterministic = true }
+	}
+|	"NOT" "DETERMINISTIC"
+	{
+		$$ = func(x *ast.CreateRoutineStmt) { x.Deterministic = false }
+	}
+|	"CONTAINS" "SQL"
+	{
+		$$ = func(x *ast.CreateRoutineStmt) { x.DataAccess = model.RoutineContainsSQL }
+	}
+|	"NO" "SQL"
+	{
+		$$ = func(x *ast.CreateRoutineStmt) { x.DataAccess = model.RoutineNoSQL }
+	}
+|	"READS" "SQL" "DATA"
+	{
+		$$ = func(x *ast.CreateRoutineStmt) { x.DataAccess = model.RoutineReadsSQLData }
+	}
+|	"MODIFIES" "SQL" "DATA"
+	{
+		$$ = func(x *ast.CreateRoutineStmt) { x.DataAccess = model.RoutineModifiesSQLData }
+	}
+|	"SQL" "SECURITY" "DEFINER"
+	{
+		$$ = func(x *ast.CreateRoutineStmt) { x.Security = model.SecurityDefiner }
+	}
+|	"SQL" "SECURITY" "INVOKER"
+	{
+		$$ = func(x *ast.CreateRoutineStmt) { x.Security = model.SecurityInvoker }
+	}
+
+/*******************************************************************
+ *
+ *  Stored routine body
+ *
+ *  See https://dev.mysql.com/doc/refman/8.0/en/sql-compound-statements.html
+ *
+ *  Labels only accept the identifier token, so that an unreserved keyword
+ *  starting a routine characteristic is never taken as a label.
+ *******************************************************************/
+RoutineStmt:
+	RoutineSQLStmt
+|	RoutineControlStmt
+
+/* The body of a stored function doesn't return result sets, so it's never a bare SQL statement. */
+RoutineControlStmt:
+	RoutineLabelableStmt
+|	identifier ':' RoutineLabelableStmt RoutineEndLabelOpt
+	{
+		if $4 != "" && !strings.EqualFold($1, $4) {
+			yylex.AppendError(ErrSpLabelMismatch.GenWithStackByArgs($4))
+			return 1
+		}
+		switch x := $3.(type) {
+		case *ast.BlockStmt:
+			x.Label = $1
+		case *ast.LoopStmt:
+			x.Label = $1
+		case *ast.WhileStmt:
+			x.Label = $1
+		case *ast.RepeatStmt:
+			x.Label = $1
+		}
+		$$ = $3
+	}
+|	"IF" Expression "THEN" RoutineStmtList RoutineElseIfListOpt RoutineElseOpt "END" "IF"
+	{
+		branches := []*ast.IfBranch{{Cond: $2, Stmts: $4.([]ast.StmtNode)}}
+		x := &ast.IfStmt{Branches: append(branches, $5.([]*ast.IfBranch)...)}
+		if $6 != nil {
+			x.Else = $6.([]ast.StmtNode)
+		}
+		$$ = x
+	}
+|	"LEAVE" identifier
+	{
+		$$ = &ast.LeaveStmt{Label: $2}
+	}
+|	"ITERATE" identifier
+	{
+		$$ = &ast.IterateStmt{Label: $2}
+	}
+|	"RETURN" Expression
+	{
+		$$ = &ast.ReturnStmt{Expr: $2}
+	}
+|	"OPEN" Identifier
+	{
+		$$ = &ast.OpenCursorStmt{Name: $2}
+	}
+|	"FETCH" Identifier "INTO" RoutineVarList
+	{
+		$$ = &ast.FetchCursorStmt{Name: $2, Vars: $4.([]string)}
+	}
+|	"FETCH" "FROM" Identifier "INTO" RoutineVarList
+	{
+		$$ = &ast.FetchCursorStmt{Name: $3, Vars: $5.([]string)}
+	}
+|	"FETCH" "NEXT" "FROM" Identifier "INTO" RoutineVarList
+	{
+		$$ = &ast.FetchCursorStmt{Name: $4, Vars: $6.([]string)}
+	}
+|	"CLOSE" Identifier
+	{
+		$$ = &ast.CloseCursorStmt{Name: $2}
+	}
+|	"SIGNAL" "SQLSTATE" SQLStateValueOpt stringLit RoutineSignalInfoOpt
+	{
+		x := $5.(*ast.SignalStmt)
+		x.SQLState = $4
+		$$ = x
+	}
+
+/* The SQL statements which can be used in a stored routine body. */
+RoutineSQLStmt:
+	SelectStmt
+|	SetOprStmt
+|	SelectStmtWithClause
+|	InsertIntoStmt
+|	ReplaceIntoStmt
+|	UpdateStmt
+|	DeleteFromStmt
+|	SetStmt
+|	CallStmt
+|	DoStmt
+|	TruncateTableStmt
+|	CreateTableStmt
+|	DropTableStmt
+|	CommitStmt
+|	RollbackStmt
+|	ShowStmt
+
+RoutineLabelableStmt:
+	"BEGIN" RoutineDeclList RoutineStmtListOpt "END"
+	{
+		$$ = &ast.BlockStmt{Decls: $2.([]ast.StmtNode), Stmts: $3.([]ast.StmtNode)}
+	}
+|	"LOOP" RoutineStmtList "END" "LOOP"
+	{
+		$$ = &ast.LoopStmt{Stmts: $2.([]ast.StmtNode)}
+	}
+|	"WHILE" Expression "DO" RoutineStmtList "END" "WHILE"
+	{
+		$$ = &ast.WhileStmt{Cond: $2, Stmts: $4.([]ast.StmtNode)}
+	}
+|	"REPEAT" RoutineStmtList "UNTIL" Expression "END" "REPEAT"
+	{
+		$$ = &ast.RepeatStmt{Stmts: $2.([]ast.StmtNode), Cond: $4}
+	}
+
+RoutineEndLabelOpt:
+	/* EMPTY */
+	{
+		$$ = ""
+	}
+|	identifier
+
+RoutineStmtListOpt:
+	/* EMPTY */
+	{
+		$$ = []ast.StmtNode{}
+	}
+|	RoutineStmtList
+
+RoutineStmtList:
+	RoutineStmt ';'
+	{
+		$$ = []ast.StmtNode{$1}
+	}
+|	RoutineStmtList RoutineStmt ';'
+	{
+		$$ = append($1.([]ast.StmtNode), $2)
+	}
+
+RoutineElseIfListOpt:
+	/* EMPTY */
+	{
+		$$ = []*ast.IfBranch{}
+	}
+|	RoutineElseIfListOpt "ELSEIF" Expression "THEN" RoutineStmtList
+	{
+		$$ = append($1.([]*ast.IfBranch), &ast.IfBranch{Cond: $3, Stmts: $5.([]ast.StmtNode)})
+	}
+
+RoutineElseOpt:
+	/* EMPTY */
+	{
+		$$ = nil
+	}
+|	"ELSE" RoutineStmtList
+	{
+		$$ = $2
+	}
+
+RoutineDeclList:
+	/* EMPTY */
+	{
+		$$ = []ast.StmtNode{}
+	}
+|	RoutineDeclList RoutineDecl ';'
+	{
+		$$ = append($1.([]ast.StmtNode), $2)
+	}
+
+RoutineDecl:
+	"DECLARE" RoutineVarList Type RoutineVarDefaultOpt
+	{
+		x := &ast.DeclareVarStmt{Names: $2.([]string), Tp: $3.(*types.FieldType)}
+		if $4 != nil {
+			x.Default = $4.(ast.ExprNode)
+		}
+		$$ = x
+	}
+|	"DECLARE" Identifier "CURSOR" "FOR" RoutineCursorSelect
+	{
+		$$ = &ast.DeclareCursorStmt{Name: $2, Select: $5}
+	}
+|	"DECLARE" RoutineHandlerAction "HANDLER" "FOR" RoutineHandlerConditionList RoutineStmt
+	{
+		$$ = &ast.DeclareHandlerStmt{
+			Action:     $2.(ast.HandlerAction),
+			Conditions: $5.([]*ast.HandlerCondition),
+			Body:       $6,
+		}
+	}
+
+RoutineCursorSelect:
+	SelectStmt
+|	SetOprStmt
+|	SelectStmtWithClause
+
+RoutineVarList:
+	Identifier
+	{
+		$$ = []string{$1}
+	}
+|	RoutineVarList ',' Identifier
+	{
+		$$ = append($1.([]string), $3)
+	}
+
+RoutineVarDefaultOpt:
+	/* EMPTY */
+	{
+		$$ = nil
+	}
+|	"DEFAULT" Expression
+	{
+		$$ = $2
+	}
+
+RoutineHandlerAction:
+	"CONTINUE"
+	{
+		$$ = ast.HandlerContinue
+	}
+|	"EXIT"
+	{
+		$$ = ast.HandlerExit
+	}
+
+RoutineHandlerConditionList:
+	RoutineHandlerCondition
+	{
+		$$ = []*ast.HandlerCondition{$1.(*ast.HandlerCondition)}
+	}
+|	RoutineHandlerConditionList ',' RoutineHandlerCondition
+	{
+		$$ = append($1.([]*ast.HandlerCondition), $3.(*ast.HandlerCondition))
+	}
+
+RoutineHandlerCondition:
+	NUM
+	{
+		$$ = &ast.HandlerCondition{Tp: ast.HandlerConditionErrorCode, ErrorCode: getUint64FromNUM($1)}
+	}
+|	"SQLSTATE" SQLStateValueOpt stringLit
+	{
+		$$ = &ast.HandlerCondition{Tp: ast.HandlerConditionSQLState, SQLState: $3}
+	}
+|	"SQLWARNING"
+	{
+		$$ = &ast.HandlerCondition{Tp: ast.HandlerConditionSQLWarning}
+	}
+|	"NOT" "FOUND"
+	{
+		$$ = &ast.HandlerCondition{Tp: ast.HandlerConditionNotFound}
+	}
+|	"SQLEXCEPTION"
+	{
+		$$ = &ast.HandlerCondition{Tp: ast.HandlerConditionSQLException}
+	}
+
+SQLStateValueOpt:
+	{}
+|	"VALUE"
+
+RoutineSignalInfoOpt:
+	/* EMPTY */
+	{
+		$$ = &ast.SignalStmt{}
+	}
+|	"SET" RoutineSignalInfoList
+	{
+		$$ = $2
+	}
+
+RoutineSignalInfoList:
+	RoutineSignalInfo
+|	RoutineSignalInfoList ',' RoutineSignalInfo
+	{
+		x, info := $1.(*ast.SignalStmt), $3.(*ast.SignalStmt)
+		if info.MessageText != nil {
+			x.MessageText = info.MessageText
+		}
+		if info.MySQLErrno != nil {
+			x.MySQLErrno = info.MySQLErrno
+		}
+		$$ = x
+	}
+
+RoutineSignalInfo:
+	"MESSAGE_TEXT" EqOrAssignmentEq Expression
+	{
+		$$ = &ast.SignalStmt{MessageText: $3}
+	}
+|	"MYSQL_ERRNO" EqOrAssignmentEq Expression
+	{
+		$$ = &ast.SignalStmt{MySQLErrno: $3}
+	}
+
 OrReplace:
 	/* EMPTY */
 	{
@@ -4474,6 +5133,24 @@ DropViewStmt:
 		$$ = &ast.DropTableStmt{IfExists: true, Tables: $5.([]*ast.TableName), IsView: true}
 	}
 
+DropProcedureStmt:
+	"DROP" "PROCEDURE" IfExists TableName
+	{
+		$$ = &ast.DropRoutineStmt{Type: model.RoutineProcedure, IfExists: $3.(bool), Name: $4.(*ast.TableName)}
+	}
+
+DropFunctionStmt:
+	"DROP" "FUNCTION" IfExists TableName
+	{
+		$$ = &ast.DropRoutineStmt{Type: model.RoutineFunction, IfExists: $3.(bool), Name: $4.(*ast.TableName)}
+	}
+
+DropTriggerStmt:
+	"DROP" "TRIGGER" IfExists TableName
+	{
+		$$ = &ast.DropTriggerStmt{IfExists: $3.(bool), Name: $4.(*ast.TableName)}
+	}
+
 DropUserStmt:
 	"DROP" "USER" UsernameList
 	{
@@ -5526,6 +6203,10 @@ GroupByClause:
 	{
 		$$ = &ast.GroupByClause{Items: $3.([]*ast.ByItem)}
 	}
+|	"GROUP" "BY" ByList "WITH ROLLUP"
+	{
+		$$ = &ast.GroupByClause{Items: $3.([]*ast.ByItem), Rollup: true}
+	}
 
 HavingClause:
 	{
@@ -6079,6 +6760,23 @@ UnReservedKeyword:
 |	"CLUSTERED"
 |	"NONCLUSTERED"
 |	"PRESERVE"
+|	"EMPTY"
+|	"NESTED"
+|	"ORDINALITY"
+|	"PATH"
+|	"ROLLUP"
+|	"SAVEPOINT"
+|	"CLOSE"
+|	"CONTAINS"
+|	"FOUND"
+|	"HANDLER"
+|	"MESSAGE_TEXT"
+|	"MYSQL_ERRNO"
+|	"RETURNS"
+|	"BEFORE"
+|	"EACH"
+|	"FOLLOWS"
+|	"PRECEDES"
 
 TiDBKeyword:
 	"ADMIN"
@@ -8024,6 +8722,26 @@ RollbackStmt:
 	{
 		$$ = &ast.RollbackStmt{CompletionType: $2.(ast.CompletionType)}
 	}
+|	"ROLLBACK" "TO" Identifier
+	{
+		$$ = &ast.RollbackStmt{SavepointName: $3}
+	}
+|	"ROLLBACK" "TO" "SAVEPOINT" Identifier
+	{
+		$$ = &ast.RollbackStmt{SavepointName: $4}
+	}
+
+SavepointStmt:
+	"SAVEPOINT" Identifier
+	{
+		$$ = &ast.SavepointStmt{Name: $2}
+	}
+
+ReleaseSavepointStmt:
+	"RELEASE" "SAVEPOINT" Identifier
+	{
+		$$ = &ast.ReleaseSavepointStmt{Name: $3}
+	}
 
 CompletionTypeWithinTransaction:
 	"AND" "CHAIN" "NO" "RELEASE"
@@ -8126,6 +8844,33 @@ SelectStmtFromTable:
 		}
 		$$ = st
 	}
+|	SelectStmtBasic "INTO" SelectIntoVarList "FROM" TableRefsClause WhereClauseOptional SelectStmtGroup HavingClause WindowClauseOptional
+	{
+		st := $1.(*ast.SelectStmt)
+		st.SelectIntoOpt = &ast.SelectIntoOption{
+			Tp:        ast.SelectIntoVars,
+			Variables: $3.([]ast.ExprNode),
+		}
+		st.From = $5.(*ast.TableRefsClause)
+		lastField := st.Fields.Fields[len(st.Fields.Fields)-1]
+		if lastField.Expr != nil && lastField.AsName.O == "" {
+			lastEnd := parser.endOffset(&yyS[yypt-7])
+			lastField.SetText(parser.lexer.client, parser.src[lastField.Offset:lastEnd])
+		}
+		if $6 != nil {
+			st.Where = $6.(ast.ExprNode)
+		}
+		if $7 != nil {
+			st.GroupBy = $7.(*ast.GroupByClause)
+		}
+		if $8 != nil {
+			st.Having = $8.(*ast.HavingClause)
+		}
+		if $9 != nil {
+			st.WindowSpecs = ($9.([]ast.WindowSpec))
+		}
+		$$ = st
+	}
 
 TableSampleOpt:
 	%prec empty
@@ -8247,6 +8992,17 @@ SelectStmt:
 		}
 		$$ = st
 	}
+|	SelectStmtBasic SelectStmtIntoClause
+	{
+		st := $1.(*ast.SelectStmt)
+		lastField := st.Fields.Fields[len(st.Fields.Fields)-1]
+		if lastField.Expr != nil && lastField.AsName.O == "" {
+			lastEnd := yyS[yypt].offset - 1
+			lastField.SetText(parser.lexer.client, parser.src[lastField.Offset:lastEnd])
+		}
+		st.SelectIntoOpt = $2.(*ast.SelectIntoOption)
+		$$ = st
+	}
 |	SelectStmtFromDualTable SelectStmtGroup OrderByOptional SelectStmtLimitOpt SelectLockOpt SelectStmtIntoOption
 	{
 		st := $1.(*ast.SelectStmt)
@@ -8280,6 +9036,10 @@ SelectStmt:
 			st.Limit = $3.(*ast.Limit)
 		}
 		if $5 != nil {
+			if st.SelectIntoOpt != nil {
+				yylex.AppendError(yylex.Errorf("Multiple INTO clauses in one query block."))
+				return 1
+			}
 			st.SelectIntoOpt = $5.(*ast.SelectIntoOption)
 		}
 		$$ = st
@@ -8749,6 +9509,89 @@ TableFactor:
 		j.ExplicitParens = true
 		$$ = $2
 	}
+|	"JSON_TABLE" '(' Expression ',' stringLit JSONTableColumnsClause ')' TableAsName
+	{
+		jt := &ast.JSONTable{Expr: $3, Path: $5, Columns: $6.([]*ast.JSONTableColumn)}
+		$$ = &ast.TableSource{Source: jt, AsName: $8.(model.CIStr)}
+	}
+
+JSONTableColumnsClause:
+	"COLUMNS" '(' JSONTableColumnList ')'
+	{
+		$$ = $3
+	}
+
+JSONTableColumnList:
+	JSONTableColumn
+	{
+		$$ = []*ast.JSONTableColumn{$1.(*ast.JSONTableColumn)}
+	}
+|	JSONTableColumnList ',' JSONTableColumn
+	{
+		$$ = append($1.([]*ast.JSONTableColumn), $3.(*ast.JSONTableColumn))
+	}
+
+JSONTableColumn:
+	Identifier "FOR" "ORDINALITY"
+	{
+		$$ = &ast.JSONTableColumn{Type: ast.JSONTableColumnOrdinality, Name: model.NewCIStr($1)}
+	}
+|	Identifier Type "PATH" stringLit JSONTableOnEmptyOnErrorOpt
+	{
+		responses := $5.([]*ast.JSONTableOnResponse)
+		$$ = &ast.JSONTableColumn{
+			Type:    ast.JSONTableColumnPath,
+			Name:    model.NewCIStr($1),
+			Tp:      $2.(*types.FieldType),
+			Path:    $4,
+			OnEmpty: responses[0],
+			OnError: responses[1],
+		}
+	}
+|	Identifier Type "EXISTS" "PATH" stringLit
+	{
+		$$ = &ast.JSONTableColumn{Type: ast.JSONTableColumnExists, Name: model.NewCIStr($1), Tp: $2.(*types.FieldType), Path: $5}
+	}
+|	"NESTED" stringLit JSONTableColumnsClause
+	{
+		$$ = &ast.JSONTableColumn{Type: ast.JSONTableColumnNested, Path: $2, NestedColumns: $3.([]*ast.JSONTableColumn)}
+	}
+|	"NESTED" "PATH" stringLit JSONTableColumnsClause
+	{
+		$$ = &ast.JSONTableColumn{Type: ast.JSONTableColumnNested, Path: $3, NestedColumns: $4.([]*ast.JSONTableColumn)}
+	}
+
+/* The returned value is the pair of the ON EMPTY response and the ON ERROR response. */
+JSONTableOnEmptyOnErrorOpt:
+	{
+		$$ = []*ast.JSONTableOnResponse{nil, nil}
+	}
+|	JSONTableOnResponse "ON" "EMPTY"
+	{
+		$$ = []*ast.JSONTableOnResponse{$1.(*ast.JSONTableOnResponse), nil}
+	}
+|	JSONTableOnResponse "ON" "ERROR"
+	{
+		$$ = []*ast.JSONTableOnResponse{nil, $1.(*ast.JSONTableOnResponse)}
+	}
+|	JSONTableOnResponse "ON" "EMPTY" JSONTableOnResponse "ON" "ERROR"
+	{
+		$$ = []*ast.JSONTableOnResponse{$1.(*ast.JSONTableOnResponse), $4.(*ast.JSONTableOnResponse)}
+	}
+
+JSONTableOnResponse:
+	"NULL"
+	{
+		$$ = &ast.JSONTableOnResponse{Type: ast.JSONTableOnResponseNull}
+	}
+|	"ERROR"
+	{
+		$$ = &ast.JSONTableOnResponse{Type: ast.JSONTableOnResponseError}
+	}
+|	"DEFAULT" stringLit
+	{
+		$$ = &ast.JSONTableOnResponse{Type: ast.JSONTableOnResponseDefault, Default: $2}
+	}
 
 PartitionNameListOpt:
 	/* empty */
@@ -9145,7 +9988,10 @@ SelectStmtIntoOption:
 	{
 		$$ = nil
 	}
-|	"INTO" "OUTFILE" stringLit Fields Lines
+|	SelectStmtIntoClause
+
+SelectStmtIntoClause:
+	"INTO" "OUTFILE" stringLit Fields Lines
 	{
 		x := &ast.SelectIntoOption{
 			Tp:       ast.SelectIntoOutfile,
@@ -9160,6 +10006,33 @@ SelectStmtIntoOption:
 
 		$$ = x
 	}
+|	"INTO" SelectIntoVarList
+	{
+		$$ = &ast.SelectIntoOption{
+			Tp:        ast.SelectIntoVars,
+			Variables: $2.([]ast.ExprNode),
+		}
+	}
+
+SelectIntoVarList:
+	SelectIntoVar
+	{
+		$$ = []ast.ExprNode{$1.(ast.ExprNode)}
+	}
+|	SelectIntoVarList ',' SelectIntoVar
+	{
+		$$ = append($1.([]ast.ExprNode), $3.(ast.ExprNode))
+	}
+
+SelectIntoVar:
+	UserVariable
+	{
+		$$ = $1
+	}
+|	Identifier
+	{
+		$$ = &ast.ColumnNameExpr{Name: &ast.ColumnName{Name: model.NewCIStr($1)}}
+	}
 
 // See https://dev.mysql.com/doc/refman/5.7/en/subqueries.html
 SubSelect:
@@ -10284,6 +11157,27 @@ ShowStmt:
 			Table: $4.(*ast.TableName),
 		}
 	}
+|	"SHOW" "CREATE" "PROCEDURE" TableName
+	{
+		$$ = &ast.ShowStmt{
+			Tp:    ast.ShowCreateProcedure,
+			Table: $4.(*ast.TableName),
+		}
+	}
+|	"SHOW" "CREATE" "FUNCTION" TableName
+	{
+		$$ = &ast.ShowStmt{
+			Tp:    ast.ShowCreateFunction,
+			Table: $4.(*ast.TableName),
+		}
+	}
+|	"SHOW" "CREATE" "TRIGGER" TableName
+	{
+		$$ = &ast.ShowStmt{
+			Tp:    ast.ShowCreateTrigger,
+			Table: $4.(*ast.TableName),
+		}
+	}
 |	"SHOW" "CREATE" "PLACEMENT" "POLICY" PolicyName
 	{
 		$$ = &ast.ShowStmt{
@@ -10657,10 +11551,8 @@ ShowTargetFilterable:
 	{
 		// This statement is similar to SHOW PROCEDURE STATUS but for stored functions.
 		// See http://dev.mysql.com/doc/refman/5.7/en/show-function-status.html
-		// We do not support neither stored functions nor stored procedures.
-		// So we reuse show procedure status process logic.
 		$$ = &ast.ShowStmt{
-			Tp: ast.ShowProcedureStatus,
+			Tp: ast.ShowFunctionStatus,
 		}
 	}
 |	"EVENTS" ShowDatabaseNameOpt
@@ -10960,6 +11852,9 @@ Statement:
 |	CreateIndexStmt
 |	CreateTableStmt
 |	CreateViewStmt
+|	CreateProcedureStmt
+|	CreateFunctionStmt
+|	CreateTriggerStmt
 |	CreateUserStmt
 |	CreateRoleStmt
 |	CreateBindingStmt
@@ -10974,6 +11869,9 @@ Statement:
 |	DropPolicyStmt
 |	DropSequenceStmt
 |	DropViewStmt
+|	DropProcedureStmt
+|	DropFunctionStmt
+|	DropTriggerStmt
 |	DropUserStmt
 |	DropRoleStmt
 |	DropStatisticsStmt
@@ -10993,6 +11891,7 @@ Statement:
 |	PlanReplayerStmt
 |	PreparedStmt
 |	PurgeImportStmt
+|	ReleaseSavepointStmt
 |	RollbackStmt
 |	RenameTableStmt
 |	RenameUserStmt
@@ -11001,6 +11900,7 @@ Statement:
 |	ResumeImportStmt
 |	RevokeStmt
 |	RevokeRoleStmt
+|	SavepointStmt
 |	SetOprStmt
 |	SelectStmt
 |	SelectStmtWithClause
@@ -12188,6 +13088,7 @@ WhereClause:
 	}
 
 WhereClauseOptional:
+	%prec empty
 	{
 		$$ = nil
 	}
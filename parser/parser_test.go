@@ -536,6 +536,17 @@ func TestDMLStmt(t *testing.T) {
 		{"ROLLBACK AND NO CHAIN RELEASE", true, "ROLLBACK RELEASE"},
 		{"ROLLBACK AND CHAIN NO RELEASE", true, "ROLLBACK AND CHAIN"},
 		{"ROLLBACK AND CHAIN RELEASE", false, ""},
+		{"ROLLBACK TO s1", true, "ROLLBACK TO `s1`"},
+		{"ROLLBACK TO SAVEPOINT s1", true, "ROLLBACK TO `s1`"},
+		{"ROLLBACK TO SAVEPOINT savepoint", true, "ROLLBACK TO `savepoint`"},
+		{"ROLLBACK TO", false, ""},
+		{"ROLLBACK AND CHAIN TO s1", false, ""},
+		{"SAVEPOINT s1", true, "SAVEPOINT `s1`"},
+		{"SAVEPOINT `s 1`", true, "SAVEPOINT `s 1`"},
+		{"SAVEPOINT", false, ""},
+		{"RELEASE SAVEPOINT s1", true, "RELEASE SAVEPOINT `s1`"},
+		{"RELEASE s1", false, ""},
+		{"create table savepoint (savepoint int)", true, "CREATE TABLE `savepoint` (`savepoint` INT)"},
 		{`BEGIN;
 			INSERT INTO foo VALUES (42, 3.14);
 			INSERT INTO foo VALUES (-1, 2.78);
@@ -1062,7 +1073,7 @@ func TestDBAStmt(t *testing.T) {
 		// PROCEDURE and FUNCTION are currently not supported.
 		// And FUNCTION reuse show procedure status process logic.
 		{`SHOW PROCEDURE STATUS WHERE Db='test'`, true, "SHOW PROCEDURE STATUS WHERE `Db`=_UTF8MB4'test'"},
-		{`SHOW FUNCTION STATUS WHERE Db='test'`, true, "SHOW PROCEDURE STATUS WHERE `Db`=_UTF8MB4'test'"},
+		{`SHOW FUNCTION STATUS WHERE Db='test'`, true, "SHOW FUNCTION STATUS WHERE `Db`=_UTF8MB4'test'"},
 		{`SHOW INDEX FROM t;`, true, "SHOW INDEX IN `t`"},
 		{`SHOW KEYS FROM t;`, true, "SHOW INDEX IN `t`"},
 		{`SHOW INDEX IN t;`, true, "SHOW INDEX IN `t`"},
@@ -5042,6 +5053,93 @@ func TestView(t *testing.T) {
 	require.Equal(t, model.CheckOptionCascaded, v.CheckOption)
 }
 
+func TestStoredRoutine(t *testing.T) {
+	table := []testCase{
+		{"create procedure p() select 1", true, "CREATE PROCEDURE `p`() SELECT 1"},
+		{"create definer = 'root'@'localhost' procedure if not exists test.p(in a int, out b varchar(10), inout c int) comment 'x' sql security invoker modifies sql data begin select a into b; end", true, "CREATE DEFINER = `root`@`localhost` PROCEDURE IF NOT EXISTS `test`.`p`(`a` INT, OUT `b` VARCHAR(10), INOUT `c` INT) COMMENT 'x' MODIFIES SQL DATA SQL SECURITY INVOKER BEGIN SELECT `a` INTO `b`; END"},
+		{"create procedure p() language sql not deterministic contains sql begin end", true, "CREATE PROCEDURE `p`() BEGIN END"},
+		{"create procedure p() lbl: begin declare a, b int default 1; declare c cursor for select id from t; declare continue handler for not found, sqlstate value '23000', 1062 set a = 0; declare exit handler for sqlexception, sqlwarning begin end; open c; l1: loop fetch next from c into a; if a = 0 then leave l1; elseif a > 10 then iterate l1; else set b = b + a; end if; end loop l1; close c; while a < 10 do set a = a + 1; end while; repeat set a = a - 1; until a = 0 end repeat; signal sqlstate '45000' set message_text = 'oops', mysql_errno = 1644; end lbl", true, "CREATE PROCEDURE `p`() `lbl`: BEGIN DECLARE `a`, `b` INT DEFAULT 1; DECLARE `c` CURSOR FOR SELECT `id` FROM `t`; DECLARE CONTINUE HANDLER FOR NOT FOUND, SQLSTATE '23000', 1062 SET @@SESSION.`a`=0; DECLARE EXIT HANDLER FOR SQLEXCEPTION, SQLWARNING BEGIN END; OPEN `c`; `l1`: LOOP FETCH `c` INTO `a`; IF `a`=0 THEN LEAVE `l1`; ELSEIF `a`>10 THEN ITERATE `l1`; ELSE SET @@SESSION.`b`=`b`+`a`; END IF; END LOOP `l1`; CLOSE `c`; WHILE `a`<10 DO SET @@SESSION.`a`=`a`+1; END WHILE; REPEAT SET @@SESSION.`a`=`a`-1; UNTIL `a`=0 END REPEAT; SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = _UTF8MB4'oops', MYSQL_ERRNO = 1644; END `lbl`"},
+		{"create procedure p() lbl: begin end lbl2", false, ""},
+		{"create procedure p() begin end lbl", false, ""},
+		{"create or replace procedure p() select 1", false, ""},
+		{"create procedure p() begin select 1; declare a int; end", false, ""},
+		{"create function f(a int) returns int deterministic return a + 1", true, "CREATE FUNCTION `f`(`a` INT) RETURNS INT DETERMINISTIC RETURN `a`+1"},
+		{"create function test.f(a int, b varchar(10)) returns varchar(20) reads sql data begin declare c int; set c = a; return concat(b, c); end", true, "CREATE FUNCTION `test`.`f`(`a` INT, `b` VARCHAR(10)) RETURNS VARCHAR(20) READS SQL DATA BEGIN DECLARE `c` INT; SET @@SESSION.`c`=`a`; RETURN CONCAT(`b`, `c`); END"},
+		{"create function f(in a int) returns int return a", false, ""},
+		{"create function f() returns int select 1", false, ""},
+		{"drop procedure p", true, "DROP PROCEDURE `p`"},
+		{"drop procedure if exists test.p", true, "DROP PROCEDURE IF EXISTS `test`.`p`"},
+		{"drop function if exists f", true, "DROP FUNCTION IF EXISTS `f`"},
+		{"show create procedure test.p", true, "SHOW CREATE PROCEDURE `test`.`p`"},
+		{"show create function f", true, "SHOW CREATE FUNCTION `f`"},
+		{"show function status like 'f%'", true, "SHOW FUNCTION STATUS LIKE _UTF8MB4'f%'"},
+		{"call p(1, @a)", true, "CALL `p`(1, @`a`)"},
+		{"select a, b from t into @a, c", true, "SELECT `a`,`b` FROM `t` INTO @`a`, `c`"},
+		{"select count(*) into y from t where a > 1", true, "SELECT COUNT(1) FROM `t` WHERE `a`>1 INTO `y`"},
+		{"select a, b into @a, c from t group by a having b > 0 order by a limit 1 for update", true, "SELECT `a`,`b` FROM `t` GROUP BY `a` HAVING `b`>0 ORDER BY `a` LIMIT 1 FOR UPDATE INTO @`a`, `c`"},
+		{"select 1 into @a", true, "SELECT 1 INTO @`a`"},
+		{"select 1 into outfile '/tmp/t.txt'", true, "SELECT 1 INTO OUTFILE '/tmp/t.txt'"},
+		{"select a into @a from t into @b", false, ""},
+		{"select a into outfile '/tmp/t.txt' from t", false, ""},
+
+		// the new unreserved keywords can still be used as identifiers
+		{"create table t (close int, contains int, found int, handler int, message_text int, mysql_errno int, returns int)", true, "CREATE TABLE `t` (`close` INT,`contains` INT,`found` INT,`handler` INT,`message_text` INT,`mysql_errno` INT,`returns` INT)"},
+	}
+	RunTest(t, table, false)
+
+	p := parser.New()
+	stmts, _, err := p.Parse("create procedure p(a int) begin select a; end; select 1", "", "")
+	require.NoError(t, err)
+	require.Len(t, stmts, 2)
+	proc, ok := stmts[0].(*ast.CreateRoutineStmt)
+	require.True(t, ok)
+	require.Equal(t, model.RoutineProcedure, proc.Type)
+	require.Equal(t, "begin select a; end", proc.Body.Text())
+
+	st, err := p.ParseOneStmt("create function f() returns int lbl: begin return 1; end lbl", "", "")
+	require.NoError(t, err)
+	fn, ok := st.(*ast.CreateRoutineStmt)
+	require.True(t, ok)
+	require.Equal(t, model.RoutineFunction, fn.Type)
+	require.Equal(t, "lbl: begin return 1; end lbl", fn.Body.Text())
+
+	st, err = p.ParseOneStmt("select a + 1 into @a from t", "", "")
+	require.NoError(t, err)
+	sel, ok := st.(*ast.SelectStmt)
+	require.True(t, ok)
+	require.Equal(t, "a + 1", sel.Fields.Fields[0].Text())
+	st, err = p.ParseOneStmt("select a + 1 into @a", "", "")
+	require.NoError(t, err)
+	require.Equal(t, "a + 1", st.(*ast.SelectStmt).Fields.Fields[0].Text())
+}
+
+func TestTrigger(t *testing.T) {
+	table := []testCase{
+		{"create trigger t_bi before insert on t for each row set new.a = 1", true, "CREATE TRIGGER `t_bi` BEFORE INSERT ON `t` FOR EACH ROW SET @@SESSION.`new.a`=1"},
+		{"create definer = 'root'@'%' trigger if not exists test.t_au after update on test.t for each row follows t_au0 begin insert into log values (old.a, new.a); end", true, "CREATE DEFINER = `root`@`%` TRIGGER IF NOT EXISTS `test`.`t_au` AFTER UPDATE ON `test`.`t` FOR EACH ROW FOLLOWS `t_au0` BEGIN INSERT INTO `log` VALUES (`old`.`a`,`new`.`a`); END"},
+		{"create trigger t_bd before delete on t for each row precedes t_bd0 delete from s where id = old.id", true, "CREATE TRIGGER `t_bd` BEFORE DELETE ON `t` FOR EACH ROW PRECEDES `t_bd0` DELETE FROM `s` WHERE `id`=`old`.`id`"},
+		{"create trigger t_bi before insert on t set new.a = 1", false, ""},
+		{"create trigger t_bi insert on t for each row set new.a = 1", false, ""},
+		{"create or replace trigger t_bi before insert on t for each row set new.a = 1", false, ""},
+		{"drop trigger t_bi", true, "DROP TRIGGER `t_bi`"},
+		{"drop trigger if exists test.t_bi", true, "DROP TRIGGER IF EXISTS `test`.`t_bi`"},
+		{"show create trigger test.t_bi", true, "SHOW CREATE TRIGGER `test`.`t_bi`"},
+
+		// the new unreserved keywords can still be used as identifiers
+		{"create table t (before int, each int, follows int, precedes int)", true, "CREATE TABLE `t` (`before` INT,`each` INT,`follows` INT,`precedes` INT)"},
+	}
+	RunTest(t, table, false)
+
+	p := parser.New()
+	st, err := p.ParseOneStmt("create trigger t_bi before insert on t for each row begin set new.a = 1; end", "", "")
+	require.NoError(t, err)
+	trigger, ok := st.(*ast.CreateTriggerStmt)
+	require.True(t, ok)
+	require.Equal(t, model.TriggerBefore, trigger.Timing)
+	require.Equal(t, model.TriggerInsert, trigger.Event)
+	require.Equal(t, "begin set new.a = 1; end", trigger.Body.Text())
+}
+
 func TestTimestampDiffUnit(t *testing.T) {
 	// Test case for timestampdiff unit.
 	// TimeUnit should be unified to upper case.
@@ -5338,6 +5436,40 @@ func TestTableSample(t *testing.T) {
 	}
 }
 
+func TestJSONTable(t *testing.T) {
+	table := []testCase{
+		{`select * from json_table('[1, 2]', '$[*]' columns (a int path '$')) as jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[1, 2]', '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt`"},
+		{`select * from json_table('[1, 2]', '$[*]' columns (id for ordinality, a json path '$', b int exists path '$.b')) jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[1, 2]', '$[*]' COLUMNS (`id` FOR ORDINALITY, `a` JSON PATH '$', `b` INT EXISTS PATH '$.b')) AS `jt`"},
+		{`select * from json_table('{}', '$' columns (a varchar(10) path '$.a' default '"x"' on empty error on error, b int path '$.b' null on error)) jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'{}', '$' COLUMNS (`a` VARCHAR(10) PATH '$.a' DEFAULT '\"x\"' ON EMPTY ERROR ON ERROR, `b` INT PATH '$.b' NULL ON ERROR)) AS `jt`"},
+		{`select * from json_table('{}', '$' columns (a int path '$.a' null on empty)) jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'{}', '$' COLUMNS (`a` INT PATH '$.a' NULL ON EMPTY)) AS `jt`"},
+		{`select * from json_table('[]', '$[*]' columns (a int path '$.a', nested path '$.b[*]' columns (b int path '$'), nested '$.c[*]' columns (c int path '$', nested path '$.d' columns (d int path '$')))) jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a', NESTED PATH '$.b[*]' COLUMNS (`b` INT PATH '$'), NESTED PATH '$.c[*]' COLUMNS (`c` INT PATH '$', NESTED PATH '$.d' COLUMNS (`d` INT PATH '$')))) AS `jt`"},
+		{`select t.id, jt.* from t, json_table(t.j, '$[*]' columns (nested int path '$', path int path '$', ordinality int path '$')) as jt`, true, "SELECT `t`.`id`,`jt`.* FROM (`t`) JOIN JSON_TABLE(`t`.`j`, '$[*]' COLUMNS (`nested` INT PATH '$', `path` INT PATH '$', `ordinality` INT PATH '$')) AS `jt`"},
+		{`select * from t left join json_table(t.j, '$' columns (a int path '$')) jt on true`, true, "SELECT * FROM `t` LEFT JOIN JSON_TABLE(`t`.`j`, '$' COLUMNS (`a` INT PATH '$')) AS `jt` ON TRUE"},
+		{`select path, nested, ordinality, empty from t`, true, "SELECT `path`,`nested`,`ordinality`,`empty` FROM `t`"},
+
+		// The alias of JSON_TABLE is required.
+		{`select * from json_table('[1, 2]', '$[*]' columns (a int path '$'))`, false, ""},
+		{`select * from json_table('[1, 2]', '$[*]' columns ()) jt`, false, ""},
+		{`select * from json_table('[1, 2]', '$[*]') jt`, false, ""},
+		{`select * from json_table('[1, 2]', '$[*]' columns (a int path '$' error on error null on empty)) jt`, false, ""},
+		{`select json_table from t`, false, ""},
+	}
+	RunTest(t, table, false)
+}
+
+func TestGroupByWithRollup(t *testing.T) {
+	table := []testCase{
+		{`select a, b, sum(c) from t group by a, b with rollup`, true, "SELECT `a`,`b`,SUM(`c`) FROM `t` GROUP BY `a`,`b` WITH ROLLUP"},
+		{`select a, grouping(a), count(*) from t group by a with rollup having grouping(a) = 0 order by a`, true, "SELECT `a`,GROUPING(`a`),COUNT(1) FROM `t` GROUP BY `a` WITH ROLLUP HAVING GROUPING(`a`)=0 ORDER BY `a`"},
+		{`select a from t group by a with rollup limit 1`, true, "SELECT `a` FROM `t` GROUP BY `a` WITH ROLLUP LIMIT 1"},
+		{`create view v as select a from t group by a with local check option`, true, "CREATE ALGORITHM = UNDEFINED DEFINER = CURRENT_USER SQL SECURITY DEFINER VIEW `v` AS SELECT `a` FROM `t` GROUP BY `a` WITH LOCAL CHECK OPTION"},
+		{`select rollup from t group by rollup`, true, "SELECT `rollup` FROM `t` GROUP BY `rollup`"},
+		{`select a from t with rollup`, false, ""},
+		{`select a from t group by a with rollup with rollup`, false, ""},
+	}
+	RunTest(t, table, false)
+}
+
 func TestGeneratedColumn(t *testing.T) {
 	tests := []struct {
 		input string
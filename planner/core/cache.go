@@ -215,6 +215,7 @@ type CachedPrepareStmt struct {
 	PreparedAst         *ast.Prepared
 	StmtDB              string // which DB the statement will be processed over
 	VisitInfos          []visitInfo
+	RoutineVisitInfos   []routineVisitInfo
 	ColumnInfos         interface{}
 	Executor            interface{}
 	NormalizedSQL       string
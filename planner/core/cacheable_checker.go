@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/sessionctx"
 	driver "github.com/pingcap/tidb/types/parser_driver"
+	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/logutil"
 	"go.uber.org/zap"
 )
@@ -51,6 +52,36 @@ func CacheableWithCtx(sctx sessionctx.Context, node ast.Node, is infoschema.Info
 	return checker.cacheable
 }
 
+// NonPreparedPlanCacheable checks whether the plan of the plain text statement can be cached by the
+// non-prepared plan cache. Only the SELECT statements reading a single normal table are supported now.
+func NonPreparedPlanCacheable(sctx sessionctx.Context, node ast.Node, is infoschema.InfoSchema) bool {
+	sel, ok := node.(*ast.SelectStmt)
+	if !ok || sel.Kind != ast.SelectStmtKindSelect || sel.With != nil || sel.SelectIntoOpt != nil ||
+		len(sel.WindowSpecs) > 0 || sel.From == nil || sel.From.TableRefs == nil {
+		return false
+	}
+	if sel.LockInfo != nil && sel.LockInfo.LockType != ast.SelectLockNone {
+		return false
+	}
+	join := sel.From.TableRefs
+	if join.Right != nil {
+		return false
+	}
+	source, ok := join.Left.(*ast.TableSource)
+	if !ok {
+		return false
+	}
+	tn, ok := source.Source.(*ast.TableName)
+	if !ok || util.IsMemOrSysDB(tn.Schema.L) {
+		return false
+	}
+	tb, err := is.TableByName(tn.Schema, tn.Name)
+	if err != nil || tb.Meta().IsView() || tb.Meta().IsSequence() {
+		return false
+	}
+	return CacheableWithCtx(sctx, node, is)
+}
+
 // cacheableChecker checks whether a query's plan can be cached, querys that:
 //	 1. have ExistsSubqueryExpr, or
 //	 2. have VariableExpr
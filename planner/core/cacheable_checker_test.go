@@ -15,11 +15,14 @@
 package core_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/format"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/testkit"
@@ -246,3 +249,69 @@ func TestCacheable(t *testing.T) {
 	require.True(t, core.Cacheable(stmt, is))
 
 }
+
+func TestNonPreparedPlanCacheable(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t1(a int, b varchar(10))")
+	tk.MustExec("create table t2(a int, b int)")
+	tk.MustExec("create view v as select * from t1")
+	is := tk.Session().GetInfoSchema().(infoschema.InfoSchema)
+	p := parser.New()
+
+	cacheable := []string{
+		"select * from test.t1 where a = 1",
+		"select b from test.t1 where a in (1, 2) and b like 'x%' order by a limit 10",
+		"select count(*) from test.t1 where a between 1 and 10 group by b",
+	}
+	unCacheable := []string{
+		"select * from test.t1, test.t2 where t1.a = t2.a",
+		"select * from test.t1 where a in (select a from test.t2)",
+		"select * from test.t1 where a = 1 for update",
+		"select * from test.v where a = 1",
+		"select * from mysql.user where user = 'root'",
+		"select * from test.t1 where a = 1 union select * from test.t1 where a = 2",
+		"with cte as (select * from test.t1) select * from cte",
+		"select * from test.t1 where a = @a",
+		"insert into test.t1 values (1, 'a')",
+	}
+	for _, sql := range cacheable {
+		stmt, err := p.ParseOneStmt(sql, "", "")
+		require.NoError(t, err)
+		require.True(t, core.NonPreparedPlanCacheable(tk.Session(), stmt, is), sql)
+	}
+	for _, sql := range unCacheable {
+		stmt, err := p.ParseOneStmt(sql, "", "")
+		require.NoError(t, err)
+		require.False(t, core.NonPreparedPlanCacheable(tk.Session(), stmt, is), sql)
+	}
+}
+
+func TestParameterizeAST(t *testing.T) {
+	p := parser.New()
+	cases := []struct {
+		sql      string
+		paramSQL string
+		params   int
+	}{
+		{"select * from t where a = 1", "SELECT * FROM `t` WHERE `a`=?", 1},
+		{"select * from t where 1 < a and b in ('x', 'y')", "SELECT * FROM `t` WHERE ?<`a` AND `b` IN (?,?)", 3},
+		{"select * from t where a between 1.5 and 2 limit 10", "SELECT * FROM `t` WHERE `a` BETWEEN ? AND ? LIMIT 10", 2},
+		{"select a + 1 from t where a + 1 = 2 and b = true", "SELECT `a`+1 FROM `t` WHERE `a`+1=2 AND `b`=TRUE", 0},
+	}
+	for _, c := range cases {
+		stmt, err := p.ParseOneStmt(c.sql, "", "")
+		require.NoError(t, err)
+		paramSQL, params, restore, err := core.ParameterizeAST(stmt)
+		require.NoError(t, err)
+		require.Equal(t, c.paramSQL, paramSQL)
+		require.Len(t, params, c.params)
+		restore()
+		var sb strings.Builder
+		require.NoError(t, stmt.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)))
+		require.NotContains(t, sb.String(), "?")
+	}
+}
@@ -382,6 +382,9 @@ func (e *Execute) checkPreparedPriv(ctx context.Context, sctx sessionctx.Context
 		if err := CheckPrivilege(sctx.GetSessionVars().ActiveRoles, pm, visitInfo); err != nil {
 			return err
 		}
+		if err := CheckRoutinePrivilege(sctx.GetSessionVars().ActiveRoles, pm, preparedObj.RoutineVisitInfos); err != nil {
+			return err
+		}
 	}
 	err := CheckTableLock(sctx, is, preparedObj.VisitInfos)
 	return err
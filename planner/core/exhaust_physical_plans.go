@@ -2224,6 +2224,36 @@ func (p *LogicalWindow) exhaustPhysicalPlans(prop *property.PhysicalProperty) ([
 	return []PhysicalPlan{window}, true, nil
 }
 
+func (p *LogicalExpand) exhaustPhysicalPlans(prop *property.PhysicalProperty) ([]PhysicalPlan, bool, error) {
+	// The expand outputs the rows of different grouping sets in turn, so it can't keep any order.
+	if !prop.IsEmpty() || (prop.IsFlashProp() && prop.TaskTp != property.MppTaskType) {
+		return nil, true, nil
+	}
+	// The expand can't keep the partition of its child for the expanded columns, the exchange enforced above it
+	// partitions the expanded rows.
+	if prop.TaskTp == property.MppTaskType && prop.MPPPartitionTp != property.AnyType {
+		return nil, true, nil
+	}
+	canUseMpp := p.ctx.GetSessionVars().IsMPPAllowed() && p.canPushToCop(kv.TiFlash)
+	childProps := make([]*property.PhysicalProperty, 0, 2)
+	if prop.TaskTp != property.MppTaskType {
+		childProps = append(childProps, &property.PhysicalProperty{ExpectedCnt: math.MaxFloat64})
+	}
+	if canUseMpp {
+		childProps = append(childProps, &property.PhysicalProperty{ExpectedCnt: math.MaxFloat64, TaskTp: property.MppTaskType, RejectSort: true})
+	}
+	ret := make([]PhysicalPlan, 0, len(childProps))
+	for _, childProp := range childProps {
+		expand := PhysicalExpand{
+			GroupingCols: p.GroupingCols,
+			GroupingSets: p.GroupingSets,
+		}.Init(p.ctx, p.stats.ScaleByExpectCnt(prop.ExpectedCnt), p.blockOffset, childProp)
+		expand.SetSchema(p.Schema())
+		ret = append(ret, expand)
+	}
+	return ret, true, nil
+}
+
 // exhaustPhysicalPlans is only for implementing interface. DataSource and Dual generate task in `findBestTask` directly.
 func (p *baseLogicalPlan) exhaustPhysicalPlans(_ *property.PhysicalProperty) ([]PhysicalPlan, bool, error) {
 	panic("baseLogicalPlan.exhaustPhysicalPlans() should never be called.")
@@ -2284,7 +2314,7 @@ func (p *baseLogicalPlan) canPushToCopImpl(storeTp kv.StoreType, considerDual bo
 			}
 		case *LogicalTableDual:
 			return storeTp == kv.TiFlash && considerDual
-		case *LogicalAggregation, *LogicalSelection, *LogicalJoin:
+		case *LogicalAggregation, *LogicalSelection, *LogicalJoin, *LogicalExpand:
 			if storeTp == kv.TiFlash {
 				ret = ret && c.canPushToCop(storeTp)
 			} else {
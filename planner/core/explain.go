@@ -493,6 +493,9 @@ func (p *PhysicalIndexLookUpReader) accessObject(sctx sessionctx.Context) string
 
 // ExplainInfo implements Plan interface.
 func (p *PhysicalIndexMergeReader) ExplainInfo() string {
+	if p.IsIntersectionType {
+		return "type: intersection"
+	}
 	return ""
 }
 
@@ -546,6 +549,29 @@ func (p *PhysicalTableDual) ExplainInfo() string {
 	return str.String()
 }
 
+// ExplainInfo implements Plan interface.
+func (p *PhysicalJSONTable) ExplainInfo() string {
+	return explainJSONTable(p.Expr, p.Root, false)
+}
+
+// ExplainNormalizedInfo implements Plan interface.
+func (p *PhysicalJSONTable) ExplainNormalizedInfo() string {
+	return explainJSONTable(p.Expr, p.Root, true)
+}
+
+func explainJSONTable(expr expression.Expression, root *JSONTablePath, normalized bool) string {
+	var str strings.Builder
+	str.WriteString("expr:")
+	if normalized {
+		str.WriteString(expr.ExplainNormalizedInfo())
+	} else {
+		str.WriteString(expr.ExplainInfo())
+	}
+	str.WriteString(", path:")
+	str.WriteString(root.Path.String())
+	return str.String()
+}
+
 // ExplainInfo implements Plan interface.
 func (p *PhysicalSort) ExplainInfo() string {
 	buffer := bytes.NewBufferString("")
@@ -820,6 +846,29 @@ func (p *PhysicalWindow) formatFrameBound(buffer *bytes.Buffer, bound *FrameBoun
 	}
 }
 
+// ExplainInfo implements Plan interface.
+func (p *PhysicalExpand) ExplainInfo() string {
+	return explainGroupingSets(p.GroupingCols, p.GroupingSets)
+}
+
+func explainGroupingSets(groupingCols []*expression.Column, groupingSets [][]int) string {
+	buffer := bytes.NewBufferString("grouping sets:")
+	for i, set := range groupingSets {
+		if i > 0 {
+			buffer.WriteString(", ")
+		}
+		buffer.WriteString("[")
+		for j, offset := range set {
+			if j > 0 {
+				buffer.WriteString(", ")
+			}
+			buffer.WriteString(groupingCols[offset].ExplainInfo())
+		}
+		buffer.WriteString("]")
+	}
+	return buffer.String()
+}
+
 // ExplainInfo implements Plan interface.
 func (p *PhysicalWindow) ExplainInfo() string {
 	buffer := bytes.NewBufferString("")
@@ -958,6 +1007,16 @@ func (p *LogicalTableDual) ExplainInfo() string {
 	return str.String()
 }
 
+// ExplainInfo implements Plan interface.
+func (p *LogicalExpand) ExplainInfo() string {
+	return explainGroupingSets(p.GroupingCols, p.GroupingSets)
+}
+
+// ExplainInfo implements Plan interface.
+func (p *LogicalJSONTable) ExplainInfo() string {
+	return explainJSONTable(p.Expr, p.Root, false)
+}
+
 // ExplainInfo implements Plan interface.
 func (ds *DataSource) ExplainInfo() string {
 	buffer := bytes.NewBufferString("")
@@ -1837,11 +1837,123 @@ func (er *expressionRewriter) rewriteFuncCall(v *ast.FuncCallExpr) bool {
 		er.ctxStackPop(len(v.Args))
 		er.ctxStackAppend(funcIf, types.EmptyName)
 		return true
+	case ast.Grouping:
+		er.rewriteGrouping(v)
+		return true
 	default:
 		return false
 	}
 }
 
+// rewriteGrouping rewrites GROUPING(col, ...) into the bit operations on the grouping id of the LogicalExpand.
+// GROUPING(col) is 1 if col is not in the grouping set of the row, and GROUPING(a, b) is
+// GROUPING(a) << 1 | GROUPING(b).
+func (er *expressionRewriter) rewriteGrouping(v *ast.FuncCallExpr) {
+	if len(v.Args) == 0 {
+		er.err = expression.ErrIncorrectParameterCount.GenWithStackByArgs(v.FnName.O)
+		return
+	}
+	if er.b == nil || er.b.curExpand == nil {
+		stackLen := len(er.ctxStack)
+		for i, arg := range er.ctxStack[stackLen-len(v.Args):] {
+			if inGroupBy, hasGroupBy := groupingArgInGroupBy(er.p, arg); hasGroupBy && !inGroupBy {
+				er.err = ErrFieldInGroupingNotGroupBy.GenWithStackByArgs(i + 1)
+				return
+			}
+		}
+		er.err = ErrInvalidGroupFuncUse
+		return
+	}
+	expand := er.b.curExpand
+	gidIdx := er.schema.ColumnIndex(expand.GroupingID)
+	if gidIdx < 0 {
+		// The GROUPING function in the having or order by clause has been evaluated in the projection.
+		if idx, ok := er.b.groupingMapper[v]; ok {
+			er.ctxStackPop(len(v.Args))
+			er.ctxStackAppend(er.schema.Columns[idx], er.names[idx])
+			return
+		}
+		er.err = ErrNotSupportedYet.GenWithStackByArgs("GROUPING function in this clause")
+		return
+	}
+	uintTp := types.NewFieldType(mysql.TypeLonglong)
+	uintTp.Flag |= mysql.UnsignedFlag
+	uintConst := func(val int) expression.Expression {
+		return &expression.Constant{Value: types.NewUintDatum(uint64(val)), RetType: uintTp.Clone()}
+	}
+	stackLen := len(er.ctxStack)
+	args := er.ctxStack[stackLen-len(v.Args):]
+	var result expression.Expression
+	for i, arg := range args {
+		offset := -1
+		if col, ok := expand.substituteGroupingExprs(arg).(*expression.Column); ok {
+			for j, expandedCol := range expand.ExpandedCols {
+				if expandedCol.UniqueID == col.UniqueID {
+					offset = j
+					break
+				}
+			}
+		}
+		if offset < 0 {
+			er.err = ErrFieldInGroupingNotGroupBy.GenWithStackByArgs(i + 1)
+			return
+		}
+		// (gid >> (n-1-offset)) & 1
+		bit, err := er.newFunction(ast.RightShift, uintTp.Clone(), er.schema.Columns[gidIdx], uintConst(len(expand.ExpandedCols)-1-offset))
+		if err == nil {
+			bit, err = er.newFunction(ast.And, uintTp.Clone(), bit, uintConst(1))
+		}
+		if err == nil && i < len(args)-1 {
+			bit, err = er.newFunction(ast.LeftShift, uintTp.Clone(), bit, uintConst(len(args)-1-i))
+		}
+		if err == nil && result != nil {
+			bit, err = er.newFunction(ast.Or, uintTp.Clone(), result, bit)
+		}
+		if err != nil {
+			er.err = err
+			return
+		}
+		result = bit
+	}
+	er.ctxStackPop(len(v.Args))
+	er.ctxStackAppend(result, types.EmptyName)
+}
+
+// groupingArgInGroupBy checks whether the argument of the GROUPING function is a GROUP BY item of the aggregation
+// without ROLLUP below p. hasGroupBy is false if there is no such aggregation or it has no GROUP BY items.
+func groupingArgInGroupBy(p LogicalPlan, arg expression.Expression) (inGroupBy, hasGroupBy bool) {
+	for p != nil {
+		switch x := p.(type) {
+		case *LogicalAggregation:
+			if len(x.GroupByItems) == 0 {
+				return false, false
+			}
+			exprs := make([]expression.Expression, 0, len(x.AggFuncs))
+			for i, aggFunc := range x.AggFuncs {
+				if aggFunc.Name == ast.AggFuncFirstRow {
+					exprs = append(exprs, aggFunc.Args[0])
+				} else {
+					exprs = append(exprs, x.Schema().Columns[i])
+				}
+			}
+			arg = expression.ColumnSubstitute(arg, x.Schema(), exprs)
+			for _, item := range x.GroupByItems {
+				if item.Equal(x.ctx, arg) {
+					return true, true
+				}
+			}
+			return false, true
+		case *LogicalProjection:
+			arg = expression.ColumnSubstitute(arg, x.Schema(), x.Exprs)
+		case *LogicalSelection, *LogicalSort, *LogicalLimit:
+		default:
+			return false, false
+		}
+		p = p.Children()[0]
+	}
+	return false, false
+}
+
 func (er *expressionRewriter) funcCallToExpression(v *ast.FuncCallExpr) {
 	stackLen := len(er.ctxStack)
 	args := er.ctxStack[stackLen-len(v.Args):]
@@ -1854,6 +1966,10 @@ func (er *expressionRewriter) funcCallToExpression(v *ast.FuncCallExpr) {
 		return
 	}
 
+	if er.rewriteStoredFuncCall(v, args) {
+		return
+	}
+
 	var function expression.Expression
 	er.ctxStackPop(len(v.Args))
 	if _, ok := expression.DeferredFunctions[v.FnName.L]; er.useCache() && ok {
@@ -1873,6 +1989,47 @@ func (er *expressionRewriter) funcCallToExpression(v *ast.FuncCallExpr) {
 	}
 }
 
+// rewriteStoredFuncCall rewrites the call of a stored function, the function is looked up
+// when it's qualified by a schema name or isn't a builtin function.
+func (er *expressionRewriter) rewriteStoredFuncCall(v *ast.FuncCallExpr, args []expression.Expression) bool {
+	if er.b.is == nil {
+		return false
+	}
+	if v.Schema.L == "" && expression.IsFunctionSupported(v.FnName.L) {
+		return false
+	}
+	schema := v.Schema
+	if schema.L == "" {
+		schema = model.NewCIStr(er.sctx.GetSessionVars().CurrentDB)
+	}
+	routine, ok := er.b.is.RoutineByName(schema, v.FnName, model.RoutineFunction)
+	if !ok {
+		if v.Schema.L != "" {
+			er.err = infoschema.ErrRoutineNotExists.GenWithStackByArgs("FUNCTION", schema.O+"."+v.FnName.O)
+			return true
+		}
+		return false
+	}
+	if len(args) != len(routine.Params) {
+		er.err = ErrSpWrongNoOfArgs.GenWithStackByArgs("FUNCTION", schema.O+"."+routine.Name.O, len(routine.Params), len(args))
+		return true
+	}
+	var authErr error
+	if user := er.sctx.GetSessionVars().User; user != nil {
+		authErr = ErrProcaccessDenied.GenWithStackByArgs("execute", user.AuthUsername, user.AuthHostname, schema.L+"."+routine.Name.L)
+	}
+	er.b.routineVisitInfo = append(er.b.routineVisitInfo, routineVisitInfo{privilege: mysql.ExecutePriv, db: schema.L,
+		routine: routine.Name.L, routineType: model.RoutineFunction, err: authErr})
+	er.ctxStackPop(len(args))
+	function, err := expression.BuildStoredFunction(er.sctx, schema, routine, args)
+	if err != nil {
+		er.err = err
+		return true
+	}
+	er.ctxStackAppend(function, types.EmptyName)
+	return true
+}
+
 // Now TableName in expression only used by sequence function like nextval(seq).
 // The function arg should be evaluated as a table name rather than normal column name like mysql does.
 func (er *expressionRewriter) toTable(v *ast.TableName) {
@@ -187,6 +187,27 @@ func untwistPlanAndRemoveUnionAll(stack []PhysicalPlan, forest *[]*PhysicalExcha
 				return errors.Trace(err)
 			}
 		}
+	case *PhysicalExpand:
+		// The expand is replaced by the projection of each grouping set, which reads the whole child on its own.
+		for i := range x.GroupingSets {
+			ch := x.children[0]
+			if i > 0 {
+				cloned, err := ch.Clone()
+				if err != nil {
+					return errors.Trace(err)
+				}
+				renewExchangeSenderIDs(cloned)
+				ch = cloned
+			}
+			stack[len(stack)-1] = x.projectionOfSet(i, ch)
+			stack = append(stack, ch)
+			err := untwistPlanAndRemoveUnionAll(stack, forest)
+			stack = stack[:len(stack)-1]
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+		stack[len(stack)-1] = x
 	default:
 		if len(cur.Children()) != 1 {
 			return errors.Trace(errors.New("unexpected plan " + cur.ExplainID().String()))
@@ -200,6 +221,18 @@ func untwistPlanAndRemoveUnionAll(stack []PhysicalPlan, forest *[]*PhysicalExcha
 	return nil
 }
 
+// renewExchangeSenderIDs gives the exchange senders in p new plan ids. The fragments are cached by the ids of their
+// senders, so a cloned plan gets its own fragments instead of sharing the data of the original ones.
+func renewExchangeSenderIDs(p PhysicalPlan) {
+	if sender, ok := p.(*PhysicalExchangeSender); ok {
+		sender.ctx.GetSessionVars().PlanID++
+		sender.id = sender.ctx.GetSessionVars().PlanID
+	}
+	for _, ch := range p.Children() {
+		renewExchangeSenderIDs(ch)
+	}
+}
+
 func buildFragments(s *PhysicalExchangeSender) ([]*Fragment, error) {
 	forest := make([]*PhysicalExchangeSender, 0, 1)
 	err := untwistPlanAndRemoveUnionAll([]PhysicalPlan{s}, &forest)
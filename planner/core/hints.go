@@ -178,9 +178,13 @@ func genHintsFromPhysicalPlan(p PhysicalPlan, nodeType utilhint.NodeType) (res [
 				Indexs = append(Indexs, indexName)
 			}
 		}
+		hintName := HintIndexMerge
+		if pp.IsIntersectionType {
+			hintName = HintIndexMergeIntersection
+		}
 		res = append(res, &ast.TableOptimizerHint{
 			QBName:   qbName,
-			HintName: model.NewCIStr(HintIndexMerge),
+			HintName: model.NewCIStr(hintName),
 			Tables:   []ast.HintTable{{TableName: getTableName(tableName, tableAsName)}},
 			Indexes:  Indexs,
 		})
@@ -35,6 +35,7 @@ import (
 	"github.com/pingcap/tidb/metrics"
 	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/charset"
 	"github.com/pingcap/tidb/parser/format"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
@@ -50,6 +51,7 @@ import (
 	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/table/temptable"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	driver "github.com/pingcap/tidb/types/parser_driver"
 	util2 "github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/chunk"
@@ -102,6 +104,8 @@ const (
 	HintTiKV = "tikv"
 	// HintIndexMerge is a hint to enforce using some indexes at the same time.
 	HintIndexMerge = "use_index_merge"
+	// HintIndexMergeIntersection is a hint to enforce intersecting the handles read from some indexes.
+	HintIndexMergeIntersection = "use_index_merge_intersection"
 	// HintTimeRange is a hint to specify the time range for metrics summary tables
 	HintTimeRange = "time_range"
 	// HintIgnorePlanCache is a hint to enforce ignoring plan cache
@@ -166,7 +170,7 @@ func (a *aggOrderByResolver) Leave(inNode ast.Node) (ast.Node, bool) {
 }
 
 func (b *PlanBuilder) buildAggregation(ctx context.Context, p LogicalPlan, aggFuncList []*ast.AggregateFuncExpr, gbyItems []expression.Expression,
-	correlatedAggMap map[*ast.AggregateFuncExpr]int) (LogicalPlan, map[int]int, error) {
+	correlatedAggMap map[*ast.AggregateFuncExpr]int, rollup bool) (LogicalPlan, map[int]int, error) {
 	b.optFlag |= flagBuildKeyInfo
 	b.optFlag |= flagPushDownAgg
 	// We may apply aggregation eliminate optimization.
@@ -262,6 +266,15 @@ func (b *PlanBuilder) buildAggregation(ctx context.Context, p LogicalPlan, aggFu
 			}
 		}
 	}
+	if rollup {
+		// The arguments of the aggregate functions are resolved before the expand, so they refer to the
+		// original columns instead of the expanded ones.
+		var err error
+		p, gbyItems, err = b.buildExpand(p, gbyItems)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 	for i, col := range p.Schema().Columns {
 		newFunc, err := aggregation.NewAggFuncDesc(b.ctx, ast.AggFuncFirstRow, []expression.Expression{col}, false)
 		if err != nil {
@@ -304,6 +317,83 @@ func (b *PlanBuilder) buildAggregation(ctx context.Context, p LogicalPlan, aggFu
 	return plan4Agg, aggIndexMap, nil
 }
 
+// buildExpand builds the LogicalExpand for GROUP BY ... WITH ROLLUP. It returns the expand and the new GROUP BY
+// items, which are the expanded columns followed by the grouping id. The names of the GROUP BY columns are moved
+// to the expanded columns, so the references to them above the aggregation get NULL in the super-aggregate rows.
+// The GROUP BY items which are not columns are projected into columns below the expand.
+func (b *PlanBuilder) buildExpand(p LogicalPlan, gbyItems []expression.Expression) (LogicalPlan, []expression.Expression, error) {
+	groupingCols := make([]*expression.Column, 0, len(gbyItems))
+	groupingExprs := make([]expression.Expression, len(gbyItems))
+	var proj *LogicalProjection
+	for i, item := range gbyItems {
+		if col, ok := item.(*expression.Column); ok {
+			groupingCols = append(groupingCols, col)
+			continue
+		}
+		if proj == nil {
+			proj = LogicalProjection{Exprs: expression.Column2Exprs(p.Schema().Columns)}.Init(b.ctx, b.getSelectOffset())
+			proj.SetSchema(p.Schema().Clone())
+			proj.names = make(types.NameSlice, p.Schema().Len(), p.Schema().Len()+len(gbyItems))
+			copy(proj.names, p.OutputNames())
+			proj.SetChildren(p)
+		}
+		col := &expression.Column{
+			UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+			RetType:  item.GetType(),
+		}
+		proj.Exprs = append(proj.Exprs, item)
+		proj.schema.Append(col)
+		proj.names = append(proj.names, types.EmptyName)
+		groupingCols = append(groupingCols, col)
+		groupingExprs[i] = item
+	}
+	if proj != nil {
+		p = proj
+	}
+	expand := LogicalExpand{
+		GroupingCols:  groupingCols,
+		GroupingExprs: groupingExprs,
+		GroupingSets:  rollupGroupingSets(len(groupingCols)),
+	}.Init(b.ctx, b.getSelectOffset())
+	schema := p.Schema().Clone()
+	names := make(types.NameSlice, p.Schema().Len(), p.Schema().Len()+len(groupingCols)+1)
+	copy(names, p.OutputNames())
+	newGbyItems := make([]expression.Expression, 0, len(groupingCols)+1)
+	for _, col := range groupingCols {
+		expandedCol := &expression.Column{
+			UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+			RetType:  col.RetType.Clone(),
+			OrigName: col.OrigName,
+		}
+		expandedCol.RetType.Flag &= ^mysql.NotNullFlag
+		name := types.EmptyName
+		// If a column appears more than once in GROUP BY, only its first expanded column gets the name.
+		if idx := p.Schema().ColumnIndex(col); idx >= 0 {
+			name, names[idx] = names[idx], types.EmptyName
+		}
+		expand.ExpandedCols = append(expand.ExpandedCols, expandedCol)
+		schema.Append(expandedCol)
+		names = append(names, name)
+		newGbyItems = append(newGbyItems, expandedCol)
+	}
+	gidTp := types.NewFieldType(mysql.TypeLonglong)
+	gidTp.Flag = mysql.UnsignedFlag | mysql.NotNullFlag
+	gidTp.Flen, gidTp.Decimal = mysql.GetDefaultFieldLengthAndDecimal(mysql.TypeLonglong)
+	expand.GroupingID = &expression.Column{
+		UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+		RetType:  gidTp,
+	}
+	schema.Append(expand.GroupingID)
+	names = append(names, types.EmptyName)
+	newGbyItems = append(newGbyItems, expand.GroupingID)
+
+	expand.SetChildren(p)
+	expand.SetSchema(schema)
+	expand.names = names
+	b.curExpand = expand
+	return expand, newGbyItems, nil
+}
+
 func (b *PlanBuilder) buildTableRefs(ctx context.Context, from *ast.TableRefsClause) (p LogicalPlan, err error) {
 	if from == nil {
 		p = b.buildTableDual()
@@ -336,6 +426,9 @@ func (b *PlanBuilder) buildResultSetNode(ctx context.Context, node ast.ResultSet
 		case *ast.TableName:
 			p, err = b.buildDataSource(ctx, v, &x.AsName)
 			isTableName = true
+		case *ast.JSONTable:
+			p, err = b.buildJSONTable(ctx, v, x.AsName)
+			isTableName = true
 		default:
 			err = ErrUnsupportedType.GenWithStackByArgs(v)
 		}
@@ -375,6 +468,128 @@ func (b *PlanBuilder) buildResultSetNode(ctx context.Context, node ast.ResultSet
 	}
 }
 
+// buildJSONTable builds the LogicalJSONTable for the JSON_TABLE table function. The document expression
+// may refer to the columns of the tables on the left of JSON_TABLE, which are found in b.outerSchemas.
+func (b *PlanBuilder) buildJSONTable(ctx context.Context, jt *ast.JSONTable, asName model.CIStr) (LogicalPlan, error) {
+	dual := LogicalTableDual{RowCount: 1}.Init(b.ctx, b.getSelectOffset())
+	dual.SetSchema(expression.NewSchema())
+	expr, newPlan, err := b.rewrite(ctx, jt.Expr, dual, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	if newPlan != dual {
+		return nil, errors.New("JSON_TABLE doesn't support subqueries yet")
+	}
+
+	p := LogicalJSONTable{Expr: expression.WrapWithCastAsJSON(b.ctx, expr)}.Init(b.ctx, b.getSelectOffset())
+	schema := expression.NewSchema()
+	names := make([]*types.FieldName, 0, len(jt.Columns))
+	p.Root, err = b.buildJSONTablePath(jt.Path, jt.Columns, asName, schema, &names)
+	if err != nil {
+		return nil, err
+	}
+	p.SetSchema(schema)
+	p.names = names
+	b.handleHelper.pushMap(nil)
+	return p, nil
+}
+
+// buildJSONTablePath builds the JSONTablePath of the row path and its columns. The columns are appended
+// to schema and names in the order of their declarations, including the ones in the nested paths.
+func (b *PlanBuilder) buildJSONTablePath(path string, columns []*ast.JSONTableColumn, asName model.CIStr,
+	schema *expression.Schema, names *[]*types.FieldName) (*JSONTablePath, error) {
+	pathExpr, err := json.ParseJSONPathExpr(path)
+	if err != nil {
+		return nil, err
+	}
+	tablePath := &JSONTablePath{Path: pathExpr}
+	for _, col := range columns {
+		if col.Type == ast.JSONTableColumnNested {
+			nested, err := b.buildJSONTablePath(col.Path, col.NestedColumns, asName, schema, names)
+			if err != nil {
+				return nil, err
+			}
+			tablePath.Nested = append(tablePath.Nested, nested)
+			continue
+		}
+		tableCol := &JSONTableColumn{Type: col.Type, Name: col.Name, Offset: schema.Len()}
+		var ft *types.FieldType
+		if col.Type == ast.JSONTableColumnOrdinality {
+			ft = types.NewFieldType(mysql.TypeLong)
+			ft.Flag |= mysql.UnsignedFlag | mysql.NotNullFlag
+			ft.Flen, ft.Decimal = mysql.GetDefaultFieldLengthAndDecimal(mysql.TypeLong)
+			types.SetBinChsClnFlag(ft)
+		} else {
+			if tableCol.Path, err = json.ParseJSONPathExpr(col.Path); err != nil {
+				return nil, err
+			}
+			if tableCol.OnEmpty, err = buildJSONTableOnResponse(col.OnEmpty); err != nil {
+				return nil, err
+			}
+			if tableCol.OnError, err = buildJSONTableOnResponse(col.OnError); err != nil {
+				return nil, err
+			}
+			ft = b.buildJSONTableColumnType(col.Tp)
+		}
+		tablePath.Columns = append(tablePath.Columns, tableCol)
+		schema.Append(&expression.Column{
+			UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+			RetType:  ft,
+		})
+		*names = append(*names, &types.FieldName{
+			TblName:     asName,
+			OrigTblName: asName,
+			ColName:     col.Name,
+			OrigColName: col.Name,
+		})
+	}
+	return tablePath, nil
+}
+
+func buildJSONTableOnResponse(response *ast.JSONTableOnResponse) (JSONTableOnResponse, error) {
+	// NULL is the default response of both ON EMPTY and ON ERROR.
+	if response == nil {
+		return JSONTableOnResponse{Type: ast.JSONTableOnResponseNull}, nil
+	}
+	res := JSONTableOnResponse{Type: response.Type}
+	if response.Type == ast.JSONTableOnResponseDefault {
+		var err error
+		if res.Default, err = json.ParseBinaryFromString(response.Default); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// buildJSONTableColumnType fills the unspecified charset, collation, length and decimal of the column type
+// in the same way as the column definition does.
+func (b *PlanBuilder) buildJSONTableColumnType(tp *types.FieldType) *types.FieldType {
+	ft := tp.Clone()
+	if types.IsString(ft.Tp) || ft.Tp == mysql.TypeEnum || ft.Tp == mysql.TypeSet {
+		if ft.Charset == "" {
+			ft.Charset, ft.Collate = b.ctx.GetSessionVars().GetCharsetInfo()
+		} else if ft.Collate == "" {
+			ft.Collate, _ = charset.GetDefaultCollation(ft.Charset)
+		}
+		if ft.Charset == charset.CharsetBin {
+			ft.Flag |= mysql.BinaryFlag
+		}
+	} else {
+		types.SetBinChsClnFlag(ft)
+	}
+	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(ft.Tp)
+	if ft.Decimal == types.UnspecifiedLength {
+		ft.Decimal = defaultDecimal
+	}
+	if ft.Flen == types.UnspecifiedLength {
+		ft.Flen = defaultFlen
+		if mysql.HasUnsignedFlag(ft.Flag) && ft.Tp != mysql.TypeLonglong && mysql.IsIntegerType(ft.Tp) {
+			ft.Flen--
+		}
+	}
+	return ft
+}
+
 // pushDownConstExpr checks if the condition is from filter condition, if true, push it down to both
 // children of join, whatever the join type is; if false, push it down to inner child of outer join,
 // and both children of non-outer-join.
@@ -667,10 +882,27 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		return nil, err
 	}
 
+	// JSON_TABLE is a lateral table function, so its document can refer to the columns on the left.
+	isLateral := false
+	if ts, ok := joinNode.Right.(*ast.TableSource); ok {
+		_, isLateral = ts.Source.(*ast.JSONTable)
+	}
+	if isLateral {
+		b.outerSchemas = append(b.outerSchemas, leftPlan.Schema().Clone())
+		b.outerNames = append(b.outerNames, leftPlan.OutputNames())
+	}
 	rightPlan, err := b.buildResultSetNode(ctx, joinNode.Right)
+	if isLateral {
+		b.outerSchemas = b.outerSchemas[0 : len(b.outerSchemas)-1]
+		b.outerNames = b.outerNames[0 : len(b.outerNames)-1]
+	}
 	if err != nil {
 		return nil, err
 	}
+	isLateral = isLateral && len(extractCorColumnsBySchema4LogicalPlan(rightPlan, leftPlan.Schema())) > 0
+	if isLateral && joinNode.Tp == ast.RightJoin {
+		return nil, ErrTFForbiddenJoinType.GenWithStackByArgs(joinNode.Right.(*ast.TableSource).AsName.O)
+	}
 
 	// The recursive part in CTE must not be on the right side of a LEFT JOIN.
 	if lc, ok := rightPlan.(*LogicalCTETable); ok && joinNode.Tp == ast.LeftJoin {
@@ -681,7 +913,17 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 	handleMap2 := b.handleHelper.popMap()
 	b.handleHelper.mergeAndPush(handleMap1, handleMap2)
 
-	joinPlan := LogicalJoin{StraightJoin: joinNode.StraightJoin || b.inStraightJoin}.Init(b.ctx, b.getSelectOffset())
+	var joinPlan *LogicalJoin
+	var resultPlan LogicalPlan
+	if isLateral {
+		// The JSON_TABLE is evaluated for every row from the left side.
+		b.optFlag = b.optFlag | flagBuildKeyInfo | flagDecorrelate
+		ap := LogicalApply{LogicalJoin: LogicalJoin{StraightJoin: joinNode.StraightJoin || b.inStraightJoin}}.Init(b.ctx, b.getSelectOffset())
+		joinPlan, resultPlan = &ap.LogicalJoin, ap
+	} else {
+		joinPlan = LogicalJoin{StraightJoin: joinNode.StraightJoin || b.inStraightJoin}.Init(b.ctx, b.getSelectOffset())
+		resultPlan = joinPlan
+	}
 	joinPlan.SetChildren(leftPlan, rightPlan)
 	joinPlan.SetSchema(expression.MergeSchema(leftPlan.Schema(), rightPlan.Schema()))
 	joinPlan.names = make([]*types.FieldName, leftPlan.Schema().Len()+rightPlan.Schema().Len())
@@ -771,11 +1013,11 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		}
 	} else if joinNode.On != nil {
 		b.curClause = onClause
-		onExpr, newPlan, err := b.rewrite(ctx, joinNode.On.Expr, joinPlan, nil, false)
+		onExpr, newPlan, err := b.rewrite(ctx, joinNode.On.Expr, resultPlan, nil, false)
 		if err != nil {
 			return nil, err
 		}
-		if newPlan != joinPlan {
+		if newPlan != resultPlan {
 			return nil, errors.New("ON condition doesn't support subqueries yet")
 		}
 		onCondition := expression.SplitCNFItems(onExpr)
@@ -783,17 +1025,17 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		// possible decorrelate optimizations. The ON clause is actually treated as a WHERE clause now.
 		if joinPlan.JoinType == InnerJoin {
 			sel := LogicalSelection{Conditions: onCondition}.Init(b.ctx, b.getSelectOffset())
-			sel.SetChildren(joinPlan)
+			sel.SetChildren(resultPlan)
 			return sel, nil
 		}
 		joinPlan.AttachOnConds(onCondition)
 	} else if joinPlan.JoinType == InnerJoin {
 		// If a inner join without "ON" or "USING" clause, it's a cartesian
 		// product over the join tables.
-		joinPlan.cartesianJoin = true
+		joinPlan.cartesianJoin = !isLateral
 	}
 
-	return joinPlan, nil
+	return resultPlan, nil
 }
 
 // buildUsingClause eliminate the redundant columns and ordering columns based
@@ -1256,6 +1498,9 @@ func (b *PlanBuilder) buildProjection(ctx context.Context, p LogicalPlan, fields
 		if err != nil {
 			return nil, nil, 0, err
 		}
+		if b.curExpand != nil && np.Schema().Contains(b.curExpand.GroupingID) {
+			newExpr = b.curExpand.substituteGroupingExprs(newExpr)
+		}
 
 		// For window functions in the order by clause, we will append an field for it.
 		// We need rewrite the window mapper here so order by clause could find the added field.
@@ -1925,20 +2170,21 @@ func resolveFromSelectFields(v *ast.ColumnNameExpr, fields []*ast.SelectField, i
 // havingWindowAndOrderbyExprResolver visits Expr tree.
 // It converts ColunmNameExpr to AggregateFuncExpr and collects AggregateFuncExpr.
 type havingWindowAndOrderbyExprResolver struct {
-	inAggFunc    bool
-	inWindowFunc bool
-	inWindowSpec bool
-	inExpr       bool
-	err          error
-	p            LogicalPlan
-	selectFields []*ast.SelectField
-	aggMapper    map[*ast.AggregateFuncExpr]int
-	colMapper    map[*ast.ColumnNameExpr]int
-	gbyItems     []*ast.ByItem
-	outerSchemas []*expression.Schema
-	outerNames   [][]*types.FieldName
-	curClause    clauseCode
-	prevClause   []clauseCode
+	inAggFunc      bool
+	inWindowFunc   bool
+	inWindowSpec   bool
+	inExpr         bool
+	err            error
+	p              LogicalPlan
+	selectFields   []*ast.SelectField
+	aggMapper      map[*ast.AggregateFuncExpr]int
+	colMapper      map[*ast.ColumnNameExpr]int
+	groupingMapper map[*ast.FuncCallExpr]int
+	gbyItems       []*ast.ByItem
+	outerSchemas   []*expression.Schema
+	outerNames     [][]*types.FieldName
+	curClause      clauseCode
+	prevClause     []clauseCode
 }
 
 func (a *havingWindowAndOrderbyExprResolver) pushCurClause(newClause clauseCode) {
@@ -1961,6 +2207,12 @@ func (a *havingWindowAndOrderbyExprResolver) Enter(n ast.Node) (node ast.Node, s
 	case *ast.WindowSpec:
 		a.inWindowSpec = true
 	case *driver.ParamMarkerExpr, *ast.ColumnNameExpr, *ast.ColumnName:
+	case *ast.FuncCallExpr:
+		a.inExpr = true
+		if a.isGroupingFunc(n) {
+			// The arguments of GROUPING are resolved against the aggregation, skip them.
+			return n, true
+		}
 	case *ast.SubqueryExpr, *ast.ExistsSubqueryExpr:
 		// Enter a new context, skip it.
 		// For example: select sum(c) + c + exists(select c from t) from t;
@@ -2025,6 +2277,15 @@ func (a *havingWindowAndOrderbyExprResolver) resolveFromPlan(v *ast.ColumnNameEx
 	return len(a.selectFields) - 1, nil
 }
 
+// isGroupingFunc checks whether the node is a GROUPING function in the having or order by clause. Its value is
+// computed from the grouping id, which is only visible below the projection, so it's evaluated there like the
+// aggregate functions.
+func (a *havingWindowAndOrderbyExprResolver) isGroupingFunc(n ast.Node) bool {
+	v, ok := n.(*ast.FuncCallExpr)
+	return ok && v.FnName.L == ast.Grouping && a.groupingMapper != nil && !a.inAggFunc && !a.inWindowFunc &&
+		(a.curClause == havingClause || a.curClause == orderByClause)
+}
+
 // Leave implements Visitor interface.
 func (a *havingWindowAndOrderbyExprResolver) Leave(n ast.Node) (node ast.Node, ok bool) {
 	switch v := n.(type) {
@@ -2049,6 +2310,15 @@ func (a *havingWindowAndOrderbyExprResolver) Leave(n ast.Node) (node ast.Node, o
 				AsName:    model.NewCIStr(fmt.Sprintf("sel_window_%d", len(a.selectFields))),
 			})
 		}
+	case *ast.FuncCallExpr:
+		if a.isGroupingFunc(v) {
+			a.groupingMapper[v] = len(a.selectFields)
+			a.selectFields = append(a.selectFields, &ast.SelectField{
+				Auxiliary: true,
+				Expr:      v,
+				AsName:    model.NewCIStr(fmt.Sprintf("sel_grouping_%d", len(a.selectFields))),
+			})
+		}
 	case *ast.WindowSpec:
 		a.inWindowSpec = false
 	case *ast.PartitionByClause:
@@ -2157,6 +2427,9 @@ func (b *PlanBuilder) resolveHavingAndOrderBy(sel *ast.SelectStmt, p LogicalPlan
 		outerSchemas: b.outerSchemas,
 		outerNames:   b.outerNames,
 	}
+	if sel.GroupBy != nil && sel.GroupBy.Rollup {
+		extractor.groupingMapper = b.groupingMapper
+	}
 	if sel.GroupBy != nil {
 		extractor.gbyItems = sel.GroupBy.Items
 	}
@@ -2850,8 +3123,9 @@ func checkExprInGroupByOrIsSingleValue(
 	}
 	// Function `any_value` can be used in aggregation, even `ONLY_FULL_GROUP_BY` is set.
 	// See https://dev.mysql.com/doc/refman/5.7/en/miscellaneous-functions.html#function_any-value for details
+	// The arguments of function `grouping` are checked to be GROUP BY items when it's rewritten.
 	if f, ok := expr.(*ast.FuncCallExpr); ok {
-		if f.FnName.L == ast.AnyValue {
+		if f.FnName.L == ast.AnyValue || f.FnName.L == ast.Grouping {
 			return
 		}
 	}
@@ -3294,7 +3568,7 @@ func (b *PlanBuilder) pushTableHints(hints []*ast.TableOptimizerHint, currentLev
 		// Set warning for the hint that requires the table name.
 		switch hint.HintName.L {
 		case TiDBMergeJoin, HintSMJ, TiDBIndexNestedLoopJoin, HintINLJ, HintINLHJ, HintINLMJ,
-			TiDBHashJoin, HintHJ, HintUseIndex, HintIgnoreIndex, HintForceIndex, HintIndexMerge:
+			TiDBHashJoin, HintHJ, HintUseIndex, HintIgnoreIndex, HintForceIndex, HintIndexMerge, HintIndexMergeIntersection:
 			if len(hint.Tables) == 0 {
 				b.pushHintWithoutTableWarning(hint)
 				continue
@@ -3372,7 +3646,7 @@ func (b *PlanBuilder) pushTableHints(hints []*ast.TableOptimizerHint, currentLev
 			case HintTiKV:
 				tikvTables = append(tikvTables, tableNames2HintTableInfo(b.ctx, hint.HintName.L, hint.Tables, b.hintProcessor, currentLevel)...)
 			}
-		case HintIndexMerge:
+		case HintIndexMerge, HintIndexMergeIntersection:
 			dbName := hint.Tables[0].DBName
 			if dbName.L == "" {
 				dbName = model.NewCIStr(b.ctx.GetSessionVars().CurrentDB)
@@ -3386,6 +3660,7 @@ func (b *PlanBuilder) pushTableHints(hints []*ast.TableOptimizerHint, currentLev
 					HintType:   ast.HintUse,
 					HintScope:  ast.HintForScan,
 				},
+				indexMergeIntersection: hint.HintName.L == HintIndexMergeIntersection,
 			})
 		case HintTimeRange:
 			timeRangeHint = hint.HintData.(ast.HintTimeRange)
@@ -3436,7 +3711,7 @@ func (b *PlanBuilder) appendUnmatchedIndexHintWarning(indexHints []indexHintInfo
 		if !hint.matched {
 			var hintTypeString string
 			if usedForIndexMerge {
-				hintTypeString = "use_index_merge"
+				hintTypeString = hint.indexMergeHintTypeString()
 			} else {
 				hintTypeString = hint.hintTypeString()
 			}
@@ -3515,6 +3790,10 @@ func (b *PlanBuilder) buildSelect(ctx context.Context, sel *ast.SelectStmt) (p L
 		b.inStraightJoin = sel.SelectStmtOpts.StraightJoin
 		defer func() { b.inStraightJoin = origin }()
 	}
+	// The GROUPING function only refers to the WITH ROLLUP of the current SELECT.
+	originExpand := b.curExpand
+	b.curExpand = nil
+	defer func() { b.curExpand = originExpand }()
 
 	var (
 		aggFuncs                      []*ast.AggregateFuncExpr
@@ -3661,7 +3940,7 @@ func (b *PlanBuilder) buildSelect(ctx context.Context, sel *ast.SelectStmt) (p L
 	}
 	if needBuildAgg {
 		var aggIndexMap map[int]int
-		p, aggIndexMap, err = b.buildAggregation(ctx, p, aggFuncs, gbyCols, correlatedAggMap)
+		p, aggIndexMap, err = b.buildAggregation(ctx, p, aggFuncs, gbyCols, correlatedAggMap, sel.GroupBy != nil && sel.GroupBy.Rollup)
 		if err != nil {
 			return nil, err
 		}
@@ -4089,9 +4368,9 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 					indexMergeHints = append(indexMergeHints, hint)
 				} else {
 					// Append warning if there are invalid index names.
-					errMsg := fmt.Sprintf("use_index_merge(%s) is inapplicable, check whether the indexes (%s) "+
+					errMsg := fmt.Sprintf("%s(%s) is inapplicable, check whether the indexes (%s) "+
 						"exist, or the indexes are conflicted with use_index/ignore_index/force_index hints.",
-						hint.indexString(), strings.Join(invalidIdxNames, ", "))
+						hint.indexMergeHintTypeString(), hint.indexString(), strings.Join(invalidIdxNames, ", "))
 					b.ctx.GetSessionVars().StmtCtx.AppendWarning(ErrInternal.GenWithStack(errMsg))
 				}
 			}
@@ -4388,8 +4667,8 @@ func (b *PlanBuilder) BuildDataSourceFromView(ctx context.Context, dbName model.
 	if err != nil {
 		return nil, err
 	}
-	originalVisitInfo := b.visitInfo
-	b.visitInfo = make([]visitInfo, 0)
+	originalVisitInfo, originalRoutineVisitInfo := b.visitInfo, b.routineVisitInfo
+	b.visitInfo, b.routineVisitInfo = make([]visitInfo, 0), make([]routineVisitInfo, 0)
 	selectLogicalPlan, err := b.Build(ctx, selectNode)
 	if err != nil {
 		if terror.ErrorNotEqual(err, ErrViewRecursive) &&
@@ -4407,10 +4686,16 @@ func (b *PlanBuilder) BuildDataSourceFromView(ctx context.Context, dbName model.
 					return nil, ErrViewInvalid.GenWithStackByArgs(dbName.O, tableInfo.Name.O)
 				}
 			}
+			for _, v := range b.routineVisitInfo {
+				if !pm.RequestVerificationWithUser(v.db, "", "", v.privilege, tableInfo.View.Definer) {
+					return nil, ErrViewInvalid.GenWithStackByArgs(dbName.O, tableInfo.Name.O)
+				}
+			}
 		}
-		b.visitInfo = b.visitInfo[:0]
+		b.visitInfo, b.routineVisitInfo = b.visitInfo[:0], b.routineVisitInfo[:0]
 	}
 	b.visitInfo = append(originalVisitInfo, b.visitInfo...)
+	b.routineVisitInfo = append(originalRoutineVisitInfo, b.routineVisitInfo...)
 
 	if b.ctx.GetSessionVars().StmtCtx.InExplainStmt {
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.ShowViewPriv, dbName.L, tableInfo.Name.L, "", ErrViewNoExplain)
@@ -4895,6 +5180,9 @@ func (b *PlanBuilder) buildUpdateLists(ctx context.Context, tableList []*ast.Tab
 	// If columns in set list contains generated columns, raise error.
 	// And, fill virtualAssignments here; that's for generated columns.
 	virtualAssignments := make([]*ast.Assignment, 0)
+	// The BEFORE UPDATE triggers may change any column, so all the generated
+	// columns of the tables with them are composed.
+	triggeredAssignments := make(map[*ast.Assignment]bool)
 	for _, tn := range tableList {
 		if isCTE(tn) || tn.TableInfo.IsView() || tn.TableInfo.IsSequence() {
 			continue
@@ -4919,10 +5207,14 @@ func (b *PlanBuilder) buildUpdateLists(ctx context.Context, tableList []*ast.Tab
 			if ok && !isDefault {
 				return nil, nil, false, ErrBadGeneratedColumn.GenWithStackByArgs(colInfo.Name.O, tableInfo.Name.O)
 			}
-			virtualAssignments = append(virtualAssignments, &ast.Assignment{
+			assign := &ast.Assignment{
 				Column: &ast.ColumnName{Schema: tn.Schema, Table: tn.Name, Name: colInfo.Name},
 				Expr:   tableVal.Cols()[i].GeneratedExpr,
-			})
+			}
+			virtualAssignments = append(virtualAssignments, assign)
+			if tableInfo.HasTrigger(model.TriggerBefore, model.TriggerUpdate) {
+				triggeredAssignments[assign] = true
+			}
 		}
 	}
 
@@ -4995,7 +5287,7 @@ func (b *PlanBuilder) buildUpdateLists(ctx context.Context, tableList []*ast.Tab
 				}
 			}
 			// skip unmodified generated columns
-			if !isModified {
+			if !isModified && !triggeredAssignments[assign] {
 				continue
 			}
 		}
@@ -1756,7 +1756,13 @@ func byItemsToProperty(byItems []*util.ByItems) *property.PhysicalProperty {
 func pathsName(paths []*candidatePath) string {
 	var names []string
 	for _, path := range paths {
-		if path.path.IsTablePath() {
+		if path.path.PartialIndexPaths != nil {
+			partialNames := make([]string, 0, len(path.path.PartialIndexPaths))
+			for _, partialPath := range path.path.PartialIndexPaths {
+				partialNames = append(partialNames, partialPath.Index.Name.O)
+			}
+			names = append(names, fmt.Sprintf("IndexMerge{%s}", strings.Join(partialNames, ",")))
+		} else if path.path.IsTablePath() {
 			names = append(names, "PRIMARY_KEY")
 		} else {
 			names = append(names, path.path.Index.Name.O)
@@ -1800,7 +1806,7 @@ func TestSkylinePruning(t *testing.T) {
 		},
 		{
 			sql:    "select * from t where f > 1 and g > 1",
-			result: "PRIMARY_KEY,g,f_g",
+			result: "PRIMARY_KEY,g,f_g,IndexMerge{f,g}",
 		},
 		{
 			sql:    "select count(1) from t",
@@ -1808,7 +1814,7 @@ func TestSkylinePruning(t *testing.T) {
 		},
 		{
 			sql:    "select * from t where f > 3 and g = 5",
-			result: "PRIMARY_KEY,g,f_g",
+			result: "PRIMARY_KEY,g,f_g,IndexMerge{g,f}",
 		},
 		{
 			sql:    "select * from t where g = 5 order by f",
@@ -1820,7 +1826,7 @@ func TestSkylinePruning(t *testing.T) {
 		},
 		{
 			sql:    "select * from t where d = 1 and f > 1 and g > 1 order by c, e",
-			result: "PRIMARY_KEY,c_d_e,g,f_g",
+			result: "PRIMARY_KEY,c_d_e,g,f_g,IndexMerge{f,g}",
 		},
 	}
 	s := createPlannerSuite()
@@ -30,6 +30,7 @@ import (
 	"github.com/pingcap/tidb/statistics"
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/ranger"
 	"go.uber.org/zap"
@@ -52,6 +53,8 @@ var (
 	_ LogicalPlan = &LogicalLock{}
 	_ LogicalPlan = &LogicalLimit{}
 	_ LogicalPlan = &LogicalWindow{}
+	_ LogicalPlan = &LogicalJSONTable{}
+	_ LogicalPlan = &LogicalExpand{}
 )
 
 // JoinType contains CrossJoin, InnerJoin, LeftOuterJoin, RightOuterJoin, FullOuterJoin, SemiJoin.
@@ -1184,6 +1187,74 @@ func (p *LogicalWindow) GetWindowResultColumns() []*expression.Column {
 	return p.schema.Columns[p.schema.Len()-len(p.WindowFuncDescs):]
 }
 
+// LogicalExpand is used to compute the super-aggregate rows of GROUP BY ... WITH ROLLUP. It outputs each input
+// row once for every grouping set, in which the grouping columns not in the set are NULL. The aggregation above
+// it groups by the expanded columns and the grouping id.
+type LogicalExpand struct {
+	logicalSchemaProducer
+
+	// GroupingCols are the GROUP BY columns of the child.
+	GroupingCols []*expression.Column
+	// GroupingExprs are the GROUP BY items which are projected into GroupingCols, the items of the columns are nil.
+	GroupingExprs []expression.Expression
+	// ExpandedCols are the nullable copies of GroupingCols output by the expand.
+	ExpandedCols []*expression.Column
+	// GroupingSets are the offsets of the GroupingCols in each grouping set.
+	GroupingSets [][]int
+	// GroupingID identifies the grouping set of a row, see GroupingIDOfSet.
+	GroupingID *expression.Column
+}
+
+// substituteGroupingExprs replaces the GROUP BY expressions in expr with their expanded columns, so they get NULL in
+// the super-aggregate rows like the GROUP BY columns.
+func (p *LogicalExpand) substituteGroupingExprs(expr expression.Expression) expression.Expression {
+	for i, gbyExpr := range p.GroupingExprs {
+		if gbyExpr != nil && expr.Equal(p.ctx, gbyExpr) {
+			return p.ExpandedCols[i]
+		}
+	}
+	sf, ok := expr.(*expression.ScalarFunction)
+	if !ok {
+		return expr
+	}
+	args := sf.GetArgs()
+	newArgs := make([]expression.Expression, len(args))
+	changed := false
+	for i, arg := range args {
+		newArgs[i] = p.substituteGroupingExprs(arg)
+		changed = changed || newArgs[i] != arg
+	}
+	if !changed {
+		return expr
+	}
+	retType := sf.RetType.Clone()
+	retType.Flag &= ^mysql.NotNullFlag
+	return expression.NewFunctionWithArgs(sf, retType, newArgs...)
+}
+
+// rollupGroupingSets returns the grouping sets of WITH ROLLUP, the i-th set is the first n-i grouping columns.
+func rollupGroupingSets(n int) [][]int {
+	sets := make([][]int, 0, n+1)
+	for i := n; i >= 0; i-- {
+		set := make([]int, 0, i)
+		for j := 0; j < i; j++ {
+			set = append(set, j)
+		}
+		sets = append(sets, set)
+	}
+	return sets
+}
+
+// GroupingIDOfSet returns the grouping id of a grouping set among n grouping columns. The bit n-1-i of the id
+// is set if the i-th grouping column is not in the set, so the id equals GROUPING() of all the grouping columns.
+func GroupingIDOfSet(n int, set []int) uint64 {
+	id := uint64(1)<<uint(n) - 1
+	for _, offset := range set {
+		id &^= 1 << uint(n-1-offset)
+	}
+	return id
+}
+
 // ExtractCorColumnsBySchema only extracts the correlated columns that match the specified schema.
 // e.g. If the correlated columns from plan are [t1.a, t2.a, t3.a] and specified schema is [t2.a, t2.b, t2.c],
 // only [t2.a] is returned.
@@ -1269,6 +1340,47 @@ type LogicalShowDDLJobs struct {
 	JobNumber int64
 }
 
+// JSONTableOnResponse is the ON EMPTY or ON ERROR response of a JSON_TABLE column.
+type JSONTableOnResponse struct {
+	Type ast.JSONTableOnResponseType
+	// Default is the parsed value of `DEFAULT json_string`.
+	Default json.BinaryJSON
+}
+
+// JSONTableColumn is a non-nested column of JSON_TABLE.
+type JSONTableColumn struct {
+	Type ast.JSONTableColumnType
+	Name model.CIStr
+	// Offset is the offset of this column in the schema of JSON_TABLE.
+	Offset int
+	// Path is the path of the path column or the exists column, it is relative to the enclosing path.
+	Path    json.PathExpression
+	OnEmpty JSONTableOnResponse
+	OnError JSONTableOnResponse
+}
+
+// JSONTablePath is a row path of JSON_TABLE together with the columns and the nested paths under it.
+type JSONTablePath struct {
+	Path    json.PathExpression
+	Columns []*JSONTableColumn
+	Nested  []*JSONTablePath
+}
+
+// LogicalJSONTable represents the JSON_TABLE table function, which generates rows from a JSON document.
+// The document may refer to the columns of the tables on the left of it, in which case
+// the JSON_TABLE is placed on the inner side of a LogicalApply.
+type LogicalJSONTable struct {
+	logicalSchemaProducer
+
+	Expr expression.Expression
+	Root *JSONTablePath
+}
+
+// ExtractCorrelatedCols implements LogicalPlan interface.
+func (p *LogicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.Expr)
+}
+
 // CTEClass holds the information and plan for a CTE. Most of the fields in this struct are the same as cteInfo.
 // But the cteInfo is used when building the plan, and CTEClass is used also for building the executor.
 type CTEClass struct {
@@ -0,0 +1,245 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"strings"
+
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/metrics"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/opcode"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+	driver "github.com/pingcap/tidb/types/parser_driver"
+	"github.com/pingcap/tidb/util/kvcache"
+	"github.com/pingcap/tidb/util/logutil"
+	"go.uber.org/zap"
+)
+
+var nonPreparedPlanCacheCounter = metrics.PlanCacheCounter.WithLabelValues("non-prepare")
+
+// paramReplacer replaces the constants compared with columns with parameter markers. The constants compared
+// with other expressions are kept, because their types and collations may affect the result.
+type paramReplacer struct {
+	params []types.Datum
+	undoes []func()
+}
+
+// Enter implements Visitor interface.
+func (r *paramReplacer) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
+	switch node := in.(type) {
+	case *ast.BinaryOperationExpr:
+		switch node.Op {
+		case opcode.EQ, opcode.NE, opcode.LT, opcode.LE, opcode.GT, opcode.GE:
+			if isColumnNameExpr(node.L) {
+				r.replace(&node.R)
+			} else if isColumnNameExpr(node.R) {
+				r.replace(&node.L)
+			}
+		}
+	case *ast.PatternInExpr:
+		if node.Sel == nil && isColumnNameExpr(node.Expr) {
+			for i := range node.List {
+				r.replace(&node.List[i])
+			}
+		}
+	case *ast.BetweenExpr:
+		if isColumnNameExpr(node.Expr) {
+			r.replace(&node.Left)
+			r.replace(&node.Right)
+		}
+	}
+	return in, false
+}
+
+// Leave implements Visitor interface.
+func (r *paramReplacer) Leave(in ast.Node) (out ast.Node, ok bool) {
+	return in, true
+}
+
+func (r *paramReplacer) replace(expr *ast.ExprNode) {
+	val, ok := (*expr).(*driver.ValueExpr)
+	if !ok {
+		return
+	}
+	switch val.Kind() {
+	case types.KindInt64:
+		if mysql.HasIsBooleanFlag(val.Type.Flag) {
+			return
+		}
+	case types.KindUint64, types.KindFloat32, types.KindFloat64, types.KindMysqlDecimal, types.KindString:
+	default:
+		return
+	}
+	marker := &driver.ParamMarkerExpr{
+		ValueExpr: *val,
+		Order:     len(r.params),
+		InExecute: true,
+	}
+	r.params = append(r.params, val.Datum)
+	*expr = marker
+	r.undoes = append(r.undoes, func() {
+		*expr = val
+	})
+}
+
+func isColumnNameExpr(expr ast.ExprNode) bool {
+	_, ok := expr.(*ast.ColumnNameExpr)
+	return ok
+}
+
+// ParameterizeAST replaces the constants compared with columns in the WHERE clause of the statement with
+// parameter markers. It returns the text of the parameterized statement, the replaced constants and a function
+// to restore the statement.
+func ParameterizeAST(stmt ast.StmtNode) (paramSQL string, params []types.Datum, restore func(), err error) {
+	r := &paramReplacer{}
+	if sel, ok := stmt.(*ast.SelectStmt); ok && sel.Where != nil {
+		sel.Where.Accept(r)
+	}
+	restore = func() {
+		for _, undo := range r.undoes {
+			undo()
+		}
+	}
+	var sb strings.Builder
+	if err = stmt.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		restore()
+		return "", nil, nil, err
+	}
+	return sb.String(), r.params, restore, nil
+}
+
+// NewNonPreparedPlanCacheKey creates the key of the non-prepared plan cache, which is based on the normalized
+// digest of the parameterized statement. The statements with different numbers of constants in the IN list share
+// the same key, so the parameterized statement is also kept in the cached value.
+func NewNonPreparedPlanCacheKey(sessVars *variable.SessionVars, paramSQL string, schemaVersion int64) (kvcache.Key, error) {
+	_, digest := parser.NormalizeDigest(paramSQL)
+	return NewPlanCacheKey(sessVars, digest.String(), "", schemaVersion)
+}
+
+// nonPreparedPlanCacheValue is the plan cached by the non-prepared plan cache.
+type nonPreparedPlanCacheValue struct {
+	*PlanCacheValue
+	ParamSQL       string
+	NormalizedPlan string
+	PlanDigest     *parser.Digest
+}
+
+func paramTypes(params []types.Datum) []*types.FieldType {
+	tps := make([]*types.FieldType, len(params))
+	for i := range params {
+		tps[i] = types.NewFieldType(mysql.TypeUnspecified)
+		types.DefaultParamTypeForValue(params[i].GetValue(), tps[i])
+	}
+	return tps
+}
+
+// checkNonPreparedPlanPriv checks the privileges of the cached plan. Only the single table SELECT statements are
+// cached, so the SELECT privilege of the table is all that the plan needs.
+func checkNonPreparedPlanPriv(sctx sessionctx.Context, is infoschema.InfoSchema, stmt ast.StmtNode) error {
+	sel := stmt.(*ast.SelectStmt)
+	tn := sel.From.TableRefs.Left.(*ast.TableSource).Source.(*ast.TableName)
+	sessionVars := sctx.GetSessionVars()
+	var authErr error
+	if sessionVars.User != nil {
+		authErr = ErrTableaccessDenied.FastGenByArgs("SELECT", sessionVars.User.AuthUsername, sessionVars.User.AuthHostname, tn.Name.L)
+	}
+	vs := appendVisitInfo(nil, mysql.SelectPriv, tn.Schema.L, tn.Name.L, "", authErr)
+	if pm := privilege.GetPrivilegeManager(sctx); pm != nil {
+		if err := CheckPrivilege(sessionVars.ActiveRoles, pm, vs); err != nil {
+			return err
+		}
+	}
+	return CheckTableLock(sctx, is, vs)
+}
+
+// GetPlanFromNonPreparedPlanCache gets the cached plan of the parameterized statement, the parameters should have
+// been set into the session variables.
+func GetPlanFromNonPreparedPlanCache(sctx sessionctx.Context, is infoschema.InfoSchema, stmt ast.StmtNode,
+	key kvcache.Key, paramSQL string, params []types.Datum) (Plan, types.NameSlice, bool, error) {
+	sessVars := sctx.GetSessionVars()
+	cache := sctx.PreparedPlanCache()
+	// The plan cache of all sessions may have been flushed by 'admin flush instance plan_cache'.
+	expiredTimeStamp4PC := domain.GetDomain(sctx).ExpiredTimeStamp4PC()
+	if expiredTimeStamp4PC.Compare(sessVars.LastUpdateTime4PC) > 0 {
+		cache.DeleteAll()
+		sessVars.LastUpdateTime4PC = expiredTimeStamp4PC
+	}
+	cacheVals, exists := cache.Get(key)
+	if !exists {
+		return nil, nil, false, nil
+	}
+	tps := paramTypes(params)
+	for _, cacheVal := range cacheVals.([]*nonPreparedPlanCacheValue) {
+		if cacheVal.ParamSQL != paramSQL || !cacheVal.UserVarTypes.Equal(tps) {
+			continue
+		}
+		for tblInfo, unionScan := range cacheVal.TblInfo2UnionScan {
+			if !unionScan && tableHasDirtyContent(sctx, tblInfo) {
+				cache.Delete(key)
+				return nil, nil, false, nil
+			}
+		}
+		if err := checkNonPreparedPlanPriv(sctx, is, stmt); err != nil {
+			return nil, nil, false, err
+		}
+		if err := (&Execute{}).rebuildRange(cacheVal.Plan); err != nil {
+			logutil.BgLogger().Debug("rebuild range failed", zap.Error(err))
+			return nil, nil, false, nil
+		}
+		if err := sessVars.SetSystemVar(variable.TiDBFoundInPlanCache, variable.BoolToOnOff(true)); err != nil {
+			return nil, nil, false, err
+		}
+		nonPreparedPlanCacheCounter.Inc()
+		sessVars.StmtCtx.SetPlanDigest(cacheVal.NormalizedPlan, cacheVal.PlanDigest)
+		return cacheVal.Plan, cacheVal.OutPutNames, true, nil
+	}
+	return nil, nil, false, nil
+}
+
+// PutPlanToNonPreparedPlanCache puts the plan built for the parameterized statement into the plan cache.
+func PutPlanToNonPreparedPlanCache(sctx sessionctx.Context, key kvcache.Key, paramSQL string, params []types.Datum,
+	p Plan, names types.NameSlice) {
+	stmtCtx := sctx.GetSessionVars().StmtCtx
+	// The table dual plan may be built due to the values of the parameters.
+	if stmtCtx.SkipPlanCache || (len(params) > 0 && containTableDual(p)) {
+		return
+	}
+	tps := paramTypes(params)
+	cached := &nonPreparedPlanCacheValue{
+		PlanCacheValue: NewPlanCacheValue(p, names, stmtCtx.TblInfo2UnionScan, tps, ""),
+		ParamSQL:       paramSQL,
+	}
+	cached.NormalizedPlan, cached.PlanDigest = NormalizePlan(p)
+	stmtCtx.SetPlanDigest(cached.NormalizedPlan, cached.PlanDigest)
+
+	cache := sctx.PreparedPlanCache()
+	var cacheVals []*nonPreparedPlanCacheValue
+	if vals, exists := cache.Get(key); exists {
+		for _, val := range vals.([]*nonPreparedPlanCacheValue) {
+			if val.ParamSQL != paramSQL || !val.UserVarTypes.Equal(tps) {
+				cacheVals = append(cacheVals, val)
+			}
+		}
+	}
+	cache.Put(key, append(cacheVals, cached))
+}
@@ -164,6 +164,19 @@ func CheckPrivilege(activeRoles []*auth.RoleIdentity, pm privilege.Manager, vs [
 	return nil
 }
 
+// CheckRoutinePrivilege checks the privilege on the stored routines for a user.
+func CheckRoutinePrivilege(activeRoles []*auth.RoleIdentity, pm privilege.Manager, vs []routineVisitInfo) error {
+	for _, v := range vs {
+		if !pm.RequestRoutineVerification(activeRoles, v.db, v.routine, v.routineType, v.privilege) {
+			if v.err == nil {
+				return ErrPrivilegeCheckFail.GenWithStackByArgs(v.privilege.String())
+			}
+			return v.err
+		}
+	}
+	return nil
+}
+
 // VisitInfo4PrivCheck generates privilege check infos because privilege check of local temporary tables is different
 // with normal tables. `CREATE` statement needs `CREATE TEMPORARY TABLE` privilege from the database, and subsequent
 // statements do not need any privileges.
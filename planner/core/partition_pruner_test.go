@@ -718,3 +718,64 @@ func TestIssue32007(t *testing.T) {
 	tk.MustExec("set @@tidb_partition_prune_mode='dynamic'")
 	tk.MustQuery("select * from t3 where t3.a <> ALL (select t1.a from t1 partition (p0)) order by t3.a").Sort().Check(testkit.Rows("10 10", "11 11", "12 12", "13 13", "14 14", "15 15", "16 16", "17 17", "18 18", "19 19", "20 20", "21 21", "22 22", "23 23", "5 5", "6 6", "7 7", "8 8", "9 9"))
 }
+
+func TestKeyPartitionPruning(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a varchar(20) collate utf8mb4_general_ci, b int, c int) partition by key(a, b) partitions 7")
+	tk.MustExec("create table tl (a bigint, b int) partition by linear key(a) partitions 6")
+	for i := 0; i < 20; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t values ('k%d', %d, %d)", i, i, i))
+		tk.MustExec(fmt.Sprintf("insert into tl values (%d, %d)", i*1000, i))
+	}
+	tk.MustExec("insert into t values (null, null, 100)")
+
+	usedPartitions := func(sql string) []string {
+		var used []string
+		for _, row := range tk.MustQuery("explain format = 'brief' " + sql).Rows() {
+			accessObject := row[3].(string)
+			if idx := strings.Index(accessObject, "partition:"); idx >= 0 {
+				used = append(used, strings.TrimPrefix(accessObject[idx:], "partition:"))
+			}
+		}
+		sort.Strings(used)
+		return used
+	}
+	locate := func(tbl, cond string) string {
+		for i := 0; i < 7; i++ {
+			if len(tk.MustQuery(fmt.Sprintf("select 1 from %s partition (p%d) where %s", tbl, i, cond)).Rows()) > 0 {
+				return fmt.Sprintf("p%d", i)
+			}
+		}
+		return ""
+	}
+
+	tk.MustExec("set @@tidb_partition_prune_mode = 'static'")
+	for i := 0; i < 20; i += 3 {
+		cond := fmt.Sprintf("a = 'k%d' and b = %d", i, i)
+		require.Equal(t, []string{locate("t", cond)}, usedPartitions("select * from t where "+cond))
+		// The collation of the column is used to hash the value.
+		upperCond := fmt.Sprintf("a = 'K%d  ' and b = %d", i, i)
+		require.Equal(t, []string{locate("t", cond)}, usedPartitions("select * from t where "+upperCond))
+		tk.MustQuery("select c from t where " + upperCond).Check(testkit.Rows(fmt.Sprintf("%d", i)))
+
+		cond = fmt.Sprintf("a = %d", i*1000)
+		require.Equal(t, []string{locate("tl", cond)}, usedPartitions("select * from tl where "+cond))
+	}
+	// Only the conditions fixing all the partitioning columns can prune partitions.
+	require.Len(t, usedPartitions("select * from t where a = 'k1'"), 7)
+	require.Len(t, usedPartitions("select * from t where a = 'k1' and b > 1"), 7)
+	require.Len(t, usedPartitions("select * from tl where a > 1000"), 6)
+	used := usedPartitions("select * from t where (a, b) in (('k1', 1), ('k2', 2))")
+	require.Subset(t, used, []string{locate("t", "a = 'k1' and b = 1"), locate("t", "a = 'k2' and b = 2")})
+	require.LessOrEqual(t, len(used), 2)
+	require.Equal(t, []string{locate("t", "a is null and b is null")}, usedPartitions("select * from t where a is null and b is null"))
+	tk.MustQuery("select * from t partition (p0) where a = 'k1' and b = 1 and 1 = 0").Check(testkit.Rows())
+
+	tk.MustExec("set @@tidb_partition_prune_mode = 'dynamic'")
+	tk.MustQuery("select c from t where a = 'k4' and b = 4").Check(testkit.Rows("4"))
+	tk.MustQuery("select b from tl where a in (3000, 5000, 6001)").Sort().Check(testkit.Rows("3", "5"))
+	require.Equal(t, []string{locate("tl", "a = 3000")}, usedPartitions("select * from tl where a = 3000"))
+}
@@ -66,6 +66,8 @@ var (
 	_ PhysicalPlan = &PhysicalShuffleReceiverStub{}
 	_ PhysicalPlan = &BatchPointGetPlan{}
 	_ PhysicalPlan = &PhysicalTableSample{}
+	_ PhysicalPlan = &PhysicalJSONTable{}
+	_ PhysicalPlan = &PhysicalExpand{}
 )
 
 type tableScanAndPartitionInfo struct {
@@ -336,6 +338,8 @@ type PhysicalIndexMergeReader struct {
 	partialPlans []PhysicalPlan
 	// tablePlan is a PhysicalTableScan to get the table tuples. Current, it must be not nil.
 	tablePlan PhysicalPlan
+	// IsIntersectionType means the handles fetched by the partial plans are intersected, otherwise they are unioned.
+	IsIntersectionType bool
 
 	// Used by partition table.
 	PartitionInfo PartitionInfo
@@ -1295,6 +1299,55 @@ func (p *PhysicalWindow) ExtractCorrelatedCols() []*expression.CorrelatedColumn
 	return corCols
 }
 
+// PhysicalExpand is the physical operator of LogicalExpand.
+type PhysicalExpand struct {
+	physicalSchemaProducer
+
+	GroupingCols []*expression.Column
+	GroupingSets [][]int
+}
+
+// Clone implements PhysicalPlan interface.
+func (p *PhysicalExpand) Clone() (PhysicalPlan, error) {
+	cloned := new(PhysicalExpand)
+	*cloned = *p
+	base, err := p.physicalSchemaProducer.cloneWithSelf(cloned)
+	if err != nil {
+		return nil, err
+	}
+	cloned.physicalSchemaProducer = *base
+	cloned.GroupingCols = cloneCols(p.GroupingCols)
+	return cloned, nil
+}
+
+// projectionOfSet returns the projection which outputs the rows of the idx-th grouping set. TiFlash has no expand
+// executor, so the expand pushed down to it is split into such projections, one for each grouping set, when
+// building the MPP fragments.
+func (p *PhysicalExpand) projectionOfSet(idx int, child PhysicalPlan) *PhysicalProjection {
+	childLen := p.Schema().Len() - len(p.GroupingCols) - 1
+	exprs := make([]expression.Expression, 0, p.Schema().Len())
+	for _, col := range p.Schema().Columns[:childLen] {
+		exprs = append(exprs, col)
+	}
+	inSet := make([]bool, len(p.GroupingCols))
+	for _, offset := range p.GroupingSets[idx] {
+		inSet[offset] = true
+	}
+	for i, col := range p.GroupingCols {
+		if inSet[i] {
+			exprs = append(exprs, col)
+		} else {
+			exprs = append(exprs, &expression.Constant{Value: types.NewDatum(nil), RetType: p.Schema().Columns[childLen+i].RetType})
+		}
+	}
+	gid := p.Schema().Columns[p.Schema().Len()-1]
+	exprs = append(exprs, &expression.Constant{Value: types.NewUintDatum(GroupingIDOfSet(len(p.GroupingCols), p.GroupingSets[idx])), RetType: gid.RetType})
+	proj := PhysicalProjection{Exprs: exprs}.Init(p.ctx, child.statsInfo(), p.blockOffset)
+	proj.SetSchema(p.Schema())
+	proj.SetChildren(child)
+	return proj
+}
+
 // PhysicalShuffle represents a shuffle plan.
 // `Tails` and `DataSources` are the last plan within and the first plan following the "shuffle", respectively,
 //  to build the child executors chain.
@@ -1367,6 +1420,19 @@ type PhysicalShow struct {
 	Extractor ShowPredicateExtractor
 }
 
+// PhysicalJSONTable is the physical operator of JSON_TABLE.
+type PhysicalJSONTable struct {
+	physicalSchemaProducer
+
+	Expr expression.Expression
+	Root *JSONTablePath
+}
+
+// ExtractCorrelatedCols implements PhysicalPlan interface.
+func (p *PhysicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.Expr)
+}
+
 // PhysicalShowDDLJobs is for showing DDL job list.
 type PhysicalShowDDLJobs struct {
 	physicalSchemaProducer
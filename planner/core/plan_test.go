@@ -484,7 +484,7 @@ func TestNthPlanHint(t *testing.T) {
 	tk.MustExec("create table tt (a int,b int, index(a), index(b));")
 	tk.MustExec("insert into tt values (1, 1), (2, 2), (3, 4)")
 
-	tk.MustExec("explain select /*+nth_plan(4)*/ * from tt where a=1 and b=1;")
+	tk.MustExec("explain select /*+nth_plan(5)*/ * from tt where a=1 and b=1;")
 	tk.MustQuery("show warnings").Check(testkit.Rows(
 		"Warning 1105 The parameter of nth_plan() is out of range."))
 
@@ -525,6 +525,8 @@ func TestNthPlanHint(t *testing.T) {
 		"1 1"))
 	tk.MustQuery("select  /*+ nth_plan(3) */ * from tt where a=1 and b=1;").Check(testkit.Rows(
 		"1 1"))
+	tk.MustQuery("select  /*+ nth_plan(4) */ * from tt where a=1 and b=1;").Check(testkit.Rows(
+		"1 1"))
 
 	// Make sure nth_plan() doesn't affect separately executed subqueries by asserting there's only one warning.
 	tk.MustExec("select /*+ nth_plan(1000) */ count(1) from t where (select count(1) from t, tt) > 1;")
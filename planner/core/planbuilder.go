@@ -78,6 +78,17 @@ type visitInfo struct {
 	dynamicWithGrant bool
 }
 
+// routineVisitInfo is the privilege checked on a stored routine, the
+// privileges granted on the routine are checked as well as the global and
+// database level ones.
+type routineVisitInfo struct {
+	privilege   mysql.PrivilegeType
+	db          string
+	routine     string
+	routineType model.RoutineType
+	err         error
+}
+
 type indexNestedLoopJoinTables struct {
 	inljTables  []hintTableInfo
 	inlhjTables []hintTableInfo
@@ -120,6 +131,8 @@ type indexHintInfo struct {
 	// If an indexHintInfo is not matched after building
 	// a Select statement, we will generate a warning for it.
 	matched bool
+	// indexMergeIntersection indicates whether this is a use_index_merge_intersection hint.
+	indexMergeIntersection bool
 }
 
 func (hint *indexHintInfo) hintTypeString() string {
@@ -134,6 +147,13 @@ func (hint *indexHintInfo) hintTypeString() string {
 	return ""
 }
 
+func (hint *indexHintInfo) indexMergeHintTypeString() string {
+	if hint.indexMergeIntersection {
+		return HintIndexMergeIntersection
+	}
+	return HintIndexMerge
+}
+
 // indexString formats the indexHint as dbName.tableName[, indexNames].
 func (hint *indexHintInfo) indexString() string {
 	var indexListString string
@@ -437,9 +457,13 @@ type PlanBuilder struct {
 	outerCTEs    []*cteInfo
 	// colMapper stores the column that must be pre-resolved.
 	colMapper map[*ast.ColumnNameExpr]int
+	// groupingMapper stores the GROUPING functions of the having and order by clauses that must be pre-resolved.
+	groupingMapper map[*ast.FuncCallExpr]int
 	// visitInfo is used for privilege check.
 	visitInfo     []visitInfo
 	tableHintInfo []tableHintInfo
+	// routineVisitInfo is used for privilege check on the stored routines.
+	routineVisitInfo []routineVisitInfo
 	// optFlag indicates the flags of the optimizer rules.
 	optFlag uint64
 	// capFlag indicates the capability flags.
@@ -492,6 +516,10 @@ type PlanBuilder struct {
 	// correlatedAggMapper stores columns for correlated aggregates which should be evaluated in outer query.
 	correlatedAggMapper map[*ast.AggregateFuncExpr]*expression.CorrelatedColumn
 
+	// curExpand is the LogicalExpand built for the GROUP BY ... WITH ROLLUP of the current SELECT, it's used to
+	// rewrite the GROUPING function.
+	curExpand *LogicalExpand
+
 	// isForUpdateRead should be true in either of the following situations
 	// 1. use `inside insert`, `update`, `delete` or `select for update` statement
 	// 2. isolation level is RC
@@ -549,6 +577,11 @@ func (b *PlanBuilder) GetVisitInfo() []visitInfo {
 	return b.visitInfo
 }
 
+// GetRoutineVisitInfo gets the routineVisitInfo of the PlanBuilder.
+func (b *PlanBuilder) GetRoutineVisitInfo() []routineVisitInfo {
+	return b.routineVisitInfo
+}
+
 // GetIsForUpdateRead gets if the PlanBuilder use forUpdateRead
 func (b *PlanBuilder) GetIsForUpdateRead() bool {
 	return b.isForUpdateRead
@@ -604,6 +637,7 @@ func NewPlanBuilder() *PlanBuilder {
 	return &PlanBuilder{
 		outerCTEs:           make([]*cteInfo, 0),
 		colMapper:           make(map[*ast.ColumnNameExpr]int),
+		groupingMapper:      make(map[*ast.FuncCallExpr]int),
 		handleHelper:        &handleColHelper{id2HandleMapStack: make([]map[int64][]HandleCols, 0)},
 		correlatedAggMapper: make(map[*ast.AggregateFuncExpr]*expression.CorrelatedColumn),
 	}
@@ -638,6 +672,10 @@ func (b *PlanBuilder) ResetForReuse() *PlanBuilder {
 	for k := range saveColMapper {
 		delete(saveColMapper, k)
 	}
+	saveGroupingMapper := b.groupingMapper
+	for k := range saveGroupingMapper {
+		delete(saveGroupingMapper, k)
+	}
 	saveHandleHelper := b.handleHelper
 	saveHandleHelper.resetForReuse()
 
@@ -653,6 +691,7 @@ func (b *PlanBuilder) ResetForReuse() *PlanBuilder {
 	// It's a bit conservative but easier to get right.
 	b.outerCTEs = saveOuterCTEs
 	b.colMapper = saveColMapper
+	b.groupingMapper = saveGroupingMapper
 	b.handleHelper = saveHandleHelper
 	b.correlatedAggMapper = saveCorrelateAggMapper
 
@@ -714,7 +753,7 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (Plan, error) {
 		*ast.BeginStmt, *ast.CommitStmt, *ast.RollbackStmt, *ast.CreateUserStmt, *ast.SetPwdStmt, *ast.AlterInstanceStmt,
 		*ast.GrantStmt, *ast.DropUserStmt, *ast.AlterUserStmt, *ast.RevokeStmt, *ast.KillStmt, *ast.DropStatsStmt,
 		*ast.GrantRoleStmt, *ast.RevokeRoleStmt, *ast.SetRoleStmt, *ast.SetDefaultRoleStmt, *ast.ShutdownStmt,
-		*ast.RenameUserStmt:
+		*ast.RenameUserStmt, *ast.SavepointStmt, *ast.ReleaseSavepointStmt, *ast.CallStmt:
 		return b.buildSimple(ctx, node.(ast.StmtNode))
 	case ast.DDLNode:
 		return b.buildDDL(ctx, x)
@@ -791,7 +830,7 @@ func (b *PlanBuilder) buildDo(ctx context.Context, v *ast.DoStmt) (Plan, error)
 	}
 	if needBuildAgg {
 		var aggIndexMap map[int]int
-		p, aggIndexMap, err = b.buildAggregation(ctx, p, aggFuncs, nil, nil)
+		p, aggIndexMap, err = b.buildAggregation(ctx, p, aggFuncs, nil, nil, false)
 		if err != nil {
 			return nil, err
 		}
@@ -2982,8 +3021,16 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (Plan,
 	var np LogicalPlan
 	np = p
 	if show.Pattern != nil {
+		colName := p.OutputNames()[0].ColName
+		if show.Tp == ast.ShowProcedureStatus || show.Tp == ast.ShowFunctionStatus {
+			// The pattern is matched against the routine names.
+			colName = p.OutputNames()[1].ColName
+		} else if show.Tp == ast.ShowTriggers {
+			// The pattern is matched against the table names.
+			colName = p.OutputNames()[2].ColName
+		}
 		show.Pattern.Expr = &ast.ColumnNameExpr{
-			Name: &ast.ColumnName{Name: p.OutputNames()[0].ColName},
+			Name: &ast.ColumnName{Name: colName},
 		}
 		np, err = b.buildSelection(ctx, np, show.Pattern, nil)
 		if err != nil {
@@ -3039,7 +3086,11 @@ func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (Plan,
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreateUserPriv, "", "", "", err)
 	case *ast.GrantStmt:
 		var err error
-		b.visitInfo, err = collectVisitInfoFromGrantStmt(b.ctx, b.visitInfo, raw)
+		if isRoutineObjectType(raw.ObjectType) {
+			b.routineVisitInfo, err = collectVisitInfoForRoutineGrant(b.ctx, b.routineVisitInfo, raw.Privs, raw.ObjectType, raw.Level)
+		} else {
+			b.visitInfo, err = collectVisitInfoFromGrantStmt(b.ctx, b.visitInfo, raw)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -3064,7 +3115,11 @@ func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (Plan,
 		}
 	case *ast.RevokeStmt:
 		var err error
-		b.visitInfo, err = collectVisitInfoFromRevokeStmt(b.ctx, b.visitInfo, raw)
+		if isRoutineObjectType(raw.ObjectType) {
+			b.routineVisitInfo, err = collectVisitInfoForRoutineGrant(b.ctx, b.routineVisitInfo, raw.Privs, raw.ObjectType, raw.Level)
+		} else {
+			b.visitInfo, err = collectVisitInfoFromRevokeStmt(b.ctx, b.visitInfo, raw)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -3183,6 +3238,38 @@ func appendVisitInfoIsRestrictedUser(visitInfo []visitInfo, sctx sessionctx.Cont
 	return visitInfo
 }
 
+func isRoutineObjectType(objectType ast.ObjectTypeType) bool {
+	return objectType == ast.ObjectTypeFunction || objectType == ast.ObjectTypeProcedure
+}
+
+// collectVisitInfoForRoutineGrant collects the privileges needed to grant or
+// revoke the privileges on a stored routine, they are checked on the routine.
+func collectVisitInfoForRoutineGrant(sctx sessionctx.Context, vi []routineVisitInfo, privs []*ast.PrivElem,
+	objectType ast.ObjectTypeType, level *ast.GrantLevel) ([]routineVisitInfo, error) {
+	dbName := level.DBName
+	if dbName == "" {
+		if sctx.GetSessionVars().CurrentDB == "" {
+			return nil, ErrNoDB
+		}
+		dbName = sctx.GetSessionVars().CurrentDB
+	}
+	routineType := model.RoutineProcedure
+	if objectType == ast.ObjectTypeFunction {
+		routineType = model.RoutineFunction
+	}
+	routineName := strings.ToLower(level.TableName)
+	for _, item := range privs {
+		if item.Priv == mysql.AllPriv {
+			for _, priv := range mysql.AllRoutinePrivs {
+				vi = append(vi, routineVisitInfo{privilege: priv, db: dbName, routine: routineName, routineType: routineType})
+			}
+			continue
+		}
+		vi = append(vi, routineVisitInfo{privilege: item.Priv, db: dbName, routine: routineName, routineType: routineType})
+	}
+	return append(vi, routineVisitInfo{privilege: mysql.GrantPriv, db: dbName, routine: routineName, routineType: routineType}), nil
+}
+
 func collectVisitInfoFromGrantStmt(sctx sessionctx.Context, vi []visitInfo, stmt *ast.GrantStmt) ([]visitInfo, error) {
 	// To use GRANT, you must have the GRANT OPTION privilege,
 	// and you must have the privileges that you are granting.
@@ -3422,6 +3509,14 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 		return nil, err
 	}
 
+	if len(insert.OnDuplicate) > 0 && tableInfo.HasTrigger(model.TriggerBefore, model.TriggerUpdate) {
+		// The BEFORE UPDATE triggers may change any column, so all the
+		// generated columns are evaluated for the duplicated rows.
+		for _, col := range insertPlan.Table.Cols() {
+			onDupColSet[col.Name.L] = struct{}{}
+		}
+	}
+
 	// Calculate generated columns.
 	mockTablePlan.schema = insertPlan.tableSchema
 	mockTablePlan.names = insertPlan.tableColNames
@@ -4356,6 +4451,55 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (Plan, err
 	case *ast.DropPlacementPolicyStmt, *ast.CreatePlacementPolicyStmt, *ast.AlterPlacementPolicyStmt:
 		err := ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or PLACEMENT_ADMIN")
 		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, "PLACEMENT_ADMIN", false, err)
+	case *ast.CreateRoutineStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = ErrDBaccessDenied.GenWithStackByArgs(b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.Name.Schema.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreateRoutinePriv, v.Name.Schema.L,
+			"", "", authErr)
+		if v.Definer == nil || v.Definer.CurrentUser {
+			v.Definer = b.ctx.GetSessionVars().User
+		}
+		if b.ctx.GetSessionVars().User != nil && v.Definer.String() != b.ctx.GetSessionVars().User.String() {
+			err := ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "",
+				"", "", err)
+		}
+	case *ast.DropRoutineStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = ErrProcaccessDenied.GenWithStackByArgs("alter routine", b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.Name.Schema.L+"."+v.Name.Name.L)
+		}
+		b.routineVisitInfo = append(b.routineVisitInfo, routineVisitInfo{privilege: mysql.AlterRoutinePriv, db: v.Name.Schema.L,
+			routine: v.Name.Name.L, routineType: v.Type, err: authErr})
+	case *ast.CreateTriggerStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = ErrTableaccessDenied.GenWithStackByArgs("TRIGGER", b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.Table.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.TriggerPriv, v.Table.Schema.L,
+			v.Table.Name.L, "", authErr)
+		if v.Definer == nil || v.Definer.CurrentUser {
+			v.Definer = b.ctx.GetSessionVars().User
+		}
+		if b.ctx.GetSessionVars().User != nil && v.Definer.String() != b.ctx.GetSessionVars().User.String() {
+			err := ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "",
+				"", "", err)
+		}
+	case *ast.DropTriggerStmt:
+		// The privilege is checked on the table of the trigger.
+		tableName := ""
+		if tbl, _, ok := b.is.TriggerByName(v.Name.Schema, v.Name.Name); ok {
+			tableName = tbl.Meta().Name.L
+		}
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = ErrTableaccessDenied.GenWithStackByArgs("TRIGGER", b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, tableName)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.TriggerPriv, v.Name.Schema.L,
+			tableName, "", authErr)
 	}
 	p := &DDL{Statement: node}
 	return p, nil
@@ -4565,7 +4709,7 @@ func buildShowSchema(s *ast.ShowStmt, isView bool, isSequence bool) (schema *exp
 	var names []string
 	var ftypes []byte
 	switch s.Tp {
-	case ast.ShowProcedureStatus:
+	case ast.ShowProcedureStatus, ast.ShowFunctionStatus:
 		return buildShowProcedureSchema()
 	case ast.ShowTriggers:
 		return buildShowTriggerSchema()
@@ -4619,6 +4763,13 @@ func buildShowSchema(s *ast.ShowStmt, isView bool, isSequence bool) (schema *exp
 		}
 	case ast.ShowCreatePlacementPolicy:
 		names = []string{"Policy", "Create Policy"}
+	case ast.ShowCreateProcedure:
+		names = []string{"Procedure", "sql_mode", "Create Procedure", "character_set_client", "collation_connection", "Database Collation"}
+	case ast.ShowCreateFunction:
+		names = []string{"Function", "sql_mode", "Create Function", "character_set_client", "collation_connection", "Database Collation"}
+	case ast.ShowCreateTrigger:
+		names = []string{"Trigger", "sql_mode", "SQL Original Statement", "character_set_client", "collation_connection", "Database Collation", "Created"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeDatetime}
 	case ast.ShowCreateUser:
 		if s.User != nil {
 			names = []string{fmt.Sprintf("CREATE USER for %s", s.User)}
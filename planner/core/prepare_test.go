@@ -2625,3 +2625,108 @@ func TestCachedTable(t *testing.T) {
 	require.True(t, lastReadFromCache(tk))
 	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
 }
+
+func TestNonPreparedPlanCache(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	orgEnable := core.PreparedPlanCacheEnabled()
+	defer core.SetPreparedPlanCache(orgEnable)
+	core.SetPreparedPlanCache(true)
+	se, err := session.CreateSession4TestWithOpt(store, &session.Opt{
+		PreparedPlanCache: kvcache.NewSimpleLRUCache(100, 0.1, math.MaxUint64),
+	})
+	require.NoError(t, err)
+	tk := testkit.NewTestKitWithSession(t, store, se)
+
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t, t2")
+	tk.MustExec("create table t (a int, b varchar(10), key(a))")
+	tk.MustExec("create table t2 (a int)")
+	tk.MustExec("insert into t values (1, 'a'), (2, 'b'), (3, 'c')")
+	tk.MustExec("insert into t2 values (1)")
+
+	// The plan cache is disabled by default.
+	tk.MustQuery("select * from t where a = 1").Check(testkit.Rows("1 a"))
+	tk.MustQuery("select * from t where a = 2").Check(testkit.Rows("2 b"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+
+	tk.MustExec("set @@tidb_enable_non_prepared_plan_cache = 1")
+	tk.MustQuery("select * from t where a = 1").Check(testkit.Rows("1 a"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk.MustQuery("select * from t where a = 2").Check(testkit.Rows("2 b"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.MustQuery("select * from t where a = 4").Check(testkit.Rows())
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+
+	// The parameters of different types don't share the plan.
+	tk.MustQuery("select * from t where a = '3'").Check(testkit.Rows("3 c"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk.MustQuery("select * from t where b = 'a'").Check(testkit.Rows("1 a"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk.MustQuery("select * from t where b = 'c'").Check(testkit.Rows("3 c"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+
+	// The IN lists of different lengths don't share the plan.
+	tk.MustQuery("select * from t where a in (1, 2) order by a").Check(testkit.Rows("1 a", "2 b"))
+	tk.MustQuery("select * from t where a in (2, 3) order by a").Check(testkit.Rows("2 b", "3 c"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.MustQuery("select * from t where a in (1, 2, 3) order by a").Check(testkit.Rows("1 a", "2 b", "3 c"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+
+	// Range conditions.
+	tk.MustQuery("select b from t where a between 1 and 2 and b > 'a'").Check(testkit.Rows("b"))
+	tk.MustQuery("select b from t where a between 2 and 3 and b > 'b'").Check(testkit.Rows("c"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+
+	// The uncacheable statements.
+	for _, sql := range []string{
+		"select * from t, t2 where t.a = t2.a and t.a = 1",
+		"select * from t where a in (select a from t2 where a = 1)",
+		"select * from t where a = 1 for update",
+		"select * from t where a = 1 union all select * from t where a = 1",
+		"select * from information_schema.tables where table_name = 't'",
+	} {
+		tk.MustQuery(sql)
+		tk.MustQuery(sql)
+		tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	}
+
+	// The plan reflects the uncommitted data.
+	tk.MustExec("begin")
+	tk.MustExec("insert into t values (4, 'd')")
+	tk.MustQuery("select * from t where a = 4").Check(testkit.Rows("4 d"))
+	tk.MustQuery("select * from t where a = 4").Check(testkit.Rows("4 d"))
+	tk.MustExec("rollback")
+	tk.MustQuery("select * from t where a = 4").Check(testkit.Rows())
+
+	tk.MustExec("set @@tidb_enable_non_prepared_plan_cache = 0")
+	tk.MustQuery("select * from t where a = 1").Check(testkit.Rows("1 a"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+}
+
+func TestNonPreparedPlanCacheStmtSummary(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	orgEnable := core.PreparedPlanCacheEnabled()
+	defer core.SetPreparedPlanCache(orgEnable)
+	core.SetPreparedPlanCache(true)
+	se, err := session.CreateSession4TestWithOpt(store, &session.Opt{
+		PreparedPlanCache: kvcache.NewSimpleLRUCache(100, 0.1, math.MaxUint64),
+	})
+	require.NoError(t, err)
+	tk := testkit.NewTestKitWithSession(t, store, se)
+	require.True(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil))
+
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("set @@tidb_enable_non_prepared_plan_cache = 1")
+	tk.MustExec("set global tidb_enable_stmt_summary = 1")
+	defer tk.MustExec("set global tidb_enable_stmt_summary = default")
+	tk.MustExec("admin flush instance plan_cache")
+	for i := 0; i < 3; i++ {
+		tk.MustQuery(fmt.Sprintf("select b from t where a = %d", i))
+	}
+	tk.MustQuery("select exec_count, plan_cache_hits, plan_in_cache from information_schema.statements_summary " +
+		"where digest_text = 'select `b` from `t` where `a` = ?'").Check(testkit.Rows("3 2 1"))
+}
@@ -253,6 +253,11 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		p.stmtTp = TypeShow
 		p.showTp = node.Tp
 		p.resolveShowStmt(node)
+		if node.Tp == ast.ShowCreateProcedure || node.Tp == ast.ShowCreateFunction || node.Tp == ast.ShowCreateTrigger {
+			// The name of a stored routine or a trigger is not a table name.
+			p.resolveRoutineName(node.Table)
+			return in, true
+		}
 	case *ast.SetOprSelectList:
 		p.checkSetOprSelectList(node)
 	case *ast.DeleteTableList:
@@ -292,6 +297,24 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		p.stmtTp = TypeDrop
 		p.flag |= inCreateOrDropTable
 		p.checkDropSequenceGrammar(node)
+	case *ast.CreateRoutineStmt:
+		p.stmtTp = TypeCreate
+		p.resolveRoutineName(node.Name)
+		// The routine body is resolved when the routine is called.
+		return in, true
+	case *ast.DropRoutineStmt:
+		p.stmtTp = TypeDrop
+		p.resolveRoutineName(node.Name)
+		return in, true
+	case *ast.CreateTriggerStmt:
+		p.stmtTp = TypeCreate
+		p.resolveCreateTriggerNames(node)
+		// The trigger body is resolved when the trigger is activated.
+		return in, true
+	case *ast.DropTriggerStmt:
+		p.stmtTp = TypeDrop
+		p.resolveRoutineName(node.Name)
+		return in, true
 	case *ast.FuncCastExpr:
 		p.checkFuncCastExpr(node)
 	case *ast.FuncCallExpr:
@@ -1420,6 +1443,36 @@ func (p *preprocessor) stmtType() string {
 	}
 }
 
+func (p *preprocessor) resolveRoutineName(tn *ast.TableName) {
+	if tn.Schema.L != "" {
+		return
+	}
+	currentDB := p.ctx.GetSessionVars().CurrentDB
+	if currentDB == "" {
+		p.err = errors.Trace(ErrNoDB)
+		return
+	}
+	tn.Schema = model.NewCIStr(currentDB)
+}
+
+// resolveCreateTriggerNames resolves the schemas of the trigger and the table,
+// the trigger must be created in the schema of the table.
+func (p *preprocessor) resolveCreateTriggerNames(node *ast.CreateTriggerStmt) {
+	if node.Table.Schema.L == "" {
+		node.Table.Schema = node.Name.Schema
+	} else if node.Name.Schema.L == "" {
+		node.Name.Schema = node.Table.Schema
+	}
+	p.resolveRoutineName(node.Name)
+	if p.err != nil {
+		return
+	}
+	p.resolveRoutineName(node.Table)
+	if node.Name.Schema.L != node.Table.Schema.L {
+		p.err = ErrTrgInWrongSchema
+	}
+}
+
 func (p *preprocessor) handleTableName(tn *ast.TableName) {
 	if tn.Schema.L == "" {
 		if _, ok := p.withName[tn.Name.L]; ok {
@@ -446,6 +446,31 @@ func (p *NominalSort) ResolveIndices() (err error) {
 	return resolveIndicesForSort(p.basePhysicalPlan)
 }
 
+// ResolveIndices implements Plan interface.
+func (p *PhysicalExpand) ResolveIndices() (err error) {
+	err = p.physicalSchemaProducer.ResolveIndices()
+	if err != nil {
+		return err
+	}
+	// The child may output more columns than the expand passes through, e.g. the join keys of an MPP join.
+	childLen := p.Schema().Len() - len(p.GroupingCols) - 1
+	for i := 0; i < childLen; i++ {
+		newCol, err := p.Schema().Columns[i].ResolveIndices(p.children[0].Schema())
+		if err != nil {
+			return err
+		}
+		p.Schema().Columns[i] = newCol.(*expression.Column)
+	}
+	for i, col := range p.GroupingCols {
+		newCol, err := col.ResolveIndices(p.children[0].Schema())
+		if err != nil {
+			return err
+		}
+		p.GroupingCols[i] = newCol.(*expression.Column)
+	}
+	return nil
+}
+
 // ResolveIndices implements Plan interface.
 func (p *PhysicalWindow) ResolveIndices() (err error) {
 	err = p.physicalSchemaProducer.ResolveIndices()
@@ -114,6 +114,13 @@ func (p *LogicalSelection) BuildKeyInfo(selfSchema *expression.Schema, childSche
 	p.maxOneRow = p.checkMaxOneRowCond(eqCols, childSchema[0])
 }
 
+// BuildKeyInfo implements LogicalPlan BuildKeyInfo interface.
+func (p *LogicalExpand) BuildKeyInfo(selfSchema *expression.Schema, childSchema []*expression.Schema) {
+	// The child rows are duplicated for every grouping set, so the keys of the child are lost.
+	selfSchema.Keys = nil
+	p.baseLogicalPlan.BuildKeyInfo(selfSchema, childSchema)
+}
+
 // BuildKeyInfo implements LogicalPlan BuildKeyInfo interface.
 func (p *LogicalLimit) BuildKeyInfo(selfSchema *expression.Schema, childSchema []*expression.Schema) {
 	p.logicalSchemaProducer.BuildKeyInfo(selfSchema, childSchema)
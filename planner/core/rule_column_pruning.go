@@ -527,6 +527,26 @@ func (p *LogicalWindow) PruneColumns(parentUsedCols []*expression.Column, opt *l
 	return nil
 }
 
+// PruneColumns implements LogicalPlan interface.
+func (p *LogicalExpand) PruneColumns(parentUsedCols []*expression.Column, opt *logicalOptimizeOp) error {
+	// The expanded columns and the grouping id are always used by the aggregation above.
+	childUsedCols := make([]*expression.Column, 0, len(parentUsedCols)+len(p.GroupingCols))
+	for _, col := range parentUsedCols {
+		if p.children[0].Schema().Contains(col) {
+			childUsedCols = append(childUsedCols, col)
+		}
+	}
+	childUsedCols = append(childUsedCols, p.GroupingCols...)
+	err := p.children[0].PruneColumns(childUsedCols, opt)
+	if err != nil {
+		return err
+	}
+	p.SetSchema(p.children[0].Schema().Clone())
+	p.Schema().Append(p.ExpandedCols...)
+	p.Schema().Append(p.GroupingID)
+	return nil
+}
+
 func (p *LogicalWindow) extractUsedCols(parentUsedCols []*expression.Column) []*expression.Column {
 	for _, desc := range p.WindowFuncDescs {
 		for _, arg := range desc.Args {
@@ -279,6 +279,12 @@ func (lt *LogicalTopN) replaceExprColumns(replace map[string]*expression.Column)
 	}
 }
 
+func (p *LogicalExpand) replaceExprColumns(replace map[string]*expression.Column) {
+	for _, col := range p.GroupingCols {
+		resolveColumnAndReplace(col, replace)
+	}
+}
+
 func (p *LogicalWindow) replaceExprColumns(replace map[string]*expression.Column) {
 	for _, desc := range p.WindowFuncDescs {
 		for _, arg := range desc.Args {
@@ -33,7 +33,6 @@ import (
 	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
-	"github.com/pingcap/tidb/util/math"
 	"github.com/pingcap/tidb/util/plancodec"
 	"github.com/pingcap/tidb/util/ranger"
 	"github.com/pingcap/tidb/util/set"
@@ -160,11 +159,11 @@ func (s *partitionProcessor) findUsedPartitions(ctx sessionctx.Context, tbl tabl
 			if isNull {
 				pos = 0
 			}
-			idx := math.Abs(pos % int64(pi.Num))
+			idx := tables.HashPartitionIdx(pi, pos)
 			if len(partitionNames) > 0 && !s.findByName(partitionNames, pi.Definitions[idx].Name.L) {
 				continue
 			}
-			used = append(used, int(idx))
+			used = append(used, idx)
 		} else {
 			// processing hash partition pruning. eg:
 			// create table t2 (a int, b bigint, index (a), index (b)) partition by hash(a) partitions 10;
@@ -201,11 +200,11 @@ func (s *partitionProcessor) findUsedPartitions(ctx sessionctx.Context, tbl tabl
 				// if range is less than the number of partitions, there will be unused partitions we can prune out.
 				if rangeScalar < float64(numPartitions) && !highIsNull && !lowIsNull {
 					for i := posLow; i <= posHigh; i++ {
-						idx := math.Abs(i % int64(pi.Num))
+						idx := tables.HashPartitionIdx(pi, i)
 						if len(partitionNames) > 0 && !s.findByName(partitionNames, pi.Definitions[idx].Name.L) {
 							continue
 						}
-						used = append(used, int(idx))
+						used = append(used, idx)
 					}
 					continue
 				}
@@ -282,6 +281,88 @@ func (s *partitionProcessor) pruneHashPartition(ctx sessionctx.Context, tbl tabl
 	return used, nil
 }
 
+// findUsedKeyPartitions locates the partitions of a KEY partitioned table. Only
+// the conditions that fix every partitioning column to a single value can prune
+// partitions, since the hash of the columns keeps no order.
+func (s *partitionProcessor) findUsedKeyPartitions(ctx sessionctx.Context, tbl table.Table, partitionNames []model.CIStr,
+	conds []expression.Expression, columns []*expression.Column, names types.NameSlice) ([]int, error) {
+	pi := tbl.Meta().Partition
+	partCols := make([]*expression.Column, 0, len(pi.Columns))
+	colLen := make([]int, 0, len(pi.Columns))
+	fts := make([]*types.FieldType, 0, len(pi.Columns))
+	for _, col := range pi.Columns {
+		idx := expression.FindFieldNameIdxByColName(names, col.L)
+		if idx < 0 {
+			return []int{FullRange}, nil
+		}
+		partCols = append(partCols, columns[idx])
+		colLen = append(colLen, types.UnspecifiedLength)
+		fts = append(fts, columns[idx].RetType)
+	}
+	detachedResult, err := ranger.DetachCondAndBuildRangeForPartition(ctx, conds, partCols, colLen)
+	if err != nil {
+		return nil, err
+	}
+	sc := ctx.GetSessionVars().StmtCtx
+	used := make([]int, 0, len(detachedResult.Ranges))
+	for _, r := range detachedResult.Ranges {
+		if len(r.LowVal) != len(partCols) || !r.IsPointNullable(ctx) {
+			used = []int{FullRange}
+			break
+		}
+		vals := make([]types.Datum, len(partCols))
+		for i := range vals {
+			vals[i], err = r.LowVal[i].ConvertTo(sc, fts[i])
+			if err != nil {
+				break
+			}
+		}
+		var idx int
+		if err == nil {
+			idx, err = tables.KeyPartitionIdx(sc, pi, fts, vals)
+		}
+		if err != nil {
+			// The value can not be located, so no partition can be pruned.
+			used = []int{FullRange}
+			break
+		}
+		if len(partitionNames) > 0 && !s.findByName(partitionNames, pi.Definitions[idx].Name.L) {
+			continue
+		}
+		used = append(used, idx)
+	}
+	if len(used) == 1 && used[0] == FullRange {
+		or := partitionRangeOR{partitionRange{0, len(pi.Definitions)}}
+		return s.convertToIntSlice(or, pi, partitionNames), nil
+	}
+	sort.Ints(used)
+	ret := used[:0]
+	for i := 0; i < len(used); i++ {
+		if i == 0 || used[i] != used[i-1] {
+			ret = append(ret, used[i])
+		}
+	}
+	return ret, nil
+}
+
+func (s *partitionProcessor) processKeyPartition(ds *DataSource, pi *model.PartitionInfo, opt *logicalOptimizeOp) (LogicalPlan, error) {
+	names, err := s.reconstructTableColNames(ds)
+	if err != nil {
+		return nil, err
+	}
+	used, err := s.findUsedKeyPartitions(ds.SCtx(), ds.table, ds.partitionNames, ds.allConds, ds.TblCols, names)
+	if err != nil {
+		return nil, err
+	}
+	if used != nil {
+		return s.makeUnionAllChildren(ds, pi, convertToRangeOr(used, pi), opt)
+	}
+	tableDual := LogicalTableDual{RowCount: 0}.Init(ds.SCtx(), ds.blockOffset)
+	tableDual.schema = ds.Schema()
+	appendNoPartitionChildTraceStep(ds, tableDual, opt)
+	return tableDual, nil
+}
+
 // reconstructTableColNames reconstructs FieldsNames according to ds.TblCols.
 // ds.names may not match ds.TblCols since ds.names is pruned while ds.TblCols contains all original columns.
 // please see https://github.com/pingcap/tidb/issues/22635 for more details.
@@ -646,11 +727,13 @@ func (s *partitionProcessor) prune(ds *DataSource, opt *logicalOptimizeOp) (Logi
 		return s.processRangePartition(ds, pi, opt)
 	case model.PartitionTypeHash:
 		return s.processHashPartition(ds, pi, opt)
+	case model.PartitionTypeKey:
+		return s.processKeyPartition(ds, pi, opt)
 	case model.PartitionTypeList:
 		return s.processListPartition(ds, pi, opt)
 	}
 
-	// We haven't implement partition by key and so on.
+	// We haven't implement partition by system time and so on.
 	return s.makeUnionAllChildren(ds, pi, fullRange(len(pi.Definitions)), opt)
 }
 
@@ -1436,7 +1519,7 @@ func (s *partitionProcessor) checkHintsApplicable(ds *DataSource, partitionSet s
 	}
 	for _, idxMergeHint := range ds.indexMergeHints {
 		unknownPartitions := checkTableHintsApplicableForPartition(idxMergeHint.partitions, partitionSet)
-		appendWarnForUnknownPartitions(ds.ctx, restore2IndexHint(HintIndexMerge, idxMergeHint), unknownPartitions)
+		appendWarnForUnknownPartitions(ds.ctx, restore2IndexHint(idxMergeHint.indexMergeHintTypeString(), idxMergeHint), unknownPartitions)
 	}
 	unknownPartitions := checkTableHintsApplicableForPartition(ds.preferPartitions[preferTiKV], partitionSet)
 	unknownPartitions = append(unknownPartitions,
@@ -490,6 +490,13 @@ func (p *LogicalLimit) PredicatePushDown(predicates []expression.Expression, opt
 	return predicates, p
 }
 
+// PredicatePushDown implements LogicalPlan PredicatePushDown interface.
+func (p *LogicalExpand) PredicatePushDown(predicates []expression.Expression, opt *logicalOptimizeOp) ([]expression.Expression, LogicalPlan) {
+	// The conditions above the expand may refer to the expanded columns, so keep them above.
+	p.baseLogicalPlan.PredicatePushDown(nil, opt)
+	return predicates, p
+}
+
 // PredicatePushDown implements LogicalPlan PredicatePushDown interface.
 func (p *LogicalMaxOneRow) PredicatePushDown(predicates []expression.Expression, opt *logicalOptimizeOp) ([]expression.Expression, LogicalPlan) {
 	// MaxOneRow forbids any condition to push down.
@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/planner/property"
 	"github.com/pingcap/tidb/planner/util"
+	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/statistics"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/logutil"
@@ -106,6 +107,16 @@ func (p *LogicalShowDDLJobs) DeriveStats(childStats []*property.StatsInfo, selfS
 	return p.stats, nil
 }
 
+// DeriveStats implement LogicalPlan DeriveStats interface.
+func (p *LogicalJSONTable) DeriveStats(childStats []*property.StatsInfo, selfSchema *expression.Schema, childSchema []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
+	if p.stats != nil {
+		return p.stats, nil
+	}
+	// The row count of JSON_TABLE depends on the document, so use a fake count here.
+	p.stats = getFakeStats(selfSchema)
+	return p.stats, nil
+}
+
 // RecursiveDeriveStats4Test is a exporter just for test.
 func RecursiveDeriveStats4Test(p LogicalPlan) (*property.StatsInfo, error) {
 	return p.recursiveDeriveStats(nil)
@@ -409,7 +420,8 @@ func (ds *DataSource) DeriveStats(childStats []*property.StatsInfo, selfSchema *
 		return nil, err
 	}
 
-	// Consider the IndexMergePath. Now, we just generate `IndexMergePath` in DNF case.
+	// Consider the IndexMergePath. Now, we generate the union type `IndexMergePath` in DNF case,
+	// and the intersection type `IndexMergePath` in CNF case.
 	// Use allConds instread of pushedDownConds,
 	// because we want to use IndexMerge even if some expr cannot be pushed to TiKV.
 	// We will create new Selection for exprs that cannot be pushed in convertToIndexMergeScan.
@@ -422,29 +434,29 @@ func (ds *DataSource) DeriveStats(childStats []*property.StatsInfo, selfSchema *
 	isPossibleIdxMerge := len(indexMergeConds) > 0 && len(ds.possibleAccessPaths) > 1
 	sessionAndStmtPermission := (ds.ctx.GetSessionVars().GetEnableIndexMerge() || len(ds.indexMergeHints) > 0) && !stmtCtx.NoIndexMergeHint
 	// We current do not consider `IndexMergePath`:
-	// 1. If there is an index path.
+	// 1. The union type `IndexMergePath` if there is an index path. The intersection type `IndexMergePath`
+	//    needs the index paths, and it's left to the cost to choose.
 	// 2. TODO: If there exists exprs that cannot be pushed down. This is to avoid wrongly estRow of Selection added by rule_predicate_push_down.
 	needConsiderIndexMerge := true
+	needConsiderUnion := true
 	if len(ds.indexMergeHints) == 0 {
 		for i := 1; i < len(ds.possibleAccessPaths); i++ {
 			if len(ds.possibleAccessPaths[i].AccessConds) != 0 {
-				needConsiderIndexMerge = false
+				needConsiderUnion = false
 				break
 			}
 		}
-		if needConsiderIndexMerge {
-			// PushDownExprs() will append extra warnings, which is annoying. So we reset warnings here.
-			warnings := stmtCtx.GetWarnings()
-			_, remaining := expression.PushDownExprs(stmtCtx, indexMergeConds, ds.ctx.GetClient(), kv.UnSpecified)
-			stmtCtx.SetWarnings(warnings)
-			if len(remaining) != 0 {
-				needConsiderIndexMerge = false
-			}
+		// PushDownExprs() will append extra warnings, which is annoying. So we reset warnings here.
+		warnings := stmtCtx.GetWarnings()
+		_, remaining := expression.PushDownExprs(stmtCtx, indexMergeConds, ds.ctx.GetClient(), kv.UnSpecified)
+		stmtCtx.SetWarnings(warnings)
+		if len(remaining) != 0 {
+			needConsiderIndexMerge = false
 		}
 	}
 
 	if isPossibleIdxMerge && sessionAndStmtPermission && needConsiderIndexMerge && ds.tableInfo.TempTableType != model.TempTableLocal {
-		err := ds.generateAndPruneIndexMergePath(indexMergeConds, ds.indexMergeHints != nil)
+		err := ds.generateAndPruneIndexMergePath(indexMergeConds, needConsiderUnion, ds.indexMergeHints != nil)
 		if err != nil {
 			return nil, err
 		}
@@ -465,11 +477,18 @@ func (ds *DataSource) DeriveStats(childStats []*property.StatsInfo, selfSchema *
 	return ds.stats, nil
 }
 
-func (ds *DataSource) generateAndPruneIndexMergePath(indexMergeConds []expression.Expression, needPrune bool) error {
+func (ds *DataSource) generateAndPruneIndexMergePath(indexMergeConds []expression.Expression, needConsiderUnion, needPrune bool) error {
 	regularPathCount := len(ds.possibleAccessPaths)
-	err := ds.generateIndexMergeOrPaths(indexMergeConds)
-	if err != nil {
-		return err
+	if needConsiderUnion && ds.isIndexMergeTypeInHints(false) {
+		err := ds.generateIndexMergeOrPaths(indexMergeConds)
+		if err != nil {
+			return err
+		}
+	}
+	if ds.isIndexMergeTypeInHints(true) {
+		if path := ds.generateIndexMergeAndPath(indexMergeConds, regularPathCount); path != nil {
+			ds.possibleAccessPaths = append(ds.possibleAccessPaths, path)
+		}
 	}
 	// If without hints, it means that `enableIndexMerge` is true
 	if len(ds.indexMergeHints) == 0 {
@@ -614,6 +633,20 @@ func (ds *DataSource) generateIndexMergeOrPaths(filters []expression.Expression)
 	return nil
 }
 
+// isIndexMergeTypeInHints checks whether the intersection type or the union type IndexMerge is allowed by IndexMerge hints.
+// Both types are allowed without the hints.
+func (ds *DataSource) isIndexMergeTypeInHints(intersection bool) bool {
+	if len(ds.indexMergeHints) == 0 {
+		return true
+	}
+	for _, hint := range ds.indexMergeHints {
+		if hint.indexMergeIntersection == intersection {
+			return true
+		}
+	}
+	return false
+}
+
 // isInIndexMergeHints checks whether current index or primary key is in IndexMerge hints.
 func (ds *DataSource) isInIndexMergeHints(name string) bool {
 	if len(ds.indexMergeHints) == 0 {
@@ -753,6 +786,103 @@ func (ds *DataSource) buildIndexMergeOrPath(filters []expression.Expression, par
 	return indexMergePath
 }
 
+// generateIndexMergeAndPath generates the intersection type IndexMergePath for the CNF filters.
+// Every index path with a range condition becomes a partial path, and the filters that are not
+// used by any partial path are kept in the TableFilters.
+func (ds *DataSource) generateIndexMergeAndPath(filters []expression.Expression, regularPathCount int) *util.AccessPath {
+	// TiFlash storage do not support index scan.
+	if ds.preferStoreType&preferTiFlash != 0 {
+		return nil
+	}
+	candidatePaths := make([]*util.AccessPath, 0, regularPathCount)
+	for i := 0; i < regularPathCount; i++ {
+		originalPath := ds.possibleAccessPaths[i]
+		// The table path is not considered as a partial path, and the handles of TiFlash can't be intersected.
+		if originalPath.IsTablePath() || originalPath.StoreType == kv.TiFlash || len(originalPath.AccessConds) == 0 {
+			continue
+		}
+		if !ds.isInIndexMergeHints(originalPath.Index.Name.L) {
+			continue
+		}
+		// If the path contains a full range, ignore it.
+		if ranger.HasFullRange(originalPath.Ranges, false) {
+			continue
+		}
+		candidatePaths = append(candidatePaths, originalPath)
+	}
+	// Choose the paths with fewer rows first, and ignore the paths whose access conditions are all
+	// used by the chosen paths, since they can't filter more handles.
+	sort.SliceStable(candidatePaths, func(i, j int) bool {
+		return candidatePaths[i].CountAfterAccess < candidatePaths[j].CountAfterAccess
+	})
+	partialPaths := make([]*util.AccessPath, 0, len(candidatePaths))
+	originalPaths := make([]*util.AccessPath, 0, len(candidatePaths))
+	accessConds := make([]expression.Expression, 0, len(filters))
+	for _, originalPath := range candidatePaths {
+		coveredByChosenPaths := true
+		for _, cond := range originalPath.AccessConds {
+			if !containsEqualExpr(ds.ctx, accessConds, cond) {
+				coveredByChosenPaths = false
+				break
+			}
+		}
+		if coveredByChosenPaths {
+			continue
+		}
+		accessConds = append(accessConds, originalPath.AccessConds...)
+		path := *originalPath
+		// The TableFilters are checked after the handles are intersected.
+		path.TableFilters = nil
+		if len(path.IndexFilters) != 0 && !expression.CanExprsPushDown(ds.ctx.GetSessionVars().StmtCtx, path.IndexFilters, ds.ctx.GetClient(), kv.TiKV) {
+			path.IndexFilters = nil
+			path.CountAfterIndex = path.CountAfterAccess
+		}
+		partialPaths = append(partialPaths, &path)
+		originalPaths = append(originalPaths, originalPath)
+	}
+	if len(partialPaths) < 2 {
+		return nil
+	}
+
+	partialConds := make([]expression.Expression, 0, len(filters))
+	// usedConds are the filters that are fully checked by the partial paths. The filters on the
+	// prefix index columns are also in the TableFilters of the original path, so they are not used.
+	usedConds := make([]expression.Expression, 0, len(filters))
+	for i, path := range partialPaths {
+		conds := make([]expression.Expression, 0, len(path.AccessConds)+len(path.IndexFilters))
+		conds = append(conds, path.AccessConds...)
+		conds = append(conds, path.IndexFilters...)
+		for _, cond := range conds {
+			partialConds = append(partialConds, cond)
+			if !containsEqualExpr(ds.ctx, originalPaths[i].TableFilters, cond) {
+				usedConds = append(usedConds, cond)
+			}
+		}
+	}
+	indexMergePath := &util.AccessPath{PartialIndexPaths: partialPaths, IndexMergeIsIntersection: true}
+	for _, cond := range filters {
+		if !containsEqualExpr(ds.ctx, usedConds, cond) {
+			indexMergePath.TableFilters = append(indexMergePath.TableFilters, cond)
+		}
+	}
+	sel, _, err := ds.tableStats.HistColl.Selectivity(ds.ctx, partialConds, nil)
+	if err != nil {
+		logutil.BgLogger().Debug("something wrong happened, use the default selectivity", zap.Error(err))
+		sel = SelectionFactor
+	}
+	indexMergePath.CountAfterAccess = sel * ds.tableStats.RowCount
+	return indexMergePath
+}
+
+func containsEqualExpr(ctx sessionctx.Context, exprs []expression.Expression, e expression.Expression) bool {
+	for _, expr := range exprs {
+		if expr.Equal(ctx, e) {
+			return true
+		}
+	}
+	return false
+}
+
 // DeriveStats implement LogicalPlan DeriveStats interface.
 func (p *LogicalSelection) DeriveStats(childStats []*property.StatsInfo, selfSchema *expression.Schema, childSchema []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
 	if p.stats != nil {
@@ -1233,6 +1363,28 @@ func (p *LogicalWindow) DeriveStats(childStats []*property.StatsInfo, selfSchema
 	return p.stats, nil
 }
 
+// DeriveStats implement LogicalPlan DeriveStats interface.
+func (p *LogicalExpand) DeriveStats(childStats []*property.StatsInfo, selfSchema *expression.Schema, childSchema []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
+	if p.stats != nil {
+		return p.stats, nil
+	}
+	childProfile := childStats[0]
+	setCnt := float64(len(p.GroupingSets))
+	p.stats = &property.StatsInfo{
+		RowCount: childProfile.RowCount * setCnt,
+		ColNDVs:  make(map[int64]float64, selfSchema.Len()),
+	}
+	for _, col := range childSchema[0].Columns {
+		p.stats.ColNDVs[col.UniqueID] = childProfile.ColNDVs[col.UniqueID]
+	}
+	for i, col := range p.ExpandedCols {
+		// The expanded column has an extra NULL value.
+		p.stats.ColNDVs[col.UniqueID] = childProfile.ColNDVs[p.GroupingCols[i].UniqueID] + 1
+	}
+	p.stats.ColNDVs[p.GroupingID.UniqueID] = setCnt
+	return p.stats, nil
+}
+
 // ExtractColGroups implements LogicalPlan ExtractColGroups interface.
 func (p *LogicalWindow) ExtractColGroups(colGroups [][]*expression.Column) [][]*expression.Column {
 	if len(colGroups) == 0 {
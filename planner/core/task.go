@@ -84,6 +84,8 @@ type copTask struct {
 	// is used to compute average row width when computing scan cost.
 	tblCols           []*expression.Column
 	idxMergePartPlans []PhysicalPlan
+	// idxMergeIsIntersection indicates whether the handles of idxMergePartPlans are intersected.
+	idxMergeIsIntersection bool
 	// rootTaskConds stores select conditions containing virtual columns.
 	// These conditions can't push to TiKV, so we have to add a selection for rootTask
 	rootTaskConds []expression.Expression
@@ -1061,8 +1063,9 @@ func (t *copTask) convertToRootTaskImpl(ctx sessionctx.Context) *rootTask {
 	}
 	if t.idxMergePartPlans != nil {
 		p := PhysicalIndexMergeReader{
-			partialPlans: t.idxMergePartPlans,
-			tablePlan:    t.tablePlan,
+			partialPlans:       t.idxMergePartPlans,
+			tablePlan:          t.tablePlan,
+			IsIntersectionType: t.idxMergeIsIntersection,
 		}.Init(ctx, t.idxMergePartPlans[0].SelectBlockOffset())
 		p.PartitionInfo = t.partitionInfo
 		setTableScanToTableRowIDScan(p.tablePlan)
@@ -1452,6 +1455,18 @@ func (p *PhysicalProjection) attach2Task(tasks ...task) task {
 	return t
 }
 
+func (p *PhysicalExpand) attach2Task(tasks ...task) task {
+	if mpp, ok := tasks[0].copy().(*mppTask); ok {
+		p.SetChildren(mpp.p)
+		mpp.p = p
+		// The child is cloned and executed for each grouping set in MPP, see untwistPlanAndRemoveUnionAll.
+		mpp.cst *= float64(len(p.GroupingSets))
+		p.cost = mpp.cost()
+		return mpp
+	}
+	return p.basePhysicalPlan.attach2Task(tasks...)
+}
+
 func (p *PhysicalUnionAll) attach2MppTasks(tasks ...task) task {
 	t := &mppTask{p: p}
 	childPlans := make([]PhysicalPlan, 0, len(tasks))
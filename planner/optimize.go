@@ -165,6 +165,16 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 		node = stmtNode
 	}
 
+	if ok && !useBinding && sessVars.EnableNonPreparedPlanCache && !sessVars.InRestrictedSQL && sctx.PreparedPlanCache() != nil {
+		p, names, ok, err := getPlanFromNonPreparedPlanCache(ctx, sctx, stmtNode, is)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			return p, names, nil
+		}
+	}
+
 	var (
 		names                      types.NameSlice
 		bestPlan, bestPlanFromBind plannercore.Plan
@@ -266,6 +276,40 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 	return bestPlan, names, nil
 }
 
+// getPlanFromNonPreparedPlanCache tries to get the plan of the plain text statement from the plan cache. The constants
+// of the statement are parameterized, so the statements of the same normalized digest can share the plan. If the plan
+// isn't cached, it's built for the parameterized statement and then put into the cache.
+func getPlanFromNonPreparedPlanCache(ctx context.Context, sctx sessionctx.Context, stmt ast.StmtNode, is infoschema.InfoSchema) (plannercore.Plan, types.NameSlice, bool, error) {
+	if !plannercore.NonPreparedPlanCacheable(sctx, stmt, is) {
+		return nil, nil, false, nil
+	}
+	paramSQL, params, restore, err := plannercore.ParameterizeAST(stmt)
+	if err != nil {
+		logutil.BgLogger().Debug("parameterize statement failed", zap.Error(err))
+		return nil, nil, false, nil
+	}
+	defer restore()
+
+	sessVars := sctx.GetSessionVars()
+	key, err := plannercore.NewNonPreparedPlanCacheKey(sessVars, paramSQL, is.SchemaMetaVersion())
+	if err != nil {
+		return nil, nil, false, err
+	}
+	// The parameter markers read their values from the prepared parameters.
+	sessVars.PreparedParams = append(sessVars.PreparedParams[:0], params...)
+	sessVars.StmtCtx.UseCache = true
+	p, names, ok, err := plannercore.GetPlanFromNonPreparedPlanCache(sctx, is, stmt, key, paramSQL, params)
+	if err != nil || ok {
+		return p, names, ok, err
+	}
+	p, names, _, err = optimize(ctx, sctx, stmt, is)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	plannercore.PutPlanToNonPreparedPlanCache(sctx, key, paramSQL, params, p, names)
+	return p, names, true, nil
+}
+
 func allowInReadOnlyMode(sctx sessionctx.Context, node ast.Node) (bool, error) {
 	pm := privilege.GetPrivilegeManager(sctx)
 	if pm == nil {
@@ -289,7 +333,9 @@ func allowInReadOnlyMode(sctx sessionctx.Context, node ast.Node) (bool, error) {
 		*ast.DropBindingStmt,
 		*ast.PrepareStmt,
 		*ast.BeginStmt,
-		*ast.RollbackStmt:
+		*ast.RollbackStmt,
+		*ast.SavepointStmt,
+		*ast.ReleaseSavepointStmt:
 		return true, nil
 	case *ast.CommitStmt:
 		txn, err := sctx.Txn(true)
@@ -361,6 +407,9 @@ func optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 		if err := plannercore.CheckPrivilege(activeRoles, pm, visitInfo); err != nil {
 			return nil, nil, 0, err
 		}
+		if err := plannercore.CheckRoutinePrivilege(activeRoles, pm, builder.GetRoutineVisitInfo()); err != nil {
+			return nil, nil, 0, err
+		}
 	}
 
 	if err := plannercore.CheckTableLock(sctx, is, builder.GetVisitInfo()); err != nil {
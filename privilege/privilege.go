@@ -18,6 +18,7 @@ import (
 	"crypto/tls"
 
 	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/types"
@@ -44,6 +45,10 @@ type Manager interface {
 	// this means any privilege would be OK.
 	RequestVerification(activeRole []*auth.RoleIdentity, db, table, column string, priv mysql.PrivilegeType) bool
 
+	// RequestRoutineVerification verifies user privilege on a stored routine.
+	// The privileges granted on the routine are checked besides the global/db scope privileges.
+	RequestRoutineVerification(activeRole []*auth.RoleIdentity, db, routine string, routineType model.RoutineType, priv mysql.PrivilegeType) bool
+
 	// RequestVerificationWithUser verifies specific user privilege for the request.
 	RequestVerificationWithUser(db, table, column string, priv mysql.PrivilegeType, user *auth.UserIdentity) bool
 
@@ -47,6 +47,7 @@ var (
 	userTablePrivilegeMask = computePrivMask(mysql.AllGlobalPrivs)
 	dbTablePrivilegeMask   = computePrivMask(mysql.AllDBPrivs)
 	tablePrivMask          = computePrivMask(mysql.AllTablePrivs)
+	routinePrivMask        = computePrivMask(mysql.AllRoutinePrivs)
 )
 
 const globalDBVisible = mysql.CreatePriv | mysql.SelectPriv | mysql.InsertPriv | mysql.UpdatePriv | mysql.DeletePriv | mysql.ShowDBPriv | mysql.DropPriv | mysql.AlterPriv | mysql.IndexPriv | mysql.CreateViewPriv | mysql.ShowViewPriv | mysql.GrantPriv | mysql.TriggerPriv | mysql.ReferencesPriv | mysql.ExecutePriv
@@ -57,6 +58,7 @@ const (
 	sqlLoadDBTable          = "SELECT HIGH_PRIORITY Host,DB,User,Select_priv,Insert_priv,Update_priv,Delete_priv,Create_priv,Drop_priv,Grant_priv,Index_priv,References_priv,Lock_tables_priv,Create_tmp_table_priv,Event_priv,Create_routine_priv,Alter_routine_priv,Alter_priv,Execute_priv,Create_view_priv,Show_view_priv FROM mysql.db ORDER BY host, db, user"
 	sqlLoadTablePrivTable   = "SELECT HIGH_PRIORITY Host,DB,User,Table_name,Grantor,Timestamp,Table_priv,Column_priv FROM mysql.tables_priv"
 	sqlLoadColumnsPrivTable = "SELECT HIGH_PRIORITY Host,DB,User,Table_name,Column_name,Timestamp,Column_priv FROM mysql.columns_priv"
+	sqlLoadProcsPrivTable   = "SELECT HIGH_PRIORITY Host,DB,User,Routine_name,Routine_type,Proc_priv FROM mysql.procs_priv"
 	sqlLoadDefaultRoles     = "SELECT HIGH_PRIORITY HOST, USER, DEFAULT_ROLE_HOST, DEFAULT_ROLE_USER FROM mysql.default_roles"
 	// list of privileges from mysql.Priv2UserCol
 	sqlLoadUserTable = `SELECT HIGH_PRIORITY Host,User,authentication_string,
@@ -206,6 +208,16 @@ type tablesPrivRecord struct {
 	ColumnPriv mysql.PrivilegeType
 }
 
+type procsPrivRecord struct {
+	baseRecord
+
+	DB          string
+	RoutineName string
+	// RoutineType is FUNCTION or PROCEDURE.
+	RoutineType string
+	ProcPriv    mysql.PrivilegeType
+}
+
 type columnsPrivRecord struct {
 	baseRecord
 
@@ -265,6 +277,7 @@ type MySQLPrivilege struct {
 	TablesPriv    []tablesPrivRecord
 	TablesPrivMap map[string][]tablesPrivRecord // Accelerate TablesPriv searching
 	ColumnsPriv   []columnsPrivRecord
+	ProcsPriv     []procsPrivRecord
 	DefaultRoles  []defaultRoleRecord
 	RoleGraph     map[string]roleGraphEdgesTable
 }
@@ -371,6 +384,15 @@ func (p *MySQLPrivilege) LoadAll(ctx sessionctx.Context) error {
 		logutil.BgLogger().Warn("mysql.columns_priv missing")
 	}
 
+	err = p.LoadProcsPrivTable(ctx)
+	if err != nil {
+		if !noSuchTable(err) {
+			logutil.BgLogger().Warn("load mysql.procs_priv", zap.Error(err))
+			return errLoadPrivilege.FastGen("mysql.procs_priv")
+		}
+		logutil.BgLogger().Warn("mysql.procs_priv missing")
+	}
+
 	err = p.LoadRoleGraph(ctx)
 	if err != nil {
 		if !noSuchTable(err) {
@@ -559,6 +581,11 @@ func (p *MySQLPrivilege) LoadColumnsPrivTable(ctx sessionctx.Context) error {
 	return p.loadTable(ctx, sqlLoadColumnsPrivTable, p.decodeColumnsPrivTableRow)
 }
 
+// LoadProcsPrivTable loads the mysql.procs_priv table from database.
+func (p *MySQLPrivilege) LoadProcsPrivTable(ctx sessionctx.Context) error {
+	return p.loadTable(ctx, sqlLoadProcsPrivTable, p.decodeProcsPrivTableRow)
+}
+
 // LoadDefaultRoles loads the mysql.columns_priv table from database.
 func (p *MySQLPrivilege) LoadDefaultRoles(ctx sessionctx.Context) error {
 	return p.loadTable(ctx, sqlLoadDefaultRoles, p.decodeDefaultRoleTableRow)
@@ -771,6 +798,26 @@ func (p *MySQLPrivilege) decodeTablesPrivTableRow(row chunk.Row, fs []*ast.Resul
 	return nil
 }
 
+func (p *MySQLPrivilege) decodeProcsPrivTableRow(row chunk.Row, fs []*ast.ResultField) error {
+	var value procsPrivRecord
+	for i, f := range fs {
+		switch {
+		case f.ColumnAsName.L == "db":
+			value.DB = row.GetString(i)
+		case f.ColumnAsName.L == "routine_name":
+			value.RoutineName = row.GetString(i)
+		case f.ColumnAsName.L == "routine_type":
+			value.RoutineType = row.GetEnum(i).String()
+		case f.ColumnAsName.L == "proc_priv":
+			value.ProcPriv = decodeSetToPrivilege(row.GetSet(i))
+		default:
+			value.assignUserOrHost(row, i, f)
+		}
+	}
+	p.ProcsPriv = append(p.ProcsPriv, value)
+	return nil
+}
+
 func (p *MySQLPrivilege) decodeRoleEdgesTable(row chunk.Row, fs []*ast.ResultField) error {
 	var fromUser, fromHost, toHost, toUser string
 	for i, f := range fs {
@@ -893,6 +940,13 @@ func (record *tablesPrivRecord) match(user, host, db, table string) bool {
 		strings.EqualFold(record.TableName, table)
 }
 
+func (record *procsPrivRecord) match(user, host, db, routine, routineType string) bool {
+	return record.baseRecord.match(user, host) &&
+		strings.EqualFold(record.DB, db) &&
+		strings.EqualFold(record.RoutineName, routine) &&
+		record.RoutineType == routineType
+}
+
 func (record *columnsPrivRecord) match(user, host, db, table, col string) bool {
 	return record.baseRecord.match(user, host) &&
 		strings.EqualFold(record.DB, db) &&
@@ -1020,6 +1074,16 @@ func (p *MySQLPrivilege) matchColumns(user, host, db, table, column string) *col
 	return nil
 }
 
+func (p *MySQLPrivilege) matchProcs(user, host, db, routine, routineType string) *procsPrivRecord {
+	for i := 0; i < len(p.ProcsPriv); i++ {
+		record := &p.ProcsPriv[i]
+		if record.match(user, host, db, routine, routineType) {
+			return record
+		}
+	}
+	return nil
+}
+
 // HasExplicitlyGrantedDynamicPrivilege checks if a user has a DYNAMIC privilege
 // without accepting SUPER privilege as a fallback.
 func (p *MySQLPrivilege) HasExplicitlyGrantedDynamicPrivilege(activeRoles []*auth.RoleIdentity, user, host, privName string, withGrant bool) bool {
@@ -1123,6 +1187,27 @@ func (p *MySQLPrivilege) RequestVerification(activeRoles []*auth.RoleIdentity, u
 	return priv == 0
 }
 
+// RequestRoutineVerification checks whether the user have sufficient privileges
+// to do the operation on the stored routine, the privileges granted on the
+// routine are checked besides the global and db scope privileges.
+func (p *MySQLPrivilege) RequestRoutineVerification(activeRoles []*auth.RoleIdentity, user, host, db, routine, routineType string, priv mysql.PrivilegeType) bool {
+	if p.RequestVerification(activeRoles, user, host, db, "", "", priv) {
+		return true
+	}
+
+	roleList := p.FindAllUserEffectiveRoles(user, host, activeRoles)
+	roleList = append(roleList, &auth.RoleIdentity{Username: user, Hostname: host})
+
+	var procPriv mysql.PrivilegeType
+	for _, r := range roleList {
+		procRecord := p.matchProcs(r.Username, r.Hostname, db, routine, routineType)
+		if procRecord != nil {
+			procPriv |= procRecord.ProcPriv
+		}
+	}
+	return procPriv&priv > 0
+}
+
 // DBIsVisible checks whether the user can see the db.
 func (p *MySQLPrivilege) DBIsVisible(user, host, db string) bool {
 	if record := p.matchUser(user, host); record != nil {
@@ -1164,6 +1249,15 @@ func (p *MySQLPrivilege) DBIsVisible(user, host, db string) bool {
 		}
 	}
 
+	for _, record := range p.ProcsPriv {
+		if record.baseRecord.match(user, host) &&
+			strings.EqualFold(record.DB, db) {
+			if record.ProcPriv != 0 {
+				return true
+			}
+		}
+	}
+
 	return false
 }
 
@@ -1312,6 +1406,39 @@ func (p *MySQLPrivilege) showGrants(user, host string, roles []*auth.RoleIdentit
 	}
 	sort.Strings(gs[sortFromIdx:])
 
+	// Show stored routine scope grants.
+	sortFromIdx = len(gs)
+	procPrivTable := make(map[string]mysql.PrivilegeType)
+	for _, record := range p.ProcsPriv {
+		recordKey := record.RoutineType + " " + record.DB + "." + record.RoutineName
+		if user == record.User && host == record.Host {
+			procPrivTable[recordKey] |= record.ProcPriv
+		} else {
+			for _, r := range allRoles {
+				if record.baseRecord.match(r.Username, r.Hostname) {
+					procPrivTable[recordKey] |= record.ProcPriv
+				}
+			}
+		}
+	}
+	for k, priv := range procPrivTable {
+		g := routinePrivToString(priv)
+		if len(g) == 0 {
+			if (priv & mysql.GrantPriv) == 0 {
+				continue
+			}
+			g = "USAGE"
+		}
+		var s string
+		if (priv & mysql.GrantPriv) > 0 {
+			s = fmt.Sprintf(`GRANT %s ON %s TO '%s'@'%s' WITH GRANT OPTION`, g, k, user, host)
+		} else {
+			s = fmt.Sprintf(`GRANT %s ON %s TO '%s'@'%s'`, g, k, user, host)
+		}
+		gs = append(gs, s)
+	}
+	sort.Strings(gs[sortFromIdx:])
+
 	// Show role grants.
 	graphKey := user + "@" + host
 	edgeTable, ok := p.RoleGraph[graphKey]
@@ -1453,6 +1580,13 @@ func tablePrivToString(privs mysql.PrivilegeType) string {
 	return PrivToString(privs, mysql.AllTablePrivs, mysql.Priv2Str)
 }
 
+func routinePrivToString(privs mysql.PrivilegeType) string {
+	if (privs & ^mysql.GrantPriv) == routinePrivMask {
+		return mysql.AllPrivilegeLiteral
+	}
+	return PrivToString(privs, mysql.AllRoutinePrivs, mysql.Priv2Str)
+}
+
 // PrivToString converts the privileges to string.
 func PrivToString(priv mysql.PrivilegeType, allPrivs []mysql.PrivilegeType, allPrivNames map[mysql.PrivilegeType]string) string {
 	pstrs := make([]string, 0, 20)
@@ -86,6 +86,7 @@ import (
 	"github.com/pingcap/tidb/util/hack"
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/memory"
+	"github.com/pingcap/tidb/util/sqlexec"
 	topsqlstate "github.com/pingcap/tidb/util/topsql/state"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tikv/client-go/v2/util"
@@ -195,6 +196,7 @@ type clientConn struct {
 	rsEncoder     *resultEncoder    // rsEncoder is used to encode the string result to different charsets.
 	inputDecoder  *inputDecoder     // inputDecoder is used to decode the different charsets of incoming strings to utf-8.
 	socketCredUID uint32            // UID from the other end of the Unix Socket
+	zstdLevel     int               // zstd compression level requested by the client
 	// mu is used for cancelling the execution of current transaction.
 	mu struct {
 		sync.RWMutex
@@ -290,7 +292,7 @@ func (cc *clientConn) handshake(ctx context.Context) error {
 	}
 
 	err := cc.writePacket(data)
-	cc.pkt.sequence = 0
+	cc.pkt.resetSequence()
 	if err != nil {
 		err = errors.SuspendStack(err)
 		logutil.Logger(ctx).Debug("write response to client failed", zap.Error(err))
@@ -303,6 +305,13 @@ func (cc *clientConn) handshake(ctx context.Context) error {
 		logutil.Logger(ctx).Debug("flush response to client failed", zap.Error(err))
 		return err
 	}
+
+	// The compressed protocol is used after the OK packet of the authentication.
+	if cc.capability&mysql.ClientZstdCompressionAlgorithm > 0 {
+		cc.pkt.setCompression(compressionZstd, cc.zstdLevel)
+	} else if cc.capability&mysql.ClientCompress > 0 {
+		cc.pkt.setCompression(compressionZlib, 0)
+	}
 	return err
 }
 
@@ -429,6 +438,7 @@ type handshakeResponse41 struct {
 	Auth       []byte
 	AuthPlugin string
 	Attrs      map[string]string
+	ZstdLevel  int
 }
 
 // parseOldHandshakeResponseHeader parses the old version handshake header HandshakeResponse320
@@ -575,12 +585,20 @@ func parseHandshakeResponseBody(ctx context.Context, packet *handshakeResponse41
 		if num, null, off := parseLengthEncodedInt(data[offset:]); !null {
 			offset += off
 			row := data[offset : offset+int(num)]
+			offset += int(num)
 			attrs, err := parseAttrs(row)
 			if err != nil {
 				logutil.Logger(ctx).Warn("parse attrs failed", zap.Error(err))
-				return nil
+			} else {
+				packet.Attrs = attrs
 			}
-			packet.Attrs = attrs
+		}
+	}
+
+	if packet.Capability&mysql.ClientZstdCompressionAlgorithm > 0 {
+		// The zstd compression level is optional, the default level is used if it's absent.
+		if len(data[offset:]) > 0 {
+			packet.ZstdLevel = int(data[offset])
 		}
 	}
 
@@ -688,6 +706,7 @@ func (cc *clientConn) readOptionalSSLRequestAndHandshakeResponse(ctx context.Con
 	cc.dbname = resp.DBName
 	cc.collation = resp.Collation
 	cc.attrs = resp.Attrs
+	cc.zstdLevel = resp.ZstdLevel
 
 	err = cc.handleAuthPlugin(ctx, &resp)
 	if err != nil {
@@ -1134,7 +1153,7 @@ func (cc *clientConn) Run(ctx context.Context) {
 			terror.Log(err1)
 		}
 		cc.addMetrics(data[0], startTime, err)
-		cc.pkt.sequence = 0
+		cc.pkt.resetSequence()
 	}
 }
 
@@ -1490,6 +1509,9 @@ func (cc *clientConn) writeError(ctx context.Context, e error) error {
 		switch y := e.(type) {
 		case *terror.Error:
 			m = terror.ToSQLError(y)
+		case *mysql.SQLError:
+			// Raised by the SIGNAL statement in stored procedures.
+			m = y
 		default:
 			m = mysql.NewErrf(mysql.ErrUnknown, "%s", nil, e.Error())
 		}
@@ -1971,11 +1993,28 @@ func (cc *clientConn) prefetchPointPlanKeys(ctx context.Context, stmts []ast.Stm
 	return pointPlans, nil
 }
 
+// withCallResultHandler returns a context which writes the result sets
+// returned by a stored procedure to the client before the final OK packet.
+func (cc *clientConn) withCallResultHandler(ctx context.Context) context.Context {
+	if cc.capability&mysql.ClientMultiResults == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, session.CallResultHandlerKey, session.CallResultHandler(func(ctx context.Context, rs sqlexec.RecordSet) error {
+		status := cc.ctx.Status() | mysql.ServerMoreResultsExists
+		_, err := cc.writeResultset(ctx, &tidbResultSet{recordSet: rs}, false, status, 0)
+		return err
+	}))
+}
+
 // The first return value indicates whether the call of handleStmt has no side effect and can be retried.
 // Currently, the first return value is used to fall back to TiKV when TiFlash is down.
 func (cc *clientConn) handleStmt(ctx context.Context, stmt ast.StmtNode, warns []stmtctx.SQLWarn, lastStmt bool) (bool, error) {
 	ctx = context.WithValue(ctx, execdetails.StmtExecDetailKey, &execdetails.StmtExecDetails{})
 	ctx = context.WithValue(ctx, util.ExecDetailsKey, &util.ExecDetails{})
+	switch stmt.(type) {
+	case *ast.CallStmt, *ast.ExecuteStmt:
+		ctx = cc.withCallResultHandler(ctx)
+	}
 	reg := trace.StartRegion(ctx, "ExecuteStmt")
 	cc.audit(plugin.Starting)
 	rs, err := cc.ctx.ExecuteStmt(ctx, stmt)
@@ -224,6 +224,15 @@ func (cc *clientConn) handleStmtExecute(ctx context.Context, data []byte) (err e
 // The first return value indicates whether the call of executePreparedStmtAndWriteResult has no side effect and can be retried.
 // Currently the first return value is used to fallback to TiKV when TiFlash is down.
 func (cc *clientConn) executePreparedStmtAndWriteResult(ctx context.Context, stmt PreparedStatement, args []types.Datum, useCursor bool) (bool, error) {
+	if useCursor {
+		limit := cc.ctx.GetSessionVars().MaxOpenCursors
+		if limit > 0 && cc.ctx.openCursorCount(stmt.ID()) >= limit {
+			return true, errTooManyOpenCursors.GenWithStackByArgs(limit)
+		}
+	}
+	if !useCursor {
+		ctx = cc.withCallResultHandler(ctx)
+	}
 	rs, err := stmt.Execute(ctx, args)
 	if err != nil {
 		return true, errors.Annotate(err, cc.preparedStmt2String(uint32(stmt.ID())))
@@ -236,6 +245,13 @@ func (cc *clientConn) executePreparedStmtAndWriteResult(ctx context.Context, stm
 	// we should hold the ResultSet in PreparedStatement for next stmt_fetch, and only send back ColumnInfo.
 	// Tell the client cursor exists in server by setting proper serverStatus.
 	if useCursor {
+		// Read the rows into a row container, so the executors can be released before the rows are fetched.
+		if trs, ok := rs.(*tidbResultSet); ok {
+			rs, err = newCursorResultSet(ctx, cc.ctx.GetSessionVars(), trs)
+			if err != nil {
+				return true, errors.Annotate(err, cc.preparedStmt2String(uint32(stmt.ID())))
+			}
+		}
 		cc.initResultEncoder(ctx)
 		defer cc.rsEncoder.clean()
 		stmt.StoreResultSet(rs)
@@ -79,6 +79,19 @@ func TestParseHandshakeResponse(t *testing.T) {
 		"_pid":            "22344"})
 	require.True(t, eq)
 
+	// The zstd compression level follows the connection attributes.
+	zstdData := append([]byte{}, data...)
+	zstdData[3] |= byte(mysql.ClientZstdCompressionAlgorithm >> 24)
+	zstdData = append(zstdData, 0x07)
+	p = handshakeResponse41{}
+	offset, err = parseHandshakeResponseHeader(context.Background(), &p, zstdData)
+	require.NoError(t, err)
+	require.Equal(t, mysql.ClientZstdCompressionAlgorithm, p.Capability&mysql.ClientZstdCompressionAlgorithm)
+	err = parseHandshakeResponseBody(context.Background(), &p, zstdData, offset)
+	require.NoError(t, err)
+	require.Equal(t, 7, p.ZstdLevel)
+	require.Equal(t, "bar", p.Attrs["foo"])
+
 	data = []byte{
 		0x8d, 0xa6, 0x0f, 0x00, 0x00, 0x00, 0x00, 0x01, 0x08, 0x00, 0x00, 0x00,
 		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -211,6 +224,29 @@ func TestInitialHandshake(t *testing.T) {
 	require.Equal(t, expected.Bytes(), outBuffer.Bytes()[4:])
 }
 
+func TestProtocolCompressionCapability(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	cfg := newTestConfig()
+	cfg.Port = 0
+	cfg.Status.StatusPort = 0
+	drv := NewTiDBDriver(store)
+	srv, err := NewServer(cfg, drv)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), srv.capability&compressionCapability)
+	srv.Close()
+
+	cfg = newTestConfig()
+	cfg.Port = 0
+	cfg.Status.StatusPort = 0
+	cfg.EnableProtocolCompression = true
+	srv, err = NewServer(cfg, drv)
+	require.NoError(t, err)
+	require.Equal(t, compressionCapability, srv.capability&compressionCapability)
+	srv.Close()
+}
+
 type dispatchInput struct {
 	com byte
 	in  []byte
@@ -774,8 +810,10 @@ func TestTiFlashFallback(t *testing.T) {
 	tk.MustQuery("show warnings").Check(testkit.Rows("Error 9012 TiFlash server timeout"))
 
 	// test COM_STMT_FETCH (cursor mode)
+	// The rows of a cursor are read when executing, so the execution falls back to TiKV.
 	require.NoError(t, cc.handleStmtExecute(ctx, []byte{0x1, 0x0, 0x0, 0x0, 0x1, 0x1, 0x0, 0x0, 0x0}))
-	require.Error(t, cc.handleStmtFetch(ctx, []byte{0x1, 0x0, 0x0, 0x0, 0x1, 0x0, 0x0, 0x0}))
+	tk.MustQuery("show warnings").Check(testkit.Rows("Error 9012 TiFlash server timeout"))
+	require.NoError(t, cc.handleStmtFetch(ctx, []byte{0x1, 0x0, 0x0, 0x0, 0x1, 0x0, 0x0, 0x0}))
 	tk.MustExec("set @@tidb_allow_fallback_to_tikv=''")
 	require.Error(t, cc.handleStmtExecute(ctx, []byte{0x1, 0x0, 0x0, 0x0, 0x0, 0x1, 0x0, 0x0, 0x0}))
 	require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/store/mockstore/unistore/BatchCopRpcErrtiflash0"))
@@ -0,0 +1,163 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/config"
+	"github.com/pingcap/tidb/executor"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/memory"
+)
+
+// cursorResultSet is the ResultSet of a server-side cursor. All the rows of the
+// statement are read into a row container when the cursor is opened, so the
+// executors and the snapshot of the statement are released before the client
+// fetches the rows. The memory of the rows is charged to the session, the row
+// container spills to disk when its memory usage exceeds the memory quota of a
+// query, and each chunk is released once it's read to be sent to the client.
+type cursorResultSet struct {
+	columns      []*ColumnInfo
+	fieldTypes   []*types.FieldType
+	rowContainer *chunk.RowContainer
+	chunkSize    int
+	// chkIdx is the index of the next chunk in the row container to be read.
+	chkIdx int
+	rows   []chunk.Row
+	closed bool
+}
+
+// newCursorResultSet reads all the rows of rs into a new cursorResultSet and closes rs.
+func newCursorResultSet(ctx context.Context, vars *variable.SessionVars, rs *tidbResultSet) (_ *cursorResultSet, err error) {
+	defer func() {
+		terror.Call(rs.Close)
+	}()
+	fields := rs.recordSet.Fields()
+	fieldTypes := make([]*types.FieldType, 0, len(fields))
+	for _, field := range fields {
+		fieldTypes = append(fieldTypes, &field.Column.FieldType)
+	}
+	rowContainer := chunk.NewRowContainer(fieldTypes, vars.MaxChunkSize)
+	memTracker := rowContainer.GetMemTracker()
+	memTracker.SetLabel(memory.LabelForCursorFetch)
+	memTracker.SetBytesLimit(vars.MemQuotaQuery)
+	memTracker.AttachTo(vars.MemTracker)
+	if config.GetGlobalConfig().OOMUseTmpStorage {
+		memTracker.FallbackOldAndSetNewAction(rowContainer.ActionSpill())
+		diskTracker := rowContainer.GetDiskTracker()
+		diskTracker.SetLabel(memory.LabelForCursorFetch)
+		diskTracker.AttachTo(executor.GlobalDiskUsageTracker)
+	}
+	crs := &cursorResultSet{
+		fieldTypes:   fieldTypes,
+		rowContainer: rowContainer,
+		chunkSize:    vars.MaxChunkSize,
+	}
+	defer func() {
+		if err != nil {
+			terror.Call(crs.Close)
+		}
+	}()
+	for {
+		// The chunk is kept by the row container, so it can't be reused.
+		chk := rs.NewChunk(nil)
+		if err = rs.Next(ctx, chk); err != nil {
+			return nil, err
+		}
+		if chk.NumRows() == 0 {
+			break
+		}
+		if err = rowContainer.Add(chk); err != nil {
+			return nil, err
+		}
+	}
+	// The columns are got after calling Next, see writeChunks.
+	crs.columns = rs.Columns()
+	// The statement is finished, log it before closing the record set.
+	rs.OnFetchReturned()
+	return crs, nil
+}
+
+func (crs *cursorResultSet) Columns() []*ColumnInfo {
+	return crs.columns
+}
+
+func (crs *cursorResultSet) NewChunk(alloc chunk.Allocator) *chunk.Chunk {
+	if alloc == nil {
+		return chunk.New(crs.fieldTypes, crs.chunkSize, crs.chunkSize)
+	}
+	return alloc.Alloc(crs.fieldTypes, crs.chunkSize, crs.chunkSize)
+}
+
+// Next reads the next chunk of the rows in the row container, the chunk is
+// copied to req and released from the row container.
+func (crs *cursorResultSet) Next(_ context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if crs.closed || crs.chkIdx >= crs.rowContainer.NumChunks() {
+		return nil
+	}
+	chk, err := crs.rowContainer.GetChunk(crs.chkIdx)
+	if err != nil {
+		return err
+	}
+	req.Append(chk, 0, chk.NumRows())
+	crs.rowContainer.ReleaseChunk(crs.chkIdx)
+	crs.chkIdx++
+	return nil
+}
+
+func (crs *cursorResultSet) StoreFetchedRows(rows []chunk.Row) {
+	crs.rows = rows
+}
+
+func (crs *cursorResultSet) GetFetchedRows() []chunk.Row {
+	if crs.rows == nil {
+		crs.rows = make([]chunk.Row, 0, 1024)
+	}
+	return crs.rows
+}
+
+// Close releases the memory and the disk used by the row container.
+func (crs *cursorResultSet) Close() error {
+	if crs.closed {
+		return nil
+	}
+	crs.closed = true
+	crs.rows = nil
+	err := crs.rowContainer.Close()
+	crs.rowContainer.GetMemTracker().Detach()
+	crs.rowContainer.GetDiskTracker().Detach()
+	return err
+}
+
+// openCursorCount returns the number of the open cursors of the connection,
+// except the cursor of the statement stmtID, which is closed before a new
+// cursor is opened for the statement.
+func (tc *TiDBContext) openCursorCount(stmtID int) int {
+	count := 0
+	for id, stmt := range tc.stmts {
+		if id == stmtID {
+			continue
+		}
+		if crs, ok := stmt.GetResultSet().(*cursorResultSet); ok && !crs.closed {
+			count++
+		}
+	}
+	return count
+}
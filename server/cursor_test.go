@@ -0,0 +1,165 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/config"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/pingcap/tidb/util/arena"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorFetch(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	cc := &clientConn{
+		alloc:      arena.NewAllocator(1024),
+		chunkAlloc: chunk.NewAllocator(),
+		pkt: &packetIO{
+			bufWriter: bufio.NewWriter(bytes.NewBuffer(nil)),
+		},
+	}
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	cc.ctx = &TiDBContext{Session: tk.Session(), stmts: make(map[int]*TiDBStatement)}
+
+	tk.MustExec("create table t(a int primary key, b varchar(32))")
+	for i := 0; i < 100; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t values (%d, 'abcdefghijklmnopqrstuvwxyz')", i))
+	}
+
+	ctx := context.Background()
+	require.NoError(t, cc.handleStmtPrepare(ctx, "select * from t"))
+	require.NoError(t, cc.handleStmtPrepare(ctx, "select * from t where a < 10"))
+
+	// The rows are read into the cursor when executing.
+	tk.MustExec("set @@tidb_max_open_cursors = 1")
+	require.NoError(t, cc.handleStmtExecute(ctx, []byte{0x1, 0x0, 0x0, 0x0, 0x1, 0x1, 0x0, 0x0, 0x0}))
+	crs, ok := cc.ctx.GetStatement(1).GetResultSet().(*cursorResultSet)
+	require.True(t, ok)
+	require.Equal(t, 100, crs.rowContainer.NumRow())
+	require.Len(t, crs.Columns(), 2)
+
+	// The number of the open cursors is limited.
+	err := cc.handleStmtExecute(ctx, []byte{0x2, 0x0, 0x0, 0x0, 0x1, 0x1, 0x0, 0x0, 0x0})
+	require.True(t, errTooManyOpenCursors.Equal(err))
+	// Executing the statement again replaces its own cursor.
+	require.NoError(t, cc.handleStmtExecute(ctx, []byte{0x1, 0x0, 0x0, 0x0, 0x1, 0x1, 0x0, 0x0, 0x0}))
+	require.True(t, crs.closed)
+	crs = cc.ctx.GetStatement(1).GetResultSet().(*cursorResultSet)
+
+	// The memory of the rows is charged to the session.
+	require.NotZero(t, crs.rowContainer.GetMemTracker().BytesConsumed())
+	require.Equal(t, crs.rowContainer.GetMemTracker().BytesConsumed(), tk.Session().GetSessionVars().MemTracker.BytesConsumed())
+
+	// The chunks are released from the row container once they are read to be sent.
+	consumed := crs.rowContainer.GetMemTracker().BytesConsumed()
+	require.NoError(t, cc.handleStmtFetch(ctx, []byte{0x1, 0x0, 0x0, 0x0, 0x40, 0x0, 0x0, 0x0}))
+	require.NotZero(t, crs.chkIdx)
+	require.Less(t, crs.rowContainer.GetMemTracker().BytesConsumed(), consumed)
+	require.Equal(t, crs.rowContainer.GetMemTracker().BytesConsumed(), tk.Session().GetSessionVars().MemTracker.BytesConsumed())
+
+	// The cursor is closed after all the rows are fetched.
+	require.False(t, crs.closed)
+	require.NoError(t, cc.handleStmtFetch(ctx, []byte{0x1, 0x0, 0x0, 0x0, 0x40, 0x0, 0x0, 0x0}))
+	require.False(t, crs.closed)
+	require.NoError(t, cc.handleStmtFetch(ctx, []byte{0x1, 0x0, 0x0, 0x0, 0x40, 0x0, 0x0, 0x0}))
+	require.True(t, crs.closed)
+	require.Zero(t, tk.Session().GetSessionVars().MemTracker.BytesConsumed())
+	require.NoError(t, cc.handleStmtExecute(ctx, []byte{0x2, 0x0, 0x0, 0x0, 0x1, 0x1, 0x0, 0x0, 0x0}))
+
+	tk.MustExec("set @@tidb_max_open_cursors = 0")
+	require.NoError(t, cc.handleStmtExecute(ctx, []byte{0x1, 0x0, 0x0, 0x0, 0x1, 0x1, 0x0, 0x0, 0x0}))
+	require.NoError(t, cc.ctx.GetStatement(1).Close())
+	require.NoError(t, cc.ctx.GetStatement(2).Close())
+
+	// The rows of a cursor spill to disk when exceeding the memory quota.
+	defer config.RestoreFunc()()
+	config.UpdateGlobal(func(conf *config.Config) {
+		conf.OOMUseTmpStorage = true
+	})
+	tk.MustExec("set @@tidb_mem_quota_query = 4500")
+	tk.MustExec("set @@tidb_max_chunk_size = 32")
+	require.NoError(t, cc.handleStmtPrepare(ctx, "select * from t"))
+	require.NoError(t, cc.handleStmtExecute(ctx, []byte{0x3, 0x0, 0x0, 0x0, 0x1, 0x1, 0x0, 0x0, 0x0}))
+	crs = cc.ctx.GetStatement(3).GetResultSet().(*cursorResultSet)
+	require.Eventually(t, crs.rowContainer.AlreadySpilledSafeForTest, time.Second, 10*time.Millisecond)
+	require.NoError(t, cc.handleStmtFetch(ctx, []byte{0x3, 0x0, 0x0, 0x0, 0xff, 0x0, 0x0, 0x0}))
+	require.NoError(t, cc.handleStmtFetch(ctx, []byte{0x3, 0x0, 0x0, 0x0, 0xff, 0x0, 0x0, 0x0}))
+	require.True(t, crs.closed)
+}
+
+func TestConcurrentCursors(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	cc := &clientConn{
+		alloc:      arena.NewAllocator(1024),
+		chunkAlloc: chunk.NewAllocator(),
+		pkt: &packetIO{
+			bufWriter: bufio.NewWriter(bytes.NewBuffer(nil)),
+		},
+	}
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	cc.ctx = &TiDBContext{Session: tk.Session(), stmts: make(map[int]*TiDBStatement)}
+
+	tk.MustExec("create table t(a int primary key)")
+	tk.MustExec("insert into t values (1), (2), (3)")
+
+	ctx := context.Background()
+	require.NoError(t, cc.handleStmtPrepare(ctx, "select * from t"))
+	require.NoError(t, cc.handleStmtPrepare(ctx, "select * from t where a > 1"))
+
+	// The executor of each cursor is closed after executing, which finishes
+	// the statement and commits its transaction.
+	isTxnValid := func() bool {
+		txn, err := tk.Session().Txn(false)
+		require.NoError(t, err)
+		return txn.Valid()
+	}
+	require.NoError(t, cc.handleStmtExecute(ctx, []byte{0x1, 0x0, 0x0, 0x0, 0x1, 0x1, 0x0, 0x0, 0x0}))
+	require.False(t, isTxnValid())
+	require.NoError(t, cc.handleStmtExecute(ctx, []byte{0x2, 0x0, 0x0, 0x0, 0x1, 0x1, 0x0, 0x0, 0x0}))
+	require.False(t, isTxnValid())
+	crs1 := cc.ctx.GetStatement(1).GetResultSet().(*cursorResultSet)
+	crs2 := cc.ctx.GetStatement(2).GetResultSet().(*cursorResultSet)
+	require.Equal(t, 3, crs1.rowContainer.NumRow())
+	require.Equal(t, 2, crs2.rowContainer.NumRow())
+
+	// The rows read by the cursors are not affected by the later changes.
+	tk.MustExec("delete from t")
+	require.NoError(t, cc.handleStmtFetch(ctx, []byte{0x2, 0x0, 0x0, 0x0, 0x1, 0x0, 0x0, 0x0}))
+	require.NoError(t, cc.handleStmtFetch(ctx, []byte{0x1, 0x0, 0x0, 0x0, 0x1, 0x0, 0x0, 0x0}))
+	require.NoError(t, cc.handleStmtFetch(ctx, []byte{0x2, 0x0, 0x0, 0x0, 0x1, 0x0, 0x0, 0x0}))
+	require.NoError(t, cc.handleStmtFetch(ctx, []byte{0x1, 0x0, 0x0, 0x0, 0xff, 0x0, 0x0, 0x0}))
+	require.False(t, crs1.closed)
+	require.False(t, crs2.closed)
+	// The cursors are closed by the fetches after all the rows are fetched.
+	require.NoError(t, cc.handleStmtFetch(ctx, []byte{0x2, 0x0, 0x0, 0x0, 0x1, 0x0, 0x0, 0x0}))
+	require.True(t, crs2.closed)
+	require.NoError(t, cc.handleStmtFetch(ctx, []byte{0x1, 0x0, 0x0, 0x0, 0x1, 0x0, 0x0, 0x0}))
+	require.True(t, crs1.closed)
+	require.Zero(t, tk.Session().GetSessionVars().MemTracker.BytesConsumed())
+}
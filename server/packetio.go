@@ -37,28 +37,107 @@ package server
 
 import (
 	"bufio"
+	"bytes"
+	"compress/zlib"
 	"io"
+	"sync"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/metrics"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 const defaultWriterSize = 16 * 1024
 
+// Compression algorithms of the compressed protocol.
+const (
+	compressionNone = iota
+	compressionZlib
+	compressionZstd
+)
+
+const (
+	// compressedHeaderSize is the size of the header of a compressed packet, which is made up of the 3-byte
+	// length of the payload, the 1-byte compressed sequence and the 3-byte length of the payload before
+	// compression.
+	compressedHeaderSize = 7
+	// minCompressLength is the minimal length of the payload to be compressed, shorter payloads are sent as
+	// they are. It is the same as MySQL.
+	minCompressLength = 50
+	// defaultZstdCompressionLevel is the zstd compression level used when the client doesn't specify a valid one.
+	defaultZstdCompressionLevel = 3
+	maxZstdCompressionLevel     = 22
+)
+
 var (
 	readPacketBytes  = metrics.PacketIOCounter.WithLabelValues("read")
 	writePacketBytes = metrics.PacketIOCounter.WithLabelValues("write")
 )
 
-// packetIO is a helper to read and write data in packet format.
+var (
+	zstdDecoderOnce sync.Once
+	zstdDecoder     *zstd.Decoder
+	zstdDecoderErr  error
+
+	zstdEncoders struct {
+		sync.Mutex
+		m map[zstd.EncoderLevel]*zstd.Encoder
+	}
+)
+
+// getZstdDecoder returns the decoder shared by all connections, DecodeAll can be called concurrently.
+func getZstdDecoder() (*zstd.Decoder, error) {
+	zstdDecoderOnce.Do(func() {
+		zstdDecoder, zstdDecoderErr = zstd.NewReader(nil, zstd.WithDecoderMaxMemory(mysql.MaxPayloadLen))
+	})
+	return zstdDecoder, zstdDecoderErr
+}
+
+// getZstdEncoder returns the encoder of the level shared by all connections, EncodeAll can be called concurrently.
+func getZstdEncoder(level int) (*zstd.Encoder, error) {
+	encoderLevel := zstd.EncoderLevelFromZstd(level)
+	zstdEncoders.Lock()
+	defer zstdEncoders.Unlock()
+	if enc, ok := zstdEncoders.m[encoderLevel]; ok {
+		return enc, nil
+	}
+	enc, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(encoderLevel))
+	if err != nil {
+		return nil, err
+	}
+	if zstdEncoders.m == nil {
+		zstdEncoders.m = make(map[zstd.EncoderLevel]*zstd.Encoder)
+	}
+	zstdEncoders.m[encoderLevel] = enc
+	return enc, nil
+}
+
 type packetIO struct {
 	bufReadConn *bufferedReadConn
 	bufWriter   *bufio.Writer
 	sequence    uint8
 	readTimeout time.Duration
+
+	// The following fields are used by the compressed protocol.
+	compressionAlgorithm int
+	zstdLevel            int
+	compressedSequence   uint8
+	// compressedReadBuf holds the decompressed data which hasn't been read.
+	compressedReadBuf []byte
+	// compressedWriteBuf holds the written packets which haven't been compressed.
+	compressedWriteBuf bytes.Buffer
+	compressBuf        []byte
+	zlibReader         io.ReadCloser
+	zlibWriter         *zlib.Writer
+
+	readCompressedBytes  prometheus.Counter
+	readRawBytes         prometheus.Counter
+	writeCompressedBytes prometheus.Counter
+	writeRawBytes        prometheus.Counter
 }
 
 func newPacketIO(bufReadConn *bufferedReadConn) *packetIO {
@@ -76,6 +155,120 @@ func (p *packetIO) setReadTimeout(timeout time.Duration) {
 	p.readTimeout = timeout
 }
 
+// setCompression makes the following packets use the compressed protocol with the negotiated algorithm.
+// The zstd level is ignored by zlib.
+func (p *packetIO) setCompression(algorithm int, zstdLevel int) {
+	p.compressionAlgorithm = algorithm
+	p.zstdLevel = zstdLevel
+	if zstdLevel < 1 || zstdLevel > maxZstdCompressionLevel {
+		p.zstdLevel = defaultZstdCompressionLevel
+	}
+	name := "zlib"
+	if algorithm == compressionZstd {
+		name = "zstd"
+	}
+	p.readCompressedBytes = metrics.CompressedPacketIOCounter.WithLabelValues("read", name, "compressed")
+	p.readRawBytes = metrics.CompressedPacketIOCounter.WithLabelValues("read", name, "raw")
+	p.writeCompressedBytes = metrics.CompressedPacketIOCounter.WithLabelValues("write", name, "compressed")
+	p.writeRawBytes = metrics.CompressedPacketIOCounter.WithLabelValues("write", name, "raw")
+}
+
+// resetSequence resets the sequences at the beginning of a command.
+func (p *packetIO) resetSequence() {
+	p.sequence = 0
+	p.compressedSequence = 0
+}
+
+// readFull reads exactly len(buf) bytes from the connection, the data is decompressed if the compressed
+// protocol is used.
+func (p *packetIO) readFull(buf []byte) error {
+	if p.compressionAlgorithm == compressionNone {
+		_, err := io.ReadFull(p.bufReadConn, buf)
+		return errors.Trace(err)
+	}
+	for len(buf) > 0 {
+		if len(p.compressedReadBuf) == 0 {
+			if err := p.readCompressedPacket(); err != nil {
+				return err
+			}
+			continue
+		}
+		n := copy(buf, p.compressedReadBuf)
+		p.compressedReadBuf = p.compressedReadBuf[n:]
+		buf = buf[n:]
+	}
+	return nil
+}
+
+func (p *packetIO) readCompressedPacket() error {
+	var header [compressedHeaderSize]byte
+	if _, err := io.ReadFull(p.bufReadConn, header[:]); err != nil {
+		return errors.Trace(err)
+	}
+
+	sequence := header[3]
+	if sequence != p.compressedSequence {
+		return errInvalidSequence.GenWithStack("invalid compressed sequence %d != %d", sequence, p.compressedSequence)
+	}
+	p.compressedSequence++
+
+	length := int(uint32(header[0]) | uint32(header[1])<<8 | uint32(header[2])<<16)
+	rawLength := int(uint32(header[4]) | uint32(header[5])<<8 | uint32(header[6])<<16)
+	data := make([]byte, length)
+	if _, err := io.ReadFull(p.bufReadConn, data); err != nil {
+		return errors.Trace(err)
+	}
+	p.readCompressedBytes.Add(float64(compressedHeaderSize + length))
+
+	// The raw length is 0 if the payload isn't compressed.
+	if rawLength > 0 {
+		var err error
+		data, err = p.decompress(data, rawLength)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	p.readRawBytes.Add(float64(len(data)))
+	p.compressedReadBuf = data
+	return nil
+}
+
+func (p *packetIO) decompress(data []byte, rawLength int) ([]byte, error) {
+	if p.compressionAlgorithm == compressionZstd {
+		decoder, err := getZstdDecoder()
+		if err != nil {
+			return nil, err
+		}
+		raw, err := decoder.DecodeAll(data, make([]byte, 0, rawLength))
+		if err != nil {
+			return nil, err
+		}
+		if len(raw) != rawLength {
+			return nil, mysql.ErrMalformPacket
+		}
+		return raw, nil
+	}
+
+	var err error
+	if p.zlibReader == nil {
+		p.zlibReader, err = zlib.NewReader(bytes.NewReader(data))
+	} else {
+		err = p.zlibReader.(zlib.Resetter).Reset(bytes.NewReader(data), nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	raw := make([]byte, rawLength)
+	if _, err = io.ReadFull(p.zlibReader, raw); err != nil {
+		return nil, err
+	}
+	// Make sure there is no more data than claimed by the header.
+	if n, _ := p.zlibReader.Read(make([]byte, 1)); n > 0 {
+		return nil, mysql.ErrMalformPacket
+	}
+	return raw, nil
+}
+
 func (p *packetIO) readOnePacket() ([]byte, error) {
 	var header [4]byte
 	if p.readTimeout > 0 {
@@ -83,16 +276,18 @@ func (p *packetIO) readOnePacket() ([]byte, error) {
 			return nil, err
 		}
 	}
-	if _, err := io.ReadFull(p.bufReadConn, header[:]); err != nil {
+	if err := p.readFull(header[:]); err != nil {
 		return nil, errors.Trace(err)
 	}
 
 	sequence := header[3]
-	if sequence != p.sequence {
+	// The compressed sequence has been checked for the compressed protocol, and clients may not keep
+	// the sequence of the inner packets in sync with the server's.
+	if sequence != p.sequence && p.compressionAlgorithm == compressionNone {
 		return nil, errInvalidSequence.GenWithStack("invalid sequence %d != %d", sequence, p.sequence)
 	}
 
-	p.sequence++
+	p.sequence = sequence + 1
 
 	length := int(uint32(header[0]) | uint32(header[1])<<8 | uint32(header[2])<<16)
 
@@ -102,7 +297,7 @@ func (p *packetIO) readOnePacket() ([]byte, error) {
 			return nil, err
 		}
 	}
-	if _, err := io.ReadFull(p.bufReadConn, data); err != nil {
+	if err := p.readFull(data); err != nil {
 		return nil, errors.Trace(err)
 	}
 	return data, nil
@@ -147,6 +342,12 @@ func (p *packetIO) writePacket(data []byte) error {
 	length := len(data) - 4
 	writePacketBytes.Add(float64(len(data)))
 
+	var w io.Writer = p.bufWriter
+	if p.compressionAlgorithm != compressionNone {
+		// The packets are compressed when enough data is buffered or they are flushed.
+		w = &p.compressedWriteBuf
+	}
+
 	for length >= mysql.MaxPayloadLen {
 		data[0] = 0xff
 		data[1] = 0xff
@@ -154,7 +355,7 @@ func (p *packetIO) writePacket(data []byte) error {
 
 		data[3] = p.sequence
 
-		if n, err := p.bufWriter.Write(data[:4+mysql.MaxPayloadLen]); err != nil {
+		if n, err := w.Write(data[:4+mysql.MaxPayloadLen]); err != nil {
 			return errors.Trace(mysql.ErrBadConn)
 		} else if n != (4 + mysql.MaxPayloadLen) {
 			return errors.Trace(mysql.ErrBadConn)
@@ -170,18 +371,103 @@ func (p *packetIO) writePacket(data []byte) error {
 	data[2] = byte(length >> 16)
 	data[3] = p.sequence
 
-	if n, err := p.bufWriter.Write(data); err != nil {
+	if n, err := w.Write(data); err != nil {
 		terror.Log(errors.Trace(err))
 		return errors.Trace(mysql.ErrBadConn)
 	} else if n != len(data) {
 		return errors.Trace(mysql.ErrBadConn)
 	} else {
 		p.sequence++
-		return nil
 	}
+	if p.compressionAlgorithm != compressionNone && p.compressedWriteBuf.Len() >= defaultWriterSize {
+		return p.writeCompressedPackets()
+	}
+	return nil
+}
+
+// writeCompressedPackets compresses the buffered packets and writes them to the buffered writer.
+func (p *packetIO) writeCompressedPackets() error {
+	data := p.compressedWriteBuf.Bytes()
+	for len(data) > 0 {
+		n := len(data)
+		if n > mysql.MaxPayloadLen {
+			n = mysql.MaxPayloadLen
+		}
+		if err := p.writeCompressedPacket(data[:n]); err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+	p.compressedWriteBuf.Reset()
+	return nil
+}
+
+func (p *packetIO) writeCompressedPacket(raw []byte) error {
+	payload, rawLength := raw, 0
+	if len(raw) >= minCompressLength {
+		compressed, err := p.compress(raw)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// Send the raw payload if it can't be compressed.
+		if len(compressed) < len(raw) {
+			payload, rawLength = compressed, len(raw)
+		}
+	}
+
+	length := len(payload)
+	header := [compressedHeaderSize]byte{
+		byte(length), byte(length >> 8), byte(length >> 16),
+		p.compressedSequence,
+		byte(rawLength), byte(rawLength >> 8), byte(rawLength >> 16),
+	}
+	if _, err := p.bufWriter.Write(header[:]); err != nil {
+		terror.Log(errors.Trace(err))
+		return errors.Trace(mysql.ErrBadConn)
+	}
+	if _, err := p.bufWriter.Write(payload); err != nil {
+		terror.Log(errors.Trace(err))
+		return errors.Trace(mysql.ErrBadConn)
+	}
+	p.compressedSequence++
+	p.writeRawBytes.Add(float64(len(raw)))
+	p.writeCompressedBytes.Add(float64(compressedHeaderSize + length))
+	return nil
+}
+
+// compress compresses the data, the returned slice is only valid until the next call.
+func (p *packetIO) compress(raw []byte) ([]byte, error) {
+	if p.compressionAlgorithm == compressionZstd {
+		encoder, err := getZstdEncoder(p.zstdLevel)
+		if err != nil {
+			return nil, err
+		}
+		p.compressBuf = encoder.EncodeAll(raw, p.compressBuf[:0])
+		return p.compressBuf, nil
+	}
+
+	buf := bytes.NewBuffer(p.compressBuf[:0])
+	if p.zlibWriter == nil {
+		p.zlibWriter = zlib.NewWriter(buf)
+	} else {
+		p.zlibWriter.Reset(buf)
+	}
+	if _, err := p.zlibWriter.Write(raw); err != nil {
+		return nil, err
+	}
+	if err := p.zlibWriter.Close(); err != nil {
+		return nil, err
+	}
+	p.compressBuf = buf.Bytes()
+	return p.compressBuf, nil
 }
 
 func (p *packetIO) flush() error {
+	if p.compressionAlgorithm != compressionNone {
+		if err := p.writeCompressedPackets(); err != nil {
+			return err
+		}
+	}
 	err := p.bufWriter.Flush()
 	if err != nil {
 		return errors.Trace(err)
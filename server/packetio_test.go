@@ -86,6 +86,70 @@ func TestPacketIORead(t *testing.T) {
 	require.Equal(t, byte(0x0a), bytes[mysql.MaxPayloadLen])
 }
 
+func TestCompressedPacketIO(t *testing.T) {
+	for _, algorithm := range []int{compressionZlib, compressionZstd} {
+		var outBuffer bytes.Buffer
+		writer := &packetIO{bufWriter: bufio.NewWriter(&outBuffer)}
+		writer.setCompression(algorithm, 3)
+		small := []byte{0x00, 0x00, 0x00, 0x00, 0x01, 0x02, 0x03}
+		require.NoError(t, writer.writePacket(small))
+		require.NoError(t, writer.flush())
+		// The short payload isn't compressed.
+		require.Equal(t, []byte{0x07, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, 0x02, 0x03}, outBuffer.Bytes())
+		require.Equal(t, uint8(1), writer.compressedSequence)
+
+		// The large packets are split and compressed.
+		large := make([]byte, mysql.MaxPayloadLen+4+100)
+		for i := 4; i < len(large); i++ {
+			large[i] = byte(i % 10)
+		}
+		expected := append([]byte(nil), large[4:]...)
+		require.NoError(t, writer.writePacket(large))
+		medium := bytes.Repeat([]byte{'a'}, 4+defaultWriterSize)
+		require.NoError(t, writer.writePacket(medium))
+		require.NoError(t, writer.flush())
+		require.Less(t, outBuffer.Len(), len(expected))
+
+		reader := newPacketIO(newBufferedReadConn(&bytesConn{outBuffer}))
+		reader.setCompression(algorithm, 0)
+		data, err := reader.readPacket()
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x01, 0x02, 0x03}, data)
+		data, err = reader.readPacket()
+		require.NoError(t, err)
+		require.Equal(t, expected, data)
+		data, err = reader.readPacket()
+		require.NoError(t, err)
+		require.Equal(t, medium[4:], data)
+		require.Equal(t, writer.sequence, reader.sequence)
+		require.Equal(t, writer.compressedSequence, reader.compressedSequence)
+
+		// The compressed sequence is checked.
+		outBuffer.Reset()
+		writer.resetSequence()
+		require.NoError(t, writer.writePacket(small))
+		require.NoError(t, writer.flush())
+		reader = newPacketIO(newBufferedReadConn(&bytesConn{outBuffer}))
+		reader.setCompression(algorithm, 0)
+		reader.compressedSequence = 1
+		_, err = reader.readPacket()
+		require.Error(t, err)
+
+		// The sequence of the inner packets isn't checked, like MySQL, since some
+		// clients don't keep it in sync.
+		outBuffer.Reset()
+		writer.resetSequence()
+		require.NoError(t, writer.writePacket(small))
+		require.NoError(t, writer.flush())
+		reader = newPacketIO(newBufferedReadConn(&bytesConn{outBuffer}))
+		reader.setCompression(algorithm, 0)
+		reader.sequence = 1
+		data, err = reader.readPacket()
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x01, 0x02, 0x03}, data)
+	}
+}
+
 type bytesConn struct {
 	b bytes.Buffer
 }
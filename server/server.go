@@ -103,6 +103,7 @@ var (
 	errMultiStatementDisabled  = dbterror.ClassServer.NewStd(errno.ErrMultiStatementDisabled)
 	errNewAbortingConnection   = dbterror.ClassServer.NewStd(errno.ErrNewAbortingConnection)
 	errNotSupportedAuthMode    = dbterror.ClassServer.NewStd(errno.ErrNotSupportedAuthMode)
+	errTooManyOpenCursors      = dbterror.ClassServer.NewStd(errno.ErrTooManyOpenCursors)
 )
 
 // DefaultCapability is the capability of the server when it is created using the default configuration.
@@ -113,6 +114,10 @@ const defaultCapability = mysql.ClientLongPassword | mysql.ClientLongFlag |
 	mysql.ClientMultiStatements | mysql.ClientMultiResults | mysql.ClientLocalFiles |
 	mysql.ClientConnectAtts | mysql.ClientPluginAuth | mysql.ClientInteractive
 
+// compressionCapability is the capability of the compressed protocol, which is advertised only when
+// the config item `enable-protocol-compression` is on.
+const compressionCapability = mysql.ClientCompress | mysql.ClientZstdCompressionAlgorithm
+
 // Server is the MySQL protocol server
 type Server struct {
 	cfg               *config.Config
@@ -193,6 +198,9 @@ func NewServer(cfg *config.Config, driver IDriver) (*Server, error) {
 		globalConnID:      util.NewGlobalConnID(0, true),
 	}
 	s.capability = defaultCapability
+	if cfg.EnableProtocolCompression {
+		s.capability |= compressionCapability
+	}
 	setTxnScope()
 	setSystemTimeZoneVariable()
 
@@ -145,6 +145,17 @@ const (
 		Timestamp	TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 		Column_priv	SET('Select','Insert','Update','References'),
 		PRIMARY KEY (Host, DB, User, Table_name, Column_name));`
+	// CreateProcsPrivTable is the SQL statement creates stored routine scope privilege table in system db.
+	CreateProcsPrivTable = `CREATE TABLE IF NOT EXISTS mysql.procs_priv(
+		Host			CHAR(255),
+		DB				CHAR(64),
+		User			CHAR(32),
+		Routine_name	CHAR(64),
+		Routine_type	ENUM('FUNCTION','PROCEDURE') NOT NULL,
+		Grantor			CHAR(77),
+		Proc_priv		SET('Execute','Alter Routine','Grant'),
+		Timestamp		TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (Host, DB, User, Routine_name, Routine_type));`
 	// CreateGlobalVariablesTable is the SQL statement creates global variable table in system db.
 	// TODO: MySQL puts GLOBAL_VARIABLES table in INFORMATION_SCHEMA db.
 	// INFORMATION_SCHEMA is a virtual db in TiDB. So we put this table in system db.
@@ -581,11 +592,16 @@ const (
 	version83 = 83
 	// version84 adds the tables mysql.stats_meta_history
 	version84 = 84
+	// version85 adds the table mysql.procs_priv
+	version85 = 85
+	// version86 insert "foreign_key_checks|off" to mysql.GLOBAL_VARIABLES if there is no foreign_key_checks.
+	// The foreign keys weren't checked before, so the upgraded clusters keep foreign_key_checks off.
+	version86 = 86
 )
 
 // currentBootstrapVersion is defined as a variable, so we can modify its value for testing.
 // please make sure this is the largest version
-var currentBootstrapVersion int64 = version84
+var currentBootstrapVersion int64 = version86
 
 var (
 	bootstrapVersion = []func(Session, int64){
@@ -673,6 +689,8 @@ var (
 		upgradeToVer82,
 		upgradeToVer83,
 		upgradeToVer84,
+		upgradeToVer85,
+		upgradeToVer86,
 	}
 )
 
@@ -1742,6 +1760,22 @@ func upgradeToVer84(s Session, ver int64) {
 	doReentrantDDL(s, CreateStatsMetaHistory)
 }
 
+func upgradeToVer85(s Session, ver int64) {
+	if ver >= version85 {
+		return
+	}
+	doReentrantDDL(s, CreateProcsPrivTable)
+}
+
+func upgradeToVer86(s Session, ver int64) {
+	if ver >= version86 {
+		return
+	}
+	// The clusters bootstrapped before have foreign_key_checks off in mysql.GLOBAL_VARIABLES, it's kept as it is.
+	mustExecute(s, "INSERT HIGH_PRIORITY IGNORE INTO %n.%n VALUES (%?, %?);",
+		mysql.SystemDB, mysql.GlobalVariablesTable, variable.ForeignKeyChecks, variable.Off)
+}
+
 func writeOOMAction(s Session) {
 	comment := "oom-action is `log` by default in v3.0.x, `cancel` by default in v4.0.11+"
 	mustExecute(s, `INSERT HIGH_PRIORITY INTO %n.%n VALUES (%?, %?, %?) ON DUPLICATE KEY UPDATE VARIABLE_VALUE= %?`,
@@ -1782,6 +1816,7 @@ func doDDLWorks(s Session) {
 	mustExecute(s, CreateDBPrivTable)
 	mustExecute(s, CreateTablePrivTable)
 	mustExecute(s, CreateColumnPrivTable)
+	mustExecute(s, CreateProcsPrivTable)
 	// Create global system variable table.
 	mustExecute(s, CreateGlobalVariablesTable)
 	// Create TiDB table.
@@ -1873,13 +1908,6 @@ func doDMLWorks(s Session) {
 					vVal = string(variable.Dynamic)
 				}
 			}
-			if v.Name == variable.TiDBEnableChangeMultiSchema {
-				vVal = variable.Off
-				if flag.Lookup("test.v") != nil || flag.Lookup("check.v") != nil {
-					// enable change multi schema in test case for compatibility with old cases.
-					vVal = variable.On
-				}
-			}
 			if v.Name == variable.TiDBEnableAsyncCommit && config.GetGlobalConfig().Store == "tikv" {
 				vVal = variable.On
 			}
@@ -829,6 +829,96 @@ func TestUpgradeVersion75(t *testing.T) {
 	require.Equal(t, "char(255)", strings.ToLower(row.GetString(1)))
 }
 
+func TestUpgradeKeepsChangeMultiSchema(t *testing.T) {
+	ctx := context.Background()
+	store, dom := createStoreAndBootstrap(t)
+	defer func() { require.NoError(t, store.Close()) }()
+	seV84 := createSessionAndSetID(t, store)
+	txn, err := store.Begin()
+	require.NoError(t, err)
+	m := meta.NewMeta(txn)
+	err = m.FinishBootstrap(int64(84))
+	require.NoError(t, err)
+	err = txn.Commit(context.Background())
+	require.NoError(t, err)
+	mustExec(t, seV84, "update mysql.tidb set variable_value='84' where variable_name='tidb_server_version'")
+	// The clusters bootstrapped before have tidb_enable_change_multi_schema turned off, the upgrade keeps it.
+	mustExec(t, seV84, "set @@global.tidb_enable_change_multi_schema = 0")
+	mustExec(t, seV84, "commit")
+	unsetStoreBootstrapped(store.UUID())
+	ver, err := getBootstrapVersion(seV84)
+	require.NoError(t, err)
+	require.Equal(t, int64(84), ver)
+	dom.Close()
+	domCurVer, err := BootstrapSession(store)
+	require.NoError(t, err)
+	defer domCurVer.Close()
+
+	seCurVer := createSessionAndSetID(t, store)
+	ver, err = getBootstrapVersion(seCurVer)
+	require.NoError(t, err)
+	require.Equal(t, currentBootstrapVersion, ver)
+	r := mustExec(t, seCurVer, `select @@global.tidb_enable_change_multi_schema`)
+	req := r.NewChunk(nil)
+	require.NoError(t, r.Next(ctx, req))
+	require.Equal(t, 1, req.NumRows())
+	require.Equal(t, int64(0), req.GetRow(0).GetInt64(0))
+}
+
+func TestForeignKeyChecksUpgradeFrom85(t *testing.T) {
+	for i := 0; i < 2; i++ {
+		func() {
+			ctx := context.Background()
+			store, dom := createStoreAndBootstrap(t)
+			defer func() { require.NoError(t, store.Close()) }()
+
+			// In a new created cluster, foreign_key_checks is on by default.
+			seV85 := createSessionAndSetID(t, store)
+			res := mustExec(t, seV85, "select @@global.foreign_key_checks")
+			chk := res.NewChunk(nil)
+			require.NoError(t, res.Next(ctx, chk))
+			require.Equal(t, int64(1), chk.GetRow(0).GetInt64(0))
+
+			txn, err := store.Begin()
+			require.NoError(t, err)
+			m := meta.NewMeta(txn)
+			err = m.FinishBootstrap(int64(85))
+			require.NoError(t, err)
+			err = txn.Commit(context.Background())
+			require.NoError(t, err)
+			mustExec(t, seV85, "update mysql.tidb set variable_value='85' where variable_name='tidb_server_version'")
+			if i == 0 {
+				// The clusters bootstrapped before have foreign_key_checks off.
+				mustExec(t, seV85, fmt.Sprintf("update mysql.GLOBAL_VARIABLES set variable_value='%s' where variable_name='%s'", variable.Off, variable.ForeignKeyChecks))
+			} else {
+				mustExec(t, seV85, fmt.Sprintf("delete from mysql.GLOBAL_VARIABLES where variable_name='%s'", variable.ForeignKeyChecks))
+			}
+			mustExec(t, seV85, "commit")
+			unsetStoreBootstrapped(store.UUID())
+			ver, err := getBootstrapVersion(seV85)
+			require.NoError(t, err)
+			require.Equal(t, int64(85), ver)
+			dom.Close()
+			domCurVer, err := BootstrapSession(store)
+			require.NoError(t, err)
+			defer domCurVer.Close()
+			seCurVer := createSessionAndSetID(t, store)
+			ver, err = getBootstrapVersion(seCurVer)
+			require.NoError(t, err)
+			require.Equal(t, currentBootstrapVersion, ver)
+
+			// foreign_key_checks is still off after the upgrade.
+			res = mustExec(t, seCurVer, "select @@global.foreign_key_checks, @@session.foreign_key_checks")
+			chk = res.NewChunk(nil)
+			require.NoError(t, res.Next(ctx, chk))
+			require.Equal(t, 1, chk.NumRows())
+			row := chk.GetRow(0)
+			require.Equal(t, int64(0), row.GetInt64(0))
+			require.Equal(t, int64(0), row.GetInt64(1))
+		}()
+	}
+}
+
 func TestForIssue23387(t *testing.T) {
 	// For issue https://github.com/pingcap/tidb/issues/23387
 	saveCurrentBootstrapVersion := currentBootstrapVersion
@@ -0,0 +1,1409 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/config"
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/executor"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/terror"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/privilege/privileges"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+	driver "github.com/pingcap/tidb/types/parser_driver"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/memory"
+	"github.com/pingcap/tidb/util/sqlexec"
+)
+
+func init() {
+	expression.EvalStoredFunction = evalStoredFunction
+}
+
+// CallResultHandler handles a result set returned by a statement in a stored
+// procedure. The result set is closed by the caller after it returns.
+type CallResultHandler func(ctx context.Context, rs sqlexec.RecordSet) error
+
+// CallResultHandlerKeyType is a dummy type to avoid naming collision in context.
+type CallResultHandlerKeyType int
+
+// String defines a Stringer function for debugging and pretty printing.
+func (k CallResultHandlerKeyType) String() string {
+	return "call_result_handler"
+}
+
+// CallResultHandlerKey is the context key of the CallResultHandler. A CALL
+// statement fails if the procedure returns a result set without the handler.
+const CallResultHandlerKey CallResultHandlerKeyType = 0
+
+// preparedCallStmt returns the prepared CALL statement executed by stmt, ok is
+// false if the prepared statement isn't a CALL.
+func (s *session) preparedCallStmt(stmt *ast.ExecuteStmt) (*ast.Prepared, *ast.CallStmt, bool) {
+	stmtID := stmt.ExecID
+	if stmt.Name != "" {
+		stmtID = s.sessionVars.PreparedStmtNameToID[stmt.Name]
+	}
+	preparedObj, ok := s.sessionVars.PreparedStmts[stmtID].(*plannercore.CachedPrepareStmt)
+	if !ok {
+		return nil, nil, false
+	}
+	call, ok := preparedObj.PreparedAst.Stmt.(*ast.CallStmt)
+	return preparedObj.PreparedAst, call, ok
+}
+
+// evalUsingVars evaluates the USING clause of the EXECUTE statement.
+func (s *session) evalUsingVars(exprs []ast.ExprNode) ([]types.Datum, error) {
+	var scope *routineScope
+	args := make([]types.Datum, 0, len(exprs))
+	for _, expr := range exprs {
+		d, err := scope.evalExpr(s, expr)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, d)
+	}
+	return args, nil
+}
+
+// callPreparedProcedure executes the prepared CALL statement with the
+// parameters bound to args.
+func (s *session) callPreparedProcedure(ctx context.Context, prepared *ast.Prepared, call *ast.CallStmt, args []types.Datum) error {
+	if len(prepared.Params) != len(args) {
+		return errors.Trace(plannercore.ErrWrongParamCount)
+	}
+	s.sessionVars.StmtCtx.StmtType = prepared.StmtType
+	s.sessionVars.PreparedParams = args
+	for i, arg := range args {
+		param := prepared.Params[i].(*driver.ParamMarkerExpr)
+		param.Datum = arg
+		param.InExecute = true
+	}
+	return s.callProcedure(ctx, call, nil)
+}
+
+// callProcedure executes the CALL statement. The caller is the scope of the
+// routine which executes the statement, it's nil for a top level CALL.
+func (s *session) callProcedure(ctx context.Context, stmt *ast.CallStmt, caller *routineScope) error {
+	fn := stmt.Procedure
+	schema := fn.Schema
+	if schema.L == "" {
+		if s.sessionVars.CurrentDB == "" {
+			return plannercore.ErrNoDB
+		}
+		schema = model.NewCIStr(s.sessionVars.CurrentDB)
+	}
+	is, ok := s.GetInfoSchema().(infoschema.InfoSchema)
+	if !ok {
+		return errors.New("the info schema is not available")
+	}
+	routine, ok := is.RoutineByName(schema, fn.FnName, model.RoutineProcedure)
+	if !ok {
+		return infoschema.ErrRoutineNotExists.GenWithStackByArgs("PROCEDURE", schema.O+"."+fn.FnName.O)
+	}
+	name := schema.O + "." + routine.Name.O
+	if pm := privilege.GetPrivilegeManager(s); pm != nil && s.sessionVars.User != nil {
+		if !pm.RequestRoutineVerification(s.sessionVars.ActiveRoles, schema.L, routine.Name.L, routine.Type, mysql.ExecutePriv) {
+			user := s.sessionVars.User
+			return plannercore.ErrProcaccessDenied.GenWithStackByArgs("execute", user.AuthUsername, user.AuthHostname, schema.L+"."+routine.Name.L)
+		}
+	}
+	if len(fn.Args) != len(routine.Params) {
+		return plannercore.ErrSpWrongNoOfArgs.GenWithStackByArgs("PROCEDURE", name, len(routine.Params), len(fn.Args))
+	}
+	args := make([]types.Datum, len(routine.Params))
+	for i, param := range routine.Params {
+		if param.Mode != model.RoutineParamIn && !isRoutineAssignTarget(caller, fn.Args[i]) {
+			return ErrSpNotVarArg.GenWithStackByArgs(i+1, name)
+		}
+		if param.Mode == model.RoutineParamOut {
+			continue
+		}
+		d, err := caller.evalExpr(s, fn.Args[i])
+		if err != nil {
+			return err
+		}
+		args[i] = d
+	}
+
+	maxDepth, err := variable.GetSessionOrGlobalSystemVar(s.sessionVars, variable.MaxSpRecursionDepth)
+	if err != nil {
+		return err
+	}
+	depth, err := strconv.Atoi(maxDepth)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if s.procedureDepth[routine.ID] > depth {
+		return ErrSpRecursionLimit.GenWithStackByArgs(depth, routine.Name.O)
+	}
+	if s.procedureDepth == nil {
+		s.procedureDepth = make(map[int64]int)
+	}
+	s.procedureDepth[routine.ID]++
+	defer func() {
+		s.procedureDepth[routine.ID]--
+	}()
+
+	results, err := s.runProcedure(ctx, schema, routine, args)
+	if err != nil {
+		return err
+	}
+	for i, param := range routine.Params {
+		if param.Mode == model.RoutineParamIn {
+			continue
+		}
+		if err := assignRoutineTarget(s, caller, fn.Args[i], results[i], param.Tp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runProcedure runs the body of the stored procedure and returns the values of
+// the parameters when it finishes.
+func (s *session) runProcedure(ctx context.Context, schema model.CIStr, routine *model.RoutineInfo, args []types.Datum) ([]types.Datum, error) {
+	body, err := parseRoutineBody(s, routine)
+	if err != nil {
+		return nil, err
+	}
+	restore, err := s.enterProcedure(schema, routine)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+
+	scope := newRoutineScope(nil)
+	for i, param := range routine.Params {
+		if err := scope.declare(s, param.Name.L, param.Tp, args[i]); err != nil {
+			return nil, err
+		}
+	}
+	e := &routineExec{sctx: s, s: s, routine: routine}
+	if _, err := e.execStmt(ctx, scope, body); err != nil {
+		return nil, unwrapRoutineError(err)
+	}
+	results := make([]types.Datum, len(routine.Params))
+	for i, param := range routine.Params {
+		results[i] = scope.vars[param.Name.L].value
+	}
+	return results, nil
+}
+
+// enterProcedure switches the session to the context the procedure runs in:
+// the schema of the procedure becomes the current database, the sql_mode in
+// effect when the procedure was created is used, and the privileges of the
+// definer are used unless the procedure is SQL SECURITY INVOKER. The returned
+// function switches the session back.
+func (s *session) enterProcedure(schema model.CIStr, routine *model.RoutineInfo) (func(), error) {
+	vars := s.sessionVars
+	sqlMode, err := mysql.GetSQLMode(routine.SQLMode)
+	if err != nil {
+		return nil, err
+	}
+	oldDB, oldSQLMode := vars.CurrentDB, vars.SQLMode
+	oldUser, oldRoles := vars.User, vars.ActiveRoles
+	oldPM := privilege.GetPrivilegeManager(s)
+	switchUser := routine.Security == model.SecurityDefiner && routine.Definer != nil && oldPM != nil && oldUser != nil
+	if switchUser {
+		definer := routine.Definer
+		pm := &privileges.UserPrivileges{
+			Handle: domain.GetDomain(s).PrivilegeHandle(),
+		}
+		if !pm.GetAuthWithoutVerification(definer.Username, definer.Hostname) {
+			return nil, ErrNoSuchUser.GenWithStackByArgs(definer.Username, definer.Hostname)
+		}
+		privilege.BindPrivilegeManager(s, pm)
+		vars.User = &auth.UserIdentity{
+			Username:     definer.Username,
+			Hostname:     definer.Hostname,
+			AuthUsername: definer.Username,
+			AuthHostname: definer.Hostname,
+		}
+		vars.ActiveRoles = nil
+	}
+	vars.CurrentDB = schema.O
+	vars.SQLMode = sqlMode
+	return func() {
+		vars.CurrentDB, vars.SQLMode = oldDB, oldSQLMode
+		if switchUser {
+			vars.User, vars.ActiveRoles = oldUser, oldRoles
+			privilege.BindPrivilegeManager(s, oldPM)
+		}
+	}, nil
+}
+
+// routineEvalContext is the context to evaluate the expressions in the body of
+// a stored function, it records the functions being called to detect recursive
+// calls. The calls are only recorded in the session when the function executes
+// SQL statements, see routineExec.runSQL.
+type routineEvalContext struct {
+	sessionctx.Context
+	routineIDs []int64
+}
+
+// evalStoredFunction evaluates the stored function of the schema with the
+// arguments. The function runs in the context switched by enterProcedure like
+// a stored procedure, and its SQL statements are executed as nested statements
+// of the statement which calls the function.
+func evalStoredFunction(sctx sessionctx.Context, schema model.CIStr, routine *model.RoutineInfo, args []types.Datum) (types.Datum, error) {
+	var callers []int64
+	if rctx, ok := sctx.(*routineEvalContext); ok {
+		sctx, callers = rctx.Context, rctx.routineIDs
+	}
+	s, ok := sctx.(*session)
+	if !ok {
+		return types.Datum{}, errors.New("stored functions are not supported in this context")
+	}
+	if len(callers) == 0 {
+		callers = s.functionIDs
+	}
+	for _, id := range callers {
+		if id == routine.ID {
+			return types.Datum{}, ErrSpNoRecursion.GenWithStackByArgs()
+		}
+	}
+	body, err := s.functionBody(routine)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	restore, err := s.enterProcedure(schema, routine)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	defer restore()
+
+	rctx := &routineEvalContext{
+		Context:    sctx,
+		routineIDs: append(callers[:len(callers):len(callers)], routine.ID),
+	}
+	scope := newRoutineScope(nil)
+	for i, param := range routine.Params {
+		if err := scope.declare(sctx, param.Name.L, param.Tp, args[i]); err != nil {
+			return types.Datum{}, err
+		}
+	}
+	e := &routineExec{sctx: rctx, s: s, routine: routine}
+	flow, err := e.execStmt(context.TODO(), scope, body)
+	if err != nil {
+		return types.Datum{}, unwrapRoutineError(err)
+	}
+	if flow.kind != routineFlowReturn {
+		return types.Datum{}, ErrSpNoreturnend.GenWithStackByArgs(routine.Name.O)
+	}
+	return e.result.ConvertTo(sctx.GetSessionVars().StmtCtx, routine.ReturnType)
+}
+
+// functionBody returns the parsed body of the stored function, the bodies are
+// parsed once for a schema version instead of for every evaluated row.
+func (s *session) functionBody(routine *model.RoutineInfo) (ast.StmtNode, error) {
+	if version := s.GetInfoSchema().SchemaMetaVersion(); version != s.functionBodiesVersion {
+		// The functions may be changed with the schema, their bodies are parsed again.
+		s.functionBodies, s.functionBodiesVersion = nil, version
+	}
+	if body, ok := s.functionBodies[routine.ID]; ok {
+		return body, nil
+	}
+	body, err := parseRoutineBody(s, routine)
+	if err != nil {
+		return nil, err
+	}
+	if s.functionBodies == nil {
+		s.functionBodies = make(map[int64]ast.StmtNode)
+	}
+	s.functionBodies[routine.ID] = body
+	return body, nil
+}
+
+// parseRoutineBody parses the body of the routine with the sql_mode and the
+// charset in effect when the routine was created.
+func parseRoutineBody(sctx sessionctx.Context, routine *model.RoutineInfo) (ast.StmtNode, error) {
+	sqlMode, err := mysql.GetSQLMode(routine.SQLMode)
+	if err != nil {
+		return nil, err
+	}
+	p := parser.New()
+	p.SetSQLMode(sqlMode)
+	p.EnableWindowFunc(sctx.GetSessionVars().EnableWindowFunction)
+	sql := "CREATE PROCEDURE p() " + routine.Body
+	if routine.Type == model.RoutineFunction {
+		sql = "CREATE FUNCTION f() RETURNS INT " + routine.Body
+	}
+	stmt, err := p.ParseOneStmt(sql, routine.Charset, routine.Collate)
+	if err != nil {
+		return nil, err
+	}
+	return stmt.(*ast.CreateRoutineStmt).Body, nil
+}
+
+type routineVar struct {
+	tp    *types.FieldType
+	value types.Datum
+}
+
+// routineCursor reads all the rows of the query into a row container when it's
+// opened. The memory of the row container is charged to the memory tracker of
+// the session, and it spills to disk when it exceeds the memory quota of a query.
+type routineCursor struct {
+	query        ast.StmtNode
+	fieldTypes   []*types.FieldType
+	rowContainer *chunk.RowContainer
+	// chk is the chunk being fetched, chkIdx is the index of the next chunk in
+	// the row container and rowIdx is the index of the next row in chk.
+	chk    *chunk.Chunk
+	chkIdx int
+	rowIdx int
+	opened bool
+}
+
+// close releases the memory and the disk used by the opened cursor.
+func (c *routineCursor) close() error {
+	if !c.opened {
+		return nil
+	}
+	c.opened, c.chk = false, nil
+	err := c.rowContainer.Close()
+	c.rowContainer.GetMemTracker().Detach()
+	c.rowContainer.GetDiskTracker().Detach()
+	c.rowContainer = nil
+	return err
+}
+
+// routineScope is the scope of the variables, cursors and handlers declared in
+// a BEGIN ... END block of a stored routine.
+type routineScope struct {
+	parent   *routineScope
+	vars     map[string]*routineVar
+	cursors  map[string]*routineCursor
+	handlers []*ast.DeclareHandlerStmt
+	// handlerOf is the scope whose handler is running in this scope, the
+	// handlers of that scope are not used for the errors raised by the handler.
+	handlerOf *routineScope
+	// trigger is the rows the trigger is activated for, it's only set in the
+	// outermost scope of a trigger body.
+	trigger *triggerRows
+}
+
+func newRoutineScope(parent *routineScope) *routineScope {
+	return &routineScope{
+		parent:  parent,
+		vars:    make(map[string]*routineVar),
+		cursors: make(map[string]*routineCursor),
+	}
+}
+
+func (sc *routineScope) declare(sctx sessionctx.Context, name string, tp *types.FieldType, value types.Datum) error {
+	name = strings.ToLower(name)
+	if _, ok := sc.vars[name]; ok {
+		return ErrSpDupVar.GenWithStackByArgs(name)
+	}
+	v := &routineVar{tp: tp}
+	if err := v.set(sctx, value); err != nil {
+		return err
+	}
+	sc.vars[name] = v
+	return nil
+}
+
+// closeCursors closes the opened cursors declared in the scope when the block
+// of the scope ends.
+func (sc *routineScope) closeCursors() {
+	for _, cursor := range sc.cursors {
+		terror.Call(cursor.close)
+	}
+}
+
+func (sc *routineScope) lookupVar(name string) *routineVar {
+	name = strings.ToLower(name)
+	for ; sc != nil; sc = sc.parent {
+		if v, ok := sc.vars[name]; ok {
+			return v
+		}
+	}
+	return nil
+}
+
+func (sc *routineScope) triggerRows() *triggerRows {
+	for ; sc != nil; sc = sc.parent {
+		if sc.trigger != nil {
+			return sc.trigger
+		}
+	}
+	return nil
+}
+
+func (sc *routineScope) lookupCursor(name string) (*routineCursor, error) {
+	name = strings.ToLower(name)
+	for ; sc != nil; sc = sc.parent {
+		if c, ok := sc.cursors[name]; ok {
+			return c, nil
+		}
+	}
+	return nil, ErrSpCursorMismatch.GenWithStackByArgs(name)
+}
+
+// evalExpr evaluates the expression, the variables in scope are visible to it
+// as columns of the row being evaluated.
+func (sc *routineScope) evalExpr(sctx sessionctx.Context, expr ast.ExprNode) (types.Datum, error) {
+	if sc.triggerRows() != nil {
+		// NEW.col and OLD.col are replaced by their values.
+		replacer := &routineVarReplacer{scope: sc, replaced: make(map[ast.Node]ast.Node), keepVars: true}
+		node, _ := expr.Accept(replacer)
+		defer expr.Accept(&routineVarRestorer{replaced: replacer.replaced})
+		if replacer.err != nil {
+			return types.Datum{}, replacer.err
+		}
+		expr = node.(ast.ExprNode)
+	}
+	var (
+		cols   []*expression.Column
+		names  types.NameSlice
+		values []types.Datum
+		seen   = make(map[string]struct{})
+	)
+	for s := sc; s != nil; s = s.parent {
+		for name, v := range s.vars {
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+			cols = append(cols, &expression.Column{
+				RetType:  v.tp,
+				UniqueID: sctx.GetSessionVars().AllocPlanColumnID(),
+				Index:    len(cols),
+			})
+			names = append(names, &types.FieldName{ColName: model.NewCIStr(name)})
+			values = append(values, v.value)
+		}
+	}
+	e, err := expression.RewriteAstExpr(sctx, expr, expression.NewSchema(cols...), names)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	fieldTypes := make([]*types.FieldType, 0, len(cols))
+	for _, col := range cols {
+		fieldTypes = append(fieldTypes, col.RetType)
+	}
+	row := chunk.MutRowFromTypes(fieldTypes)
+	for i, value := range values {
+		row.SetDatum(i, value)
+	}
+	return e.Eval(row.ToRow())
+}
+
+// findHandler finds the handler for the error raised in the scope. The handler
+// in the innermost scope is used, and a handler for the error code is preferred
+// to the one for the SQLSTATE, which is preferred to the general conditions.
+func (sc *routineScope) findHandler(code uint16, state string) (*ast.DeclareHandlerStmt, *routineScope) {
+	var skipped []*routineScope
+	for s := sc; s != nil; s = s.parent {
+		if s.handlerOf != nil {
+			skipped = append(skipped, s.handlerOf)
+		}
+		if containsRoutineScope(skipped, s) {
+			continue
+		}
+		var (
+			best     *ast.DeclareHandlerStmt
+			bestRank int
+		)
+		for _, handler := range s.handlers {
+			for _, cond := range handler.Conditions {
+				if rank := matchHandlerCondition(cond, code, state); rank > bestRank {
+					best, bestRank = handler, rank
+				}
+			}
+		}
+		if best != nil {
+			return best, s
+		}
+	}
+	return nil, nil
+}
+
+func containsRoutineScope(scopes []*routineScope, sc *routineScope) bool {
+	for _, s := range scopes {
+		if s == sc {
+			return true
+		}
+	}
+	return false
+}
+
+// matchHandlerCondition returns the rank of the condition for the error, 0 means
+// the condition doesn't match.
+func matchHandlerCondition(cond *ast.HandlerCondition, code uint16, state string) int {
+	class := state[:2]
+	switch cond.Tp {
+	case ast.HandlerConditionErrorCode:
+		if cond.ErrorCode == uint64(code) {
+			return 3
+		}
+	case ast.HandlerConditionSQLState:
+		if cond.SQLState == state {
+			return 2
+		}
+	case ast.HandlerConditionSQLWarning:
+		if class == "01" {
+			return 1
+		}
+	case ast.HandlerConditionNotFound:
+		if class == "02" {
+			return 1
+		}
+	case ast.HandlerConditionSQLException:
+		if class != "00" && class != "01" && class != "02" {
+			return 1
+		}
+	}
+	return 0
+}
+
+// routineErrorState returns the error code and the SQLSTATE of the error.
+func routineErrorState(err error) (uint16, string) {
+	switch x := errors.Cause(err).(type) {
+	case *mysql.SQLError:
+		return x.Code, x.State
+	case *terror.Error:
+		sqlErr := terror.ToSQLError(x)
+		return sqlErr.Code, sqlErr.State
+	}
+	return mysql.ErrUnknown, mysql.DefaultMySQLState
+}
+
+// routineUnhandledError is an error which no handler is found for, it's not
+// looked up again by the outer blocks.
+type routineUnhandledError struct {
+	err error
+}
+
+func (e *routineUnhandledError) Error() string {
+	return e.err.Error()
+}
+
+func unwrapRoutineError(err error) error {
+	if x, ok := err.(*routineUnhandledError); ok {
+		return x.err
+	}
+	return err
+}
+
+type routineFlowKind int
+
+const (
+	routineFlowNext routineFlowKind = iota
+	routineFlowLeave
+	routineFlowIterate
+	routineFlowReturn
+	// routineFlowExit leaves the block which declares an EXIT handler after
+	// the handler runs.
+	routineFlowExit
+)
+
+// routineFlow tells how the execution continues after a statement.
+type routineFlow struct {
+	kind  routineFlowKind
+	label string
+	scope *routineScope
+}
+
+func (f routineFlow) leaves(label string) bool {
+	return f.kind == routineFlowLeave && label != "" && strings.EqualFold(f.label, label)
+}
+
+func (f routineFlow) iterates(label string) bool {
+	return f.kind == routineFlowIterate && label != "" && strings.EqualFold(f.label, label)
+}
+
+// routineExec interprets the body of a stored routine.
+type routineExec struct {
+	sctx sessionctx.Context
+	// s executes the SQL statements in the routine.
+	s       *session
+	routine *model.RoutineInfo
+	// trigger is set if the routine is the body of a trigger.
+	trigger *model.TriggerInfo
+	// result is the value returned by a stored function.
+	result types.Datum
+}
+
+func (e *routineExec) execStmts(ctx context.Context, scope *routineScope, stmts []ast.StmtNode) (routineFlow, error) {
+	for _, stmt := range stmts {
+		flow, err := e.execStmt(ctx, scope, stmt)
+		if err != nil {
+			flow, err = e.handleError(ctx, scope, stmt, err)
+			if err != nil {
+				return flow, err
+			}
+		}
+		if flow.kind != routineFlowNext {
+			return flow, nil
+		}
+	}
+	return routineFlow{}, nil
+}
+
+// handleError runs the handler for the error raised by the statement. It returns
+// the error wrapped as routineUnhandledError if no handler is found.
+func (e *routineExec) handleError(ctx context.Context, scope *routineScope, stmt ast.StmtNode, err error) (routineFlow, error) {
+	if _, ok := err.(*routineUnhandledError); ok {
+		return routineFlow{}, err
+	}
+	if executor.ErrQueryInterrupted.Equal(err) {
+		return routineFlow{}, &routineUnhandledError{err: err}
+	}
+	code, state := routineErrorState(err)
+	handler, declScope := scope.findHandler(code, state)
+	if handler == nil {
+		// SELECT ... INTO without any row only raises a warning if it's not handled.
+		if sel, ok := stmt.(*ast.SelectStmt); ok && sel.SelectIntoOpt != nil && ErrSpFetchNoData.Equal(err) {
+			e.sctx.GetSessionVars().StmtCtx.AppendWarning(err)
+			return routineFlow{}, nil
+		}
+		return routineFlow{}, &routineUnhandledError{err: err}
+	}
+	handlerScope := newRoutineScope(declScope)
+	handlerScope.handlerOf = declScope
+	flow, err := e.execStmts(ctx, handlerScope, []ast.StmtNode{handler.Body})
+	if err != nil || flow.kind != routineFlowNext {
+		return flow, err
+	}
+	if handler.Action == ast.HandlerExit {
+		return routineFlow{kind: routineFlowExit, scope: declScope}, nil
+	}
+	return routineFlow{}, nil
+}
+
+func (e *routineExec) execStmt(ctx context.Context, scope *routineScope, stmt ast.StmtNode) (routineFlow, error) {
+	switch x := stmt.(type) {
+	case *ast.BlockStmt:
+		return e.execBlock(ctx, scope, x)
+	case *ast.IfStmt:
+		for _, branch := range x.Branches {
+			ok, err := e.evalCond(ctx, scope, branch.Cond)
+			if err != nil {
+				return routineFlow{}, err
+			}
+			if ok {
+				return e.execStmts(ctx, scope, branch.Stmts)
+			}
+		}
+		return e.execStmts(ctx, scope, x.Else)
+	case *ast.LoopStmt:
+		return e.execLoop(ctx, scope, x.Label, nil, x.Stmts, nil)
+	case *ast.WhileStmt:
+		return e.execLoop(ctx, scope, x.Label, x.Cond, x.Stmts, nil)
+	case *ast.RepeatStmt:
+		return e.execLoop(ctx, scope, x.Label, nil, x.Stmts, x.Cond)
+	case *ast.LeaveStmt:
+		return routineFlow{kind: routineFlowLeave, label: x.Label}, nil
+	case *ast.IterateStmt:
+		return routineFlow{kind: routineFlowIterate, label: x.Label}, nil
+	case *ast.ReturnStmt:
+		d, err := e.evalExpr(ctx, scope, x.Expr)
+		if err != nil {
+			return routineFlow{}, err
+		}
+		e.result = d
+		return routineFlow{kind: routineFlowReturn}, nil
+	case *ast.SetStmt:
+		return routineFlow{}, e.execSet(ctx, scope, x)
+	case *ast.SignalStmt:
+		return routineFlow{}, e.signal(scope, x)
+	case *ast.OpenCursorStmt:
+		return routineFlow{}, e.openCursor(ctx, scope, x)
+	case *ast.FetchCursorStmt:
+		return routineFlow{}, e.fetchCursor(scope, x)
+	case *ast.CloseCursorStmt:
+		cursor, err := scope.lookupCursor(x.Name)
+		if err != nil {
+			return routineFlow{}, err
+		}
+		if !cursor.opened {
+			return routineFlow{}, ErrSpCursorNotOpen.GenWithStackByArgs()
+		}
+		return routineFlow{}, cursor.close()
+	}
+	switch x := stmt.(type) {
+	case *ast.CallStmt:
+		defer e.enterSQL()()
+		return routineFlow{}, e.s.callProcedure(ctx, x, scope)
+	case *ast.SelectStmt:
+		if x.SelectIntoOpt != nil && x.SelectIntoOpt.Tp == ast.SelectIntoVars {
+			return routineFlow{}, e.selectInto(ctx, scope, x)
+		}
+	}
+	return routineFlow{}, e.execSQL(ctx, scope, stmt)
+}
+
+func (e *routineExec) execBlock(ctx context.Context, parent *routineScope, block *ast.BlockStmt) (routineFlow, error) {
+	scope := newRoutineScope(parent)
+	defer scope.closeCursors()
+	for _, decl := range block.Decls {
+		if err := e.declare(ctx, scope, decl); err != nil {
+			return routineFlow{}, err
+		}
+	}
+	flow, err := e.execStmts(ctx, scope, block.Stmts)
+	if err != nil {
+		return flow, err
+	}
+	if flow.leaves(block.Label) || flow.kind == routineFlowExit && flow.scope == scope {
+		return routineFlow{}, nil
+	}
+	return flow, nil
+}
+
+func (e *routineExec) declare(ctx context.Context, scope *routineScope, decl ast.StmtNode) error {
+	switch x := decl.(type) {
+	case *ast.DeclareVarStmt:
+		tp := e.routineVarType(x.Tp)
+		var value types.Datum
+		if x.Default != nil {
+			d, err := e.evalExpr(ctx, scope, x.Default)
+			if err != nil {
+				return err
+			}
+			value = d
+		}
+		for _, name := range x.Names {
+			if err := scope.declare(e.sctx, name, tp, value); err != nil {
+				return err
+			}
+		}
+	case *ast.DeclareCursorStmt:
+		name := strings.ToLower(x.Name)
+		if _, ok := scope.cursors[name]; ok {
+			return ErrSpDupCurs.GenWithStackByArgs(x.Name)
+		}
+		scope.cursors[name] = &routineCursor{query: x.Select}
+	case *ast.DeclareHandlerStmt:
+		scope.handlers = append(scope.handlers, x)
+	}
+	return nil
+}
+
+// routineVarType fills the unspecified attributes of the type of a declared
+// variable like the columns of a table.
+func (e *routineExec) routineVarType(tp *types.FieldType) *types.FieldType {
+	tp = tp.Clone()
+	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(tp.Tp)
+	if tp.Flen == types.UnspecifiedLength {
+		tp.Flen = defaultFlen
+	}
+	if tp.Decimal == types.UnspecifiedLength {
+		tp.Decimal = defaultDecimal
+	}
+	if tp.Charset == "" {
+		if types.IsString(tp.Tp) {
+			tp.Charset, tp.Collate = e.routine.Charset, e.routine.Collate
+		} else {
+			tp.Charset, tp.Collate = charset.CharsetBin, charset.CollationBin
+		}
+	}
+	return tp
+}
+
+func (e *routineExec) execLoop(ctx context.Context, scope *routineScope, label string, whileCond ast.ExprNode, stmts []ast.StmtNode, untilCond ast.ExprNode) (routineFlow, error) {
+	for {
+		if atomic.LoadUint32(&e.sctx.GetSessionVars().Killed) == 1 {
+			return routineFlow{}, executor.ErrQueryInterrupted
+		}
+		if whileCond != nil {
+			ok, err := e.evalCond(ctx, scope, whileCond)
+			if err != nil || !ok {
+				return routineFlow{}, err
+			}
+		}
+		flow, err := e.execStmts(ctx, scope, stmts)
+		if err != nil {
+			return flow, err
+		}
+		switch {
+		case flow.leaves(label):
+			return routineFlow{}, nil
+		case flow.iterates(label):
+			continue
+		case flow.kind != routineFlowNext:
+			return flow, nil
+		}
+		if untilCond != nil {
+			ok, err := e.evalCond(ctx, scope, untilCond)
+			if err != nil || ok {
+				return routineFlow{}, err
+			}
+		}
+	}
+}
+
+// evalExpr evaluates the expression in the routine. The subqueries in it can't
+// see the variables as the columns, so the expression is evaluated by a SELECT
+// statement, in which the variables are replaced by their values.
+func (e *routineExec) evalExpr(ctx context.Context, scope *routineScope, expr ast.ExprNode) (types.Datum, error) {
+	finder := &subqueryFinder{}
+	expr.Accept(finder)
+	if !finder.found {
+		return scope.evalExpr(e.sctx, expr)
+	}
+	stmt := &ast.SelectStmt{
+		Kind:           ast.SelectStmtKindSelect,
+		SelectStmtOpts: &ast.SelectStmtOpts{SQLCache: true},
+		Fields:         &ast.FieldList{Fields: []*ast.SelectField{{Expr: expr}}},
+	}
+	row, err := e.queryRow(ctx, scope, stmt)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	return row[0], nil
+}
+
+func (e *routineExec) evalCond(ctx context.Context, scope *routineScope, cond ast.ExprNode) (bool, error) {
+	d, err := e.evalExpr(ctx, scope, cond)
+	if err != nil || d.IsNull() {
+		return false, err
+	}
+	v, err := d.ToBool(e.sctx.GetSessionVars().StmtCtx)
+	return v != 0, err
+}
+
+func (e *routineExec) execSet(ctx context.Context, scope *routineScope, stmt *ast.SetStmt) error {
+	others := make([]*ast.VariableAssignment, 0, len(stmt.Variables))
+	rows := scope.triggerRows()
+	for _, assign := range stmt.Variables {
+		if tbl, col, ok := splitTriggerColumn(assign.Name); ok && rows != nil && assign.IsSystem && !assign.IsGlobal {
+			if err := e.setNewColumn(ctx, scope, rows, assign, tbl, col); err != nil {
+				return err
+			}
+			continue
+		}
+		v := scope.lookupVar(assign.Name)
+		if !assign.IsSystem || assign.IsGlobal || v == nil {
+			others = append(others, assign)
+			continue
+		}
+		d, err := e.evalExpr(ctx, scope, assign.Value)
+		if err != nil {
+			return err
+		}
+		if err := v.set(e.sctx, d); err != nil {
+			return err
+		}
+	}
+	if len(others) == 0 {
+		return nil
+	}
+	assigns := stmt.Variables
+	stmt.Variables = others
+	defer func() {
+		stmt.Variables = assigns
+	}()
+	return e.execSQL(ctx, scope, stmt)
+}
+
+func (e *routineExec) signal(scope *routineScope, stmt *ast.SignalStmt) error {
+	state := stmt.SQLState
+	if len(state) != 5 || strings.HasPrefix(state, "00") {
+		return ErrSpBadSQLstate.GenWithStackByArgs(state)
+	}
+	code := uint16(mysql.ErrSignalException)
+	switch state[:2] {
+	case "01":
+		code = mysql.ErrSignalWarn
+	case "02":
+		code = mysql.ErrSignalNotFound
+	}
+	msg := mysql.MySQLErrName[code].Raw
+	if stmt.MySQLErrno != nil {
+		d, err := scope.evalExpr(e.sctx, stmt.MySQLErrno)
+		if err != nil {
+			return err
+		}
+		errno, err := d.ToInt64(e.sctx.GetSessionVars().StmtCtx)
+		if err != nil {
+			return err
+		}
+		code = uint16(errno)
+	}
+	if stmt.MessageText != nil {
+		d, err := scope.evalExpr(e.sctx, stmt.MessageText)
+		if err != nil {
+			return err
+		}
+		if msg, err = d.ToString(); err != nil {
+			return err
+		}
+	}
+	sqlErr := &mysql.SQLError{Code: code, State: state, Message: msg}
+	if state[:2] == "01" {
+		e.sctx.GetSessionVars().StmtCtx.AppendWarning(sqlErr)
+		return nil
+	}
+	return sqlErr
+}
+
+func (e *routineExec) openCursor(ctx context.Context, scope *routineScope, stmt *ast.OpenCursorStmt) (err error) {
+	cursor, err := scope.lookupCursor(stmt.Name)
+	if err != nil {
+		return err
+	}
+	if cursor.opened {
+		return ErrSpCursorAlreadyOpen.GenWithStackByArgs()
+	}
+	rs, err := e.runSQL(ctx, scope, cursor.query)
+	if err != nil || rs == nil {
+		return err
+	}
+	defer func() {
+		if closeErr := rs.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	vars := e.sctx.GetSessionVars()
+	fieldTypes := recordSetFieldTypes(rs)
+	rowContainer := chunk.NewRowContainer(fieldTypes, vars.MaxChunkSize)
+	memTracker := rowContainer.GetMemTracker()
+	memTracker.SetLabel(memory.LabelForCursorFetch)
+	memTracker.SetBytesLimit(vars.MemQuotaQuery)
+	memTracker.AttachTo(vars.MemTracker)
+	if config.GetGlobalConfig().OOMUseTmpStorage {
+		memTracker.FallbackOldAndSetNewAction(rowContainer.ActionSpill())
+		diskTracker := rowContainer.GetDiskTracker()
+		diskTracker.SetLabel(memory.LabelForCursorFetch)
+		diskTracker.AttachTo(executor.GlobalDiskUsageTracker)
+	}
+	cursor.fieldTypes, cursor.rowContainer = fieldTypes, rowContainer
+	cursor.chk, cursor.chkIdx, cursor.rowIdx, cursor.opened = nil, 0, 0, true
+	for {
+		// The chunk is kept by the row container, so it can't be reused.
+		chk := rs.NewChunk(nil)
+		if err = rs.Next(ctx, chk); err == nil && chk.NumRows() > 0 {
+			err = rowContainer.Add(chk)
+		}
+		if err != nil {
+			terror.Call(cursor.close)
+			return err
+		}
+		if chk.NumRows() == 0 {
+			return nil
+		}
+	}
+}
+
+func (e *routineExec) fetchCursor(scope *routineScope, stmt *ast.FetchCursorStmt) error {
+	cursor, err := scope.lookupCursor(stmt.Name)
+	if err != nil {
+		return err
+	}
+	if !cursor.opened {
+		return ErrSpCursorNotOpen.GenWithStackByArgs()
+	}
+	for cursor.chk == nil || cursor.rowIdx >= cursor.chk.NumRows() {
+		if cursor.chkIdx >= cursor.rowContainer.NumChunks() {
+			return ErrSpFetchNoData.GenWithStackByArgs()
+		}
+		if cursor.chk, err = cursor.rowContainer.GetChunk(cursor.chkIdx); err != nil {
+			return err
+		}
+		cursor.chkIdx, cursor.rowIdx = cursor.chkIdx+1, 0
+	}
+	if len(cursor.fieldTypes) != len(stmt.Vars) {
+		return ErrSpWrongNoOfFetchArgs.GenWithStackByArgs()
+	}
+	row := types.CloneRow(cursor.chk.GetRow(cursor.rowIdx).GetDatumRow(cursor.fieldTypes))
+	cursor.rowIdx++
+	for i, name := range stmt.Vars {
+		v := scope.lookupVar(name)
+		if v == nil {
+			return ErrSpUndeclaredVar.GenWithStackByArgs(name)
+		}
+		if err := v.set(e.sctx, row[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *routineExec) selectInto(ctx context.Context, scope *routineScope, stmt *ast.SelectStmt) error {
+	into := stmt.SelectIntoOpt
+	stmt.SelectIntoOpt = nil
+	defer func() {
+		stmt.SelectIntoOpt = into
+	}()
+	row, err := e.queryRow(ctx, scope, stmt)
+	if err != nil {
+		return err
+	}
+	if row == nil {
+		return ErrSpFetchNoData.GenWithStackByArgs()
+	}
+	if len(row) != len(into.Variables) {
+		return ErrWrongNumberOfColumnsInSelect.GenWithStackByArgs()
+	}
+	for i, target := range into.Variables {
+		if err := assignRoutineTarget(e.sctx, scope, target, row[i], nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// execSQL executes the SQL statement, the result set is returned to the client
+// by the CallResultHandler in ctx.
+func (e *routineExec) execSQL(ctx context.Context, scope *routineScope, stmt ast.StmtNode) error {
+	rs, err := e.runSQL(ctx, scope, stmt)
+	if err != nil || rs == nil {
+		return err
+	}
+	if e.s.inTrigger() {
+		terror.Call(rs.Close)
+		return ErrSpNoRetset.GenWithStackByArgs("trigger")
+	}
+	if e.s.inStoredFunction() {
+		terror.Call(rs.Close)
+		return ErrSpNoRetset.GenWithStackByArgs("function")
+	}
+	handler, ok := ctx.Value(CallResultHandlerKey).(CallResultHandler)
+	if !ok {
+		terror.Call(rs.Close)
+		return ErrSpBadselect.GenWithStackByArgs(e.routine.Name.O)
+	}
+	err = handler(ctx, rs)
+	if closeErr := rs.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// queryRow executes the query and reads the only row of it, nil is returned if
+// the query returns no rows.
+func (e *routineExec) queryRow(ctx context.Context, scope *routineScope, stmt ast.StmtNode) (row []types.Datum, err error) {
+	rs, err := e.runSQL(ctx, scope, stmt)
+	if err != nil || rs == nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rs.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	fieldTypes := recordSetFieldTypes(rs)
+	chk := rs.NewChunk(nil)
+	for {
+		if err := rs.Next(ctx, chk); err != nil {
+			return nil, err
+		}
+		if chk.NumRows() == 0 {
+			return row, nil
+		}
+		if row != nil || chk.NumRows() > 1 {
+			return nil, ErrTooManyRows.GenWithStackByArgs()
+		}
+		row = types.CloneRow(chk.GetRow(0).GetDatumRow(fieldTypes))
+	}
+}
+
+func recordSetFieldTypes(rs sqlexec.RecordSet) []*types.FieldType {
+	fieldTypes := make([]*types.FieldType, 0, len(rs.Fields()))
+	for _, field := range rs.Fields() {
+		fieldTypes = append(fieldTypes, &field.Column.FieldType)
+	}
+	return fieldTypes
+}
+
+// runSQL executes the SQL statement in the session. The local variables used
+// by the statement are replaced by their values before it's executed.
+func (e *routineExec) runSQL(ctx context.Context, scope *routineScope, stmt ast.StmtNode) (sqlexec.RecordSet, error) {
+	var sb strings.Builder
+	if err := stmt.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return nil, err
+	}
+	stmt.SetText(nil, sb.String())
+	replacer := &routineVarReplacer{scope: scope, replaced: make(map[ast.Node]ast.Node)}
+	stmt.Accept(replacer)
+	defer stmt.Accept(&routineVarRestorer{replaced: replacer.replaced})
+	if replacer.err != nil {
+		return nil, replacer.err
+	}
+	defer e.enterSQL()()
+	if e.s.inStoredFunction() {
+		return e.s.executeFunctionStmt(ctx, stmt)
+	}
+	if e.s.inTrigger() {
+		return e.s.executeNestedStmt(ctx, stmt)
+	}
+	return e.s.ExecuteStmt(ctx, stmt)
+}
+
+// enterSQL records the stored functions being called in the session before a
+// stored function executes a SQL statement, so the statement is executed as a
+// nested statement and the functions called by it are checked for recursion.
+// The returned function restores the session.
+func (e *routineExec) enterSQL() func() {
+	rctx, ok := e.sctx.(*routineEvalContext)
+	if !ok {
+		return func() {}
+	}
+	outer := e.s.functionIDs
+	e.s.functionIDs = rctx.routineIDs
+	return func() {
+		e.s.functionIDs = outer
+	}
+}
+
+// executeFunctionStmt executes a SQL statement in a stored function. The
+// statement runs in the transaction of the statement which calls the function,
+// whose statement context is restored after the statement finishes. The changes
+// of the statement are discarded if it fails.
+func (s *session) executeFunctionStmt(ctx context.Context, stmtNode ast.StmtNode) (sqlexec.RecordSet, error) {
+	switch stmtNode.(type) {
+	case ast.DDLNode, *ast.BeginStmt, *ast.CommitStmt, *ast.RollbackStmt:
+		return nil, ErrCommitNotAllowedInSfOrTrg.GenWithStackByArgs()
+	}
+	vars := s.sessionVars
+	outerStmtCtx, outerParams := vars.StmtCtx, vars.PreparedParams
+	vars.NestedStmtLevel++
+	restore := func() {
+		sc := vars.StmtCtx
+		if sc != outerStmtCtx {
+			outerStmtCtx.AppendWarnings(sc.GetWarnings())
+			if sc.MemTracker != nil {
+				sc.MemTracker.DetachFromGlobalTracker()
+			}
+			if sc.DiskTracker != nil {
+				sc.DiskTracker.DetachFromGlobalTracker()
+			}
+		}
+		vars.StmtCtx, vars.PreparedParams = outerStmtCtx, outerParams
+		vars.NestedStmtLevel--
+	}
+
+	if err := executor.ResetContextOfStmt(s, stmtNode); err != nil {
+		restore()
+		return nil, err
+	}
+	compiler := executor.Compiler{Ctx: s}
+	stmt, err := compiler.Compile(ctx, stmtNode)
+	if err != nil {
+		restore()
+		return nil, err
+	}
+	txn, err := s.Txn(true)
+	if err != nil {
+		restore()
+		return nil, err
+	}
+	memBuffer := txn.GetMemBuffer()
+	sh := memBuffer.Staging()
+	rs, err := stmt.Exec(ctx)
+	if err != nil {
+		memBuffer.Cleanup(sh)
+		restore()
+		return nil, err
+	}
+	memBuffer.Release(sh)
+	// The statement calling the function isn't read-only if the function
+	// modifies data, otherwise it's not recorded in the transaction history.
+	if !stmt.IsReadOnly(vars) {
+		outerStmtCtx.WrittenByNestedStmt = true
+	}
+	if rs == nil {
+		restore()
+		return nil, nil
+	}
+	return &functionStmtResult{RecordSet: rs, restore: restore}, nil
+}
+
+// functionStmtResult restores the statement context of the calling statement
+// when the result set of a statement in a stored function is closed.
+type functionStmtResult struct {
+	sqlexec.RecordSet
+	restore func()
+}
+
+func (rs *functionStmtResult) Close() error {
+	err := rs.RecordSet.Close()
+	rs.restore()
+	return err
+}
+
+// inStoredFunction checks whether the session is running the SQL statements of
+// stored functions.
+func (s *session) inStoredFunction() bool {
+	return len(s.functionIDs) > 0
+}
+
+// routineVarReplacer replaces the local variables in a SQL statement with their
+// values. The names which are not qualified by a table are looked up in the
+// local variables first, like MySQL does. NEW.col and OLD.col in a trigger are
+// replaced by the values of the rows too.
+type routineVarReplacer struct {
+	scope    *routineScope
+	replaced map[ast.Node]ast.Node
+	// keepVars keeps the local variables, only NEW.col and OLD.col are replaced.
+	keepVars bool
+	err      error
+}
+
+// Enter implements Visitor interface.
+func (r *routineVarReplacer) Enter(in ast.Node) (ast.Node, bool) {
+	col, ok := in.(*ast.ColumnNameExpr)
+	if !ok || r.err != nil {
+		return in, r.err != nil
+	}
+	var value ast.ValueExpr
+	if col.Name.Table.L != "" {
+		rows := r.scope.triggerRows()
+		if rows == nil {
+			return in, false
+		}
+		c, d, err := rows.column(col.Name.Schema.L, col.Name.Table.L, col.Name.Name.O)
+		if err != nil {
+			r.err = err
+			return in, true
+		}
+		if c == nil {
+			return in, false
+		}
+		value = ast.NewValueExpr(d.GetValue(), c.Charset, c.Collate)
+	} else {
+		v := r.scope.lookupVar(col.Name.Name.L)
+		if v == nil || r.keepVars {
+			return in, false
+		}
+		value = ast.NewValueExpr(v.value.GetValue(), v.tp.Charset, v.tp.Collate)
+	}
+	r.replaced[value] = col
+	return value, true
+}
+
+// Leave implements Visitor interface.
+func (r *routineVarReplacer) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+// subqueryFinder checks whether an expression contains subqueries.
+type subqueryFinder struct {
+	found bool
+}
+
+// Enter implements Visitor interface.
+func (f *subqueryFinder) Enter(in ast.Node) (ast.Node, bool) {
+	if _, ok := in.(*ast.SubqueryExpr); ok {
+		f.found = true
+	}
+	return in, f.found
+}
+
+// Leave implements Visitor interface.
+func (f *subqueryFinder) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+// routineVarRestorer restores the statement changed by routineVarReplacer.
+type routineVarRestorer struct {
+	replaced map[ast.Node]ast.Node
+}
+
+// Enter implements Visitor interface.
+func (r *routineVarRestorer) Enter(in ast.Node) (ast.Node, bool) {
+	if origin, ok := r.replaced[in]; ok {
+		return origin, true
+	}
+	return in, false
+}
+
+// Leave implements Visitor interface.
+func (r *routineVarRestorer) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+func (v *routineVar) set(sctx sessionctx.Context, d types.Datum) error {
+	if d.IsNull() {
+		v.value.SetNull()
+		return nil
+	}
+	value, err := d.ConvertTo(sctx.GetSessionVars().StmtCtx, v.tp)
+	if err != nil {
+		return err
+	}
+	v.value = value
+	return nil
+}
+
+// isRoutineAssignTarget checks whether the expression can be the OUT or INOUT
+// argument of a stored procedure.
+func isRoutineAssignTarget(scope *routineScope, expr ast.ExprNode) bool {
+	switch x := expr.(type) {
+	case *ast.VariableExpr:
+		return !x.IsSystem
+	case *ast.ColumnNameExpr:
+		return x.Name.Table.L == "" && scope.lookupVar(x.Name.Name.L) != nil
+	}
+	return false
+}
+
+// assignRoutineTarget assigns the value to a local variable or a user variable.
+func assignRoutineTarget(sctx sessionctx.Context, scope *routineScope, target ast.ExprNode, d types.Datum, tp *types.FieldType) error {
+	switch x := target.(type) {
+	case *ast.VariableExpr:
+		if !x.IsSystem {
+			setUserVar(sctx, x.Name, d, tp)
+			return nil
+		}
+	case *ast.ColumnNameExpr:
+		if v := scope.lookupVar(x.Name.Name.L); v != nil && x.Name.Table.L == "" {
+			return v.set(sctx, d)
+		}
+		return ErrSpUndeclaredVar.GenWithStackByArgs(x.Name.Name.O)
+	}
+	return errors.Errorf("can't assign a value to %T", target)
+}
+
+// setUserVar sets the user variable, the type of it is inferred from the value
+// if tp is nil.
+func setUserVar(sctx sessionctx.Context, name string, d types.Datum, tp *types.FieldType) {
+	if tp == nil {
+		tp = new(types.FieldType)
+		types.DefaultTypeForValue(d.GetValue(), tp, mysql.DefaultCharset, mysql.DefaultCollationName)
+	}
+	sessionVars := sctx.GetSessionVars()
+	name = strings.ToLower(name)
+	sessionVars.UsersLock.Lock()
+	defer sessionVars.UsersLock.Unlock()
+	if d.IsNull() {
+		delete(sessionVars.Users, name)
+		delete(sessionVars.UserVarTypes, name)
+		return
+	}
+	sessionVars.Users[name] = *d.Clone()
+	sessionVars.UserVarTypes[name] = tp
+}
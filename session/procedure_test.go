@@ -0,0 +1,409 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/session"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoredProcedure(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v int)")
+	tk.MustExec(`create procedure fill(in n int, out total int, inout cnt int)
+begin
+	declare i int default 0;
+	set total = 0;
+	l: while i < n do
+		set i = i + 1;
+		if i = 3 then
+			iterate l;
+		end if;
+		insert into t values (i, i * 10);
+		set total = total + i, cnt = cnt + 1;
+	end while l;
+end`)
+	tk.MustExec("set @cnt = 100")
+	tk.MustExec("call fill(5, @total, @cnt)")
+	tk.MustQuery("select @total, @cnt").Check(testkit.Rows("12 104"))
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 10", "2 20", "4 40", "5 50"))
+
+	tk.MustGetErrCode("call fill(1, 2, @cnt)", errno.ErrSpNotVarArg)
+	tk.MustGetErrCode("call fill(1)", errno.ErrSpWrongNoOfArgs)
+	tk.MustGetErrCode("call nope()", errno.ErrSpDoesNotExist)
+
+	// Cursors and handlers.
+	tk.MustExec(`create procedure sum_t(out s int)
+begin
+	declare done int default 0;
+	declare x int;
+	declare c cursor for select v from t where id > 1 order by id;
+	declare continue handler for not found set done = 1;
+	set s = 0;
+	open c;
+	r: repeat
+		fetch c into x;
+		if not done then
+			set s = s + x;
+		end if;
+	until done end repeat r;
+	close c;
+end`)
+	tk.MustExec("call sum_t(@s)")
+	tk.MustQuery("select @s").Check(testkit.Rows("110"))
+	// The cursors left open are closed when their block ends, the memory of
+	// their rows is released from the session.
+	tk.MustExec(`create procedure first_v(out x int)
+begin
+	declare c cursor for select v from t order by id;
+	open c;
+	fetch c into x;
+end`)
+	tk.MustExec("call first_v(@x)")
+	tk.MustQuery("select @x").Check(testkit.Rows("10"))
+	require.Zero(t, tk.Session().GetSessionVars().MemTracker.BytesConsumed())
+	tk.MustExec("create procedure too_many(out x int) begin select v from t into x; end")
+	tk.MustGetErrCode("call too_many(@x)", errno.ErrTooManyRows)
+
+	tk.MustExec(`create procedure dup_key(out result varchar(20))
+begin
+	declare exit handler for 1062 set result = 'duplicated';
+	set result = 'inserted';
+	insert into t values (1, 1);
+	set result = 'unreachable';
+end`)
+	tk.MustExec("call dup_key(@r)")
+	tk.MustQuery("select @r").Check(testkit.Rows("duplicated"))
+
+	// SELECT ... INTO and nested CALL with local variables.
+	tk.MustExec(`create procedure max_v(out m int)
+begin
+	select max(v) from t into m;
+end`)
+	tk.MustExec(`create procedure outer_p()
+begin
+	declare m int;
+	call max_v(m);
+	select m + 1 into @m;
+end`)
+	tk.MustExec("call outer_p()")
+	tk.MustQuery("select @m").Check(testkit.Rows("51"))
+
+	// The variables are visible to the subqueries in the expressions.
+	tk.MustExec(`create procedure sub_q(in x int, out r int, out c varchar(10))
+begin
+	declare lo int default 1;
+	set r = (select v from t where id = x);
+	if (select count(*) from t where id > lo and id <= x) > 1 then
+		set c = 'many';
+	elseif exists (select 1 from t where id = x) then
+		set c = 'exists';
+	else
+		set c = 'none';
+	end if;
+end`)
+	for _, c := range []struct {
+		x    int
+		rows []string
+	}{{4, []string{"40 many"}}, {2, []string{"20 exists"}}, {3, []string{"<nil> none"}}} {
+		tk.MustExec(fmt.Sprintf("call sub_q(%d, @r, @c)", c.x))
+		tk.MustQuery("select @r, @c").Check(testkit.Rows(c.rows...))
+	}
+
+	// Recursion is limited by max_sp_recursion_depth.
+	tk.MustExec(`create procedure fact(in n int, out r int)
+begin
+	if n <= 1 then
+		set r = 1;
+	else
+		call fact(n - 1, r);
+		set r = r * n;
+	end if;
+end`)
+	tk.MustGetErrCode("call fact(3, @r)", errno.ErrSpRecursionLimit)
+	tk.MustExec("set max_sp_recursion_depth = 10")
+	tk.MustExec("call fact(5, @r)")
+	tk.MustQuery("select @r").Check(testkit.Rows("120"))
+
+	// Statements returning result sets fail without a client to receive them.
+	tk.MustExec("create procedure q() begin select 1; select id from t order by id limit 2; end")
+	tk.MustGetErrCode("call q()", errno.ErrSpBadselect)
+	var results []string
+	ctx := context.WithValue(context.Background(), session.CallResultHandlerKey, session.CallResultHandler(func(ctx context.Context, rs sqlexec.RecordSet) error {
+		rows, err := session.ResultSetToStringSlice(ctx, tk.Session(), rs)
+		if err != nil {
+			return err
+		}
+		results = append(results, fmt.Sprint(rows))
+		return nil
+	}))
+	stmts, err := tk.Session().Parse(ctx, "call q()")
+	require.NoError(t, err)
+	_, err = tk.Session().ExecuteStmt(ctx, stmts[0])
+	require.NoError(t, err)
+	require.Equal(t, []string{"[[1]]", "[[1] [2]]"}, results)
+
+	// Prepared CALL statements.
+	tk.MustExec("prepare s from 'call fact(?, @r)'")
+	tk.MustExec("set @n = 4")
+	tk.MustExec("execute s using @n")
+	tk.MustQuery("select @r").Check(testkit.Rows("24"))
+	tk.MustGetErrCode("execute s", errno.ErrWrongParamCount)
+	stmtID, _, _, err := tk.Session().PrepareStmt("call fact(?, @r)")
+	require.NoError(t, err)
+	_, err = tk.Session().ExecutePreparedStmt(context.Background(), stmtID, types.MakeDatums(6))
+	require.NoError(t, err)
+	tk.MustQuery("select @r").Check(testkit.Rows("720"))
+
+	tk.MustExec("drop procedure fill")
+	tk.MustGetErrCode("drop procedure fill", errno.ErrSpDoesNotExist)
+	tk.MustExec("drop procedure if exists fill")
+}
+
+func TestStoredProcedureSignal(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec(`create procedure check_positive(in n int)
+begin
+	if n <= 0 then
+		signal sqlstate '45000' set message_text = 'n must be positive', mysql_errno = 3001;
+	end if;
+end`)
+	tk.MustExec("call check_positive(1)")
+	tk.MustGetErrMsg("call check_positive(0)", "ERROR 3001 (45000): n must be positive")
+
+	tk.MustExec(`create procedure catch_signal(out r varchar(10))
+begin
+	declare exit handler for sqlstate '45000' set r = 'caught';
+	set r = 'none';
+	call check_positive(-1);
+end`)
+	tk.MustExec("call catch_signal(@r)")
+	tk.MustQuery("select @r").Check(testkit.Rows("caught"))
+}
+
+func TestStoredFunction(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec(`create function fib(n int) returns bigint deterministic
+begin
+	declare a, b, c bigint default 0;
+	set b = 1;
+	while n > 0 do
+		set c = a + b, a = b, b = c, n = n - 1;
+	end while;
+	return a;
+end`)
+	tk.MustQuery("select fib(10), test.fib(1)").Check(testkit.Rows("55 1"))
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("insert into t values (1), (2), (3), (4)")
+	tk.MustQuery("select a, fib(a) from t where fib(a) > 1 order by a").Check(testkit.Rows("3 2", "4 3"))
+	tk.MustGetErrCode("select fib()", errno.ErrSpWrongNoOfArgs)
+
+	tk.MustExec("create function r(n int) returns int return r(n)")
+	require.True(t, session.ErrSpNoRecursion.Equal(tk.QueryToErr("select r(1)")))
+	tk.MustExec("create function noreturn(n int) returns int begin if n > 0 then return n; end if; end")
+	tk.MustQuery("select noreturn(1)").Check(testkit.Rows("1"))
+	require.True(t, session.ErrSpNoreturnend.Equal(tk.QueryToErr("select noreturn(0)")))
+
+	tk.MustGetErrCode("create function bad() returns int begin select 1; return 1; end", errno.ErrSpNoRetset)
+	tk.MustGetErrCode("create function bad() returns int begin commit; return 1; end", errno.ErrCommitNotAllowedInSfOrTrg)
+	tk.MustGetErrCode("create function bad() returns int begin end", errno.ErrSpNoreturn)
+
+	// SQL statements in stored functions.
+	tk.MustExec(`create function cnt(lo int) returns int reads sql data
+begin
+	declare c int;
+	select count(*) from t where a >= lo into c;
+	return c;
+end`)
+	tk.MustQuery("select cnt(2), cnt(0) + 1").Check(testkit.Rows("3 5"))
+	tk.MustExec(`create function cnt_before_from(lo int) returns int reads sql data
+begin
+	declare c int;
+	select count(*) into c from t where a >= lo;
+	return c;
+end`)
+	tk.MustQuery("select cnt_before_from(2)").Check(testkit.Rows("3"))
+	tk.MustQuery("select a, cnt(a) from t order by a").Check(testkit.Rows("1 4", "2 3", "3 2", "4 1"))
+	// The table names in a function are resolved in the schema of the function.
+	tk.MustExec("create database d2")
+	tk.MustExec("use d2")
+	tk.MustQuery("select test.cnt(2)").Check(testkit.Rows("3"))
+	tk.MustExec("use test")
+	tk.MustExec(`create function sum_t() returns int reads sql data
+begin
+	declare done, s, x int default 0;
+	declare c cursor for select a from t;
+	declare continue handler for not found set done = 1;
+	open c;
+	l: loop
+		fetch c into x;
+		if done then
+			leave l;
+		end if;
+		set s = s + x;
+	end loop l;
+	close c;
+	return s;
+end`)
+	tk.MustQuery("select sum_t()").Check(testkit.Rows("10"))
+	tk.MustExec(`create function add_row(x int) returns int modifies sql data
+begin
+	insert into t values (x);
+	return cnt(0);
+end`)
+	tk.MustQuery("select add_row(5)").Check(testkit.Rows("5"))
+	tk.MustExec("begin")
+	tk.MustQuery("select add_row(a + 10) from t where a > 4").Check(testkit.Rows("6"))
+	tk.MustExec("rollback")
+	tk.MustQuery("select a from t order by a").Check(testkit.Rows("1", "2", "3", "4", "5"))
+	// The calls are rebuilt when the arguments are substituted by the optimizer.
+	tk.MustQuery("select * from (select a + 1 as b from t) x where cnt(b) > 2 order by b").Check(testkit.Rows("2", "3"))
+	tk.MustQuery("select * from t where cnt(a) > 3 and a = 1").Check(testkit.Rows("1"))
+	// The executors evaluating the functions don't run them concurrently.
+	tk.MustExec("create table t2 (a int, key(a))")
+	tk.MustExec("insert into t2 values (1), (3), (5)")
+	for _, hint := range []string{"inl_join", "inl_hash_join", "inl_merge_join"} {
+		tk.MustQuery(fmt.Sprintf("select /*+ %s(t2) */ t.a from t join t2 on t.a = t2.a and cnt(t.a) >= t2.a order by t.a", hint)).
+			Check(testkit.Rows("1", "3"))
+	}
+	tk.MustQuery("select cnt(a) from t union all select cnt(a) + 10 from t2 order by 1").
+		Check(testkit.Rows("1", "2", "3", "4", "5", "11", "13", "15"))
+
+	// The variables are visible to the subqueries in the expressions.
+	tk.MustExec(`create function next_a(x int) returns int reads sql data
+begin
+	declare r int;
+	set r = (select min(a) from t where a > x);
+	if exists (select 1 from t where a = r + 1) then
+		return (select count(*) from t where a > x);
+	end if;
+	return r;
+end`)
+	tk.MustQuery("select next_a(1), next_a(4), next_a(5)").Check(testkit.Rows("4 5 <nil>"))
+
+	// The functions called through a procedure are checked for recursion.
+	tk.MustExec("create procedure call_rec(out r int) select rec() into r")
+	tk.MustExec("create function rec() returns int begin declare r int; call call_rec(r); return r; end")
+	require.True(t, session.ErrSpNoRecursion.Equal(tk.QueryToErr("select rec()")))
+}
+
+func TestStoredRoutinePrivilege(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	require.True(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("create procedure ins() insert into t values (1)")
+	tk.MustExec("create procedure ins_invoker() sql security invoker insert into t values (2)")
+	tk.MustExec("create function one() returns int return 1")
+	tk.MustExec("create function cnt() returns int reads sql data begin declare c int; select count(*) from t into c; return c; end")
+	tk.MustExec("create function cnt_invoker() returns int sql security invoker reads sql data begin declare c int; select count(*) from t into c; return c; end")
+	tk.MustExec("create user 'u'@'%'")
+
+	tk1 := testkit.NewTestKit(t, store)
+	require.True(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u", Hostname: "%"}, nil, nil))
+	tk1.MustGetErrCode("call test.ins()", errno.ErrProcaccessDenied)
+	tk1.MustGetErrCode("select test.one()", errno.ErrProcaccessDenied)
+
+	tk.MustExec("grant execute on test.* to 'u'@'%'")
+	tk1.MustExec("call test.ins()")
+	tk1.MustQuery("select test.one()").Check(testkit.Rows("1"))
+	// The invoker can't access the table.
+	tk1.MustGetErrCode("call test.ins_invoker()", errno.ErrTableaccessDenied)
+	require.EqualError(t, tk1.QueryToErr("select test.cnt_invoker()"), "[planner:1142]SELECT command denied to user 'u'@'%' for table 't'")
+	tk1.MustQuery("select test.cnt()").Check(testkit.Rows("1"))
+	tk.MustQuery("select * from t").Check(testkit.Rows("1"))
+	tk1.MustGetErrCode("drop procedure test.ins", errno.ErrProcaccessDenied)
+
+	// Routine level privileges.
+	tk.MustExec("revoke execute on test.* from 'u'@'%'")
+	tk.MustExec("grant execute on procedure test.ins to 'u'@'%'")
+	tk.MustExec("grant execute, alter routine on function test.one to 'u'@'%'")
+	tk.MustQuery("show grants for 'u'@'%'").Check(testkit.Rows(
+		"GRANT USAGE ON *.* TO 'u'@'%'",
+		"GRANT ALL PRIVILEGES ON FUNCTION test.one TO 'u'@'%'",
+		"GRANT EXECUTE ON PROCEDURE test.ins TO 'u'@'%'",
+	))
+	tk1.MustExec("call test.ins()")
+	tk1.MustGetErrCode("call test.ins_invoker()", errno.ErrProcaccessDenied)
+	tk1.MustQuery("select test.one()").Check(testkit.Rows("1"))
+	tk1.MustQuery("select routine_name from information_schema.routines where routine_schema = 'test' order by routine_name").Check(testkit.Rows("ins", "one"))
+	tk1.MustGetErrCode("drop procedure test.ins", errno.ErrProcaccessDenied)
+	tk.MustExec("revoke execute on function test.one from 'u'@'%'")
+	tk1.MustGetErrCode("select test.one()", errno.ErrProcaccessDenied)
+	tk1.MustExec("drop function test.one")
+
+	tk.MustGetErrCode("grant execute on procedure test.one to 'u'@'%'", errno.ErrSpDoesNotExist)
+	tk.MustGetErrCode("grant select on procedure test.ins to 'u'@'%'", errno.ErrIllegalGrantForTable)
+	tk.MustGetErrCode("grant execute on procedure test.* to 'u'@'%'", errno.ErrIllegalGrantForTable)
+	tk.MustExec("drop user 'u'@'%'")
+	tk.MustQuery("select count(*) from mysql.procs_priv where user = 'u'").Check(testkit.Rows("0"))
+}
+
+func TestStoredRoutineInfoSchema(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	require.True(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create procedure p(in a int, out b varchar(10)) comment 'proc' set b = a")
+	tk.MustExec("create function f(x decimal(10, 2)) returns varchar(20) deterministic reads sql data return x")
+
+	tk.MustQuery("select routine_name, routine_type, data_type, character_maximum_length, dtd_identifier, routine_definition, is_deterministic, sql_data_access, security_type, routine_comment, definer " +
+		"from information_schema.routines where routine_schema = 'test' order by routine_name").Check(testkit.RowsWithSep("|",
+		"f|FUNCTION|varchar|20|varchar(20)|return x|YES|READS SQL DATA|DEFINER||root@%",
+		"p|PROCEDURE||<nil>|<nil>|set b = a|NO|CONTAINS SQL|DEFINER|proc|root@%",
+	))
+	tk.MustQuery("select specific_name, ordinal_position, parameter_mode, parameter_name, data_type, numeric_precision, numeric_scale, dtd_identifier, routine_type " +
+		"from information_schema.parameters where specific_schema = 'test' order by specific_name, ordinal_position").Check(testkit.RowsWithSep("|",
+		"f|0|<nil>|<nil>|varchar|<nil>|<nil>|varchar(20)|FUNCTION",
+		"f|1|IN|x|decimal|10|2|decimal(10,2)|FUNCTION",
+		"p|1|IN|a|int|11|0|int(11)|PROCEDURE",
+		"p|2|OUT|b|varchar|<nil>|<nil>|varchar(10)|PROCEDURE",
+	))
+
+	// The routines are invisible to the users without any privilege on them.
+	tk.MustExec("create user 'u'@'%'")
+	tk1 := testkit.NewTestKit(t, store)
+	require.True(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u", Hostname: "%"}, nil, nil))
+	tk1.MustQuery("select count(*) from information_schema.routines where routine_schema = 'test'").Check(testkit.Rows("0"))
+	tk1.MustQuery("select count(*) from information_schema.parameters where specific_schema = 'test'").Check(testkit.Rows("0"))
+	tk.MustExec("grant execute on test.* to 'u'@'%'")
+	tk1.MustQuery("select count(*) from information_schema.routines where routine_schema = 'test'").Check(testkit.Rows("2"))
+}
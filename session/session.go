@@ -221,6 +221,22 @@ type session struct {
 	ddlOwnerChecker owner.DDLOwnerChecker
 	// lockedTables use to record the table locks hold by the session.
 	lockedTables map[int64]model.TableLockTpInfo
+	// procedureDepth records the nesting depth of the running stored procedures.
+	procedureDepth map[int64]int
+	// functionIDs are the IDs of the stored functions whose SQL statements are
+	// running, the innermost one is the last.
+	functionIDs []int64
+	// functionBodies caches the parsed bodies of the stored functions by their
+	// IDs, for the schema version functionBodiesVersion.
+	functionBodies        map[int64]ast.StmtNode
+	functionBodiesVersion int64
+	// triggerTables are the IDs of the tables whose triggers are running, they
+	// can't be modified by the statements in the triggers.
+	triggerTables []int64
+	// triggerBodies caches the parsed bodies of the triggers by their IDs, for
+	// the schema version triggerBodiesVersion.
+	triggerBodies        map[int64]ast.StmtNode
+	triggerBodiesVersion int64
 
 	// client shared coprocessor client per session
 	client kv.Client
@@ -1060,7 +1076,7 @@ func (s *session) checkTxnAborted(stmt sqlexec.Statement) error {
 	if _, ok := stmt.(*executor.ExecStmt).StmtNode.(*ast.CommitStmt); ok {
 		return nil
 	}
-	if _, ok := stmt.(*executor.ExecStmt).StmtNode.(*ast.RollbackStmt); ok {
+	if rs, ok := stmt.(*executor.ExecStmt).StmtNode.(*ast.RollbackStmt); ok && rs.SavepointName == "" {
 		return nil
 	}
 	return err
@@ -1845,6 +1861,19 @@ func (s *session) ExecuteStmt(ctx context.Context, stmtNode ast.StmtNode) (sqlex
 	// Uncorrelated subqueries will execute once when building plan, so we reset process info before building plan.
 	cmd32 := atomic.LoadUint32(&s.GetSessionVars().CommandValue)
 	s.SetProcessInfo(stmtNode.Text(), time.Now(), byte(cmd32), 0)
+	// The statements in a stored procedure are executed by ExecuteStmt one by one.
+	if call, ok := stmtNode.(*ast.CallStmt); ok {
+		return nil, s.callProcedure(ctx, call, nil)
+	}
+	if execStmt, ok := stmtNode.(*ast.ExecuteStmt); ok {
+		if prepared, call, ok := s.preparedCallStmt(execStmt); ok {
+			args, err := s.evalUsingVars(execStmt.UsingVars)
+			if err != nil {
+				return nil, err
+			}
+			return nil, s.callPreparedProcedure(ctx, prepared, call, args)
+		}
+	}
 	s.txn.onStmtStart(digest.String())
 	defer s.txn.onStmtEnd()
 
@@ -2291,6 +2320,12 @@ func (s *session) ExecutePreparedStmt(ctx context.Context, stmtID uint32, args [
 	if !ok {
 		return nil, errors.Errorf("invalid CachedPrepareStmt type")
 	}
+	if call, ok := preparedStmt.PreparedAst.Stmt.(*ast.CallStmt); ok {
+		if err = executor.ResetContextOfStmt(s, &ast.ExecuteStmt{ExecID: stmtID, BinaryArgs: args}); err != nil {
+			return nil, err
+		}
+		return nil, s.callPreparedProcedure(ctx, preparedStmt.PreparedAst, call, args)
+	}
 
 	var is infoschema.InfoSchema
 	var snapshotTS uint64
@@ -2552,6 +2587,7 @@ func (s *session) Close() {
 		s.idxUsageCollector.Delete()
 	}
 	telemetry.GlobalBuiltinFunctionsUsage.Collect(s.GetBuiltinFunctionUsage())
+	s.sessionVars.MemTracker.DetachFromGlobalTracker()
 	bindValue := s.Value(bindinfo.SessionBindInfoKeyType)
 	if bindValue != nil {
 		bindValue.(*bindinfo.SessionHandle).Close()
@@ -2969,6 +3005,7 @@ func createSessionWithOpt(store kv.Storage, opt *Opt) (*session, error) {
 	domain.BindDomain(s, dom)
 	// session implements variable.GlobalVarAccessor. Bind it to ctx.
 	s.sessionVars.GlobalVarsAccessor = s
+	s.sessionVars.MemTracker.AttachToGlobalTracker(executor.GlobalMemoryUsageTracker)
 	s.sessionVars.BinlogClient = binloginfo.GetPumpsClient()
 	s.txn.init()
 
@@ -2999,6 +3036,7 @@ func CreateSessionWithDomain(store kv.Storage, dom *domain.Domain) (*session, er
 	domain.BindDomain(s, dom)
 	// session implements variable.GlobalVarAccessor. Bind it to ctx.
 	s.sessionVars.GlobalVarsAccessor = s
+	s.sessionVars.MemTracker.AttachToGlobalTracker(executor.GlobalMemoryUsageTracker)
 	s.txn.init()
 	return s, nil
 }
@@ -220,7 +220,7 @@ func recordAbortTxnDuration(sessVars *variable.SessionVars) {
 
 func finishStmt(ctx context.Context, se *session, meetsErr error, sql sqlexec.Statement) error {
 	sessVars := se.sessionVars
-	if !sql.IsReadOnly(sessVars) {
+	if !sql.IsReadOnly(sessVars) || sessVars.StmtCtx.WrittenByNestedStmt {
 		// All the history should be added here.
 		if meetsErr == nil && sessVars.TxnCtx.CouldRetry {
 			GetHistory(se).Add(sql, sessVars.StmtCtx)
@@ -371,4 +371,30 @@ func ResultSetToStringSlice(ctx context.Context, s Session, rs sqlexec.RecordSet
 // Session errors.
 var (
 	ErrForUpdateCantRetry = dbterror.ClassSession.NewStd(errno.ErrForUpdateCantRetry)
+
+	// Stored routine errors.
+	ErrSpBadselect                  = dbterror.ClassSession.NewStd(errno.ErrSpBadselect)
+	ErrSpNotVarArg                  = dbterror.ClassSession.NewStd(errno.ErrSpNotVarArg)
+	ErrSpRecursionLimit             = dbterror.ClassSession.NewStd(errno.ErrSpRecursionLimit)
+	ErrSpNoRecursion                = dbterror.ClassSession.NewStd(errno.ErrSpNoRecursion)
+	ErrSpNoreturnend                = dbterror.ClassSession.NewStd(errno.ErrSpNoreturnend)
+	ErrSpFetchNoData                = dbterror.ClassSession.NewStd(errno.ErrSpFetchNoData)
+	ErrSpWrongNoOfFetchArgs         = dbterror.ClassSession.NewStd(errno.ErrSpWrongNoOfFetchArgs)
+	ErrSpCursorMismatch             = dbterror.ClassSession.NewStd(errno.ErrSpCursorMismatch)
+	ErrSpCursorNotOpen              = dbterror.ClassSession.NewStd(errno.ErrSpCursorNotOpen)
+	ErrSpCursorAlreadyOpen          = dbterror.ClassSession.NewStd(errno.ErrSpCursorAlreadyOpen)
+	ErrSpUndeclaredVar              = dbterror.ClassSession.NewStd(errno.ErrSpUndeclaredVar)
+	ErrSpDupVar                     = dbterror.ClassSession.NewStd(errno.ErrSpDupVar)
+	ErrSpDupCurs                    = dbterror.ClassSession.NewStd(errno.ErrSpDupCurs)
+	ErrSpBadSQLstate                = dbterror.ClassSession.NewStd(errno.ErrSpBadSQLstate)
+	ErrTooManyRows                  = dbterror.ClassSession.NewStd(errno.ErrTooManyRows)
+	ErrWrongNumberOfColumnsInSelect = dbterror.ClassSession.NewStd(errno.ErrWrongNumberOfColumnsInSelect)
+	ErrNoSuchUser                   = dbterror.ClassSession.NewStd(errno.ErrNoSuchUser)
+	// Trigger errors.
+	ErrSpNoRetset                   = dbterror.ClassSession.NewStd(errno.ErrSpNoRetset)
+	ErrTrgCantChangeRow             = dbterror.ClassSession.NewStd(errno.ErrTrgCantChangeRow)
+	ErrTrgNoSuchRowInTrg            = dbterror.ClassSession.NewStd(errno.ErrTrgNoSuchRowInTrg)
+	ErrCantUpdateUsedTableInSfOrTrg = dbterror.ClassSession.NewStd(errno.ErrCantUpdateUsedTableInSfOrTrg)
+	ErrCommitNotAllowedInSfOrTrg    = dbterror.ClassSession.NewStd(errno.ErrCommitNotAllowedInSfOrTrg)
+	ErrUnknownTriggerColumn         = dbterror.ClassSession.NewStd(errno.ErrBadField)
 )
@@ -0,0 +1,267 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/executor"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/sqlexec"
+)
+
+func init() {
+	executor.RunTriggers = runTriggers
+}
+
+// runTriggers runs the triggers of the table activated by the event at the timing.
+func runTriggers(ctx context.Context, sctx sessionctx.Context, tbl table.Table, timing model.TriggerTiming, event model.TriggerEvent, oldRow, newRow []types.Datum) error {
+	s, ok := sctx.(*session)
+	if !ok {
+		return errors.New("triggers are not supported in this context")
+	}
+	tblInfo := tbl.Meta()
+	for _, id := range s.triggerTables {
+		if id == tblInfo.ID {
+			return ErrCantUpdateUsedTableInSfOrTrg.GenWithStackByArgs(tblInfo.Name.O)
+		}
+	}
+	var schema model.CIStr
+	for _, trigger := range tblInfo.Triggers {
+		if trigger.Timing != timing || trigger.Event != event {
+			continue
+		}
+		if schema.L == "" {
+			is, ok := s.GetInfoSchema().(infoschema.InfoSchema)
+			if !ok {
+				return errors.New("the info schema is not available")
+			}
+			dbInfo, ok := is.SchemaByTable(tblInfo)
+			if !ok {
+				return infoschema.ErrTableNotExists.GenWithStackByArgs("", tblInfo.Name.O)
+			}
+			schema = dbInfo.Name
+		}
+		rows := &triggerRows{cols: tbl.Cols(), timing: timing, event: event, oldRow: oldRow, newRow: newRow}
+		if err := s.runTrigger(ctx, schema, tblInfo.ID, trigger, rows); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runTrigger runs the body of the trigger like a stored procedure with the
+// privileges of its definer.
+func (s *session) runTrigger(ctx context.Context, schema model.CIStr, tableID int64, trigger *model.TriggerInfo, rows *triggerRows) error {
+	routine := &model.RoutineInfo{
+		ID:       trigger.ID,
+		Name:     trigger.Name,
+		Type:     model.RoutineProcedure,
+		Body:     trigger.Body,
+		Definer:  trigger.Definer,
+		Security: model.SecurityDefiner,
+		SQLMode:  trigger.SQLMode,
+		Charset:  trigger.Charset,
+		Collate:  trigger.Collate,
+	}
+	if version := s.GetInfoSchema().SchemaMetaVersion(); version != s.triggerBodiesVersion {
+		// The triggers may be changed with the schema, their bodies are parsed again.
+		s.triggerBodies, s.triggerBodiesVersion = nil, version
+	}
+	body, ok := s.triggerBodies[trigger.ID]
+	if !ok {
+		var err error
+		if body, err = parseRoutineBody(s, routine); err != nil {
+			return err
+		}
+		if s.triggerBodies == nil {
+			s.triggerBodies = make(map[int64]ast.StmtNode)
+		}
+		s.triggerBodies[trigger.ID] = body
+	}
+	restore, err := s.enterProcedure(schema, routine)
+	if err != nil {
+		return err
+	}
+	defer restore()
+	s.triggerTables = append(s.triggerTables, tableID)
+	defer func() {
+		s.triggerTables = s.triggerTables[:len(s.triggerTables)-1]
+	}()
+
+	scope := newRoutineScope(nil)
+	scope.trigger = rows
+	e := &routineExec{sctx: s, s: s, routine: routine, trigger: trigger}
+	if _, err := e.execStmt(ctx, scope, body); err != nil {
+		return unwrapRoutineError(err)
+	}
+	return nil
+}
+
+// triggerRows are the rows a trigger is activated for, the trigger body
+// accesses them by NEW.col and OLD.col.
+type triggerRows struct {
+	cols   []*table.Column
+	timing model.TriggerTiming
+	event  model.TriggerEvent
+	oldRow []types.Datum
+	newRow []types.Datum
+}
+
+// column finds the column referred by NEW.col or OLD.col. It returns a nil
+// column if the name doesn't refer to the rows.
+func (r *triggerRows) column(schema, tbl, name string) (*table.Column, *types.Datum, error) {
+	var (
+		row   []types.Datum
+		which string
+	)
+	switch {
+	case schema != "":
+		return nil, nil, nil
+	case strings.EqualFold(tbl, "new"):
+		row, which = r.newRow, "NEW"
+	case strings.EqualFold(tbl, "old"):
+		row, which = r.oldRow, "OLD"
+	default:
+		return nil, nil, nil
+	}
+	if row == nil {
+		return nil, nil, ErrTrgNoSuchRowInTrg.GenWithStackByArgs(which, r.event.String())
+	}
+	for i, col := range r.cols {
+		if col.Name.L == strings.ToLower(name) {
+			return col, &row[i], nil
+		}
+	}
+	return nil, nil, ErrUnknownTriggerColumn.GenWithStackByArgs(name, which)
+}
+
+// setNewColumn sets the value of NEW.col by the SET statement in a BEFORE trigger.
+func (e *routineExec) setNewColumn(ctx context.Context, scope *routineScope, rows *triggerRows, assign *ast.VariableAssignment, tbl, name string) error {
+	if strings.EqualFold(tbl, "old") {
+		return ErrTrgCantChangeRow.GenWithStackByArgs("OLD", "")
+	}
+	if rows.timing == model.TriggerAfter {
+		return ErrTrgCantChangeRow.GenWithStackByArgs("NEW", "after ")
+	}
+	col, value, err := rows.column("", tbl, name)
+	if err != nil {
+		return err
+	}
+	d, err := e.evalExpr(ctx, scope, assign.Value)
+	if err != nil {
+		return err
+	}
+	v, err := table.CastValue(e.sctx, d, col.ToInfo(), false, false)
+	if err != nil {
+		return err
+	}
+	*value = v
+	return nil
+}
+
+// splitTriggerColumn splits the name of a variable assigned by the SET
+// statement into the row and the column if it's NEW.col or OLD.col.
+func splitTriggerColumn(name string) (tbl, col string, ok bool) {
+	idx := strings.IndexByte(name, '.')
+	if idx < 0 {
+		return "", "", false
+	}
+	tbl, col = name[:idx], name[idx+1:]
+	return tbl, col, strings.EqualFold(tbl, "new") || strings.EqualFold(tbl, "old")
+}
+
+// executeNestedStmt executes a statement in a trigger. The statement runs in
+// the transaction of the statement which activates the trigger, whose statement
+// context is restored after the nested statement finishes. The changes of the
+// nested statement are discarded if it fails.
+func (s *session) executeNestedStmt(ctx context.Context, stmtNode ast.StmtNode) (sqlexec.RecordSet, error) {
+	switch stmtNode.(type) {
+	case ast.DDLNode, *ast.BeginStmt, *ast.CommitStmt, *ast.RollbackStmt:
+		return nil, ErrCommitNotAllowedInSfOrTrg.GenWithStackByArgs()
+	}
+	vars := s.sessionVars
+	outerStmtCtx, outerParams := vars.StmtCtx, vars.PreparedParams
+	vars.NestedStmtLevel++
+	restore := func() {
+		sc := vars.StmtCtx
+		if sc != outerStmtCtx {
+			outerStmtCtx.AppendWarnings(sc.GetWarnings())
+			if sc.MemTracker != nil {
+				sc.MemTracker.DetachFromGlobalTracker()
+			}
+			if sc.DiskTracker != nil {
+				sc.DiskTracker.DetachFromGlobalTracker()
+			}
+		}
+		vars.StmtCtx, vars.PreparedParams = outerStmtCtx, outerParams
+		vars.NestedStmtLevel--
+	}
+
+	if err := executor.ResetContextOfStmt(s, stmtNode); err != nil {
+		restore()
+		return nil, err
+	}
+	compiler := executor.Compiler{Ctx: s}
+	stmt, err := compiler.Compile(ctx, stmtNode)
+	if err != nil {
+		restore()
+		return nil, err
+	}
+	txn, err := s.Txn(true)
+	if err != nil {
+		restore()
+		return nil, err
+	}
+	memBuffer := txn.GetMemBuffer()
+	sh := memBuffer.Staging()
+	rs, err := stmt.Exec(ctx)
+	if err != nil {
+		memBuffer.Cleanup(sh)
+		restore()
+		return nil, err
+	}
+	memBuffer.Release(sh)
+	if rs == nil {
+		restore()
+		return nil, nil
+	}
+	return &nestedStmtResult{RecordSet: rs, restore: restore}, nil
+}
+
+// nestedStmtResult restores the statement context of the outer statement when
+// the result set of a nested statement is closed.
+type nestedStmtResult struct {
+	sqlexec.RecordSet
+	restore func()
+}
+
+func (rs *nestedStmtResult) Close() error {
+	err := rs.RecordSet.Close()
+	rs.restore()
+	return err
+}
+
+// inTrigger checks whether the session is running triggers.
+func (s *session) inTrigger() bool {
+	return len(s.triggerTables) > 0
+}
@@ -0,0 +1,186 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrigger(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v int, s varchar(10))")
+	tk.MustExec("create table audit (id int auto_increment primary key, action varchar(10), old_v int, new_v int)")
+	tk.MustExec("create trigger t_bi before insert on t for each row set new.v = ifnull(new.v, 0) * 10, new.s = upper(new.s)")
+	tk.MustExec("create trigger t_ai after insert on t for each row insert into audit (action, new_v) values ('insert', new.v)")
+	tk.MustExec(`create trigger t_bu before update on t for each row
+begin
+	if new.v < 0 then
+		signal sqlstate '45000' set message_text = 'negative value';
+	end if;
+	set new.s = concat(old.s, '+');
+end`)
+	tk.MustExec("create trigger t_au after update on t for each row insert into audit (action, old_v, new_v) values ('update', old.v, new.v)")
+	tk.MustExec("create trigger t_ad after delete on t for each row insert into audit (action, old_v) values ('delete', old.v)")
+
+	tk.MustExec("insert into t values (1, 1, 'a'), (2, null, 'b')")
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 10 A", "2 0 B"))
+	tk.MustExec("update t set v = v + 1 where id = 1")
+	tk.MustQuery("select * from t where id = 1").Check(testkit.Rows("1 11 A+"))
+	tk.MustGetErrMsg("update t set v = -1 where id = 1", "ERROR 1644 (45000): negative value")
+	tk.MustExec("delete from t where id = 2")
+	tk.MustExec("insert into t values (3, 3, 'c') on duplicate key update v = 100")
+	tk.MustQuery("select action, old_v, new_v from audit order by id").Check(testkit.Rows(
+		"insert <nil> 10",
+		"insert <nil> 0",
+		"update 10 11",
+		"delete 0 <nil>",
+		"insert <nil> 30",
+	))
+
+	// The triggers run in the transaction of the statement.
+	tk.MustExec("begin")
+	tk.MustExec("insert into t values (4, 4, 'd')")
+	tk.MustExec("rollback")
+	tk.MustQuery("select count(*) from audit").Check(testkit.Rows("5"))
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("2"))
+	// A failed trigger fails the statement.
+	tk.MustExec("create trigger t_bd before delete on t for each row signal sqlstate '45000'")
+	tk.MustGetErrMsg("delete from t", "ERROR 1644 (45000): Unhandled user-defined exception condition")
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("2"))
+	tk.MustQuery("select count(*) from audit").Check(testkit.Rows("5"))
+
+	tk.MustGetErrCode("create trigger t_ai after insert on t for each row set @a = 1", errno.ErrTrgAlreadyExists)
+	tk.MustExec("create trigger if not exists t_ai after insert on t for each row set @a = 1")
+	tk.MustGetErrCode("create trigger bad after insert on t for each row set new.v = 1", errno.ErrTrgCantChangeRow)
+	tk.MustGetErrCode("create trigger bad before insert on t for each row set old.v = 1", errno.ErrTrgCantChangeRow)
+	tk.MustGetErrCode("create trigger bad before insert on t for each row set @a = old.v", errno.ErrTrgNoSuchRowInTrg)
+	tk.MustGetErrCode("create trigger bad before delete on t for each row insert into audit (new_v) values (new.v)", errno.ErrTrgNoSuchRowInTrg)
+	tk.MustGetErrCode("create trigger bad before update on t for each row set new.nope = 1", errno.ErrBadField)
+	tk.MustGetErrCode("create trigger bad before insert on t for each row follows nope set @a = 1", errno.ErrReferencedTrgDoesNotExist)
+	tk.MustGetErrCode("create trigger bad before insert on t for each row return 1", errno.ErrSpBadreturn)
+	tk.MustGetErrCode("create trigger bad before insert on test.nope for each row set @a = 1", errno.ErrNoSuchTable)
+	tk.MustGetErrCode("create trigger other.bad before insert on test.t for each row set @a = 1", errno.ErrTrgInWrongSchema)
+	tk.MustExec("create view v as select * from t")
+	tk.MustGetErrCode("create trigger bad before insert on v for each row set @a = 1", errno.ErrTrgOnViewOrTempTable)
+	tk.MustExec("drop trigger t_ai")
+	tk.MustGetErrCode("drop trigger t_ai", errno.ErrTrgDoesNotExist)
+	tk.MustExec("drop trigger if exists t_ai")
+}
+
+func TestTriggerErrors(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("create table t2 (a int)")
+	tk.MustExec("create trigger t_ai after insert on t for each row insert into t values (new.a)")
+	tk.MustGetErrCode("insert into t values (1)", errno.ErrCantUpdateUsedTableInSfOrTrg)
+	tk.MustExec("drop trigger t_ai")
+	tk.MustExec("create trigger t_ai after insert on t for each row select 1")
+	tk.MustGetErrCode("insert into t values (1)", errno.ErrSpNoRetset)
+	tk.MustExec("drop trigger t_ai")
+	tk.MustExec("create trigger t_ai after insert on t for each row commit")
+	tk.MustGetErrCode("insert into t values (1)", errno.ErrCommitNotAllowedInSfOrTrg)
+	tk.MustExec("drop trigger t_ai")
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("0"))
+
+	// The triggers are activated in the order of FOLLOWS and PRECEDES.
+	tk.MustExec("create trigger t1 before insert on t for each row set new.a = new.a * 2")
+	tk.MustExec("create trigger t2 before insert on t for each row precedes t1 set new.a = new.a + 1")
+	tk.MustExec("create trigger t3 before insert on t for each row follows t2 set new.a = new.a * 10")
+	tk.MustExec("create trigger t4 after insert on t for each row insert into t2 values (new.a)")
+	tk.MustExec("insert into t values (1)")
+	tk.MustQuery("select a from t").Check(testkit.Rows("40"))
+	tk.MustQuery("select a from t2").Check(testkit.Rows("40"))
+	tk.MustGetErrCode("rename table t to mysql.t", errno.ErrTrgInWrongSchema)
+}
+
+func TestTriggerGeneratedColumns(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, a int, b int, g int as (a * 2) virtual, s int as (a + 1) stored, index ig (g), index is_ (s))")
+	tk.MustExec("create trigger t_bi before insert on t for each row set new.a = new.b * 10")
+	tk.MustExec("create trigger t_bu before update on t for each row set new.a = new.b * 100")
+
+	// The generated columns are evaluated with the values set by the triggers.
+	tk.MustExec("insert into t (id, a, b) values (1, 0, 1)")
+	tk.MustQuery("select a, g, s from t").Check(testkit.Rows("10 20 11"))
+	tk.MustExec("update t set b = 2 where id = 1")
+	tk.MustQuery("select a, g, s from t").Check(testkit.Rows("200 400 201"))
+	tk.MustExec("insert into t (id, a, b) values (1, 0, 0) on duplicate key update b = 3")
+	tk.MustQuery("select a, g, s from t").Check(testkit.Rows("300 600 301"))
+	tk.MustQuery("select id from t use index (ig) where g = 600").Check(testkit.Rows("1"))
+	tk.MustQuery("select id from t use index (is_) where s = 301").Check(testkit.Rows("1"))
+	tk.MustExec("admin check table t")
+}
+
+func TestBeforeInsertTriggerEachRow(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, n int, g int as (n + 1) stored)")
+	tk.MustExec("create table src (id int)")
+	tk.MustExec("insert into src values (4), (5), (6)")
+	// The trigger for a row sees the rows added before it by the same statement.
+	tk.MustExec("create trigger t_bi before insert on t for each row begin declare c int; select count(*) from t into c; set new.n = c; end")
+	tk.MustExec("insert into t (id) values (1), (2), (3)")
+	tk.MustQuery("select id, n, g from t order by id").Check(testkit.Rows("1 0 1", "2 1 2", "3 2 3"))
+	tk.MustExec("insert into t (id) select id from src")
+	tk.MustQuery("select id, n, g from t where id > 3 order by id").Check(testkit.Rows("4 3 4", "5 4 5", "6 5 6"))
+	tk.MustExec("replace into t (id) values (1), (7)")
+	tk.MustQuery("select id, n from t where id in (1, 7) order by id").Check(testkit.Rows("1 6", "7 6"))
+	tk.MustExec("admin check table t")
+}
+
+func TestShowTriggers(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("create table t2 (a int)")
+	tk.MustExec("create trigger t_bi before insert on t for each row set new.a = new.a + 1")
+	tk.MustExec("create trigger t_bi2 before insert on t for each row set new.a = new.a * 2")
+	tk.MustExec("create trigger t2_ad after delete on t2 for each row set @a = old.a")
+
+	tk.MustQuery("select trigger_name, event_manipulation, event_object_table, action_order, action_statement, action_timing from information_schema.triggers where trigger_schema = 'test' order by trigger_name").Check(testkit.RowsWithSep("|",
+		"t2_ad|DELETE|t2|1|set @a = old.a|AFTER",
+		"t_bi|INSERT|t|1|set new.a = new.a + 1|BEFORE",
+		"t_bi2|INSERT|t|2|set new.a = new.a * 2|BEFORE",
+	))
+	tk.MustQuery("show triggers like 't2'").CheckAt([]int{0, 1, 2, 3, 4}, testkit.RowsWithSep("|", "t2_ad|DELETE|t2|set @a = old.a|AFTER"))
+	tk.MustQuery("show triggers").CheckAt([]int{0, 2}, testkit.Rows("t_bi t", "t_bi2 t", "t2_ad t2"))
+	tk.MustQuery("show create trigger t_bi").CheckAt([]int{0, 2}, testkit.RowsWithSep("|",
+		"t_bi|CREATE TRIGGER `t_bi` BEFORE INSERT ON `t` FOR EACH ROW set new.a = new.a + 1"))
+	err := tk.QueryToErr("show create trigger nope")
+	require.True(t, infoschema.ErrTriggerNotExists.Equal(err))
+}
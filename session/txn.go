@@ -189,6 +189,9 @@ func (txn *LazyTxn) GoString() string {
 		if len(txn.mutations) > 0 {
 			fmt.Fprintf(&s, ", len(mutations)=%d, %#v", len(txn.mutations), txn.mutations)
 		}
+		if savepoints := txn.Transaction.Savepoints(); len(savepoints) > 0 {
+			fmt.Fprintf(&s, ", savepoints=%v", savepoints)
+		}
 	} else {
 		s.WriteString("state=invalid")
 	}
@@ -376,6 +379,41 @@ func (txn *LazyTxn) Rollback() error {
 	return txn.Transaction.Rollback()
 }
 
+// Savepoint overrides the Transaction interface.
+func (txn *LazyTxn) Savepoint(name string) {
+	txn.Transaction.Savepoint(name)
+
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	txn.mu.TxnInfo.Savepoints = txn.Transaction.Savepoints()
+}
+
+// RollbackToSavepoint overrides the Transaction interface.
+// The statement buffer is discarded before rolling back and recreated after that, otherwise the restored values
+// would be taken as the modifications of the statement. The statement itself doesn't modify the buffer.
+func (txn *LazyTxn) RollbackToSavepoint(name string) (bool, error) {
+	txn.cleanupStmtBuf()
+	ok, err := txn.Transaction.RollbackToSavepoint(name)
+	txn.initStmtBuf()
+
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	txn.mu.TxnInfo.EntriesCount = uint64(txn.Transaction.Len())
+	txn.mu.TxnInfo.EntriesSize = uint64(txn.Transaction.Size())
+	txn.mu.TxnInfo.Savepoints = txn.Transaction.Savepoints()
+	return ok, err
+}
+
+// ReleaseSavepoint overrides the Transaction interface.
+func (txn *LazyTxn) ReleaseSavepoint(name string) bool {
+	ok := txn.Transaction.ReleaseSavepoint(name)
+
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	txn.mu.TxnInfo.Savepoints = txn.Transaction.Savepoints()
+	return ok
+}
+
 // LockKeys Wrap the inner transaction's `LockKeys` to record the status
 func (txn *LazyTxn) LockKeys(ctx context.Context, lockCtx *kv.LockCtx, keys ...kv.Key) error {
 	failpoint.Inject("beforeLockKeys", func() {})
@@ -99,6 +99,8 @@ type TxnInfo struct {
 	EntriesCount uint64
 	// MemDB used memory
 	EntriesSize uint64
+	// Names of the savepoints in the order they are created
+	Savepoints []string
 
 	// The following fields will be filled in `session` instead of `LazyTxn`
 
@@ -65,6 +65,10 @@ type StatementContext struct {
 	// Set the following variables before execution
 	StmtHints
 
+	// MultiSchemaInfo is used to collect the sub-jobs of a multi-schema change
+	// when there are more than one specs in an ALTER TABLE statement.
+	MultiSchemaInfo *model.MultiSchemaInfo
+
 	// IsDDLJobInQueue is used to mark whether the DDL job is put into the queue.
 	// If IsDDLJobInQueue is true, it means the DDL job is in the queue of storage, and it can be handled by the DDL worker.
 	IsDDLJobInQueue        bool
@@ -98,6 +102,9 @@ type StatementContext struct {
 	// or is affected by the tidb_read_staleness session variable, then the statement will be makred as isStaleness
 	// in stmtCtx
 	IsStaleness bool
+	// WrittenByNestedStmt is set if a nested statement executed by a trigger or a stored
+	// function modifies data, the statement can't be treated as read-only when it finishes.
+	WrittenByNestedStmt bool
 	// mu struct holds variables that change during execution.
 	mu struct {
 		sync.Mutex
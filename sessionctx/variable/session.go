@@ -47,6 +47,7 @@ import (
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/execdetails"
 	utilMath "github.com/pingcap/tidb/util/math"
+	"github.com/pingcap/tidb/util/memory"
 	"github.com/pingcap/tidb/util/rowcodec"
 	"github.com/pingcap/tidb/util/stringutil"
 	"github.com/pingcap/tidb/util/tableutil"
@@ -579,6 +580,14 @@ type SessionVars struct {
 	// StmtCtx holds variables for current executing statement.
 	StmtCtx *stmtctx.StatementContext
 
+	// NestedStmtLevel is the nesting level of the statement executed by the
+	// triggers of other statements, whose statement contexts are still in use.
+	NestedStmtLevel int
+
+	// MemTracker tracks the memory held by the session across statements,
+	// e.g. the rows of the opened cursors.
+	MemTracker *memory.Tracker
+
 	// AllowAggPushDown can be set to false to forbid aggregation push down.
 	AllowAggPushDown bool
 
@@ -758,6 +767,9 @@ type SessionVars struct {
 	// ConstraintCheckInPlace indicates whether to check the constraint when the SQL executing.
 	ConstraintCheckInPlace bool
 
+	// ForeignKeyChecks indicates whether to check the foreign key constraints and perform the referential actions when writing rows.
+	ForeignKeyChecks bool
+
 	// CommandValue indicates which command current session is doing.
 	CommandValue uint32
 
@@ -938,6 +950,15 @@ type SessionVars struct {
 	// EnableIndexMergeJoin indicates whether to enable index merge join.
 	EnableIndexMergeJoin bool
 
+	// EnableNonPreparedPlanCache indicates whether to cache the plans of the plain text statements.
+	EnableNonPreparedPlanCache bool
+
+	// EnableGraceHashJoin indicates whether hash join partitions both sides into disk when the memory quota is exceeded.
+	EnableGraceHashJoin bool
+
+	// MaxOpenCursors is the maximum number of the server-side cursors which can be open at the same time, 0 means no limit.
+	MaxOpenCursors int
+
 	// TrackAggregateMemoryUsage indicates whether to track the memory usage of aggregate function.
 	TrackAggregateMemoryUsage bool
 
@@ -1169,6 +1190,7 @@ func NewSessionVars() *SessionVars {
 		AutoIncrementOffset:         DefAutoIncrementOffset,
 		Status:                      mysql.ServerStatusAutocommit,
 		StmtCtx:                     new(stmtctx.StatementContext),
+		MemTracker:                  memory.NewTracker(memory.LabelForSession, -1),
 		AllowAggPushDown:            false,
 		AllowCartesianBCJ:           DefOptCartesianBCJ,
 		MPPOuterJoinFixedBuildSide:  DefOptMPPOuterJoinFixedBuildSide,
@@ -1233,6 +1255,9 @@ func NewSessionVars() *SessionVars {
 		GuaranteeLinearizability:    DefTiDBGuaranteeLinearizability,
 		AnalyzeVersion:              DefTiDBAnalyzeVersion,
 		EnableIndexMergeJoin:        DefTiDBEnableIndexMergeJoin,
+		EnableNonPreparedPlanCache:  DefTiDBEnableNonPreparedPlanCache,
+		EnableGraceHashJoin:         DefTiDBEnableGraceHashJoin,
+		MaxOpenCursors:              DefTiDBMaxOpenCursors,
 		AllowFallbackToTiKV:         make(map[kv.StoreType]struct{}),
 		CTEMaxRecursionDepth:        DefCTEMaxRecursionDepth,
 		TMPTableSize:                DefTiDBTmpTableMaxSize,
@@ -638,6 +638,21 @@ const (
 	TiDBStatsLoadPseudoTimeout = "tidb_stats_load_pseudo_timeout"
 	// TiDBMemQuotaBindCache indicates the memory quota for the bind cache.
 	TiDBMemQuotaBindCache = "tidb_mem_quota_bind_cache"
+	// TiDBServerMemoryLimit indicates the memory limit of the tidb-server instance, 0 means no limit.
+	// When the limit is exceeded, the query using the most memory is asked to spill and then cancelled.
+	TiDBServerMemoryLimit = "tidb_server_memory_limit"
+	// TiDBServerMemoryLimitSessMinSize indicates the minimal memory usage of a query which can be cancelled
+	// when the memory usage of the tidb-server instance exceeds TiDBServerMemoryLimit.
+	TiDBServerMemoryLimitSessMinSize = "tidb_server_memory_limit_sess_min_size"
+	// TiDBEnableNonPreparedPlanCache indicates whether to cache the plans of the plain text statements.
+	// It only takes effect when the prepared plan cache is enabled in the config.
+	TiDBEnableNonPreparedPlanCache = "tidb_enable_non_prepared_plan_cache"
+	// TiDBEnableGraceHashJoin indicates whether hash join partitions both the build side and the probe side
+	// into disk when the memory quota is exceeded. It only takes effect when oom-use-tmp-storage is enabled.
+	TiDBEnableGraceHashJoin = "tidb_enable_grace_hash_join"
+	// TiDBMaxOpenCursors indicates the maximum number of the server-side cursors which can be open at the same
+	// time in a connection, 0 means no limit.
+	TiDBMaxOpenCursors = "tidb_max_open_cursors"
 )
 
 // TiDB intentional limits
@@ -698,6 +713,11 @@ const (
 	DefWaitTimeout                        = 28800
 	DefTiDBMemQuotaApplyCache             = 32 << 20 // 32MB.
 	DefTiDBMemQuotaBindCache              = 64 << 20 // 64MB.
+	DefTiDBServerMemoryLimit              = 0
+	DefTiDBServerMemoryLimitSessMinSize   = 128 << 20 // 128MB.
+	DefTiDBEnableNonPreparedPlanCache     = false
+	DefTiDBEnableGraceHashJoin            = false
+	DefTiDBMaxOpenCursors                 = 64
 	DefTiDBGeneralLog                     = false
 	DefTiDBPProfSQLCPU                    = 0
 	DefTiDBRetryLimit                     = 10
@@ -720,7 +740,7 @@ const (
 	DefTiDBDDLReorgBatchSize              = 256
 	DefTiDBDDLErrorCountLimit             = 512
 	DefTiDBMaxDeltaSchemaCount            = 1024
-	DefTiDBChangeMultiSchema              = false
+	DefTiDBChangeMultiSchema              = true
 	DefTiDBPointGetCache                  = false
 	DefTiDBPlacementMode                  = PlacementModeStrict
 	DefTiDBEnableAutoIncrementInGenerated = false
@@ -784,6 +804,7 @@ const (
 	DefTiDBRegardNULLAsPoint              = true
 	DefEnablePlacementCheck               = true
 	DefTimestamp                          = "0"
+	DefForeignKeyChecks                   = true
 	DefTiDBEnableStmtSummary              = true
 	DefTiDBStmtSummaryInternalQuery       = false
 	DefTiDBStmtSummaryRefreshInterval     = 1800
@@ -835,4 +856,6 @@ var (
 	StatsLoadSyncWait                     = atomic.NewInt64(DefTiDBStatsLoadSyncWait)
 	StatsLoadPseudoTimeout                = atomic.NewBool(DefTiDBStatsLoadPseudoTimeout)
 	MemQuotaBindCache                     = atomic.NewInt64(DefTiDBMemQuotaBindCache)
+	ServerMemoryLimit                     = atomic.NewUint64(DefTiDBServerMemoryLimit)
+	ServerMemoryLimitSessMinSize          = atomic.NewUint64(DefTiDBServerMemoryLimitSessMinSize)
 )
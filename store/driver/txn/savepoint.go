@@ -0,0 +1,247 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txn
+
+import (
+	"strings"
+
+	derr "github.com/pingcap/tidb/store/driver/error"
+	tikverr "github.com/tikv/client-go/v2/error"
+	tikvstore "github.com/tikv/client-go/v2/kv"
+	"github.com/tikv/client-go/v2/tikv"
+)
+
+// savepointLog records the savepoints of a transaction and the undo log of the modifications after the first one.
+//
+// The savepoints can't be kept as staging buffers of the MemBuffer, because the snapshot of the MemBuffer, which
+// the statements read the data before them from, is taken at the first staging buffer. Instead, the modifications
+// of a statement are recorded when the first staging buffer, which is the one of the statement, is released.
+//
+// Only the old values of the keys modified after the first savepoint are kept. They are read from the MemBuffer
+// when the keys are written by a statement for the first time.
+type savepointLog struct {
+	savepoints []savepoint
+	undo       []undoEntry
+	// stmtUndo are the old values of the keys modified by the current statement, it's nil if there is no savepoint
+	// when the statement starts.
+	stmtUndo map[string]undoEntry
+}
+
+type savepoint struct {
+	name string
+	// undoLen is the length of the undo log when the savepoint is created.
+	undoLen int
+}
+
+type undoEntry struct {
+	key   []byte
+	value []byte
+	// exists indicates whether the key has a value, which may be a tombstone, in the MemBuffer.
+	exists bool
+	// flags are the flags of the key, they are also kept for a key without a value, e.g. a locked key.
+	flags tikvstore.KeyFlags
+}
+
+func (l *savepointLog) find(name string) int {
+	for i := len(l.savepoints) - 1; i >= 0; i-- {
+		if strings.EqualFold(l.savepoints[i].name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// startStmt is called when the first staging buffer, which is the one of a statement, is created.
+func (l *savepointLog) startStmt() {
+	if l == nil || len(l.savepoints) == 0 {
+		return
+	}
+	l.stmtUndo = make(map[string]undoEntry)
+}
+
+// recordOldValue records the value of the key before it's modified by the current statement.
+func (l *savepointLog) recordOldValue(db *tikv.MemDB, key []byte) {
+	if l == nil || l.stmtUndo == nil {
+		return
+	}
+	if _, ok := l.stmtUndo[string(key)]; ok {
+		return
+	}
+	entry := undoEntry{key: append([]byte{}, key...)}
+	// Getting from the MemBuffer fails only if the key has no value.
+	if old, err := db.Get(key); err == nil {
+		entry.value, entry.exists = append([]byte{}, old...), true
+	}
+	if flags, err := db.GetFlags(key); err == nil {
+		entry.flags = flags
+	}
+	l.stmtUndo[string(key)] = entry
+}
+
+// endStmt records the old values of the keys modified by the statement if its modifications are published.
+func (l *savepointLog) endStmt(published bool) {
+	if l == nil {
+		return
+	}
+	if published && len(l.savepoints) > 0 {
+		for _, entry := range l.stmtUndo {
+			l.undo = append(l.undo, entry)
+		}
+	}
+	l.stmtUndo = nil
+}
+
+// restore sets the value and the flags of the key back to the recorded ones.
+func (e *undoEntry) restore(db *tikv.MemDB) error {
+	flags, err := db.GetFlags(e.key)
+	if err != nil {
+		if tikverr.IsErrNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	ops := flagsOps(e.flags)
+	// The pessimistic locks acquired after the savepoint are retained until the transaction ends, so the flags
+	// are kept for committing or rolling back the locks.
+	if flags.HasLocked() && !e.flags.HasLocked() {
+		ops = append(ops, tikvstore.SetKeyLocked)
+		if flags.HasLockedValueExists() {
+			ops = append(ops, tikvstore.SetKeyLockedValueExists)
+		}
+	}
+	// Most of the flags can be reset by the flags ops, but some of them, e.g. NewlyInserted, can't be unset, and
+	// there is no way to drop the value of a key either. The key is removed and written again in such cases.
+	resetOps := append([]tikvstore.FlagsOp{
+		tikvstore.DelPresumeKeyNotExists, tikvstore.DelKeyLocked, tikvstore.DelNeedLocked,
+		tikvstore.SetKeyLockedValueNotExists, tikvstore.SetAssertNone,
+	}, ops...)
+	if e.exists && tikvstore.ApplyFlagsOps(flags, resetOps...) == tikvstore.ApplyFlagsOps(0, ops...) {
+		ops = resetOps
+	} else {
+		// No value of the current staging buffer refers to the node, since every key is restored only once.
+		db.RemoveFromBuffer(e.key)
+	}
+	switch {
+	case !e.exists:
+		if len(ops) > 0 {
+			db.UpdateFlags(e.key, ops...)
+		}
+		return nil
+	// A tombstone is restored by deleting the key, the empty value can't be set.
+	case len(e.value) == 0:
+		return db.DeleteWithFlags(e.key, ops...)
+	default:
+		return db.SetWithFlags(e.key, e.value, ops...)
+	}
+}
+
+// flagsOps returns the flags ops that set the flags of a key without any flags to the given ones.
+func flagsOps(flags tikvstore.KeyFlags) []tikvstore.FlagsOp {
+	var ops []tikvstore.FlagsOp
+	if flags.HasPresumeKeyNotExists() {
+		ops = append(ops, tikvstore.SetPresumeKeyNotExists)
+		if !flags.HasNeedCheckExists() {
+			ops = append(ops, tikvstore.DelNeedCheckExists)
+		}
+	}
+	if flags.HasLocked() {
+		ops = append(ops, tikvstore.SetKeyLocked)
+	}
+	if flags.HasLockedValueExists() {
+		ops = append(ops, tikvstore.SetKeyLockedValueExists)
+	}
+	if flags.HasNeedLocked() {
+		ops = append(ops, tikvstore.SetNeedLocked)
+	}
+	if flags.HasPrewriteOnly() {
+		ops = append(ops, tikvstore.SetPrewriteOnly)
+	}
+	if flags.HasIgnoredIn2PC() {
+		ops = append(ops, tikvstore.SetIgnoredIn2PC)
+	}
+	if flags.HasReadable() {
+		ops = append(ops, tikvstore.SetReadable)
+	}
+	if flags.HasNewlyInserted() {
+		ops = append(ops, tikvstore.SetNewlyInserted)
+	}
+	switch {
+	case flags.HasAssertUnknown():
+		ops = append(ops, tikvstore.SetAssertUnknown)
+	case flags.HasAssertExist():
+		ops = append(ops, tikvstore.SetAssertExist)
+	case flags.HasAssertNotExist():
+		ops = append(ops, tikvstore.SetAssertNotExist)
+	}
+	return ops
+}
+
+// Savepoint implements the kv.Transaction interface.
+func (txn *tikvTxn) Savepoint(name string) {
+	l := &txn.savepoints
+	if idx := l.find(name); idx >= 0 {
+		l.savepoints = append(l.savepoints[:idx], l.savepoints[idx+1:]...)
+	}
+	l.savepoints = append(l.savepoints, savepoint{name: name, undoLen: len(l.undo)})
+}
+
+// RollbackToSavepoint implements the kv.Transaction interface.
+func (txn *tikvTxn) RollbackToSavepoint(name string) (bool, error) {
+	l := &txn.savepoints
+	idx := l.find(name)
+	if idx < 0 {
+		return false, nil
+	}
+	db := txn.KVTxn.GetMemBuffer()
+	undoLen := l.savepoints[idx].undoLen
+	// Only the first recorded value of a key after the savepoint is restored.
+	restored := make(map[string]struct{}, len(l.undo)-undoLen)
+	for i := undoLen; i < len(l.undo); i++ {
+		entry := &l.undo[i]
+		if _, ok := restored[string(entry.key)]; ok {
+			continue
+		}
+		restored[string(entry.key)] = struct{}{}
+		if err := entry.restore(db); err != nil {
+			return true, derr.ToTiDBErr(err)
+		}
+	}
+	l.undo = l.undo[:undoLen]
+	l.savepoints = l.savepoints[:idx+1]
+	return true, nil
+}
+
+// ReleaseSavepoint implements the kv.Transaction interface.
+func (txn *tikvTxn) ReleaseSavepoint(name string) bool {
+	l := &txn.savepoints
+	idx := l.find(name)
+	if idx < 0 {
+		return false
+	}
+	l.savepoints = l.savepoints[:idx]
+	if len(l.savepoints) == 0 {
+		l.undo = nil
+	}
+	return true
+}
+
+// Savepoints implements the kv.Transaction interface.
+func (txn *tikvTxn) Savepoints() []string {
+	names := make([]string, 0, len(txn.savepoints.savepoints))
+	for _, sp := range txn.savepoints.savepoints {
+		names = append(names, sp.name)
+	}
+	return names
+}
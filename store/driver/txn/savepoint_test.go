@@ -0,0 +1,146 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txn
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/kv"
+	"github.com/stretchr/testify/require"
+	tikvstore "github.com/tikv/client-go/v2/kv"
+	"github.com/tikv/client-go/v2/oracle"
+	"github.com/tikv/client-go/v2/testutils"
+	"github.com/tikv/client-go/v2/tikv"
+)
+
+// mutations returns the mutations which are committed for the keys in the MemBuffer, the way the 2PC committer
+// builds them from the values and the flags.
+func mutations(t *testing.T, db *tikv.MemDB) map[string]string {
+	muts := make(map[string]string)
+	it := db.IterWithFlags(nil, nil)
+	defer it.Close()
+	for ; it.Valid(); require.NoError(t, it.Next()) {
+		flags, key := it.Flags(), string(it.Key())
+		switch {
+		case !it.HasValue():
+			if flags.HasLocked() {
+				muts[key] = "lock"
+			}
+		case len(it.Value()) == 0:
+			if !flags.HasNewlyInserted() {
+				muts[key] = "del"
+			} else if flags.HasLocked() {
+				muts[key] = "lock"
+			}
+		case flags.HasPresumeKeyNotExists():
+			muts[key] = "insert"
+		default:
+			muts[key] = "put"
+		}
+	}
+	return muts
+}
+
+func TestRollbackToSavepointPessimistic(t *testing.T) {
+	rpcClient, cluster, pdClient, err := testutils.NewMockTiKV("", nil)
+	require.NoError(t, err)
+	testutils.BootstrapWithSingleStore(cluster)
+	store, err := tikv.NewTestTiKVStore(rpcClient, pdClient, nil, nil, 0)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+
+	ctx := context.Background()
+	k0, k1, k2, k3, k4, k5 := kv.Key("k0"), kv.Key("k1"), kv.Key("k2"), kv.Key("k3"), kv.Key("k4"), kv.Key("k5")
+	tikvTxn, err := store.Begin()
+	require.NoError(t, err)
+	tikvTxn.SetPessimistic(true)
+	txn := NewTiKVTxn(tikvTxn)
+	buf := txn.GetMemBuffer()
+	db := tikvTxn.GetMemBuffer()
+	lockCtx := tikvstore.NewLockCtx(tikvTxn.StartTS(), tikvstore.LockNoWait, time.Now())
+
+	require.NoError(t, buf.SetWithFlags(k0, []byte("v0"), kv.SetPresumeKeyNotExists))
+	require.NoError(t, txn.SetAssertion(k0, kv.SetAssertNotExist))
+	require.NoError(t, txn.LockKeys(ctx, lockCtx, k3))
+	require.NoError(t, txn.Set(k3, []byte("v3")))
+	require.NoError(t, txn.Set(k4, []byte("v4")))
+	// k5 only has the flags before the savepoint.
+	require.NoError(t, txn.SetAssertion(k5, kv.SetAssertExist))
+	txn.Savepoint("s1")
+
+	h := buf.Staging()
+	// k1 is locked and written for the first time after the savepoint.
+	require.NoError(t, txn.LockKeys(ctx, lockCtx, k1))
+	require.NoError(t, buf.SetWithFlags(k1, []byte("v1"), kv.SetPresumeKeyNotExists))
+	require.NoError(t, txn.SetAssertion(k1, kv.SetAssertNotExist))
+	// k2 is written for the first time after the savepoint without a lock.
+	require.NoError(t, buf.Set(k2, []byte("v2")))
+	require.NoError(t, buf.Set(k0, []byte("v0+")))
+	require.NoError(t, buf.Delete(k3))
+	require.NoError(t, buf.SetWithFlags(k4, []byte("v4+"), kv.SetPresumeKeyNotExists))
+	require.NoError(t, buf.Set(k5, []byte("v5")))
+	buf.Release(h)
+	require.Equal(t, map[string]string{"k0": "insert", "k1": "insert", "k2": "put", "k3": "del", "k4": "insert", "k5": "put"},
+		mutations(t, db))
+
+	ok, err := txn.RollbackToSavepoint("s1")
+	require.True(t, ok)
+	require.NoError(t, err)
+	// The lock of k1 is retained, but the key isn't written any more.
+	require.Equal(t, map[string]string{"k0": "insert", "k1": "lock", "k3": "put", "k4": "put"}, mutations(t, db))
+	flags, err := db.GetFlags(k0)
+	require.NoError(t, err)
+	require.True(t, flags.HasPresumeKeyNotExists())
+	require.True(t, flags.HasNeedCheckExists())
+	require.True(t, flags.HasAssertNotExist())
+	flags, err = db.GetFlags(k1)
+	require.NoError(t, err)
+	require.True(t, flags.HasLocked())
+	require.False(t, flags.HasPresumeKeyNotExists())
+	require.False(t, flags.HasAssertionFlags())
+	flags, err = db.GetFlags(k3)
+	require.NoError(t, err)
+	require.True(t, flags.HasLocked())
+	_, err = db.GetFlags(k2)
+	require.Error(t, err)
+	flags, err = db.GetFlags(k4)
+	require.NoError(t, err)
+	require.False(t, flags.HasPresumeKeyNotExists())
+	flags, err = db.GetFlags(k5)
+	require.NoError(t, err)
+	require.True(t, flags.HasAssertExist())
+
+	require.NoError(t, txn.Commit(ctx))
+	ts, err := store.CurrentTimestamp(oracle.GlobalTxnScope)
+	require.NoError(t, err)
+	snapshot := store.GetSnapshot(ts)
+	v, err := snapshot.Get(ctx, k0)
+	require.NoError(t, err)
+	require.Equal(t, []byte("v0"), v)
+	v, err = snapshot.Get(ctx, k3)
+	require.NoError(t, err)
+	require.Equal(t, []byte("v3"), v)
+	v, err = snapshot.Get(ctx, k4)
+	require.NoError(t, err)
+	require.Equal(t, []byte("v4"), v)
+	for _, k := range []kv.Key{k1, k2, k5} {
+		_, err = snapshot.Get(ctx, k)
+		require.Error(t, err)
+	}
+}
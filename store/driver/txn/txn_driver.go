@@ -42,6 +42,7 @@ type tikvTxn struct {
 	*tikv.KVTxn
 	idxNameCache        map[int64]*model.TableInfo
 	snapshotInterceptor kv.SnapshotInterceptor
+	savepoints          savepointLog
 }
 
 // NewTiKVTxn returns a new Transaction.
@@ -52,7 +53,7 @@ func NewTiKVTxn(txn *tikv.KVTxn) kv.Transaction {
 	totalLimit := atomic.LoadUint64(&kv.TxnTotalSizeLimit)
 	txn.GetUnionStore().SetEntrySizeLimit(entryLimit, totalLimit)
 
-	return &tikvTxn{txn, make(map[int64]*model.TableInfo), nil}
+	return &tikvTxn{txn, make(map[int64]*model.TableInfo), nil, savepointLog{}}
 }
 
 func (txn *tikvTxn) GetTableInfo(id int64) *model.TableInfo {
@@ -146,6 +147,7 @@ func (txn *tikvTxn) BatchGet(ctx context.Context, keys []kv.Key) (map[string][]b
 }
 
 func (txn *tikvTxn) Delete(k kv.Key) error {
+	txn.savepoints.recordOldValue(txn.KVTxn.GetMemBuffer(), k)
 	err := txn.KVTxn.Delete(k)
 	return derr.ToTiDBErr(err)
 }
@@ -164,12 +166,13 @@ func (txn *tikvTxn) Get(ctx context.Context, k kv.Key) ([]byte, error) {
 }
 
 func (txn *tikvTxn) Set(k kv.Key, v []byte) error {
+	txn.savepoints.recordOldValue(txn.KVTxn.GetMemBuffer(), k)
 	err := txn.KVTxn.Set(k, v)
 	return derr.ToTiDBErr(err)
 }
 
 func (txn *tikvTxn) GetMemBuffer() kv.MemBuffer {
-	return newMemBuffer(txn.KVTxn.GetMemBuffer())
+	return newMemBuffer(txn.KVTxn.GetMemBuffer(), &txn.savepoints)
 }
 
 func (txn *tikvTxn) SetOption(opt int, val interface{}) {
@@ -26,13 +26,14 @@ import (
 // memBuffer wraps tikv.MemDB as kv.MemBuffer.
 type memBuffer struct {
 	*tikv.MemDB
+	savepoints *savepointLog
 }
 
-func newMemBuffer(m *tikv.MemDB) kv.MemBuffer {
+func newMemBuffer(m *tikv.MemDB, savepoints *savepointLog) kv.MemBuffer {
 	if m == nil {
 		return nil
 	}
-	return &memBuffer{MemDB: m}
+	return &memBuffer{MemDB: m, savepoints: savepoints}
 }
 
 func (m *memBuffer) Size() int {
@@ -40,10 +41,12 @@ func (m *memBuffer) Size() int {
 }
 
 func (m *memBuffer) Delete(k kv.Key) error {
+	m.savepoints.recordOldValue(m.MemDB, k)
 	return m.MemDB.Delete(k)
 }
 
 func (m *memBuffer) DeleteWithFlags(k kv.Key, ops ...kv.FlagsOp) error {
+	m.savepoints.recordOldValue(m.MemDB, k)
 	err := m.MemDB.DeleteWithFlags(k, getTiKVFlagsOps(ops)...)
 	return derr.ToTiDBErr(err)
 }
@@ -59,14 +62,25 @@ func (m *memBuffer) GetFlags(key kv.Key) (kv.KeyFlags, error) {
 }
 
 func (m *memBuffer) Staging() kv.StagingHandle {
-	return kv.StagingHandle(m.MemDB.Staging())
+	h := m.MemDB.Staging()
+	if h == 1 {
+		m.savepoints.startStmt()
+	}
+	return kv.StagingHandle(h)
 }
 
 func (m *memBuffer) Cleanup(h kv.StagingHandle) {
+	if h == 1 {
+		m.savepoints.endStmt(false)
+	}
 	m.MemDB.Cleanup(int(h))
 }
 
 func (m *memBuffer) Release(h kv.StagingHandle) {
+	// The modifications are published to the root of the MemBuffer when the first staging buffer is released.
+	if h == 1 {
+		m.savepoints.endStmt(true)
+	}
 	m.MemDB.Release(int(h))
 }
 
@@ -78,11 +92,13 @@ func (m *memBuffer) InspectStage(handle kv.StagingHandle, f func(kv.Key, kv.KeyF
 }
 
 func (m *memBuffer) Set(key kv.Key, value []byte) error {
+	m.savepoints.recordOldValue(m.MemDB, key)
 	err := m.MemDB.Set(key, value)
 	return derr.ToTiDBErr(err)
 }
 
 func (m *memBuffer) SetWithFlags(key kv.Key, value []byte, ops ...kv.FlagsOp) error {
+	m.savepoints.recordOldValue(m.MemDB, key)
 	err := m.MemDB.SetWithFlags(key, value, getTiKVFlagsOps(ops)...)
 	return derr.ToTiDBErr(err)
 }
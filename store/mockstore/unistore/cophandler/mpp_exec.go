@@ -426,10 +426,10 @@ func (e *topNExec) next() (*chunk.Chunk, error) {
 type exchSenderExec struct {
 	baseMPPExec
 
-	tunnels       []*ExchangerTunnel
-	outputOffsets []uint32
-	exchangeTp    tipb.ExchangeType
-	hashKeyOffset int
+	tunnels        []*ExchangerTunnel
+	outputOffsets  []uint32
+	exchangeTp     tipb.ExchangeType
+	hashKeyOffsets []int
 }
 
 func (e *exchSenderExec) open() error {
@@ -484,13 +484,14 @@ func (e *exchSenderExec) next() (*chunk.Chunk, error) {
 				}
 				for i := 0; i < rows; i++ {
 					row := chk.GetRow(i)
-					d := row.GetDatum(e.hashKeyOffset, e.fieldTypes[e.hashKeyOffset])
-					if d.IsNull() {
-						targetChunks[0].AppendRow(row)
-					} else {
-						hashKey := int(d.GetInt64() % int64(len(e.tunnels)))
-						targetChunks[hashKey].AppendRow(row)
+					hashKey := uint64(0)
+					for _, offset := range e.hashKeyOffsets {
+						d := row.GetDatum(offset, e.fieldTypes[offset])
+						if !d.IsNull() {
+							hashKey = hashKey*31 + uint64(d.GetInt64())
+						}
 					}
+					targetChunks[hashKey%uint64(len(e.tunnels))].AppendRow(row)
 				}
 				for i, tunnel := range e.tunnels {
 					if targetChunks[i].NumRows() > 0 {
@@ -0,0 +1,80 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+import (
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// Constraint provides meta data describing a check constraint and the expression to evaluate it.
+type Constraint struct {
+	*model.ConstraintInfo
+	// ConstraintExpr is built from ExprString, its columns refer to the public columns of the table by offset.
+	ConstraintExpr expression.Expression
+}
+
+// BuildWritableConstraints builds the enforced check constraints of the table which should be checked when writing rows.
+// The constraints being added are writable too, so that the rows written during the validation can't violate them.
+func BuildWritableConstraints(ctx sessionctx.Context, tblInfo *model.TableInfo) ([]*Constraint, error) {
+	var constraints []*Constraint
+	for _, constraintInfo := range tblInfo.Constraints {
+		if !constraintInfo.Enforced {
+			continue
+		}
+		if constraintInfo.State != model.StateWriteOnly && constraintInfo.State != model.StatePublic {
+			continue
+		}
+		expr, err := expression.ParseSimpleExprWithTableInfo(ctx, constraintInfo.ExprString, tblInfo)
+		if err != nil {
+			return nil, err
+		}
+		constraints = append(constraints, &Constraint{
+			ConstraintInfo: constraintInfo,
+			ConstraintExpr: expr,
+		})
+	}
+	return constraints, nil
+}
+
+// CheckRowConstraint checks whether the row satisfies all the constraints.
+// The row should contain at least the public columns of the table.
+// A constraint is only violated when its expression evaluates to false, NULL satisfies the constraint.
+func CheckRowConstraint(ctx sessionctx.Context, constraints []*Constraint, row []types.Datum) error {
+	if len(constraints) == 0 {
+		return nil
+	}
+	r := chunk.MutRowFromDatums(row).ToRow()
+	for _, constraint := range constraints {
+		val, err := constraint.ConstraintExpr.Eval(r)
+		if err != nil {
+			return err
+		}
+		if val.IsNull() {
+			continue
+		}
+		ok, err := val.ToBool(ctx.GetSessionVars().StmtCtx)
+		if err != nil {
+			return err
+		}
+		if ok == 0 {
+			return ErrCheckConstraintViolated.GenWithStackByArgs(constraint.Name.O)
+		}
+	}
+	return nil
+}
@@ -81,6 +81,13 @@ type partitionedTable struct {
 	partitions      map[int64]*partition
 	evalBufferTypes []*types.FieldType
 	evalBufferPool  sync.Pool
+
+	// The following fields are only set while the partitions are being reorganized.
+	// Writes are mirrored into reorgPartitions, which are located by reorgPartitionExpr
+	// against reorgPartitionInfo, the layout on the other side of the reorganization.
+	reorgPartitionInfo *model.PartitionInfo
+	reorgPartitionExpr *PartitionExpr
+	reorgPartitions    map[int64]struct{}
 }
 
 func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Table, error) {
@@ -110,9 +117,54 @@ func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Tabl
 		partitions[p.ID] = &t
 	}
 	ret.partitions = partitions
+	if pi.IsReorganizing() {
+		if err := initReorgPartitions(ret, tblInfo); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	return ret, nil
 }
 
+// initReorgPartitions prepares the partitioned table to mirror writes while the
+// partitions are being reorganized. Before the new partitions become public, writes
+// are mirrored into the AddingDefinitions; afterwards they are mirrored into the
+// DroppingDefinitions, which are still read by the servers on the old schema.
+func initReorgPartitions(t *partitionedTable, tblInfo *model.TableInfo) error {
+	pi := tblInfo.GetPartitionInfo()
+	from, to := pi.DroppingDefinitions, pi.AddingDefinitions
+	if pi.DDLState == model.StateDeleteReorganization {
+		from, to = to, from
+	}
+	reorgPi := *pi
+	reorgPi.Definitions = pi.ReplaceDefinitions(from, to)
+	reorgPi.AddingDefinitions = nil
+	reorgPi.DroppingDefinitions = nil
+	reorgPi.DDLState = model.StateNone
+	if reorgPi.Type == model.PartitionTypeHash || reorgPi.Type == model.PartitionTypeKey {
+		reorgPi.Num = uint64(len(reorgPi.Definitions))
+	}
+	reorgTblInfo := tblInfo.Clone()
+	reorgTblInfo.Partition = &reorgPi
+	reorgExpr, err := newPartitionExpr(reorgTblInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	t.reorgPartitions = make(map[int64]struct{}, len(to))
+	for _, def := range to {
+		var p partition
+		err := initTableCommonWithIndices(&p.TableCommon, tblInfo, def.ID, t.Columns, t.allocs)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		t.partitions[def.ID] = &p
+		t.reorgPartitions[def.ID] = struct{}{}
+	}
+	t.reorgPartitionInfo = &reorgPi
+	t.reorgPartitionExpr = reorgExpr
+	return nil
+}
+
 func newPartitionExpr(tblInfo *model.TableInfo) (*PartitionExpr, error) {
 	ctx := mock.NewContext()
 	dbName := model.NewCIStr(ctx.GetSessionVars().CurrentDB)
@@ -126,6 +178,8 @@ func newPartitionExpr(tblInfo *model.TableInfo) (*PartitionExpr, error) {
 		return generateRangePartitionExpr(ctx, pi, columns, names)
 	case model.PartitionTypeHash:
 		return generateHashPartitionExpr(ctx, pi, columns, names)
+	case model.PartitionTypeKey:
+		return generateKeyPartitionExpr(pi, names)
 	case model.PartitionTypeList:
 		return generateListPartitionExpr(ctx, tblInfo, columns, names)
 	}
@@ -934,6 +988,27 @@ func generateHashPartitionExpr(ctx sessionctx.Context, pi *model.PartitionInfo,
 	}, nil
 }
 
+func generateKeyPartitionExpr(pi *model.PartitionInfo, names types.NameSlice) (*PartitionExpr, error) {
+	// KEY partitioning hashes the partitioning columns directly.
+	offset := make([]int, len(pi.Columns))
+	for i, col := range pi.Columns {
+		idx := -1
+		for j, name := range names {
+			if name.ColName.L == col.L {
+				idx = j
+				break
+			}
+		}
+		if idx < 0 {
+			return nil, errors.Trace(table.ErrUnknownColumn.GenWithStackByArgs(col.O))
+		}
+		offset[i] = idx
+	}
+	return &PartitionExpr{
+		ColumnOffset: offset,
+	}, nil
+}
+
 // PartitionExpr returns the partition expression.
 func (t *partitionedTable) PartitionExpr() (*PartitionExpr, error) {
 	return t.partitionExpr, nil
@@ -970,19 +1045,37 @@ func PartitionRecordKey(pid int64, handle int64) kv.Key {
 
 // locatePartition returns the partition ID of the input record.
 func (t *partitionedTable) locatePartition(ctx sessionctx.Context, pi *model.PartitionInfo, r []types.Datum) (int64, error) {
+	return t.locatePartitionByExpr(ctx, pi, t.partitionExpr, r)
+}
+
+// locateReorgPartition returns the ID of the partition that the input record is
+// mirrored into during partition reorganization, ok is false if the record is
+// located in a partition that is not reorganized.
+func (t *partitionedTable) locateReorgPartition(ctx sessionctx.Context, r []types.Datum) (pid int64, ok bool, err error) {
+	pid, err = t.locatePartitionByExpr(ctx, t.reorgPartitionInfo, t.reorgPartitionExpr, r)
+	if err != nil {
+		return 0, false, errors.Trace(err)
+	}
+	_, ok = t.reorgPartitions[pid]
+	return pid, ok, nil
+}
+
+func (t *partitionedTable) locatePartitionByExpr(ctx sessionctx.Context, pi *model.PartitionInfo, partExpr *PartitionExpr, r []types.Datum) (int64, error) {
 	var err error
 	var idx int
-	switch t.meta.Partition.Type {
+	switch pi.Type {
 	case model.PartitionTypeRange:
 		if len(pi.Columns) == 0 {
-			idx, err = t.locateRangePartition(ctx, pi, r)
+			idx, err = t.locateRangePartition(ctx, pi, partExpr, r)
 		} else {
-			idx, err = t.locateRangeColumnPartition(ctx, pi, r)
+			idx, err = t.locateRangeColumnPartition(ctx, pi, partExpr, r)
 		}
 	case model.PartitionTypeHash:
-		idx, err = t.locateHashPartition(ctx, pi, r)
+		idx, err = t.locateHashPartition(ctx, pi, partExpr, r)
+	case model.PartitionTypeKey:
+		idx, err = t.locateKeyPartition(ctx, pi, partExpr, r)
 	case model.PartitionTypeList:
-		idx, err = t.locateListPartition(ctx, pi, r)
+		idx, err = t.locateListPartition(ctx, partExpr, r)
 	}
 	if err != nil {
 		return 0, errors.Trace(err)
@@ -990,10 +1083,10 @@ func (t *partitionedTable) locatePartition(ctx sessionctx.Context, pi *model.Par
 	return pi.Definitions[idx].ID, nil
 }
 
-func (t *partitionedTable) locateRangeColumnPartition(ctx sessionctx.Context, pi *model.PartitionInfo, r []types.Datum) (int, error) {
+func (t *partitionedTable) locateRangeColumnPartition(ctx sessionctx.Context, pi *model.PartitionInfo, partExpr *PartitionExpr, r []types.Datum) (int, error) {
 	var err error
 	var isNull bool
-	partitionExprs := t.partitionExpr.UpperBounds
+	partitionExprs := partExpr.UpperBounds
 	evalBuffer := t.evalBufferPool.Get().(*chunk.MutRow)
 	defer t.evalBufferPool.Put(evalBuffer)
 	idx := sort.Search(len(partitionExprs), func(i int) bool {
@@ -1035,22 +1128,22 @@ func (t *partitionedTable) locateRangeColumnPartition(ctx sessionctx.Context, pi
 	return idx, nil
 }
 
-func (t *partitionedTable) locateListPartition(ctx sessionctx.Context, pi *model.PartitionInfo, r []types.Datum) (int, error) {
-	lp := t.partitionExpr.ForListPruning
+func (t *partitionedTable) locateListPartition(ctx sessionctx.Context, partExpr *PartitionExpr, r []types.Datum) (int, error) {
+	lp := partExpr.ForListPruning
 	if len(lp.ColPrunes) == 0 {
 		return lp.locateListPartitionByRow(ctx, r)
 	}
 	return lp.locateListColumnsPartitionByRow(ctx, r)
 }
 
-func (t *partitionedTable) locateRangePartition(ctx sessionctx.Context, pi *model.PartitionInfo, r []types.Datum) (int, error) {
+func (t *partitionedTable) locateRangePartition(ctx sessionctx.Context, pi *model.PartitionInfo, partExpr *PartitionExpr, r []types.Datum) (int, error) {
 	var (
 		ret    int64
 		val    int64
 		isNull bool
 		err    error
 	)
-	if col, ok := t.partitionExpr.Expr.(*expression.Column); ok {
+	if col, ok := partExpr.Expr.(*expression.Column); ok {
 		if r[col.Index].IsNull() {
 			isNull = true
 		}
@@ -1059,14 +1152,14 @@ func (t *partitionedTable) locateRangePartition(ctx sessionctx.Context, pi *mode
 		evalBuffer := t.evalBufferPool.Get().(*chunk.MutRow)
 		defer t.evalBufferPool.Put(evalBuffer)
 		evalBuffer.SetDatums(r...)
-		val, isNull, err = t.partitionExpr.Expr.EvalInt(ctx, evalBuffer.ToRow())
+		val, isNull, err = partExpr.Expr.EvalInt(ctx, evalBuffer.ToRow())
 		if err != nil {
 			return 0, err
 		}
 		ret = val
 	}
-	unsigned := mysql.HasUnsignedFlag(t.partitionExpr.Expr.GetType().Flag)
-	ranges := t.partitionExpr.ForRangePruning
+	unsigned := mysql.HasUnsignedFlag(partExpr.Expr.GetType().Flag)
+	ranges := partExpr.ForRangePruning
 	length := len(ranges.LessThan)
 	pos := sort.Search(length, func(i int) bool {
 		if isNull {
@@ -1098,8 +1191,8 @@ func (t *partitionedTable) locateRangePartition(ctx sessionctx.Context, pi *mode
 }
 
 // TODO: supports linear hashing
-func (t *partitionedTable) locateHashPartition(ctx sessionctx.Context, pi *model.PartitionInfo, r []types.Datum) (int, error) {
-	if col, ok := t.partitionExpr.Expr.(*expression.Column); ok {
+func (t *partitionedTable) locateHashPartition(ctx sessionctx.Context, pi *model.PartitionInfo, partExpr *PartitionExpr, r []types.Datum) (int, error) {
+	if col, ok := partExpr.Expr.(*expression.Column); ok {
 		var data types.Datum
 		switch r[col.Index].Kind() {
 		case types.KindInt64, types.KindUint64:
@@ -1111,28 +1204,30 @@ func (t *partitionedTable) locateHashPartition(ctx sessionctx.Context, pi *model
 				return 0, err
 			}
 		}
-		ret := data.GetInt64()
-		ret = ret % int64(t.meta.Partition.Num)
-		if ret < 0 {
-			ret = -ret
-		}
-		return int(ret), nil
+		return HashPartitionIdx(pi, data.GetInt64()), nil
 	}
 	evalBuffer := t.evalBufferPool.Get().(*chunk.MutRow)
 	defer t.evalBufferPool.Put(evalBuffer)
 	evalBuffer.SetDatums(r...)
-	ret, isNull, err := t.partitionExpr.Expr.EvalInt(ctx, evalBuffer.ToRow())
+	ret, isNull, err := partExpr.Expr.EvalInt(ctx, evalBuffer.ToRow())
 	if err != nil {
 		return 0, err
 	}
 	if isNull {
 		return 0, nil
 	}
-	ret = ret % int64(t.meta.Partition.Num)
-	if ret < 0 {
-		ret = -ret
+	return HashPartitionIdx(pi, ret), nil
+}
+
+func (t *partitionedTable) locateKeyPartition(ctx sessionctx.Context, pi *model.PartitionInfo, partExpr *PartitionExpr, r []types.Datum) (int, error) {
+	cols := t.Cols()
+	fts := make([]*types.FieldType, len(partExpr.ColumnOffset))
+	vals := make([]types.Datum, len(partExpr.ColumnOffset))
+	for i, offset := range partExpr.ColumnOffset {
+		fts[i] = &cols[offset].FieldType
+		vals[i] = r[offset]
 	}
-	return int(ret), nil
+	return KeyPartitionIdx(ctx.GetSessionVars().StmtCtx, pi, fts, vals)
 }
 
 // GetPartition returns a Table, which is actually a partition.
@@ -1185,7 +1280,114 @@ func partitionedTableAddRecord(ctx sessionctx.Context, t *partitionedTable, r []
 		}
 	}
 	tbl := t.GetPartition(pid)
-	return tbl.AddRecord(ctx, r, opts...)
+	recordID, err = tbl.AddRecord(ctx, r, opts...)
+	if err != nil {
+		return recordID, err
+	}
+	if t.mirrorWrites() {
+		if err = t.addReorgRecord(ctx, recordID, r); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return recordID, nil
+}
+
+// mirrorWrites reports whether added records should also be written into the
+// reorganized partitions. Deletes are mirrored from the delete-only state on.
+func (t *partitionedTable) mirrorWrites() bool {
+	return t.reorgPartitionExpr != nil && t.meta.Partition.DDLState != model.StateDeleteOnly
+}
+
+// addReorgRecord mirrors an added record into the reorganized partition it belongs to.
+// Only the row and index data are written, the binlog is left to the record in the
+// current layout, and no assertion is made since the record may have been backfilled.
+func (t *partitionedTable) addReorgRecord(ctx sessionctx.Context, h kv.Handle, r []types.Datum) error {
+	pid, ok, err := t.locateReorgPartition(ctx, r)
+	if err != nil || !ok {
+		return errors.Trace(err)
+	}
+	p := t.partitions[pid]
+	txn, err := ctx.Txn(true)
+	if err != nil {
+		return err
+	}
+	sessVars := ctx.GetSessionVars()
+	cols := p.WritableCols()
+	colIDs := make([]int64, 0, len(cols))
+	row := make([]types.Datum, 0, len(cols))
+	for _, col := range cols {
+		if !p.canSkip(col, &r[col.Offset]) {
+			colIDs = append(colIDs, col.ID)
+			row = append(row, r[col.Offset])
+		}
+	}
+	value, err := tablecodec.EncodeRow(sessVars.StmtCtx, row, colIDs, nil, nil, &sessVars.RowEncoder)
+	if err != nil {
+		return err
+	}
+	key := p.RecordKey(h)
+	if err = txn.SetAssertion(key, kv.SetAssertUnknown); err != nil {
+		return err
+	}
+	if err = txn.Set(key, value); err != nil {
+		return err
+	}
+	for _, idx := range p.Indices() {
+		if !IsIndexWritable(idx) || (t.meta.IsCommonHandle && idx.Meta().Primary) {
+			continue
+		}
+		vals, err := idx.FetchValues(r, nil)
+		if err != nil {
+			return err
+		}
+		rsData := TryGetHandleRestoredDataWrapper(p, r, nil, idx.Meta())
+		if _, err = idx.Create(ctx, txn, vals, h, rsData, table.WithIgnoreAssertion); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeReorgRecord mirrors a removed record into the reorganized partition it belongs to.
+// The record may not have been backfilled yet, so its existence is not asserted.
+func (t *partitionedTable) removeReorgRecord(ctx sessionctx.Context, h kv.Handle, r []types.Datum) error {
+	pid, ok, err := t.locateReorgPartition(ctx, r)
+	if err != nil || !ok {
+		return errors.Trace(err)
+	}
+	p := t.partitions[pid]
+	txn, err := ctx.Txn(true)
+	if err != nil {
+		return err
+	}
+	key := p.RecordKey(h)
+	if err = txn.SetAssertion(key, kv.SetAssertUnknown); err != nil {
+		return err
+	}
+	if err = txn.Delete(key); err != nil {
+		return err
+	}
+	sc := ctx.GetSessionVars().StmtCtx
+	for _, idx := range p.deletableIndices() {
+		if idx.Meta().Primary && (t.meta.IsCommonHandle || t.meta.PKIsHandle) {
+			continue
+		}
+		vals, err := idx.FetchValues(r, nil)
+		if err != nil {
+			return err
+		}
+		idxKey, _, err := idx.GenIndexKey(sc, vals, h, nil)
+		if err != nil {
+			return err
+		}
+		if err = txn.SetAssertion(idxKey, kv.SetAssertUnknown); err != nil {
+			return err
+		}
+		if err = idx.Delete(sc, txn, vals, h); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // partitionTableWithGivenSets is used for this kind of grammar: partition (p0,p1)
@@ -1229,13 +1431,21 @@ func (t *partitionedTable) RemoveRecord(ctx sessionctx.Context, h kv.Handle, r [
 	}
 
 	tbl := t.GetPartition(pid)
-	return tbl.RemoveRecord(ctx, h, r)
+	if err = tbl.RemoveRecord(ctx, h, r); err != nil {
+		return err
+	}
+	if t.reorgPartitionExpr != nil {
+		return t.removeReorgRecord(ctx, h, r)
+	}
+	return nil
 }
 
 func (t *partitionedTable) GetAllPartitionIDs() []int64 {
-	ptIDs := make([]int64, 0, len(t.partitions))
-	for id := range t.partitions {
-		ptIDs = append(ptIDs, id)
+	// The partitions being reorganized are not included.
+	defs := t.meta.Partition.Definitions
+	ptIDs := make([]int64, 0, len(defs))
+	for _, def := range defs {
+		ptIDs = append(ptIDs, def.ID)
 	}
 	return ptIDs
 }
@@ -1289,11 +1499,22 @@ func partitionedTableUpdateRecord(gctx context.Context, ctx sessionctx.Context,
 			logutil.BgLogger().Error("update partition record fails", zap.String("message", "new record inserted while old record is not removed"), zap.Error(err))
 			return errors.Trace(err)
 		}
-		return nil
+	} else {
+		tbl := t.GetPartition(to)
+		if err = tbl.UpdateRecord(gctx, ctx, h, currData, newData, touched); err != nil {
+			return err
+		}
 	}
 
-	tbl := t.GetPartition(to)
-	return tbl.UpdateRecord(gctx, ctx, h, currData, newData, touched)
+	if t.reorgPartitionExpr != nil {
+		if err = t.removeReorgRecord(ctx, h, currData); err != nil {
+			return errors.Trace(err)
+		}
+		if t.mirrorWrites() {
+			return t.addReorgRecord(ctx, h, newData)
+		}
+	}
+	return nil
 }
 
 // FindPartitionByName finds partition in table meta by name.
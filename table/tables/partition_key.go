@@ -0,0 +1,308 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tables
+
+import (
+	"encoding/binary"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/collate"
+)
+
+// HashPartitionIdx returns the index of the partition that the value of the
+// partitioning expression of a HASH partitioned table belongs to.
+func HashPartitionIdx(pi *model.PartitionInfo, val int64) int {
+	if pi.IsLinear {
+		return linearHashPartitionIdx(uint64(val), pi.Num)
+	}
+	idx := val % int64(pi.Num)
+	if idx < 0 {
+		idx = -idx
+	}
+	return int(idx)
+}
+
+// KeyPartitionIdx returns the index of the partition that the values of the
+// partitioning columns of a KEY partitioned table belong to. The values must
+// have the types of the columns, and are hashed the way MySQL hashes its record
+// format, so a table dumped from MySQL keeps the rows in the same partitions.
+func KeyPartitionIdx(sc *stmtctx.StatementContext, pi *model.PartitionInfo, fts []*types.FieldType, vals []types.Datum) (int, error) {
+	h := keyHasher{nr1: 1, nr2: 4, algorithm: pi.KeyAlgorithm}
+	for i := range vals {
+		if err := h.hashDatum(sc, fts[i], &vals[i]); err != nil {
+			return 0, errors.Trace(err)
+		}
+	}
+	hash := uint64(uint32(h.nr1))
+	if pi.IsLinear {
+		return linearHashPartitionIdx(hash, pi.Num), nil
+	}
+	return int(hash % pi.Num), nil
+}
+
+// linearHashPartitionIdx locates a partition with the powers-of-two algorithm
+// of LINEAR HASH and LINEAR KEY partitioning.
+func linearHashPartitionIdx(hash uint64, num uint64) int {
+	mask := uint64(1)
+	for mask < num {
+		mask <<= 1
+	}
+	mask--
+	idx := hash & mask
+	if idx >= num {
+		idx = hash & ((mask+1)>>1 - 1)
+	}
+	return int(idx)
+}
+
+// keyHasher accumulates the hash of the partitioning columns like the
+// `Field::hash` functions of MySQL.
+type keyHasher struct {
+	nr1, nr2  uint64
+	algorithm uint64
+}
+
+func (h *keyHasher) add(b byte) {
+	h.nr1 ^= ((h.nr1&63)+h.nr2)*uint64(b) + (h.nr1 << 8)
+	h.nr2 += 3
+}
+
+// hashBin is `my_hash_sort_bin` of MySQL.
+func (h *keyHasher) hashBin(b []byte) {
+	for _, c := range b {
+		h.add(c)
+	}
+}
+
+// hashNumeric hashes the record format of numeric and temporal columns. MySQL
+// 5.5 and later hash them as latin1_swedish_ci strings, while MySQL 5.1 (and
+// ALGORITHM=1) hashes the plain bytes.
+func (h *keyHasher) hashNumeric(b []byte) {
+	if h.algorithm == 1 {
+		h.hashBin(b)
+		return
+	}
+	end := len(b)
+	for end > 0 && b[end-1] == ' ' {
+		end--
+	}
+	for _, c := range b[:end] {
+		h.add(latin1SortOrder[c])
+	}
+}
+
+// hashString hashes the weights of a string in its collation.
+func (h *keyHasher) hashString(ft *types.FieldType, str string) {
+	key := collate.GetCollator(ft.Collate).Key(str)
+	if !collate.NewCollationEnabled() || !strings.HasSuffix(ft.Collate, "_general_ci") {
+		h.hashBin(key)
+		return
+	}
+	// The general_ci collations of MySQL hash the low byte of a weight first.
+	for i := 0; i+1 < len(key); i += 2 {
+		h.add(key[i+1])
+		h.add(key[i])
+	}
+}
+
+func (h *keyHasher) hashDatum(sc *stmtctx.StatementContext, ft *types.FieldType, d *types.Datum) error {
+	if d.IsNull() {
+		h.nr1 ^= (h.nr1 << 1) | 1
+		return nil
+	}
+	var buf [8]byte
+	switch ft.Tp {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong:
+		binary.LittleEndian.PutUint64(buf[:], d.GetUint64())
+		h.hashNumeric(buf[:intPackLength(ft.Tp)])
+	case mysql.TypeYear:
+		year := d.GetInt64()
+		if year > 0 {
+			year -= 1900
+		}
+		buf[0] = byte(year)
+		h.hashNumeric(buf[:1])
+	case mysql.TypeFloat:
+		binary.LittleEndian.PutUint32(buf[:], math.Float32bits(float32(d.GetFloat64())))
+		h.hashNumeric(buf[:4])
+	case mysql.TypeDouble:
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(d.GetFloat64()))
+		h.hashNumeric(buf[:8])
+	case mysql.TypeNewDecimal:
+		b, err := d.GetMysqlDecimal().ToBin(ft.Flen, ft.Decimal)
+		if err != nil {
+			return err
+		}
+		h.hashNumeric(b)
+	case mysql.TypeDate:
+		t := d.GetMysqlTime()
+		v := uint32(t.Year()*16*32 + t.Month()*32 + t.Day())
+		binary.LittleEndian.PutUint32(buf[:], v)
+		h.hashNumeric(buf[:3])
+	case mysql.TypeDatetime:
+		h.hashNumeric(datetimeBinary(d.GetMysqlTime(), ft.Decimal))
+	case mysql.TypeTimestamp:
+		b, err := timestampBinary(sc, d.GetMysqlTime(), ft.Decimal)
+		if err != nil {
+			return err
+		}
+		h.hashNumeric(b)
+	case mysql.TypeDuration:
+		h.hashNumeric(durationBinary(d.GetMysqlDuration(), ft.Decimal))
+	case mysql.TypeBit:
+		v, err := d.GetBinaryLiteral().ToInt(sc)
+		if err != nil {
+			return err
+		}
+		binary.BigEndian.PutUint64(buf[:], v)
+		h.hashBin(buf[8-(ft.Flen+7)/8:])
+	case mysql.TypeEnum:
+		binary.LittleEndian.PutUint64(buf[:], d.GetMysqlEnum().Value)
+		if len(ft.Elems) < 256 {
+			h.hashBin(buf[:1])
+		} else {
+			h.hashBin(buf[:2])
+		}
+	case mysql.TypeSet:
+		binary.LittleEndian.PutUint64(buf[:], d.GetMysqlSet().Value)
+		n := (len(ft.Elems) + 7) / 8
+		if n > 4 {
+			n = 8
+		}
+		h.hashBin(buf[:n])
+	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar:
+		h.hashString(ft, d.GetString())
+	default:
+		return errors.Errorf("unsupported type %s in KEY partitioning", types.TypeStr(ft.Tp))
+	}
+	return nil
+}
+
+func intPackLength(tp byte) int {
+	switch tp {
+	case mysql.TypeTiny:
+		return 1
+	case mysql.TypeShort:
+		return 2
+	case mysql.TypeInt24:
+		return 3
+	case mysql.TypeLong:
+		return 4
+	default:
+		return 8
+	}
+}
+
+// appendFracBinary appends the fractional seconds part of the binary format of
+// DATETIME, TIMESTAMP and TIME values.
+func appendFracBinary(b []byte, frac int64, fsp int) []byte {
+	switch fsp {
+	case 1, 2:
+		return append(b, byte(frac/10000))
+	case 3, 4:
+		v := frac / 100
+		return append(b, byte(v>>8), byte(v))
+	case 5, 6:
+		return append(b, byte(frac>>16), byte(frac>>8), byte(frac))
+	}
+	return b
+}
+
+// datetimeBinary returns the record format of a DATETIME value in MySQL.
+func datetimeBinary(t types.Time, fsp int) []byte {
+	ymd := uint64((t.Year()*13+t.Month())<<5 | t.Day())
+	hms := uint64(t.Hour()<<12 | t.Minute()<<6 | t.Second())
+	v := (ymd<<17 | hms) + 0x8000000000
+	b := []byte{byte(v >> 32), byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}
+	return appendFracBinary(b, int64(t.Microsecond()), fsp)
+}
+
+// timestampBinary returns the record format of a TIMESTAMP value in MySQL,
+// which stores the seconds since the epoch.
+func timestampBinary(sc *stmtctx.StatementContext, t types.Time, fsp int) ([]byte, error) {
+	var sec, frac int64
+	if !t.IsZero() {
+		tz := time.UTC
+		if sc != nil && sc.TimeZone != nil {
+			tz = sc.TimeZone
+		}
+		gt, err := t.GoTime(tz)
+		if err != nil {
+			return nil, err
+		}
+		sec, frac = gt.Unix(), int64(t.Microsecond())
+	}
+	b := make([]byte, 4, 7)
+	binary.BigEndian.PutUint32(b, uint32(sec))
+	return appendFracBinary(b, frac, fsp), nil
+}
+
+// durationBinary returns the record format of a TIME value in MySQL.
+func durationBinary(d types.Duration, fsp int) []byte {
+	dur := d.Duration
+	neg := dur < 0
+	if neg {
+		dur = -dur
+	}
+	us := int64(dur / time.Microsecond)
+	secs := us / 1000000
+	hms := (secs/3600)<<12 | (secs/60%60)<<6 | secs%60
+	packed := hms<<24 + us%1000000
+	if neg {
+		packed = -packed
+	}
+	if fsp >= 5 {
+		v := uint64(packed + 0x800000000000)
+		return []byte{byte(v >> 40), byte(v >> 32), byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}
+	}
+	v := uint64((packed >> 24) + 0x800000)
+	b := []byte{byte(v >> 16), byte(v >> 8), byte(v)}
+	frac := packed % (1 << 24)
+	switch fsp {
+	case 1, 2:
+		return append(b, byte(int8(frac/10000)))
+	case 3, 4:
+		v := frac / 100
+		return append(b, byte(v>>8), byte(v))
+	}
+	return b
+}
+
+// latin1SortOrder is the sort order of the latin1_swedish_ci collation in MySQL.
+var latin1SortOrder = [256]byte{
+	0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15,
+	16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
+	32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47,
+	48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63,
+	64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79,
+	80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 91, 92, 93, 94, 95,
+	96, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79,
+	80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 123, 124, 125, 126, 127,
+	128, 129, 130, 131, 132, 133, 134, 135, 136, 137, 138, 139, 140, 141, 142, 143,
+	144, 145, 146, 147, 148, 149, 150, 151, 152, 153, 154, 155, 156, 157, 158, 159,
+	160, 161, 162, 163, 164, 165, 166, 167, 168, 169, 170, 171, 172, 173, 174, 175,
+	176, 177, 178, 179, 180, 181, 182, 183, 184, 185, 186, 187, 188, 189, 190, 191,
+	65, 65, 65, 65, 92, 91, 92, 67, 69, 69, 69, 69, 73, 73, 73, 73,
+	68, 78, 79, 79, 79, 79, 93, 215, 216, 89, 89, 89, 89, 89, 222, 223,
+	65, 65, 65, 65, 92, 91, 92, 67, 69, 69, 69, 69, 73, 73, 73, 73,
+	68, 78, 79, 79, 79, 79, 93, 247, 216, 89, 89, 89, 89, 89, 222, 255,
+}
@@ -17,16 +17,20 @@ package tables_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	mysql "github.com/pingcap/tidb/errno"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/parser/model"
+	parsermysql "github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx/binloginfo"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/testkit"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tidb/util/dbterror"
 	"github.com/stretchr/testify/require"
 )
@@ -728,3 +732,68 @@ func TestIssue31721(t *testing.T) {
 	tk.MustExec("insert into t_31721 values ('1')")
 	tk.MustExec("select * from t_31721 partition(p0, p1) where col1 != 2;")
 }
+
+func TestHashPartitionIdx(t *testing.T) {
+	pi := &model.PartitionInfo{Type: model.PartitionTypeHash, Num: 5}
+	require.Equal(t, 2, tables.HashPartitionIdx(pi, 7))
+	require.Equal(t, 2, tables.HashPartitionIdx(pi, -7))
+
+	// LINEAR HASH masks the value by the next power of two, and folds the
+	// values beyond the number of partitions by the smaller mask.
+	pi.IsLinear = true
+	expected := []int{0, 1, 2, 3, 4, 1, 2, 3, 0, 1}
+	for i, idx := range expected {
+		require.Equal(t, idx, tables.HashPartitionIdx(pi, int64(i)))
+	}
+	require.Equal(t, 2, tables.HashPartitionIdx(pi, -6))
+	pi.Num = 8
+	require.Equal(t, 7, tables.HashPartitionIdx(pi, 15))
+}
+
+func TestKeyPartitionIdx(t *testing.T) {
+	collate.SetNewCollationEnabledForTest(true)
+	defer collate.SetNewCollationEnabledForTest(false)
+
+	sc := &stmtctx.StatementContext{TimeZone: time.UTC}
+	// A partition count of 2^32 exposes the hash value of MySQL.
+	pi := &model.PartitionInfo{Type: model.PartitionTypeKey, Num: 1 << 32}
+	intTp := types.NewFieldType(parsermysql.TypeLong)
+	bigintTp := types.NewFieldType(parsermysql.TypeLonglong)
+	binTp := types.NewFieldType(parsermysql.TypeVarchar)
+	binTp.Collate = "utf8mb4_bin"
+	ciTp := types.NewFieldType(parsermysql.TypeVarchar)
+	ciTp.Collate = "utf8mb4_general_ci"
+	tests := []struct {
+		algorithm uint64
+		fts       []*types.FieldType
+		vals      []types.Datum
+		hash      int
+	}{
+		{2, []*types.FieldType{intTp}, types.MakeDatums(1), 84215044},
+		// Numeric columns are hashed as latin1_swedish_ci strings since MySQL 5.5, so 0x61 is
+		// hashed as 'A', while ALGORITHM=1 hashes the plain bytes.
+		{2, []*types.FieldType{intTp}, types.MakeDatums(0x61), 1179010628},
+		{1, []*types.FieldType{intTp}, types.MakeDatums(0x61), 3873892068},
+		{2, []*types.FieldType{bigintTp}, types.MakeDatums(-1), 872696919},
+		{2, []*types.FieldType{binTp}, types.MakeDatums("abc  "), 49572422},
+		{2, []*types.FieldType{intTp, binTp}, types.MakeDatums(nil, "abc"), 71877958},
+		{2, []*types.FieldType{ciTp}, types.MakeDatums("ab"), 1178982872},
+		{2, []*types.FieldType{ciTp}, types.MakeDatums("AB "), 1178982872},
+	}
+	for _, tt := range tests {
+		pi.KeyAlgorithm = tt.algorithm
+		idx, err := tables.KeyPartitionIdx(sc, pi, tt.fts, tt.vals)
+		require.NoError(t, err)
+		require.Equal(t, tt.hash, idx)
+	}
+
+	pi.KeyAlgorithm = 0
+	pi.Num = 4
+	idx, err := tables.KeyPartitionIdx(sc, pi, []*types.FieldType{intTp}, types.MakeDatums(1))
+	require.NoError(t, err)
+	require.Equal(t, 0, idx)
+	pi.IsLinear, pi.Num = true, 3
+	idx, err = tables.KeyPartitionIdx(sc, pi, []*types.FieldType{intTp}, types.MakeDatums(1))
+	require.NoError(t, err)
+	require.Equal(t, 0, idx)
+}
@@ -1446,7 +1446,9 @@ func GetColDefaultValue(ctx sessionctx.Context, col *table.Column, defaultVals [
 	if col.GetOriginDefaultValue() == nil && mysql.HasNotNullFlag(col.Flag) {
 		return colVal, errors.New("Miss column")
 	}
-	if col.State != model.StatePublic {
+	// The column in write reorganization state is being added, whose origin default value
+	// is needed by the reorganization of the other sub-jobs in the multi-schema change.
+	if col.State != model.StatePublic && col.State != model.StateWriteReorganization {
 		return colVal, nil
 	}
 	if defaultVals[col.Offset].IsNull() {
@@ -692,7 +692,7 @@ func createServer(storage kv.Storage, dom *domain.Domain) *server.Server {
 	}
 	svr.SetDomain(dom)
 	svr.InitGlobalConnID(dom.ServerID)
-	go dom.ExpensiveQueryHandle().SetSessionManager(svr).Run()
+	go dom.ExpensiveQueryHandle().SetSessionManager(svr).SetGlobalMemoryTracker(executor.GlobalMemoryUsageTracker).Run()
 	dom.InfoSyncer().SetSessionManager(svr)
 	return svr
 }
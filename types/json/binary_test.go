@@ -92,6 +92,29 @@ func TestBinaryJSONExtract(t *testing.T) {
 	}
 }
 
+func TestBinaryJSONExtractAll(t *testing.T) {
+	bj := mustParseBinaryFromString(t, `{"a": [1, "2", {"aa": "bb"}, 4.0, {"aa": "cc"}], "b": true}`)
+	var tests = []struct {
+		pathExpr string
+		expected []string
+	}{
+		{"$.a", []string{`[1, "2", {"aa": "bb"}, 4.0, {"aa": "cc"}]`}},
+		{"$.a[*]", []string{`1`, `"2"`, `{"aa": "bb"}`, `4.0`, `{"aa": "cc"}`}},
+		{"$.a[*].aa", []string{`"bb"`, `"cc"`}},
+		{"$.b[0]", []string{`true`}},
+		{"$.c", nil},
+	}
+	for _, test := range tests {
+		pe, err := ParseJSONPathExpr(test.pathExpr)
+		require.NoError(t, err)
+		result := bj.ExtractAll(pe)
+		require.Len(t, result, len(test.expected))
+		for i, expected := range test.expected {
+			require.Equal(t, mustParseBinaryFromString(t, expected).String(), result[i].String())
+		}
+	}
+}
+
 func TestBinaryJSONType(t *testing.T) {
 	var tests = []struct {
 		in  string
@@ -111,13 +111,19 @@ func IsJobRollbackable(job *model.Job) bool {
 		}
 	case model.ActionAddTablePartition:
 		return job.SchemaState == model.StateNone || job.SchemaState == model.StateReplicaOnly
+	case model.ActionMultiSchemaChange:
+		return job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible
+	case model.ActionReorganizePartition:
+		// The new partitions are public in the delete reorganization state.
+		return job.SchemaState != model.StateDeleteReorganization
 	case model.ActionDropColumn, model.ActionDropColumns, model.ActionDropTablePartition,
 		model.ActionRebaseAutoID, model.ActionShardRowID,
 		model.ActionTruncateTable, model.ActionAddForeignKey,
 		model.ActionDropForeignKey, model.ActionRenameTable,
 		model.ActionModifyTableCharsetAndCollate, model.ActionTruncateTablePartition,
 		model.ActionModifySchemaCharsetAndCollate, model.ActionRepairTable,
-		model.ActionModifyTableAutoIdCache, model.ActionModifySchemaDefaultPlacement:
+		model.ActionModifyTableAutoIdCache, model.ActionModifySchemaDefaultPlacement,
+		model.ActionDropCheckConstraint, model.ActionAlterCheckConstraint:
 		return job.SchemaState == model.StateNone
 	}
 	return true
@@ -140,12 +140,29 @@ func (l *List) GetRow(ptr RowPtr) Row {
 	return chk.GetRow(int(ptr.RowIdx))
 }
 
+// ReleaseChunk releases the memory of the chunk chkIdx, the chunk can't be
+// read any more but the indexes of the other chunks are kept.
+func (l *List) ReleaseChunk(chkIdx int) {
+	chk := l.chunks[chkIdx]
+	if chk == nil {
+		return
+	}
+	if chkIdx <= l.consumedIdx {
+		l.memTracker.Consume(-chk.MemoryUsage())
+	}
+	l.chunks[chkIdx] = nil
+}
+
 // Reset resets the List.
 func (l *List) Reset() {
-	if lastIdx := len(l.chunks) - 1; lastIdx != l.consumedIdx {
+	if lastIdx := len(l.chunks) - 1; lastIdx != l.consumedIdx && l.chunks[lastIdx] != nil {
 		l.memTracker.Consume(l.chunks[lastIdx].MemoryUsage())
 	}
-	l.freelist = append(l.freelist, l.chunks...)
+	for _, chk := range l.chunks {
+		if chk != nil {
+			l.freelist = append(l.freelist, chk)
+		}
+	}
 	l.chunks = l.chunks[:0]
 	l.length = 0
 	l.consumedIdx = -1
@@ -139,6 +139,11 @@ func (c *RowContainer) SpillToDisk() {
 	c.m.records.inDisk.diskTracker.AttachTo(c.diskTracker)
 	for i := 0; i < N; i++ {
 		chk := c.m.records.inMemory.GetChunk(i)
+		if chk == nil {
+			// The released chunk is spilled as an empty one to keep the indexes of the other chunks.
+			c.m.records.inDisk.offsets = append(c.m.records.inDisk.offsets, nil)
+			continue
+		}
 		err = c.m.records.inDisk.Add(chk)
 		if err != nil {
 			c.m.records.spillError = err
@@ -230,6 +235,8 @@ func (c *RowContainer) Add(chk *Chunk) (err error) {
 
 // AllocChunk allocates a new chunk from RowContainer.
 func (c *RowContainer) AllocChunk() (chk *Chunk) {
+	c.m.RLock()
+	defer c.m.RUnlock()
 	return c.m.records.inMemory.allocChunk()
 }
 
@@ -246,6 +253,17 @@ func (c *RowContainer) GetChunk(chkIdx int) (*Chunk, error) {
 	return c.m.records.inDisk.GetChunk(chkIdx)
 }
 
+// ReleaseChunk releases the memory of the chkIdx th chunk, which can't be read
+// any more. The chunks spilled to disk are released when the RowContainer is closed.
+func (c *RowContainer) ReleaseChunk(chkIdx int) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if c.alreadySpilled() {
+		return
+	}
+	c.m.records.inMemory.ReleaseChunk(chkIdx)
+}
+
 // GetRow returns the row the ptr pointed to.
 func (c *RowContainer) GetRow(ptr RowPtr) (Row, error) {
 	c.m.RLock()
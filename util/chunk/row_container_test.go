@@ -134,6 +134,38 @@ func TestSpillAction(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestRowContainerReleaseChunk(t *testing.T) {
+	sz := 4
+	fields := []*types.FieldType{types.NewFieldType(mysql.TypeLonglong)}
+	rc := NewRowContainer(fields, sz)
+	for i := 0; i < 3; i++ {
+		chk := NewChunkWithCapacity(fields, sz)
+		for j := 0; j < sz; j++ {
+			chk.AppendInt64(0, int64(i*sz+j))
+		}
+		require.NoError(t, rc.Add(chk))
+	}
+	chk, err := rc.GetChunk(0)
+	require.NoError(t, err)
+	consumed := rc.GetMemTracker().BytesConsumed()
+	rc.ReleaseChunk(0)
+	require.Equal(t, consumed-chk.MemoryUsage(), rc.GetMemTracker().BytesConsumed())
+	// Releasing a chunk twice does nothing.
+	rc.ReleaseChunk(0)
+	require.Equal(t, consumed-chk.MemoryUsage(), rc.GetMemTracker().BytesConsumed())
+
+	// The indexes of the other chunks are kept after spilling.
+	rc.SpillToDisk()
+	require.True(t, rc.AlreadySpilledSafeForTest())
+	require.Equal(t, 3, rc.NumChunks())
+	require.Equal(t, 0, rc.NumRowsOfChunk(0))
+	chk, err = rc.GetChunk(1)
+	require.NoError(t, err)
+	require.Equal(t, sz, chk.NumRows())
+	require.Equal(t, int64(sz), chk.GetRow(0).GetInt64(0))
+	require.NoError(t, rc.Close())
+}
+
 func TestNewSortedRowContainer(t *testing.T) {
 	fields := []*types.FieldType{types.NewFieldType(mysql.TypeLonglong)}
 	rc := NewSortedRowContainer(fields, 1024, nil, nil, nil)
@@ -34,6 +34,12 @@ var (
 	ErrCancelledDDLJob = ClassDDL.NewStd(mysql.ErrCancelledDDLJob)
 	// ErrRunMultiSchemaChanges means we run multi schema changes.
 	ErrRunMultiSchemaChanges = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "multi schema change"), nil))
+	// ErrOperateSameColumn means we change the same columns multiple times in a DDL.
+	ErrOperateSameColumn = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "operate same column '%s'"), nil))
+	// ErrOperateSameIndex means we change the same indexes multiple times in a DDL.
+	ErrOperateSameIndex = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "operate same index '%s'"), nil))
+	// ErrOperateSameConstraint means we change the same constraints multiple times in a DDL.
+	ErrOperateSameConstraint = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "operate same constraint '%s'"), nil))
 	// ErrWaitReorgTimeout means we wait for reorganization timeout.
 	ErrWaitReorgTimeout = ClassDDL.NewStdErr(mysql.ErrLockWaitTimeout, mysql.MySQLErrName[mysql.ErrWaitReorgTimeout])
 	// ErrInvalidStoreVer means invalid store version.
@@ -162,6 +168,10 @@ var (
 	ErrDupKeyName = ClassDDL.NewStd(mysql.ErrDupKeyName)
 	// ErrFkDupName returns for duplicated FK name.
 	ErrFkDupName = ClassDDL.NewStd(mysql.ErrFkDupName)
+	// ErrFkNoIndexParent returns when the referenced table has no index on the referenced columns of the foreign key.
+	ErrFkNoIndexParent = ClassDDL.NewStd(mysql.ErrFkNoIndexParent)
+	// ErrDropIndexFk returns when the index to be dropped is needed by a foreign key.
+	ErrDropIndexFk = ClassDDL.NewStd(mysql.ErrDropIndexFk)
 	// ErrInvalidDDLState returns for invalid ddl model object state.
 	ErrInvalidDDLState = ClassDDL.NewStdErr(mysql.ErrInvalidDDLState, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrInvalidDDLState].Raw), nil))
 	// ErrUnsupportedModifyPrimaryKey returns an error when add or drop the primary key.
@@ -229,6 +239,14 @@ var (
 	ErrPartitionMaxvalue = ClassDDL.NewStd(mysql.ErrPartitionMaxvalue)
 	// ErrDropLastPartition returns cannot remove all partitions, use drop table instead.
 	ErrDropLastPartition = ClassDDL.NewStd(mysql.ErrDropLastPartition)
+	// ErrReorgNoParam returns reorganize partition without parameters can only be used on hash partitions.
+	ErrReorgNoParam = ClassDDL.NewStd(mysql.ErrReorgNoParam)
+	// ErrReorgPartitionNotExist returns more partitions to reorganize than there are partitions.
+	ErrReorgPartitionNotExist = ClassDDL.NewStd(mysql.ErrReorgPartitionNotExist)
+	// ErrConsecutiveReorgPartitions returns the reorganized partitions must be in consecutive order.
+	ErrConsecutiveReorgPartitions = ClassDDL.NewStd(mysql.ErrConsecutiveReorgPartitions)
+	// ErrReorgOutsideRange returns reorganize of range partitions cannot change total ranges.
+	ErrReorgOutsideRange = ClassDDL.NewStd(mysql.ErrReorgOutsideRange)
 	// ErrTooManyPartitions returns too many partitions were defined.
 	ErrTooManyPartitions = ClassDDL.NewStd(mysql.ErrTooManyPartitions)
 	// ErrPartitionConstDomain returns partition constant is out of partition function domain.
@@ -245,6 +263,10 @@ var (
 	ErrWarnDataTruncated = ClassDDL.NewStd(mysql.WarnDataTruncated)
 	// ErrCoalesceOnlyOnHashPartition returns coalesce partition can only be used on hash/key partitions.
 	ErrCoalesceOnlyOnHashPartition = ClassDDL.NewStd(mysql.ErrCoalesceOnlyOnHashPartition)
+	// ErrAddPartitionNoNewPartition returns at least one partition must be added.
+	ErrAddPartitionNoNewPartition = ClassDDL.NewStd(mysql.ErrAddPartitionNoNewPartition)
+	// ErrCoalescePartitionNoPartition returns at least one partition must be coalesced.
+	ErrCoalescePartitionNoPartition = ClassDDL.NewStd(mysql.ErrCoalescePartitionNoPartition)
 	// ErrViewWrongList returns create view must include all columns in the select clause
 	ErrViewWrongList = ClassDDL.NewStd(mysql.ErrViewWrongList)
 	// ErrAlterOperationNotSupported returns when alter operations is not supported.
@@ -255,6 +277,8 @@ var (
 	ErrTableCantHandleFt = ClassDDL.NewStd(mysql.ErrTableCantHandleFt)
 	// ErrFieldNotFoundPart returns an error when 'partition by columns' are not found in table columns.
 	ErrFieldNotFoundPart = ClassDDL.NewStd(mysql.ErrFieldNotFoundPart)
+	// ErrBlobFieldInPartFunc returns an error when a BLOB, TEXT or JSON column is used in 'partition by key'.
+	ErrBlobFieldInPartFunc = ClassDDL.NewStd(mysql.ErrBlobFieldInPartFunc)
 	// ErrWrongTypeColumnValue returns 'Partition column values of incorrect type'
 	ErrWrongTypeColumnValue = ClassDDL.NewStd(mysql.ErrWrongTypeColumnValue)
 	// ErrValuesIsNotIntType returns 'VALUES value for partition '%-.64s' must have type INT'
@@ -267,6 +291,22 @@ var (
 	ErrInvalidAutoRandom = ClassDDL.NewStd(mysql.ErrInvalidAutoRandom)
 	// ErrUnsupportedConstraintCheck returns when use ADD CONSTRAINT CHECK
 	ErrUnsupportedConstraintCheck = ClassDDL.NewStd(mysql.ErrUnsupportedConstraintCheck)
+	// ErrColumnCheckConstraintReferencesOtherColumn returns when a column check constraint refers to other columns.
+	ErrColumnCheckConstraintReferencesOtherColumn = ClassDDL.NewStd(mysql.ErrColumnCheckConstraintReferencesOtherColumn)
+	// ErrCheckConstraintFunctionIsNotAllowed returns when a check constraint expression contains a disallowed function.
+	ErrCheckConstraintFunctionIsNotAllowed = ClassDDL.NewStd(mysql.ErrCheckConstraintFunctionIsNotAllowed)
+	// ErrCheckConstraintVariables returns when a check constraint expression refers to a user or system variable.
+	ErrCheckConstraintVariables = ClassDDL.NewStd(mysql.ErrCheckConstraintVariables)
+	// ErrCheckConstraintRefersAutoIncrementColumn returns when a check constraint refers to an auto-increment column.
+	ErrCheckConstraintRefersAutoIncrementColumn = ClassDDL.NewStd(mysql.ErrCheckConstraintRefersAutoIncrementColumn)
+	// ErrTableCheckConstraintReferUnknown returns when a check constraint refers to a non-existing column.
+	ErrTableCheckConstraintReferUnknown = ClassDDL.NewStd(mysql.ErrTableCheckConstraintReferUnknown)
+	// ErrCheckConstraintNotFound returns when the check constraint to alter or drop doesn't exist.
+	ErrCheckConstraintNotFound = ClassDDL.NewStd(mysql.ErrCheckConstraintNotFound)
+	// ErrCheckConstraintDupName returns when the check constraint name is duplicated.
+	ErrCheckConstraintDupName = ClassDDL.NewStd(mysql.ErrCheckConstraintDupName)
+	// ErrDependentByCheckConstraint returns when the dropped or renamed column is used by a check constraint.
+	ErrDependentByCheckConstraint = ClassDDL.NewStd(mysql.ErrDependentByCheckConstraint)
 	// ErrDerivedMustHaveAlias returns when a sub select statement does not have a table alias.
 	ErrDerivedMustHaveAlias = ClassDDL.NewStd(mysql.ErrDerivedMustHaveAlias)
 
@@ -362,4 +402,29 @@ var (
 
 	// ErrAutoConvert when auto convert happens
 	ErrAutoConvert = ClassDDL.NewStd(mysql.ErrAutoConvert)
+
+	// ErrSpDupParam returns when a stored routine has duplicate parameter names.
+	ErrSpDupParam = ClassDDL.NewStd(mysql.ErrSpDupParam)
+	// ErrSpNoreturn returns when a stored function has no RETURN statement.
+	ErrSpNoreturn = ClassDDL.NewStd(mysql.ErrSpNoreturn)
+	// ErrSpBadreturn returns when a RETURN statement is used outside a stored function.
+	ErrSpBadreturn = ClassDDL.NewStd(mysql.ErrSpBadreturn)
+	// ErrSpLilabelMismatch returns when a LEAVE or ITERATE statement has no matching label.
+	ErrSpLilabelMismatch = ClassDDL.NewStd(mysql.ErrSpLilabelMismatch)
+	// ErrSpNoRetset returns when a statement in a stored function returns a result set.
+	ErrSpNoRetset = ClassDDL.NewStd(mysql.ErrSpNoRetset)
+	// ErrCommitNotAllowedInSfOrTrg returns when a stored function commits the transaction.
+	ErrCommitNotAllowedInSfOrTrg = ClassDDL.NewStd(mysql.ErrCommitNotAllowedInSfOrTrg)
+	// ErrTrgOnViewOrTempTable returns when creating a trigger on a view, a sequence or a temporary table.
+	ErrTrgOnViewOrTempTable = ClassDDL.NewStd(mysql.ErrTrgOnViewOrTempTable)
+	// ErrNoTriggersOnSystemSchema returns when creating a trigger on a table of a system schema.
+	ErrNoTriggersOnSystemSchema = ClassDDL.NewStd(mysql.ErrNoTriggersOnSystemSchema)
+	// ErrReferencedTrgDoesNotExist returns when the trigger referenced by FOLLOWS or PRECEDES doesn't exist.
+	ErrReferencedTrgDoesNotExist = ClassDDL.NewStd(mysql.ErrReferencedTrgDoesNotExist)
+	// ErrTrgInWrongSchema returns when renaming a table with triggers to another schema.
+	ErrTrgInWrongSchema = ClassDDL.NewStd(mysql.ErrTrgInWrongSchema)
+	// ErrTrgCantChangeRow returns when a trigger body updates the OLD row, or the NEW row in an AFTER trigger.
+	ErrTrgCantChangeRow = ClassDDL.NewStd(mysql.ErrTrgCantChangeRow)
+	// ErrTrgNoSuchRowInTrg returns when a trigger body uses the OLD row in an INSERT trigger or the NEW row in a DELETE trigger.
+	ErrTrgNoSuchRowInTrg = ClassDDL.NewStd(mysql.ErrTrgNoSuchRowInTrg)
 )
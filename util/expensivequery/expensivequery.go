@@ -26,6 +26,7 @@ import (
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/memory"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -34,6 +35,8 @@ import (
 type Handle struct {
 	exitCh chan struct{}
 	sm     atomic.Value
+	// globalMemTracker is the ancestor of the memory trackers of all the statements.
+	globalMemTracker *memory.Tracker
 }
 
 // NewExpensiveQueryHandle builds a new expensive query handler.
@@ -48,6 +51,12 @@ func (eqh *Handle) SetSessionManager(sm util.SessionManager) *Handle {
 	return eqh
 }
 
+// SetGlobalMemoryTracker sets the global memory tracker which is used to enforce the server memory limit.
+func (eqh *Handle) SetGlobalMemoryTracker(tracker *memory.Tracker) *Handle {
+	eqh.globalMemTracker = tracker
+	return eqh
+}
+
 // Run starts a expensive query checker goroutine at the start time of the server.
 func (eqh *Handle) Run() {
 	threshold := atomic.LoadUint64(&variable.ExpensiveQueryTimeThreshold)
@@ -57,6 +66,7 @@ func (eqh *Handle) Run() {
 	defer ticker.Stop()
 	sm := eqh.sm.Load().(util.SessionManager)
 	record := &memoryUsageAlarm{}
+	arbitrator := &serverMemoryLimitArbitrator{}
 	for {
 		select {
 		case <-ticker.C:
@@ -81,6 +91,7 @@ func (eqh *Handle) Run() {
 			if record.err == nil {
 				record.alarm4ExcessiveMemUsage(sm)
 			}
+			arbitrator.arbitrate(sm, eqh.globalMemTracker)
 		case <-eqh.exitCh:
 			return
 		}
@@ -0,0 +1,193 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expensivequery
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb/metrics"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/memory"
+	"go.uber.org/zap"
+)
+
+const (
+	// ServerMemoryLimitOpSpill means the query is asked to spill its intermediate data.
+	ServerMemoryLimitOpSpill = "SPILL"
+	// ServerMemoryLimitOpKill means the query is cancelled.
+	ServerMemoryLimitOpKill = "KILL"
+
+	// spillWaitDuration is how long the arbitrator waits for the spilling to take effect before
+	// cancelling the query.
+	spillWaitDuration = time.Second
+	// maxServerMemoryLimitOpsHistory is the number of the operations kept in the history.
+	maxServerMemoryLimitOpsHistory = 50
+)
+
+// ServerMemoryLimitOp records an operation taken on a query when the memory usage of the tidb-server
+// instance exceeds tidb_server_memory_limit.
+type ServerMemoryLimitOp struct {
+	Time          time.Time
+	Op            string
+	MemoryLimit   uint64
+	MemoryCurrent int64
+	ProcessID     uint64
+	Mem           int64
+	Disk          int64
+	Client        string
+	DB            string
+	User          string
+	SQLDigest     string
+	SQLText       string
+}
+
+var serverMemoryLimitOps struct {
+	sync.Mutex
+	history []ServerMemoryLimitOp
+}
+
+// GetServerMemoryLimitOpsHistory returns the recent operations taken due to tidb_server_memory_limit.
+func GetServerMemoryLimitOpsHistory() []ServerMemoryLimitOp {
+	serverMemoryLimitOps.Lock()
+	defer serverMemoryLimitOps.Unlock()
+	return append([]ServerMemoryLimitOp(nil), serverMemoryLimitOps.history...)
+}
+
+func recordServerMemoryLimitOp(op ServerMemoryLimitOp) {
+	serverMemoryLimitOps.Lock()
+	defer serverMemoryLimitOps.Unlock()
+	if len(serverMemoryLimitOps.history) >= maxServerMemoryLimitOpsHistory {
+		serverMemoryLimitOps.history = serverMemoryLimitOps.history[1:]
+	}
+	serverMemoryLimitOps.history = append(serverMemoryLimitOps.history, op)
+}
+
+// serverMemoryLimitArbitrator enforces tidb_server_memory_limit. When the memory usage tracked by the
+// global memory tracker exceeds the limit, the query using the most memory is asked to spill first. If
+// the memory usage is still over the limit after the spilling, the query is cancelled.
+// Only one query is handled at a time, the next query is selected after the current one is finished.
+type serverMemoryLimitArbitrator struct {
+	target     *memory.Tracker
+	targetInfo *util.ProcessInfo
+	op         string
+	opTime     time.Time
+}
+
+func (a *serverMemoryLimitArbitrator) reset() {
+	a.target, a.targetInfo, a.op = nil, nil, ""
+}
+
+// isTargetRunning checks whether the statement selected by the arbitrator is still running.
+func (a *serverMemoryLimitArbitrator) isTargetRunning(sm util.SessionManager) bool {
+	info, ok := sm.GetProcessInfo(a.targetInfo.ID)
+	// The memory tracker is reused by the following statements of the session, so the start time is
+	// also checked.
+	return ok && len(info.Info) > 0 && info.StmtCtx != nil && info.StmtCtx.MemTracker == a.target && info.Time.Equal(a.targetInfo.Time)
+}
+
+func (a *serverMemoryLimitArbitrator) arbitrate(sm util.SessionManager, globalTracker *memory.Tracker) {
+	limit := variable.ServerMemoryLimit.Load()
+	if limit == 0 || globalTracker == nil {
+		a.reset()
+		return
+	}
+	if a.target != nil && !a.isTargetRunning(sm) {
+		a.reset()
+	}
+	current := globalTracker.BytesConsumed()
+	if current < int64(limit) {
+		if a.op == ServerMemoryLimitOpSpill {
+			// The spilling works, give the query a chance to finish.
+			a.reset()
+		}
+		return
+	}
+
+	switch a.op {
+	case "":
+		info := a.selectHeaviestQuery(sm)
+		if info == nil {
+			return
+		}
+		a.target, a.targetInfo = info.StmtCtx.MemTracker, info
+		a.target.MarkNeedSpill()
+		a.takeOp(ServerMemoryLimitOpSpill, limit, current)
+	case ServerMemoryLimitOpSpill:
+		if time.Since(a.opTime) < spillWaitDuration {
+			return
+		}
+		a.target.MarkNeedKill()
+		a.takeOp(ServerMemoryLimitOpKill, limit, current)
+	case ServerMemoryLimitOpKill:
+		// Wait for the query to be cancelled.
+	}
+}
+
+// selectHeaviestQuery returns the running query which uses the most memory, the queries using less memory
+// than tidb_server_memory_limit_sess_min_size are ignored.
+func (a *serverMemoryLimitArbitrator) selectHeaviestQuery(sm util.SessionManager) *util.ProcessInfo {
+	minSize := int64(variable.ServerMemoryLimitSessMinSize.Load())
+	var heaviest *util.ProcessInfo
+	var maxConsumed int64
+	for _, info := range sm.ShowProcessList() {
+		if len(info.Info) == 0 || info.StmtCtx == nil || info.StmtCtx.MemTracker == nil {
+			continue
+		}
+		consumed := info.StmtCtx.MemTracker.BytesConsumed()
+		if consumed >= minSize && consumed > maxConsumed {
+			heaviest, maxConsumed = info, consumed
+		}
+	}
+	return heaviest
+}
+
+func (a *serverMemoryLimitArbitrator) takeOp(op string, limit uint64, current int64) {
+	a.op, a.opTime = op, time.Now()
+	info := a.targetInfo
+	record := ServerMemoryLimitOp{
+		Time:          a.opTime,
+		Op:            op,
+		MemoryLimit:   limit,
+		MemoryCurrent: current,
+		ProcessID:     info.ID,
+		Mem:           a.target.BytesConsumed(),
+		Client:        info.Host,
+		DB:            info.DB,
+		User:          info.User,
+		SQLDigest:     info.Digest,
+		SQLText:       info.Info,
+	}
+	if info.StmtCtx.DiskTracker != nil {
+		record.Disk = info.StmtCtx.DiskTracker.BytesConsumed()
+	}
+	if info.RedactSQL {
+		record.SQLText = ""
+	}
+	recordServerMemoryLimitOp(record)
+	metrics.ServerMemoryLimitOpsCounter.WithLabelValues(op).Inc()
+	logutil.BgLogger().Warn("memory usage exceeds tidb_server_memory_limit",
+		zap.String("op", op),
+		zap.Uint64("memory-limit", limit),
+		zap.Int64("memory-current", current),
+		zap.Uint64("conn_id", info.ID),
+		zap.Int64("query-memory", record.Mem),
+		zap.String("sql-digest", info.Digest))
+	if op == ServerMemoryLimitOpKill {
+		logExpensiveQuery(time.Since(info.Time), info)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expensivequery
+
+import (
+	"crypto/tls"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/session/txninfo"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/memory"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSessionManager struct {
+	processInfos map[uint64]*util.ProcessInfo
+}
+
+func (msm *mockSessionManager) ShowProcessList() map[uint64]*util.ProcessInfo {
+	return msm.processInfos
+}
+
+func (msm *mockSessionManager) ShowTxnList() []*txninfo.TxnInfo {
+	return nil
+}
+
+func (msm *mockSessionManager) GetProcessInfo(id uint64) (*util.ProcessInfo, bool) {
+	info, ok := msm.processInfos[id]
+	return info, ok
+}
+
+func (msm *mockSessionManager) Kill(uint64, bool) {}
+
+func (msm *mockSessionManager) KillAllConnections() {}
+
+func (msm *mockSessionManager) UpdateTLSConfig(*tls.Config) {}
+
+func (msm *mockSessionManager) ServerID() uint64 {
+	return 1
+}
+
+func TestServerMemoryLimitArbitrator(t *testing.T) {
+	defer func() {
+		variable.ServerMemoryLimit.Store(variable.DefTiDBServerMemoryLimit)
+		variable.ServerMemoryLimitSessMinSize.Store(variable.DefTiDBServerMemoryLimitSessMinSize)
+	}()
+	globalTracker := memory.NewGlobalTracker(memory.LabelForGlobalMemory, -1)
+	sm := &mockSessionManager{processInfos: make(map[uint64]*util.ProcessInfo)}
+	newQuery := func(id uint64, consumed int64) *memory.Tracker {
+		tracker := memory.NewTracker(memory.LabelForSQLText, -1)
+		tracker.AttachToGlobalTracker(globalTracker)
+		tracker.Consume(consumed)
+		sm.processInfos[id] = &util.ProcessInfo{
+			ID:      id,
+			Info:    "select 1",
+			Time:    time.Now(),
+			StmtCtx: &stmtctx.StatementContext{MemTracker: tracker},
+			StatsInfo: func(interface{}) map[string]uint64 {
+				return nil
+			},
+		}
+		return tracker
+	}
+	small := newQuery(1, 100)
+	heavy := newQuery(2, 200)
+	tiny := newQuery(3, 10)
+
+	a := &serverMemoryLimitArbitrator{}
+	// No limit.
+	a.arbitrate(sm, globalTracker)
+	require.Nil(t, a.target)
+
+	variable.ServerMemoryLimit.Store(300)
+	variable.ServerMemoryLimitSessMinSize.Store(50)
+	// The heaviest query is asked to spill first.
+	a.arbitrate(sm, globalTracker)
+	require.Equal(t, heavy, a.target)
+	require.Equal(t, ServerMemoryLimitOpSpill, a.op)
+	// The query isn't cancelled until the spilling has taken time.
+	a.arbitrate(sm, globalTracker)
+	require.False(t, heavy.NeedKill())
+	a.opTime = a.opTime.Add(-spillWaitDuration)
+	a.arbitrate(sm, globalTracker)
+	require.True(t, heavy.NeedKill())
+	require.False(t, small.NeedKill())
+	require.False(t, tiny.NeedKill())
+	require.Equal(t, ServerMemoryLimitOpKill, a.op)
+
+	// The next query is handled after the cancelled one is finished.
+	heavy.DetachFromGlobalTracker()
+	delete(sm.processInfos, 2)
+	newQuery(4, 250)
+	a.arbitrate(sm, globalTracker)
+	require.Equal(t, ServerMemoryLimitOpSpill, a.op)
+	require.Equal(t, uint64(4), a.targetInfo.ID)
+
+	// The spilling works.
+	sm.processInfos[4].StmtCtx.MemTracker.Consume(-250)
+	a.arbitrate(sm, globalTracker)
+	require.Nil(t, a.target)
+
+	// The queries using less memory than tidb_server_memory_limit_sess_min_size are not cancelled.
+	variable.ServerMemoryLimit.Store(50)
+	variable.ServerMemoryLimitSessMinSize.Store(1000)
+	a.arbitrate(sm, globalTracker)
+	require.Nil(t, a.target)
+
+	history := GetServerMemoryLimitOpsHistory()
+	require.Len(t, history, 3)
+	require.Equal(t, ServerMemoryLimitOpSpill, history[0].Op)
+	require.Equal(t, uint64(2), history[0].ProcessID)
+	require.Equal(t, int64(200), history[0].Mem)
+	require.Equal(t, uint64(300), history[0].MemoryLimit)
+	require.Equal(t, int64(310), history[0].MemoryCurrent)
+	require.Equal(t, ServerMemoryLimitOpKill, history[1].Op)
+	require.Equal(t, uint64(2), history[1].ProcessID)
+	require.Equal(t, ServerMemoryLimitOpSpill, history[2].Op)
+	require.Equal(t, uint64(4), history[2].ProcessID)
+}
@@ -141,4 +141,7 @@ var (
 const (
 	// PanicMemoryExceed represents the panic message when out of memory quota.
 	PanicMemoryExceed string = "Out Of Memory Quota!"
+	// PanicMemoryExceedForInstance represents the panic message when the query is cancelled because
+	// the tidb-server instance exceeds the server memory limit.
+	PanicMemoryExceedForInstance = PanicMemoryExceed + " The memory usage of the tidb-server instance exceeds tidb_server_memory_limit, and this query uses the most memory."
 )
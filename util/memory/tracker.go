@@ -51,6 +51,10 @@ import (
 // or the system variable `tidb_mem_query_quota`.
 // The actions that could be triggered are: SpillDiskAction, SortAndSpillDiskAction, rateLimitAction,
 // PanicOnExceed, globalPanicOnExceed, LogOnExceed.
+//
+// If the memory usage of the tidb-server instance exceeds `tidb_server_memory_limit`, the statement
+// using the most memory is marked by MarkNeedSpill and then MarkNeedKill. The actions are taken when
+// the statement consumes memory the next time.
 type Tracker struct {
 	mu struct {
 		sync.Mutex
@@ -71,8 +75,22 @@ type Tracker struct {
 	bytesSoftLimit int64
 	maxConsumed    int64 // max number of bytes consumed during execution.
 	isGlobal       bool  // isGlobal indicates whether this tracker is global tracker
+	// arbitration is the state of the server memory limit arbitration, it's only used by the
+	// trackers of the statements, which are attached to the global tracker.
+	arbitration int32
 }
 
+// The states of the server memory limit arbitration on a statement.
+const (
+	arbitrationNone int32 = iota
+	// arbitrationNeedSpill means the statement should spill as if its memory quota is exceeded.
+	arbitrationNeedSpill
+	// arbitrationSpilled means the spill actions of the statement have been triggered.
+	arbitrationSpilled
+	// arbitrationNeedKill means the statement should be cancelled.
+	arbitrationNeedKill
+)
+
 type actionMu struct {
 	sync.Mutex
 	actionOnExceed ActionOnExceed
@@ -96,6 +114,7 @@ func InitTracker(t *Tracker, label int, bytesLimit int64, action ActionOnExceed)
 	t.bytesSoftLimit = int64(float64(bytesLimit) * softScale)
 	t.maxConsumed = 0
 	t.isGlobal = false
+	t.arbitration = arbitrationNone
 }
 
 // NewTracker creates a memory tracker.
@@ -309,8 +328,12 @@ func (t *Tracker) Consume(bytes int64) {
 	if bytes == 0 {
 		return
 	}
-	var rootExceed, rootExceedForSoftLimit *Tracker
-	for tracker := t; tracker != nil; tracker = tracker.getParent() {
+	var rootExceed, rootExceedForSoftLimit, stmtRoot *Tracker
+	for tracker := t; tracker != nil; {
+		parent := tracker.getParent()
+		if parent != nil && parent.isGlobal {
+			stmtRoot = tracker
+		}
 		bytesConsumed := atomic.AddInt64(&tracker.bytesConsumed, bytes)
 		if bytesConsumed >= tracker.bytesHardLimit && tracker.bytesHardLimit > 0 {
 			rootExceed = tracker
@@ -327,6 +350,7 @@ func (t *Tracker) Consume(bytes int64) {
 			}
 			break
 		}
+		tracker = parent
 	}
 
 	tryAction := func(mu *actionMu, tracker *Tracker) {
@@ -343,6 +367,53 @@ func (t *Tracker) Consume(bytes int64) {
 	if bytes > 0 && rootExceed != nil {
 		tryAction(&rootExceed.actionMuForHardLimit, rootExceed)
 	}
+	if bytes > 0 && stmtRoot != nil {
+		switch atomic.LoadInt32(&stmtRoot.arbitration) {
+		case arbitrationNeedSpill:
+			// The tidb-server instance exceeds the server memory limit, ask the statement to spill.
+			// Only the spill actions are taken, the statement is cancelled later by MarkNeedKill if
+			// spilling doesn't release enough memory.
+			if atomic.CompareAndSwapInt32(&stmtRoot.arbitration, arbitrationNeedSpill, arbitrationSpilled) {
+				tryAction(&stmtRoot.actionMuForSoftLimit, stmtRoot)
+				trySpillActions(&stmtRoot.actionMuForHardLimit, stmtRoot)
+			}
+		case arbitrationNeedKill:
+			panic(PanicMemoryExceedForInstance)
+		}
+	}
+}
+
+// trySpillActions takes the spill actions in the action chain, without falling back to the
+// actions that cancel the query or log the memory usage.
+func trySpillActions(mu *actionMu, tracker *Tracker) {
+	mu.Lock()
+	defer mu.Unlock()
+	for action := mu.actionOnExceed; action != nil; {
+		fallback := action.GetFallback()
+		if action.GetPriority() == DefSpillPriority {
+			action.SetFallback(nil)
+			action.Action(tracker)
+			action.SetFallback(fallback)
+		}
+		action = fallback
+	}
+}
+
+// MarkNeedSpill asks the statement to spill its intermediate data the next time it consumes memory.
+// It's used when the tidb-server instance exceeds the server memory limit.
+func (t *Tracker) MarkNeedSpill() {
+	atomic.CompareAndSwapInt32(&t.arbitration, arbitrationNone, arbitrationNeedSpill)
+}
+
+// MarkNeedKill asks the statement to be cancelled the next time it consumes memory.
+// It's used when the tidb-server instance exceeds the server memory limit.
+func (t *Tracker) MarkNeedKill() {
+	atomic.StoreInt32(&t.arbitration, arbitrationNeedKill)
+}
+
+// NeedKill indicates whether the statement has been asked to be cancelled.
+func (t *Tracker) NeedKill() bool {
+	return atomic.LoadInt32(&t.arbitration) == arbitrationNeedKill
 }
 
 // BytesConsumed returns the consumed memory usage value in bytes.
@@ -558,4 +629,12 @@ const (
 	LabelForIndexJoinOuterWorker int = -21
 	// LabelForBindCache represents the label of the bind cache
 	LabelForBindCache int = -22
+	// LabelForHashAggPartialWorker represents the label of HashAgg PartialWorker
+	LabelForHashAggPartialWorker int = -23
+	// LabelForHashAggFinalWorker represents the label of HashAgg FinalWorker
+	LabelForHashAggFinalWorker int = -24
+	// LabelForCursorFetch represents the label of the rows of a server-side cursor
+	LabelForCursorFetch int = -25
+	// LabelForSession represents the label of the memory held by a session across statements
+	LabelForSession int = -26
 )
@@ -371,6 +371,51 @@ func parseByte(str string) (int64, error) {
 	return b, nil
 }
 
+func TestServerMemoryLimitArbitration(t *testing.T) {
+	globalTracker := NewGlobalTracker(LabelForGlobalMemory, -1)
+	stmtTracker := NewTracker(LabelForSQLText, -1)
+	stmtTracker.AttachToGlobalTracker(globalTracker)
+	child := NewTracker(1, -1)
+	child.AttachTo(stmtTracker)
+
+	softAction, hardAction := &mockAction{priority: DefSpillPriority}, &mockAction{priority: DefPanicPriority}
+	spillAction := &mockAction{priority: DefSpillPriority}
+	stmtTracker.FallbackOldAndSetNewActionForSoftLimit(softAction)
+	stmtTracker.SetActionOnExceed(hardAction)
+	stmtTracker.FallbackOldAndSetNewAction(spillAction)
+	child.Consume(100)
+	require.False(t, softAction.called)
+	require.False(t, spillAction.called)
+	require.False(t, hardAction.called)
+
+	// The spill actions are triggered only once even though the memory quota of the statement isn't exceeded,
+	// and the actions cancelling the query are never triggered.
+	stmtTracker.MarkNeedSpill()
+	child.Consume(100)
+	require.True(t, softAction.called)
+	require.True(t, spillAction.called)
+	require.False(t, hardAction.called)
+	require.Equal(t, hardAction, spillAction.GetFallback())
+	softAction.called, spillAction.called = false, false
+	child.Consume(100)
+	require.False(t, softAction.called)
+	require.False(t, spillAction.called)
+	require.False(t, hardAction.called)
+
+	stmtTracker.MarkNeedKill()
+	require.True(t, stmtTracker.NeedKill())
+	// Releasing memory doesn't panic.
+	child.Consume(-100)
+	require.PanicsWithValue(t, PanicMemoryExceedForInstance, func() {
+		child.Consume(100)
+	})
+	require.Equal(t, int64(300), globalTracker.BytesConsumed())
+
+	// The state is reset when the tracker is reused by the next statement.
+	InitTracker(stmtTracker, LabelForSQLText, -1, nil)
+	require.False(t, stmtTracker.NeedKill())
+}
+
 func TestFormatBytesWithPrune(t *testing.T) {
 	cases := []struct {
 		b string
@@ -127,6 +127,10 @@ const (
 	TypeCTE = "CTEFullScan"
 	// TypeCTEDefinition is the type of CTE definition
 	TypeCTEDefinition = "CTE"
+	// TypeJSONTable is the type of JSONTable.
+	TypeJSONTable = "JSONTable"
+	// TypeExpand is the type of Expand.
+	TypeExpand = "Expand"
 )
 
 // plan id.
@@ -184,6 +188,8 @@ const (
 	typeCTE                   int = 50
 	typeCTEDefinition         int = 51
 	typeCTETable              int = 52
+	typeJSONTable             int = 53
+	typeExpand                int = 54
 )
 
 // TypeStringToPhysicalID converts the plan type string to plan id.
@@ -293,6 +299,10 @@ func TypeStringToPhysicalID(tp string) int {
 		return typeCTEDefinition
 	case TypeCTETable:
 		return typeCTETable
+	case TypeJSONTable:
+		return typeJSONTable
+	case TypeExpand:
+		return typeExpand
 	}
 	// Should never reach here.
 	return 0
@@ -403,6 +413,10 @@ func PhysicalIDToTypeString(id int) string {
 		return TypeCTEDefinition
 	case typeCTETable:
 		return TypeCTETable
+	case typeJSONTable:
+		return TypeJSONTable
+	case typeExpand:
+		return TypeExpand
 	}
 
 	// Should never reach here.
@@ -78,6 +78,8 @@ func TestPlanIDChanged(t *testing.T) {
 		{typeCTE, 50},
 		{typeCTEDefinition, 51},
 		{typeCTETable, 52},
+		{typeJSONTable, 53},
+		{typeExpand, 54},
 	}
 
 	for _, testcase := range testCases {